@@ -0,0 +1,103 @@
+package beads
+
+import (
+	"fmt"
+	"time"
+)
+
+// AgingActor is the comment author for priority aging notes.
+const AgingActor = "gt-aging"
+
+// AgingPolicy says how long an open bead may sit untouched at each priority
+// before AgePriorities raises it one level. Priorities without an entry
+// never age, which also bounds how urgent aging can make a bead: with no
+// entry for P1, nothing is ever aged to P0.
+type AgingPolicy struct {
+	After  map[int]time.Duration
+	DryRun bool
+}
+
+// DefaultAgingPolicy ages backlog beads after 30 days and low-priority
+// beads after 14. Medium and above never age.
+func DefaultAgingPolicy() AgingPolicy {
+	return AgingPolicy{After: map[int]time.Duration{
+		PriorityBacklog: 30 * 24 * time.Hour,
+		PriorityLow:     14 * 24 * time.Hour,
+	}}
+}
+
+// AgingResult summarizes a priority aging pass.
+type AgingResult struct {
+	Aged   []PriorityChange `json:"aged"`
+	Failed []string         `json:"failed,omitempty"` // IDs whose update failed
+	DryRun bool             `json:"dry_run,omitempty"`
+}
+
+// AgePriorities raises stale open beads one priority level. See
+// AgePrioritiesWith.
+func (b *Beads) AgePriorities(policy AgingPolicy) (*AgingResult, error) {
+	return AgePrioritiesWith(b, policy, time.Now())
+}
+
+// AgePrioritiesWith raises by one level every open bead whose LastActivity
+// is older than policy.After for its priority, and notes why in a comment.
+// The update itself resets updated_at, so a bead climbs at most one level
+// per threshold. Only work is aged: messages, agents, roles, rigs, and
+// convoys keep their priority, as do beads labeled LabelPinnedPriority. With
+// policy.DryRun, the result lists what would change without updating.
+func AgePrioritiesWith(c Client, policy AgingPolicy, now time.Time) (*AgingResult, error) {
+	for p, d := range policy.After {
+		if p <= PriorityCritical || p > PriorityBacklog || d <= 0 {
+			return nil, fmt.Errorf("invalid aging rule P%d after %s: want P1-P4 and a positive duration", p, d)
+		}
+	}
+
+	issues, err := c.List(ListOptions{Status: "open", Priority: PriorityUnset, Limit: NoLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AgingResult{DryRun: policy.DryRun}
+	for _, issue := range issues {
+		after, ok := policy.After[issue.Priority]
+		if !ok || !ageable(issue) || hasLabel(issue, LabelPinnedPriority) {
+			continue
+		}
+		last := issue.UpdatedAt
+		if last.IsZero() || now.Sub(last) < after {
+			continue
+		}
+
+		change := PriorityChange{ID: issue.ID, Title: issue.Title, From: issue.Priority, To: issue.Priority - 1}
+		if policy.DryRun {
+			result.Aged = append(result.Aged, change)
+			continue
+		}
+		if err := c.Update(issue.ID, UpdateOptions{Priority: &change.To}); err != nil {
+			result.Failed = append(result.Failed, issue.ID)
+			continue
+		}
+		note := fmt.Sprintf("Priority aged P%d → P%d: untouched for %s.", change.From, change.To, formatAge(now.Sub(last)))
+		_, _ = c.AddComment(issue.ID, AgingActor, note) // Best effort; the bump already happened
+		result.Aged = append(result.Aged, change)
+	}
+	return result, nil
+}
+
+// ageable reports whether issue is work whose priority can go stale, rather
+// than mail or a bookkeeping bead that merely sits open.
+func ageable(issue *Issue) bool {
+	switch issue.Type {
+	case TypeMessage, "agent", "role", "rig", "convoy":
+		return false
+	}
+	return true
+}
+
+// formatAge renders a duration in whole days, or hours under a day.
+func formatAge(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return fmt.Sprintf("%dh", int(d/time.Hour))
+}
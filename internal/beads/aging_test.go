@@ -0,0 +1,60 @@
+package beads_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+)
+
+func TestAgePriorities(t *testing.T) {
+	f := beadstest.NewFakeClient()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	f.Add(&beads.Issue{ID: "gt-rotting", Priority: 4, UpdatedAt: now.Add(-40 * day)})
+	f.Add(&beads.Issue{ID: "gt-recent", Priority: 4, UpdatedAt: now.Add(-5 * day)})
+	f.Add(&beads.Issue{ID: "gt-low", Priority: 3, UpdatedAt: now.Add(-20 * day)})
+	f.Add(&beads.Issue{ID: "gt-medium", Priority: 2, UpdatedAt: now.Add(-90 * day)})
+	f.Add(&beads.Issue{ID: "gt-pinned", Priority: 4, UpdatedAt: now.Add(-90 * day), Labels: []string{beads.LabelPinnedPriority}})
+	f.Add(&beads.Issue{ID: "gt-working", Status: "in_progress", Priority: 4, UpdatedAt: now.Add(-90 * day)})
+	for _, typ := range []string{beads.TypeMessage, "agent", "role", "convoy"} {
+		f.Add(&beads.Issue{ID: "gt-" + typ, Type: typ, Priority: 4, UpdatedAt: now.Add(-90 * day)})
+	}
+
+	policy := beads.DefaultAgingPolicy()
+	policy.DryRun = true
+	dry, err := beads.AgePrioritiesWith(f, policy, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dry.Aged) != 2 {
+		t.Fatalf("dry run aged %+v", dry.Aged)
+	}
+	if issue, _ := f.Show("gt-rotting"); issue.Priority != 4 {
+		t.Error("dry run changed priority")
+	}
+
+	policy.DryRun = false
+	result, err := beads.AgePrioritiesWith(f, policy, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"gt-rotting": 3, "gt-recent": 4, "gt-low": 2, "gt-medium": 2, "gt-pinned": 4, "gt-working": 4,
+		"gt-message": 4, "gt-agent": 4, "gt-role": 4, "gt-convoy": 4}
+	for id, p := range want {
+		if issue, _ := f.Show(id); issue.Priority != p {
+			t.Errorf("%s priority = %d, want %d", id, issue.Priority, p)
+		}
+	}
+	if len(result.Aged) != 2 || result.Aged[0].From != 4 || result.Aged[0].To != 3 {
+		t.Errorf("aged = %+v", result.Aged)
+	}
+	if comments, _ := f.Comments("gt-rotting"); len(comments) != 1 || comments[0].Author != beads.AgingActor {
+		t.Errorf("aging note = %+v", comments)
+	}
+
+	if _, err := beads.AgePrioritiesWith(f, beads.AgingPolicy{After: map[int]time.Duration{0: day}}, now); err == nil {
+		t.Error("aging P0 should be rejected")
+	}
+}
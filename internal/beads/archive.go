@@ -0,0 +1,117 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ArchiveDirName is the directory under .beads where Archive writes its
+// JSONL exports.
+const ArchiveDirName = "archive"
+
+// deleteChunk bounds how many IDs one bd delete call receives.
+const deleteChunk = 100
+
+// ArchiveResult reports what Archive did.
+type ArchiveResult struct {
+	Path     string   `json:"path,omitempty"` // JSONL export of the archived issues
+	Archived []string `json:"archived"`
+}
+
+// Delete removes issues with bd delete. bd keeps tombstones so the
+// deletion syncs to other clones; dependents of a deleted issue lose the
+// dependency. Use it for spam and test beads, not finished work: closed
+// issues belong in Archive.
+func (b *Beads) Delete(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := checkIDs(ids...); err != nil {
+		return err
+	}
+	for start := 0; start < len(ids); start += deleteChunk {
+		end := min(start+deleteChunk, len(ids))
+		args := append([]string{"delete"}, ids[start:end]...)
+		if _, err := b.run(append(args, "--force")...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Archive exports issues closed more than olderThan ago to a timestamped
+// JSONL file under .beads/archive, then deletes them, keeping the working
+// set small. The file is written in Export's format, so Import restores it.
+// Issues whose close time is unknown are kept. Nothing is deleted unless the
+// export was written in full.
+func (b *Beads) Archive(olderThan time.Duration) (*ArchiveResult, error) {
+	return b.archive(olderThan, time.Now())
+}
+
+func (b *Beads) archive(olderThan time.Duration, now time.Time) (*ArchiveResult, error) {
+	if olderThan <= 0 {
+		return nil, fmt.Errorf("archive age must be positive, got %s", olderThan)
+	}
+
+	all, err := b.exportAll()
+	if err != nil {
+		return nil, err
+	}
+	cutoff := now.Add(-olderThan)
+	var old []*Issue
+	for _, issue := range all {
+		if issue.Status == "closed" && !issue.ClosedAt.IsZero() && issue.ClosedAt.Before(cutoff) {
+			old = append(old, issue)
+		}
+	}
+	result := &ArchiveResult{Archived: []string{}}
+	if len(old) == 0 {
+		return result, nil
+	}
+
+	beadsDir := b.beadsDir
+	if beadsDir == "" {
+		beadsDir = ResolveBeadsDir(b.workDir)
+	}
+	dir := filepath.Join(beadsDir, ArchiveDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating archive directory: %w", err)
+	}
+	path := filepath.Join(dir, "closed-"+now.UTC().Format("20060102-150405")+".jsonl")
+	if err := writeArchive(path, old); err != nil {
+		return nil, err
+	}
+	result.Path = path
+
+	ids := make([]string, len(old))
+	for i, issue := range old {
+		ids[i] = issue.ID
+	}
+	if err := b.Delete(ids...); err != nil {
+		return result, fmt.Errorf("deleting archived issues (export kept at %s): %w", path, err)
+	}
+	result.Archived = ids
+	return result, nil
+}
+
+// writeArchive writes issues to a new file at path as JSONL.
+func writeArchive(path string, issues []*Issue) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // G302: archive is non-sensitive
+	if err != nil {
+		return fmt.Errorf("creating archive: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	for _, issue := range issues {
+		if err := enc.Encode(issue); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("writing archive: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	return nil
+}
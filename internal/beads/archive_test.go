@@ -0,0 +1,62 @@
+package beads
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveExportsThenDeletes(t *testing.T) {
+	installFakeBd(t, `
+echo "$@" >> "$(dirname "$0")/log"
+case "$2" in
+export)
+  echo '{"id":"gt-old","status":"closed","closed_at":"2026-01-01T00:00:00Z"}'
+  echo '{"id":"gt-new","status":"closed","closed_at":"2026-02-27T00:00:00Z"}'
+  echo '{"id":"gt-undated","status":"closed"}'
+  echo '{"id":"gt-open","status":"open","updated_at":"2025-01-01T00:00:00Z"}'
+  ;;
+esac
+`)
+	b := New(t.TempDir())
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := b.archive(30*24*time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Archived) != 1 || result.Archived[0] != "gt-old" {
+		t.Errorf("archived %v, want [gt-old]", result.Archived)
+	}
+
+	f, err := os.Open(result.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	exported, err := ParseExport(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(exported); len(got) != 1 || got[0] != "gt-old" {
+		t.Errorf("archive file holds %v", got)
+	}
+	if log := bdLog(t); !strings.Contains(log, "delete gt-old --force") || strings.Contains(log, "delete gt-new") {
+		t.Errorf("unexpected deletes:\n%s", log)
+	}
+}
+
+func TestDeleteChunks(t *testing.T) {
+	installFakeBd(t, `echo "$@" >> "$(dirname "$0")/log"`)
+	many := make([]string, deleteChunk+1)
+	for i := range many {
+		many[i] = "gt-" + strings.Repeat("a", i%5+1)
+	}
+	if err := New(t.TempDir()).Delete(many...); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(bdLog(t), "delete "); n != 2 {
+		t.Errorf("bd delete ran %d times, want 2", n)
+	}
+}
@@ -0,0 +1,61 @@
+// Package beads provides argument-safety helpers for bd invocation.
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsafeArg is returned when a value would be misparsed by bd (e.g., an ID
+// that looks like a flag, or a value containing a NUL byte).
+var ErrUnsafeArg = errors.New("unsafe bd argument")
+
+// Argument-safety rules for bd invocation:
+//
+//   - Free-text values (titles, descriptions, reasons) are always passed in
+//     "--flag=value" form, never as a separate argv entry, so a value with a
+//     leading dash can't be read as a flag.
+//   - Positional arguments (issue IDs, slot values, agent states) are checked
+//     with checkPositional: they must be non-empty, must not start with "-",
+//     and must not contain whitespace or control characters.
+//   - NUL bytes are rejected everywhere (exec can't pass them, and a truncated
+//     argument is worse than an error).
+//   - Single-line values (titles) have newlines collapsed to spaces.
+
+// checkPositional validates values that bd will parse as positional arguments.
+func checkPositional(values ...string) error {
+	for _, v := range values {
+		if v == "" {
+			return fmt.Errorf("%w: empty positional argument", ErrUnsafeArg)
+		}
+		if strings.HasPrefix(v, "-") {
+			return fmt.Errorf("%w: %q looks like a flag", ErrUnsafeArg, v)
+		}
+		for _, r := range v {
+			if r < 0x20 || r == 0x7f || r == ' ' {
+				return fmt.Errorf("%w: %q contains whitespace or control characters", ErrUnsafeArg, v)
+			}
+		}
+	}
+	return nil
+}
+
+// checkArgs rejects argv entries that can't be passed to bd intact.
+func checkArgs(args []string) error {
+	for _, a := range args {
+		if strings.ContainsRune(a, 0) {
+			return fmt.Errorf("%w: argument contains NUL byte", ErrUnsafeArg)
+		}
+	}
+	return nil
+}
+
+// singleLine collapses CR/LF runs into single spaces and trims the result.
+// Used for values bd stores as one line (titles, reasons).
+func singleLine(s string) string {
+	if !strings.ContainsAny(s, "\r\n") {
+		return s
+	}
+	return strings.Join(strings.Fields(strings.NewReplacer("\r", " ", "\n", " ").Replace(s)), " ")
+}
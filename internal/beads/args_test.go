@@ -0,0 +1,93 @@
+package beads
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckPositional(t *testing.T) {
+	tests := []struct {
+		value string
+		ok    bool
+	}{
+		{"gt-abc", true},
+		{"hq-mayor-role", true},
+		{"", false},
+		{"-rf", false},
+		{"--help", false},
+		{"--status=closed", false},
+		{"gt-abc --force", false},
+		{"gt-abc\n--force", false},
+		{"gt-\x00abc", false},
+		{"gt-\tabc", false},
+	}
+
+	for _, tt := range tests {
+		err := checkPositional(tt.value)
+		if tt.ok && err != nil {
+			t.Errorf("checkPositional(%q) = %v, want nil", tt.value, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrUnsafeArg) {
+			t.Errorf("checkPositional(%q) = %v, want ErrUnsafeArg", tt.value, err)
+		}
+	}
+}
+
+func TestCheckArgsRejectsNUL(t *testing.T) {
+	if err := checkArgs([]string{"create", "--title=ok"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := checkArgs([]string{"create", "--title=a\x00b"}); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("expected ErrUnsafeArg, got %v", err)
+	}
+}
+
+func TestSingleLine(t *testing.T) {
+	tests := map[string]string{
+		"plain title":             "plain title",
+		"--leading dash":          "--leading dash",
+		"line one\nline two":      "line one line two",
+		"crlf\r\n--status=closed": "crlf --status=closed",
+		"trailing newline\n":      "trailing newline",
+	}
+	for in, want := range tests {
+		if got := singleLine(in); got != want {
+			t.Errorf("singleLine(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAdversarialIDsNeverReachBd(t *testing.T) {
+	// These must fail validation before exec, so they're safe to run
+	// without bd installed.
+	b := New(t.TempDir())
+
+	if _, err := b.Show("--all"); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("Show: expected ErrUnsafeArg, got %v", err)
+	}
+	if err := b.Close("gt-ok", "-f"); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("Close: expected ErrUnsafeArg, got %v", err)
+	}
+	if err := b.Update("--status=closed", UpdateOptions{}); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("Update: expected ErrUnsafeArg, got %v", err)
+	}
+	if err := b.AddDependency("gt-a", "-b"); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("AddDependency: expected ErrUnsafeArg, got %v", err)
+	}
+	if err := b.SetHookBead("gt-agent", "--force"); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("SetHookBead: expected ErrUnsafeArg, got %v", err)
+	}
+	d := &Delegation{Parent: "gt-a", Child: "--force", DelegatedBy: "mayor", DelegatedTo: "gastown/crew/joe"}
+	if err := b.AddDelegation(d); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("AddDelegation: expected ErrUnsafeArg, got %v", err)
+	}
+	if err := b.RemoveDelegation("-a", "gt-b"); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("RemoveDelegation: expected ErrUnsafeArg, got %v", err)
+	}
+	if _, err := b.GetDelegation("--json"); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("GetDelegation: expected ErrUnsafeArg, got %v", err)
+	}
+	if _, err := b.CreateAgentBead("gt-agent", "Agent", &AgentFields{HookBead: "--force"}); !errors.Is(err, ErrUnsafeArg) {
+		t.Errorf("CreateAgentBead: expected ErrUnsafeArg, got %v", err)
+	}
+}
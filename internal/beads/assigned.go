@@ -0,0 +1,63 @@
+package beads
+
+import "sort"
+
+// assignedStatuses are the statuses that count as an agent's current work.
+var assignedStatuses = []string{"in_progress", "open"}
+
+// SelectionPolicy orders the issues assigned to one agent: Less reports
+// whether a is the better pick. SelectAssigned returns the first issue in
+// this order.
+type SelectionPolicy func(a, b *Issue) bool
+
+// InProgressFirst prefers work already under way, then the most urgent
+// priority, then the oldest issue, then the lowest ID. The ID tie-break
+// keeps the choice stable when bd returns issues in a different order.
+func InProgressFirst(a, b *Issue) bool {
+	if ap, bp := a.Status == "in_progress", b.Status == "in_progress"; ap != bp {
+		return ap
+	}
+	return priorityAgeLess(a, b)
+}
+
+// DefaultSelection is the policy GetAssignedIssues orders by.
+var DefaultSelection SelectionPolicy = InProgressFirst
+
+// GetAssignedIssues returns every open or in_progress issue assigned to
+// assignee, ordered by DefaultSelection.
+func (b *Beads) GetAssignedIssues(assignee string) ([]*Issue, error) {
+	return GetAssignedIssuesWith(b, assignee, DefaultSelection)
+}
+
+// GetAssignedIssuesWith returns c's open and in_progress issues assigned
+// to assignee, ordered by policy (DefaultSelection if nil).
+func GetAssignedIssuesWith(c Client, assignee string, policy SelectionPolicy) ([]*Issue, error) {
+	if policy == nil {
+		policy = DefaultSelection
+	}
+	var issues []*Issue
+	for _, status := range assignedStatuses {
+		found, err := c.List(ListOptions{Status: status, Assignee: assignee, Priority: PriorityUnset, Limit: NoLimit})
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return policy(issues[i], issues[j]) })
+	return issues, nil
+}
+
+// SelectAssigned returns the issue assignee should be working on under
+// policy, or nil if nothing is assigned.
+func (b *Beads) SelectAssigned(assignee string, policy SelectionPolicy) (*Issue, error) {
+	return SelectAssignedWith(b, assignee, policy)
+}
+
+// SelectAssignedWith is SelectAssigned against any Client.
+func SelectAssignedWith(c Client, assignee string, policy SelectionPolicy) (*Issue, error) {
+	issues, err := GetAssignedIssuesWith(c, assignee, policy)
+	if err != nil || len(issues) == 0 {
+		return nil, err
+	}
+	return issues[0], nil
+}
@@ -0,0 +1,40 @@
+package beads_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+)
+
+func TestSelectAssigned(t *testing.T) {
+	f := beadstest.NewFakeClient()
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	me := "gastown/polecats/Toast"
+	f.Add(&beads.Issue{ID: "gt-urgent", Assignee: me, Priority: 0, CreatedAt: base})
+	f.Add(&beads.Issue{ID: "gt-newer", Assignee: me, Status: "in_progress", Priority: 2, CreatedAt: base})
+	f.Add(&beads.Issue{ID: "gt-older", Assignee: me, Status: "in_progress", Priority: 2, CreatedAt: base.Add(-time.Hour)})
+	f.Add(&beads.Issue{ID: "gt-done", Assignee: me, Status: "closed"})
+	f.Add(&beads.Issue{ID: "gt-theirs", Assignee: "gastown/polecats/Nux", Status: "in_progress"})
+
+	all, err := beads.GetAssignedIssuesWith(f, me, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, issue := range all {
+		ids = append(ids, issue.ID)
+	}
+	if len(ids) != 3 || ids[0] != "gt-older" || ids[1] != "gt-newer" || ids[2] != "gt-urgent" {
+		t.Errorf("assigned = %v, want [gt-older gt-newer gt-urgent]", ids)
+	}
+
+	byPriority := func(a, b *beads.Issue) bool { return a.Priority < b.Priority }
+	if got, err := beads.SelectAssignedWith(f, me, byPriority); err != nil || got.ID != "gt-urgent" {
+		t.Errorf("SelectAssigned(byPriority) = %v, %v; want gt-urgent", got, err)
+	}
+	if got, err := beads.SelectAssignedWith(f, "nobody", nil); err != nil || got != nil {
+		t.Errorf("SelectAssigned(nobody) = %v, %v; want nil", got, err)
+	}
+}
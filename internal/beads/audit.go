@@ -2,10 +2,17 @@
 package beads
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/flock"
 )
 
 // DetachAuditEntry represents an audit log entry for a detach operation.
@@ -19,6 +26,37 @@ type DetachAuditEntry struct {
 	PreviousState    string `json:"previous_state,omitempty"`
 }
 
+// AttachAuditEntry represents an audit log entry for an attach operation.
+type AttachAuditEntry struct {
+	Timestamp        string `json:"timestamp"`
+	Operation        string `json:"operation"` // Always "attach"
+	PinnedBeadID     string `json:"pinned_bead_id"`
+	AttachedMolecule string `json:"attached_molecule"`
+	AttachedBy       string `json:"attached_by,omitempty"`       // Agent that triggered attach
+	ReplacedMolecule string `json:"replaced_molecule,omitempty"` // Molecule attached before, if any
+	PreviousState    string `json:"previous_state,omitempty"`
+}
+
+// AttachOptions specifies optional context for an attach operation.
+type AttachOptions struct {
+	Agent string // Who is performing the attach
+}
+
+// AttachmentRecord is one attach or detach in a pinned bead's history.
+type AttachmentRecord struct {
+	Timestamp     string `json:"timestamp"`
+	Operation     string `json:"operation"` // "attach", "detach", "burn", "squash"
+	PinnedBeadID  string `json:"pinned_bead_id"`
+	MoleculeID    string `json:"molecule_id"`        // Molecule attached or detached
+	Agent         string `json:"agent,omitempty"`    // Who performed the operation
+	Reason        string `json:"reason,omitempty"`   // Detach reason, if given
+	Replaced      string `json:"replaced,omitempty"` // Molecule an attach replaced
+	PreviousState string `json:"previous_state,omitempty"`
+}
+
+// attachmentOps are the audit operations that make up attachment history.
+var attachmentOps = map[string]bool{"attach": true, "detach": true, "burn": true, "squash": true}
+
 // DetachOptions specifies optional context for a detach operation.
 type DetachOptions struct {
 	Operation string // "detach", "burn", "squash" - defaults to "detach"
@@ -29,6 +67,10 @@ type DetachOptions struct {
 // DetachMoleculeWithAudit removes molecule attachment from a pinned bead and logs the operation.
 // Returns the updated issue.
 func (b *Beads) DetachMoleculeWithAudit(pinnedBeadID string, opts DetachOptions) (*Issue, error) {
+	if err := checkPermission(config.OpDetachMolecule, pinnedBeadID); err != nil {
+		return nil, err
+	}
+
 	// Fetch the pinned bead first to get previous state
 	issue, err := b.Show(pinnedBeadID)
 	if err != nil {
@@ -61,10 +103,7 @@ func (b *Beads) DetachMoleculeWithAudit(pinnedBeadID string, opts DetachOptions)
 	}
 
 	// Clear attachment fields by passing nil
-	newDesc := SetAttachmentFields(issue, nil)
-
-	// Update the issue
-	if err := b.Update(pinnedBeadID, UpdateOptions{Description: &newDesc}); err != nil {
+	if err := b.StoreAttachmentFields(issue, nil); err != nil {
 		return nil, fmt.Errorf("updating pinned bead: %w", err)
 	}
 
@@ -72,25 +111,220 @@ func (b *Beads) DetachMoleculeWithAudit(pinnedBeadID string, opts DetachOptions)
 	return b.Show(pinnedBeadID)
 }
 
-// LogDetachAudit appends an audit entry to the audit log file.
-// The audit log is stored in .beads/audit.log as JSONL format.
-func (b *Beads) LogDetachAudit(entry DetachAuditEntry) error {
-	auditPath := filepath.Join(b.workDir, ".beads", "audit.log")
+// AttachMoleculeWithAudit attaches a molecule to a pinned bead and logs the
+// operation, so attachment history shows both halves of each lineage.
+// Returns the updated issue.
+func (b *Beads) AttachMoleculeWithAudit(pinnedBeadID, moleculeID string, opts AttachOptions) (*Issue, error) {
+	// Fetch the pinned bead first to capture what is being replaced
+	prior, err := b.Show(pinnedBeadID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching pinned bead: %w", err)
+	}
 
-	// Marshal entry to JSON
-	data, err := json.Marshal(entry)
+	issue, err := b.AttachMolecule(pinnedBeadID, moleculeID)
 	if err != nil {
-		return fmt.Errorf("marshaling audit entry: %w", err)
+		return nil, err
+	}
+
+	entry := AttachAuditEntry{
+		Timestamp:        currentTimestamp(),
+		Operation:        "attach",
+		PinnedBeadID:     pinnedBeadID,
+		AttachedMolecule: moleculeID,
+		AttachedBy:       opts.Agent,
+		PreviousState:    prior.Status,
+	}
+	if attachment := ParseAttachmentFields(prior); attachment != nil {
+		entry.ReplacedMolecule = attachment.AttachedMolecule
+	}
+	if err := b.LogAttachAudit(entry); err != nil {
+		// Log error but don't fail the attach operation
+		fmt.Fprintf(os.Stderr, "Warning: failed to write audit log: %v\n", err)
 	}
 
-	// Append to audit log file
-	f, err := os.OpenFile(auditPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600) //nolint:gosec // G304: path is constructed internally
+	return issue, nil
+}
+
+// AttachmentHistory returns the attach, detach, burn and squash records for
+// a pinned bead from the audit log, oldest first. A missing log is an empty
+// history.
+func (b *Beads) AttachmentHistory(pinnedBeadID string) ([]AttachmentRecord, error) {
+	f, err := os.Open(b.AuditLogPath())
 	if err != nil {
-		return fmt.Errorf("opening audit log: %w", err)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
 	defer f.Close()
 
-	if _, err := f.Write(append(data, '\n')); err != nil {
+	var out []AttachmentRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Attach and detach entries share timestamp, operation and bead ID;
+		// decode into the union of their fields.
+		var line struct {
+			AttachAuditEntry
+			DetachedMolecule string `json:"detached_molecule"`
+			DetachedBy       string `json:"detached_by"`
+			Reason           string `json:"reason"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue // Skip malformed lines
+		}
+		if !attachmentOps[line.Operation] || line.PinnedBeadID != pinnedBeadID {
+			continue
+		}
+
+		rec := AttachmentRecord{
+			Timestamp:     line.Timestamp,
+			Operation:     line.Operation,
+			PinnedBeadID:  line.PinnedBeadID,
+			PreviousState: line.PreviousState,
+		}
+		if line.Operation == "attach" {
+			rec.MoleculeID = line.AttachedMolecule
+			rec.Agent = line.AttachedBy
+			rec.Replaced = line.ReplacedMolecule
+		} else {
+			rec.MoleculeID = line.DetachedMolecule
+			rec.Agent = line.DetachedBy
+			rec.Reason = line.Reason
+		}
+		out = append(out, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Timestamp < out[j].Timestamp })
+	return out, nil
+}
+
+// AuditFilter selects detach audit entries for ReadAudit. Zero fields
+// match everything.
+type AuditFilter struct {
+	Since      time.Time // Entries at or after this time
+	Until      time.Time // Entries before this time
+	Operations []string  // "detach", "burn", "squash"; empty for all
+	Agent      string    // Exact DetachedBy match
+	BeadID     string    // Pinned bead or detached molecule ID
+}
+
+// matches reports whether e passes f. ts is e's parsed timestamp.
+func (f AuditFilter) matches(e DetachAuditEntry, ts time.Time) bool {
+	if !f.Since.IsZero() && ts.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !ts.Before(f.Until) {
+		return false
+	}
+	if len(f.Operations) > 0 && !slices.Contains(f.Operations, e.Operation) {
+		return false
+	}
+	if f.Agent != "" && e.DetachedBy != f.Agent {
+		return false
+	}
+	if f.BeadID != "" && e.PinnedBeadID != f.BeadID && e.DetachedMolecule != f.BeadID {
+		return false
+	}
+	return true
+}
+
+// ReadAudit returns the detach, burn and squash entries in the audit log
+// that match filter, oldest first. Other record types sharing the log are
+// skipped, as are entries with unparseable timestamps when a time range is
+// set. A missing log yields no entries.
+func (b *Beads) ReadAudit(filter AuditFilter) ([]DetachAuditEntry, error) {
+	f, err := os.Open(b.AuditLogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	timed := !filter.Since.IsZero() || !filter.Until.IsZero()
+	var out []DetachAuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e DetachAuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // Skip malformed lines
+		}
+		if e.Operation == "attach" || !attachmentOps[e.Operation] {
+			continue
+		}
+		ts, err := ParseTimestamp(e.Timestamp)
+		if err != nil && timed {
+			continue
+		}
+		if filter.matches(e, ts) {
+			out = append(out, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Timestamp < out[j].Timestamp })
+	return out, nil
+}
+
+// SetAuditLogPath overrides where this Beads writes attach/detach audit entries.
+func (b *Beads) SetAuditLogPath(path string) {
+	b.auditLog = path
+}
+
+// defaultAuditLogPath maps a beads directory to its audit log for every
+// Beads without its own override. See SetDefaultAuditLogPath.
+var defaultAuditLogPath func(beadsDir string) string
+
+// SetDefaultAuditLogPath sets how every Beads locates its audit log, so all
+// handles in the process write to the same file. Callers resolve the path
+// from config.Paths; beads itself does not read town settings.
+func SetDefaultAuditLogPath(fn func(beadsDir string) string) {
+	defaultAuditLogPath = fn
+}
+
+// AuditLogPath returns the audit log location: the SetAuditLogPath override,
+// else the process default, else audit.log in the beads directory
+// (following a .beads/redirect).
+func (b *Beads) AuditLogPath() string {
+	if b.auditLog != "" {
+		return b.auditLog
+	}
+	beadsDir := b.beadsDir
+	if beadsDir == "" {
+		beadsDir = ResolveBeadsDir(b.workDir)
+	}
+	if defaultAuditLogPath != nil {
+		return defaultAuditLogPath(beadsDir)
+	}
+	return filepath.Join(beadsDir, "audit.log")
+}
+
+// LogDetachAudit appends an audit entry to the audit log file as JSONL.
+func (b *Beads) LogDetachAudit(entry DetachAuditEntry) error {
+	return b.appendAudit(entry)
+}
+
+// LogAttachAudit appends an attach entry to the audit log file as JSONL.
+func (b *Beads) LogAttachAudit(entry AttachAuditEntry) error {
+	return b.appendAudit(entry)
+}
+
+// appendAudit appends one JSON record to the audit log.
+func (b *Beads) appendAudit(entry interface{}) error {
+	auditPath := b.AuditLogPath()
+
+	// Marshal entry to JSON
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling audit entry: %w", err)
+	}
+
+	// Append to audit log file; several gt processes may write at once
+	if err := flock.AppendFile(auditPath, append(data, '\n'), 0600); err != nil {
 		return fmt.Errorf("writing audit entry: %w", err)
 	}
 
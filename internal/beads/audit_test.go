@@ -0,0 +1,145 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAttachmentHistory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	b := New(dir)
+
+	if hist, err := b.AttachmentHistory("gt-pin"); err != nil || hist != nil {
+		t.Fatalf("empty log: %v, %v", hist, err)
+	}
+
+	mustLog := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustLog(b.LogDetachAudit(DetachAuditEntry{Timestamp: "2026-01-01T12:00:00Z", Operation: "burn",
+		PinnedBeadID: "gt-pin", DetachedMolecule: "gt-mol1", DetachedBy: "gastown/Nux", Reason: "abandoned"}))
+	mustLog(b.LogAttachAudit(AttachAuditEntry{Timestamp: "2026-01-01T10:00:00Z", Operation: "attach",
+		PinnedBeadID: "gt-pin", AttachedMolecule: "gt-mol1", AttachedBy: "gastown/Toast"}))
+	mustLog(b.LogAttachAudit(AttachAuditEntry{Timestamp: "2026-01-01T11:00:00Z", Operation: "attach",
+		PinnedBeadID: "gt-other", AttachedMolecule: "gt-mol9"}))
+	mustLog(b.logRelease(ReleaseRecord{Timestamp: "2026-01-01T11:30:00Z", Operation: AuditOpRelease, IssueID: "gt-pin"}))
+
+	hist, err := b.AttachmentHistory("gt-pin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hist) != 2 {
+		t.Fatalf("history = %+v", hist)
+	}
+	if h := hist[0]; h.Operation != "attach" || h.MoleculeID != "gt-mol1" || h.Agent != "gastown/Toast" {
+		t.Errorf("first record = %+v", h)
+	}
+	if h := hist[1]; h.Operation != "burn" || h.MoleculeID != "gt-mol1" || h.Agent != "gastown/Nux" || h.Reason != "abandoned" {
+		t.Errorf("second record = %+v", h)
+	}
+}
+
+func TestAuditLogFollowsRedirect(t *testing.T) {
+	// crew/max/.beads/redirect -> ../../mayor/rig/.beads
+	town := t.TempDir()
+	crewDir := filepath.Join(town, "crew", "max")
+	rigBeads := filepath.Join(town, "mayor", "rig", ".beads")
+	for _, dir := range []string{filepath.Join(crewDir, ".beads"), rigBeads} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(crewDir, ".beads", "redirect"), []byte("../../mayor/rig/.beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := New(crewDir)
+	if got, want := b.AuditLogPath(), filepath.Join(rigBeads, "audit.log"); got != want {
+		t.Errorf("AuditLogPath = %q, want %q", got, want)
+	}
+	if err := b.logRelease(ReleaseRecord{Timestamp: "2026-01-01T11:30:00Z", Operation: AuditOpRelease, IssueID: "gt-pin"}); err != nil {
+		t.Fatal(err)
+	}
+	// Any worktree sharing the rig's beads reads the same records
+	records, err := New(filepath.Join(town, "mayor", "rig")).releaseRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].IssueID != "gt-pin" {
+		t.Errorf("release records = %+v", records)
+	}
+}
+
+func TestReadAuditFilters(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	b := New(dir)
+
+	for _, e := range []DetachAuditEntry{
+		{Timestamp: "2026-01-06T09:00:00Z", Operation: "detach", PinnedBeadID: "gt-pin", DetachedMolecule: "gt-xyz", DetachedBy: "gastown/Nux"},
+		{Timestamp: "2026-01-05T09:00:00Z", Operation: "burn", PinnedBeadID: "gt-pin", DetachedMolecule: "gt-abc", DetachedBy: "gastown/Toast"},
+		{Timestamp: "2026-01-07T09:00:00Z", Operation: "squash", PinnedBeadID: "gt-other", DetachedMolecule: "gt-xyz", DetachedBy: "gastown/Nux"},
+	} {
+		if err := b.LogDetachAudit(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.LogAttachAudit(AttachAuditEntry{Timestamp: "2026-01-06T08:00:00Z", Operation: "attach", PinnedBeadID: "gt-pin", AttachedMolecule: "gt-xyz"}); err != nil {
+		t.Fatal(err)
+	}
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name   string
+		filter AuditFilter
+		want   []string // Operations, in order
+	}{
+		{"all", AuditFilter{}, []string{"burn", "detach", "squash"}},
+		{"time range", AuditFilter{Since: day(6), Until: day(7)}, []string{"detach"}},
+		{"operation", AuditFilter{Operations: []string{"burn", "squash"}}, []string{"burn", "squash"}},
+		{"agent", AuditFilter{Agent: "gastown/Nux"}, []string{"detach", "squash"}},
+		{"bead by molecule", AuditFilter{BeadID: "gt-xyz", Agent: "gastown/Nux", Since: day(6), Until: day(7)}, []string{"detach"}},
+		{"bead by pin", AuditFilter{BeadID: "gt-pin"}, []string{"burn", "detach"}},
+	}
+	for _, tt := range tests {
+		got, err := b.ReadAudit(tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ops []string
+		for _, e := range got {
+			ops = append(ops, e.Operation)
+		}
+		if !slices.Equal(ops, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, ops, tt.want)
+		}
+	}
+}
+
+func TestDefaultAuditLogPath(t *testing.T) {
+	shared := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	SetDefaultAuditLogPath(func(string) string { return shared })
+	t.Cleanup(func() { SetDefaultAuditLogPath(nil) })
+
+	dir := t.TempDir()
+	if got := New(dir).AuditLogPath(); got != shared {
+		t.Errorf("AuditLogPath = %q, want %q", got, shared)
+	}
+	// A per-handle override still wins
+	b := New(dir)
+	b.SetAuditLogPath(filepath.Join(dir, "own.log"))
+	if got, want := b.AuditLogPath(), filepath.Join(dir, "own.log"); got != want {
+		t.Errorf("AuditLogPath = %q, want %q", got, want)
+	}
+}
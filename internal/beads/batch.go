@@ -0,0 +1,98 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CreateBatch creates several issues, returned in the same order as opts.
+// Every option is validated before anything is written, and the batch is
+// all-or-nothing: if one create fails, the issues already created are
+// closed. bd has no batch create (its create --file reads a markdown
+// plan, not options), so each issue is one bd create.
+func (b *Beads) CreateBatch(opts []CreateOptions) ([]*Issue, error) {
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	for i, o := range opts {
+		if err := o.Validate(); err != nil {
+			return nil, fmt.Errorf("issue %d: %w", i, err)
+		}
+	}
+
+	issues := make([]*Issue, 0, len(opts))
+	for i, o := range opts {
+		issue, err := b.Create(o)
+		if err != nil {
+			for _, created := range issues {
+				_ = b.Close(created.ID) // Best-effort cleanup
+			}
+			return nil, fmt.Errorf("creating issue %d of %d (%q): %w", i+1, len(opts), o.Title, err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// isUnknownFlag reports whether bd rejected a flag it doesn't know.
+func isUnknownFlag(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "unknown flag") || strings.Contains(msg, "unknown shorthand flag")
+}
+
+// UpdateBatch applies many updates with as few bd invocations as possible.
+// Issues receiving identical options share one "bd update id1 id2 ..." call,
+// so the common case (same status or assignee change across many issues)
+// costs a single subprocess. Groups run in order of their smallest ID; the
+// first failure stops the batch and is returned.
+func (b *Beads) UpdateBatch(updates map[string]UpdateOptions) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	if err := checkIDs(ids...); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := updates[id].Validate(); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+	}
+
+	// Group IDs by their flag set, preserving first-seen order.
+	type group struct {
+		flags []string
+		ids   []string
+	}
+	var groups []*group
+	byKey := make(map[string]*group)
+	for _, id := range ids {
+		flags := updateFlags(updates[id])
+		if len(flags) == 0 {
+			continue // Nothing to change
+		}
+		key := strings.Join(flags, "\x00")
+		g, ok := byKey[key]
+		if !ok {
+			g = &group{flags: flags}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.ids = append(g.ids, id)
+	}
+
+	for _, g := range groups {
+		args := append([]string{"update"}, g.ids...)
+		args = append(args, g.flags...)
+		if _, err := b.run(args...); err != nil {
+			return fmt.Errorf("updating %s: %w", strings.Join(g.ids, ", "), err)
+		}
+	}
+	return nil
+}
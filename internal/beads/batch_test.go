@@ -0,0 +1,122 @@
+package beads
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// installFakeBd puts a bd shell script first on PATH for the test.
+func installFakeBd(t *testing.T, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script fake bd not supported on windows")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bd"), []byte("#!/bin/sh\n"+script), 0755); err != nil { //nolint:gosec // test script must be executable
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestCreateBatch(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+echo "$*" >> "$dir/log"
+echo x >> "$dir/n"
+echo "{\"id\":\"gt-s$(wc -l < "$dir/n" | tr -d ' ')\"}"
+`)
+	b := New(t.TempDir())
+	issues, err := b.CreateBatch([]CreateOptions{{Title: "one", Priority: -1}, {Title: "two", Priority: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].ID != "gt-s1" || issues[1].ID != "gt-s2" {
+		t.Errorf("issues = %+v", issues)
+	}
+
+	bdPath, _ := exec.LookPath("bd")
+	data, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "log"))
+	if strings.Contains(string(data), "--file") {
+		t.Errorf("bd create --file reads markdown, not options; bd calls:\n%s", data)
+	}
+}
+
+func TestCreateBatchClosesCreatedOnFailure(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+echo "$*" >> "$dir/log"
+case "$2" in close) exit 0;; esac
+case "$*" in *--title=two*) echo "Error: database locked" >&2; exit 1;; esac
+echo x >> "$dir/n"
+echo "{\"id\":\"gt-s$(wc -l < "$dir/n" | tr -d ' ')\"}"
+`)
+	b := New(t.TempDir())
+	if _, err := b.CreateBatch([]CreateOptions{{Title: "one"}, {Title: "two"}, {Title: "three"}}); err == nil {
+		t.Fatal("expected error when a create fails")
+	}
+
+	bdPath, _ := exec.LookPath("bd")
+	data, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "log"))
+	if !strings.Contains(string(data), "close gt-s1") {
+		t.Errorf("gt-s1 not closed after the batch failed; bd calls:\n%s", data)
+	}
+	if strings.Contains(string(data), "--title=three") {
+		t.Errorf("batch kept creating after a failure; bd calls:\n%s", data)
+	}
+}
+
+func TestUpdateBatchGroupsIdenticalOptions(t *testing.T) {
+	installFakeBd(t, `echo "$*" >> "$(dirname "$0")/log"`)
+	b := New(t.TempDir())
+
+	open, closed := "open", "closed"
+	err := b.UpdateBatch(map[string]UpdateOptions{
+		"gt-3": {Status: &open},
+		"gt-1": {Status: &open},
+		"gt-2": {Status: &closed},
+		"gt-4": {}, // no-op
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bdPath, _ := exec.LookPath("bd")
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "--no-daemon update gt-1 gt-3 --status=open\n--no-daemon update gt-2 --status=closed\n"
+	if string(data) != want {
+		t.Errorf("bd calls:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestInstantiateMoleculeCreatesSteps(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+case "$2" in
+  list) echo '[]'; exit 0;;
+  dep) echo "$*" >> "$dir/deps"; exit 0;;
+esac
+echo x >> "$dir/n"
+echo "{\"id\":\"gt-s$(wc -l < "$dir/n" | tr -d ' ')\"}"
+`)
+	b := New(t.TempDir())
+	mol := &Issue{ID: "mol-1", Description: "## Step: design\nThink.\n\n## Step: implement\nBuild it.\nNeeds: design"}
+	steps, err := b.InstantiateMolecule(mol, &Issue{ID: "gt-parent", Priority: 2}, InstantiateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 2 || steps[0].ID != "gt-s1" || steps[1].ID != "gt-s2" {
+		t.Errorf("steps = %+v", steps)
+	}
+	bdPath, _ := exec.LookPath("bd")
+	deps, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "deps"))
+	if !strings.Contains(string(deps), "gt-s2 gt-s1") {
+		t.Errorf("step dependency not wired; bd dep calls:\n%s", deps)
+	}
+}
@@ -6,10 +6,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Common errors
@@ -231,23 +236,26 @@ func SetupRedirect(townRoot, worktreePath string) error {
 
 // Issue represents a beads issue.
 type Issue struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Status      string   `json:"status"`
-	Priority    int      `json:"priority"`
-	Type        string   `json:"issue_type"`
-	CreatedAt   string   `json:"created_at"`
-	CreatedBy   string   `json:"created_by,omitempty"`
-	UpdatedAt   string   `json:"updated_at"`
-	ClosedAt    string   `json:"closed_at,omitempty"`
-	Parent      string   `json:"parent,omitempty"`
-	Assignee    string   `json:"assignee,omitempty"`
-	Children    []string `json:"children,omitempty"`
-	DependsOn   []string `json:"depends_on,omitempty"`
-	Blocks      []string `json:"blocks,omitempty"`
-	BlockedBy   []string `json:"blocked_by,omitempty"`
-	Labels      []string `json:"labels,omitempty"`
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	Priority    int       `json:"priority"`
+	Type        string    `json:"issue_type"`
+	CreatedAt   time.Time `json:"created_at,omitzero"` // Parsed tolerantly; see ParseTimestamp
+	CreatedBy   string    `json:"created_by,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitzero"`
+	ClosedAt    time.Time `json:"closed_at,omitzero"`
+	Parent      string    `json:"parent,omitempty"`
+	Assignee    string    `json:"assignee,omitempty"`
+	Children    []string  `json:"children,omitempty"`
+	DependsOn   []string  `json:"depends_on,omitempty"`
+	Blocks      []string  `json:"blocks,omitempty"`
+	BlockedBy   []string  `json:"blocked_by,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+	Metadata    Metadata  `json:"metadata,omitempty"` // Custom fields; see FieldStore
+
+	EstimatedMinutes int `json:"estimated_minutes,omitempty"` // Effort estimate, if set
 
 	// Agent bead slots (type=agent only)
 	HookBead   string `json:"hook_bead,omitempty"`   // Current work attached to agent's hook
@@ -274,6 +282,21 @@ type IssueDep struct {
 	DependencyType string `json:"dependency_type,omitempty"`
 }
 
+// Dependency types understood by bd. Only blocks (the default) holds up
+// work; the rest are links for navigation and provenance.
+const (
+	DepBlocks         = "blocks"
+	DepRelated        = "related"
+	DepParentChild    = "parent-child"
+	DepDiscoveredFrom = "discovered-from"
+)
+
+// Blocking reports whether d is a hard blocker rather than a soft link.
+// An untyped dependency is a blocker, as it is in bd.
+func (d IssueDep) Blocking() bool {
+	return d.DependencyType == "" || d.DependencyType == DepBlocks
+}
+
 // Delegation represents a work delegation relationship between work units.
 // Delegation links a parent work unit to a child work unit, tracking who
 // delegated the work and to whom, along with any terms of the delegation.
@@ -316,12 +339,17 @@ type DelegationTerms struct {
 
 // ListOptions specifies filters for listing issues.
 type ListOptions struct {
-	Status     string // "open", "closed", "all"
-	Type       string // "task", "bug", "feature", "epic"
-	Priority   int    // 0-4, -1 for no filter
-	Parent     string // filter by parent ID
-	Assignee   string // filter by assignee (e.g., "gastown/Toast")
-	NoAssignee bool   // filter for issues with no assignee
+	Status     string   // "open", "closed", "all"
+	Type       string   // "task", "bug", "feature", "epic"
+	Priority   int      // 0-4, -1 for no filter
+	Parent     string   // filter by parent ID
+	Assignee   string   // filter by assignee (e.g., "gastown/Toast")
+	NoAssignee bool     // filter for issues with no assignee
+	Label      string   // filter by a single label
+	Labels     []string // filter by labels (issue must have all)
+	Limit      int      // max results; 0 or NoLimit returns everything
+	Offset     int      // results to skip before Limit applies
+	SortBy     string   // bd sort field (e.g. "priority", "created", "updated")
 }
 
 // CreateOptions specifies options for creating an issue.
@@ -331,6 +359,9 @@ type CreateOptions struct {
 	Priority    int    // 0-4
 	Description string
 	Parent      string
+	Assignee    string
+	Labels      []string
+	Visibility  string // Optional visibility scope (see VisibilityPublic)
 	Actor       string // Who is creating this issue (populates created_by)
 }
 
@@ -344,6 +375,9 @@ type UpdateOptions struct {
 	AddLabels    []string // Labels to add
 	RemoveLabels []string // Labels to remove
 	SetLabels    []string // Labels to set (replaces all existing)
+
+	SetMetadata   map[string]string // Custom fields to set (bd with metadata support)
+	UnsetMetadata []string          // Custom fields to remove
 }
 
 // SyncStatus represents the sync status of the beads repository.
@@ -357,25 +391,53 @@ type SyncStatus struct {
 // Beads wraps bd CLI operations for a working directory.
 type Beads struct {
 	workDir  string
-	beadsDir string // Optional BEADS_DIR override for cross-database access
+	beadsDir string       // Optional BEADS_DIR override for cross-database access
+	auditLog string       // Optional audit log override (see SetAuditLogPath)
+	progress ProgressFunc // Optional stderr line stream (see SetProgress)
+	scope    string       // Optional visibility restriction (see SetVisibilityScope)
+	cache    *runCache    // Optional read-through cache (see WithCache)
+	fields   *fieldState  // Where structured fields are written (see WithFieldStore)
+
+	middleware []Middleware // Wraps the core executor (see WithMiddleware)
 }
 
 // New creates a new Beads wrapper for the given directory.
-func New(workDir string) *Beads {
-	return &Beads{workDir: workDir}
+func New(workDir string, opts ...Option) *Beads {
+	b := &Beads{workDir: workDir, fields: &fieldState{}, middleware: slices.Clone(defaultMiddleware)}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
 // NewWithBeadsDir creates a Beads wrapper with an explicit BEADS_DIR.
 // This is needed when running from a polecat worktree but accessing town-level beads.
-func NewWithBeadsDir(workDir, beadsDir string) *Beads {
-	return &Beads{workDir: workDir, beadsDir: beadsDir}
+func NewWithBeadsDir(workDir, beadsDir string, opts ...Option) *Beads {
+	b := New(workDir, opts...)
+	b.beadsDir = beadsDir
+	return b
 }
 
-// run executes a bd command and returns stdout.
+// run executes a bd command through b's middleware and returns stdout.
 func (b *Beads) run(args ...string) ([]byte, error) {
+	if err := checkArgs(args); err != nil {
+		return nil, err
+	}
+	return b.executor()(args)
+}
+
+// execBd is the core executor: it forks bd and returns stdout.
+func (b *Beads) execBd(args []string) ([]byte, error) {
+	// Offload oversized descriptions to temp files (bd chokes on huge argv entries)
+	execArgs, cleanup, err := offloadLongDescriptions(args)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
 	// Use --no-daemon for faster read operations (avoids daemon IPC overhead)
 	// The daemon is primarily useful for write coalescing, not reads
-	fullArgs := append([]string{"--no-daemon"}, args...)
+	fullArgs := append([]string{"--no-daemon"}, execArgs...)
 	cmd := exec.Command("bd", fullArgs...) //nolint:gosec // G204: bd is a trusted internal tool
 	cmd.Dir = b.workDir
 
@@ -387,10 +449,14 @@ func (b *Beads) run(args ...string) ([]byte, error) {
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
+	if b.progress != nil {
+		lw := &lineWriter{fn: b.progress}
+		defer lw.Flush()
+		cmd.Stderr = io.MultiWriter(&stderr, lw)
+	}
 
-	err := cmd.Run()
-	if err != nil {
-		return nil, b.wrapError(err, stderr.String(), args)
+	if err := cmd.Run(); err != nil {
+		return nil, b.wrapError(err, stderr.String(), execArgs)
 	}
 
 	return stdout.Bytes(), nil
@@ -432,39 +498,13 @@ func (b *Beads) wrapError(err error, stderr string, args []string) error {
 }
 
 // List returns issues matching the given options.
+// Use ListPage to learn whether Limit cut the results short.
 func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
-	args := []string{"list", "--json"}
-
-	if opts.Status != "" {
-		args = append(args, "--status="+opts.Status)
-	}
-	if opts.Type != "" {
-		args = append(args, "--type="+opts.Type)
-	}
-	if opts.Priority >= 0 {
-		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
-	}
-	if opts.Parent != "" {
-		args = append(args, "--parent="+opts.Parent)
-	}
-	if opts.Assignee != "" {
-		args = append(args, "--assignee="+opts.Assignee)
-	}
-	if opts.NoAssignee {
-		args = append(args, "--no-assignee")
-	}
-
-	out, err := b.run(args...)
+	page, err := b.ListPage(opts)
 	if err != nil {
 		return nil, err
 	}
-
-	var issues []*Issue
-	if err := json.Unmarshal(out, &issues); err != nil {
-		return nil, fmt.Errorf("parsing bd list output: %w", err)
-	}
-
-	return issues, nil
+	return page.Issues, nil
 }
 
 // ListByAssignee returns all issues assigned to a specific assignee.
@@ -477,35 +517,13 @@ func (b *Beads) ListByAssignee(assignee string) ([]*Issue, error) {
 	})
 }
 
-// GetAssignedIssue returns the first open issue assigned to the given assignee.
-// Returns nil if no open issue is assigned.
-func (b *Beads) GetAssignedIssue(assignee string) (*Issue, error) {
-	issues, err := b.List(ListOptions{
-		Status:   "open",
-		Assignee: assignee,
+// ListByLabel returns all issues, open or closed, carrying label.
+func (b *Beads) ListByLabel(label string) ([]*Issue, error) {
+	return b.List(ListOptions{
+		Status:   "all",
+		Label:    label,
 		Priority: -1,
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	// Also check in_progress status explicitly
-	if len(issues) == 0 {
-		issues, err = b.List(ListOptions{
-			Status:   "in_progress",
-			Assignee: assignee,
-			Priority: -1,
-		})
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if len(issues) == 0 {
-		return nil, nil
-	}
-
-	return issues[0], nil
 }
 
 // Ready returns issues that are ready to work (not blocked).
@@ -520,27 +538,34 @@ func (b *Beads) Ready() ([]*Issue, error) {
 		return nil, fmt.Errorf("parsing bd ready output: %w", err)
 	}
 
-	return issues, nil
+	return FilterVisible(issues, b.scope), nil
 }
 
-// ReadyWithType returns ready issues filtered by type.
-// Uses bd ready --type flag for server-side filtering.
+// ReadyWithType returns all ready issues filtered by type.
+// Uses bd ready --type flag for server-side filtering, widening the
+// limit until bd returns fewer results than asked for.
 func (b *Beads) ReadyWithType(issueType string) ([]*Issue, error) {
-	out, err := b.run("ready", "--json", "--type", issueType, "-n", "100")
-	if err != nil {
-		return nil, err
-	}
+	for limit := readyPageSize; ; limit *= 2 {
+		out, err := b.run("ready", "--json", "--type", issueType, "-n", strconv.Itoa(limit))
+		if err != nil {
+			return nil, err
+		}
 
-	var issues []*Issue
-	if err := json.Unmarshal(out, &issues); err != nil {
-		return nil, fmt.Errorf("parsing bd ready output: %w", err)
+		var issues []*Issue
+		if err := json.Unmarshal(out, &issues); err != nil {
+			return nil, fmt.Errorf("parsing bd ready output: %w", err)
+		}
+		if len(issues) < limit {
+			return FilterVisible(issues, b.scope), nil
+		}
 	}
-
-	return issues, nil
 }
 
 // Show returns detailed information about an issue.
 func (b *Beads) Show(id string) (*Issue, error) {
+	if err := checkIDs(id); err != nil {
+		return nil, err
+	}
 	out, err := b.run("show", id, "--json")
 	if err != nil {
 		return nil, err
@@ -552,7 +577,7 @@ func (b *Beads) Show(id string) (*Issue, error) {
 		return nil, fmt.Errorf("parsing bd show output: %w", err)
 	}
 
-	if len(issues) == 0 {
+	if len(issues) == 0 || !VisibleTo(issues[0], b.scope) {
 		return nil, ErrNotFound
 	}
 
@@ -566,6 +591,10 @@ func (b *Beads) ShowMultiple(ids []string) (map[string]*Issue, error) {
 		return make(map[string]*Issue), nil
 	}
 
+	if err := checkIDs(ids...); err != nil {
+		return nil, err
+	}
+
 	// bd show supports multiple IDs
 	args := append([]string{"show", "--json"}, ids...)
 	out, err := b.run(args...)
@@ -580,7 +609,7 @@ func (b *Beads) ShowMultiple(ids []string) (map[string]*Issue, error) {
 	}
 
 	result := make(map[string]*Issue, len(issues))
-	for _, issue := range issues {
+	for _, issue := range FilterVisible(issues, b.scope) {
 		result[issue.ID] = issue
 	}
 
@@ -601,7 +630,7 @@ func (b *Beads) ListAgentBeads() (map[string]*Issue, error) {
 	}
 
 	result := make(map[string]*Issue, len(issues))
-	for _, issue := range issues {
+	for _, issue := range FilterVisible(issues, b.scope) {
 		result[issue.ID] = issue
 	}
 
@@ -620,17 +649,20 @@ func (b *Beads) Blocked() ([]*Issue, error) {
 		return nil, fmt.Errorf("parsing bd blocked output: %w", err)
 	}
 
-	return issues, nil
+	return FilterVisible(issues, b.scope), nil
 }
 
 // Create creates a new issue and returns it.
 // If opts.Actor is empty, it defaults to the BD_ACTOR environment variable.
 // This ensures created_by is populated for issue provenance tracking.
 func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 	args := []string{"create", "--json"}
 
 	if opts.Title != "" {
-		args = append(args, "--title="+opts.Title)
+		args = append(args, "--title="+singleLine(opts.Title))
 	}
 	if opts.Type != "" {
 		args = append(args, "--type="+opts.Type)
@@ -644,6 +676,12 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 	if opts.Parent != "" {
 		args = append(args, "--parent="+opts.Parent)
 	}
+	if opts.Assignee != "" {
+		args = append(args, "--assignee="+opts.Assignee)
+	}
+	if labels := opts.labels(); len(labels) > 0 {
+		args = append(args, "--labels="+strings.Join(labels, ","))
+	}
 	// Default Actor from BD_ACTOR env var if not specified
 	actor := opts.Actor
 	if actor == "" {
@@ -670,10 +708,16 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 // This is useful for agent beads, role beads, and other beads that need
 // deterministic IDs rather than auto-generated ones.
 func (b *Beads) CreateWithID(id string, opts CreateOptions) (*Issue, error) {
+	if err := checkIDs(id); err != nil {
+		return nil, err
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 	args := []string{"create", "--json", "--id=" + id}
 
 	if opts.Title != "" {
-		args = append(args, "--title="+opts.Title)
+		args = append(args, "--title="+singleLine(opts.Title))
 	}
 	if opts.Type != "" {
 		args = append(args, "--type="+opts.Type)
@@ -711,10 +755,23 @@ func (b *Beads) CreateWithID(id string, opts CreateOptions) (*Issue, error) {
 
 // Update updates an existing issue.
 func (b *Beads) Update(id string, opts UpdateOptions) error {
-	args := []string{"update", id}
+	if err := checkIDs(id); err != nil {
+		return err
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	args := append([]string{"update", id}, updateFlags(opts)...)
+	_, err := b.run(args...)
+	return err
+}
+
+// updateFlags converts UpdateOptions to bd update flags.
+func updateFlags(opts UpdateOptions) []string {
+	var args []string
 
 	if opts.Title != nil {
-		args = append(args, "--title="+*opts.Title)
+		args = append(args, "--title="+singleLine(*opts.Title))
 	}
 	if opts.Status != nil {
 		args = append(args, "--status="+*opts.Status)
@@ -741,9 +798,14 @@ func (b *Beads) Update(id string, opts UpdateOptions) error {
 			args = append(args, "--remove-label="+label)
 		}
 	}
+	for _, key := range slices.Sorted(maps.Keys(opts.SetMetadata)) {
+		args = append(args, "--set-metadata="+key+"="+opts.SetMetadata[key])
+	}
+	for _, key := range opts.UnsetMetadata {
+		args = append(args, "--unset-metadata="+key)
+	}
 
-	_, err := b.run(args...)
-	return err
+	return args
 }
 
 // Close closes one or more issues.
@@ -754,6 +816,10 @@ func (b *Beads) Close(ids ...string) error {
 		return nil
 	}
 
+	if err := checkIDs(ids...); err != nil {
+		return err
+	}
+
 	args := append([]string{"close"}, ids...)
 
 	// Pass session ID for work attribution if available
@@ -773,6 +839,10 @@ func (b *Beads) CloseWithReason(reason string, ids ...string) error {
 		return nil
 	}
 
+	if err := checkIDs(ids...); err != nil {
+		return err
+	}
+
 	args := append([]string{"close"}, ids...)
 	args = append(args, "--reason="+reason)
 
@@ -793,8 +863,19 @@ func (b *Beads) Release(id string) error {
 }
 
 // ReleaseWithReason moves an in_progress issue back to open status with a reason.
-// The reason is added as a note to the issue for tracking purposes.
+// The reason is added as a note to the issue, and a ReleaseRecord is appended
+// to the audit log so repeated bouncing can be queried with ReleaseHistory.
 func (b *Beads) ReleaseWithReason(id, reason string) error {
+	if err := checkIDs(id); err != nil {
+		return err
+	}
+
+	// Capture prior state for the release record (best effort)
+	var prior *Issue
+	if issue, err := b.Show(id); err == nil {
+		prior = issue
+	}
+
 	args := []string{"update", id, "--status=open", "--assignee="}
 
 	// Add reason as a note if provided
@@ -802,18 +883,44 @@ func (b *Beads) ReleaseWithReason(id, reason string) error {
 		args = append(args, "--notes=Released: "+reason)
 	}
 
-	_, err := b.run(args...)
-	return err
+	if _, err := b.run(args...); err != nil {
+		return err
+	}
+
+	if err := b.logRelease(newReleaseRecord(id, reason, prior)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write release record: %v\n", err)
+	}
+	return nil
 }
 
 // AddDependency adds a dependency: issue depends on dependsOn.
 func (b *Beads) AddDependency(issue, dependsOn string) error {
-	_, err := b.run("dep", "add", issue, dependsOn)
+	return b.AddDependencyTyped(issue, dependsOn, "")
+}
+
+// AddDependencyTyped adds a dependency of the given type (DepBlocks,
+// DepRelated, DepParentChild, or DepDiscoveredFrom). An empty type leaves
+// the choice to bd, which defaults to blocks.
+func (b *Beads) AddDependencyTyped(issue, dependsOn, depType string) error {
+	if err := checkIDs(issue, dependsOn); err != nil {
+		return err
+	}
+	if err := validateDepType(depType); err != nil {
+		return err
+	}
+	args := []string{"dep", "add", issue, dependsOn}
+	if depType != "" {
+		args = append(args, "--type="+depType)
+	}
+	_, err := b.run(args...)
 	return err
 }
 
 // RemoveDependency removes a dependency.
 func (b *Beads) RemoveDependency(issue, dependsOn string) error {
+	if err := checkIDs(issue, dependsOn); err != nil {
+		return err
+	}
 	_, err := b.run("dep", "remove", issue, dependsOn)
 	return err
 }
@@ -832,6 +939,9 @@ func (b *Beads) AddDelegation(d *Delegation) error {
 	if d.DelegatedBy == "" || d.DelegatedTo == "" {
 		return fmt.Errorf("delegation requires both delegated_by and delegated_to entities")
 	}
+	if err := checkIDs(d.Parent, d.Child); err != nil {
+		return err
+	}
 
 	// Store delegation as JSON in the child issue's delegated_from slot
 	delegationJSON, err := json.Marshal(d)
@@ -856,6 +966,10 @@ func (b *Beads) AddDelegation(d *Delegation) error {
 
 // RemoveDelegation removes a delegation relationship.
 func (b *Beads) RemoveDelegation(parent, child string) error {
+	if err := checkIDs(parent, child); err != nil {
+		return err
+	}
+
 	// Clear the delegated_from slot on the child
 	_, err := b.run("slot", "clear", child, "delegated_from")
 	if err != nil {
@@ -874,6 +988,10 @@ func (b *Beads) RemoveDelegation(parent, child string) error {
 // GetDelegation retrieves the delegation information for a child work unit.
 // Returns nil if the issue has no delegation.
 func (b *Beads) GetDelegation(child string) (*Delegation, error) {
+	if err := checkIDs(child); err != nil {
+		return nil, err
+	}
+
 	// Get the issue to read its slot
 	issue, err := b.Show(child)
 	if err != nil {
@@ -961,7 +1079,8 @@ func (b *Beads) SyncStatus() (*SyncStatus, error) {
 	return &status, nil
 }
 
-// Stats returns repository statistics.
+// Stats returns repository statistics as bd's human-readable text.
+// Use StatsJSON for structured counts.
 func (b *Beads) Stats() (string, error) {
 	out, err := b.run("stats")
 	if err != nil {
@@ -1097,6 +1216,21 @@ func ParseAgentFields(description string) *AgentFields {
 // Use AgentBeadID() helper to generate correct IDs.
 // The created_by field is populated from BD_ACTOR env var for provenance tracking.
 func (b *Beads) CreateAgentBead(id, title string, fields *AgentFields) (*Issue, error) {
+	// The ID and slot values are passed positionally to bd slot set below
+	if err := checkPositional(id); err != nil {
+		return nil, err
+	}
+	if fields != nil {
+		for _, slot := range []string{fields.RoleBead, fields.HookBead} {
+			if slot == "" {
+				continue
+			}
+			if err := checkPositional(slot); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	description := FormatAgentDescription(title, fields)
 
 	args := []string{"create", "--json",
@@ -1153,6 +1287,15 @@ func (b *Beads) CreateAgentBead(id, title string, fields *AgentFields) (*Issue,
 // Previously, this function embedded these fields in the description text,
 // which caused inconsistencies with bd slot commands (see GH #gt-9v52).
 func (b *Beads) UpdateAgentState(id string, state string, hookBead *string) error {
+	if err := checkPositional(id, state); err != nil {
+		return err
+	}
+	if hookBead != nil && *hookBead != "" {
+		if err := checkPositional(*hookBead); err != nil {
+			return err
+		}
+	}
+
 	// Update agent state using bd agent state command
 	// This updates the agent_state column directly in SQLite
 	_, err := b.run("agent", "state", id, state)
@@ -1195,6 +1338,10 @@ func (b *Beads) UpdateAgentState(id string, state string, hookBead *string) erro
 // Per gt-zecmc: agent_state ("running", "dead", "idle") is observable from tmux
 // and should not be recorded in beads ("discover, don't track" principle).
 func (b *Beads) SetHookBead(agentBeadID, hookBeadID string) error {
+	if err := checkPositional(agentBeadID, hookBeadID); err != nil {
+		return err
+	}
+
 	// Set the hook using bd slot set
 	// This updates the hook_bead column directly in SQLite
 	_, err := b.run("slot", "set", agentBeadID, "hook", hookBeadID)
@@ -1306,6 +1453,9 @@ func (b *Beads) GetAgentNotificationLevel(id string) (string, error) {
 // DeleteAgentBead permanently deletes an agent bead.
 // Uses --hard --force for immediate permanent deletion (no tombstone).
 func (b *Beads) DeleteAgentBead(id string) error {
+	if err := checkPositional(id); err != nil {
+		return err
+	}
 	_, err := b.run("delete", id, "--hard", "--force")
 	return err
 }
@@ -1436,10 +1586,10 @@ func (b *Beads) CreateDogAgentBead(name, location string) (*Issue, error) {
 // Searches for agent beads with role_type:dog and matching title.
 // Returns nil if not found.
 func (b *Beads) FindDogAgentBead(name string) (*Issue, error) {
-	// List all agent beads and filter by role_type:dog label
 	issues, err := b.List(ListOptions{
 		Type:     "agent",
 		Status:   "all",
+		Label:    "role_type:dog",
 		Priority: -1, // No priority filter
 	})
 	if err != nil {
@@ -1448,13 +1598,8 @@ func (b *Beads) FindDogAgentBead(name string) (*Issue, error) {
 
 	expectedTitle := fmt.Sprintf("Dog: %s", name)
 	for _, issue := range issues {
-		// Check title match and role_type:dog label
 		if issue.Title == expectedTitle {
-			for _, label := range issue.Labels {
-				if label == "role_type:dog" {
-					return issue, nil
-				}
-			}
+			return issue, nil
 		}
 	}
 
@@ -1655,35 +1800,14 @@ func (b *Beads) GetRoleConfig(roleBeadID string) (*RoleConfig, error) {
 	return ParseRoleConfig(issue.Description), nil
 }
 
-// FindMRForBranch searches for an existing merge-request bead for the given branch.
-// Returns the MR bead if found, nil if not found.
-// This enables idempotent `gt done` - if an MR already exists, we skip creation.
-func (b *Beads) FindMRForBranch(branch string) (*Issue, error) {
-	// List all merge-request beads (open status only - closed MRs are already processed)
-	issues, err := b.List(ListOptions{
-		Status: "open",
-		Type:   "merge-request",
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	// Search for one matching this branch
-	// MR description format: "branch: <branch>\ntarget: ..."
-	branchPrefix := "branch: " + branch + "\n"
-	for _, issue := range issues {
-		if strings.HasPrefix(issue.Description, branchPrefix) {
-			return issue, nil
-		}
-	}
-
-	return nil, nil
-}
-
 // AddGateWaiter registers an agent as a waiter on a gate bead.
 // When the gate closes, the waiter will receive a wake notification via gt gate wake.
 // The waiter is typically the polecat's address (e.g., "gastown/polecats/Toast").
 func (b *Beads) AddGateWaiter(gateID, waiter string) error {
+	if err := checkPositional(gateID, waiter); err != nil {
+		return err
+	}
+
 	// Use bd gate add-waiter to register the waiter on the gate
 	// This adds the waiter to the gate's native waiters field
 	_, err := b.run("gate", "add-waiter", gateID, waiter)
@@ -0,0 +1,415 @@
+// Package beadstest provides an in-memory beads.Client for unit tests.
+package beadstest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// FakeClient is a deterministic in-memory beads.Client.
+// It models statuses, assignees, labels, parents, and dependencies closely
+// enough for daemon and command logic; it does not emulate bd's formatting,
+// sync, or routing behavior.
+type FakeClient struct {
+	// Prefix is the ID prefix for created issues. Default: "gt".
+	Prefix string
+
+	// Now supplies timestamps. Default: a fixed clock advancing one second
+	// per call, so tests are reproducible.
+	Now func() time.Time
+
+	// Scope restricts List and Show to issues visible at a visibility
+	// scope, as (*beads.Beads).SetVisibilityScope does. Empty shows all.
+	Scope string
+
+	mu           sync.Mutex
+	issues       map[string]*beads.Issue
+	order        []string
+	next         int
+	clock        time.Time
+	comments     map[string][]beads.Comment
+	nextComment  int
+	CloseReasons map[string]string // id → reason passed to CloseWithReason
+}
+
+var _ beads.Client = (*FakeClient)(nil)
+
+// NewFakeClient returns an empty fake store.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		Prefix:       "gt",
+		issues:       make(map[string]*beads.Issue),
+		comments:     make(map[string][]beads.Comment),
+		clock:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		CloseReasons: make(map[string]string),
+	}
+}
+
+// Add seeds the store with an existing issue. Missing status defaults to open.
+func (f *FakeClient) Add(issue *beads.Issue) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := clone(issue)
+	if c.Status == "" {
+		c.Status = "open"
+	}
+	if _, exists := f.issues[c.ID]; !exists {
+		f.order = append(f.order, c.ID)
+	}
+	f.issues[c.ID] = c
+}
+
+// List returns issues matching opts in insertion order. As with bd, an
+// empty Status lists non-closed issues and "all" lists everything.
+// Offset and Limit apply; SortBy is ignored.
+func (f *FakeClient) List(opts beads.ListOptions) ([]*beads.Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []*beads.Issue
+	for _, id := range f.order {
+		issue := f.issues[id]
+		switch opts.Status {
+		case "all":
+		case "":
+			if issue.Status == "closed" {
+				continue
+			}
+		default:
+			if issue.Status != opts.Status {
+				continue
+			}
+		}
+		if opts.Type != "" && issue.Type != opts.Type {
+			continue
+		}
+		if opts.Priority >= 0 && issue.Priority != opts.Priority {
+			continue
+		}
+		if opts.Parent != "" && issue.Parent != opts.Parent {
+			continue
+		}
+		if opts.Assignee != "" && issue.Assignee != opts.Assignee {
+			continue
+		}
+		if opts.NoAssignee && issue.Assignee != "" {
+			continue
+		}
+		if opts.Label != "" && !beads.HasAllLabels(issue, opts.Label) {
+			continue
+		}
+		if !beads.HasAllLabels(issue, opts.Labels...) {
+			continue
+		}
+		if !beads.VisibleTo(issue, f.Scope) {
+			continue
+		}
+		out = append(out, clone(issue))
+	}
+	out, _ = beads.Paginate(out, opts.Offset, opts.Limit)
+	return out, nil
+}
+
+// Ready returns open issues with no open blockers, most urgent first.
+func (f *FakeClient) Ready() ([]*beads.Issue, error) {
+	return f.filterOpen(func(issue *beads.Issue) bool { return !f.blocked(issue) })
+}
+
+// Blocked returns open issues with at least one open blocker.
+func (f *FakeClient) Blocked() ([]*beads.Issue, error) {
+	return f.filterOpen(f.blocked)
+}
+
+// Show returns a copy of an issue or beads.ErrNotFound.
+func (f *FakeClient) Show(id string) (*beads.Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	issue, ok := f.issues[id]
+	if !ok || !beads.VisibleTo(issue, f.Scope) {
+		return nil, beads.ErrNotFound
+	}
+	return clone(issue), nil
+}
+
+// Create adds a new open issue with a sequential ID.
+func (f *FakeClient) Create(opts beads.CreateOptions) (*beads.Issue, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	f.next++
+	id := fmt.Sprintf("%s-%d", f.Prefix, f.next)
+	now := f.now()
+	f.mu.Unlock()
+
+	priority := opts.Priority
+	if priority < 0 {
+		priority = beads.PriorityMedium
+	}
+	issue := &beads.Issue{
+		ID:          id,
+		Title:       opts.Title,
+		Description: opts.Description,
+		Status:      "open",
+		Priority:    priority,
+		Type:        opts.Type,
+		CreatedAt:   now,
+		CreatedBy:   opts.Actor,
+		UpdatedAt:   now,
+		Parent:      opts.Parent,
+		Assignee:    opts.Assignee,
+		Labels:      append([]string(nil), opts.Labels...),
+	}
+	if opts.Visibility != "" && opts.Visibility != beads.VisibilityPublic {
+		issue.Labels = append(issue.Labels, beads.VisibilityLabel(opts.Visibility))
+	}
+	f.Add(issue)
+
+	if opts.Parent != "" {
+		f.mu.Lock()
+		if parent, ok := f.issues[opts.Parent]; ok {
+			parent.Children = append(parent.Children, id)
+		}
+		f.mu.Unlock()
+	}
+	return clone(issue), nil
+}
+
+// Update applies the non-nil fields of opts.
+func (f *FakeClient) Update(id string, opts beads.UpdateOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	issue, ok := f.issues[id]
+	if !ok {
+		return beads.ErrNotFound
+	}
+	if opts.Title != nil {
+		issue.Title = *opts.Title
+	}
+	if opts.Status != nil {
+		issue.Status = *opts.Status
+	}
+	if opts.Priority != nil {
+		issue.Priority = *opts.Priority
+	}
+	if opts.Description != nil {
+		issue.Description = *opts.Description
+	}
+	if opts.Assignee != nil {
+		issue.Assignee = *opts.Assignee
+	}
+	if len(opts.SetLabels) > 0 {
+		issue.Labels = append([]string(nil), opts.SetLabels...)
+	} else {
+		for _, l := range opts.AddLabels {
+			if !contains(issue.Labels, l) {
+				issue.Labels = append(issue.Labels, l)
+			}
+		}
+		issue.Labels = without(issue.Labels, opts.RemoveLabels...)
+	}
+	issue.UpdatedAt = f.now()
+	return nil
+}
+
+// UpdateBatch applies each update in ID order.
+func (f *FakeClient) UpdateBatch(updates map[string]beads.UpdateOptions) error {
+	ids := make([]string, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := f.Update(id, updates[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Claim assigns an open, unassigned issue and marks it in_progress, as
+// (*beads.Beads).Claim does; claiming one assignee already holds succeeds.
+func (f *FakeClient) Claim(id, assignee string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	issue, ok := f.issues[id]
+	if !ok {
+		return beads.ErrNotFound
+	}
+	if issue.Assignee == assignee && issue.Status == "in_progress" {
+		return nil
+	}
+	if issue.Status != "open" || issue.Assignee != "" {
+		return fmt.Errorf("%s: %w", id, beads.ErrAlreadyClaimed)
+	}
+	issue.Status = "in_progress"
+	issue.Assignee = assignee
+	issue.UpdatedAt = f.now()
+	return nil
+}
+
+// Close closes issues.
+func (f *FakeClient) Close(ids ...string) error {
+	return f.CloseWithReason("", ids...)
+}
+
+// CloseWithReason closes issues and records the reason.
+func (f *FakeClient) CloseWithReason(reason string, ids ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := f.issues[id]; !ok {
+			return beads.ErrNotFound
+		}
+	}
+	now := f.now()
+	for _, id := range ids {
+		issue := f.issues[id]
+		issue.Status = "closed"
+		issue.ClosedAt = now
+		issue.UpdatedAt = now
+		if reason != "" {
+			f.CloseReasons[id] = reason
+		}
+	}
+	return nil
+}
+
+// ReleaseWithReason reopens an issue and clears its assignee.
+func (f *FakeClient) ReleaseWithReason(id, reason string) error {
+	open, empty := "open", ""
+	return f.Update(id, beads.UpdateOptions{Status: &open, Assignee: &empty})
+}
+
+// AddComment appends a comment to an existing issue.
+func (f *FakeClient) AddComment(id, author, body string) (*beads.Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.issues[id]; !ok {
+		return nil, beads.ErrNotFound
+	}
+	f.nextComment++
+	c := beads.Comment{ID: f.nextComment, IssueID: id, Author: author, Text: body, CreatedAt: f.now().Format(time.RFC3339)}
+	f.comments[id] = append(f.comments[id], c)
+	return &c, nil
+}
+
+// Comments returns a copy of an issue's comments, oldest first.
+func (f *FakeClient) Comments(id string) ([]beads.Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.issues[id]; !ok {
+		return nil, beads.ErrNotFound
+	}
+	return append([]beads.Comment(nil), f.comments[id]...), nil
+}
+
+// AddDependency records that issue depends on dependsOn.
+func (f *FakeClient) AddDependency(issue, dependsOn string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	from, ok := f.issues[issue]
+	to, ok2 := f.issues[dependsOn]
+	if !ok || !ok2 {
+		return beads.ErrNotFound
+	}
+	if !contains(from.DependsOn, dependsOn) {
+		from.DependsOn = append(from.DependsOn, dependsOn)
+		to.Blocks = append(to.Blocks, issue)
+	}
+	return nil
+}
+
+// RemoveDependency removes a dependency edge if present.
+func (f *FakeClient) RemoveDependency(issue, dependsOn string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	from, ok := f.issues[issue]
+	to, ok2 := f.issues[dependsOn]
+	if !ok || !ok2 {
+		return beads.ErrNotFound
+	}
+	from.DependsOn = without(from.DependsOn, dependsOn)
+	to.Blocks = without(to.Blocks, issue)
+	return nil
+}
+
+// filterOpen returns open issues matching keep, most urgent first.
+func (f *FakeClient) filterOpen(keep func(*beads.Issue) bool) ([]*beads.Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []*beads.Issue
+	for _, id := range f.order {
+		issue := f.issues[id]
+		if issue.Status == "open" && keep(issue) {
+			out = append(out, clone(issue))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Priority < out[j].Priority })
+	return out, nil
+}
+
+// blocked reports whether any dependency is still open. Caller holds f.mu.
+func (f *FakeClient) blocked(issue *beads.Issue) bool {
+	for _, dep := range issue.DependsOn {
+		if d, ok := f.issues[dep]; ok && d.Status != "closed" {
+			return true
+		}
+	}
+	return false
+}
+
+// now returns the next timestamp. Caller holds f.mu.
+func (f *FakeClient) now() time.Time {
+	if f.Now != nil {
+		return f.Now().UTC().Truncate(time.Second)
+	}
+	f.clock = f.clock.Add(time.Second)
+	return f.clock
+}
+
+func clone(issue *beads.Issue) *beads.Issue {
+	c := *issue
+	c.Labels = append([]string(nil), issue.Labels...)
+	c.Children = append([]string(nil), issue.Children...)
+	c.DependsOn = append([]string(nil), issue.DependsOn...)
+	c.Blocks = append([]string(nil), issue.Blocks...)
+	c.BlockedBy = append([]string(nil), issue.BlockedBy...)
+	return &c
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func without(list []string, remove ...string) []string {
+	var out []string
+	for _, v := range list {
+		if !contains(remove, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
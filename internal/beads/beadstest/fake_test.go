@@ -0,0 +1,119 @@
+package beadstest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestFakeClientLifecycle(t *testing.T) {
+	f := NewFakeClient()
+
+	epic, _ := f.Create(beads.CreateOptions{Title: "Epic", Type: "epic", Priority: beads.PriorityHigh})
+	task, _ := f.Create(beads.CreateOptions{Title: "Task", Type: "task", Parent: epic.ID, Priority: beads.PriorityUnset})
+	if task.ID != "gt-2" || task.Priority != beads.PriorityMedium {
+		t.Errorf("task = %+v", task)
+	}
+
+	toast := "gastown/Toast"
+	if err := f.Update(task.ID, beads.UpdateOptions{Assignee: &toast, AddLabels: []string{"x"}}); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := f.List(beads.ListOptions{Assignee: toast, Priority: -1})
+	if len(got) != 1 || got[0].ID != task.ID {
+		t.Errorf("List by assignee = %v", got)
+	}
+
+	if err := f.ReleaseWithReason(task.ID, "stuck"); err != nil {
+		t.Fatal(err)
+	}
+	shown, _ := f.Show(task.ID)
+	if shown.Assignee != "" || shown.Status != "open" {
+		t.Errorf("after release = %+v", shown)
+	}
+
+	if err := f.CloseWithReason("done", task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if open, _ := f.List(beads.ListOptions{Priority: -1}); len(open) != 1 {
+		t.Errorf("open issues = %d, want 1", len(open))
+	}
+	if f.CloseReasons[task.ID] != "done" {
+		t.Errorf("CloseReasons = %v", f.CloseReasons)
+	}
+
+	if _, err := f.Show("gt-99"); !errors.Is(err, beads.ErrNotFound) {
+		t.Errorf("Show missing = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFakeClientReadyBlocked(t *testing.T) {
+	f := NewFakeClient()
+	a, _ := f.Create(beads.CreateOptions{Title: "A", Priority: beads.PriorityLow})
+	b, _ := f.Create(beads.CreateOptions{Title: "B", Priority: beads.PriorityCritical})
+	_ = f.AddDependency(a.ID, b.ID)
+
+	ready, _ := f.Ready()
+	if len(ready) != 1 || ready[0].ID != b.ID {
+		t.Errorf("Ready = %v, want [%s]", ready, b.ID)
+	}
+	blocked, _ := f.Blocked()
+	if len(blocked) != 1 || blocked[0].ID != a.ID {
+		t.Errorf("Blocked = %v, want [%s]", blocked, a.ID)
+	}
+
+	_ = f.Close(b.ID)
+	if ready, _ = f.Ready(); len(ready) != 1 || ready[0].ID != a.ID {
+		t.Errorf("after closing blocker, Ready = %v", ready)
+	}
+}
+
+func TestFakeClientReturnsCopies(t *testing.T) {
+	f := NewFakeClient()
+	issue, _ := f.Create(beads.CreateOptions{Title: "A"})
+	issue.Title = "mutated"
+
+	if shown, _ := f.Show(issue.ID); shown.Title != "A" {
+		t.Errorf("store was mutated through returned issue: %q", shown.Title)
+	}
+}
+
+func TestFakeClientComments(t *testing.T) {
+	f := NewFakeClient()
+	issue, _ := f.Create(beads.CreateOptions{Title: "A"})
+
+	if _, err := f.AddComment(issue.ID, "gastown/polecats/Toast", "halfway done"); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.AddComment(issue.ID, "gastown/witness", "checked in")
+
+	comments, err := f.Comments(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 || comments[0].Text != "halfway done" || comments[1].Author != "gastown/witness" {
+		t.Errorf("Comments = %+v", comments)
+	}
+	if shown, _ := f.Show(issue.ID); shown.Description != "" {
+		t.Errorf("comment touched description: %q", shown.Description)
+	}
+	if _, err := f.AddComment("gt-99", "x", "y"); !errors.Is(err, beads.ErrNotFound) {
+		t.Errorf("AddComment missing = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFakeClientListLabels(t *testing.T) {
+	f := NewFakeClient()
+	a, _ := f.Create(beads.CreateOptions{Title: "A", Labels: []string{"gt:merge", "rig:gastown"}})
+	_, _ = f.Create(beads.CreateOptions{Title: "B", Labels: []string{"gt:merge"}})
+
+	got, _ := f.List(beads.ListOptions{Priority: -1, Label: "gt:merge"})
+	if len(got) != 2 {
+		t.Errorf("Label filter = %d issues, want 2", len(got))
+	}
+	got, _ = f.List(beads.ListOptions{Priority: -1, Labels: []string{"gt:merge", "rig:gastown"}})
+	if len(got) != 1 || got[0].ID != a.ID {
+		t.Errorf("Labels filter = %v, want [%s]", got, a.ID)
+	}
+}
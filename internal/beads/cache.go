@@ -0,0 +1,117 @@
+package beads
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheSweepSize is the entry count above which expired entries are swept.
+const cacheSweepSize = 256
+
+// runCache memoizes bd output keyed on the full argument list.
+type runCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	out     []byte
+	expires time.Time
+}
+
+// WithCache enables a read-through cache for Show and List results on b
+// and returns b. Entries live for ttl and are dropped whenever b runs a
+// command that may mutate the database (create, update, close, sync, ...).
+// Writes made by other processes are only seen once an entry expires, so
+// keep ttl short. A ttl <= 0 disables caching.
+func (b *Beads) WithCache(ttl time.Duration) *Beads {
+	if ttl <= 0 {
+		b.cache = nil
+		return b
+	}
+	b.cache = &runCache{ttl: ttl, now: time.Now, entries: make(map[string]cacheEntry)}
+	return b
+}
+
+// WithCache is the construction-time form of (*Beads).WithCache.
+func WithCache(ttl time.Duration) Option {
+	return func(b *Beads) {
+		b.WithCache(ttl)
+	}
+}
+
+// Invalidate drops all cached results. Use it after changing the
+// database outside of b, e.g. through another Beads or a bd subprocess.
+func (b *Beads) Invalidate() {
+	if b.cache != nil {
+		b.cache.clear()
+	}
+}
+
+// middleware serves cacheable calls from c and records their results.
+func (c *runCache) middleware(next Executor) Executor {
+	return func(args []string) ([]byte, error) {
+		if out, ok := c.lookup(args); ok {
+			return out, nil
+		}
+		out, err := next(args)
+		if IsMutation(args) {
+			// Drop reads that raced with the write.
+			c.clear()
+		} else if err == nil {
+			c.store(args, out)
+		}
+		return out, err
+	}
+}
+
+// lookup returns cached output for args. Commands that may mutate the
+// database clear the cache as a side effect.
+func (c *runCache) lookup(args []string) ([]byte, bool) {
+	if !cachedCommands[subcommand(args)] {
+		if IsMutation(args) {
+			c.clear()
+		}
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[cacheKey(args)]
+	if !ok || !c.now().Before(e.expires) {
+		return nil, false
+	}
+	return append([]byte(nil), e.out...), true
+}
+
+// store records output for args if the command is cacheable.
+func (c *runCache) store(args []string, out []byte) {
+	if !cachedCommands[subcommand(args)] {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= cacheSweepSize {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[cacheKey(args)] = cacheEntry{out: append([]byte(nil), out...), expires: now.Add(c.ttl)}
+}
+
+func (c *runCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+func cacheKey(args []string) string {
+	return strings.Join(args, "\x00")
+}
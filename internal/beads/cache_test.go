@@ -0,0 +1,89 @@
+package beads
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// bdCalls returns how many times the fake bd appended to its calls file.
+func bdCalls(t *testing.T) int {
+	t.Helper()
+	bdPath, err := exec.LookPath("bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "calls"))
+	return len(calls) / 2
+}
+
+func TestWithCacheMemoizesReads(t *testing.T) {
+	installFakeBd(t, `
+echo x >> "$(dirname "$0")/calls"
+case "$2" in
+show) echo '[{"id":"gt-h1","title":"handoff"}]' ;;
+list) echo '[{"id":"gt-h1"}]' ;;
+*) echo '{"id":"gt-h2"}' ;;
+esac
+`)
+	b := New(t.TempDir()).WithCache(time.Minute)
+
+	for i := 0; i < 3; i++ {
+		issue, err := b.Show("gt-h1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if issue.Title != "handoff" {
+			t.Fatalf("Show title = %q", issue.Title)
+		}
+	}
+	if _, err := b.List(ListOptions{Status: "open", Priority: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.List(ListOptions{Status: "open", Priority: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if n := bdCalls(t); n != 2 {
+		t.Errorf("bd invoked %d times, want 2 (one show, one list)", n)
+	}
+
+	if _, err := b.Create(CreateOptions{Title: "new", Priority: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Show("gt-h1"); err != nil {
+		t.Fatal(err)
+	}
+	if n := bdCalls(t); n != 4 {
+		t.Errorf("bd invoked %d times after create, want 4", n)
+	}
+}
+
+func TestRunCacheExpiry(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := &runCache{ttl: time.Second, now: func() time.Time { return now }, entries: make(map[string]cacheEntry)}
+	args := []string{"show", "gt-1", "--json"}
+
+	c.store(args, []byte("out"))
+	if out, ok := c.lookup(args); !ok || string(out) != "out" {
+		t.Fatalf("lookup = %q, %v", out, ok)
+	}
+
+	// Read-only commands pass through without invalidating.
+	c.lookup([]string{"ready", "--json"})
+	if _, ok := c.lookup(args); !ok {
+		t.Error("ready invalidated the cache")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := c.lookup(args); ok {
+		t.Error("entry survived its ttl")
+	}
+
+	c.store(args, []byte("out"))
+	c.lookup([]string{"close", "gt-1"})
+	if _, ok := c.lookup(args); ok {
+		t.Error("close did not invalidate the cache")
+	}
+}
@@ -185,7 +185,7 @@ func (mol *CatalogMolecule) ToIssue() *Issue {
 		Description: mol.Description,
 		Type:        "molecule",
 		Status:      "open",
-		Priority:    2,
+		Priority:    PriorityMedium,
 	}
 }
 
@@ -0,0 +1,110 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/flock"
+)
+
+// ErrAlreadyClaimed is returned by Claim when the issue is assigned to
+// someone else or is no longer open.
+var ErrAlreadyClaimed = errors.New("already claimed")
+
+// claimLockName is the lock file serializing fallback claims on one
+// beads database.
+const claimLockName = "claim.lock"
+
+// Claim assigns id to assignee and marks it in_progress, but only if it is
+// open and unassigned; otherwise it returns ErrAlreadyClaimed. Claiming an
+// issue assignee already holds succeeds.
+//
+// bd's atomic "update --claim" is used when available. Older bd versions
+// fall back to check-then-update under a file lock on the beads directory,
+// followed by a re-read to verify the assignment stuck; that fallback is
+// only atomic among gt processes sharing the directory.
+func (b *Beads) Claim(id, assignee string) error {
+	if err := checkIDs(id); err != nil {
+		return err
+	}
+	if assignee == "" {
+		return &ValidationError{Field: "Assignee", Value: assignee, Reason: "claim needs an assignee"}
+	}
+
+	_, err := b.run("update", id, "--claim", "--actor="+assignee)
+	if err == nil {
+		return nil
+	}
+	if isAlreadyClaimed(err) {
+		return b.claimConflict(id, assignee)
+	}
+	if !isUnknownFlag(err) {
+		return err
+	}
+
+	beadsDir := b.beadsDir
+	if beadsDir == "" {
+		beadsDir = ResolveBeadsDir(b.workDir)
+	}
+	return flock.With(filepath.Join(beadsDir, claimLockName), flock.DefaultTimeout, func() error {
+		return b.claimVerified(id, assignee)
+	})
+}
+
+// claimVerified is the check-then-update fallback for Claim.
+func (b *Beads) claimVerified(id, assignee string) error {
+	issue, err := b.Show(id)
+	if err != nil {
+		return err
+	}
+	if issue.Assignee == assignee && issue.Status == "in_progress" {
+		return nil
+	}
+	if issue.Status != "open" || issue.Assignee != "" {
+		return claimedError(id, issue)
+	}
+
+	status := "in_progress"
+	if err := b.Update(id, UpdateOptions{Status: &status, Assignee: &assignee}); err != nil {
+		return err
+	}
+
+	// Verify: a writer outside our lock may have assigned it concurrently
+	issue, err = b.Show(id)
+	if err != nil {
+		return fmt.Errorf("verifying claim on %s: %w", id, err)
+	}
+	if issue.Assignee != assignee {
+		return claimedError(id, issue)
+	}
+	return nil
+}
+
+// claimConflict explains a claim bd rejected, treating an existing claim by
+// assignee as success.
+func (b *Beads) claimConflict(id, assignee string) error {
+	issue, err := b.Show(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", id, ErrAlreadyClaimed)
+	}
+	if issue.Assignee == assignee && issue.Status == "in_progress" {
+		return nil
+	}
+	return claimedError(id, issue)
+}
+
+func claimedError(id string, issue *Issue) error {
+	if issue.Assignee != "" {
+		return fmt.Errorf("%s: %w by %s", id, ErrAlreadyClaimed, issue.Assignee)
+	}
+	return fmt.Errorf("%s: %w (status %s)", id, ErrAlreadyClaimed, issue.Status)
+}
+
+// isAlreadyClaimed reports whether bd refused --claim because the issue is
+// taken.
+func isAlreadyClaimed(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "already claimed") || strings.Contains(msg, "already assigned")
+}
@@ -0,0 +1,68 @@
+package beads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClaimAtomic(t *testing.T) {
+	installFakeBd(t, `
+echo "$@" >> "$(dirname "$0")/log"
+case "$*" in
+*"update gt-taken --claim"*) echo "Error: issue gt-taken already claimed by gastown/Nux" >&2; exit 1;;
+*"show gt-taken"*) echo '[{"id":"gt-taken","status":"in_progress","assignee":"gastown/Nux"}]';;
+esac
+`)
+	b := New(t.TempDir())
+
+	if err := b.Claim("gt-1", "gastown/Toast"); err != nil {
+		t.Fatal(err)
+	}
+	if log := bdLog(t); !strings.Contains(log, "update gt-1 --claim --actor=gastown/Toast") {
+		t.Errorf("no atomic claim:\n%s", log)
+	}
+
+	err := b.Claim("gt-taken", "gastown/Toast")
+	if !errors.Is(err, ErrAlreadyClaimed) || !strings.Contains(err.Error(), "gastown/Nux") {
+		t.Errorf("Claim(taken) = %v, want ErrAlreadyClaimed naming holder", err)
+	}
+	if err := b.Claim("gt-taken", "gastown/Nux"); err != nil {
+		t.Errorf("re-claim by holder = %v", err)
+	}
+}
+
+func TestClaimFallbackVerifies(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+echo "$@" >> "$dir/log"
+case "$*" in
+*--claim*) echo "Error: unknown flag: --claim" >&2; exit 1;;
+*"show gt-open"*)
+  if [ -f "$dir/assigned" ]; then
+    echo '[{"id":"gt-open","status":"in_progress","assignee":"gastown/Nux"}]'
+  else
+    echo '[{"id":"gt-open","status":"open"}]'
+  fi;;
+*"show gt-closed"*) echo '[{"id":"gt-closed","status":"closed"}]';;
+*"update gt-open"*) touch "$dir/assigned";;
+esac
+`)
+	b := New(t.TempDir())
+
+	// A concurrent writer wins between our update and the verify read.
+	err := b.Claim("gt-open", "gastown/Toast")
+	if !errors.Is(err, ErrAlreadyClaimed) {
+		t.Errorf("lost race: err = %v, want ErrAlreadyClaimed", err)
+	}
+	if log := bdLog(t); !strings.Contains(log, "update gt-open --status=in_progress --assignee=gastown/Toast") {
+		t.Errorf("fallback didn't assign:\n%s", log)
+	}
+
+	if err := b.Claim("gt-closed", "gastown/Toast"); !errors.Is(err, ErrAlreadyClaimed) {
+		t.Errorf("Claim(closed) = %v, want ErrAlreadyClaimed", err)
+	}
+	if err := b.Claim("gt-open", ""); err == nil {
+		t.Error("Claim with empty assignee should fail")
+	}
+}
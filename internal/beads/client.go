@@ -0,0 +1,24 @@
+package beads
+
+// Client is the subset of bd operations used by daemons and commands.
+// *Beads implements it by shelling out to bd; beadstest.FakeClient
+// implements it in memory for unit tests.
+type Client interface {
+	List(opts ListOptions) ([]*Issue, error)
+	Ready() ([]*Issue, error)
+	Blocked() ([]*Issue, error)
+	Show(id string) (*Issue, error)
+	Create(opts CreateOptions) (*Issue, error)
+	Update(id string, opts UpdateOptions) error
+	UpdateBatch(updates map[string]UpdateOptions) error
+	Claim(id, assignee string) error
+	Close(ids ...string) error
+	CloseWithReason(reason string, ids ...string) error
+	ReleaseWithReason(id, reason string) error
+	AddDependency(issue, dependsOn string) error
+	RemoveDependency(issue, dependsOn string) error
+	AddComment(id, author, body string) (*Comment, error)
+	Comments(id string) ([]Comment, error)
+}
+
+var _ Client = (*Beads)(nil)
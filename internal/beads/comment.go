@@ -0,0 +1,63 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Comment is a note appended to an issue. Unlike the description or
+// notes fields, comments accumulate, so agents can post progress updates
+// without overwriting each other.
+type Comment struct {
+	ID        int    `json:"id"`
+	IssueID   string `json:"issue_id"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+}
+
+// AddComment appends a comment to an issue. An empty author lets bd use
+// its default actor.
+func (b *Beads) AddComment(id, author, body string) (*Comment, error) {
+	if err := checkIDs(id); err != nil {
+		return nil, err
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, fmt.Errorf("empty comment")
+	}
+
+	args := []string{"comments", "add", id, "--json"}
+	if author != "" {
+		args = append(args, "--author="+author)
+	}
+	// A body starting with "-" must not be taken for a flag
+	out, err := b.run(append(args, "--", body)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Comment
+	if err := json.Unmarshal(out, &c); err != nil {
+		return nil, fmt.Errorf("parsing bd comments add output: %w", err)
+	}
+	return &c, nil
+}
+
+// Comments returns an issue's comments, oldest first.
+func (b *Beads) Comments(id string) ([]Comment, error) {
+	if err := checkIDs(id); err != nil {
+		return nil, err
+	}
+	out, err := b.run("comments", id, "--json")
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []Comment
+	if err := json.Unmarshal(out, &comments); err != nil {
+		return nil, fmt.Errorf("parsing bd comments output: %w", err)
+	}
+	return comments, nil
+}
@@ -0,0 +1,54 @@
+package beads
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestComments(t *testing.T) {
+	installFakeBd(t, `
+echo "$@" >> "$(dirname "$0")/args"
+case "$3" in
+  add) echo '{"id":3,"issue_id":"gt-1","author":"gastown/witness","text":"ok","created_at":"2026-01-01T00:00:00Z"}';;
+  *) echo '[{"id":1,"issue_id":"gt-1","text":"first"},{"id":3,"issue_id":"gt-1","text":"ok"}]';;
+esac
+`)
+	b := New(t.TempDir())
+
+	c, err := b.AddComment("gt-1", "gastown/witness", "  ok \n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ID != 3 || c.Author != "gastown/witness" {
+		t.Errorf("AddComment = %+v", c)
+	}
+
+	comments, err := b.Comments("gt-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 || comments[0].Text != "first" {
+		t.Errorf("Comments = %+v", comments)
+	}
+
+	bdPath, _ := exec.LookPath("bd")
+	args, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "args"))
+	if !strings.Contains(string(args), "comments add gt-1 --json --author=gastown/witness -- ok") {
+		t.Errorf("bd args = %q", args)
+	}
+
+	if _, err := b.AddComment("gt-1", "", "--help"); err != nil {
+		t.Fatal(err)
+	}
+	args, _ = os.ReadFile(filepath.Join(filepath.Dir(bdPath), "args"))
+	if !strings.Contains(string(args), "comments add gt-1 --json -- --help") {
+		t.Errorf("flag-like body not passed after --: %q", args)
+	}
+
+	if _, err := b.AddComment("gt-1", "", "   "); err == nil {
+		t.Error("expected error for empty comment")
+	}
+}
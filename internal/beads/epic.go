@@ -0,0 +1,61 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// EpicProgress is a rollup of an epic's direct children.
+type EpicProgress struct {
+	EpicID           string         `json:"epic_id"`
+	Total            int            `json:"total"`
+	ByStatus         map[string]int `json:"by_status"`
+	Done             int            `json:"done"`
+	Percent          int            `json:"percent_complete"`
+	Blocked          []string       `json:"blocked,omitempty"`
+	Remaining        int            `json:"remaining"`             // children not closed
+	RemainingMinutes int            `json:"remaining_minutes"`     // sum of estimates on remaining children
+	Unestimated      int            `json:"unestimated,omitempty"` // remaining children without an estimate
+}
+
+// EpicProgress rolls up an epic's children with a single parent-filtered
+// list call.
+func (b *Beads) EpicProgress(epicID string) (*EpicProgress, error) {
+	children, err := b.List(ListOptions{Parent: epicID, Status: "all", Priority: PriorityUnset, Limit: NoLimit})
+	if err != nil {
+		return nil, fmt.Errorf("listing children of %s: %w", epicID, err)
+	}
+	return ComputeEpicProgress(epicID, children), nil
+}
+
+// ComputeEpicProgress builds an EpicProgress from an epic's children. A
+// child is blocked if a sibling it depends on is still open, or bd reports
+// open blockers for it outside the epic.
+func ComputeEpicProgress(epicID string, children []*Issue) *EpicProgress {
+	p := &EpicProgress{EpicID: epicID, Total: len(children), ByStatus: make(map[string]int)}
+	snap := NewSnapshot(children, time.Time{})
+
+	for _, child := range children {
+		p.ByStatus[child.Status]++
+		if child.Status == "closed" {
+			p.Done++
+			continue
+		}
+		p.Remaining++
+		if child.EstimatedMinutes > 0 {
+			p.RemainingMinutes += child.EstimatedMinutes
+		} else {
+			p.Unestimated++
+		}
+		if child.Status == "blocked" || snap.IsBlocked(child) {
+			p.Blocked = append(p.Blocked, child.ID)
+		}
+	}
+	sort.Strings(p.Blocked)
+
+	if p.Total > 0 {
+		p.Percent = p.Done * 100 / p.Total
+	}
+	return p
+}
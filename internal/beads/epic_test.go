@@ -0,0 +1,36 @@
+package beads
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeEpicProgress(t *testing.T) {
+	p := ComputeEpicProgress("gt-epic", []*Issue{
+		{ID: "gt-1", Status: "closed", EstimatedMinutes: 60},
+		{ID: "gt-2", Status: "in_progress", EstimatedMinutes: 30},
+		{ID: "gt-3", Status: "open", DependsOn: []string{"gt-2"}, EstimatedMinutes: 90},
+		{ID: "gt-4", Status: "open", BlockedByCount: 1},
+		{ID: "gt-5", Status: "open", DependsOn: []string{"gt-1"}},
+	})
+
+	if p.Total != 5 || p.Done != 1 || p.Percent != 20 || p.Remaining != 4 {
+		t.Errorf("counts = %+v", p)
+	}
+	if p.ByStatus["open"] != 3 || p.ByStatus["in_progress"] != 1 || p.ByStatus["closed"] != 1 {
+		t.Errorf("ByStatus = %v", p.ByStatus)
+	}
+	if p.RemainingMinutes != 120 || p.Unestimated != 2 {
+		t.Errorf("estimate = %d min, %d unestimated", p.RemainingMinutes, p.Unestimated)
+	}
+	if want := []string{"gt-3", "gt-4"}; !reflect.DeepEqual(p.Blocked, want) {
+		t.Errorf("Blocked = %v, want %v", p.Blocked, want)
+	}
+}
+
+func TestComputeEpicProgressEmpty(t *testing.T) {
+	p := ComputeEpicProgress("gt-epic", nil)
+	if p.Total != 0 || p.Percent != 0 {
+		t.Errorf("empty epic = %+v", p)
+	}
+}
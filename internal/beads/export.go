@@ -0,0 +1,364 @@
+package beads
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// ExportOptions filters Export.
+type ExportOptions struct {
+	Status string   // "open", "closed", ...; empty or "all" exports every issue
+	Labels []string // issues must carry every label
+}
+
+// ImportOptions controls Import.
+type ImportOptions struct {
+	// NewIDs creates issues under the target repo's own prefix instead of
+	// keeping their exported IDs, remapping dependencies and parents to
+	// match. Use it to seed fixtures or merge a rig into another prefix.
+	NewIDs bool
+}
+
+// ImportResult reports what Import did.
+type ImportResult struct {
+	Imported int               `json:"imported"`
+	Skipped  []string          `json:"skipped,omitempty"` // IDs that already existed
+	IDs      map[string]string `json:"ids,omitempty"`     // exported ID → new ID (NewIDs only)
+}
+
+// exportDep is a dependency as bd export writes it.
+type exportDep struct {
+	IssueID     string `json:"issue_id,omitempty"`
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type,omitempty"`
+}
+
+// exportDeps reads the dependencies of a line in either bd's export
+// format or Export's own, which differ only in how they are spelled.
+type exportDeps struct {
+	Dependencies []json.RawMessage `json:"dependencies,omitempty"`
+}
+
+// Export writes every issue matching opts to w as JSONL, one Issue per
+// line with its dependencies (DependsOn and Dependencies) filled in.
+// Uses bd export when available and falls back to bd list + show.
+func (b *Beads) Export(w io.Writer, opts ExportOptions) error {
+	issues, err := b.exportAll()
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for _, issue := range issues {
+		if !matchesExport(issue, opts) {
+			continue
+		}
+		if err := enc.Encode(issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Beads) exportAll() ([]*Issue, error) {
+	out, err := b.run("export")
+	if err != nil {
+		if isUnknownCommand(err) {
+			return b.exportFallback()
+		}
+		return nil, err
+	}
+	issues, err := ParseExport(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("parsing bd export output: %w", err)
+	}
+	return FilterVisible(issues, b.scope), nil
+}
+
+// exportFallback assembles an export from list (for the issues) and show
+// (for their dependencies), for bd versions without export.
+func (b *Beads) exportFallback() ([]*Issue, error) {
+	listed, err := b.List(ListOptions{Status: "all", Priority: -1, Limit: NoLimit})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(listed))
+	for _, issue := range listed {
+		ids = append(ids, issue.ID)
+	}
+	detailed, err := b.ShowMultiple(ids)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]*Issue, 0, len(listed))
+	for _, issue := range listed {
+		if d, ok := detailed[issue.ID]; ok {
+			issue = d
+		}
+		normalizeDeps(issue)
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// ParseExport reads JSONL written by Export or by bd export.
+func ParseExport(r io.Reader) ([]*Issue, error) {
+	var issues []*Issue
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var issue Issue
+		var deps exportDeps
+		if err := json.Unmarshal(scanner.Bytes(), &deps); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		issue.Dependencies = nil
+		for _, raw := range deps.Dependencies {
+			var dep struct {
+				IssueDep
+				exportDep
+			}
+			if err := json.Unmarshal(raw, &dep); err != nil {
+				return nil, fmt.Errorf("line %d: dependency: %w", line, err)
+			}
+			d := dep.IssueDep
+			if dep.DependsOnID != "" {
+				d.ID, d.DependencyType = dep.DependsOnID, dep.exportDep.Type
+			}
+			if d.ID != "" {
+				issue.Dependencies = append(issue.Dependencies, d)
+			}
+		}
+		normalizeDeps(&issue)
+		issues = append(issues, &issue)
+	}
+	return issues, scanner.Err()
+}
+
+// normalizeDeps makes DependsOn list every blocking dependency ID. Soft
+// links stay only in Dependencies; parent-child links are carried by Parent.
+func normalizeDeps(issue *Issue) {
+	for _, d := range issue.Dependencies {
+		if !d.Blocking() || slices.Contains(issue.DependsOn, d.ID) {
+			continue
+		}
+		issue.DependsOn = append(issue.DependsOn, d.ID)
+	}
+}
+
+// softLinks returns issue's non-blocking dependencies other than its
+// parent link, which Parent carries.
+func softLinks(issue *Issue) []IssueDep {
+	var out []IssueDep
+	for _, d := range issue.Dependencies {
+		if !d.Blocking() && d.DependencyType != DepParentChild {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func matchesExport(issue *Issue, opts ExportOptions) bool {
+	if opts.Status != "" && opts.Status != "all" && issue.Status != opts.Status {
+		return false
+	}
+	return HasAllLabels(issue, opts.Labels...)
+}
+
+// Import reads JSONL written by Export and adds the issues to this repo,
+// dependencies included. Uses bd import when IDs are kept and bd has it;
+// otherwise creates the issues one by one, skipping IDs that exist.
+func (b *Beads) Import(r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	issues, err := ParseExport(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return &ImportResult{}, nil
+	}
+	if !opts.NewIDs {
+		res, err := b.bdImport(issues)
+		if err == nil || !isUnknownCommand(err) {
+			return res, err
+		}
+	}
+	return b.importFallback(issues, opts)
+}
+
+// bdImport hands the issues to bd import in bd's export format.
+func (b *Beads) bdImport(issues []*Issue) (*ImportResult, error) {
+	f, err := os.CreateTemp("", "gt-import-*.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	enc := json.NewEncoder(f)
+	for _, issue := range issues {
+		rec := struct {
+			*Issue
+			Dependencies []exportDep `json:"dependencies,omitempty"`
+		}{Issue: issue}
+		for _, id := range issue.DependsOn {
+			rec.Dependencies = append(rec.Dependencies, exportDep{IssueID: issue.ID, DependsOnID: id, Type: DepBlocks})
+		}
+		for _, d := range softLinks(issue) {
+			rec.Dependencies = append(rec.Dependencies, exportDep{IssueID: issue.ID, DependsOnID: d.ID, Type: d.DependencyType})
+		}
+		if issue.Parent != "" {
+			rec.Dependencies = append(rec.Dependencies, exportDep{IssueID: issue.ID, DependsOnID: issue.Parent, Type: DepParentChild})
+		}
+		if err := enc.Encode(rec); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	if _, err := b.run("import", "-i", filepath.Clean(f.Name())); err != nil {
+		return nil, err
+	}
+	return &ImportResult{Imported: len(issues)}, nil
+}
+
+// importFallback creates issues with bd create, parents before their
+// children, then wires up dependencies once every issue exists.
+func (b *Beads) importFallback(issues []*Issue, opts ImportOptions) (*ImportResult, error) {
+	res := &ImportResult{}
+	ids := make(map[string]string, len(issues))
+	resolve := func(id string) string {
+		if newID, ok := ids[id]; ok {
+			return newID
+		}
+		return id
+	}
+
+	for _, issue := range parentsFirst(issues) {
+		if !opts.NewIDs {
+			if _, err := b.Show(issue.ID); err == nil {
+				res.Skipped = append(res.Skipped, issue.ID)
+				continue
+			}
+		}
+		created, err := b.importOne(issue, resolve(issue.Parent), opts.NewIDs)
+		if err != nil {
+			return res, fmt.Errorf("importing %s: %w", issue.ID, err)
+		}
+		ids[issue.ID] = created
+		res.Imported++
+	}
+
+	for _, issue := range issues {
+		newID, ok := ids[issue.ID]
+		if !ok {
+			continue
+		}
+		for _, dep := range issue.DependsOn {
+			if err := b.AddDependency(newID, resolve(dep)); err != nil {
+				return res, fmt.Errorf("importing %s: dependency on %s: %w", issue.ID, dep, err)
+			}
+		}
+		for _, d := range softLinks(issue) {
+			if err := b.AddDependencyTyped(newID, resolve(d.ID), d.DependencyType); err != nil {
+				return res, fmt.Errorf("importing %s: %s link to %s: %w", issue.ID, d.DependencyType, d.ID, err)
+			}
+		}
+	}
+	if opts.NewIDs {
+		res.IDs = ids
+	}
+	return res, nil
+}
+
+// parentsFirst orders issues so each comes after its parent when both
+// are in the set. Parents outside the set (or cycles) don't hold anything
+// back.
+func parentsFirst(issues []*Issue) []*Issue {
+	inSet := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inSet[issue.ID] = true
+	}
+	out := make([]*Issue, 0, len(issues))
+	done := make(map[string]bool, len(issues))
+	for len(out) < len(issues) {
+		progress := false
+		for _, issue := range issues {
+			if done[issue.ID] || (issue.Parent != "" && inSet[issue.Parent] && !done[issue.Parent]) {
+				continue
+			}
+			out = append(out, issue)
+			done[issue.ID] = true
+			progress = true
+		}
+		if !progress {
+			for _, issue := range issues {
+				if !done[issue.ID] {
+					out = append(out, issue)
+					done[issue.ID] = true
+				}
+			}
+		}
+	}
+	return out
+}
+
+// importOne creates one issue under parent and brings its status,
+// assignee, and labels in line with the export. Returns the ID it was
+// created under.
+func (b *Beads) importOne(issue *Issue, parent string, newID bool) (string, error) {
+	create := CreateOptions{
+		Title:       issue.Title,
+		Type:        issue.Type,
+		Priority:    issue.Priority,
+		Description: issue.Description,
+		Parent:      parent,
+		Actor:       issue.CreatedBy,
+	}
+	var created *Issue
+	var err error
+	if newID {
+		created, err = b.Create(create)
+	} else {
+		created, err = b.CreateWithID(issue.ID, create)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var update UpdateOptions
+	if issue.Assignee != "" {
+		update.Assignee = &issue.Assignee
+	}
+	if len(issue.Labels) > 0 {
+		update.AddLabels = issue.Labels
+	}
+	if issue.Status != "" && issue.Status != "open" && issue.Status != "closed" {
+		update.Status = &issue.Status
+	}
+	if update.Assignee != nil || update.Status != nil || len(update.AddLabels) > 0 {
+		if err := b.Update(created.ID, update); err != nil {
+			return created.ID, err
+		}
+	}
+	if issue.Status == "closed" {
+		if err := b.Close(created.ID); err != nil {
+			return created.ID, err
+		}
+	}
+	return created.ID, nil
+}
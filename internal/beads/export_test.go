@@ -0,0 +1,105 @@
+package beads
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseExportReadsBdFormat(t *testing.T) {
+	jsonl := `{"id":"gt-2","title":"Child","status":"open","parent":"gt-9","dependencies":[` +
+		`{"issue_id":"gt-2","depends_on_id":"gt-1","type":"blocks"},` +
+		`{"issue_id":"gt-2","depends_on_id":"gt-9","type":"parent-child"},` +
+		`{"issue_id":"gt-2","depends_on_id":"gt-5","type":"related"}]}` + "\n\n" +
+		`{"id":"gt-3","title":"Ours","dependencies":[{"id":"gt-1","title":"Root","dependency_type":"blocks"}]}` + "\n"
+
+	issues, err := ParseExport(strings.NewReader(jsonl))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues", len(issues))
+	}
+	child := issues[0]
+	if len(child.Dependencies) != 3 || child.Dependencies[1].DependencyType != "parent-child" {
+		t.Errorf("Dependencies = %+v", child.Dependencies)
+	}
+	if len(child.DependsOn) != 1 || child.DependsOn[0] != "gt-1" {
+		t.Errorf("DependsOn = %v, want [gt-1]", child.DependsOn)
+	}
+	if soft := softLinks(child); len(soft) != 1 || soft[0].ID != "gt-5" {
+		t.Errorf("softLinks = %+v, want the related link to gt-5", soft)
+	}
+	if len(issues[1].DependsOn) != 1 || issues[1].DependsOn[0] != "gt-1" {
+		t.Errorf("own format DependsOn = %v", issues[1].DependsOn)
+	}
+}
+
+func TestExportFiltersBdExport(t *testing.T) {
+	installFakeBd(t, `
+case "$2" in
+export)
+  echo '{"id":"gt-1","title":"Open","status":"open","labels":["keep"]}'
+  echo '{"id":"gt-2","title":"Closed","status":"closed","labels":["keep"]}'
+  echo '{"id":"gt-3","title":"Other","status":"open"}'
+  ;;
+esac
+`)
+	var buf bytes.Buffer
+	if err := New(t.TempDir()).Export(&buf, ExportOptions{Status: "open", Labels: []string{"keep"}}); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := ParseExport(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(issues); len(got) != 1 || got[0] != "gt-1" {
+		t.Errorf("exported %v, want [gt-1]", got)
+	}
+}
+
+func TestImportNewIDsRemapsDependencies(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+echo "$@" >> "$dir/log"
+case "$2" in
+create)
+  n=$(cat "$dir/n" 2>/dev/null || echo 0); n=$((n+1)); echo $n > "$dir/n"
+  echo "{\"id\":\"new-$n\"}"
+  ;;
+esac
+`)
+	jsonl := `{"id":"old-2","title":"Child","status":"closed","parent":"old-1","depends_on":["old-3"]}` + "\n" +
+		`{"id":"old-1","title":"Epic","status":"open"}` + "\n" +
+		`{"id":"old-3","title":"Blocker","status":"open","labels":["x"]}` + "\n"
+
+	res, err := New(t.TempDir()).Import(strings.NewReader(jsonl), ImportOptions{NewIDs: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Imported != 3 {
+		t.Errorf("Imported = %d, want 3", res.Imported)
+	}
+	// The epic is created before its child despite coming later.
+	if res.IDs["old-1"] != "new-1" || res.IDs["old-3"] != "new-2" || res.IDs["old-2"] != "new-3" {
+		t.Errorf("IDs = %v", res.IDs)
+	}
+
+	bdPath, err := exec.LookPath("bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	for _, want := range []string{"--parent=new-1", "dep add new-3 new-2", "close new-3", "update new-2 --add-label=x"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("bd calls missing %q:\n%s", want, log)
+		}
+	}
+}
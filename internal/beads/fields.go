@@ -3,6 +3,7 @@ package beads
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -21,49 +22,30 @@ type AttachmentFields struct {
 	AttachedAt       string // ISO 8601 timestamp when attached
 	AttachedArgs     string // Natural language args passed via gt sling --args (no-tmux mode)
 	DispatchedBy     string // Agent ID that dispatched this work (for completion notification)
+	Transcript       string // Session transcript of the work (recorded by gt done)
 }
 
-// ParseAttachmentFields extracts attachment fields from an issue's description.
-// Fields are expected as "key: value" lines. Returns nil if no attachment fields found.
+// attachmentKeys are the canonical attachment field keys.
+var attachmentKeys = []string{"attached_molecule", "attached_at", "attached_args", "dispatched_by", "transcript"}
+
+// ParseAttachmentFields extracts attachment fields from an issue. Fields are
+// read from "key: value" description lines, then from bd metadata, which
+// wins where both are set. Returns nil if no attachment fields found.
 func ParseAttachmentFields(issue *Issue) *AttachmentFields {
-	if issue == nil || issue.Description == "" {
+	if issue == nil {
 		return nil
 	}
 
 	fields := &AttachmentFields{}
 	hasFields := false
 
-	for _, line := range strings.Split(issue.Description, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// Look for "key: value" pattern
-		colonIdx := strings.Index(line, ":")
-		if colonIdx == -1 {
-			continue
-		}
-
-		key := strings.TrimSpace(line[:colonIdx])
-		value := strings.TrimSpace(line[colonIdx+1:])
-		if value == "" {
-			continue
-		}
-
-		// Map keys to fields (case-insensitive)
-		switch strings.ToLower(key) {
-		case "attached_molecule", "attached-molecule", "attachedmolecule":
-			fields.AttachedMolecule = value
-			hasFields = true
-		case "attached_at", "attached-at", "attachedat":
-			fields.AttachedAt = value
+	for _, kv := range descriptionFields(issue.Description) {
+		if fields.set(kv[0], kv[1]) {
 			hasFields = true
-		case "attached_args", "attached-args", "attachedargs":
-			fields.AttachedArgs = value
-			hasFields = true
-		case "dispatched_by", "dispatched-by", "dispatchedby":
-			fields.DispatchedBy = value
+		}
+	}
+	for key, value := range issue.Metadata {
+		if value != "" && fields.set(key, value) {
 			hasFields = true
 		}
 	}
@@ -74,101 +56,60 @@ func ParseAttachmentFields(issue *Issue) *AttachmentFields {
 	return fields
 }
 
-// FormatAttachmentFields formats AttachmentFields as a string suitable for an issue description.
-// Only non-empty fields are included.
-func FormatAttachmentFields(fields *AttachmentFields) string {
-	if fields == nil {
-		return ""
-	}
-
-	var lines []string
+// set assigns the field named by key (case-insensitive, any spelling) and
+// reports whether key is an attachment field.
+func (f *AttachmentFields) set(key, value string) bool {
+	switch strings.ToLower(key) {
+	case "attached_molecule", "attached-molecule", "attachedmolecule":
+		f.AttachedMolecule = value
+	case "attached_at", "attached-at", "attachedat":
+		f.AttachedAt = value
+	case "attached_args", "attached-args", "attachedargs":
+		f.AttachedArgs = value
+	case "dispatched_by", "dispatched-by", "dispatchedby":
+		f.DispatchedBy = value
+	case "transcript":
+		f.Transcript = value
+	default:
+		return false
+	}
+	return true
+}
 
-	if fields.AttachedMolecule != "" {
-		lines = append(lines, "attached_molecule: "+fields.AttachedMolecule)
-	}
-	if fields.AttachedAt != "" {
-		lines = append(lines, "attached_at: "+fields.AttachedAt)
-	}
-	if fields.AttachedArgs != "" {
-		lines = append(lines, "attached_args: "+fields.AttachedArgs)
+// pairs returns the non-empty fields as canonical key/value pairs.
+func (f *AttachmentFields) pairs() [][2]string {
+	if f == nil {
+		return nil
 	}
-	if fields.DispatchedBy != "" {
-		lines = append(lines, "dispatched_by: "+fields.DispatchedBy)
+	var kvs [][2]string
+	for i, value := range []string{f.AttachedMolecule, f.AttachedAt, f.AttachedArgs, f.DispatchedBy, f.Transcript} {
+		if value != "" {
+			kvs = append(kvs, [2]string{attachmentKeys[i], value})
+		}
 	}
+	return kvs
+}
 
-	return strings.Join(lines, "\n")
+// FormatAttachmentFields formats AttachmentFields as a string suitable for an issue description.
+// Only non-empty fields are included.
+func FormatAttachmentFields(fields *AttachmentFields) string {
+	return formatPairs(fields.pairs())
 }
 
 // SetAttachmentFields updates an issue's description with the given attachment fields.
 // Existing attachment field lines are replaced; other content is preserved.
 // Returns the new description string.
 func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
-	// Known attachment field keys (lowercase)
-	attachmentKeys := map[string]bool{
-		"attached_molecule": true,
-		"attached-molecule": true,
-		"attachedmolecule":  true,
-		"attached_at":       true,
-		"attached-at":       true,
-		"attachedat":        true,
-		"attached_args":     true,
-		"attached-args":     true,
-		"attachedargs":      true,
-		"dispatched_by":     true,
-		"dispatched-by":     true,
-		"dispatchedby":      true,
-	}
-
-	// Collect non-attachment lines from existing description
-	var otherLines []string
-	if issue != nil && issue.Description != "" {
-		for _, line := range strings.Split(issue.Description, "\n") {
-			trimmed := strings.TrimSpace(line)
-			if trimmed == "" {
-				// Preserve blank lines in content
-				otherLines = append(otherLines, line)
-				continue
-			}
-
-			// Check if this is an attachment field line
-			colonIdx := strings.Index(trimmed, ":")
-			if colonIdx == -1 {
-				otherLines = append(otherLines, line)
-				continue
-			}
-
-			key := strings.ToLower(strings.TrimSpace(trimmed[:colonIdx]))
-			if !attachmentKeys[key] {
-				otherLines = append(otherLines, line)
-			}
-			// Skip attachment field lines - they'll be replaced
-		}
+	var desc string
+	if issue != nil {
+		desc = issue.Description
 	}
-
-	// Build new description: attachment fields first, then other content
-	formatted := FormatAttachmentFields(fields)
-
-	// Trim trailing blank lines from other content
-	for len(otherLines) > 0 && strings.TrimSpace(otherLines[len(otherLines)-1]) == "" {
-		otherLines = otherLines[:len(otherLines)-1]
-	}
-	// Trim leading blank lines from other content
-	for len(otherLines) > 0 && strings.TrimSpace(otherLines[0]) == "" {
-		otherLines = otherLines[1:]
-	}
-
-	if formatted == "" {
-		return strings.Join(otherLines, "\n")
-	}
-	if len(otherLines) == 0 {
-		return formatted
-	}
-
-	return formatted + "\n\n" + strings.Join(otherLines, "\n")
+	return replaceFieldLines(desc, FormatAttachmentFields(fields), new(AttachmentFields).set)
 }
 
 // MRFields holds the structured fields for a merge-request issue.
-// These fields are stored as key: value lines in the issue description.
+// These fields are stored in bd metadata, or as key: value lines in the
+// issue description on bd versions without metadata support.
 type MRFields struct {
 	Branch      string // Source branch name (e.g., "polecat/Nux/gt-xyz")
 	Target      string // Target branch (e.g., "main" or "integration/gt-epic")
@@ -187,79 +128,38 @@ type MRFields struct {
 	// Convoy tracking (for priority scoring - convoy starvation prevention)
 	ConvoyID        string // Parent convoy ID if part of a convoy
 	ConvoyCreatedAt string // Convoy creation time (ISO 8601) for starvation prevention
+
+	// PRURL is the GitHub pull request opened for this MR, if the rig
+	// merges through GitHub (see package ghpr).
+	PRURL string
 }
 
-// ParseMRFields extracts structured merge-request fields from an issue's description.
-// Fields are expected as "key: value" lines, with optional prose text mixed in.
+// mrKeys are the canonical MR field keys, in description order.
+var mrKeys = []string{
+	"branch", "target", "source_issue", "worker", "rig", "merge_commit", "close_reason",
+	"agent_bead", "retry_count", "last_conflict_sha", "conflict_task_id", "convoy_id", "convoy_created_at",
+	"pr_url",
+}
+
+// ParseMRFields extracts structured merge-request fields from an issue.
+// Fields are read from "key: value" description lines (prose may be mixed
+// in), then from bd metadata, which wins where both are set.
 // Returns nil if no MR fields are found.
 func ParseMRFields(issue *Issue) *MRFields {
-	if issue == nil || issue.Description == "" {
+	if issue == nil {
 		return nil
 	}
 
 	fields := &MRFields{}
 	hasFields := false
 
-	for _, line := range strings.Split(issue.Description, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// Look for "key: value" pattern
-		colonIdx := strings.Index(line, ":")
-		if colonIdx == -1 {
-			continue
-		}
-
-		key := strings.TrimSpace(line[:colonIdx])
-		value := strings.TrimSpace(line[colonIdx+1:])
-		if value == "" {
-			continue
-		}
-
-		// Map keys to fields (case-insensitive)
-		switch strings.ToLower(key) {
-		case "branch":
-			fields.Branch = value
-			hasFields = true
-		case "target":
-			fields.Target = value
-			hasFields = true
-		case "source_issue", "source-issue", "sourceissue":
-			fields.SourceIssue = value
-			hasFields = true
-		case "worker":
-			fields.Worker = value
+	for _, kv := range descriptionFields(issue.Description) {
+		if fields.set(kv[0], kv[1]) {
 			hasFields = true
-		case "rig":
-			fields.Rig = value
-			hasFields = true
-		case "merge_commit", "merge-commit", "mergecommit":
-			fields.MergeCommit = value
-			hasFields = true
-		case "close_reason", "close-reason", "closereason":
-			fields.CloseReason = value
-			hasFields = true
-		case "agent_bead", "agent-bead", "agentbead":
-			fields.AgentBead = value
-			hasFields = true
-		case "retry_count", "retry-count", "retrycount":
-			if n, err := parseIntField(value); err == nil {
-				fields.RetryCount = n
-				hasFields = true
-			}
-		case "last_conflict_sha", "last-conflict-sha", "lastconflictsha":
-			fields.LastConflictSHA = value
-			hasFields = true
-		case "conflict_task_id", "conflict-task-id", "conflicttaskid":
-			fields.ConflictTaskID = value
-			hasFields = true
-		case "convoy_id", "convoy-id", "convoyid", "convoy":
-			fields.ConvoyID = value
-			hasFields = true
-		case "convoy_created_at", "convoy-created-at", "convoycreatedat":
-			fields.ConvoyCreatedAt = value
+		}
+	}
+	for key, value := range issue.Metadata {
+		if value != "" && fields.set(key, value) {
 			hasFields = true
 		}
 	}
@@ -270,6 +170,81 @@ func ParseMRFields(issue *Issue) *MRFields {
 	return fields
 }
 
+// set assigns the field named by key (case-insensitive, any spelling) and
+// reports whether it did. A malformed retry_count is ignored.
+func (f *MRFields) set(key, value string) bool {
+	switch strings.ToLower(key) {
+	case "branch":
+		f.Branch = value
+	case "target":
+		f.Target = value
+	case "source_issue", "source-issue", "sourceissue":
+		f.SourceIssue = value
+	case "worker":
+		f.Worker = value
+	case "rig":
+		f.Rig = value
+	case "merge_commit", "merge-commit", "mergecommit":
+		f.MergeCommit = value
+	case "close_reason", "close-reason", "closereason":
+		f.CloseReason = value
+	case "agent_bead", "agent-bead", "agentbead":
+		f.AgentBead = value
+	case "retry_count", "retry-count", "retrycount":
+		n, err := parseIntField(value)
+		if err != nil {
+			return false
+		}
+		f.RetryCount = n
+	case "last_conflict_sha", "last-conflict-sha", "lastconflictsha":
+		f.LastConflictSHA = value
+	case "conflict_task_id", "conflict-task-id", "conflicttaskid":
+		f.ConflictTaskID = value
+	case "convoy_id", "convoy-id", "convoyid", "convoy":
+		f.ConvoyID = value
+	case "convoy_created_at", "convoy-created-at", "convoycreatedat":
+		f.ConvoyCreatedAt = value
+	case "pr_url", "pr-url", "prurl":
+		f.PRURL = value
+	default:
+		return false
+	}
+	return true
+}
+
+// isMRKey reports whether key names an MR field. Unlike set it also
+// matches lines whose value doesn't parse, so SetMRFields replaces them.
+func isMRKey(key, value string) bool {
+	switch strings.ToLower(key) {
+	case "retry_count", "retry-count", "retrycount":
+		return true
+	}
+	return new(MRFields).set(key, value)
+}
+
+// pairs returns the non-empty fields as canonical key/value pairs.
+func (f *MRFields) pairs() [][2]string {
+	if f == nil {
+		return nil
+	}
+	retry := ""
+	if f.RetryCount > 0 {
+		retry = strconv.Itoa(f.RetryCount)
+	}
+	values := []string{
+		f.Branch, f.Target, f.SourceIssue, f.Worker, f.Rig, f.MergeCommit, f.CloseReason,
+		f.AgentBead, retry, f.LastConflictSHA, f.ConflictTaskID, f.ConvoyID, f.ConvoyCreatedAt,
+		f.PRURL,
+	}
+	var kvs [][2]string
+	for i, value := range values {
+		if value != "" {
+			kvs = append(kvs, [2]string{mrKeys[i], value})
+		}
+	}
+	return kvs
+}
+
 // parseIntField parses an integer from a string, returning 0 on error.
 func parseIntField(s string) (int, error) {
 	var n int
@@ -280,53 +255,7 @@ func parseIntField(s string) (int, error) {
 // FormatMRFields formats MRFields as a string suitable for an issue description.
 // Only non-empty fields are included.
 func FormatMRFields(fields *MRFields) string {
-	if fields == nil {
-		return ""
-	}
-
-	var lines []string
-
-	if fields.Branch != "" {
-		lines = append(lines, "branch: "+fields.Branch)
-	}
-	if fields.Target != "" {
-		lines = append(lines, "target: "+fields.Target)
-	}
-	if fields.SourceIssue != "" {
-		lines = append(lines, "source_issue: "+fields.SourceIssue)
-	}
-	if fields.Worker != "" {
-		lines = append(lines, "worker: "+fields.Worker)
-	}
-	if fields.Rig != "" {
-		lines = append(lines, "rig: "+fields.Rig)
-	}
-	if fields.MergeCommit != "" {
-		lines = append(lines, "merge_commit: "+fields.MergeCommit)
-	}
-	if fields.CloseReason != "" {
-		lines = append(lines, "close_reason: "+fields.CloseReason)
-	}
-	if fields.AgentBead != "" {
-		lines = append(lines, "agent_bead: "+fields.AgentBead)
-	}
-	if fields.RetryCount > 0 {
-		lines = append(lines, fmt.Sprintf("retry_count: %d", fields.RetryCount))
-	}
-	if fields.LastConflictSHA != "" {
-		lines = append(lines, "last_conflict_sha: "+fields.LastConflictSHA)
-	}
-	if fields.ConflictTaskID != "" {
-		lines = append(lines, "conflict_task_id: "+fields.ConflictTaskID)
-	}
-	if fields.ConvoyID != "" {
-		lines = append(lines, "convoy_id: "+fields.ConvoyID)
-	}
-	if fields.ConvoyCreatedAt != "" {
-		lines = append(lines, "convoy_created_at: "+fields.ConvoyCreatedAt)
-	}
-
-	return strings.Join(lines, "\n")
+	return formatPairs(fields.pairs())
 }
 
 // SetMRFields updates an issue's description with the given MR fields.
@@ -336,47 +265,45 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 	if issue == nil {
 		return FormatMRFields(fields)
 	}
+	return replaceFieldLines(issue.Description, FormatMRFields(fields), isMRKey)
+}
 
-	// Known MR field keys (lowercase)
-	mrKeys := map[string]bool{
-		"branch":             true,
-		"target":             true,
-		"source_issue":       true,
-		"source-issue":       true,
-		"sourceissue":        true,
-		"worker":             true,
-		"rig":                true,
-		"merge_commit":       true,
-		"merge-commit":       true,
-		"mergecommit":        true,
-		"close_reason":       true,
-		"close-reason":       true,
-		"closereason":        true,
-		"agent_bead":         true,
-		"agent-bead":         true,
-		"agentbead":          true,
-		"retry_count":        true,
-		"retry-count":        true,
-		"retrycount":         true,
-		"last_conflict_sha":  true,
-		"last-conflict-sha":  true,
-		"lastconflictsha":    true,
-		"conflict_task_id":   true,
-		"conflict-task-id":   true,
-		"conflicttaskid":     true,
-		"convoy_id":          true,
-		"convoy-id":          true,
-		"convoyid":           true,
-		"convoy":             true,
-		"convoy_created_at":  true,
-		"convoy-created-at":  true,
-		"convoycreatedat":    true,
-	}
-
-	// Collect non-MR lines from existing description
+// descriptionFields returns the "key: value" lines of a description as
+// key/value pairs, skipping prose and empty values.
+func descriptionFields(description string) [][2]string {
+	var kvs [][2]string
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		kvs = append(kvs, [2]string{key, value})
+	}
+	return kvs
+}
+
+// formatPairs renders key/value pairs as "key: value" lines.
+func formatPairs(kvs [][2]string) string {
+	lines := make([]string, len(kvs))
+	for i, kv := range kvs {
+		lines[i] = kv[0] + ": " + kv[1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// replaceFieldLines drops the lines of description whose key isField
+// accepts and puts formatted first, separated from the remaining content
+// by a blank line.
+func replaceFieldLines(description, formatted string, isField func(key, value string) bool) string {
+	// Collect non-field lines from existing description
 	var otherLines []string
-	if issue.Description != "" {
-		for _, line := range strings.Split(issue.Description, "\n") {
+	if description != "" {
+		for _, line := range strings.Split(description, "\n") {
 			trimmed := strings.TrimSpace(line)
 			if trimmed == "" {
 				// Preserve blank lines in content
@@ -384,24 +311,14 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 				continue
 			}
 
-			// Check if this is an MR field line
-			colonIdx := strings.Index(trimmed, ":")
-			if colonIdx == -1 {
+			key, value, ok := strings.Cut(trimmed, ":")
+			if !ok || !isField(strings.TrimSpace(key), strings.TrimSpace(value)) {
 				otherLines = append(otherLines, line)
-				continue
 			}
-
-			key := strings.ToLower(strings.TrimSpace(trimmed[:colonIdx]))
-			if !mrKeys[key] {
-				otherLines = append(otherLines, line)
-			}
-			// Skip MR field lines - they'll be replaced
+			// Skip field lines - they'll be replaced
 		}
 	}
 
-	// Build new description: MR fields first, then other content
-	formatted := FormatMRFields(fields)
-
 	// Trim trailing blank lines from other content
 	for len(otherLines) > 0 && strings.TrimSpace(otherLines[len(otherLines)-1]) == "" {
 		otherLines = otherLines[:len(otherLines)-1]
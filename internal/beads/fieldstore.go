@@ -0,0 +1,206 @@
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrMetadataUnsupported is returned when metadata storage is required but
+// the installed bd has no custom field support.
+var ErrMetadataUnsupported = errors.New("bd does not support issue metadata")
+
+// Metadata holds an issue's bd custom fields. Non-string values written by
+// other tools are kept in their JSON form rather than failing the decode.
+type Metadata map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*m = nil
+		return nil
+	}
+	out := make(Metadata, len(raw))
+	for key, value := range raw {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			s = string(value)
+		}
+		out[key] = s
+	}
+	*m = out
+	return nil
+}
+
+// FieldStore selects where structured fields (attachment and MR fields)
+// are written. Reads always accept both locations.
+type FieldStore int
+
+const (
+	// FieldStoreAuto writes bd metadata, falling back to description lines
+	// once bd rejects the metadata flags.
+	FieldStoreAuto FieldStore = iota
+	// FieldStoreDescription writes "key: value" description lines only.
+	FieldStoreDescription
+	// FieldStoreMetadata writes bd metadata only and fails on older bd.
+	FieldStoreMetadata
+)
+
+// fieldState tracks metadata support across copies of a Beads.
+type fieldState struct {
+	store      FieldStore
+	noMetadata atomic.Bool // bd rejected --set-metadata
+}
+
+// WithFieldStore sets where b writes structured fields.
+func WithFieldStore(store FieldStore) Option {
+	return func(b *Beads) {
+		b.fields = &fieldState{store: store}
+	}
+}
+
+// useMetadata reports whether structured fields should go to bd metadata.
+func (b *Beads) useMetadata() bool {
+	if b.fields == nil {
+		return true
+	}
+	switch b.fields.store {
+	case FieldStoreDescription:
+		return false
+	case FieldStoreMetadata:
+		return true
+	}
+	return !b.fields.noMetadata.Load()
+}
+
+// StoreAttachmentFields replaces issue's attachment fields with fields
+// (nil clears them). issue must be freshly fetched: its description and
+// metadata are the base the update is computed from.
+func (b *Beads) StoreAttachmentFields(issue *Issue, fields *AttachmentFields) error {
+	return b.storeFields(issue, attachmentKeys, fields.pairs(),
+		SetAttachmentFields(issue, nil), SetAttachmentFields(issue, fields))
+}
+
+// StoreMRFields replaces issue's MR fields with fields (nil clears them).
+// issue must be freshly fetched, as for StoreAttachmentFields.
+func (b *Beads) StoreMRFields(issue *Issue, fields *MRFields) error {
+	return b.storeFields(issue, mrKeys, fields.pairs(),
+		SetMRFields(issue, nil), SetMRFields(issue, fields))
+}
+
+// storeFields writes kvs as metadata, unsetting the other keys and
+// stripping any description-encoded copies (stripped), or writes inline
+// as the whole description when metadata isn't available.
+func (b *Beads) storeFields(issue *Issue, keys []string, kvs [][2]string, stripped, inline string) error {
+	if issue == nil {
+		return fmt.Errorf("storing fields: nil issue")
+	}
+
+	if b.useMetadata() {
+		opts := UpdateOptions{SetMetadata: make(map[string]string, len(kvs))}
+		for _, kv := range kvs {
+			opts.SetMetadata[kv[0]] = kv[1]
+		}
+		for _, key := range keys {
+			if _, set := opts.SetMetadata[key]; !set && issue.Metadata[key] != "" {
+				opts.UnsetMetadata = append(opts.UnsetMetadata, key)
+			}
+		}
+		if stripped != issue.Description {
+			opts.Description = &stripped
+		}
+		if len(opts.SetMetadata) == 0 && len(opts.UnsetMetadata) == 0 && opts.Description == nil {
+			return nil
+		}
+
+		err := b.Update(issue.ID, opts)
+		if err == nil || !isUnknownFlag(err) {
+			return err
+		}
+		if b.fields != nil && b.fields.store == FieldStoreMetadata {
+			return ErrMetadataUnsupported
+		}
+		if b.fields != nil {
+			b.fields.noMetadata.Store(true)
+		}
+	}
+
+	if inline == issue.Description {
+		return nil
+	}
+	return b.Update(issue.ID, UpdateOptions{Description: &inline})
+}
+
+// FieldMigration describes one issue whose description-encoded fields
+// were (or, in a dry run, would be) moved into bd metadata.
+type FieldMigration struct {
+	ID    string
+	Kind  string   // "attachment" or "merge-request"
+	Keys  []string // Canonical keys moved
+	Error error    // Non-nil if the update failed
+}
+
+// MigrateFieldsToMetadata moves attachment and MR fields still encoded as
+// description lines into bd metadata, stripping them from descriptions.
+// MR fields are only migrated on merge-request issues, where "branch:"
+// and "target:" lines are known not to be prose. With dryRun nothing is
+// written. Per-issue failures are recorded in the results and don't stop
+// the migration; ErrMetadataUnsupported stops it at the first write.
+func (b *Beads) MigrateFieldsToMetadata(dryRun bool) ([]FieldMigration, error) {
+	issues, err := b.List(ListOptions{Status: "all", Priority: -1, Limit: NoLimit})
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+
+	strict := &Beads{}
+	*strict = *b
+	strict.fields = &fieldState{store: FieldStoreMetadata}
+
+	var results []FieldMigration
+	for _, issue := range issues {
+		inDesc := &Issue{Description: issue.Description}
+		var m FieldMigration
+		var store func() error
+		if issue.Type == "merge-request" {
+			if fields := ParseMRFields(inDesc); fields != nil {
+				merged := ParseMRFields(issue)
+				m = FieldMigration{ID: issue.ID, Kind: "merge-request", Keys: pairKeys(fields.pairs())}
+				store = func() error { return strict.StoreMRFields(issue, merged) }
+			}
+		}
+		if store == nil {
+			if fields := ParseAttachmentFields(inDesc); fields != nil {
+				merged := ParseAttachmentFields(issue)
+				m = FieldMigration{ID: issue.ID, Kind: "attachment", Keys: pairKeys(fields.pairs())}
+				store = func() error { return strict.StoreAttachmentFields(issue, merged) }
+			}
+		}
+		if store == nil {
+			continue
+		}
+
+		if !dryRun {
+			if err := store(); err != nil {
+				if errors.Is(err, ErrMetadataUnsupported) {
+					return results, err
+				}
+				m.Error = err
+			}
+		}
+		results = append(results, m)
+	}
+	return results, nil
+}
+
+func pairKeys(kvs [][2]string) []string {
+	keys := make([]string, len(kvs))
+	for i, kv := range kvs {
+		keys[i] = kv[0]
+	}
+	return keys
+}
@@ -0,0 +1,108 @@
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func bdLog(t *testing.T) string {
+	t.Helper()
+	bdPath, err := exec.LookPath("bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "log"))
+	return string(data)
+}
+
+func TestParseFieldsPrefersMetadata(t *testing.T) {
+	var issue Issue
+	data := `{"id":"gt-1","description":"branch: old\ntarget: main\nNote: prose with a colon",` +
+		`"metadata":{"branch":"polecat/new","retry_count":2,"attached_molecule":"gt-mol"}}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatal(err)
+	}
+
+	mr := ParseMRFields(&issue)
+	if mr == nil || mr.Branch != "polecat/new" || mr.Target != "main" || mr.RetryCount != 2 {
+		t.Errorf("ParseMRFields = %+v", mr)
+	}
+	if att := ParseAttachmentFields(&issue); att == nil || att.AttachedMolecule != "gt-mol" {
+		t.Errorf("ParseAttachmentFields = %+v", att)
+	}
+}
+
+func TestStoreFieldsFallsBackToDescription(t *testing.T) {
+	installFakeBd(t, `
+echo "$@" >> "$(dirname "$0")/log"
+for a in "$@"; do
+  case "$a" in --set-metadata=*) echo "Error: unknown flag: --set-metadata" >&2; exit 1;; esac
+done
+`)
+	b := New(t.TempDir())
+	issue := &Issue{ID: "gt-1", Description: "Some notes."}
+
+	for i := 0; i < 2; i++ {
+		if err := b.StoreAttachmentFields(issue, &AttachmentFields{AttachedMolecule: "gt-mol"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	log := bdLog(t)
+	if n := strings.Count(log, "--set-metadata"); n != 1 {
+		t.Errorf("metadata tried %d times, want 1 (support should be remembered):\n%s", n, log)
+	}
+	if !strings.Contains(log, "update gt-1 --description=attached_molecule: gt-mol\n\nSome notes.") {
+		t.Errorf("no description fallback:\n%s", log)
+	}
+
+	strict := New(t.TempDir(), WithFieldStore(FieldStoreMetadata))
+	if err := strict.StoreAttachmentFields(issue, &AttachmentFields{AttachedMolecule: "gt-mol"}); !errors.Is(err, ErrMetadataUnsupported) {
+		t.Errorf("strict store err = %v, want ErrMetadataUnsupported", err)
+	}
+}
+
+func TestMigrateFieldsToMetadata(t *testing.T) {
+	installFakeBd(t, `
+echo "$@" >> "$(dirname "$0")/log"
+case "$2" in
+list)
+  printf '%s\n' '[{"id":"gt-1","issue_type":"merge-request","description":"branch: polecat/a\ntarget: main"},` +
+		`{"id":"gt-2","issue_type":"task","description":"branch: prose, not a field"},` +
+		`{"id":"gt-3","issue_type":"task","description":"attached_molecule: gt-mol\n\nHandoff notes."}]'
+  ;;
+esac
+`)
+	b := New(t.TempDir())
+
+	dry, err := b.MigrateFieldsToMetadata(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dry) != 2 || dry[0].ID != "gt-1" || dry[0].Kind != "merge-request" || dry[1].ID != "gt-3" || dry[1].Kind != "attachment" {
+		t.Errorf("dry run = %+v", dry)
+	}
+	if log := bdLog(t); strings.Contains(log, "update") {
+		t.Errorf("dry run wrote:\n%s", log)
+	}
+
+	if _, err := b.MigrateFieldsToMetadata(false); err != nil {
+		t.Fatal(err)
+	}
+	log := bdLog(t)
+	for _, want := range []string{
+		"update gt-1 --description= --set-metadata=branch=polecat/a --set-metadata=target=main",
+		"update gt-3 --description=Handoff notes. --set-metadata=attached_molecule=gt-mol",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("bd log missing %q:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, "update gt-2") {
+		t.Errorf("migrated prose on a non-MR issue:\n%s", log)
+	}
+}
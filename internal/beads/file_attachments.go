@@ -0,0 +1,242 @@
+package beads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AttachmentsDirName is the directory under .beads/ holding attached
+// files, one subdirectory per bead.
+const AttachmentsDirName = "attachments"
+
+// fileAttachmentsKey is the structured field listing a bead's attached
+// files, as a JSON array.
+const fileAttachmentsKey = "attachments"
+
+var (
+	// ErrAttachmentNotFound is returned when a bead has no attachment of
+	// the requested name.
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrAttachmentCorrupt is returned when an attached file is missing or
+	// no longer matches its recorded checksum.
+	ErrAttachmentCorrupt = errors.New("attachment corrupt")
+)
+
+// FileAttachment is a file attached to a bead (a log, a diff), stored
+// under .beads/attachments/<bead-id>/. Not to be confused with
+// AttachmentFields, which attach molecules to pinned beads.
+type FileAttachment struct {
+	Name       string    `json:"name"`
+	Path       string    `json:"path"` // Relative to the .beads directory
+	SHA256     string    `json:"sha256"`
+	Size       int64     `json:"size"`
+	AttachedAt time.Time `json:"attached_at"`
+}
+
+// ParseFileAttachments returns the files attached to an issue, read from
+// its description's attachments line or from bd metadata, which wins.
+func ParseFileAttachments(issue *Issue) []FileAttachment {
+	if issue == nil {
+		return nil
+	}
+	var list []FileAttachment
+	for _, kv := range descriptionFields(issue.Description) {
+		if parsed, ok := parseFileAttachments(kv[0], kv[1]); ok {
+			list = parsed
+		}
+	}
+	if parsed, ok := parseFileAttachments(fileAttachmentsKey, issue.Metadata[fileAttachmentsKey]); ok {
+		list = parsed
+	}
+	return list
+}
+
+// parseFileAttachments decodes an attachments field; ok is false for any
+// other key, or a value that isn't an attachment list (prose).
+func parseFileAttachments(key, value string) ([]FileAttachment, bool) {
+	if !strings.EqualFold(key, fileAttachmentsKey) || !strings.HasPrefix(value, "[") {
+		return nil, false
+	}
+	var list []FileAttachment
+	if err := json.Unmarshal([]byte(value), &list); err != nil {
+		return nil, false
+	}
+	return list, true
+}
+
+// isFileAttachmentsField reports whether a description line holds the
+// attachments field.
+func isFileAttachmentsField(key, value string) bool {
+	_, ok := parseFileAttachments(key, value)
+	return ok
+}
+
+// attachmentsDir returns the directory holding id's attached files.
+func (b *Beads) attachmentsDir(id string) (string, error) {
+	if id == "" || id != filepath.Base(id) || id == "." || id == ".." {
+		return "", fmt.Errorf("invalid bead ID %q", id)
+	}
+	beadsDir := b.beadsDir
+	if beadsDir == "" {
+		beadsDir = ResolveBeadsDir(b.workDir)
+	}
+	return filepath.Join(beadsDir, AttachmentsDirName, id), nil
+}
+
+// AttachFile copies the file at path into the bead's attachments and
+// records its name, size and checksum on the bead. Attaching a file
+// whose name is already attached replaces it.
+func (b *Beads) AttachFile(id, path string) (*FileAttachment, error) {
+	dir, err := b.attachmentsDir(id)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := b.Show(id)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(path)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating attachments directory: %w", err)
+	}
+	sum, size, err := copyAttachment(path, filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	att := FileAttachment{
+		Name:       name,
+		Path:       filepath.ToSlash(filepath.Join(AttachmentsDirName, id, name)),
+		SHA256:     sum,
+		Size:       size,
+		AttachedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	list := []FileAttachment{att}
+	for _, existing := range ParseFileAttachments(issue) {
+		if existing.Name != name {
+			list = append(list, existing)
+		}
+	}
+	if err := b.storeFileAttachments(issue, list); err != nil {
+		return nil, fmt.Errorf("recording attachment on %s: %w", id, err)
+	}
+	return &att, nil
+}
+
+// copyAttachment copies src to dst through a temp file, so a failed copy
+// never leaves a partial attachment, and returns the file's SHA-256 and
+// size.
+func copyAttachment(src, dst string) (string, int64, error) {
+	in, err := os.Open(src) //nolint:gosec // G304: attaching a caller-chosen file is the point
+	if err != nil {
+		return "", 0, fmt.Errorf("opening %s: %w", src, err)
+	}
+	defer in.Close()
+	if info, err := in.Stat(); err == nil && info.IsDir() {
+		return "", 0, fmt.Errorf("%s is a directory", src)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".attach-*")
+	if err != nil {
+		return "", 0, fmt.Errorf("creating attachment: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	size, copyErr := io.Copy(io.MultiWriter(tmp, h), in)
+	closeErr := tmp.Close()
+	if copyErr != nil || closeErr != nil {
+		return "", 0, fmt.Errorf("copying %s: %v", src, firstErr(copyErr, closeErr))
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return "", 0, fmt.Errorf("storing attachment: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// storeFileAttachments replaces the issue's attachments field with list,
+// oldest first, in bd metadata or as a description line.
+func (b *Beads) storeFileAttachments(issue *Issue, list []FileAttachment) error {
+	sorted := append([]FileAttachment(nil), list...)
+	sortFileAttachments(sorted)
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return err
+	}
+	kv := [2]string{fileAttachmentsKey, string(data)}
+	return b.storeFields(issue, []string{fileAttachmentsKey}, [][2]string{kv},
+		replaceFieldLines(issue.Description, "", isFileAttachmentsField),
+		replaceFieldLines(issue.Description, formatPairs([][2]string{kv}), isFileAttachmentsField))
+}
+
+// sortFileAttachments orders attachments oldest first, then by name.
+func sortFileAttachments(list []FileAttachment) {
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].AttachedAt.Equal(list[j].AttachedAt) {
+			return list[i].AttachedAt.Before(list[j].AttachedAt)
+		}
+		return list[i].Name < list[j].Name
+	})
+}
+
+// ListAttachments returns the files attached to a bead, oldest first.
+func (b *Beads) ListAttachments(id string) ([]FileAttachment, error) {
+	issue, err := b.Show(id)
+	if err != nil {
+		return nil, err
+	}
+	list := ParseFileAttachments(issue)
+	sortFileAttachments(list)
+	return list, nil
+}
+
+// FetchAttachment returns the local path of a bead's attached file, after
+// checking it still matches the checksum recorded when it was attached.
+func (b *Beads) FetchAttachment(id, name string) (string, error) {
+	list, err := b.ListAttachments(id)
+	if err != nil {
+		return "", err
+	}
+	dir, err := b.attachmentsDir(id)
+	if err != nil {
+		return "", err
+	}
+	for _, att := range list {
+		if att.Name != name {
+			continue
+		}
+		path := filepath.Join(dir, filepath.Base(att.Name))
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s on %s: %v", ErrAttachmentCorrupt, name, id, err)
+		}
+		if sum != att.SHA256 {
+			return "", fmt.Errorf("%w: %s on %s: checksum %s, recorded %s", ErrAttachmentCorrupt, name, id, sum, att.SHA256)
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("%w: %s on %s", ErrAttachmentNotFound, name, id)
+}
+
+// fileSHA256 returns the hex SHA-256 of a file's contents.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,104 @@
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeShowFile returns the file the fake bd of the attachment and handoff
+// tests prints for show or list.
+func fakeShowFile(t *testing.T) string {
+	t.Helper()
+	bdPath, err := exec.LookPath("bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(filepath.Dir(bdPath), "show.json")
+}
+
+func TestAttachFile(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+echo "$@" >> "$dir/log"
+case "$*" in
+*"show gt-1"*) if [ -f "$dir/show.json" ]; then cat "$dir/show.json"; else echo '[{"id":"gt-1","description":"Fix the build."}]'; fi;;
+esac
+`)
+	workDir := t.TempDir()
+	b := New(workDir)
+	src := filepath.Join(t.TempDir(), "build.log")
+	if err := os.WriteFile(src, []byte("FAIL\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	att, err := b.AttachFile("gt-1", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if att.Name != "build.log" || att.Path != "attachments/gt-1/build.log" || att.Size != 5 || len(att.SHA256) != 64 {
+		t.Errorf("attachment = %+v", att)
+	}
+	stored := filepath.Join(workDir, ".beads", "attachments", "gt-1", "build.log")
+	if data, err := os.ReadFile(stored); err != nil || string(data) != "FAIL\n" {
+		t.Fatalf("stored copy = %q, %v", data, err)
+	}
+	if log := bdLog(t); !strings.Contains(log, `update gt-1 --set-metadata=attachments=[{"name":"build.log"`) {
+		t.Errorf("attachment not recorded:\n%s", log)
+	}
+	if _, err := b.AttachFile("gt-1", filepath.Dir(src)); err == nil {
+		t.Error("attached a directory")
+	}
+	if _, err := b.AttachFile("../gt-1", src); err == nil {
+		t.Error("attached to a bead ID with a path")
+	}
+
+	// Read back what was recorded
+	list, _ := json.Marshal([]FileAttachment{*att})
+	issue, _ := json.Marshal([]Issue{{ID: "gt-1", Metadata: Metadata{"attachments": string(list)}}})
+	if err := os.WriteFile(fakeShowFile(t), issue, 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := b.ListAttachments("gt-1")
+	if err != nil || len(got) != 1 || got[0].SHA256 != att.SHA256 {
+		t.Fatalf("ListAttachments = %+v, %v", got, err)
+	}
+	if path, err := b.FetchAttachment("gt-1", "build.log"); err != nil || path != stored {
+		t.Errorf("FetchAttachment = %q, %v", path, err)
+	}
+	if _, err := b.FetchAttachment("gt-1", "other.log"); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("FetchAttachment(unknown) = %v, want ErrAttachmentNotFound", err)
+	}
+	if err := os.WriteFile(stored, []byte("PASS\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.FetchAttachment("gt-1", "build.log"); !errors.Is(err, ErrAttachmentCorrupt) {
+		t.Errorf("FetchAttachment(modified) = %v, want ErrAttachmentCorrupt", err)
+	}
+}
+
+func TestFileAttachmentsInDescription(t *testing.T) {
+	installFakeBd(t, `echo "$@" >> "$(dirname "$0")/log"`)
+	b := New(t.TempDir(), WithFieldStore(FieldStoreDescription))
+	issue := &Issue{ID: "gt-1", Description: "Attachments: see below\n\nNotes."}
+	list := []FileAttachment{{Name: "a.diff", Path: "attachments/gt-1/a.diff", SHA256: "ab", Size: 2}}
+
+	if err := b.storeFileAttachments(issue, list); err != nil {
+		t.Fatal(err)
+	}
+	log := bdLog(t)
+	// Prose that merely starts with "Attachments:" is kept
+	if !strings.Contains(log, `--description=attachments: [{"name":"a.diff"`) || !strings.Contains(log, "Attachments: see below") {
+		t.Fatalf("description not written:\n%s", log)
+	}
+
+	issue.Description = `attachments: [{"name":"a.diff","sha256":"ab"}]` + "\n\nAttachments: see below"
+	got := ParseFileAttachments(issue)
+	if len(got) != 1 || got[0].Name != "a.diff" {
+		t.Errorf("ParseFileAttachments = %+v", got)
+	}
+}
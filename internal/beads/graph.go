@@ -0,0 +1,237 @@
+package beads
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrCycle is returned by TopologicalOrder when dependencies form a cycle.
+var ErrCycle = errors.New("dependency cycle")
+
+// Graph is an in-memory dependency graph built from List output.
+// An edge A → B means A depends on (is blocked by) B. Edges come from
+// depends_on, blocked_by, blocks, and blocking entries in dependencies
+// (see IssueDep.Blocking); parent/child and other soft links are not
+// dependencies.
+type Graph struct {
+	issues     map[string]*Issue
+	deps       map[string]map[string]bool // id → ids it depends on
+	dependents map[string]map[string]bool // id → ids depending on it
+}
+
+// Graph loads every issue and builds its dependency graph.
+func (b *Beads) Graph() (*Graph, error) {
+	issues, err := b.List(ListOptions{Status: "all", Priority: PriorityUnset, Limit: NoLimit})
+	if err != nil {
+		return nil, err
+	}
+	return NewGraph(issues), nil
+}
+
+// NewGraph builds a dependency graph from issues. Edges to IDs not in
+// issues are kept, so traversals report them, but those IDs have no
+// further edges.
+func NewGraph(issues []*Issue) *Graph {
+	g := &Graph{
+		issues:     make(map[string]*Issue, len(issues)),
+		deps:       make(map[string]map[string]bool),
+		dependents: make(map[string]map[string]bool),
+	}
+	for _, issue := range issues {
+		g.issues[issue.ID] = issue
+	}
+	for _, issue := range issues {
+		for _, id := range issue.DependsOn {
+			g.addEdge(issue.ID, id)
+		}
+		for _, id := range issue.BlockedBy {
+			g.addEdge(issue.ID, id)
+		}
+		for _, id := range issue.Blocks {
+			g.addEdge(id, issue.ID)
+		}
+		for _, dep := range issue.Dependencies {
+			if dep.Blocking() {
+				g.addEdge(issue.ID, dep.ID)
+			}
+		}
+	}
+	return g
+}
+
+func (g *Graph) addEdge(from, to string) {
+	if g.deps[from] == nil {
+		g.deps[from] = make(map[string]bool)
+	}
+	if g.dependents[to] == nil {
+		g.dependents[to] = make(map[string]bool)
+	}
+	g.deps[from][to] = true
+	g.dependents[to][from] = true
+}
+
+// Issue returns the issue with the given ID, or nil if it isn't in the graph.
+func (g *Graph) Issue(id string) *Issue {
+	return g.issues[id]
+}
+
+// Dependencies returns the direct dependencies of id, sorted.
+func (g *Graph) Dependencies(id string) []string {
+	return sortedKeys(g.deps[id])
+}
+
+// Dependents returns the issues directly depending on id, sorted.
+func (g *Graph) Dependents(id string) []string {
+	return sortedKeys(g.dependents[id])
+}
+
+// TransitiveDependencies returns everything id depends on, directly or
+// indirectly, sorted. This is the full chain that must close before id
+// is unblocked.
+func (g *Graph) TransitiveDependencies(id string) []string {
+	return g.reach(id, g.deps)
+}
+
+// TransitiveDependents returns everything that depends on id, directly or
+// indirectly, sorted: the work unblocked (in part) by closing id.
+func (g *Graph) TransitiveDependents(id string) []string {
+	return g.reach(id, g.dependents)
+}
+
+func (g *Graph) reach(start string, edges map[string]map[string]bool) []string {
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	var out []string
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for next := range edges[id] {
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			out = append(out, next)
+			queue = append(queue, next)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// DetectCycles returns each dependency cycle as a sorted list of IDs
+// (strongly connected components with more than one node, or a node that
+// depends on itself). Cycles are ordered by their first ID.
+func (g *Graph) DetectCycles() [][]string {
+	// Tarjan's algorithm
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range sortedKeys(g.deps[v]) {
+			if _, ok := indices[w]; !ok {
+				strongConnect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], indices[w])
+			}
+		}
+
+		if lowlink[v] == indices[v] {
+			var scc []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			if len(scc) > 1 || g.deps[v][v] {
+				sort.Strings(scc)
+				cycles = append(cycles, scc)
+			}
+		}
+	}
+
+	for _, v := range g.nodeIDs() {
+		if _, ok := indices[v]; !ok {
+			strongConnect(v)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// TopologicalOrder returns all node IDs with every issue after the issues
+// it depends on. Ties are broken by ID so the order is stable. Returns
+// ErrCycle (and no order) if the graph has a cycle.
+func (g *Graph) TopologicalOrder() ([]string, error) {
+	nodes := g.nodeIDs()
+	remaining := make(map[string]int, len(nodes))
+	var ready []string
+	for _, id := range nodes {
+		remaining[id] = len(g.deps[id])
+		if remaining[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]string, 0, len(nodes))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		for dependent := range g.dependents[id] {
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(nodes) {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
+
+// nodeIDs returns every ID in the graph, including edge targets not in
+// the issue list, sorted.
+func (g *Graph) nodeIDs() []string {
+	all := make(map[string]bool, len(g.issues))
+	for id := range g.issues {
+		all[id] = true
+	}
+	for id, deps := range g.deps {
+		all[id] = true
+		for dep := range deps {
+			all[dep] = true
+		}
+	}
+	return sortedKeys(all)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
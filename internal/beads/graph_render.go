@@ -0,0 +1,149 @@
+package beads
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// statusColors are the fill colors RenderDOT and RenderMermaid give each
+// status. Anything else, including IDs outside the graph, is drawn grey.
+var statusColors = map[string]string{
+	"open":        "#ffffff",
+	"in_progress": "#fff3b0",
+	"hooked":      "#fff3b0",
+	"blocked":     "#f8c4c4",
+	"deferred":    "#e0e0e0",
+	"closed":      "#c8e6c9",
+}
+
+const unknownColor = "#eeeeee"
+
+// EpicGraph builds the dependency graph of an epic and everything filed
+// under it, descending through parent links one list call per level.
+// Dependencies on issues outside the epic stay as edges to bare IDs.
+func (b *Beads) EpicGraph(epicID string) (*Graph, error) {
+	epic, err := b.Show(epicID)
+	if err != nil {
+		return nil, err
+	}
+	issues := []*Issue{epic}
+	seen := map[string]bool{epicID: true}
+	for level := []string{epicID}; len(level) > 0; {
+		var next []string
+		for _, id := range level {
+			children, err := b.List(ListOptions{Parent: id, Status: "all", Priority: PriorityUnset, Limit: NoLimit})
+			if err != nil {
+				return nil, fmt.Errorf("listing children of %s: %w", id, err)
+			}
+			for _, child := range children {
+				if seen[child.ID] {
+					continue
+				}
+				seen[child.ID] = true
+				issues = append(issues, child)
+				next = append(next, child.ID)
+			}
+		}
+		level = next
+	}
+	return NewGraph(issues), nil
+}
+
+// Subgraph returns the graph restricted to ids: their issues and the
+// edges between them.
+func (g *Graph) Subgraph(ids []string) *Graph {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+	sub := &Graph{
+		issues:     make(map[string]*Issue),
+		deps:       make(map[string]map[string]bool),
+		dependents: make(map[string]map[string]bool),
+	}
+	for _, id := range ids {
+		if issue, ok := g.issues[id]; ok {
+			sub.issues[id] = issue
+		}
+		for dep := range g.deps[id] {
+			if keep[dep] {
+				sub.addEdge(id, dep)
+			}
+		}
+	}
+	return sub
+}
+
+// RenderDOT writes the graph in Graphviz DOT. Arrows run from a blocker
+// to the work it blocks; nodes are filled by status.
+func (g *Graph) RenderDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph beads {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, `  node [shape=box, style="rounded,filled", fontname="Helvetica"];`)
+	for _, id := range g.nodeIDs() {
+		fmt.Fprintf(bw, "  %s [label=%s, fillcolor=%q];\n", dotQuote(id), dotQuote(g.label(id, `\n`)), g.color(id))
+	}
+	for _, id := range g.nodeIDs() {
+		for _, dep := range sortedKeys(g.deps[id]) {
+			fmt.Fprintf(bw, "  %s -> %s;\n", dotQuote(dep), dotQuote(id))
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// RenderMermaid writes the graph as a Mermaid flowchart, with the same
+// arrow direction and coloring as RenderDOT.
+func (g *Graph) RenderMermaid(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "flowchart LR")
+	nodes := g.nodeIDs()
+	names := make(map[string]string, len(nodes))
+	for i, id := range nodes {
+		names[id] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(bw, "  %s[\"%s\"]\n", names[id], mermaidEscape(g.label(id, "<br/>")))
+	}
+	for _, id := range nodes {
+		for _, dep := range sortedKeys(g.deps[id]) {
+			fmt.Fprintf(bw, "  %s --> %s\n", names[dep], names[id])
+		}
+	}
+	for _, id := range nodes {
+		fmt.Fprintf(bw, "  style %s fill:%s,stroke:#555\n", names[id], g.color(id))
+	}
+	return bw.Flush()
+}
+
+// label is an issue's ID and title, joined by sep.
+func (g *Graph) label(id, sep string) string {
+	issue := g.issues[id]
+	if issue == nil || issue.Title == "" {
+		return id
+	}
+	return id + sep + issue.Title
+}
+
+func (g *Graph) color(id string) string {
+	if issue := g.issues[id]; issue != nil {
+		if c, ok := statusColors[issue.Status]; ok {
+			return c
+		}
+	}
+	return unknownColor
+}
+
+// dotQuote quotes s as a DOT string, keeping \n line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", " ")
+	return `"` + s + `"`
+}
+
+// mermaidEscape makes s safe inside a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	return strings.ReplaceAll(s, "\n", " ")
+}
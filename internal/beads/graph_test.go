@@ -0,0 +1,110 @@
+package beads
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGraphTraversal(t *testing.T) {
+	// epic depends on a and b; a depends on c; d blocks b (via Blocks).
+	g := NewGraph([]*Issue{
+		{ID: "epic", DependsOn: []string{"a", "b"}},
+		{ID: "a", BlockedBy: []string{"c"}},
+		{ID: "b"},
+		{ID: "c"},
+		{ID: "d", Blocks: []string{"b"}},
+		{ID: "e", Dependencies: []IssueDep{{ID: "a", DependencyType: "parent-child"}}},
+		{ID: "f", Dependencies: []IssueDep{{ID: "a", DependencyType: DepRelated}, {ID: "b", DependencyType: DepDiscoveredFrom}, {ID: "d"}}},
+	})
+
+	if got, want := g.TransitiveDependencies("epic"), []string{"a", "b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TransitiveDependencies(epic) = %v, want %v", got, want)
+	}
+	if got, want := g.TransitiveDependents("c"), []string{"a", "epic"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TransitiveDependents(c) = %v, want %v", got, want)
+	}
+	if got := g.Dependencies("e"); got != nil {
+		t.Errorf("parent-child link treated as dependency: %v", got)
+	}
+	if got, want := g.Dependencies("f"), []string{"d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependencies(f) = %v, want only the untyped blocker %v", got, want)
+	}
+	if cycles := g.DetectCycles(); len(cycles) != 0 {
+		t.Errorf("DetectCycles = %v, want none", cycles)
+	}
+
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pos := make(map[string]int)
+	for i, id := range order {
+		pos[id] = i
+	}
+	for _, edge := range [][2]string{{"epic", "a"}, {"epic", "b"}, {"a", "c"}, {"b", "d"}} {
+		if pos[edge[0]] < pos[edge[1]] {
+			t.Errorf("%s ordered before its dependency %s: %v", edge[0], edge[1], order)
+		}
+	}
+}
+
+func TestGraphCycles(t *testing.T) {
+	g := NewGraph([]*Issue{
+		{ID: "a", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"c"}},
+		{ID: "c", DependsOn: []string{"a"}},
+		{ID: "d", DependsOn: []string{"d"}},
+		{ID: "e", DependsOn: []string{"a"}},
+	})
+
+	want := [][]string{{"a", "b", "c"}, {"d"}}
+	if got := g.DetectCycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectCycles = %v, want %v", got, want)
+	}
+	if _, err := g.TopologicalOrder(); !errors.Is(err, ErrCycle) {
+		t.Errorf("TopologicalOrder err = %v, want ErrCycle", err)
+	}
+}
+
+func TestGraphRender(t *testing.T) {
+	g := NewGraph([]*Issue{
+		{ID: "gt-a", Title: `Say "hi"`, Status: "closed"},
+		{ID: "gt-b", Title: "Build", Status: "in_progress", DependsOn: []string{"gt-a", "gt-ext"}},
+		{ID: "gt-c", Status: "open", DependsOn: []string{"gt-b"}},
+	}).Subgraph([]string{"gt-a", "gt-b", "gt-ext"})
+
+	var dot strings.Builder
+	if err := g.RenderDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"gt-a" [label="gt-a\nSay \"hi\"", fillcolor="#c8e6c9"];`,
+		`"gt-ext" [label="gt-ext", fillcolor="#eeeeee"];`,
+		`"gt-a" -> "gt-b";`,
+		`"gt-ext" -> "gt-b";`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT missing %s:\n%s", want, dot.String())
+		}
+	}
+	if strings.Contains(dot.String(), "gt-c") {
+		t.Errorf("Subgraph kept gt-c:\n%s", dot.String())
+	}
+
+	var mm strings.Builder
+	if err := g.RenderMermaid(&mm); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"flowchart LR",
+		`n0["gt-a<br/>Say #quot;hi#quot;"]`,
+		"n0 --> n1",
+		"style n1 fill:#fff3b0",
+	} {
+		if !strings.Contains(mm.String(), want) {
+			t.Errorf("Mermaid missing %s:\n%s", want, mm.String())
+		}
+	}
+}
@@ -4,6 +4,8 @@ package beads
 import (
 	"fmt"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 // StatusPinned is the status for pinned beads that never get closed.
@@ -52,7 +54,7 @@ func (b *Beads) GetOrCreateHandoffBead(role string) (*Issue, error) {
 	issue, err := b.Create(CreateOptions{
 		Title:       HandoffBeadTitle(role),
 		Type:        "task",
-		Priority:    2,
+		Priority:    PriorityMedium,
 		Description: "", // Empty until first handoff
 		Actor:       role,
 	})
@@ -71,16 +73,25 @@ func (b *Beads) GetOrCreateHandoffBead(role string) (*Issue, error) {
 }
 
 // UpdateHandoffContent updates the handoff bead's description with new content.
+// The content it replaces and the new content are retained as handoff
+// versions (see HandoffDiff).
 func (b *Beads) UpdateHandoffContent(role, content string) error {
 	issue, err := b.GetOrCreateHandoffBead(role)
 	if err != nil {
 		return err
 	}
+	if err := b.recordHandoffVersion(role, issue.ID, issue.Description); err != nil {
+		return fmt.Errorf("retaining handoff version: %w", err)
+	}
 
-	return b.Update(issue.ID, UpdateOptions{Description: &content})
+	if err := b.Update(issue.ID, UpdateOptions{Description: &content}); err != nil {
+		return err
+	}
+	return b.recordHandoffVersion(role, issue.ID, content)
 }
 
-// ClearHandoffContent clears the handoff bead's description.
+// ClearHandoffContent clears the handoff bead's description, retaining the
+// cleared content as a handoff version.
 func (b *Beads) ClearHandoffContent(role string) error {
 	issue, err := b.FindHandoffBead(role)
 	if err != nil {
@@ -89,9 +100,15 @@ func (b *Beads) ClearHandoffContent(role string) error {
 	if issue == nil {
 		return nil // Nothing to clear
 	}
+	if err := b.recordHandoffVersion(role, issue.ID, issue.Description); err != nil {
+		return fmt.Errorf("retaining handoff version: %w", err)
+	}
 
 	empty := ""
-	return b.Update(issue.ID, UpdateOptions{Description: &empty})
+	if err := b.Update(issue.ID, UpdateOptions{Description: &empty}); err != nil {
+		return err
+	}
+	return b.recordHandoffVersion(role, issue.ID, empty)
 }
 
 // ClearMailResult contains statistics from a ClearMail operation.
@@ -107,7 +124,7 @@ func (b *Beads) ClearMail(reason string) (*ClearMailResult, error) {
 	// List all open messages
 	issues, err := b.List(ListOptions{
 		Status:   "open",
-		Type:     "message",
+		Type:     TypeMessage,
 		Priority: -1,
 	})
 	if err != nil {
@@ -136,14 +153,16 @@ func (b *Beads) ClearMail(reason string) (*ClearMailResult, error) {
 		result.Closed = len(toClose)
 	}
 
-	// Clear pinned messages
+	// Clear pinned messages in one batch
 	empty := ""
+	updates := make(map[string]UpdateOptions, len(toClear))
 	for _, issue := range toClear {
-		if err := b.Update(issue.ID, UpdateOptions{Description: &empty}); err != nil {
-			return nil, fmt.Errorf("clearing pinned message %s: %w", issue.ID, err)
-		}
-		result.Cleared++
+		updates[issue.ID] = UpdateOptions{Description: &empty}
+	}
+	if err := b.UpdateBatch(updates); err != nil {
+		return nil, fmt.Errorf("clearing pinned messages: %w", err)
 	}
+	result.Cleared = len(toClear)
 
 	return result, nil
 }
@@ -168,11 +187,8 @@ func (b *Beads) AttachMolecule(pinnedBeadID, moleculeID string) (*Issue, error)
 		AttachedAt:       currentTimestamp(),
 	}
 
-	// Update description with attachment fields
-	newDesc := SetAttachmentFields(issue, fields)
-
-	// Update the issue
-	if err := b.Update(pinnedBeadID, UpdateOptions{Description: &newDesc}); err != nil {
+	// Store attachment fields on the issue
+	if err := b.StoreAttachmentFields(issue, fields); err != nil {
 		return nil, fmt.Errorf("updating pinned bead: %w", err)
 	}
 
@@ -183,6 +199,10 @@ func (b *Beads) AttachMolecule(pinnedBeadID, moleculeID string) (*Issue, error)
 // DetachMolecule removes molecule attachment from a pinned bead.
 // Returns the updated issue.
 func (b *Beads) DetachMolecule(pinnedBeadID string) (*Issue, error) {
+	if err := checkPermission(config.OpDetachMolecule, pinnedBeadID); err != nil {
+		return nil, err
+	}
+
 	// Fetch the pinned bead
 	issue, err := b.Show(pinnedBeadID)
 	if err != nil {
@@ -195,10 +215,7 @@ func (b *Beads) DetachMolecule(pinnedBeadID string) (*Issue, error) {
 	}
 
 	// Clear attachment fields by passing nil
-	newDesc := SetAttachmentFields(issue, nil)
-
-	// Update the issue
-	if err := b.Update(pinnedBeadID, UpdateOptions{Description: &newDesc}); err != nil {
+	if err := b.StoreAttachmentFields(issue, nil); err != nil {
 		return nil, fmt.Errorf("updating pinned bead: %w", err)
 	}
 
@@ -0,0 +1,203 @@
+package beads
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/flock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// HandoffVersionsDirName is the directory under .beads/ holding each
+// role's handoff versions, one JSONL file per role.
+const HandoffVersionsDirName = "handoffs"
+
+// ErrHandoffVersionNotFound is returned when a role has no retained
+// handoff version of the requested number.
+var ErrHandoffVersionNotFound = errors.New("handoff version not found")
+
+// HandoffVersion is one retained version of a role's handoff content.
+type HandoffVersion struct {
+	Version   int       `json:"version"`
+	Timestamp time.Time `json:"ts"`
+	BeadID    string    `json:"bead,omitempty"`
+	Content   string    `json:"content"`
+}
+
+// handoffVersionsPath returns the file holding role's handoff versions.
+func (b *Beads) handoffVersionsPath(role string) string {
+	beadsDir := b.beadsDir
+	if beadsDir == "" {
+		beadsDir = ResolveBeadsDir(b.workDir)
+	}
+	name := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(role)
+	return filepath.Join(beadsDir, HandoffVersionsDirName, name+".jsonl")
+}
+
+// readHandoffVersions returns every retained version of role's handoff,
+// oldest first. Unreadable lines are skipped.
+func readHandoffVersions(path string) ([]HandoffVersion, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var versions []HandoffVersion
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var v HandoffVersion
+		if err := json.Unmarshal(scanner.Bytes(), &v); err != nil {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	return versions, scanner.Err()
+}
+
+// recordHandoffVersion appends content as role's next handoff version,
+// unless it is what the latest version already holds.
+func (b *Beads) recordHandoffVersion(role, beadID, content string) error {
+	path := b.handoffVersionsPath(role)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating handoff versions directory: %w", err)
+	}
+	return flock.With(path, flock.DefaultTimeout, func() error {
+		versions, err := readHandoffVersions(path)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 && content == "" {
+			return nil // Nothing to retain yet
+		}
+		next := 1
+		if n := len(versions); n > 0 {
+			if versions[n-1].Content == content {
+				return nil
+			}
+			next = versions[n-1].Version + 1
+		}
+		line, err := json.Marshal(HandoffVersion{Version: next, Timestamp: time.Now().UTC(), BeadID: beadID, Content: content})
+		if err != nil {
+			return err
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: handoff notes are non-sensitive
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	})
+}
+
+// RecordHandoffVersion retains the current content of role's handoff bead
+// as a version, if it changed since the last one. Content written past
+// UpdateHandoffContent (bd update on the bead) is captured this way at
+// session boundaries. A role without a handoff bead records nothing.
+func (b *Beads) RecordHandoffVersion(role string) error {
+	issue, err := b.FindHandoffBead(role)
+	if err != nil || issue == nil {
+		return err
+	}
+	return b.recordHandoffVersion(role, issue.ID, issue.Description)
+}
+
+// SnapshotHandoffs retains the current content of every role's handoff
+// bead as a version, e.g. before a halt kills the sessions writing them,
+// and returns the roles that have one.
+func (b *Beads) SnapshotHandoffs() ([]string, error) {
+	issues, err := b.List(ListOptions{Status: StatusPinned, Priority: -1})
+	if err != nil {
+		return nil, fmt.Errorf("listing pinned issues: %w", err)
+	}
+	var roles []string
+	for _, issue := range issues {
+		role, ok := strings.CutSuffix(issue.Title, HandoffBeadTitle(""))
+		if !ok || role == "" {
+			continue
+		}
+		if err := b.recordHandoffVersion(role, issue.ID, issue.Description); err != nil {
+			return roles, fmt.Errorf("snapshotting %s handoff: %w", role, err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// HandoffDiff returns a unified diff of role's handoff bead from the
+// latest retained version that differs from its current content, i.e.
+// what the previous session added or removed, or "" if nothing changed.
+// A handoff with no earlier version diffs against empty content.
+func (b *Beads) HandoffDiff(role string) (string, error) {
+	issue, err := b.FindHandoffBead(role)
+	if err != nil {
+		return "", err
+	}
+	current := ""
+	if issue != nil {
+		current = issue.Description
+	}
+	versions, err := readHandoffVersions(b.handoffVersionsPath(role))
+	if err != nil {
+		return "", fmt.Errorf("reading handoff versions: %w", err)
+	}
+
+	fromName, from := role+" handoff (empty)", ""
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].Content != current {
+			v := versions[i]
+			fromName = fmt.Sprintf("%s handoff v%d (%s)", role, v.Version, v.Timestamp.Format(time.RFC3339))
+			from = v.Content
+			break
+		}
+	}
+	return util.UnifiedDiff(fromName, role+" handoff (current)", from, current, 3), nil
+}
+
+// HandoffHistory returns role's retained handoff versions, newest first:
+// at most limit of them, or all with limit <= 0. The history is append
+// only; restoring or clearing adds versions rather than removing any.
+func (b *Beads) HandoffHistory(role string, limit int) ([]HandoffVersion, error) {
+	versions, err := readHandoffVersions(b.handoffVersionsPath(role))
+	if err != nil {
+		return nil, fmt.Errorf("reading handoff versions: %w", err)
+	}
+	slices.Reverse(versions)
+	if limit > 0 && len(versions) > limit {
+		versions = versions[:limit]
+	}
+	return versions, nil
+}
+
+// RestoreHandoffVersion makes a retained version role's handoff content
+// again, e.g. to recover context lost to a bad handoff. The content it
+// replaces is retained like any other update.
+func (b *Beads) RestoreHandoffVersion(role string, version int) (*HandoffVersion, error) {
+	versions, err := readHandoffVersions(b.handoffVersionsPath(role))
+	if err != nil {
+		return nil, fmt.Errorf("reading handoff versions: %w", err)
+	}
+	for i := range versions {
+		if versions[i].Version == version {
+			if err := b.UpdateHandoffContent(role, versions[i].Content); err != nil {
+				return nil, err
+			}
+			return &versions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s v%d", ErrHandoffVersionNotFound, role, version)
+}
@@ -0,0 +1,170 @@
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setFakeHandoff makes the fake bd list the witness handoff bead with the
+// given content.
+func setFakeHandoff(t *testing.T, content string) {
+	t.Helper()
+	data, _ := json.Marshal([]Issue{{ID: "hq-h", Title: HandoffBeadTitle("witness"), Status: StatusPinned, Description: content}})
+	if err := os.WriteFile(fakeShowFile(t), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandoffVersionsAndDiff(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+echo "$@" >> "$dir/log"
+case "$*" in
+*list*) cat "$dir/show.json";;
+esac
+`)
+	workDir := t.TempDir()
+	b := New(workDir)
+
+	setFakeHandoff(t, "Patrol clean.")
+	if err := b.UpdateHandoffContent("witness", "Patrol clean.\nToast is stuck on gt-abc."); err != nil {
+		t.Fatal(err)
+	}
+	if log := bdLog(t); !strings.Contains(log, "update hq-h --description=Patrol clean.\nToast is stuck on gt-abc.") {
+		t.Errorf("content not written:\n%s", log)
+	}
+	setFakeHandoff(t, "Patrol clean.\nToast is stuck on gt-abc.")
+
+	diff, err := b.HandoffDiff("witness")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "--- witness handoff v1 (") || !strings.Contains(diff, "+Toast is stuck on gt-abc.\n") ||
+		!strings.Contains(diff, " Patrol clean.\n") {
+		t.Errorf("diff =\n%s", diff)
+	}
+
+	// Content written straight to the bead is retained at the next snapshot
+	setFakeHandoff(t, "Toast is stuck on gt-abc.")
+	for i := 0; i < 2; i++ {
+		if err := b.RecordHandoffVersion("witness"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	diff, _ = b.HandoffDiff("witness")
+	if !strings.Contains(diff, "--- witness handoff v2 (") || !strings.Contains(diff, "-Patrol clean.\n") {
+		t.Errorf("diff after edit =\n%s", diff)
+	}
+
+	versions, err := readHandoffVersions(filepath.Join(workDir, ".beads", HandoffVersionsDirName, "witness.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 || versions[2].Version != 3 || versions[2].BeadID != "hq-h" {
+		t.Errorf("versions = %+v, want 3 (unchanged snapshots skipped)", versions)
+	}
+}
+
+func TestHandoffDiffWithoutVersions(t *testing.T) {
+	installFakeBd(t, `
+case "$*" in
+*list*) cat "$(dirname "$0")/show.json";;
+esac
+`)
+	b := New(t.TempDir())
+
+	setFakeHandoff(t, "")
+	if diff, err := b.HandoffDiff("witness"); err != nil || diff != "" {
+		t.Errorf("empty handoff diff = %q, %v", diff, err)
+	}
+	setFakeHandoff(t, "First notes.")
+	diff, err := b.HandoffDiff("witness")
+	if err != nil || !strings.Contains(diff, "--- witness handoff (empty)") || !strings.Contains(diff, "+First notes.") {
+		t.Errorf("first handoff diff = %q, %v", diff, err)
+	}
+}
+
+func TestHandoffHistoryAndRestore(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+echo "$@" >> "$dir/log"
+case "$*" in
+*list*) cat "$dir/show.json";;
+esac
+`)
+	b := New(t.TempDir())
+
+	if history, err := b.HandoffHistory("witness", 0); err != nil || len(history) != 0 {
+		t.Fatalf("history before any handoff = %+v, %v", history, err)
+	}
+	for _, content := range []string{"one", "two", "three"} {
+		setFakeHandoff(t, content)
+		if err := b.RecordHandoffVersion("witness"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := b.HandoffHistory("witness", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].Content != "three" || history[1].Version != 2 || history[0].Timestamp.IsZero() {
+		t.Errorf("HandoffHistory(limit 2) = %+v", history)
+	}
+
+	// A bad handoff wiped the notes; bring back v2
+	setFakeHandoff(t, "")
+	restored, err := b.RestoreHandoffVersion("witness", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Content != "two" || !strings.Contains(bdLog(t), "update hq-h --description=two") {
+		t.Errorf("restored %+v:\n%s", restored, bdLog(t))
+	}
+	history, _ = b.HandoffHistory("witness", 0)
+	if len(history) != 5 || history[0].Content != "two" || history[1].Content != "" {
+		t.Errorf("history after restore = %+v, want the wiped and restored content appended", history)
+	}
+
+	if _, err := b.RestoreHandoffVersion("witness", 42); !errors.Is(err, ErrHandoffVersionNotFound) {
+		t.Errorf("RestoreHandoffVersion(42) = %v, want ErrHandoffVersionNotFound", err)
+	}
+}
+
+func TestSnapshotHandoffs(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+case "$*" in
+*list*) cat "$dir/show.json";;
+esac
+`)
+	data, _ := json.Marshal([]Issue{
+		{ID: "hq-h1", Title: HandoffBeadTitle("witness"), Status: StatusPinned, Description: "Toast is stuck."},
+		{ID: "hq-h2", Title: HandoffBeadTitle("mayor"), Status: StatusPinned, Description: ""},
+		{ID: "hq-r", Title: "Witness Role", Status: StatusPinned, Description: "Role definition"},
+	})
+	if err := os.WriteFile(fakeShowFile(t), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	b := New(t.TempDir())
+
+	roles, err := b.SnapshotHandoffs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(roles, ",") != "witness,mayor" {
+		t.Errorf("roles = %v, want [witness mayor]", roles)
+	}
+	history, _ := b.HandoffHistory("witness", 0)
+	if len(history) != 1 || history[0].Content != "Toast is stuck." || history[0].BeadID != "hq-h1" {
+		t.Errorf("witness history = %+v", history)
+	}
+	// An empty handoff with no history has nothing to retain
+	if history, _ := b.HandoffHistory("mayor", 0); len(history) != 0 {
+		t.Errorf("mayor history = %+v, want none", history)
+	}
+}
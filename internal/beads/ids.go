@@ -0,0 +1,122 @@
+// Package beads provides issue ID validation, normalization, and resolution.
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ID errors.
+var (
+	ErrInvalidID   = errors.New("invalid issue ID")
+	ErrAmbiguousID = errors.New("ambiguous issue ID")
+)
+
+// idPattern matches bead IDs: a lowercase alphanumeric prefix, a hyphen, and
+// a body of letters, digits, dots (hierarchical children like gt-abc.3),
+// underscores, and hyphens (agent IDs like gt-gastown-polecat-Toast).
+var idPattern = regexp.MustCompile(`^[a-z][a-z0-9]*-[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// NormalizeID trims whitespace and lowercases the prefix of an issue ID.
+// The body is left as-is since agent names are case-sensitive (gt-gastown-polecat-Toast).
+func NormalizeID(id string) string {
+	id = strings.TrimSpace(id)
+	idx := strings.Index(id, "-")
+	if idx <= 0 {
+		return id
+	}
+	return strings.ToLower(id[:idx]) + id[idx:]
+}
+
+// ValidateID checks that id is a well-formed bead ID.
+// Errors wrap ErrInvalidID (or ErrUnsafeArg) and suggest a fix where possible.
+func ValidateID(id string) error {
+	if err := checkPositional(id); err != nil {
+		return err
+	}
+	if idPattern.MatchString(id) {
+		return nil
+	}
+	if normalized := NormalizeID(id); normalized != id && idPattern.MatchString(normalized) {
+		return fmt.Errorf("%w: %q (did you mean %q?)", ErrInvalidID, id, normalized)
+	}
+	if ExtractPrefix(id) == "" {
+		return fmt.Errorf("%w: %q has no prefix (expected e.g. gt-abc)", ErrInvalidID, id)
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidID, id)
+}
+
+// ValidateIDWithPrefix checks that id is well-formed and carries the expected
+// prefix. The prefix may be given with or without the trailing hyphen.
+func ValidateIDWithPrefix(id, prefix string) error {
+	if err := ValidateID(id); err != nil {
+		return err
+	}
+	want := strings.TrimSuffix(prefix, "-") + "-"
+	if got := ExtractPrefix(id); got != want {
+		return fmt.Errorf("%w: %q has prefix %q, expected %q", ErrInvalidID, id, got, want)
+	}
+	return nil
+}
+
+// checkIDs validates issue IDs at API boundaries, before any bd call.
+func checkIDs(ids ...string) error {
+	for _, id := range ids {
+		if err := ValidateID(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResolveID expands a full ID or a short unique suffix to a full issue ID.
+// A reference like "abc" or "-abc" matches any ID whose body (after the
+// prefix) starts with "abc". Returns ErrNotFound if nothing matches and
+// ErrAmbiguousID (listing candidates) if several do.
+func (b *Beads) ResolveID(ref string) (string, error) {
+	ref = NormalizeID(ref)
+	if ref == "" {
+		return "", fmt.Errorf("%w: empty reference", ErrInvalidID)
+	}
+
+	if ValidateID(ref) == nil {
+		if _, err := b.Show(ref); err == nil {
+			return ref, nil
+		} else if !errors.Is(err, ErrNotFound) {
+			return "", err
+		}
+	}
+
+	suffix := strings.TrimPrefix(ref, "-")
+	issues, err := b.List(ListOptions{Status: "all", Priority: -1})
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	for _, issue := range issues {
+		body := strings.TrimPrefix(issue.ID, ExtractPrefix(issue.ID))
+		if issue.ID == ref {
+			return issue.ID, nil
+		}
+		if strings.HasPrefix(body, suffix) {
+			matches = append(matches, issue.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: no issue matches %q", ErrNotFound, ref)
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		if len(matches) > 5 {
+			matches = append(matches[:5], "...")
+		}
+		return "", fmt.Errorf("%w: %q matches %s", ErrAmbiguousID, ref, strings.Join(matches, ", "))
+	}
+}
@@ -0,0 +1,73 @@
+package beads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateID(t *testing.T) {
+	valid := []string{
+		"gt-abc",
+		"gt-abc.3",
+		"ap-qtsup.16",
+		"hq-cv-abc",
+		"gt-gastown-polecat-Toast",
+		"bd2-x_y",
+	}
+	for _, id := range valid {
+		if err := ValidateID(id); err != nil {
+			t.Errorf("ValidateID(%q) = %v, want nil", id, err)
+		}
+	}
+
+	invalid := []struct {
+		id   string
+		hint string
+	}{
+		{"abc", "no prefix"},
+		{"GT-abc", `did you mean "gt-abc"`},
+		{"gt-", ""},
+		{"gt-abc!", ""},
+		{"--all", "looks like a flag"},
+	}
+	for _, tt := range invalid {
+		err := ValidateID(tt.id)
+		if err == nil {
+			t.Errorf("ValidateID(%q) = nil, want error", tt.id)
+			continue
+		}
+		if !errors.Is(err, ErrInvalidID) && !errors.Is(err, ErrUnsafeArg) {
+			t.Errorf("ValidateID(%q) = %v, want ErrInvalidID or ErrUnsafeArg", tt.id, err)
+		}
+		if tt.hint != "" && !strings.Contains(err.Error(), tt.hint) {
+			t.Errorf("ValidateID(%q) = %q, want hint %q", tt.id, err, tt.hint)
+		}
+	}
+}
+
+func TestValidateIDWithPrefix(t *testing.T) {
+	if err := ValidateIDWithPrefix("gt-abc", "gt"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateIDWithPrefix("gt-abc", "gt-"); err != nil {
+		t.Errorf("unexpected error with hyphenated prefix: %v", err)
+	}
+	if err := ValidateIDWithPrefix("bd-abc", "gt"); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("expected ErrInvalidID for wrong prefix, got %v", err)
+	}
+}
+
+func TestNormalizeID(t *testing.T) {
+	tests := map[string]string{
+		"  gt-abc ":                "gt-abc",
+		"GT-abc":                   "gt-abc",
+		"Gt-gastown-polecat-Toast": "gt-gastown-polecat-Toast",
+		"noprefix":                 "noprefix",
+	}
+	for in, want := range tests {
+		if got := NormalizeID(in); got != want {
+			t.Errorf("NormalizeID(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TypeMessage is the issue type used for mail.
+const TypeMessage = "message"
+
+// Label prefixes carrying message metadata. The recipient is the assignee,
+// the subject is the title, and the body is the description.
+const (
+	LabelFrom    = "from:"
+	LabelThread  = "thread:"
+	LabelReplyTo = "reply-to:"
+	LabelMsgType = "msg-type:"
+	LabelCC      = "cc:"
+)
+
+// ErrNotMessage is returned when message accessors are used on a non-message issue.
+var ErrNotMessage = errors.New("issue is not a message")
+
+// NewMessage returns create options for a message following the mail
+// convention. Pass the result to Create.
+func NewMessage(from, to, subject, body string, priority int) CreateOptions {
+	return (&Message{From: from, To: to, Subject: subject, Body: body, Priority: priority}).CreateOptions()
+}
+
+// Message is the typed view of a message issue.
+type Message struct {
+	ID       string
+	From     string
+	To       string
+	Subject  string
+	Body     string
+	Priority int
+	ThreadID string
+	ReplyTo  string
+	MsgType  string
+	CC       []string
+}
+
+// CreateOptions returns create options for m following the mail
+// convention, created by its sender. ID is ignored.
+func (m *Message) CreateOptions() CreateOptions {
+	labels := []string{LabelFrom + m.From}
+	if m.ThreadID != "" {
+		labels = append(labels, LabelThread+m.ThreadID)
+	}
+	if m.ReplyTo != "" {
+		labels = append(labels, LabelReplyTo+m.ReplyTo)
+	}
+	if m.MsgType != "" {
+		labels = append(labels, LabelMsgType+m.MsgType)
+	}
+	for _, cc := range m.CC {
+		labels = append(labels, LabelCC+cc)
+	}
+	return CreateOptions{
+		Title:       m.Subject,
+		Type:        TypeMessage,
+		Priority:    m.Priority,
+		Description: m.Body,
+		Assignee:    m.To,
+		Labels:      labels,
+		Actor:       m.From,
+	}
+}
+
+// IsMessage reports whether an issue is a mail message.
+func IsMessage(issue *Issue) bool {
+	return issue != nil && issue.Type == TypeMessage
+}
+
+// ParseMessage extracts message metadata from an issue.
+// Returns ErrNotMessage if the issue is not of type message.
+func ParseMessage(issue *Issue) (*Message, error) {
+	if !IsMessage(issue) {
+		id := "<nil>"
+		if issue != nil {
+			id = issue.ID
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNotMessage, id)
+	}
+
+	msg := &Message{
+		ID:       issue.ID,
+		To:       issue.Assignee,
+		Subject:  issue.Title,
+		Body:     issue.Description,
+		Priority: issue.Priority,
+	}
+	for _, label := range issue.Labels {
+		switch {
+		case strings.HasPrefix(label, LabelFrom):
+			msg.From = strings.TrimPrefix(label, LabelFrom)
+		case strings.HasPrefix(label, LabelThread):
+			msg.ThreadID = strings.TrimPrefix(label, LabelThread)
+		case strings.HasPrefix(label, LabelReplyTo):
+			msg.ReplyTo = strings.TrimPrefix(label, LabelReplyTo)
+		case strings.HasPrefix(label, LabelMsgType):
+			msg.MsgType = strings.TrimPrefix(label, LabelMsgType)
+		case strings.HasPrefix(label, LabelCC):
+			msg.CC = append(msg.CC, strings.TrimPrefix(label, LabelCC))
+		}
+	}
+	return msg, nil
+}
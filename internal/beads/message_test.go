@@ -0,0 +1,62 @@
+package beads
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewMessage(t *testing.T) {
+	opts := NewMessage("gastown/Toast", "mayor/", "Done", "All green", 1)
+	if opts.Type != TypeMessage || opts.Assignee != "mayor/" || opts.Title != "Done" {
+		t.Errorf("opts = %+v", opts)
+	}
+	if len(opts.Labels) != 1 || opts.Labels[0] != "from:gastown/Toast" {
+		t.Errorf("Labels = %v", opts.Labels)
+	}
+}
+
+func TestMessageCreateOptionsRoundTrip(t *testing.T) {
+	in := &Message{From: "gastown/Nux", To: "mayor/", Subject: "Re: status", Body: "body", Priority: 2,
+		ThreadID: "t1", ReplyTo: "hq-xyz", MsgType: "reply", CC: []string{"deacon/", "gastown/witness"}}
+	opts := in.CreateOptions()
+	if opts.Actor != "gastown/Nux" {
+		t.Errorf("Actor = %q, want the sender", opts.Actor)
+	}
+
+	out, err := ParseMessage(&Issue{ID: "hq-abc", Type: opts.Type, Title: opts.Title, Description: opts.Description,
+		Assignee: opts.Assignee, Priority: opts.Priority, Labels: opts.Labels})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out.ID = ""
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	issue := &Issue{
+		ID:          "hq-abc",
+		Type:        TypeMessage,
+		Title:       "Re: status",
+		Description: "body",
+		Assignee:    "mayor/",
+		Priority:    2,
+		Labels:      []string{"from:gastown/Nux", "thread:t1", "reply-to:hq-xyz", "cc:deacon/", "cc:gastown/witness"},
+	}
+	msg, err := ParseMessage(issue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.From != "gastown/Nux" || msg.To != "mayor/" || msg.ThreadID != "t1" || msg.ReplyTo != "hq-xyz" {
+		t.Errorf("msg = %+v", msg)
+	}
+	if len(msg.CC) != 2 {
+		t.Errorf("CC = %v", msg.CC)
+	}
+
+	if _, err := ParseMessage(&Issue{ID: "gt-1", Type: "task"}); !errors.Is(err, ErrNotMessage) {
+		t.Errorf("expected ErrNotMessage, got %v", err)
+	}
+}
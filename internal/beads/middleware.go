@@ -0,0 +1,411 @@
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/redact"
+)
+
+// Executor runs one bd invocation (without the leading --no-daemon) and
+// returns its stdout. The core executor forks bd; middleware wraps it.
+type Executor func(args []string) ([]byte, error)
+
+// Middleware wraps an Executor with a cross-cutting concern such as
+// retries, rate limiting, or tracing.
+type Middleware func(next Executor) Executor
+
+// Option configures a Beads at construction.
+type Option func(*Beads)
+
+// defaultMiddleware is installed on every Beads created by New, ahead of
+// any per-instance middleware. See SetDefaultMiddleware.
+var defaultMiddleware []Middleware
+
+// SetDefaultMiddleware sets middleware applied to every Beads created
+// afterwards. It is meant for process-wide policy set once at startup,
+// such as ReadOnly for observer sessions.
+func SetDefaultMiddleware(mw ...Middleware) {
+	defaultMiddleware = mw
+}
+
+// AddDefaultMiddleware appends to the middleware SetDefaultMiddleware
+// installs, for process-wide concerns set up independently of each other.
+func AddDefaultMiddleware(mw ...Middleware) {
+	defaultMiddleware = append(defaultMiddleware, mw...)
+}
+
+// WithMiddleware adds middleware to a Beads. The first middleware given is
+// the outermost: it sees each call first and its result last.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(b *Beads) {
+		b.middleware = append(b.middleware, mw...)
+	}
+}
+
+// Use appends middleware to b and returns b.
+func (b *Beads) Use(mw ...Middleware) *Beads {
+	b.middleware = append(b.middleware, mw...)
+	return b
+}
+
+// executor returns the core executor wrapped in b's middleware, with the
+// read-through cache (if any) outermost so cache hits skip everything else.
+func (b *Beads) executor() Executor {
+	next := Executor(b.execBd)
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		next = b.middleware[i](next)
+	}
+	if b.cache != nil {
+		next = b.cache.middleware(next)
+	}
+	return next
+}
+
+// cachedCommands are bd subcommands whose output WithCache memoizes.
+var cachedCommands = map[string]bool{
+	"show": true,
+	"list": true,
+}
+
+// readOnlyCommands are bd subcommands that never change the database,
+// beyond the cacheable ones. Anything in neither set is a mutation.
+// Commands whose verb decides are listed with it, e.g. "slot get".
+var readOnlyCommands = map[string]bool{
+	"ready":            true,
+	"blocked":          true,
+	"search":           true,
+	"stats":            true,
+	"export":           true,
+	"version":          true,
+	"info":             true,
+	"slot get":         true,
+	"merge-slot check": true,
+}
+
+// IsMutation reports whether a bd invocation may change the database.
+// Unknown subcommands are assumed to.
+func IsMutation(args []string) bool {
+	cmd := subcommand(args)
+	switch cmd {
+	case "comments":
+		return slices.Contains(args, "add")
+	case "sync":
+		return !slices.Contains(args, "--status")
+	}
+	return !cachedCommands[cmd] && !readOnlyCommands[cmd] && !readOnlyCommands[cmd+" "+verb(args)]
+}
+
+// subcommand returns the first non-flag argument.
+func subcommand(args []string) string {
+	for _, a := range args {
+		if !strings.HasPrefix(a, "-") {
+			return a
+		}
+	}
+	return ""
+}
+
+// verb returns the second non-flag argument, e.g. "get" in "slot get".
+func verb(args []string) string {
+	seen := false
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") {
+			continue
+		}
+		if seen {
+			return a
+		}
+		seen = true
+	}
+	return ""
+}
+
+// Retry re-runs calls that fail with a retryable error, up to attempts
+// times in total, sleeping backoff, 2*backoff, ... between tries. A nil
+// retryable retries ErrSyncConflict and lock contention only; errors
+// like ErrNotFound are never worth repeating.
+func Retry(attempts int, backoff time.Duration, retryable func(error) bool) Middleware {
+	if retryable == nil {
+		retryable = isTransient
+	}
+	return func(next Executor) Executor {
+		return func(args []string) ([]byte, error) {
+			var out []byte
+			var err error
+			delay := backoff
+			for i := 0; i < max(attempts, 1); i++ {
+				if i > 0 {
+					time.Sleep(delay)
+					delay *= 2
+				}
+				out, err = next(args)
+				if err == nil || !retryable(err) {
+					return out, err
+				}
+			}
+			return out, err
+		}
+	}
+}
+
+// isTransient reports whether err looks like contention that clears up
+// on its own.
+func isTransient(err error) bool {
+	if errors.Is(err, ErrSyncConflict) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "SQLITE_BUSY")
+}
+
+// RateLimit spaces calls at least interval apart, so a tight patrol loop
+// can't fork bd faster than the machine can absorb.
+func RateLimit(interval time.Duration) Middleware {
+	var mu sync.Mutex
+	var last time.Time
+	return func(next Executor) Executor {
+		return func(args []string) ([]byte, error) {
+			mu.Lock()
+			if wait := interval - time.Since(last); wait > 0 {
+				time.Sleep(wait)
+			}
+			last = time.Now()
+			mu.Unlock()
+			return next(args)
+		}
+	}
+}
+
+// TraceFunc receives one record per bd call.
+type TraceFunc func(args []string, elapsed time.Duration, err error)
+
+// Trace reports every call, its duration, and its error to fn.
+func Trace(fn TraceFunc) Middleware {
+	return func(next Executor) Executor {
+		return func(args []string) ([]byte, error) {
+			start := time.Now()
+			out, err := next(args)
+			fn(args, time.Since(start), err)
+			return out, err
+		}
+	}
+}
+
+// DryRun prints mutating calls to w instead of running them; reads still
+// run so callers see real data. Skipped calls return "{}" when JSON was
+// requested and nothing otherwise, so callers parsing a created issue get
+// an empty one rather than an error.
+func DryRun(w io.Writer) Middleware {
+	return func(next Executor) Executor {
+		return func(args []string) ([]byte, error) {
+			if !IsMutation(args) {
+				return next(args)
+			}
+			fmt.Fprintf(w, "[dry-run] bd %s\n", strings.Join(args, " "))
+			if slices.Contains(args, "--json") {
+				return []byte("{}"), nil
+			}
+			return nil, nil
+		}
+	}
+}
+
+// ErrReadOnly is returned for mutating calls made through ReadOnly.
+var ErrReadOnly = errors.New("beads are read-only in this session")
+
+// ReadOnly rejects every mutating call with ErrReadOnly.
+func ReadOnly() Middleware {
+	return func(next Executor) Executor {
+		return func(args []string) ([]byte, error) {
+			if IsMutation(args) {
+				return nil, fmt.Errorf("bd %s: %w", subcommand(args), ErrReadOnly)
+			}
+			return next(args)
+		}
+	}
+}
+
+// Actor stamps mutating calls with --actor=actor unless the caller
+// already set one, so every write made through a Beads is attributed. The
+// flag goes ahead of any "--", after which bd reads only positionals.
+func Actor(actor string) Middleware {
+	return func(next Executor) Executor {
+		return func(args []string) ([]byte, error) {
+			if actor == "" || !IsMutation(args) || hasActorFlag(args) {
+				return next(args)
+			}
+			end := len(args)
+			if i := slices.Index(args, "--"); i >= 0 {
+				end = i
+			}
+			return next(slices.Insert(slices.Clone(args), end, "--actor="+actor))
+		}
+	}
+}
+
+func hasActorFlag(args []string) bool {
+	for _, a := range args {
+		if a == "--" {
+			break
+		}
+		if a == "--actor" || strings.HasPrefix(a, "--actor=") {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact scrubs secrets from the arguments of mutating calls (titles,
+// descriptions, comments, ...) using r; see package redact. record, if
+// not nil, is given the number of secrets replaced in each call that had
+// any.
+func Redact(r *redact.Redactor, record func(n int)) Middleware {
+	return func(next Executor) Executor {
+		return func(args []string) ([]byte, error) {
+			if r == nil || !IsMutation(args) {
+				return next(args)
+			}
+			var scrubbed []string
+			total := 0
+			for i, a := range args {
+				s, n := r.String(a)
+				if n == 0 {
+					continue
+				}
+				if scrubbed == nil {
+					scrubbed = slices.Clone(args)
+				}
+				scrubbed[i] = s
+				total += n
+			}
+			if total == 0 {
+				return next(args)
+			}
+			if record != nil {
+				record(total)
+			}
+			return next(scrubbed)
+		}
+	}
+}
+
+// permissions and permissionRole are the town's role permissions and the
+// role this process acts as, set by EnforcePermissions. Operations that
+// aren't a single bd call, like detaching a molecule, check them directly.
+var (
+	permissions    config.Permissions
+	permissionRole string
+)
+
+// EnforcePermissions applies the town's role permissions (see
+// config.Permissions) to every Beads created afterwards, acting as role:
+// deletes, epic closes and molecule detaches role isn't allowed fail with
+// config.ErrPermissionDenied.
+func EnforcePermissions(perms config.Permissions, role string) {
+	permissions, permissionRole = perms, role
+	AddDefaultMiddleware(Authorize(perms, role))
+}
+
+// checkPermission refuses op on bead id if the enforced permissions don't
+// allow it.
+func checkPermission(op, id string) error {
+	if err := permissions.Check(op, permissionRole); err != nil {
+		return fmt.Errorf("%s: %w", id, err)
+	}
+	return nil
+}
+
+// Authorize refuses the bd calls perms doesn't allow role to make:
+// deleting beads, and closing epics (by close or update --status=closed).
+// Closes are only looked up, to tell epics apart, when role may not close
+// epics.
+func Authorize(perms config.Permissions, role string) Middleware {
+	return func(next Executor) Executor {
+		return func(args []string) ([]byte, error) {
+			switch cmd, ids := subcommand(args), positionalIDs(args); {
+			case cmd == "delete":
+				if err := perms.Check(config.OpDeleteBead, role); err != nil {
+					return nil, fmt.Errorf("deleting %s: %w", strings.Join(ids, ", "), err)
+				}
+			case cmd == "close" || cmd == "update" && closesIssue(args):
+				if perms.Allows(config.OpCloseEpic, role) {
+					break
+				}
+				if cmd == "update" && len(ids) > 0 {
+					ids = ids[:1]
+				}
+				for _, id := range ids {
+					out, err := next([]string{"show", id, "--json"})
+					if err != nil {
+						return nil, fmt.Errorf("checking %s before closing: %w", id, err)
+					}
+					var issues []*Issue
+					if err := json.Unmarshal(out, &issues); err != nil {
+						return nil, fmt.Errorf("checking %s before closing: %w", id, err)
+					}
+					if len(issues) > 0 && issues[0].Type == "epic" {
+						return nil, fmt.Errorf("closing %s: %w", id, perms.Check(config.OpCloseEpic, role))
+					}
+				}
+			}
+			return next(args)
+		}
+	}
+}
+
+// valueFlags are the close, update and delete flags that take their value
+// as the next argument. An unlisted flag's value is taken for an ID, which
+// only costs a failed lookup; the reverse would let an ID slip past
+// Authorize.
+var valueFlags = map[string]bool{
+	"--reason": true, "-r": true,
+	"--status": true, "-s": true,
+	"--session": true, "--actor": true, "--db": true,
+	"--title": true, "-t": true, "--type": true,
+	"--description": true, "-d": true, "--notes": true, "--design": true,
+	"--assignee": true, "-a": true, "--priority": true, "-p": true,
+	"--add-label": true, "--remove-label": true, "--set-labels": true,
+	"--parent": true, "--from-file": true,
+}
+
+// positionalIDs returns the non-flag arguments after the subcommand,
+// skipping the values of "--flag value" pairs.
+func positionalIDs(args []string) []string {
+	var ids []string
+	seenCmd := false
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			return append(ids, args[i+1:]...)
+		}
+		if strings.HasPrefix(a, "-") {
+			if valueFlags[a] {
+				i++
+			}
+			continue
+		}
+		if seenCmd {
+			ids = append(ids, a)
+		}
+		seenCmd = true
+	}
+	return ids
+}
+
+// closesIssue reports whether an update call sets the status to closed.
+func closesIssue(args []string) bool {
+	for i, a := range args {
+		if a == "--status=closed" || a == "--status" && i+1 < len(args) && args[i+1] == "closed" {
+			return true
+		}
+	}
+	return false
+}
@@ -262,7 +262,8 @@ type InstantiateOptions struct {
 //   - Priority: inherited from parent
 //   - Dependencies wired according to template
 //
-// The function is atomic via bd CLI - either all issues are created or none.
+// Step issues are created with a single CreateBatch call; dependencies are
+// wired afterwards.
 // Returns the created step issues.
 func (b *Beads) InstantiateMolecule(mol *Issue, parent *Issue, opts InstantiateOptions) ([]*Issue, error) {
 	if mol == nil {
@@ -294,10 +295,10 @@ func (b *Beads) InstantiateMolecule(mol *Issue, parent *Issue, opts InstantiateO
 
 // instantiateFromChildren creates steps from template child issues (new format).
 func (b *Beads) instantiateFromChildren(mol *Issue, parent *Issue, templates []*Issue, opts InstantiateOptions) ([]*Issue, error) {
-	var createdIssues []*Issue
+	var batch []CreateOptions
 	templateToNew := make(map[string]string) // template ID -> new issue ID
 
-	// First pass: create all child issues
+	// First pass: build create options for all child issues
 	for _, tmpl := range templates {
 		// Expand template variables in description
 		description := tmpl.Description
@@ -311,7 +312,6 @@ func (b *Beads) instantiateFromChildren(mol *Issue, parent *Issue, templates []*
 		}
 		description += fmt.Sprintf("instantiated_from: %s\ntemplate_step: %s", mol.ID, tmpl.ID)
 
-		// Create the child issue
 		childOpts := CreateOptions{
 			Title:       tmpl.Title,
 			Type:        tmpl.Type,
@@ -322,18 +322,16 @@ func (b *Beads) instantiateFromChildren(mol *Issue, parent *Issue, templates []*
 		if childOpts.Type == "" {
 			childOpts.Type = "task"
 		}
+		batch = append(batch, childOpts)
+	}
 
-		child, err := b.Create(childOpts)
-		if err != nil {
-			// Attempt to clean up created issues on failure (best-effort cleanup)
-			for _, created := range createdIssues {
-				_ = b.Close(created.ID)
-			}
-			return nil, fmt.Errorf("creating step from template %q: %w", tmpl.ID, err)
-		}
-
-		createdIssues = append(createdIssues, child)
-		templateToNew[tmpl.ID] = child.ID
+	// Create all child issues in one bd invocation
+	createdIssues, err := b.CreateBatch(batch)
+	if err != nil {
+		return nil, fmt.Errorf("creating steps from templates: %w", err)
+	}
+	for i, tmpl := range templates {
+		templateToNew[tmpl.ID] = createdIssues[i].ID
 	}
 
 	// Second pass: wire dependencies based on template dependencies
@@ -386,8 +384,8 @@ func (b *Beads) instantiateFromMarkdown(mol *Issue, parent *Issue, opts Instanti
 		}
 	}
 
-	// Create child issues for each step
-	var createdIssues []*Issue
+	// Build create options for each step
+	var batch []CreateOptions
 	stepIssueIDs := make(map[string]string) // step ref -> issue ID
 
 	for _, step := range steps {
@@ -407,26 +405,22 @@ func (b *Beads) instantiateFromMarkdown(mol *Issue, parent *Issue, opts Instanti
 			description += fmt.Sprintf("\ntier: %s", step.Tier)
 		}
 
-		// Create the child issue
-		childOpts := CreateOptions{
+		batch = append(batch, CreateOptions{
 			Title:       step.Title,
 			Type:        "task",
 			Priority:    parent.Priority,
 			Description: description,
 			Parent:      parent.ID,
-		}
-
-		child, err := b.Create(childOpts)
-		if err != nil {
-			// Attempt to clean up created issues on failure (best-effort cleanup)
-			for _, created := range createdIssues {
-				_ = b.Close(created.ID)
-			}
-			return nil, fmt.Errorf("creating step %q: %w", step.Ref, err)
-		}
+		})
+	}
 
-		createdIssues = append(createdIssues, child)
-		stepIssueIDs[step.Ref] = child.ID
+	// Create all step issues in one bd invocation
+	createdIssues, err := b.CreateBatch(batch)
+	if err != nil {
+		return nil, fmt.Errorf("creating steps: %w", err)
+	}
+	for i, step := range steps {
+		stepIssueIDs[step.Ref] = createdIssues[i].ID
 	}
 
 	// Wire inter-step dependencies based on Needs: declarations
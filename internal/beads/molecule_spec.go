@@ -0,0 +1,232 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MoleculeSpec describes a molecule to create from scratch: a root issue
+// plus steps wired by Needs references.
+type MoleculeSpec struct {
+	Title       string
+	Description string
+	Type        string // root type (default: "epic")
+	Priority    int
+	Labels      []string
+	Steps       []StepSpec
+}
+
+// StepSpec is one step of a MoleculeSpec.
+type StepSpec struct {
+	Ref         string // unique within the spec
+	Title       string
+	Description string
+	Type        string   // default: "task"
+	Needs       []string // refs of steps that must close first
+}
+
+// Molecule is an instantiated molecule: its root and steps.
+type Molecule struct {
+	Root  *Issue
+	Steps []*Issue
+	Refs  map[string]string // step ref -> issue ID
+}
+
+// MoleculeProgress summarizes a molecule's step states.
+type MoleculeProgress struct {
+	RootID       string   `json:"root_id"`
+	Total        int      `json:"total"`
+	Done         int      `json:"done"`
+	InProgress   int      `json:"in_progress"`
+	ReadySteps   []string `json:"ready_steps"`
+	BlockedSteps []string `json:"blocked_steps"`
+	Percent      int      `json:"percent_complete"`
+	Complete     bool     `json:"complete"`
+}
+
+// CreateMolecule creates a root issue and its steps with dependencies.
+// The spec is validated (unique refs, known needs, no cycles) before
+// anything is written. Steps are created with one CreateBatch call; if any
+// later write fails, everything created so far is closed, so a partial
+// molecule is never left open.
+func (b *Beads) CreateMolecule(spec MoleculeSpec) (*Molecule, error) {
+	if err := validateMoleculeSpec(spec); err != nil {
+		return nil, err
+	}
+
+	rootType := spec.Type
+	if rootType == "" {
+		rootType = "epic"
+	}
+	root, err := b.Create(CreateOptions{
+		Title:       spec.Title,
+		Type:        rootType,
+		Priority:    spec.Priority,
+		Description: spec.Description,
+		Labels:      spec.Labels,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating molecule root: %w", err)
+	}
+
+	mol := &Molecule{Root: root, Refs: make(map[string]string)}
+	rollback := func(cause error) (*Molecule, error) {
+		ids := []string{root.ID}
+		for _, step := range mol.Steps {
+			ids = append(ids, step.ID)
+		}
+		_ = b.CloseWithReason("molecule creation failed: "+cause.Error(), ids...)
+		return nil, cause
+	}
+
+	if len(spec.Steps) == 0 {
+		return mol, nil
+	}
+
+	batch := make([]CreateOptions, len(spec.Steps))
+	for i, step := range spec.Steps {
+		stepType := step.Type
+		if stepType == "" {
+			stepType = "task"
+		}
+		batch[i] = CreateOptions{
+			Title:       step.Title,
+			Type:        stepType,
+			Priority:    spec.Priority,
+			Description: step.Description,
+			Parent:      root.ID,
+		}
+	}
+	steps, err := b.CreateBatch(batch)
+	if err != nil {
+		return rollback(fmt.Errorf("creating molecule steps: %w", err))
+	}
+	mol.Steps = steps
+	for i, step := range spec.Steps {
+		mol.Refs[step.Ref] = steps[i].ID
+	}
+
+	for _, step := range spec.Steps {
+		for _, need := range step.Needs {
+			if err := b.AddDependency(mol.Refs[step.Ref], mol.Refs[need]); err != nil {
+				return rollback(fmt.Errorf("wiring %s needs %s: %w", step.Ref, need, err))
+			}
+		}
+	}
+	return mol, nil
+}
+
+// validateMoleculeSpec checks refs, needs, and cycles.
+func validateMoleculeSpec(spec MoleculeSpec) error {
+	if spec.Title == "" {
+		return fmt.Errorf("molecule title is required")
+	}
+	refs := make(map[string]bool, len(spec.Steps))
+	steps := make([]MoleculeStep, len(spec.Steps))
+	for i, step := range spec.Steps {
+		if step.Ref == "" || step.Title == "" {
+			return fmt.Errorf("step %d: ref and title are required", i+1)
+		}
+		if refs[step.Ref] {
+			return fmt.Errorf("duplicate step ref %q", step.Ref)
+		}
+		refs[step.Ref] = true
+		steps[i] = MoleculeStep{Ref: step.Ref, Title: step.Title, Needs: step.Needs}
+	}
+	for _, step := range spec.Steps {
+		for _, need := range step.Needs {
+			if !refs[need] {
+				return fmt.Errorf("step %q needs unknown step %q", step.Ref, need)
+			}
+		}
+	}
+	return detectCycles(steps)
+}
+
+// MoleculeSteps returns a molecule's steps in dependency order: every step
+// after the steps it needs, ties broken by ID.
+func (b *Beads) MoleculeSteps(rootID string) ([]*Issue, error) {
+	children, err := b.moleculeChildren(rootID)
+	if err != nil {
+		return nil, err
+	}
+	return OrderMoleculeSteps(children)
+}
+
+// MoleculeProgress returns done/total/blocked counts for a molecule.
+func (b *Beads) MoleculeProgress(rootID string) (*MoleculeProgress, error) {
+	children, err := b.moleculeChildren(rootID)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeMoleculeProgress(rootID, children), nil
+}
+
+func (b *Beads) moleculeChildren(rootID string) ([]*Issue, error) {
+	children, err := b.List(ListOptions{Parent: rootID, Status: "all", Priority: PriorityUnset, Limit: NoLimit})
+	if err != nil {
+		return nil, fmt.Errorf("listing steps of %s: %w", rootID, err)
+	}
+	return children, nil
+}
+
+// OrderMoleculeSteps sorts steps so each follows the steps it depends on.
+// Dependencies outside the step set are ignored. Returns ErrCycle if the
+// steps depend on each other circularly.
+func OrderMoleculeSteps(steps []*Issue) ([]*Issue, error) {
+	g := NewGraph(steps)
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Issue, 0, len(steps))
+	for _, id := range order {
+		if issue := g.Issue(id); issue != nil {
+			out = append(out, issue)
+		}
+	}
+	return out, nil
+}
+
+// ComputeMoleculeProgress categorizes steps: closed steps are done, open
+// steps are ready when every step they depend on is closed, otherwise
+// blocked. Dependencies outside the molecule don't block.
+func ComputeMoleculeProgress(rootID string, steps []*Issue) *MoleculeProgress {
+	p := &MoleculeProgress{RootID: rootID, Total: len(steps)}
+
+	status := make(map[string]string, len(steps))
+	for _, step := range steps {
+		status[step.ID] = step.Status
+	}
+	g := NewGraph(steps)
+
+	for _, step := range steps {
+		switch step.Status {
+		case "closed":
+			p.Done++
+		case "in_progress", StatusHooked:
+			p.InProgress++
+		case "open":
+			blocked := false
+			for _, dep := range g.Dependencies(step.ID) {
+				if s, ok := status[dep]; ok && s != "closed" {
+					blocked = true
+					break
+				}
+			}
+			if blocked {
+				p.BlockedSteps = append(p.BlockedSteps, step.ID)
+			} else {
+				p.ReadySteps = append(p.ReadySteps, step.ID)
+			}
+		}
+	}
+	sort.Strings(p.ReadySteps)
+	sort.Strings(p.BlockedSteps)
+
+	if p.Total > 0 {
+		p.Percent = p.Done * 100 / p.Total
+	}
+	p.Complete = p.Total > 0 && p.Done == p.Total
+	return p
+}
@@ -0,0 +1,74 @@
+package beads
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateMoleculeSpec(t *testing.T) {
+	ok := MoleculeSpec{Title: "Release", Steps: []StepSpec{
+		{Ref: "build", Title: "Build"},
+		{Ref: "test", Title: "Test", Needs: []string{"build"}},
+	}}
+	if err := validateMoleculeSpec(ok); err != nil {
+		t.Errorf("valid spec rejected: %v", err)
+	}
+
+	tests := map[string]struct {
+		spec MoleculeSpec
+		want string
+	}{
+		"no title":     {MoleculeSpec{}, "title"},
+		"dup ref":      {MoleculeSpec{Title: "x", Steps: []StepSpec{{Ref: "a", Title: "A"}, {Ref: "a", Title: "B"}}}, "duplicate"},
+		"unknown need": {MoleculeSpec{Title: "x", Steps: []StepSpec{{Ref: "a", Title: "A", Needs: []string{"zz"}}}}, "unknown"},
+		"cycle": {MoleculeSpec{Title: "x", Steps: []StepSpec{
+			{Ref: "a", Title: "A", Needs: []string{"b"}},
+			{Ref: "b", Title: "B", Needs: []string{"a"}},
+		}}, "cycle"},
+	}
+	for name, tt := range tests {
+		err := validateMoleculeSpec(tt.spec)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want containing %q", name, err, tt.want)
+		}
+	}
+}
+
+func TestOrderMoleculeSteps(t *testing.T) {
+	steps := []*Issue{
+		{ID: "m.deploy", DependsOn: []string{"m.test"}},
+		{ID: "m.test", DependsOn: []string{"m.build", "outside-1"}},
+		{ID: "m.build"},
+		{ID: "m.docs"},
+	}
+	ordered, err := OrderMoleculeSteps(steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"m.build", "m.docs", "m.test", "m.deploy"}
+	if got := ids(ordered); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	cyclic := []*Issue{{ID: "a", DependsOn: []string{"b"}}, {ID: "b", DependsOn: []string{"a"}}}
+	if _, err := OrderMoleculeSteps(cyclic); !errors.Is(err, ErrCycle) {
+		t.Errorf("cyclic err = %v, want ErrCycle", err)
+	}
+}
+
+func TestComputeMoleculeProgress(t *testing.T) {
+	p := ComputeMoleculeProgress("m", []*Issue{
+		{ID: "m.build", Status: "closed"},
+		{ID: "m.test", Status: "open", DependsOn: []string{"m.build", "outside-1"}},
+		{ID: "m.lint", Status: "in_progress"},
+		{ID: "m.deploy", Status: "open", DependsOn: []string{"m.test"}},
+	})
+	if p.Total != 4 || p.Done != 1 || p.InProgress != 1 || p.Percent != 25 || p.Complete {
+		t.Errorf("progress = %+v", p)
+	}
+	if !reflect.DeepEqual(p.ReadySteps, []string{"m.test"}) || !reflect.DeepEqual(p.BlockedSteps, []string{"m.deploy"}) {
+		t.Errorf("ready = %v, blocked = %v", p.ReadySteps, p.BlockedSteps)
+	}
+}
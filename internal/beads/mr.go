@@ -0,0 +1,118 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MRCreateOptions are the issue-level options for CreateMR. The MR's
+// structured fields go in the MRFields argument instead.
+type MRCreateOptions struct {
+	Title    string // Defaults to "Merge: <source issue>"
+	Priority int    // 0-4; negative leaves it to bd
+	Actor    string // Who is submitting the MR (populates created_by)
+}
+
+// CreateMR files a merge-request bead carrying fields. Branch and Target
+// are required. The fields are written to the description (bd create has no
+// metadata flag) and then moved into metadata where bd supports it; if that
+// move fails the description copy still serves readers.
+func (b *Beads) CreateMR(fields MRFields, opts MRCreateOptions) (*Issue, error) {
+	if fields.Branch == "" {
+		return nil, &ValidationError{Field: "Branch", Value: fields.Branch, Reason: "merge request needs a source branch"}
+	}
+	if fields.Target == "" {
+		return nil, &ValidationError{Field: "Target", Value: fields.Target, Reason: "merge request needs a target branch"}
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = "Merge: " + fields.Branch
+		if fields.SourceIssue != "" {
+			title = "Merge: " + fields.SourceIssue
+		}
+	}
+	desc := FormatMRFields(&fields)
+	issue, err := b.Create(CreateOptions{
+		Title:       title,
+		Type:        "merge-request",
+		Priority:    opts.Priority,
+		Description: desc,
+		Actor:       opts.Actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if b.useMetadata() {
+		_ = b.StoreMRFields(&Issue{ID: issue.ID, Description: desc}, &fields) // Best effort
+	}
+	return issue, nil
+}
+
+// ListOpenMRs returns the open merge requests into target, or into any
+// branch when target is empty.
+func (b *Beads) ListOpenMRs(target string) ([]*Issue, error) {
+	issues, err := b.List(ListOptions{
+		Status:   "open",
+		Type:     "merge-request",
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if target == "" {
+		return issues, nil
+	}
+
+	var out []*Issue
+	for _, issue := range issues {
+		if fields := ParseMRFields(issue); fields != nil && fields.Target == target {
+			out = append(out, issue)
+		}
+	}
+	return out, nil
+}
+
+// CloseMR records mergeCommit (if any) and reason in the MR's fields, then
+// closes it with reason ("merged", "rejected", "conflict", "superseded").
+// The MR is closed even if its description can't be updated, so a merged
+// MR is never picked up again; the update error is still returned.
+func (b *Beads) CloseMR(id, mergeCommit, reason string) error {
+	var updateErr error
+	if mr, err := b.Show(id); err != nil {
+		updateErr = fmt.Errorf("fetching MR %s: %w", id, err)
+	} else {
+		fields := ParseMRFields(mr)
+		if fields == nil {
+			fields = &MRFields{}
+		}
+		if mergeCommit != "" {
+			fields.MergeCommit = mergeCommit
+		}
+		fields.CloseReason = reason
+		if err := b.StoreMRFields(mr, fields); err != nil {
+			updateErr = fmt.Errorf("recording close fields on MR %s: %w", id, err)
+		}
+	}
+
+	if err := b.CloseWithReason(reason, id); err != nil {
+		return errors.Join(updateErr, fmt.Errorf("closing MR %s: %w", id, err))
+	}
+	return updateErr
+}
+
+// MRForBranch returns the open merge request for branch, or nil if there
+// is none. This keeps `gt done` idempotent: an existing MR is reused
+// rather than filed twice.
+func (b *Beads) MRForBranch(branch string) (*Issue, error) {
+	issues, err := b.ListOpenMRs("")
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		if fields := ParseMRFields(issue); fields != nil && fields.Branch == branch {
+			return issue, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,89 @@
+package beads
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const fakeMRQueue = `
+log=$(dirname "$0")/log
+echo "$@" >> "$log"
+case "$2" in
+list)
+  printf '%s\n' '[{"id":"gt-1","type":"merge-request","description":"branch: polecat/a\ntarget: main"},` +
+	`{"id":"gt-2","type":"merge-request","description":"Rebased.\nbranch: polecat/b\ntarget: integration/gt-epic"}]'
+  ;;
+show)
+  printf '%s\n' '[{"id":"gt-1","type":"merge-request","description":"branch: polecat/a\ntarget: main\n\nNotes stay."}]'
+  ;;
+create)
+  echo '{"id":"gt-9"}'
+  ;;
+esac
+`
+
+func TestMRLifecycle(t *testing.T) {
+	installFakeBd(t, fakeMRQueue)
+	b := New(t.TempDir())
+
+	into, err := b.ListOpenMRs("integration/gt-epic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(into); len(got) != 1 || got[0] != "gt-2" {
+		t.Errorf("ListOpenMRs(integration) = %v", got)
+	}
+
+	mr, err := b.MRForBranch("polecat/b")
+	if err != nil || mr == nil || mr.ID != "gt-2" {
+		t.Errorf("MRForBranch(polecat/b) = %v, %v", mr, err)
+	}
+	if mr, _ := b.MRForBranch("polecat"); mr != nil {
+		t.Errorf("MRForBranch matched a branch prefix: %s", mr.ID)
+	}
+
+	created, err := b.CreateMR(MRFields{Branch: "polecat/c", Target: "main", SourceIssue: "gt-5"}, MRCreateOptions{Priority: 1})
+	if err != nil || created.ID != "gt-9" {
+		t.Fatalf("CreateMR = %v, %v", created, err)
+	}
+	if err := b.CloseMR("gt-1", "abc123", "merged"); err != nil {
+		t.Fatal(err)
+	}
+
+	bdPath, err := exec.LookPath("bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	for _, want := range []string{
+		"--title=Merge: gt-5",
+		"--type=merge-request",
+		"branch: polecat/c\ntarget: main\nsource_issue: gt-5",
+		"update gt-9 --description= --set-metadata=branch=polecat/c --set-metadata=source_issue=gt-5 --set-metadata=target=main",
+		"update gt-1 --description=Notes stay. --set-metadata=branch=polecat/a --set-metadata=close_reason=merged --set-metadata=merge_commit=abc123 --set-metadata=target=main",
+		"close gt-1 --reason=merged",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("bd log missing %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestCreateMRRequiresBranches(t *testing.T) {
+	b := New(t.TempDir())
+	var verr *ValidationError
+	if _, err := b.CreateMR(MRFields{Target: "main"}, MRCreateOptions{}); !errors.As(err, &verr) || verr.Field != "Branch" {
+		t.Errorf("missing branch: err = %v", err)
+	}
+	if _, err := b.CreateMR(MRFields{Branch: "polecat/a"}, MRCreateOptions{}); !errors.As(err, &verr) || verr.Field != "Target" {
+		t.Errorf("missing target: err = %v", err)
+	}
+}
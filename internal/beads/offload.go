@@ -0,0 +1,68 @@
+// Package beads provides offloading of long descriptions to temp files.
+package beads
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// MaxInlineDescription is the largest description (in bytes) passed to bd as a
+// command-line argument. Longer descriptions are written to a temp file and
+// passed via --description-file, since very long argv entries are truncated or
+// rejected by bd (and by the OS on some platforms).
+const MaxInlineDescription = 8 * 1024
+
+// offloadLongDescriptions rewrites any --description=<value> argument whose
+// value exceeds MaxInlineDescription into --description-file=<tmpfile>.
+// The returned cleanup function removes any temp files and is always non-nil.
+func offloadLongDescriptions(args []string) ([]string, func(), error) {
+	var tmpFiles []string
+	cleanup := func() {
+		for _, f := range tmpFiles {
+			_ = os.Remove(f)
+		}
+	}
+
+	var out []string
+	for i, arg := range args {
+		if arg == "--" {
+			break // Positionals from here on, even if they look like flags
+		}
+		value, ok := strings.CutPrefix(arg, "--description=")
+		if !ok || len(value) <= MaxInlineDescription {
+			continue
+		}
+		if out == nil {
+			out = append([]string(nil), args...)
+		}
+
+		f, err := os.CreateTemp("", "gt-description-*.md")
+		if err != nil {
+			cleanup()
+			return nil, func() {}, fmt.Errorf("creating description file: %w", err)
+		}
+		tmpFiles = append(tmpFiles, f.Name())
+		_, writeErr := f.WriteString(value)
+		closeErr := f.Close()
+		if writeErr != nil || closeErr != nil {
+			cleanup()
+			return nil, func() {}, fmt.Errorf("writing description file: %v", firstErr(writeErr, closeErr))
+		}
+		out[i] = "--description-file=" + f.Name()
+	}
+
+	if out == nil {
+		return args, cleanup, nil
+	}
+	return out, cleanup, nil
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package beads
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestOffloadLongDescriptions(t *testing.T) {
+	short := "--description=hello"
+	long := strings.Repeat("x", MaxInlineDescription+1)
+
+	args := []string{"create", "--json", "--title=t", short}
+	got, cleanup, err := offloadLongDescriptions(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup()
+	if got[3] != short {
+		t.Errorf("short description rewritten: %q", got[3])
+	}
+
+	args = []string{"update", "gt-1", "--description=" + long}
+	got, cleanup, err = offloadLongDescriptions(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, ok := strings.CutPrefix(got[2], "--description-file=")
+	if !ok {
+		t.Fatalf("expected --description-file, got %q", got[2])
+	}
+	if !strings.HasPrefix(args[2], "--description=") {
+		t.Error("original args slice was modified")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != long {
+		t.Error("description file content mismatch")
+	}
+
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("cleanup did not remove temp file")
+	}
+}
@@ -0,0 +1,121 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// NoLimit as ListOptions.Limit lists every matching issue, as zero does;
+// it says so at the call site.
+const NoLimit = -1
+
+// readyPageSize is the initial bd ready limit used by ReadyWithType.
+const readyPageSize = 100
+
+// ListResult is one page of List results.
+type ListResult struct {
+	Issues     []*Issue
+	HasMore    bool // more issues match beyond this page
+	NextOffset int  // Offset for the following page (valid when HasMore)
+}
+
+// ListPage returns one page of issues matching opts. bd has no offset
+// flag, so the page is cut client-side from a result one longer than
+// Offset+Limit; the extra row is how HasMore is detected.
+func (b *Beads) ListPage(opts ListOptions) (*ListResult, error) {
+	args := []string{"list", "--json"}
+
+	if opts.Status != "" {
+		args = append(args, "--status="+opts.Status)
+	}
+	if opts.Type != "" {
+		args = append(args, "--type="+opts.Type)
+	}
+	if opts.Priority >= 0 {
+		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
+	}
+	if opts.Parent != "" {
+		args = append(args, "--parent="+opts.Parent)
+	}
+	if opts.Assignee != "" {
+		args = append(args, "--assignee="+opts.Assignee)
+	}
+	if opts.NoAssignee {
+		args = append(args, "--no-assignee")
+	}
+	for _, label := range opts.labels() {
+		args = append(args, "--label="+label)
+	}
+	if opts.SortBy != "" {
+		args = append(args, "--sort="+opts.SortBy)
+	}
+	// Without a limit, ask for every match: bd's own default would cut the
+	// list short without saying so
+	if opts.Limit > 0 {
+		args = append(args, "--limit="+strconv.Itoa(max(opts.Offset, 0)+opts.Limit+1))
+	} else {
+		args = append(args, "--limit=0")
+	}
+
+	out, err := b.run(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	}
+	issues = FilterVisible(issues, b.scope)
+
+	page, more := Paginate(issues, opts.Offset, opts.Limit)
+	result := &ListResult{Issues: page, HasMore: more}
+	if more {
+		result.NextOffset = max(opts.Offset, 0) + len(page)
+	}
+	return result, nil
+}
+
+// labels returns Label and Labels combined.
+func (opts ListOptions) labels() []string {
+	if opts.Label == "" {
+		return opts.Labels
+	}
+	return append([]string{opts.Label}, opts.Labels...)
+}
+
+// HasAllLabels reports whether issue carries every label in want.
+func HasAllLabels(issue *Issue, want ...string) bool {
+	for _, label := range want {
+		if !hasLabel(issue, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListAll returns every issue matching opts from opts.Offset on; Limit is
+// ignored. bd has no offset flag, so rather than page with ListPage (each
+// page re-reading all the ones before it) the list is read once and cut
+// locally.
+func (b *Beads) ListAll(opts ListOptions) ([]*Issue, error) {
+	opts.Limit = NoLimit
+	return b.List(opts)
+}
+
+// Paginate applies offset and limit to issues, reporting whether any
+// issues fall beyond the returned page. A limit <= 0 keeps everything
+// after offset.
+func Paginate(issues []*Issue, offset, limit int) ([]*Issue, bool) {
+	if offset > 0 {
+		if offset >= len(issues) {
+			return nil, false
+		}
+		issues = issues[offset:]
+	}
+	if limit > 0 && len(issues) > limit {
+		return issues[:limit], true
+	}
+	return issues, false
+}
@@ -0,0 +1,133 @@
+package beads
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	issues := make([]*Issue, 5)
+	for i := range issues {
+		issues[i] = &Issue{ID: fmt.Sprintf("gt-%d", i)}
+	}
+
+	tests := []struct {
+		offset, limit int
+		wantFirst     string
+		wantLen       int
+		wantMore      bool
+	}{
+		{0, 0, "gt-0", 5, false},
+		{0, 2, "gt-0", 2, true},
+		{2, 2, "gt-2", 2, true},
+		{4, 2, "gt-4", 1, false},
+		{3, 2, "gt-3", 2, false},
+		{9, 2, "", 0, false},
+	}
+	for _, tt := range tests {
+		page, more := Paginate(issues, tt.offset, tt.limit)
+		if len(page) != tt.wantLen || more != tt.wantMore {
+			t.Errorf("Paginate(off=%d, lim=%d) = %d issues, more=%v; want %d, %v",
+				tt.offset, tt.limit, len(page), more, tt.wantLen, tt.wantMore)
+			continue
+		}
+		if tt.wantLen > 0 && page[0].ID != tt.wantFirst {
+			t.Errorf("Paginate(off=%d, lim=%d) starts at %s, want %s", tt.offset, tt.limit, page[0].ID, tt.wantFirst)
+		}
+	}
+}
+
+// fakeListBd emits n issues, truncated to --limit when given, and logs args.
+const fakeListBd = `
+echo "$@" >> "$(dirname "$0")/args"
+n=7
+for a in "$@"; do
+  case "$a" in --limit=0) ;; --limit=*) l=${a#--limit=}; [ "$l" -lt "$n" ] && n=$l;; esac
+done
+printf '['
+i=0
+while [ $i -lt $n ]; do
+  [ $i -gt 0 ] && printf ','
+  printf '{"id":"gt-%d"}' $i
+  i=$((i+1))
+done
+echo ']'
+`
+
+func TestListPage(t *testing.T) {
+	installFakeBd(t, fakeListBd)
+	b := New(t.TempDir())
+
+	page, err := b.ListPage(ListOptions{Priority: -1, Label: "gt:agent", Labels: []string{"rig:gastown"}, Limit: 3, Offset: 3, SortBy: "priority"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Issues) != 3 || page.Issues[0].ID != "gt-3" || !page.HasMore || page.NextOffset != 6 {
+		t.Errorf("page = %+v", page)
+	}
+
+	page, err = b.ListPage(ListOptions{Priority: -1, Limit: 3, Offset: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Issues) != 1 || page.HasMore {
+		t.Errorf("last page = %+v", page)
+	}
+
+	bdPath, _ := exec.LookPath("bd")
+	args, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "args"))
+	if !strings.Contains(string(args), "--label=gt:agent --label=rig:gastown --sort=priority --limit=7") {
+		t.Errorf("bd args = %q", args)
+	}
+}
+
+func TestListPageDefaultLimit(t *testing.T) {
+	installFakeBd(t, fakeListBd)
+	b := New(t.TempDir())
+
+	page, err := b.ListPage(ListOptions{Priority: -1, Offset: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Issues) != 5 || page.HasMore {
+		t.Errorf("page = %+v, want the 5 issues after offset 2", page)
+	}
+	bdPath, _ := exec.LookPath("bd")
+	args, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "args"))
+	if !strings.HasSuffix(strings.TrimSpace(string(args)), "--limit=0") {
+		t.Errorf("bd args = %q, want an unlimited list", args)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	installFakeBd(t, fakeListBd)
+	b := New(t.TempDir())
+
+	all, err := b.ListAll(ListOptions{Priority: -1, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 7 || all[6].ID != "gt-6" {
+		t.Errorf("ListAll returned %d issues", len(all))
+	}
+
+	rest, err := b.ListAll(ListOptions{Priority: -1, Offset: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 2 || rest[0].ID != "gt-5" {
+		t.Errorf("ListAll from offset 5 = %d issues", len(rest))
+	}
+
+	// One bd call each, reading the whole list
+	bdPath, _ := exec.LookPath("bd")
+	args, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "args"))
+	lines := strings.Split(strings.TrimSpace(string(args)), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "--limit=0") || !strings.HasSuffix(lines[1], "--limit=0") {
+		t.Errorf("bd calls = %q, want two unlimited lists", lines)
+	}
+}
@@ -0,0 +1,125 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrParentCycle is returned by SetParent when the move would make an issue
+// its own ancestor.
+var ErrParentCycle = errors.New("parent cycle")
+
+// SetParent moves id under newParent, replacing any current parent. It
+// walks newParent's ancestors first and refuses with ErrParentCycle if id
+// is among them, so an epic can't be filed under its own descendant.
+//
+// bd update --parent is used when available; older bd versions get the
+// equivalent parent-child dependency edits.
+func (b *Beads) SetParent(id, newParent string) error {
+	if err := checkIDs(id, newParent); err != nil {
+		return err
+	}
+	if id == newParent {
+		return fmt.Errorf("%s under itself: %w", id, ErrParentCycle)
+	}
+	if err := b.checkAncestors(id, newParent); err != nil {
+		return err
+	}
+
+	_, err := b.run("update", id, "--parent="+newParent)
+	if err == nil || !isUnknownFlag(err) {
+		return err
+	}
+
+	issue, err := b.Show(id)
+	if err != nil {
+		return err
+	}
+	if old := parentOf(issue); old == newParent {
+		return nil
+	} else if old != "" {
+		if _, err := b.run("dep", "remove", id, old); err != nil {
+			return err
+		}
+	}
+	_, err = b.run("dep", "add", id, newParent, "--type="+DepParentChild)
+	return err
+}
+
+// ClearParent detaches id from its parent, if it has one.
+func (b *Beads) ClearParent(id string) error {
+	if err := checkIDs(id); err != nil {
+		return err
+	}
+	_, err := b.run("update", id, "--parent=")
+	if err == nil || !isUnknownFlag(err) {
+		return err
+	}
+
+	issue, err := b.Show(id)
+	if err != nil {
+		return err
+	}
+	if old := parentOf(issue); old != "" {
+		_, err = b.run("dep", "remove", id, old)
+	}
+	return err
+}
+
+// checkAncestors follows parent links up from start and fails if it
+// reaches id.
+func (b *Beads) checkAncestors(id, start string) error {
+	seen := map[string]bool{}
+	for cur := start; cur != ""; {
+		if cur == id {
+			return fmt.Errorf("%s is an ancestor of %s: %w", id, start, ErrParentCycle)
+		}
+		if seen[cur] {
+			return nil // Existing loop above us; not ours to report
+		}
+		seen[cur] = true
+		issue, err := b.Show(cur)
+		if err != nil {
+			return err
+		}
+		cur = parentOf(issue)
+	}
+	return nil
+}
+
+// ParentEpic returns the nearest epic above id; see ParentEpicWith.
+func (b *Beads) ParentEpic(id string) (string, error) {
+	return ParentEpicWith(b, id)
+}
+
+// ParentEpicWith walks id's ancestors and returns the first that is an
+// epic, or "" if none is.
+func ParentEpicWith(c Client, id string) (string, error) {
+	seen := map[string]bool{id: true}
+	issue, err := c.Show(id)
+	for err == nil {
+		parent := parentOf(issue)
+		if parent == "" || seen[parent] {
+			return "", nil
+		}
+		seen[parent] = true
+		if issue, err = c.Show(parent); err == nil && issue.Type == "epic" {
+			return issue.ID, nil
+		}
+	}
+	return "", err
+}
+
+// parentOf returns issue's parent ID from Parent or, for bd versions that
+// only report it there, a parent-child dependency.
+func parentOf(issue *Issue) string {
+	if issue.Parent != "" {
+		return issue.Parent
+	}
+	for _, d := range issue.Dependencies {
+		if d.DependencyType == DepParentChild {
+			return d.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package beads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// parentTree is a fake bd show for gt-root ← gt-epic ← gt-task.
+const parentTree = `
+case "$*" in
+*"show gt-root"*) echo '[{"id":"gt-root","status":"open"}]';;
+*"show gt-epic"*) echo '[{"id":"gt-epic","status":"open","parent":"gt-root"}]';;
+*"show gt-task"*) echo '[{"id":"gt-task","status":"open","dependencies":[{"id":"gt-epic","dependency_type":"parent-child"}]}]';;
+esac
+`
+
+func TestSetParentRejectsCycle(t *testing.T) {
+	installFakeBd(t, `echo "$@" >> "$(dirname "$0")/log"`+parentTree)
+	b := New(t.TempDir())
+
+	if err := b.SetParent("gt-root", "gt-task"); !errors.Is(err, ErrParentCycle) {
+		t.Errorf("SetParent(root under task) = %v, want ErrParentCycle", err)
+	}
+	if err := b.SetParent("gt-epic", "gt-epic"); !errors.Is(err, ErrParentCycle) {
+		t.Errorf("SetParent(self) = %v, want ErrParentCycle", err)
+	}
+	if strings.Contains(bdLog(t), "update") {
+		t.Errorf("cycle was written:\n%s", bdLog(t))
+	}
+
+	if err := b.SetParent("gt-task", "gt-root"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ClearParent("gt-epic"); err != nil {
+		t.Fatal(err)
+	}
+	log := bdLog(t)
+	for _, want := range []string{"update gt-task --parent=gt-root", "update gt-epic --parent="} {
+		if !strings.Contains(log, want) {
+			t.Errorf("missing %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestSetParentFallback(t *testing.T) {
+	installFakeBd(t, `
+echo "$@" >> "$(dirname "$0")/log"
+case "$*" in
+*--parent*) echo "Error: unknown flag: --parent" >&2; exit 1;;
+esac`+parentTree)
+	b := New(t.TempDir())
+
+	if err := b.SetParent("gt-task", "gt-root"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ClearParent("gt-epic"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ClearParent("gt-root"); err != nil {
+		t.Fatal(err)
+	}
+	log := bdLog(t)
+	for _, want := range []string{
+		"dep remove gt-task gt-epic",
+		"dep add gt-task gt-root --type=parent-child",
+		"dep remove gt-epic gt-root",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("missing %q:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, "dep remove gt-root") {
+		t.Errorf("cleared a parent gt-root doesn't have:\n%s", log)
+	}
+}
+
+func TestAddDependencyTyped(t *testing.T) {
+	installFakeBd(t, `echo "$@" >> "$(dirname "$0")/log"`)
+	b := New(t.TempDir())
+
+	if err := b.AddDependencyTyped("gt-1", "gt-2", DepDiscoveredFrom); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddDependency("gt-1", "gt-3"); err != nil {
+		t.Fatal(err)
+	}
+	var verr *ValidationError
+	if err := b.AddDependencyTyped("gt-1", "gt-4", "kinda-blocks"); !errors.As(err, &verr) {
+		t.Errorf("unknown type: err = %v, want ValidationError", err)
+	}
+	if got, want := bdLog(t), "--no-daemon dep add gt-1 gt-2 --type=discovered-from\n--no-daemon dep add gt-1 gt-3\n"; got != want {
+		t.Errorf("bd calls = %q, want %q", got, want)
+	}
+}
+
+func TestParentEpic(t *testing.T) {
+	installFakeBd(t, `
+case "$*" in
+*"show gt-epic"*) echo '[{"id":"gt-epic","issue_type":"epic","status":"open"}]';;
+*"show gt-story"*) echo '[{"id":"gt-story","issue_type":"feature","status":"open","parent":"gt-epic"}]';;
+*"show gt-task"*) echo '[{"id":"gt-task","issue_type":"task","status":"open","dependencies":[{"id":"gt-story","dependency_type":"parent-child"}]}]';;
+*"show gt-loose"*) echo '[{"id":"gt-loose","issue_type":"task","status":"open"}]';;
+esac
+`)
+	b := New(t.TempDir())
+
+	if epic, err := b.ParentEpic("gt-task"); err != nil || epic != "gt-epic" {
+		t.Errorf("ParentEpic(gt-task) = %q, %v; want gt-epic", epic, err)
+	}
+	if epic, err := b.ParentEpic("gt-loose"); err != nil || epic != "" {
+		t.Errorf("ParentEpic(gt-loose) = %q, %v; want none", epic, err)
+	}
+}
@@ -0,0 +1,49 @@
+package beads
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Priority levels understood by bd. Lower numbers are more urgent.
+const (
+	PriorityCritical = 0
+	PriorityHigh     = 1
+	PriorityMedium   = 2
+	PriorityLow      = 3
+	PriorityBacklog  = 4
+
+	// PriorityUnset tells List and Create not to pass a priority flag.
+	PriorityUnset = -1
+)
+
+// priorityNames maps built-in level names to values.
+var priorityNames = map[string]int{
+	"critical": PriorityCritical,
+	"urgent":   PriorityCritical,
+	"high":     PriorityHigh,
+	"medium":   PriorityMedium,
+	"normal":   PriorityMedium,
+	"low":      PriorityLow,
+	"backlog":  PriorityBacklog,
+}
+
+// ParsePriority accepts "2", "P2", or a built-in level name such as "high".
+// Town-specific level names are resolved by config.PriorityConfig.
+func ParsePriority(s string) (int, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if p, ok := priorityNames[v]; ok {
+		return p, nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(v, "p"))
+	if err != nil || n < PriorityCritical || n > PriorityBacklog {
+		return 0, fmt.Errorf("invalid priority %q: want 0-4, P0-P4, or a level name", s)
+	}
+	return n, nil
+}
+
+// PriorityLabel formats a priority as "P<n>".
+func PriorityLabel(p int) string {
+	return fmt.Sprintf("P%d", p)
+}
@@ -0,0 +1,29 @@
+package beads
+
+import "testing"
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"0", PriorityCritical, true},
+		{"P3", PriorityLow, true},
+		{"p1", PriorityHigh, true},
+		{"High", PriorityHigh, true},
+		{"backlog", PriorityBacklog, true},
+		{"5", 0, false},
+		{"-1", 0, false},
+		{"whenever", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParsePriority(tt.in)
+		if tt.ok && (err != nil || got != tt.want) {
+			t.Errorf("ParsePriority(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("ParsePriority(%q) = %d, want error", tt.in, got)
+		}
+	}
+}
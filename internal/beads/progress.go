@@ -0,0 +1,58 @@
+package beads
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// ProgressFunc receives bd's stderr output one line at a time while a
+// command runs. Carriage-return updates (progress bars) arrive as
+// separate lines.
+type ProgressFunc func(line string)
+
+// SetProgress streams stderr from every bd command run by b to fn as it is
+// produced, so long operations (sync, large imports) can show live
+// progress. Stderr is still captured for error reporting. Pass nil to
+// stop streaming.
+func (b *Beads) SetProgress(fn ProgressFunc) {
+	b.progress = fn
+}
+
+// lineWriter splits written bytes into lines for a ProgressFunc.
+type lineWriter struct {
+	mu  sync.Mutex
+	fn  ProgressFunc
+	buf bytes.Buffer
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		data := w.buf.Bytes()
+		i := bytes.IndexAny(data, "\r\n")
+		if i < 0 {
+			break
+		}
+		line := string(data[:i])
+		w.buf.Next(i + 1)
+		if line = strings.TrimSpace(line); line != "" {
+			w.fn(line)
+		}
+	}
+	return len(p), nil
+}
+
+// Flush delivers any trailing partial line.
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if line := strings.TrimSpace(w.buf.String()); line != "" {
+		w.fn(line)
+	}
+	w.buf.Reset()
+}
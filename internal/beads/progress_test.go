@@ -0,0 +1,38 @@
+package beads
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLineWriter(t *testing.T) {
+	var got []string
+	w := &lineWriter{fn: func(line string) { got = append(got, line) }}
+
+	_, _ = w.Write([]byte("Importing 1/3\rImporting 2/3\rImp"))
+	_, _ = w.Write([]byte("orting 3/3\n\nDone"))
+	w.Flush()
+
+	want := []string{"Importing 1/3", "Importing 2/3", "Importing 3/3", "Done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestSetProgressStreamsStderr(t *testing.T) {
+	installFakeBd(t, `
+echo "Exporting issues..." >&2
+echo "Pushing to remote" >&2
+echo '[]'
+`)
+	b := New(t.TempDir())
+	var lines []string
+	b.SetProgress(func(line string) { lines = append(lines, line) })
+
+	if _, err := b.Run("sync"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Exporting issues...", "Pushing to remote"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("progress = %q, want %q", lines, want)
+	}
+}
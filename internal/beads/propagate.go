@@ -0,0 +1,94 @@
+package beads
+
+import "fmt"
+
+// LabelPinnedPriority marks an issue whose priority must not be changed by
+// PropagatePriority. Only that issue is pinned; its children still follow.
+const LabelPinnedPriority = "pinned-priority"
+
+// PriorityChange describes one issue touched (or skipped) by propagation.
+type PriorityChange struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	From  int    `json:"from"`
+	To    int    `json:"to"`
+}
+
+// PropagateResult summarizes a priority propagation.
+type PropagateResult struct {
+	Changed   []PriorityChange `json:"changed"`
+	Pinned    []PriorityChange `json:"pinned,omitempty"` // Skipped due to LabelPinnedPriority
+	Unchanged int              `json:"unchanged"`        // Already at target priority
+	Failed    []string         `json:"failed,omitempty"` // IDs whose update failed
+	DryRun    bool             `json:"dry_run,omitempty"`
+}
+
+// PropagatePriority sets priority on an epic and all of its open descendants.
+// See PropagatePriorityWith.
+func (b *Beads) PropagatePriority(epicID string, priority int, dryRun bool) (*PropagateResult, error) {
+	return PropagatePriorityWith(b, epicID, priority, dryRun)
+}
+
+// PropagatePriorityWith walks the parent/child tree under epicID and sets
+// every open node to priority. The epic itself is always updated; descendants
+// labeled LabelPinnedPriority keep their priority. With dryRun, the result
+// lists what would change without updating anything.
+func PropagatePriorityWith(c Client, epicID string, priority int, dryRun bool) (*PropagateResult, error) {
+	if priority < PriorityCritical || priority > PriorityBacklog {
+		return nil, fmt.Errorf("invalid priority %d: want %d-%d", priority, PriorityCritical, PriorityBacklog)
+	}
+
+	root, err := c.Show(epicID)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", epicID, err)
+	}
+
+	result := &PropagateResult{DryRun: dryRun}
+	visited := map[string]bool{root.ID: true}
+	queue := []*Issue{root}
+
+	for len(queue) > 0 {
+		issue := queue[0]
+		queue = queue[1:]
+
+		change := PriorityChange{ID: issue.ID, Title: issue.Title, From: issue.Priority, To: priority}
+		switch {
+		case issue.Priority == priority:
+			result.Unchanged++
+		case issue.ID != root.ID && hasLabel(issue, LabelPinnedPriority):
+			result.Pinned = append(result.Pinned, change)
+		case dryRun:
+			result.Changed = append(result.Changed, change)
+		default:
+			p := priority
+			if err := c.Update(issue.ID, UpdateOptions{Priority: &p}); err != nil {
+				result.Failed = append(result.Failed, issue.ID)
+			} else {
+				result.Changed = append(result.Changed, change)
+			}
+		}
+
+		children, err := c.List(ListOptions{Parent: issue.ID, Priority: PriorityUnset})
+		if err != nil {
+			return result, fmt.Errorf("listing children of %s: %w", issue.ID, err)
+		}
+		for _, child := range children {
+			if visited[child.ID] || child.Status == "closed" {
+				continue
+			}
+			visited[child.ID] = true
+			queue = append(queue, child)
+		}
+	}
+
+	return result, nil
+}
+
+func hasLabel(issue *Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package beads_test
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+)
+
+func TestPropagatePriority(t *testing.T) {
+	f := beadstest.NewFakeClient()
+	epic, _ := f.Create(beads.CreateOptions{Title: "Epic", Type: "epic", Priority: beads.PriorityLow})
+	a, _ := f.Create(beads.CreateOptions{Title: "A", Parent: epic.ID, Priority: beads.PriorityLow})
+	pinned, _ := f.Create(beads.CreateOptions{Title: "Pinned", Parent: epic.ID, Priority: beads.PriorityBacklog,
+		Labels: []string{beads.LabelPinnedPriority}})
+	grandchild, _ := f.Create(beads.CreateOptions{Title: "Grandchild", Parent: pinned.ID, Priority: beads.PriorityLow})
+	closed, _ := f.Create(beads.CreateOptions{Title: "Closed", Parent: epic.ID, Priority: beads.PriorityLow})
+	_ = f.Close(closed.ID)
+
+	// Dry run changes nothing.
+	res, err := beads.PropagatePriorityWith(f, epic.ID, beads.PriorityHigh, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Changed) != 3 || len(res.Pinned) != 1 {
+		t.Errorf("dry run = %+v", res)
+	}
+	if got, _ := f.Show(a.ID); got.Priority != beads.PriorityLow {
+		t.Errorf("dry run modified %s", a.ID)
+	}
+
+	if _, err := beads.PropagatePriorityWith(f, epic.ID, beads.PriorityHigh, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{epic.ID, a.ID, grandchild.ID} {
+		if got, _ := f.Show(id); got.Priority != beads.PriorityHigh {
+			t.Errorf("%s priority = %d, want %d", id, got.Priority, beads.PriorityHigh)
+		}
+	}
+	if got, _ := f.Show(pinned.ID); got.Priority != beads.PriorityBacklog {
+		t.Errorf("pinned issue was reprioritized to %d", got.Priority)
+	}
+	if got, _ := f.Show(closed.ID); got.Priority != beads.PriorityLow {
+		t.Errorf("closed issue was reprioritized to %d", got.Priority)
+	}
+}
+
+func TestPropagatePriorityInvalid(t *testing.T) {
+	if _, err := beads.PropagatePriorityWith(beadstest.NewFakeClient(), "gt-1", 9, true); err == nil {
+		t.Error("expected error for out-of-range priority")
+	}
+}
@@ -0,0 +1,120 @@
+package beads
+
+import "sort"
+
+// ReadyStrategy orders ready issues for scheduling. Less reports whether a
+// should be picked up before b.
+type ReadyStrategy interface {
+	Less(a, b *Issue) bool
+}
+
+// ReadyLess adapts a comparator function to ReadyStrategy.
+type ReadyLess func(a, b *Issue) bool
+
+// Less calls f(a, b).
+func (f ReadyLess) Less(a, b *Issue) bool { return f(a, b) }
+
+// GraphStrategy is a ReadyStrategy that ranks by dependency structure.
+// ReadySorted loads the graph and orders with WithGraph's result.
+type GraphStrategy interface {
+	ReadyStrategy
+	WithGraph(g *Graph) ReadyStrategy
+}
+
+// Built-in strategies. Each breaks remaining ties by ID so the order is
+// stable across calls.
+var (
+	// PriorityAge puts the most urgent first, oldest first within a
+	// priority.
+	PriorityAge ReadyStrategy = ReadyLess(priorityAgeLess)
+
+	// OldestFirst puts the longest-waiting issue first regardless of
+	// priority, then falls back to PriorityAge.
+	OldestFirst ReadyStrategy = ReadyLess(func(a, b *Issue) bool {
+		if c := compareCreated(a, b); c != 0 {
+			return c < 0
+		}
+		return priorityAgeLess(a, b)
+	})
+
+	// MostUnblocks puts first the issues whose completion unblocks the most
+	// work, directly or transitively, then falls back to PriorityAge.
+	// Without a graph it ranks by bd's direct dependent_count.
+	MostUnblocks GraphStrategy = mostUnblocks{}
+)
+
+// ReadySorted returns ready issues in strategy's order; see
+// ReadySortedWith.
+func (b *Beads) ReadySorted(strategy ReadyStrategy) ([]*Issue, error) {
+	return ReadySortedWith(b, strategy)
+}
+
+// ReadySortedWith returns c's ready issues ordered by strategy. A
+// GraphStrategy costs one extra list call to build the graph.
+func ReadySortedWith(c Client, strategy ReadyStrategy) ([]*Issue, error) {
+	issues, err := c.Ready()
+	if err != nil {
+		return nil, err
+	}
+	if gs, ok := strategy.(GraphStrategy); ok && len(issues) > 1 {
+		all, err := c.List(ListOptions{Status: "all", Priority: PriorityUnset, Limit: NoLimit})
+		if err != nil {
+			return nil, err
+		}
+		strategy = gs.WithGraph(NewGraph(all))
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return strategy.Less(issues[i], issues[j]) })
+	return issues, nil
+}
+
+type mostUnblocks struct {
+	unblocks func(*Issue) int
+}
+
+func (m mostUnblocks) WithGraph(g *Graph) ReadyStrategy {
+	counts := make(map[string]int)
+	return mostUnblocks{unblocks: func(issue *Issue) int {
+		n, ok := counts[issue.ID]
+		if !ok {
+			n = len(g.TransitiveDependents(issue.ID))
+			counts[issue.ID] = n
+		}
+		return n
+	}}
+}
+
+func (m mostUnblocks) Less(a, b *Issue) bool {
+	na, nb := a.DependentCount, b.DependentCount
+	if m.unblocks != nil {
+		na, nb = m.unblocks(a), m.unblocks(b)
+	}
+	if na != nb {
+		return na > nb
+	}
+	return priorityAgeLess(a, b)
+}
+
+func priorityAgeLess(a, b *Issue) bool {
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	if c := compareCreated(a, b); c != 0 {
+		return c < 0
+	}
+	return a.ID < b.ID
+}
+
+// compareCreated orders by creation time, unknown times last.
+func compareCreated(a, b *Issue) int {
+	switch {
+	case a.CreatedAt.Equal(b.CreatedAt):
+		return 0
+	case a.CreatedAt.IsZero():
+		return 1
+	case b.CreatedAt.IsZero():
+		return -1
+	case a.CreatedAt.Before(b.CreatedAt):
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,53 @@
+package beads_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+)
+
+func TestReadySorted(t *testing.T) {
+	f := beadstest.NewFakeClient()
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	f.Add(&beads.Issue{ID: "gt-a", Priority: 2, CreatedAt: base.Add(-2 * time.Hour)})
+	f.Add(&beads.Issue{ID: "gt-b", Priority: 1, CreatedAt: base.Add(-1 * time.Hour)})
+	f.Add(&beads.Issue{ID: "gt-c", Priority: 2, CreatedAt: base.Add(-3 * time.Hour)})
+	// gt-a unblocks two issues transitively, gt-c one, gt-b none.
+	f.Add(&beads.Issue{ID: "gt-d", Priority: 0})
+	f.Add(&beads.Issue{ID: "gt-e", Priority: 0})
+	f.Add(&beads.Issue{ID: "gt-x", Priority: 0})
+	for _, edge := range [][2]string{{"gt-d", "gt-a"}, {"gt-e", "gt-d"}, {"gt-x", "gt-c"}} {
+		if err := f.AddDependency(edge[0], edge[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	byIDDesc := beads.ReadyLess(func(a, b *beads.Issue) bool { return a.ID > b.ID })
+	for _, tt := range []struct {
+		name     string
+		strategy beads.ReadyStrategy
+		want     []string
+	}{
+		{"PriorityAge", beads.PriorityAge, []string{"gt-b", "gt-c", "gt-a"}},
+		{"OldestFirst", beads.OldestFirst, []string{"gt-c", "gt-a", "gt-b"}},
+		{"MostUnblocks", beads.MostUnblocks, []string{"gt-a", "gt-c", "gt-b"}},
+		{"custom", byIDDesc, []string{"gt-c", "gt-b", "gt-a"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := beads.ReadySortedWith(f, tt.strategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package beads
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+)
+
+// AuditOpRelease is the audit log operation for released issues.
+const AuditOpRelease = "release"
+
+// ReleaseRecord is the structured audit entry written when an issue is
+// released back to open.
+type ReleaseRecord struct {
+	Timestamp     string `json:"timestamp"`
+	Operation     string `json:"operation"` // Always AuditOpRelease
+	IssueID       string `json:"issue_id"`
+	ReleasedBy    string `json:"released_by,omitempty"`
+	PriorAssignee string `json:"prior_assignee,omitempty"`
+	PriorStatus   string `json:"prior_status,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+func newReleaseRecord(id, reason string, prior *Issue) ReleaseRecord {
+	rec := ReleaseRecord{
+		Timestamp:  currentTimestamp(),
+		Operation:  AuditOpRelease,
+		IssueID:    id,
+		ReleasedBy: os.Getenv("BD_ACTOR"),
+		Reason:     reason,
+	}
+	if prior != nil {
+		rec.PriorAssignee = prior.Assignee
+		rec.PriorStatus = prior.Status
+	}
+	return rec
+}
+
+// logRelease appends a release record. A missing .beads directory (e.g. a
+// redirected worktree) is not an error; the release itself already succeeded.
+func (b *Beads) logRelease(rec ReleaseRecord) error {
+	err := b.appendAudit(rec)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// ReleaseHistory returns the release records for an issue, oldest first.
+func (b *Beads) ReleaseHistory(id string) ([]ReleaseRecord, error) {
+	all, err := b.releaseRecords()
+	if err != nil {
+		return nil, err
+	}
+	var out []ReleaseRecord
+	for _, rec := range all {
+		if rec.IssueID == id {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// ReleaseCounts returns issues released at least min times, keyed by ID.
+// Use it to find work that keeps bouncing between agents.
+func (b *Beads) ReleaseCounts(min int) (map[string]int, error) {
+	all, err := b.releaseRecords()
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, rec := range all {
+		counts[rec.IssueID]++
+	}
+	for id, n := range counts {
+		if n < min {
+			delete(counts, id)
+		}
+	}
+	return counts, nil
+}
+
+// releaseRecords reads all release entries from the audit log, oldest first.
+func (b *Beads) releaseRecords() ([]ReleaseRecord, error) {
+	f, err := os.Open(b.AuditLogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []ReleaseRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec ReleaseRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue // Skip malformed lines
+		}
+		if rec.Operation == AuditOpRelease {
+			out = append(out, rec)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Timestamp < out[j].Timestamp })
+	return out, nil
+}
@@ -0,0 +1,53 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReleaseHistory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	b := New(dir)
+
+	recs := []ReleaseRecord{
+		{Timestamp: "2026-01-01T10:00:00Z", Operation: AuditOpRelease, IssueID: "gt-1", PriorAssignee: "gastown/Toast", Reason: "stuck"},
+		{Timestamp: "2026-01-01T11:00:00Z", Operation: AuditOpRelease, IssueID: "gt-2"},
+		{Timestamp: "2026-01-01T12:00:00Z", Operation: AuditOpRelease, IssueID: "gt-1", PriorAssignee: "gastown/Nux"},
+	}
+	for _, r := range recs {
+		if err := b.logRelease(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Detach entries share the log and must be ignored.
+	if err := b.LogDetachAudit(DetachAuditEntry{Operation: "detach", PinnedBeadID: "gt-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	hist, err := b.ReleaseHistory("gt-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hist) != 2 || hist[0].PriorAssignee != "gastown/Toast" || hist[1].PriorAssignee != "gastown/Nux" {
+		t.Errorf("history = %+v", hist)
+	}
+
+	counts, err := b.ReleaseCounts(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 1 || counts["gt-1"] != 2 {
+		t.Errorf("ReleaseCounts(2) = %v", counts)
+	}
+}
+
+func TestLogReleaseMissingBeadsDir(t *testing.T) {
+	b := New(t.TempDir())
+	if err := b.logRelease(newReleaseRecord("gt-1", "", nil)); err != nil {
+		t.Errorf("missing .beads should be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reservation labels. A reservation is a tentative, visible hold on the
+// next bead for an agent: the bead stays open and unassigned, so it is not
+// a claim, but planners skip it and the agent can pre-read its context.
+const (
+	LabelReservedFor   = "reserved-for"   // reserved-for:<agent>
+	LabelReservedUntil = "reserved-until" // reserved-until:<unix seconds>
+)
+
+// DefaultReservationTTL is how long a reservation lasts if not claimed.
+const DefaultReservationTTL = 2 * time.Hour
+
+// ErrReserved is returned when a bead is already reserved by another agent.
+var ErrReserved = errors.New("already reserved")
+
+// Reservation is a soft hold on a bead for an agent.
+type Reservation struct {
+	IssueID string    `json:"issue_id"`
+	Title   string    `json:"title"`
+	Agent   string    `json:"agent"`
+	Expires time.Time `json:"expires"`
+}
+
+// Expired reports whether the reservation has lapsed at now.
+func (r *Reservation) Expired(now time.Time) bool {
+	return !now.Before(r.Expires)
+}
+
+// labels returns the labels encoding r.
+func (r *Reservation) labels() []string {
+	return []string{
+		LabelReservedFor + ":" + r.Agent,
+		LabelReservedUntil + ":" + strconv.FormatInt(r.Expires.Unix(), 10),
+	}
+}
+
+// ParseReservation extracts a reservation from an issue's labels.
+func ParseReservation(issue *Issue) (*Reservation, bool) {
+	r := &Reservation{IssueID: issue.ID, Title: issue.Title}
+	for _, label := range issue.Labels {
+		key, value, ok := strings.Cut(label, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case LabelReservedFor:
+			r.Agent = value
+		case LabelReservedUntil:
+			if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+				r.Expires = time.Unix(secs, 0)
+			}
+		}
+	}
+	if r.Agent == "" {
+		return nil, false
+	}
+	return r, true
+}
+
+// reservationLabels returns every reservation label on an issue.
+func reservationLabels(issue *Issue) []string {
+	var out []string
+	for _, label := range issue.Labels {
+		if strings.HasPrefix(label, LabelReservedFor+":") || strings.HasPrefix(label, LabelReservedUntil+":") {
+			out = append(out, label)
+		}
+	}
+	return out
+}
+
+// Reserve tentatively holds id for agent. See ReserveWith.
+func (b *Beads) Reserve(id, agent string, ttl time.Duration) (*Reservation, error) {
+	return ReserveWith(b, id, agent, ttl, time.Now())
+}
+
+// ReserveWith reserves an open, unassigned bead for agent until now+ttl.
+// An agent holds at most one reservation; any earlier one is dropped.
+// Re-reserving a bead the agent already holds extends it. Returns
+// ErrReserved if another agent holds an unexpired reservation.
+func ReserveWith(c Client, id, agent string, ttl time.Duration, now time.Time) (*Reservation, error) {
+	if agent == "" {
+		return nil, fmt.Errorf("reserving %s: agent required", id)
+	}
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+
+	issue, err := c.Show(id)
+	if err != nil {
+		return nil, err
+	}
+	if issue.Status != "open" || issue.Assignee != "" {
+		return nil, fmt.Errorf("reserving %s: not open and unassigned (status %s, assignee %q)", id, issue.Status, issue.Assignee)
+	}
+	if existing, ok := ParseReservation(issue); ok && existing.Agent != agent && !existing.Expired(now) {
+		return nil, fmt.Errorf("%s: %w by %s until %s", id, ErrReserved, existing.Agent, existing.Expires.Format(time.RFC3339))
+	}
+
+	held, err := ReservationsWith(c, agent)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range held {
+		if r.IssueID != id {
+			if err := CancelReservationWith(c, r.IssueID); err != nil {
+				return nil, fmt.Errorf("dropping earlier reservation %s: %w", r.IssueID, err)
+			}
+		}
+	}
+
+	r := &Reservation{IssueID: id, Title: issue.Title, Agent: agent, Expires: now.Add(ttl).Truncate(time.Second)}
+	err = c.Update(id, UpdateOptions{RemoveLabels: reservationLabels(issue), AddLabels: r.labels()})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reservations lists open reservations; see ReservationsWith.
+func (b *Beads) Reservations(agent string) ([]Reservation, error) {
+	return ReservationsWith(b, agent)
+}
+
+// ReservationsWith lists reservations on open beads, including expired
+// ones not yet cleaned up, for agent (or all agents if empty), soonest
+// expiry first.
+func ReservationsWith(c Client, agent string) ([]Reservation, error) {
+	opts := ListOptions{Status: "open", Priority: PriorityUnset, Limit: NoLimit}
+	if agent != "" {
+		opts.Label = LabelReservedFor + ":" + agent
+	}
+	issues, err := c.List(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Reservation
+	for _, issue := range issues {
+		if r, ok := ParseReservation(issue); ok && (agent == "" || r.Agent == agent) {
+			out = append(out, *r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Expires.Before(out[j].Expires) })
+	return out, nil
+}
+
+// ClaimReservation converts agent's reservation into a claim; see
+// ClaimReservationWith.
+func (b *Beads) ClaimReservation(agent string) (*Reservation, error) {
+	return ClaimReservationWith(b, agent, time.Now())
+}
+
+// ClaimReservationWith converts agent's unexpired reservation into a claim
+// with Claim, so it fails with ErrAlreadyClaimed if someone else took the
+// bead first, then removes the reservation labels. Returns nil if the agent
+// holds no live reservation; expired reservations found along the way are
+// cleared.
+func ClaimReservationWith(c Client, agent string, now time.Time) (*Reservation, error) {
+	held, err := ReservationsWith(c, agent)
+	if err != nil {
+		return nil, err
+	}
+	for i := range held {
+		r := &held[i]
+		if r.Expired(now) {
+			_ = CancelReservationWith(c, r.IssueID)
+			continue
+		}
+		if err := c.Claim(r.IssueID, agent); err != nil {
+			return nil, fmt.Errorf("claiming reserved %s: %w", r.IssueID, err)
+		}
+		// Best effort: reservations are only read from open beads
+		_ = c.Update(r.IssueID, UpdateOptions{RemoveLabels: r.labels()})
+		return r, nil
+	}
+	return nil, nil
+}
+
+// CancelReservation removes any reservation from id.
+func (b *Beads) CancelReservation(id string) error {
+	return CancelReservationWith(b, id)
+}
+
+// CancelReservationWith removes any reservation labels from id.
+func CancelReservationWith(c Client, id string) error {
+	issue, err := c.Show(id)
+	if err != nil {
+		return err
+	}
+	labels := reservationLabels(issue)
+	if len(labels) == 0 {
+		return nil
+	}
+	return c.Update(id, UpdateOptions{RemoveLabels: labels})
+}
+
+// ExpireReservations clears lapsed reservations; see ExpireReservationsWith.
+func (b *Beads) ExpireReservations() ([]Reservation, error) {
+	return ExpireReservationsWith(b, time.Now())
+}
+
+// ExpireReservationsWith clears every reservation that has lapsed at now
+// and returns them.
+func ExpireReservationsWith(c Client, now time.Time) ([]Reservation, error) {
+	all, err := ReservationsWith(c, "")
+	if err != nil {
+		return nil, err
+	}
+	var expired []Reservation
+	for _, r := range all {
+		if !r.Expired(now) {
+			continue
+		}
+		if err := CancelReservationWith(c, r.IssueID); err != nil {
+			return expired, err
+		}
+		expired = append(expired, r)
+	}
+	return expired, nil
+}
@@ -0,0 +1,97 @@
+package beads_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+)
+
+func TestReserveAndClaim(t *testing.T) {
+	f := beadstest.NewFakeClient()
+	a, _ := f.Create(beads.CreateOptions{Title: "A"})
+	b, _ := f.Create(beads.CreateOptions{Title: "B"})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	toast, nux := "gastown/polecats/Toast", "gastown/polecats/Nux"
+
+	if _, err := beads.ReserveWith(f, a.ID, toast, time.Hour, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := beads.ReserveWith(f, a.ID, nux, time.Hour, now); !errors.Is(err, beads.ErrReserved) {
+		t.Errorf("second reservation err = %v, want ErrReserved", err)
+	}
+
+	// Reserved beads stay open and unassigned.
+	shown, _ := f.Show(a.ID)
+	if shown.Status != "open" || shown.Assignee != "" {
+		t.Errorf("reserved bead = %+v", shown)
+	}
+
+	// Reserving another bead replaces the agent's earlier reservation.
+	if _, err := beads.ReserveWith(f, b.ID, toast, time.Hour, now); err != nil {
+		t.Fatal(err)
+	}
+	held, _ := beads.ReservationsWith(f, toast)
+	if len(held) != 1 || held[0].IssueID != b.ID {
+		t.Errorf("Toast holds %+v, want only %s", held, b.ID)
+	}
+
+	r, err := beads.ClaimReservationWith(f, toast, now.Add(30*time.Minute))
+	if err != nil || r == nil || r.IssueID != b.ID {
+		t.Fatalf("ClaimReservation = %+v, %v", r, err)
+	}
+	shown, _ = f.Show(b.ID)
+	if shown.Status != "in_progress" || shown.Assignee != toast || len(shown.Labels) != 0 {
+		t.Errorf("claimed bead = %+v", shown)
+	}
+}
+
+func TestClaimReservationTakenMeanwhile(t *testing.T) {
+	f := beadstest.NewFakeClient()
+	a, _ := f.Create(beads.CreateOptions{Title: "A"})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	toast, nux := "gastown/polecats/Toast", "gastown/polecats/Nux"
+
+	if _, err := beads.ReserveWith(f, a.ID, toast, time.Hour, now); err != nil {
+		t.Fatal(err)
+	}
+	// Someone assigns the reserved bead without going through Reserve.
+	if err := f.Update(a.ID, beads.UpdateOptions{Assignee: &nux}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := beads.ClaimReservationWith(f, toast, now); !errors.Is(err, beads.ErrAlreadyClaimed) {
+		t.Errorf("claim err = %v, want ErrAlreadyClaimed", err)
+	}
+	if shown, _ := f.Show(a.ID); shown.Assignee != nux {
+		t.Errorf("claim overwrote assignee: %q", shown.Assignee)
+	}
+}
+
+func TestReservationExpiry(t *testing.T) {
+	f := beadstest.NewFakeClient()
+	a, _ := f.Create(beads.CreateOptions{Title: "A"})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	toast := "gastown/polecats/Toast"
+
+	if _, err := beads.ReserveWith(f, a.ID, toast, time.Hour, now); err != nil {
+		t.Fatal(err)
+	}
+	later := now.Add(2 * time.Hour)
+
+	// Expired reservations don't block others and aren't claimed.
+	if r, err := beads.ClaimReservationWith(f, toast, later); err != nil || r != nil {
+		t.Errorf("claiming expired reservation = %+v, %v", r, err)
+	}
+	if _, err := beads.ReserveWith(f, a.ID, "gastown/polecats/Nux", time.Hour, now); err != nil {
+		t.Fatal(err)
+	}
+	expired, err := beads.ExpireReservationsWith(f, later)
+	if err != nil || len(expired) != 1 {
+		t.Errorf("ExpireReservations = %+v, %v", expired, err)
+	}
+	if all, _ := beads.ReservationsWith(f, ""); len(all) != 0 {
+		t.Errorf("reservations left after expiry: %+v", all)
+	}
+}
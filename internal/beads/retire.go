@@ -0,0 +1,192 @@
+package beads
+
+import (
+	"fmt"
+)
+
+// Retirement actions recorded in a RetireReport.
+const (
+	RetireReassigned = "reassigned"
+	RetireReleased   = "released"
+	RetireClosed     = "closed"
+	RetireKept       = "kept"
+	RetireFailed     = "failed"
+)
+
+// Mail handling options for RetirePolicy.MailAction.
+const (
+	RetireMailForward = "forward" // Reassign pending mail to the successor
+	RetireMailClose   = "close"   // Close pending mail
+	RetireMailKeep    = "keep"    // Leave pending mail untouched
+)
+
+// RetirePolicy controls how a retiring agent's work is redistributed.
+type RetirePolicy struct {
+	// Successor receives open work. If empty, work is released back to the
+	// pool (status=open, no assignee) instead.
+	Successor string
+
+	// ReleaseInProgress releases in_progress/hooked claims even when a
+	// successor is set. Half-done work is often better re-slung fresh than
+	// inherited mid-flight.
+	ReleaseInProgress bool
+
+	// MailAction is one of RetireMailForward (default), RetireMailClose, or
+	// RetireMailKeep. Forwarding without a successor falls back to keep.
+	// It also covers watched mail (the agent is cc'd): forwarding moves
+	// the cc to the successor and closing drops it.
+	MailAction string
+
+	// Reason is recorded on released and closed beads.
+	// Defaults to "agent <assignee> retired".
+	Reason string
+
+	// DryRun computes the report without mutating anything.
+	DryRun bool
+}
+
+// RetireAction records what happened to a single bead.
+type RetireAction struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Kind   string `json:"kind"`   // "issue", "claim", "mail", or "watch"
+	Status string `json:"status"` // status before retirement
+	Action string `json:"action"` // RetireReassigned, RetireReleased, ...
+	Error  string `json:"error,omitempty"`
+}
+
+// RetireReport summarizes a RetireAgent run.
+type RetireReport struct {
+	Assignee  string         `json:"assignee"`
+	Successor string         `json:"successor,omitempty"`
+	DryRun    bool           `json:"dry_run,omitempty"`
+	Actions   []RetireAction `json:"actions"`
+}
+
+// Count returns how many actions of the given kind were recorded.
+func (r *RetireReport) Count(action string) int {
+	n := 0
+	for _, a := range r.Actions {
+		if a.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// RetireAgent redistributes everything assigned to a retiring agent.
+// Open issues and pending mail go to successor; in_progress and hooked
+// claims are handed to the successor too, as is mail the agent is cc'd on.
+// With an empty successor, everything is released back to the pool.
+func (b *Beads) RetireAgent(assignee, successor string) (*RetireReport, error) {
+	return b.RetireAgentWithPolicy(assignee, RetirePolicy{Successor: successor})
+}
+
+// RetireAgentWithPolicy redistributes a retiring agent's work per policy.
+// Individual bead failures are recorded in the report rather than aborting
+// the run, so one bad bead doesn't strand the rest.
+func (b *Beads) RetireAgentWithPolicy(assignee string, policy RetirePolicy) (*RetireReport, error) {
+	return RetireAgentWith(b, assignee, policy)
+}
+
+// RetireAgentWith is RetireAgentWithPolicy against any Client.
+func RetireAgentWith(c Client, assignee string, policy RetirePolicy) (*RetireReport, error) {
+	if assignee == "" {
+		return nil, fmt.Errorf("retire: assignee is required")
+	}
+	if policy.Successor == assignee {
+		return nil, fmt.Errorf("retire: successor cannot be the retiring agent")
+	}
+	reason := policy.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("agent %s retired", assignee)
+	}
+	mailAction := policy.MailAction
+	if mailAction == "" {
+		mailAction = RetireMailForward
+	}
+	if mailAction == RetireMailForward && policy.Successor == "" {
+		mailAction = RetireMailKeep
+	}
+
+	report := &RetireReport{
+		Assignee:  assignee,
+		Successor: policy.Successor,
+		DryRun:    policy.DryRun,
+	}
+
+	for _, status := range []string{"open", "in_progress", StatusHooked} {
+		issues, err := c.List(ListOptions{Status: status, Assignee: assignee, Priority: PriorityUnset, Limit: NoLimit})
+		if err != nil {
+			return report, fmt.Errorf("listing %s issues for %s: %w", status, assignee, err)
+		}
+
+		for _, issue := range issues {
+			action := RetireAction{ID: issue.ID, Title: issue.Title, Status: issue.Status, Kind: "issue"}
+			if status != "open" {
+				action.Kind = "claim"
+			}
+			if issue.Type == TypeMessage {
+				action.Kind = "mail"
+			}
+
+			var err error
+			switch {
+			case action.Kind == "mail" && mailAction == RetireMailKeep:
+				action.Action = RetireKept
+			case action.Kind == "mail" && mailAction == RetireMailClose:
+				action.Action = RetireClosed
+				if !policy.DryRun {
+					err = c.CloseWithReason(reason, issue.ID)
+				}
+			case policy.Successor == "" || (action.Kind == "claim" && policy.ReleaseInProgress):
+				action.Action = RetireReleased
+				if !policy.DryRun {
+					err = c.ReleaseWithReason(issue.ID, reason)
+				}
+			default:
+				action.Action = RetireReassigned
+				if !policy.DryRun {
+					successor := policy.Successor
+					err = c.Update(issue.ID, UpdateOptions{Assignee: &successor})
+				}
+			}
+
+			if err != nil {
+				action.Action = RetireFailed
+				action.Error = err.Error()
+			}
+			report.Actions = append(report.Actions, action)
+		}
+	}
+
+	watched, err := c.List(ListOptions{Status: "open", Label: LabelCC + assignee, Priority: PriorityUnset, Limit: NoLimit})
+	if err != nil {
+		return report, fmt.Errorf("listing mail watched by %s: %w", assignee, err)
+	}
+	for _, issue := range watched {
+		if issue.Assignee == assignee {
+			continue // Already handled as the agent's own mail
+		}
+		action := RetireAction{ID: issue.ID, Title: issue.Title, Status: issue.Status, Kind: "watch"}
+		opts := UpdateOptions{RemoveLabels: []string{LabelCC + assignee}}
+		switch mailAction {
+		case RetireMailKeep:
+			action.Action = RetireKept
+		case RetireMailClose:
+			action.Action = RetireReleased
+		default:
+			action.Action = RetireReassigned
+			opts.AddLabels = []string{LabelCC + policy.Successor}
+		}
+		if action.Action != RetireKept && !policy.DryRun {
+			if err := c.Update(issue.ID, opts); err != nil {
+				action.Action = RetireFailed
+				action.Error = err.Error()
+			}
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	return report, nil
+}
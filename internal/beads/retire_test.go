@@ -0,0 +1,203 @@
+package beads_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+)
+
+func TestRetireAgentValidation(t *testing.T) {
+	b := beads.New(t.TempDir())
+
+	if _, err := b.RetireAgent("", "gastown/polecats/Nux"); err == nil {
+		t.Error("expected error for empty assignee")
+	}
+	if _, err := b.RetireAgent("gastown/polecats/Toast", "gastown/polecats/Toast"); err == nil {
+		t.Error("expected error when successor equals assignee")
+	}
+}
+
+func TestRetireReportCount(t *testing.T) {
+	r := &beads.RetireReport{Actions: []beads.RetireAction{
+		{ID: "gt-1", Action: beads.RetireReassigned},
+		{ID: "gt-2", Action: beads.RetireReleased},
+		{ID: "gt-3", Action: beads.RetireReassigned},
+		{ID: "gt-4", Action: beads.RetireFailed, Error: "boom"},
+	}}
+
+	if got := r.Count(beads.RetireReassigned); got != 2 {
+		t.Errorf("Count(reassigned) = %d, want 2", got)
+	}
+	if got := r.Count(beads.RetireFailed); got != 1 {
+		t.Errorf("Count(failed) = %d, want 1", got)
+	}
+}
+
+const (
+	retiring  = "gastown/polecats/Toast"
+	successor = "gastown/polecats/Nux"
+)
+
+// retireFixture seeds an open task, an in_progress claim, a hooked claim,
+// pending mail, and mail cc'd to the retiring agent, plus someone else's
+// task.
+func retireFixture() *beadstest.FakeClient {
+	f := beadstest.NewFakeClient()
+	f.Add(&beads.Issue{ID: "gt-task", Title: "Task", Type: "task", Assignee: retiring})
+	f.Add(&beads.Issue{ID: "gt-claim", Title: "Claim", Type: "task", Status: "in_progress", Assignee: retiring})
+	f.Add(&beads.Issue{ID: "gt-hooked", Title: "Hooked", Type: "task", Status: beads.StatusHooked, Assignee: retiring})
+	f.Add(&beads.Issue{ID: "gt-mail", Title: "Mail", Type: beads.TypeMessage, Assignee: retiring})
+	f.Add(&beads.Issue{ID: "gt-cc", Title: "CC", Type: beads.TypeMessage, Assignee: "mayor/", Labels: []string{beads.LabelCC + retiring}})
+	f.Add(&beads.Issue{ID: "gt-other", Title: "Other", Type: "task", Assignee: successor})
+	return f
+}
+
+// actions maps each reported bead to its action.
+func actions(report *beads.RetireReport) map[string]string {
+	out := make(map[string]string)
+	for _, a := range report.Actions {
+		out[a.ID] = a.Action
+	}
+	return out
+}
+
+func TestRetireAgentWith_Release(t *testing.T) {
+	f := retireFixture()
+
+	report, err := beads.RetireAgentWith(f, retiring, beads.RetirePolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"gt-task":   beads.RetireReleased,
+		"gt-claim":  beads.RetireReleased,
+		"gt-hooked": beads.RetireReleased,
+		"gt-mail":   beads.RetireKept, // Forwarding without a successor keeps mail
+		"gt-cc":     beads.RetireKept,
+	}
+	got := actions(report)
+	for id, action := range want {
+		if got[id] != action {
+			t.Errorf("%s: action %q, want %q", id, got[id], action)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("actions = %v, want only the retiring agent's beads", got)
+	}
+	for _, id := range []string{"gt-task", "gt-claim", "gt-hooked"} {
+		if issue, _ := f.Show(id); issue.Status != "open" || issue.Assignee != "" {
+			t.Errorf("%s not released: status %q, assignee %q", id, issue.Status, issue.Assignee)
+		}
+	}
+	if mail, _ := f.Show("gt-mail"); mail.Assignee != retiring {
+		t.Errorf("kept mail reassigned to %q", mail.Assignee)
+	}
+}
+
+func TestRetireAgentWith_Successor(t *testing.T) {
+	f := retireFixture()
+
+	report, err := beads.RetireAgentWith(f, retiring, beads.RetirePolicy{Successor: successor, ReleaseInProgress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"gt-task":   beads.RetireReassigned,
+		"gt-claim":  beads.RetireReleased,
+		"gt-hooked": beads.RetireReleased,
+		"gt-mail":   beads.RetireReassigned,
+		"gt-cc":     beads.RetireReassigned,
+	}
+	got := actions(report)
+	for id, action := range want {
+		if got[id] != action {
+			t.Errorf("%s: action %q, want %q", id, got[id], action)
+		}
+	}
+	for _, id := range []string{"gt-task", "gt-mail"} {
+		if issue, _ := f.Show(id); issue.Assignee != successor {
+			t.Errorf("%s assignee = %q, want %s", id, issue.Assignee, successor)
+		}
+	}
+	if claim, _ := f.Show("gt-claim"); claim.Assignee != "" || claim.Status != "open" {
+		t.Errorf("in_progress claim not released: %+v", claim)
+	}
+	if cc, _ := f.Show("gt-cc"); !beads.HasAllLabels(cc, beads.LabelCC+successor) || beads.HasAllLabels(cc, beads.LabelCC+retiring) {
+		t.Errorf("watched mail labels = %v, want the cc moved to %s", cc.Labels, successor)
+	}
+}
+
+func TestRetireAgentWith_CloseMail(t *testing.T) {
+	f := retireFixture()
+
+	report, err := beads.RetireAgentWith(f, retiring, beads.RetirePolicy{Successor: successor, MailAction: beads.RetireMailClose})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := actions(report)["gt-mail"]; got != beads.RetireClosed {
+		t.Errorf("mail action = %q, want %q", got, beads.RetireClosed)
+	}
+	if mail, _ := f.Show("gt-mail"); mail.Status != "closed" {
+		t.Errorf("mail status = %q, want closed", mail.Status)
+	}
+	if reason := f.CloseReasons["gt-mail"]; reason != "agent "+retiring+" retired" {
+		t.Errorf("close reason = %q", reason)
+	}
+	if cc, _ := f.Show("gt-cc"); cc.Status != "open" || len(cc.Labels) != 0 {
+		t.Errorf("watched mail = %+v, want it left open without the cc", cc)
+	}
+	if got := actions(report)["gt-claim"]; got != beads.RetireReassigned {
+		t.Errorf("claim action = %q, want %q without ReleaseInProgress", got, beads.RetireReassigned)
+	}
+}
+
+func TestRetireAgentWith_DryRun(t *testing.T) {
+	f := retireFixture()
+
+	report, err := beads.RetireAgentWith(f, retiring, beads.RetirePolicy{Successor: successor, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun || report.Count(beads.RetireReassigned) != 5 {
+		t.Errorf("dry run report = %+v", report)
+	}
+	if task, _ := f.Show("gt-task"); task.Assignee != retiring {
+		t.Errorf("dry run reassigned gt-task to %q", task.Assignee)
+	}
+}
+
+// failingUpdates fails Update for the listed beads.
+type failingUpdates struct {
+	*beadstest.FakeClient
+	fail map[string]bool
+}
+
+func (f failingUpdates) Update(id string, opts beads.UpdateOptions) error {
+	if f.fail[id] {
+		return errors.New("bd update failed")
+	}
+	return f.FakeClient.Update(id, opts)
+}
+
+func TestRetireAgentWith_PartialFailure(t *testing.T) {
+	f := retireFixture()
+	c := failingUpdates{FakeClient: f, fail: map[string]bool{"gt-task": true}}
+
+	report, err := beads.RetireAgentWith(c, retiring, beads.RetirePolicy{Successor: successor})
+	if err != nil {
+		t.Fatalf("one failed bead aborted the run: %v", err)
+	}
+	if report.Count(beads.RetireFailed) != 1 || report.Count(beads.RetireReassigned) != 4 {
+		t.Errorf("report = %+v, want 1 failed and 4 reassigned", report.Actions)
+	}
+	for _, a := range report.Actions {
+		if a.ID == "gt-task" && a.Error != "bd update failed" {
+			t.Errorf("failed action error = %q", a.Error)
+		}
+	}
+	if claim, _ := f.Show("gt-claim"); claim.Assignee != successor {
+		t.Errorf("beads after the failure not reassigned: gt-claim assignee %q", claim.Assignee)
+	}
+}
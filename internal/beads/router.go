@@ -0,0 +1,241 @@
+package beads
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Router is a Client over several beads repos, picking the repo for each
+// call from the issue ID's prefix. Calls on IDs with an unknown prefix,
+// and creates without a parent, go to the default repo. List, Ready, and
+// Blocked query every repo and concatenate the results.
+type Router struct {
+	def    Client
+	routes map[string]Client // "gt-" → repo
+	rigs   map[string]Client // rig name → repo
+}
+
+var _ Client = (*Router)(nil)
+
+// NewRouter returns a router that sends everything to def until routes
+// are added.
+func NewRouter(def Client) *Router {
+	return &Router{def: def, routes: make(map[string]Client), rigs: make(map[string]Client)}
+}
+
+// NewTownRouter builds a router from the town's routes.jsonl: the town
+// beads are the default (hq-), and each route gets a Beads at its path.
+// opts apply to every Beads the router creates.
+func NewTownRouter(townRoot string, opts ...Option) (*Router, error) {
+	routes, err := LoadRoutes(GetTownBeadsPath(townRoot))
+	if err != nil {
+		return nil, err
+	}
+	town := New(townRoot, opts...)
+	r := NewRouter(town)
+	byPath := map[string]Client{".": town}
+	for _, route := range routes {
+		c, ok := byPath[route.Path]
+		if !ok {
+			c = New(filepath.Join(townRoot, route.Path), opts...)
+			byPath[route.Path] = c
+		}
+		r.Route(route.Prefix, c)
+		if rig, _, _ := strings.Cut(route.Path, "/"); rig != "." {
+			r.rigs[rig] = c
+		}
+	}
+	return r, nil
+}
+
+// Route sends IDs with prefix (with or without the trailing hyphen) to c.
+func (r *Router) Route(prefix string, c Client) {
+	if !strings.HasSuffix(prefix, "-") {
+		prefix += "-"
+	}
+	r.routes[prefix] = c
+}
+
+// RouteRig makes c the repo returned by Rig(name).
+func (r *Router) RouteRig(name string, c Client) {
+	r.rigs[name] = c
+}
+
+// For returns the repo that owns id.
+func (r *Router) For(id string) Client {
+	if c, ok := r.routes[ExtractPrefix(id)]; ok {
+		return c
+	}
+	return r.def
+}
+
+// Rig returns the repo for a rig by name, or nil if the rig has no route.
+func (r *Router) Rig(name string) Client {
+	return r.rigs[name]
+}
+
+// clients returns each distinct repo once: the default first, then the
+// routed repos in prefix order.
+func (r *Router) clients() []Client {
+	prefixes := make([]string, 0, len(r.routes))
+	for p := range r.routes {
+		prefixes = append(prefixes, p)
+	}
+	sort.Strings(prefixes)
+
+	out := []Client{r.def}
+	for _, p := range prefixes {
+		c := r.routes[p]
+		seen := false
+		for _, o := range out {
+			if o == c {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// group splits ids by owning repo, preserving order within each repo.
+func (r *Router) group(ids []string) ([]Client, map[Client][]string) {
+	var order []Client
+	groups := make(map[Client][]string)
+	for _, id := range ids {
+		c := r.For(id)
+		if _, ok := groups[c]; !ok {
+			order = append(order, c)
+		}
+		groups[c] = append(groups[c], id)
+	}
+	return order, groups
+}
+
+func (r *Router) gather(fn func(Client) ([]*Issue, error)) ([]*Issue, error) {
+	var out []*Issue
+	for _, c := range r.clients() {
+		issues, err := fn(c)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, issues...)
+	}
+	return out, nil
+}
+
+// List lists matching issues in every repo. A positive Limit caps the
+// combined result; Offset is applied per repo.
+func (r *Router) List(opts ListOptions) ([]*Issue, error) {
+	issues, err := r.gather(func(c Client) ([]*Issue, error) { return c.List(opts) })
+	if err != nil {
+		return nil, err
+	}
+	if opts.Limit > 0 && len(issues) > opts.Limit {
+		issues = issues[:opts.Limit]
+	}
+	return issues, nil
+}
+
+// Ready returns ready work from every repo.
+func (r *Router) Ready() ([]*Issue, error) {
+	return r.gather(Client.Ready)
+}
+
+// Blocked returns blocked issues from every repo.
+func (r *Router) Blocked() ([]*Issue, error) {
+	return r.gather(Client.Blocked)
+}
+
+// Show shows an issue from its repo.
+func (r *Router) Show(id string) (*Issue, error) {
+	return r.For(id).Show(id)
+}
+
+// Create creates the issue in its parent's repo, or the default repo.
+func (r *Router) Create(opts CreateOptions) (*Issue, error) {
+	if opts.Parent != "" {
+		return r.For(opts.Parent).Create(opts)
+	}
+	return r.def.Create(opts)
+}
+
+// Update updates an issue in its repo.
+func (r *Router) Update(id string, opts UpdateOptions) error {
+	return r.For(id).Update(id, opts)
+}
+
+// UpdateBatch splits the updates by repo.
+func (r *Router) UpdateBatch(updates map[string]UpdateOptions) error {
+	ids := make([]string, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	order, groups := r.group(ids)
+	for _, c := range order {
+		batch := make(map[string]UpdateOptions, len(groups[c]))
+		for _, id := range groups[c] {
+			batch[id] = updates[id]
+		}
+		if err := c.UpdateBatch(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes issues, one call per repo.
+func (r *Router) Close(ids ...string) error {
+	return r.CloseWithReason("", ids...)
+}
+
+// CloseWithReason closes issues with a reason, one call per repo.
+func (r *Router) CloseWithReason(reason string, ids ...string) error {
+	order, groups := r.group(ids)
+	for _, c := range order {
+		var err error
+		if reason == "" {
+			err = c.Close(groups[c]...)
+		} else {
+			err = c.CloseWithReason(reason, groups[c]...)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Claim claims an issue in its repo.
+func (r *Router) Claim(id, assignee string) error {
+	return r.For(id).Claim(id, assignee)
+}
+
+// ReleaseWithReason releases an issue in its repo.
+func (r *Router) ReleaseWithReason(id, reason string) error {
+	return r.For(id).ReleaseWithReason(id, reason)
+}
+
+// AddDependency records the dependency in issue's repo.
+func (r *Router) AddDependency(issue, dependsOn string) error {
+	return r.For(issue).AddDependency(issue, dependsOn)
+}
+
+// RemoveDependency removes the dependency from issue's repo.
+func (r *Router) RemoveDependency(issue, dependsOn string) error {
+	return r.For(issue).RemoveDependency(issue, dependsOn)
+}
+
+// AddComment comments on an issue in its repo.
+func (r *Router) AddComment(id, author, body string) (*Comment, error) {
+	return r.For(id).AddComment(id, author, body)
+}
+
+// Comments lists an issue's comments from its repo.
+func (r *Router) Comments(id string) ([]Comment, error) {
+	return r.For(id).Comments(id)
+}
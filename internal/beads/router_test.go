@@ -0,0 +1,92 @@
+package beads_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+)
+
+func TestRouterRoutesByPrefix(t *testing.T) {
+	town, rig := beadstest.NewFakeClient(), beadstest.NewFakeClient()
+	town.Prefix, rig.Prefix = "hq", "gt"
+	r := beads.NewRouter(town)
+	r.Route("gt", rig)
+
+	hq, err := r.Create(beads.CreateOptions{Title: "Town task", Priority: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	epic, err := rig.Create(beads.CreateOptions{Title: "Rig epic", Priority: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := r.Create(beads.CreateOptions{Title: "Step", Parent: epic.ID, Priority: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.ID != "gt-2" {
+		t.Errorf("child created as %s, want it in the parent's repo", child.ID)
+	}
+
+	if got, err := r.Show(epic.ID); err != nil || got.Title != "Rig epic" {
+		t.Errorf("Show(%s) = %v, %v", epic.ID, got, err)
+	}
+	if _, err := town.Show(epic.ID); !errors.Is(err, beads.ErrNotFound) {
+		t.Errorf("rig issue leaked into town repo: %v", err)
+	}
+
+	if err := r.Close(hq.ID, epic.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []beads.Client{town, rig} {
+		open, _ := c.List(beads.ListOptions{Priority: -1})
+		for _, issue := range open {
+			if issue.ID == hq.ID || issue.ID == epic.ID {
+				t.Errorf("%s still open", issue.ID)
+			}
+		}
+	}
+
+	all, err := r.List(beads.ListOptions{Status: "all", Priority: -1})
+	if err != nil || len(all) != 3 {
+		t.Errorf("List(all) = %d issues, %v", len(all), err)
+	}
+	limited, _ := r.List(beads.ListOptions{Status: "all", Priority: -1, Limit: 2})
+	if len(limited) != 2 {
+		t.Errorf("List(limit 2) = %d issues", len(limited))
+	}
+}
+
+func TestNewTownRouterReadsRoutes(t *testing.T) {
+	townRoot := t.TempDir()
+	beadsDir := beads.GetTownBeadsPath(townRoot)
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := beads.WriteRoutes(beadsDir, []beads.Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := beads.NewTownRouter(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.For("hq-abc") != r.For("xx-unknown") {
+		t.Error("hq- should route to the town (default) repo")
+	}
+	if r.For("gt-abc") == r.For("hq-abc") {
+		t.Error("gt- should route to the gastown repo")
+	}
+	if r.Rig("gastown") != r.For("gt-abc") {
+		t.Error("Rig(gastown) should match the gt- route")
+	}
+	if r.Rig("missing") != nil {
+		t.Error("Rig(missing) should be nil")
+	}
+}
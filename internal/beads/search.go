@@ -0,0 +1,132 @@
+package beads
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SearchOptions filters full-text search results.
+type SearchOptions struct {
+	Status string   // "open", "closed", "all"; empty excludes closed
+	Type   string   // issue type filter
+	Labels []string // issues must carry every label
+	Limit  int      // max results; 0 for no limit
+}
+
+// Search returns issues whose ID, title, or description mention query
+// (case-insensitive), leaving out any hidden by the visibility scope. Uses
+// bd search when available and falls back to scanning the issues.jsonl
+// export for older bd versions.
+func (b *Beads) Search(query string, opts SearchOptions) ([]*Issue, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("empty search query")
+	}
+
+	args := []string{"search", "--json"}
+	if opts.Status != "" {
+		args = append(args, "--status="+opts.Status)
+	}
+	if opts.Type != "" {
+		args = append(args, "--type="+opts.Type)
+	}
+	for _, label := range opts.Labels {
+		args = append(args, "--label="+label)
+	}
+	// Hidden issues would count toward bd's limit, so trim after filtering
+	if opts.Limit > 0 && b.scope == "" {
+		args = append(args, "--limit="+strconv.Itoa(opts.Limit))
+	}
+	// A query starting with "-" must not be taken for a flag
+	args = append(args, "--", query)
+
+	out, err := b.run(args...)
+	if err != nil {
+		if isUnknownCommand(err) || isUnknownFlag(err) {
+			return b.searchJSONL(query, opts)
+		}
+		return nil, err
+	}
+
+	var issues []*Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd search output: %w", err)
+	}
+	issues = FilterVisible(issues, b.scope)
+	if opts.Limit > 0 && len(issues) > opts.Limit {
+		issues = issues[:opts.Limit]
+	}
+	return issues, nil
+}
+
+// searchJSONL scans the JSONL export directly.
+func (b *Beads) searchJSONL(query string, opts SearchOptions) ([]*Issue, error) {
+	beadsDir := b.beadsDir
+	if beadsDir == "" {
+		beadsDir = ResolveBeadsDir(b.workDir)
+	}
+
+	f, err := os.Open(filepath.Join(beadsDir, "issues.jsonl")) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return nil, fmt.Errorf("search fallback: %w", err)
+	}
+	defer f.Close()
+
+	var issues []*Issue
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var issue Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			continue // Skip malformed lines
+		}
+		if !MatchesSearch(&issue, query, opts) || !VisibleTo(&issue, b.scope) {
+			continue
+		}
+		issues = append(issues, &issue)
+		if opts.Limit > 0 && len(issues) >= opts.Limit {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("search fallback: %w", err)
+	}
+	return issues, nil
+}
+
+// MatchesSearch reports whether issue satisfies query and opts, using the
+// same rules as the JSONL fallback of Search.
+func MatchesSearch(issue *Issue, query string, opts SearchOptions) bool {
+	switch opts.Status {
+	case "all":
+	case "":
+		if issue.Status == "closed" {
+			return false
+		}
+	default:
+		if issue.Status != opts.Status {
+			return false
+		}
+	}
+	if opts.Type != "" && issue.Type != opts.Type {
+		return false
+	}
+	if !HasAllLabels(issue, opts.Labels...) {
+		return false
+	}
+
+	q := strings.ToLower(query)
+	return strings.Contains(strings.ToLower(issue.ID), q) ||
+		strings.Contains(strings.ToLower(issue.Title), q) ||
+		strings.Contains(strings.ToLower(issue.Description), q)
+}
+
+// isUnknownCommand reports whether err came from a bd without the subcommand.
+func isUnknownCommand(err error) bool {
+	return strings.Contains(err.Error(), "unknown command")
+}
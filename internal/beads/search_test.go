@@ -0,0 +1,90 @@
+package beads
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchesSearch(t *testing.T) {
+	issue := &Issue{ID: "gt-42", Title: "Fix Refinery merge", Description: "conflicts on rebase",
+		Status: "open", Type: "bug", Labels: []string{"gt:merge"}}
+
+	tests := []struct {
+		query string
+		opts  SearchOptions
+		want  bool
+	}{
+		{"refinery", SearchOptions{}, true},
+		{"REBASE", SearchOptions{}, true},
+		{"gt-42", SearchOptions{}, true},
+		{"polecat", SearchOptions{}, false},
+		{"refinery", SearchOptions{Type: "task"}, false},
+		{"refinery", SearchOptions{Status: "closed"}, false},
+		{"refinery", SearchOptions{Labels: []string{"gt:merge"}}, true},
+		{"refinery", SearchOptions{Labels: []string{"gt:merge", "urgent"}}, false},
+	}
+	for _, tt := range tests {
+		if got := MatchesSearch(issue, tt.query, tt.opts); got != tt.want {
+			t.Errorf("MatchesSearch(%q, %+v) = %v, want %v", tt.query, tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestSearchFallsBackToJSONL(t *testing.T) {
+	installFakeBd(t, `echo 'Error: unknown command "search" for "bd"' >&2; exit 1`)
+
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	jsonl := strings.Join([]string{
+		`{"id":"gt-1","title":"Deploy witness","status":"open"}`,
+		`{"id":"gt-2","title":"Other","description":"mentions witness","status":"closed"}`,
+		`{"id":"gt-3","title":"Unrelated","status":"open"}`,
+	}, "\n")
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(jsonl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := New(dir).Search("witness", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].ID != "gt-1" {
+		t.Errorf("Search = %v", ids(issues))
+	}
+
+	issues, err = New(dir).Search("witness", SearchOptions{Status: "all"})
+	if err != nil || len(issues) != 2 {
+		t.Errorf("Search(all) = %v, %v", ids(issues), err)
+	}
+}
+
+func TestSearchScopeAndDashQuery(t *testing.T) {
+	installFakeBd(t, `
+echo "$@" >> "$(dirname "$0")/args"
+echo '[{"id":"gt-1","title":"a","labels":["visibility:private"]},{"id":"gt-2","title":"b"},{"id":"gt-3","title":"c"}]'
+`)
+	b := New(t.TempDir())
+	if err := b.SetVisibilityScope(VisibilityPublic); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := b.Search("-rf", SearchOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].ID != "gt-2" {
+		t.Errorf("Search = %v, want only the first public hit", ids(issues))
+	}
+
+	bdPath, _ := exec.LookPath("bd")
+	args, _ := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "args"))
+	if !strings.HasSuffix(strings.TrimSpace(string(args)), "search --json -- -rf") {
+		t.Errorf("bd args = %q, want the query after --", args)
+	}
+}
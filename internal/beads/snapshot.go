@@ -0,0 +1,134 @@
+package beads
+
+import (
+	"sort"
+	"time"
+)
+
+// Snapshot is a point-in-time view of every issue in a beads store.
+// Queries against one Snapshot are mutually consistent: an issue can't
+// appear both ready and blocked because it changed between bd calls.
+type Snapshot struct {
+	TakenAt time.Time
+	issues  []*Issue
+	byID    map[string]*Issue
+	graph   *Graph
+}
+
+// QuerySet holds the results of related queries run against one snapshot.
+type QuerySet struct {
+	TakenAt    time.Time
+	Ready      []*Issue            // open, unblocked
+	Blocked    []*Issue            // not closed, with an open blocker
+	InProgress map[string][]*Issue // in_progress issues keyed by assignee
+}
+
+// Snapshot reads every issue with a single bd call.
+func (b *Beads) Snapshot() (*Snapshot, error) {
+	issues, err := b.List(ListOptions{Status: "all", Priority: -1, Limit: NoLimit})
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(issues, time.Now()), nil
+}
+
+// QuerySet returns ready, blocked, and per-assignee in-progress issues
+// from one consistent snapshot. With no assignees, InProgress covers
+// every assignee.
+func (b *Beads) QuerySet(assignees ...string) (*QuerySet, error) {
+	snap, err := b.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snap.QuerySet(assignees...), nil
+}
+
+// NewSnapshot builds a snapshot from an already-fetched issue list.
+func NewSnapshot(issues []*Issue, takenAt time.Time) *Snapshot {
+	s := &Snapshot{TakenAt: takenAt, issues: issues, byID: make(map[string]*Issue, len(issues)), graph: NewGraph(issues)}
+	for _, issue := range issues {
+		s.byID[issue.ID] = issue
+	}
+	return s
+}
+
+// Issues returns every issue in the snapshot.
+func (s *Snapshot) Issues() []*Issue {
+	return s.issues
+}
+
+// Get returns the issue with the given ID, or nil.
+func (s *Snapshot) Get(id string) *Issue {
+	return s.byID[id]
+}
+
+// IsBlocked reports whether an issue has an open blocker. Blockers are
+// the issue's dependencies in the snapshot's Graph, so typed dependencies
+// and blocks edges count the same as they do there. Blockers missing from
+// the snapshot fall back to bd's blocked_by_count.
+func (s *Snapshot) IsBlocked(issue *Issue) bool {
+	known := false
+	for _, id := range s.graph.Dependencies(issue.ID) {
+		if dep, ok := s.byID[id]; ok {
+			known = true
+			if dep.Status != "closed" {
+				return true
+			}
+		}
+	}
+	return !known && issue.BlockedByCount > 0
+}
+
+// Ready returns open, unblocked issues, most urgent first.
+func (s *Snapshot) Ready() []*Issue {
+	return s.filter(func(issue *Issue) bool {
+		return issue.Status == "open" && !s.IsBlocked(issue)
+	})
+}
+
+// Blocked returns non-closed issues with an open blocker, most urgent first.
+func (s *Snapshot) Blocked() []*Issue {
+	return s.filter(func(issue *Issue) bool {
+		return issue.Status != "closed" && s.IsBlocked(issue)
+	})
+}
+
+// InProgress returns in_progress issues assigned to assignee.
+func (s *Snapshot) InProgress(assignee string) []*Issue {
+	return s.filter(func(issue *Issue) bool {
+		return issue.Status == "in_progress" && issue.Assignee == assignee
+	})
+}
+
+// QuerySet runs the standard status queries against this snapshot.
+func (s *Snapshot) QuerySet(assignees ...string) *QuerySet {
+	qs := &QuerySet{
+		TakenAt:    s.TakenAt,
+		Ready:      s.Ready(),
+		Blocked:    s.Blocked(),
+		InProgress: make(map[string][]*Issue),
+	}
+	if len(assignees) == 0 {
+		for _, issue := range s.filter(func(issue *Issue) bool {
+			return issue.Status == "in_progress" && issue.Assignee != ""
+		}) {
+			qs.InProgress[issue.Assignee] = append(qs.InProgress[issue.Assignee], issue)
+		}
+		return qs
+	}
+	for _, a := range assignees {
+		qs.InProgress[a] = s.InProgress(a)
+	}
+	return qs
+}
+
+func (s *Snapshot) filter(keep func(*Issue) bool) []*Issue {
+	var out []*Issue
+	for _, issue := range s.issues {
+		if keep(issue) {
+			out = append(out, issue)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Priority < out[j].Priority })
+	return out
+}
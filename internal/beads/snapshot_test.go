@@ -0,0 +1,83 @@
+package beads
+
+import (
+	"testing"
+	"time"
+)
+
+func ids(issues []*Issue) []string {
+	out := make([]string, len(issues))
+	for i, issue := range issues {
+		out[i] = issue.ID
+	}
+	return out
+}
+
+func TestSnapshotQuerySet(t *testing.T) {
+	snap := NewSnapshot([]*Issue{
+		{ID: "gt-1", Status: "open", Priority: 2},
+		{ID: "gt-2", Status: "open", Priority: 1, DependsOn: []string{"gt-1"}},
+		{ID: "gt-3", Status: "open", Priority: 0, DependsOn: []string{"gt-4"}},
+		{ID: "gt-4", Status: "closed"},
+		{ID: "gt-5", Status: "in_progress", Assignee: "gastown/polecats/Toast"},
+		{ID: "gt-6", Status: "open", Priority: 3, BlockedByCount: 1},
+		{ID: "gt-7", Status: "in_progress", Priority: 3, Assignee: "gastown/polecats/Nux", BlockedBy: []string{"gt-1"}},
+	}, time.Now())
+
+	qs := snap.QuerySet()
+	if got := ids(qs.Ready); len(got) != 2 || got[0] != "gt-3" || got[1] != "gt-1" {
+		t.Errorf("Ready = %v, want [gt-3 gt-1]", got)
+	}
+	if got := ids(qs.Blocked); len(got) != 3 || got[0] != "gt-2" {
+		t.Errorf("Blocked = %v, want gt-2, gt-6, gt-7", got)
+	}
+	if len(qs.InProgress) != 2 || qs.InProgress["gastown/polecats/Toast"][0].ID != "gt-5" {
+		t.Errorf("InProgress = %v", qs.InProgress)
+	}
+
+	// No issue may land in both columns.
+	ready := make(map[string]bool)
+	for _, issue := range qs.Ready {
+		ready[issue.ID] = true
+	}
+	for _, issue := range qs.Blocked {
+		if ready[issue.ID] {
+			t.Errorf("%s is both ready and blocked", issue.ID)
+		}
+	}
+
+	named := snap.QuerySet("gastown/polecats/Toast", "gastown/polecats/Idle")
+	if len(named.InProgress) != 2 || len(named.InProgress["gastown/polecats/Idle"]) != 0 {
+		t.Errorf("named InProgress = %v", named.InProgress)
+	}
+}
+
+func TestQuerySetSingleCall(t *testing.T) {
+	installFakeBd(t, `
+echo x >> "$(dirname "$0")/calls"
+echo '[{"id":"gt-1","status":"open"},{"id":"gt-2","status":"open","depends_on":["gt-1"]}]'
+`)
+	qs, err := New(t.TempDir()).QuerySet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qs.Ready) != 1 || len(qs.Blocked) != 1 {
+		t.Errorf("QuerySet = %+v", qs)
+	}
+}
+
+func TestSnapshotIsBlockedMatchesGraph(t *testing.T) {
+	snap := NewSnapshot([]*Issue{
+		{ID: "gt-1", Status: "open"},
+		{ID: "gt-2", Status: "open", Dependencies: []IssueDep{{ID: "gt-1", DependencyType: DepBlocks}}},
+		{ID: "gt-3", Status: "open", Dependencies: []IssueDep{{ID: "gt-1", DependencyType: DepRelated}}},
+		{ID: "gt-4", Status: "open", Blocks: []string{"gt-5"}},
+		{ID: "gt-5", Status: "open"},
+	}, time.Now())
+
+	for id, want := range map[string]bool{"gt-1": false, "gt-2": true, "gt-3": false, "gt-4": false, "gt-5": true} {
+		if got := snap.IsBlocked(snap.Get(id)); got != want {
+			t.Errorf("IsBlocked(%s) = %v, want %v", id, got, want)
+		}
+	}
+}
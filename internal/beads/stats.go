@@ -0,0 +1,128 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RepoStats is a structured summary of a beads repository.
+type RepoStats struct {
+	Total      int `json:"total"`
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Closed     int `json:"closed"`
+	Blocked    int `json:"blocked"` // not closed, with an open blocker
+	Ready      int `json:"ready"`   // open and unblocked
+
+	ByType     map[string]*StatusCounts `json:"by_type"`     // keyed by issue type ("" for untyped)
+	ByPriority map[int]int              `json:"by_priority"` // not-closed issues per priority
+}
+
+// StatusCounts splits a group of issues by status.
+type StatusCounts struct {
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Closed     int `json:"closed"`
+}
+
+// bdStatsSummary is the counts block of `bd stats --json`. Newer bd nests
+// it under "summary"; older versions print it at the top level.
+type bdStatsSummary struct {
+	Total      *int `json:"total_issues"`
+	Open       *int `json:"open_issues"`
+	InProgress *int `json:"in_progress_issues"`
+	Closed     *int `json:"closed_issues"`
+	Blocked    *int `json:"blocked_issues"`
+	Ready      *int `json:"ready_issues"`
+}
+
+// StatsJSON returns structured repository statistics. Headline counts come
+// from `bd stats --json` where bd reports them; the type and priority
+// breakdowns are computed from the issue list.
+func (b *Beads) StatsJSON() (*RepoStats, error) {
+	snap, err := b.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	stats := ComputeRepoStats(snap)
+
+	out, err := b.run("stats", "--json")
+	if err != nil {
+		// Older bd without stats --json: the computed counts stand.
+		return stats, nil //nolint:nilerr // computed stats are complete
+	}
+	summary, err := parseStatsSummary(out)
+	if err != nil {
+		return nil, fmt.Errorf("parsing bd stats output: %w", err)
+	}
+	summary.apply(stats)
+	return stats, nil
+}
+
+// ComputeRepoStats derives statistics from a snapshot.
+func ComputeRepoStats(snap *Snapshot) *RepoStats {
+	stats := &RepoStats{
+		ByType:     make(map[string]*StatusCounts),
+		ByPriority: make(map[int]int),
+	}
+	for _, issue := range snap.Issues() {
+		stats.Total++
+		counts := stats.ByType[issue.Type]
+		if counts == nil {
+			counts = &StatusCounts{}
+			stats.ByType[issue.Type] = counts
+		}
+		switch issue.Status {
+		case "closed":
+			stats.Closed++
+			counts.Closed++
+			continue
+		case "in_progress":
+			stats.InProgress++
+			counts.InProgress++
+		default:
+			stats.Open++
+			counts.Open++
+		}
+		stats.ByPriority[issue.Priority]++
+	}
+	stats.Blocked = len(snap.Blocked())
+	stats.Ready = len(snap.Ready())
+	return stats
+}
+
+func parseStatsSummary(out []byte) (*bdStatsSummary, error) {
+	var wrapped struct {
+		Summary *bdStatsSummary `json:"summary"`
+	}
+	if err := json.Unmarshal(out, &wrapped); err != nil {
+		return nil, err
+	}
+	if wrapped.Summary != nil {
+		return wrapped.Summary, nil
+	}
+	var flat bdStatsSummary
+	if err := json.Unmarshal(out, &flat); err != nil {
+		return nil, err
+	}
+	return &flat, nil
+}
+
+// apply overwrites stats with every count bd reported.
+func (s *bdStatsSummary) apply(stats *RepoStats) {
+	for _, f := range []struct {
+		src *int
+		dst *int
+	}{
+		{s.Total, &stats.Total},
+		{s.Open, &stats.Open},
+		{s.InProgress, &stats.InProgress},
+		{s.Closed, &stats.Closed},
+		{s.Blocked, &stats.Blocked},
+		{s.Ready, &stats.Ready},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package beads
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeRepoStats(t *testing.T) {
+	snap := NewSnapshot([]*Issue{
+		{ID: "gt-1", Type: "task", Status: "open", Priority: 1},
+		{ID: "gt-2", Type: "task", Status: "open", Priority: 2, BlockedBy: []string{"gt-3"}},
+		{ID: "gt-3", Type: "bug", Status: "in_progress", Priority: 1},
+		{ID: "gt-4", Type: "bug", Status: "closed", Priority: 0},
+	}, time.Now())
+
+	stats := ComputeRepoStats(snap)
+	if stats.Total != 4 || stats.Open != 2 || stats.InProgress != 1 || stats.Closed != 1 {
+		t.Errorf("counts = %+v", stats)
+	}
+	if stats.Blocked != 1 || stats.Ready != 1 {
+		t.Errorf("blocked=%d ready=%d, want 1 and 1", stats.Blocked, stats.Ready)
+	}
+	if c := stats.ByType["bug"]; c == nil || c.InProgress != 1 || c.Closed != 1 {
+		t.Errorf("bug counts = %+v", c)
+	}
+	if stats.ByPriority[1] != 2 || stats.ByPriority[0] != 0 {
+		t.Errorf("by priority = %v", stats.ByPriority)
+	}
+}
+
+func TestStatsJSONUsesBdCounts(t *testing.T) {
+	installFakeBd(t, `
+case "$2" in
+stats) echo '{"summary":{"total_issues":10,"blocked_issues":4}}' ;;
+*) echo '[{"id":"gt-1","status":"open","issue_type":"task"}]' ;;
+esac
+`)
+	stats, err := New(t.TempDir()).StatsJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Total != 10 || stats.Blocked != 4 {
+		t.Errorf("bd counts not applied: %+v", stats)
+	}
+	if stats.Open != 1 || stats.ByType["task"].Open != 1 {
+		t.Errorf("computed counts lost: %+v", stats)
+	}
+}
@@ -0,0 +1,256 @@
+package beads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TemplatesDirName is the directory under .beads that holds issue templates.
+const TemplatesDirName = "templates"
+
+// Template is a reusable issue structure: an issue, its children, and the
+// dependencies between them. Templates are TOML files named
+// <name>.toml in .beads/templates (the same format as formulas):
+//
+//	title = "Convoy: {{goal}}"
+//	type = "epic"
+//	[vars]
+//	goal = ""        # required
+//	rig = "gastown"  # default
+//
+//	[[children]]
+//	ref = "impl"
+//	title = "Implement {{goal}}"
+//
+//	[[children]]
+//	title = "Review {{goal}}"
+//	needs = ["impl"]
+//
+// {{var}} placeholders in titles, descriptions, and labels are expanded
+// by CreateFromTemplate.
+type Template struct {
+	Name string `toml:"-"` // File name without extension
+
+	// Vars declares the template's variables and their defaults. A var
+	// with an empty default must be supplied.
+	Vars map[string]string `toml:"vars"`
+
+	TemplateNode
+}
+
+// TemplateNode is one issue in a template.
+type TemplateNode struct {
+	Ref         string         `toml:"ref"` // Name siblings use in Needs
+	Title       string         `toml:"title"`
+	Description string         `toml:"description"`
+	Type        string         `toml:"type"`
+	Priority    *int           `toml:"priority"`
+	Labels      []string       `toml:"labels"`
+	Needs       []string       `toml:"needs"` // Refs of siblings this depends on
+	Children    []TemplateNode `toml:"children"`
+}
+
+// LoadTemplate reads a template file.
+func LoadTemplate(path string) (*Template, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is in the templates directory
+	if err != nil {
+		return nil, err
+	}
+	var t Template
+	md, err := toml.Decode(string(data), &t)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", path, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("parsing template %s: unknown key %q", path, undecoded[0].String())
+	}
+	t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if err := t.TemplateNode.validate(t.Name); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (n *TemplateNode) validate(where string) error {
+	if n.Title == "" {
+		return fmt.Errorf("template %s: title is required", where)
+	}
+	refs := make(map[string]bool, len(n.Children))
+	for _, c := range n.Children {
+		if c.Ref != "" {
+			if refs[c.Ref] {
+				return fmt.Errorf("template %s: duplicate ref %q", where, c.Ref)
+			}
+			refs[c.Ref] = true
+		}
+	}
+	for i := range n.Children {
+		c := &n.Children[i]
+		for _, need := range c.Needs {
+			if !refs[need] {
+				return fmt.Errorf("template %s: %q needs unknown ref %q", where, c.Title, need)
+			}
+		}
+		if err := c.validate(where); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templatesDir returns this repo's templates directory.
+func (b *Beads) templatesDir() string {
+	beadsDir := b.beadsDir
+	if beadsDir == "" {
+		beadsDir = ResolveBeadsDir(b.workDir)
+	}
+	return filepath.Join(beadsDir, TemplatesDirName)
+}
+
+// Template loads the named template from .beads/templates.
+func (b *Beads) Template(name string) (*Template, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+	t, err := LoadTemplate(filepath.Join(b.templatesDir(), name+".toml"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("template %q not found in %s", name, b.templatesDir())
+	}
+	return t, err
+}
+
+// Templates loads every template in .beads/templates, sorted by name.
+func (b *Beads) Templates() ([]*Template, error) {
+	paths, err := filepath.Glob(filepath.Join(b.templatesDir(), "*.toml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	templates := make([]*Template, 0, len(paths))
+	for _, p := range paths {
+		t, err := LoadTemplate(p)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// Expand returns the template's issue tree with vars substituted. Declared
+// defaults fill in missing vars; a required var that is missing, or a
+// placeholder that names no var, is an error.
+func (t *Template) Expand(vars map[string]string) (*TemplateNode, error) {
+	ctx := make(map[string]string, len(t.Vars)+len(vars))
+	for k, v := range t.Vars {
+		ctx[k] = v
+	}
+	for k, v := range vars {
+		ctx[k] = v
+	}
+	var missing []string
+	for k := range t.Vars {
+		if ctx[k] == "" {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("template %s: missing vars: %s", t.Name, strings.Join(missing, ", "))
+	}
+
+	node, err := t.TemplateNode.expand(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	return node, nil
+}
+
+func (n TemplateNode) expand(ctx map[string]string) (*TemplateNode, error) {
+	var err error
+	sub := func(s string) string {
+		out := ExpandTemplateVars(s, ctx)
+		if m := templateVarRegex.FindString(out); m != "" && err == nil {
+			err = fmt.Errorf("undefined var %s", m)
+		}
+		return out
+	}
+
+	out := n
+	out.Title = sub(n.Title)
+	out.Description = sub(n.Description)
+	out.Labels = make([]string, len(n.Labels))
+	for i, l := range n.Labels {
+		out.Labels[i] = sub(l)
+	}
+	out.Children = make([]TemplateNode, len(n.Children))
+	for i, c := range n.Children {
+		expanded, cerr := c.expand(ctx)
+		if cerr != nil {
+			return nil, cerr
+		}
+		out.Children[i] = *expanded
+	}
+	return &out, err
+}
+
+// CreateFromTemplate expands the named template with vars and creates its
+// issues: the root first, then each child under its parent, then the
+// needs dependencies between siblings. Children inherit the parent's
+// priority unless they set their own. Returns the root issue with
+// Children set to the IDs of its direct children.
+func (b *Beads) CreateFromTemplate(name string, vars map[string]string) (*Issue, error) {
+	t, err := b.Template(name)
+	if err != nil {
+		return nil, err
+	}
+	root, err := t.Expand(vars)
+	if err != nil {
+		return nil, err
+	}
+	return b.createNode(root, "", PriorityMedium)
+}
+
+func (b *Beads) createNode(n *TemplateNode, parent string, inherited int) (*Issue, error) {
+	priority := inherited
+	if n.Priority != nil {
+		priority = *n.Priority
+	}
+	issue, err := b.Create(CreateOptions{
+		Title:       n.Title,
+		Type:        n.Type,
+		Priority:    priority,
+		Description: n.Description,
+		Parent:      parent,
+		Labels:      n.Labels,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating %q: %w", n.Title, err)
+	}
+
+	refs := make(map[string]string, len(n.Children))
+	issue.Children = nil
+	for i := range n.Children {
+		child, err := b.createNode(&n.Children[i], issue.ID, priority)
+		if err != nil {
+			return issue, err
+		}
+		issue.Children = append(issue.Children, child.ID)
+		if ref := n.Children[i].Ref; ref != "" {
+			refs[ref] = child.ID
+		}
+	}
+	for i, c := range n.Children {
+		for _, need := range c.Needs {
+			if err := b.AddDependency(issue.Children[i], refs[need]); err != nil {
+				return issue, fmt.Errorf("wiring %q needs %q: %w", c.Title, need, err)
+			}
+		}
+	}
+	return issue, nil
+}
@@ -0,0 +1,131 @@
+package beads
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const convoyTemplate = `
+title = "Convoy: {{goal}}"
+type = "epic"
+priority = 1
+labels = ["rig:{{rig}}"]
+
+[vars]
+goal = ""
+rig = "gastown"
+
+[[children]]
+ref = "impl"
+title = "Implement {{goal}}"
+
+[[children]]
+title = "Review {{goal}}"
+priority = 3
+needs = ["impl"]
+`
+
+func writeTemplate(t *testing.T, dir, name, body string) {
+	t.Helper()
+	tdir := filepath.Join(dir, ".beads", TemplatesDirName)
+	if err := os.MkdirAll(tdir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tdir, name+".toml"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTemplateExpand(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "convoy", convoyTemplate)
+	writeTemplate(t, dir, "broken", "title = \"{{nope}}\"\n")
+	b := New(dir)
+
+	tmpl, err := b.Template("convoy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tmpl.Expand(nil); err == nil || !strings.Contains(err.Error(), "goal") {
+		t.Errorf("Expand(nil) error = %v, want missing goal", err)
+	}
+	node, err := tmpl.Expand(map[string]string{"goal": "auth"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Title != "Convoy: auth" || node.Labels[0] != "rig:gastown" || node.Children[1].Title != "Review auth" {
+		t.Errorf("expanded = %+v", node)
+	}
+	if tmpl.Title != "Convoy: {{goal}}" {
+		t.Errorf("Expand modified the template: %q", tmpl.Title)
+	}
+
+	broken, err := b.Template("broken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := broken.Expand(nil); err == nil || !strings.Contains(err.Error(), "{{nope}}") {
+		t.Errorf("Expand(broken) error = %v, want undefined var", err)
+	}
+
+	if _, err := b.Template("../convoy"); err == nil {
+		t.Error("Template accepted a path")
+	}
+	all, err := b.Templates()
+	if err != nil || len(all) != 2 {
+		t.Errorf("Templates() = %d, %v", len(all), err)
+	}
+}
+
+func TestLoadTemplateRejectsUnknownNeeds(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "bad", "title = \"x\"\n[[children]]\ntitle = \"y\"\nneeds = [\"missing\"]\n")
+	if _, err := New(dir).Template("bad"); err == nil {
+		t.Error("expected error for unknown ref")
+	}
+}
+
+func TestCreateFromTemplate(t *testing.T) {
+	installFakeBd(t, `
+dir=$(dirname "$0")
+echo "$@" >> "$dir/log"
+case "$2" in
+create)
+  n=$(cat "$dir/n" 2>/dev/null || echo 0); n=$((n+1)); echo $n > "$dir/n"
+  echo "{\"id\":\"gt-$n\"}"
+  ;;
+esac
+`)
+	dir := t.TempDir()
+	writeTemplate(t, dir, "convoy", convoyTemplate)
+
+	root, err := New(dir).CreateFromTemplate("convoy", map[string]string{"goal": "auth"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.ID != "gt-1" || strings.Join(root.Children, ",") != "gt-2,gt-3" {
+		t.Errorf("root = %s children %v", root.ID, root.Children)
+	}
+
+	bdPath, err := exec.LookPath("bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(bdPath), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := []string{
+		"--no-daemon create --json --title=Convoy: auth --type=epic --priority=1 --labels=rig:gastown",
+		"--no-daemon create --json --title=Implement auth --priority=1 --parent=gt-1",
+		"--no-daemon create --json --title=Review auth --priority=3 --parent=gt-1",
+		"--no-daemon dep add gt-3 gt-2",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("bd calls:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,72 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timestampFormats are the layouts bd has written timestamps in, most
+// common first. Zoneless layouts are read as UTC.
+var timestampFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses a bd timestamp in any of the formats bd has used.
+// An empty string is the zero time.
+func ParseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range timestampFormats {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", s)
+}
+
+// UnmarshalJSON decodes an issue, parsing its timestamps with
+// ParseTimestamp. A timestamp that can't be parsed is left zero rather
+// than failing the whole issue.
+func (i *Issue) UnmarshalJSON(data []byte) error {
+	type plain Issue
+	aux := struct {
+		*plain
+		CreatedAt string `json:"created_at"`
+		UpdatedAt string `json:"updated_at"`
+		ClosedAt  string `json:"closed_at"`
+	}{plain: (*plain)(i)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	i.CreatedAt, _ = ParseTimestamp(aux.CreatedAt)
+	i.UpdatedAt, _ = ParseTimestamp(aux.UpdatedAt)
+	i.ClosedAt, _ = ParseTimestamp(aux.ClosedAt)
+	return nil
+}
+
+// Age is how long ago the issue was created, or zero if bd didn't say.
+func (i *Issue) Age() time.Duration {
+	if i.CreatedAt.IsZero() {
+		return 0
+	}
+	return time.Since(i.CreatedAt)
+}
+
+// TimeToClose is how long the issue took from creation to close. ok is
+// false for issues that aren't closed or lack either timestamp.
+func (i *Issue) TimeToClose() (d time.Duration, ok bool) {
+	if i.CreatedAt.IsZero() || i.ClosedAt.IsZero() {
+		return 0, false
+	}
+	return i.ClosedAt.Sub(i.CreatedAt), true
+}
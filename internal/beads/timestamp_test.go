@@ -0,0 +1,75 @@
+package beads
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string // Format: "2006-01-02 15:04:05"
+		isZero   bool
+		wantErr  bool
+	}{
+		{"2025-12-30T16:19:00Z", "2025-12-30 16:19:00", false, false},
+		{"2025-12-30T16:19:00.123456-08:00", "2025-12-31 00:19:00", false, false},
+		{"2025-12-30T16:19:00", "2025-12-30 16:19:00", false, false},
+		{"2025-12-30 16:19:07", "2025-12-30 16:19:07", false, false},
+		{"2025-12-30 16:19:07.5 +0000 UTC", "2025-12-30 16:19:07", false, false},
+		{"2025-12-30 16:19", "2025-12-30 16:19:00", false, false},
+		{"2025-12-30", "2025-12-30 00:00:00", false, false},
+		{"invalid", "", true, true},
+		{"", "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimestamp(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.isZero {
+				if !got.IsZero() {
+					t.Errorf("ParseTimestamp(%q) expected zero time, got %v", tt.input, got)
+				}
+				return
+			}
+			if gotStr := got.UTC().Format("2006-01-02 15:04:05"); gotStr != tt.expected {
+				t.Errorf("ParseTimestamp(%q) = %q, want %q", tt.input, gotStr, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIssueTimestampsJSON(t *testing.T) {
+	var issue Issue
+	data := `{"id":"gt-1","created_at":"2025-01-01 12:00:00","updated_at":"garbage","closed_at":"2025-01-03T12:00:00Z"}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue.ID != "gt-1" || !issue.UpdatedAt.IsZero() {
+		t.Errorf("issue = %+v", issue)
+	}
+	if d, ok := issue.TimeToClose(); !ok || d != 48*time.Hour {
+		t.Errorf("TimeToClose() = %v, %v", d, ok)
+	}
+	if issue.Age() < 48*time.Hour {
+		t.Errorf("Age() = %v", issue.Age())
+	}
+
+	out, err := json.Marshal(&Issue{ID: "gt-2", CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"created_at":"2025-01-01T00:00:00Z"`) || strings.Contains(string(out), "closed_at") {
+		t.Errorf("Marshal = %s", out)
+	}
+
+	open := Issue{CreatedAt: time.Now()}
+	if _, ok := open.TimeToClose(); ok {
+		t.Error("TimeToClose() ok for an open issue")
+	}
+}
@@ -0,0 +1,143 @@
+package beads
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IssueTypes are the issue types Create accepts: bd's built-ins plus the
+// types Gas Town itself files.
+var IssueTypes = map[string]bool{
+	"task":          true,
+	"bug":           true,
+	"feature":       true,
+	"epic":          true,
+	"chore":         true,
+	"message":       true,
+	"molecule":      true,
+	"merge-request": true,
+	"convoy":        true,
+	"agent":         true,
+	"role":          true,
+	"rig":           true,
+	"event":         true,
+	"gate":          true,
+}
+
+// IssueStatuses are the statuses Update accepts.
+var IssueStatuses = map[string]bool{
+	"open":        true,
+	"in_progress": true,
+	"blocked":     true,
+	"deferred":    true,
+	"closed":      true,
+	StatusPinned:  true,
+	StatusHooked:  true,
+}
+
+// ValidationError is an option bd would reject, caught before running bd.
+// Field names the CreateOptions/UpdateOptions field.
+type ValidationError struct {
+	Field  string
+	Value  any
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Field, fmt.Sprint(e.Value), e.Reason)
+}
+
+// Validate checks the options Create would pass to bd.
+func (o CreateOptions) Validate() error {
+	if strings.TrimSpace(o.Title) == "" {
+		return &ValidationError{Field: "Title", Value: o.Title, Reason: "title is required"}
+	}
+	if o.Priority >= 0 { // Negative leaves the priority to bd
+		if err := validatePriority(o.Priority); err != nil {
+			return err
+		}
+	}
+	if o.Type != "" && !IssueTypes[o.Type] {
+		return &ValidationError{Field: "Type", Value: o.Type, Reason: "unknown issue type"}
+	}
+	if o.Visibility != "" && !ValidVisibility(o.Visibility) {
+		return &ValidationError{Field: "Visibility", Value: o.Visibility, Reason: "unknown visibility scope"}
+	}
+	return validateLabels("Labels", o.Labels)
+}
+
+// Validate checks the options Update would pass to bd.
+func (o UpdateOptions) Validate() error {
+	if o.Title != nil && strings.TrimSpace(*o.Title) == "" {
+		return &ValidationError{Field: "Title", Value: *o.Title, Reason: "title cannot be empty"}
+	}
+	if o.Priority != nil {
+		if err := validatePriority(*o.Priority); err != nil {
+			return err
+		}
+	}
+	if o.Status != nil && !IssueStatuses[*o.Status] {
+		return &ValidationError{Field: "Status", Value: *o.Status, Reason: "unknown status"}
+	}
+	if err := validateLabels("AddLabels", o.AddLabels); err != nil {
+		return err
+	}
+	if err := validateLabels("RemoveLabels", o.RemoveLabels); err != nil {
+		return err
+	}
+	if err := validateLabels("SetLabels", o.SetLabels); err != nil {
+		return err
+	}
+	for key := range o.SetMetadata {
+		if err := validateMetadataKey("SetMetadata", key); err != nil {
+			return err
+		}
+	}
+	for _, key := range o.UnsetMetadata {
+		if err := validateMetadataKey("UnsetMetadata", key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePriority(p int) error {
+	if p < PriorityCritical || p > PriorityBacklog {
+		return &ValidationError{Field: "Priority", Value: p,
+			Reason: fmt.Sprintf("must be %d-%d", PriorityCritical, PriorityBacklog)}
+	}
+	return nil
+}
+
+// validateLabels rejects empty labels and commas, which bd's
+// comma-separated --labels flag would split.
+func validateLabels(field string, labels []string) error {
+	for _, l := range labels {
+		if strings.TrimSpace(l) == "" {
+			return &ValidationError{Field: field, Value: l, Reason: "label cannot be empty"}
+		}
+		if strings.Contains(l, ",") {
+			return &ValidationError{Field: field, Value: l, Reason: "label cannot contain a comma"}
+		}
+	}
+	return nil
+}
+
+// validateMetadataKey rejects keys bd's key=value metadata flags can't carry.
+func validateMetadataKey(field, key string) error {
+	if strings.TrimSpace(key) == "" {
+		return &ValidationError{Field: field, Value: key, Reason: "metadata key cannot be empty"}
+	}
+	if strings.Contains(key, "=") {
+		return &ValidationError{Field: field, Value: key, Reason: "metadata key cannot contain '='"}
+	}
+	return nil
+}
+
+func validateDepType(depType string) error {
+	switch depType {
+	case "", DepBlocks, DepRelated, DepParentChild, DepDiscoveredFrom:
+		return nil
+	}
+	return &ValidationError{Field: "DependencyType", Value: depType, Reason: "must be blocks, related, parent-child, or discovered-from"}
+}
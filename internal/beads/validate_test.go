@@ -0,0 +1,80 @@
+package beads
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCreateOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  CreateOptions
+		field string // "" for valid
+	}{
+		{"valid", CreateOptions{Title: "Fix it", Type: "bug", Priority: 1}, ""},
+		{"unset priority", CreateOptions{Title: "Fix it", Priority: PriorityUnset}, ""},
+		{"empty title", CreateOptions{Title: "  ", Priority: 2}, "Title"},
+		{"priority too high", CreateOptions{Title: "x", Priority: 5}, "Priority"},
+		{"unknown type", CreateOptions{Title: "x", Type: "story"}, "Type"},
+		{"comma label", CreateOptions{Title: "x", Labels: []string{"a,b"}}, "Labels"},
+		{"unknown visibility", CreateOptions{Title: "x", Visibility: "secret"}, "Visibility"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, tt.opts.Validate(), tt.field)
+		})
+	}
+}
+
+func TestUpdateOptionsValidate(t *testing.T) {
+	empty, status, prio := "", "done", -1
+	tests := []struct {
+		name  string
+		opts  UpdateOptions
+		field string
+	}{
+		{"empty", UpdateOptions{}, ""},
+		{"empty title", UpdateOptions{Title: &empty}, "Title"},
+		{"unknown status", UpdateOptions{Status: &status}, "Status"},
+		{"negative priority", UpdateOptions{Priority: &prio}, "Priority"},
+		{"empty label", UpdateOptions{RemoveLabels: []string{""}}, "RemoveLabels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, tt.opts.Validate(), tt.field)
+		})
+	}
+}
+
+func TestCreateValidatesBeforeRunningBd(t *testing.T) {
+	installFakeBd(t, `echo x >> "$(dirname "$0")/calls"; echo '{"id":"gt-1"}'`)
+	b := New(t.TempDir())
+
+	_, err := b.Create(CreateOptions{Title: "x", Priority: 7})
+	checkValidation(t, err, "Priority")
+	_, err = b.CreateBatch([]CreateOptions{{Title: "ok", Priority: 1}, {Priority: 1}})
+	checkValidation(t, err, "Title")
+	bad := "nope"
+	checkValidation(t, b.Update("gt-1", UpdateOptions{Status: &bad}), "Status")
+
+	if n := bdCalls(t); n != 0 {
+		t.Errorf("bd ran %d times for invalid options", n)
+	}
+}
+
+func checkValidation(t *testing.T, err error, field string) {
+	t.Helper()
+	if field == "" {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *ValidationError", err)
+	}
+	if verr.Field != field {
+		t.Errorf("Field = %q, want %q (%v)", verr.Field, field, err)
+	}
+}
@@ -0,0 +1,141 @@
+package beads
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Visibility scopes, from least to most restricted. A scope is stored on
+// an issue as a "visibility:<scope>" label; issues without one are public.
+const (
+	VisibilityPublic   = "public"   // Safe for external trackers and the dashboard
+	VisibilityInternal = "internal" // Town agents and operators only
+	VisibilityPrivate  = "private"  // Human operators only (incidents, personnel notes)
+)
+
+// visibilityLabelPrefix marks the label that carries an issue's visibility.
+const visibilityLabelPrefix = "visibility:"
+
+var visibilityRank = map[string]int{
+	VisibilityPublic:   0,
+	VisibilityInternal: 1,
+	VisibilityPrivate:  2,
+}
+
+// VisibilityLabel returns the label that sets an issue's visibility.
+func VisibilityLabel(scope string) string {
+	return visibilityLabelPrefix + scope
+}
+
+// ValidVisibility reports whether scope is a known visibility scope.
+func ValidVisibility(scope string) bool {
+	_, ok := visibilityRank[scope]
+	return ok
+}
+
+// Visibility returns the issue's visibility scope. Unlabeled issues are
+// public; a label naming an unknown scope is treated as private so a typo
+// never leaks a bead, and of several labels the most restrictive wins.
+func (i *Issue) Visibility() string {
+	visibility := VisibilityPublic
+	for _, l := range i.Labels {
+		scope, ok := strings.CutPrefix(l, visibilityLabelPrefix)
+		if !ok {
+			continue
+		}
+		if !ValidVisibility(scope) {
+			return VisibilityPrivate
+		}
+		if visibilityRank[scope] > visibilityRank[visibility] {
+			visibility = scope
+		}
+	}
+	return visibility
+}
+
+// VisibleTo reports whether issue may be shown to a viewer holding scope.
+// An empty scope is unrestricted.
+func VisibleTo(issue *Issue, scope string) bool {
+	if issue == nil {
+		return true
+	}
+	return VisibleAt(issue.Visibility(), scope)
+}
+
+// VisibleAt reports whether something with the given visibility may be
+// shown to a viewer holding scope, for callers that keep an issue's
+// Visibility rather than the issue. An empty visibility is public.
+func VisibleAt(visibility, scope string) bool {
+	if scope == "" {
+		return true
+	}
+	if visibility == "" {
+		visibility = VisibilityPublic
+	}
+	rank, ok := visibilityRank[visibility]
+	if !ok {
+		rank = visibilityRank[VisibilityPrivate]
+	}
+	return rank <= visibilityRank[scope]
+}
+
+// FilterVisible returns the issues visible to a viewer holding scope.
+func FilterVisible(issues []*Issue, scope string) []*Issue {
+	if scope == "" {
+		return issues
+	}
+	out := issues[:0:0]
+	for _, issue := range issues {
+		if VisibleTo(issue, scope) {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+// labels returns Labels plus the label for a non-public Visibility.
+func (opts CreateOptions) labels() []string {
+	if opts.Visibility == "" || opts.Visibility == VisibilityPublic {
+		return opts.Labels
+	}
+	return append(opts.Labels[:len(opts.Labels):len(opts.Labels)], VisibilityLabel(opts.Visibility))
+}
+
+// SetVisibilityScope restricts List and Show results to issues visible at
+// scope. Hidden issues are reported as not found rather than forbidden so
+// their existence doesn't leak. An empty scope (the default) disables
+// filtering.
+func (b *Beads) SetVisibilityScope(scope string) error {
+	if scope != "" && !ValidVisibility(scope) {
+		return fmt.Errorf("unknown visibility scope %q", scope)
+	}
+	b.scope = scope
+	return nil
+}
+
+// SetVisibility replaces the visibility label on an issue.
+func (b *Beads) SetVisibility(id, scope string) error {
+	return SetVisibilityWith(b, id, scope)
+}
+
+// SetVisibilityWith is SetVisibility against any Client.
+func SetVisibilityWith(c Client, id, scope string) error {
+	if !ValidVisibility(scope) {
+		return fmt.Errorf("unknown visibility scope %q", scope)
+	}
+	issue, err := c.Show(id)
+	if err != nil {
+		return err
+	}
+	var remove []string
+	for _, l := range issue.Labels {
+		if strings.HasPrefix(l, visibilityLabelPrefix) && l != VisibilityLabel(scope) {
+			remove = append(remove, l)
+		}
+	}
+	opts := UpdateOptions{RemoveLabels: remove}
+	if scope != VisibilityPublic {
+		opts.AddLabels = []string{VisibilityLabel(scope)}
+	}
+	return c.Update(id, opts)
+}
@@ -0,0 +1,108 @@
+package beads
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIssueVisibility(t *testing.T) {
+	tests := []struct {
+		labels []string
+		want   string
+	}{
+		{nil, VisibilityPublic},
+		{[]string{"bug", "visibility:internal"}, VisibilityInternal},
+		{[]string{"visibility:private"}, VisibilityPrivate},
+		{[]string{"visibility:secret"}, VisibilityPrivate},
+		{[]string{"visibility:public", "visibility:private"}, VisibilityPrivate},
+		{[]string{"visibility:internal", "visibility:public"}, VisibilityInternal},
+	}
+	for _, tt := range tests {
+		if got := (&Issue{Labels: tt.labels}).Visibility(); got != tt.want {
+			t.Errorf("Visibility(%v) = %q, want %q", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestFilterVisible(t *testing.T) {
+	issues := []*Issue{
+		{ID: "pub"},
+		{ID: "int", Labels: []string{"visibility:internal"}},
+		{ID: "priv", Labels: []string{"visibility:private"}},
+	}
+	for scope, want := range map[string][]string{
+		"":                 {"pub", "int", "priv"},
+		VisibilityPublic:   {"pub"},
+		VisibilityInternal: {"pub", "int"},
+		VisibilityPrivate:  {"pub", "int", "priv"},
+	} {
+		var got []string
+		for _, issue := range FilterVisible(issues, scope) {
+			got = append(got, issue.ID)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("scope %q: got %v, want %v", scope, got, want)
+		}
+	}
+}
+
+func TestCreateOptionsVisibilityLabel(t *testing.T) {
+	opts := CreateOptions{Labels: []string{"incident"}, Visibility: VisibilityPrivate}
+	if got, want := opts.labels(), []string{"incident", "visibility:private"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("labels = %v, want %v", got, want)
+	}
+	if got := (CreateOptions{Visibility: VisibilityPublic}).labels(); len(got) != 0 {
+		t.Errorf("public labels = %v, want none", got)
+	}
+}
+
+func TestVisibilityScopeHidesIssues(t *testing.T) {
+	installFakeBd(t, `
+case "$2" in
+show) echo '[{"id":"gt-p1","labels":["visibility:private"]}]' ;;
+*) echo '[{"id":"gt-a1"},{"id":"gt-p1","labels":["visibility:private"]}]' ;;
+esac
+`)
+	b := New(t.TempDir())
+	if err := b.SetVisibilityScope("secret"); err == nil {
+		t.Error("SetVisibilityScope accepted unknown scope")
+	}
+	if err := b.SetVisibilityScope(VisibilityInternal); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := b.List(ListOptions{Priority: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].ID != "gt-a1" {
+		t.Errorf("List = %+v, want only gt-a1", issues)
+	}
+	if _, err := b.Show("gt-p1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Show hidden issue err = %v, want ErrNotFound", err)
+	}
+
+	for name, list := range map[string]func() ([]*Issue, error){
+		"Ready":   b.Ready,
+		"Blocked": b.Blocked,
+		"ReadyWithType": func() ([]*Issue, error) {
+			return b.ReadyWithType("task")
+		},
+	} {
+		issues, err := list()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 1 || issues[0].ID != "gt-a1" {
+			t.Errorf("%s = %+v, want only gt-a1", name, issues)
+		}
+	}
+	agents, err := b.ListAgentBeads()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := agents["gt-p1"]; ok || len(agents) != 1 {
+		t.Errorf("ListAgentBeads = %v, want only gt-a1", agents)
+	}
+}
@@ -0,0 +1,220 @@
+// Package bench measures Gas Town's hot paths against a synthetic
+// workload and compares runs across gt versions.
+//
+// Benchmarks run in a scratch directory and never touch the town: the
+// events benchmark appends to a temp file, and the bd benchmarks use a
+// throwaway beads repo (they are skipped when bd is not installed).
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Benchmark names.
+const (
+	BenchBdShow    = "bd_show"    // one bd show round trip
+	BenchBdList    = "bd_list"    // bd list over the synthetic workload
+	BenchEvents    = "events"     // one locked events.jsonl append
+	BenchDispatch  = "dispatch"   // compute ready work from a snapshot
+	BenchSlingDone = "sling_done" // create → in_progress → close via bd
+)
+
+// DefaultRegressionThreshold flags a benchmark whose median grew by more
+// than this fraction over the baseline.
+const DefaultRegressionThreshold = 0.20
+
+// Config controls a benchmark run.
+type Config struct {
+	Iterations int // Samples per benchmark (default 20)
+	Issues     int // Synthetic workload size (default 200)
+	Only       []string
+}
+
+// Result is the timing summary for one benchmark.
+type Result struct {
+	Name       string        `json:"name"`
+	Iterations int           `json:"iterations"`
+	Mean       time.Duration `json:"mean_ns"`
+	P50        time.Duration `json:"p50_ns"`
+	P95        time.Duration `json:"p95_ns"`
+	Max        time.Duration `json:"max_ns"`
+	PerSecond  float64       `json:"per_second"`
+	Skipped    string        `json:"skipped,omitempty"` // Why it didn't run
+}
+
+// Report is one complete run.
+type Report struct {
+	Version string    `json:"version"`
+	Commit  string    `json:"commit,omitempty"`
+	RanAt   time.Time `json:"ran_at"`
+	Issues  int       `json:"issues"`
+	Results []Result  `json:"results"`
+}
+
+// Comparison is one benchmark measured against a baseline.
+type Comparison struct {
+	Name       string        `json:"name"`
+	Baseline   time.Duration `json:"baseline_p50_ns"`
+	Current    time.Duration `json:"current_p50_ns"`
+	Change     float64       `json:"change"` // (current-baseline)/baseline
+	Regression bool          `json:"regression"`
+}
+
+func (c *Config) defaults() {
+	if c.Iterations <= 0 {
+		c.Iterations = 20
+	}
+	if c.Issues <= 0 {
+		c.Issues = 200
+	}
+}
+
+func (c *Config) wants(name string) bool {
+	if len(c.Only) == 0 {
+		return true
+	}
+	for _, n := range c.Only {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Summarize turns raw samples into a Result.
+func Summarize(name string, samples []time.Duration) Result {
+	r := Result{Name: name, Iterations: len(samples)}
+	if len(samples) == 0 {
+		return r
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, s := range sorted {
+		total += s
+	}
+	r.Mean = total / time.Duration(len(sorted))
+	r.P50 = sorted[len(sorted)/2]
+	r.P95 = sorted[min(len(sorted)*95/100, len(sorted)-1)]
+	r.Max = sorted[len(sorted)-1]
+	if r.Mean > 0 {
+		r.PerSecond = float64(time.Second) / float64(r.Mean)
+	}
+	return r
+}
+
+// sample times fn n times.
+func sample(n int, fn func() error) ([]time.Duration, error) {
+	out := make([]time.Duration, 0, n)
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		if err := fn(); err != nil {
+			return out, err
+		}
+		out = append(out, time.Since(start))
+	}
+	return out, nil
+}
+
+// Compare matches current results to baseline by name. Benchmarks missing
+// or skipped on either side are left out.
+func Compare(baseline, current *Report, threshold float64) []Comparison {
+	if threshold <= 0 {
+		threshold = DefaultRegressionThreshold
+	}
+	base := make(map[string]Result, len(baseline.Results))
+	for _, r := range baseline.Results {
+		base[r.Name] = r
+	}
+	var out []Comparison
+	for _, cur := range current.Results {
+		b, ok := base[cur.Name]
+		if !ok || b.Skipped != "" || cur.Skipped != "" || b.P50 == 0 {
+			continue
+		}
+		change := float64(cur.P50-b.P50) / float64(b.P50)
+		out = append(out, Comparison{
+			Name:       cur.Name,
+			Baseline:   b.P50,
+			Current:    cur.P50,
+			Change:     change,
+			Regression: change > threshold,
+		})
+	}
+	return out
+}
+
+// ReportDir is where reports are kept, under the town's state directory.
+func ReportDir(stateDir string) string {
+	return filepath.Join(stateDir, "bench")
+}
+
+// Save writes the report as <dir>/<version>-<timestamp>.json and returns
+// the path.
+func (r *Report) Save(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s.json", sanitize(r.Version), r.RanAt.UTC().Format("20060102T150405Z"))
+	path := filepath.Join(dir, name)
+	return path, os.WriteFile(path, data, 0644) //nolint:gosec // G306: reports are non-sensitive
+}
+
+// LoadReport reads a saved report.
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is user-chosen
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// LatestBaseline returns the newest saved report from a version other
+// than version, or nil if there is none. Comparing against another
+// version is what surfaces regressions between releases.
+func LatestBaseline(dir, version string) (*Report, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var best *Report
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		r, err := LoadReport(filepath.Join(dir, e.Name()))
+		if err != nil || r.Version == version {
+			continue
+		}
+		if best == nil || r.RanAt.After(best.RanAt) {
+			best = r
+		}
+	}
+	return best, nil
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == ' ' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,79 @@
+package bench
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	var samples []time.Duration
+	for i := 1; i <= 20; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	r := Summarize("x", samples)
+	if r.P50 != 11*time.Millisecond || r.P95 != 20*time.Millisecond || r.Max != 20*time.Millisecond {
+		t.Errorf("percentiles = %v/%v/%v", r.P50, r.P95, r.Max)
+	}
+	if r.Mean != 10500*time.Microsecond {
+		t.Errorf("mean = %v", r.Mean)
+	}
+}
+
+func TestCompareFlagsRegressions(t *testing.T) {
+	base := &Report{Version: "0.2.1", Results: []Result{
+		{Name: BenchDispatch, P50: 100 * time.Microsecond},
+		{Name: BenchEvents, P50: 100 * time.Microsecond},
+		{Name: BenchBdShow, Skipped: "bd not installed"},
+	}}
+	cur := &Report{Version: "0.2.2", Results: []Result{
+		{Name: BenchDispatch, P50: 130 * time.Microsecond},
+		{Name: BenchEvents, P50: 90 * time.Microsecond},
+		{Name: BenchBdShow, P50: time.Millisecond},
+	}}
+	cmp := Compare(base, cur, 0.2)
+	if len(cmp) != 2 {
+		t.Fatalf("comparisons = %+v, want dispatch and events only", cmp)
+	}
+	if !cmp[0].Regression || cmp[1].Regression {
+		t.Errorf("regressions = %v/%v, want true/false", cmp[0].Regression, cmp[1].Regression)
+	}
+}
+
+func TestLatestBaselineSkipsSameVersion(t *testing.T) {
+	dir := t.TempDir()
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, r := range []*Report{
+		{Version: "0.2.0", RanAt: t0},
+		{Version: "0.2.1", RanAt: t0.Add(time.Hour)},
+		{Version: "0.2.2", RanAt: t0.Add(2 * time.Hour)},
+	} {
+		if _, err := r.Save(dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := LatestBaseline(dir, "0.2.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.Version != "0.2.1" {
+		t.Errorf("baseline = %+v, want 0.2.1", got)
+	}
+}
+
+func TestRunWithoutBd(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	report, err := Run(Config{Iterations: 3, Issues: 20}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]Result)
+	for _, r := range report.Results {
+		byName[r.Name] = r
+	}
+	if r := byName[BenchDispatch]; r.Iterations != 3 || r.Skipped != "" {
+		t.Errorf("dispatch = %+v", r)
+	}
+	if r := byName[BenchSlingDone]; r.Skipped == "" {
+		t.Errorf("sling_done ran without bd: %+v", r)
+	}
+}
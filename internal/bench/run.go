@@ -0,0 +1,191 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/flock"
+)
+
+// maxBdIssues caps the workload loaded into the scratch beads repo; bd
+// benchmarks measure per-call overhead, not bulk import.
+const maxBdIssues = 50
+
+// Run executes the configured benchmarks and returns a report stamped
+// with version.
+func Run(cfg Config, version string) (*Report, error) {
+	cfg.defaults()
+	scratch, err := os.MkdirTemp("", "gt-bench-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(scratch)
+
+	report := &Report{Version: version, RanAt: time.Now(), Issues: cfg.Issues}
+	workload := Workload(cfg.Issues)
+
+	if cfg.wants(BenchDispatch) {
+		samples, err := sample(cfg.Iterations, func() error {
+			snap := beads.NewSnapshot(workload, time.Now())
+			_ = snap.Ready()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		report.Results = append(report.Results, Summarize(BenchDispatch, samples))
+	}
+
+	if cfg.wants(BenchEvents) {
+		r, err := benchEvents(filepath.Join(scratch, events.EventsFile), cfg.Iterations)
+		if err != nil {
+			return nil, fmt.Errorf("events benchmark: %w", err)
+		}
+		report.Results = append(report.Results, r)
+	}
+
+	bdResults, err := benchBd(filepath.Join(scratch, "rig"), workload, cfg)
+	if err != nil {
+		return nil, err
+	}
+	report.Results = append(report.Results, bdResults...)
+	return report, nil
+}
+
+// Workload returns n synthetic issues in which every fifth issue is
+// blocked by its predecessor and a third are closed, roughly the shape
+// of a busy rig.
+func Workload(n int) []*beads.Issue {
+	issues := make([]*beads.Issue, 0, n)
+	for i := 0; i < n; i++ {
+		issue := &beads.Issue{
+			ID:       fmt.Sprintf("bench-%d", i),
+			Title:    fmt.Sprintf("Synthetic task %d", i),
+			Type:     "task",
+			Status:   "open",
+			Priority: i % 5,
+		}
+		if i%3 == 2 {
+			issue.Status = "closed"
+		}
+		if i > 0 && i%5 == 0 {
+			issue.BlockedBy = []string{fmt.Sprintf("bench-%d", i-1)}
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func benchEvents(path string, n int) (Result, error) {
+	line, err := json.Marshal(events.Event{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Source:     "gt",
+		Type:       events.TypeSling,
+		Actor:      "bench",
+		Payload:    events.SlingPayload("bench-1", "bench/polecats/Toast"),
+		Visibility: events.VisibilityFeed,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	line = append(line, '\n')
+	samples, err := sample(n, func() error {
+		return flock.AppendFile(path, line, 0644)
+	})
+	return Summarize(BenchEvents, samples), err
+}
+
+// benchBd measures bd round trips in a scratch beads repo. Every bd
+// benchmark is reported as skipped when bd is unavailable.
+func benchBd(dir string, workload []*beads.Issue, cfg Config) ([]Result, error) {
+	names := []string{BenchBdShow, BenchBdList, BenchSlingDone}
+	var wanted []string
+	for _, n := range names {
+		if cfg.wants(n) {
+			wanted = append(wanted, n)
+		}
+	}
+	if len(wanted) == 0 {
+		return nil, nil
+	}
+	skip := func(reason string) []Result {
+		out := make([]Result, 0, len(wanted))
+		for _, n := range wanted {
+			out = append(out, Result{Name: n, Skipped: reason})
+		}
+		return out
+	}
+
+	if _, err := exec.LookPath("bd"); err != nil {
+		return skip("bd not installed"), nil
+	}
+	// Pin BEADS_DIR to the scratch repo: polecat sessions export their
+	// rig's, and bd would otherwise load the workload into it
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		return nil, err
+	}
+	env := make([]string, 0, len(os.Environ())+1)
+	for _, e := range os.Environ() {
+		if !strings.HasPrefix(e, "BEADS_DIR=") {
+			env = append(env, e)
+		}
+	}
+	initCmd := exec.Command("bd", "init", "--prefix", "bench")
+	initCmd.Dir = dir
+	initCmd.Env = append(env, "BEADS_DIR="+beadsDir)
+	if out, err := initCmd.CombinedOutput(); err != nil {
+		return skip(fmt.Sprintf("bd init failed: %s", out)), nil
+	}
+
+	b := beads.NewWithBeadsDir(dir, beadsDir)
+	opts := make([]beads.CreateOptions, 0, min(len(workload), maxBdIssues))
+	for _, issue := range workload[:min(len(workload), maxBdIssues)] {
+		opts = append(opts, beads.CreateOptions{Title: issue.Title, Type: issue.Type, Priority: issue.Priority})
+	}
+	created, err := b.CreateBatch(opts)
+	if err != nil || len(created) == 0 {
+		return skip(fmt.Sprintf("loading workload: %v", err)), nil
+	}
+
+	var results []Result
+	for _, name := range wanted {
+		var fn func() error
+		switch name {
+		case BenchBdShow:
+			fn = func() error { _, err := b.Show(created[0].ID); return err }
+		case BenchBdList:
+			fn = func() error { _, err := b.List(beads.ListOptions{Status: "open", Priority: -1}); return err }
+		case BenchSlingDone:
+			fn = func() error { return slingDone(b) }
+		}
+		samples, err := sample(cfg.Iterations, fn)
+		if err != nil {
+			results = append(results, Result{Name: name, Skipped: err.Error()})
+			continue
+		}
+		results = append(results, Summarize(name, samples))
+	}
+	return results, nil
+}
+
+// slingDone walks one bead through the lifecycle gt drives for a polecat:
+// created, slung (in_progress with an assignee), and closed by gt done.
+func slingDone(b *beads.Beads) error {
+	issue, err := b.Create(beads.CreateOptions{Title: "bench sling", Type: "task", Priority: 2})
+	if err != nil {
+		return err
+	}
+	status, assignee := "in_progress", "bench/polecats/Toast"
+	if err := b.Update(issue.ID, beads.UpdateOptions{Status: &status, Assignee: &assignee}); err != nil {
+		return err
+	}
+	return b.CloseWithReason("bench", issue.ID)
+}
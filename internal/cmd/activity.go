@@ -3,6 +3,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/events"
@@ -25,6 +28,14 @@ var (
 	activityCount     int
 )
 
+// Activity export command flags
+var (
+	activityExportFormat string
+	activityExportSince  string
+	activityExportTypes  []string
+	activityExportOutput string
+)
+
 var activityCmd = &cobra.Command{
 	Use:     "activity",
 	GroupID: GroupDiag,
@@ -34,7 +45,8 @@ var activityCmd = &cobra.Command{
 Events are written to ~/gt/.events.jsonl and can be viewed with 'gt feed'.
 
 Subcommands:
-  emit    Emit an activity event`,
+  emit    Emit an activity event
+  export  Export the events log as CSV or JSONL`,
 }
 
 var activityEmitCmd = &cobra.Command{
@@ -83,10 +95,38 @@ func init() {
 	activityEmitCmd.Flags().StringVar(&activityTo, "to", "", "Escalation target (for escalation_sent: mayor, deacon)")
 	activityEmitCmd.Flags().IntVar(&activityCount, "count", 0, "Polecat count (for patrol events)")
 
+	// Export command flags
+	activityExportCmd.Flags().StringVar(&activityExportFormat, "format", events.FormatCSV, "Output format: csv or jsonl")
+	activityExportCmd.Flags().StringVar(&activityExportSince, "since", "", "Only export events since duration (e.g., 24h, 7d)")
+	activityExportCmd.Flags().StringSliceVar(&activityExportTypes, "type", nil, "Only export these event types (repeatable)")
+	activityExportCmd.Flags().StringVarP(&activityExportOutput, "output", "o", "", "Write to file instead of stdout")
+
 	activityCmd.AddCommand(activityEmitCmd)
+	activityCmd.AddCommand(activityExportCmd)
 	rootCmd.AddCommand(activityCmd)
 }
 
+var activityExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the events log as CSV or JSONL",
+	Long: `Export the raw events log with a stable, flat schema for offline analysis.
+
+Columns (in order): ts, source, type, actor, visibility, rig, bead,
+target, reason, payload. Common payload fields are lifted into their own
+columns; the full payload is kept as JSON in the last column. New columns
+are only ever appended.
+
+Parquet is not supported directly; load the CSV with pandas/duckdb and
+convert there.
+
+Examples:
+  gt activity export > events.csv
+  gt activity export --since=7d --type=sling --type=done
+  gt activity export --format=jsonl -o events.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: runActivityExport,
+}
+
 func runActivityEmit(cmd *cobra.Command, args []string) error {
 	eventType := args[0]
 
@@ -195,4 +235,45 @@ func runActivityEmit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runActivityExport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	filter := events.ExportFilter{Types: activityExportTypes}
+	if activityExportSince != "" {
+		d, err := parseDuration(activityExportSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		filter.Since = time.Now().Add(-d)
+	}
+
+	evts, err := events.ReadTown(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if activityExportOutput != "" {
+		f, err := os.Create(activityExportOutput)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	n, err := events.Export(out, evts, activityExportFormat, filter)
+	if err != nil {
+		return fmt.Errorf("exporting events: %w", err)
+	}
+
+	if activityExportOutput != "" {
+		fmt.Printf("%s Exported %d events to %s\n", style.Success.Render("✓"), n, activityExportOutput)
+	}
+	return nil
+}
+
 // Note: detectActor is defined in sling.go and reused here
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/townspec"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Apply command flags
+var (
+	applyDryRun bool
+	applyPrune  bool
+	applyJSON   bool
+)
+
+var applyCmd = &cobra.Command{
+	Use:     "apply <spec.toml>",
+	GroupID: GroupConfig,
+	Short:   "Reconcile the town with a declarative spec",
+	Long: `Bring the town in line with a spec file kept in version control.
+
+The spec declares rigs, agent presets, daemon policies, recurring issues,
+and saved queries. gt apply shows the plan, then makes the changes.
+Sections missing from the spec are left alone. Rigs, agents, queries, and
+recurring issues that exist but aren't declared are only removed with
+--prune (removing a rig unregisters it; its files stay on disk).
+
+Recurring issues live in the town beads, tagged apply:<key>. Apply keeps
+one open issue per key, so closing it and re-running apply opens the next.
+
+Example spec:
+  [settings]
+  default_agent = "claude"
+  observers = ["auditor"]
+
+  [agents.gemini]
+  command = "gemini"
+  args = ["--yolo"]
+
+  [policies.idle_park]
+  enabled = true
+  after = "45m"
+
+  [policies.watchdog]
+  grace = "10m"
+  respawn = true
+
+  [policies.dispatch]
+  enabled = true
+  wip = 1
+  affinity = { Toast = ["bug"] }
+
+  [queries.triage]
+  description = "Unassigned bugs"
+  type = "bug"
+  status = "open"
+
+  [[rig]]
+  name = "gastown"
+  git_url = "https://github.com/steveyegge/gastown.git"
+  prefix = "gt"
+
+  [[issue]]
+  key = "weekly-deps"
+  title = "Update dependencies"
+  priority = 3
+
+Examples:
+  gt apply town.toml --dry-run
+  gt apply town.toml --prune`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApply,
+}
+
+func init() {
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Show the plan without changing anything")
+	applyCmd.Flags().BoolVar(&applyPrune, "prune", false, "Remove rigs, agents, queries, and recurring issues not in the spec")
+	applyCmd.Flags().BoolVar(&applyJSON, "json", false, "Output the plan as JSON")
+	rootCmd.AddCommand(applyCmd)
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	spec, err := townspec.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	townBeads := beads.New(townRoot)
+	var issues beads.Client
+	if len(spec.Issues) > 0 {
+		issues = townBeads
+	}
+	state, err := townspec.LoadState(townRoot, issues)
+	if err != nil {
+		return err
+	}
+	plan := townspec.Diff(spec, state, applyPrune)
+
+	if applyJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(plan); err != nil {
+			return err
+		}
+	} else {
+		printApplyPlan(plan)
+	}
+	if plan.Empty() || applyDryRun {
+		return nil
+	}
+
+	err = townspec.Apply(spec, state, plan, townspec.Target{
+		TownRoot: townRoot,
+		Beads:    townBeads,
+		AddRig: func(r townspec.RigSpec) error {
+			return addRig(townRoot, r.Name, r.GitURL, rigAddOptions{Prefix: r.Prefix, Branch: r.Branch})
+		},
+		RemoveRig: func(name string) error {
+			return runRigRemove(cmd, []string{name})
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if !applyJSON {
+		fmt.Printf("\n%s Applied %d change(s)\n", style.Success.Render("✓"), len(plan.Changes))
+	}
+	return nil
+}
+
+func printApplyPlan(plan *townspec.Plan) {
+	for _, w := range plan.Warnings {
+		fmt.Printf("%s %s\n", style.Warning.Render("!"), w)
+	}
+	if plan.Empty() {
+		fmt.Printf("%s Town matches the spec\n", style.Success.Render("✓"))
+		return
+	}
+	fmt.Printf("%s\n", style.Bold.Render("Plan:"))
+	for _, c := range plan.Changes {
+		line := "  " + c.String()
+		if c.Action == townspec.ActionDelete {
+			line = style.Warning.Render(line)
+		}
+		fmt.Println(line)
+	}
+	if applyDryRun {
+		fmt.Printf("\n%s\n", style.Dim.Render("Dry run: nothing changed"))
+	}
+}
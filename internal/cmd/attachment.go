@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	attachmentListJSON  bool
+	attachmentFetchOut  string
+	attachmentFetchPath bool
+)
+
+var attachmentCmd = &cobra.Command{
+	Use:     "attachment",
+	GroupID: GroupWork,
+	Short:   "Attach files (logs, diffs) to beads",
+	RunE:    requireSubcommand,
+	Long: `Attach files to beads, so agents can pass logs and diffs along with
+their mail and merge requests, not just prose.
+
+Attached files are copied under .beads/attachments/<bead-id>/ of the
+bead's rig (mail lives in the town's beads). The bead records each file's
+name, size and SHA-256 in its "attachments" field; fetching a file checks
+it against that checksum.
+
+Any bead takes attachments: issues, merge requests, and mail messages
+(by message ID, as shown by gt mail inbox). A town bead can also be given
+by a unique start of its ID after the prefix ("abc" for hq-abc1).
+
+Examples:
+  gt attachment add gt-abc test-output.log
+  gt attachment list gt-abc
+  gt attachment fetch gt-abc test-output.log -o /tmp/out.log`,
+}
+
+var attachmentAddCmd = &cobra.Command{
+	Use:   "add <bead> <file>...",
+	Short: "Attach files to a bead",
+	Long: `Copy files into a bead's attachments and record them on the bead.
+
+A file with the same name as an existing attachment replaces it.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAttachmentAdd,
+}
+
+var attachmentListCmd = &cobra.Command{
+	Use:   "list <bead>",
+	Short: "List a bead's attached files",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAttachmentList,
+}
+
+var attachmentFetchCmd = &cobra.Command{
+	Use:   "fetch <bead> <name>",
+	Short: "Print or copy out an attached file",
+	Long: `Write an attached file to stdout, to a file with -o, or print its
+stored path with --path. Fails if the stored file no longer matches the
+checksum recorded when it was attached.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAttachmentFetch,
+}
+
+func init() {
+	attachmentListCmd.Flags().BoolVar(&attachmentListJSON, "json", false, "Output as JSON")
+	attachmentFetchCmd.Flags().StringVarP(&attachmentFetchOut, "output", "o", "", "Write the file here instead of stdout")
+	attachmentFetchCmd.Flags().BoolVar(&attachmentFetchPath, "path", false, "Print the stored file's path instead of its contents")
+
+	attachmentCmd.AddCommand(attachmentAddCmd)
+	attachmentCmd.AddCommand(attachmentListCmd)
+	attachmentCmd.AddCommand(attachmentFetchCmd)
+	rootCmd.AddCommand(attachmentCmd)
+}
+
+// attachmentBeads returns a beads handle on the database holding ref and
+// the bead's full ID, expanding a short unique suffix.
+func attachmentBeads(ref string) (*beads.Beads, string, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	b := beads.New(beads.ResolveHookDir(townRoot, ref, townRoot))
+	beadID, err := b.ResolveID(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	return b, beadID, nil
+}
+
+func runAttachmentAdd(cmd *cobra.Command, args []string) error {
+	b, beadID, err := attachmentBeads(args[0])
+	if err != nil {
+		return err
+	}
+	for _, path := range args[1:] {
+		att, err := b.AttachFile(beadID, path)
+		if err != nil {
+			return fmt.Errorf("attaching %s: %w", path, err)
+		}
+		fmt.Printf("%s Attached %s to %s (%s, sha256 %s)\n", style.Bold.Render("✓"),
+			att.Name, beadID, formatAttachmentSize(att.Size), att.SHA256[:min(12, len(att.SHA256))])
+	}
+	return nil
+}
+
+func runAttachmentList(cmd *cobra.Command, args []string) error {
+	b, beadID, err := attachmentBeads(args[0])
+	if err != nil {
+		return err
+	}
+	list, err := b.ListAttachments(beadID)
+	if err != nil {
+		return err
+	}
+
+	if attachmentListJSON {
+		if list == nil {
+			list = []beads.FileAttachment{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+	if len(list) == 0 {
+		fmt.Printf("No files attached to %s\n", beadID)
+		return nil
+	}
+	for _, att := range list {
+		fmt.Printf("  %-32s %8s  %s  %s\n", att.Name, formatAttachmentSize(att.Size),
+			att.AttachedAt.Local().Format(time.DateTime), style.Dim.Render(att.SHA256[:min(12, len(att.SHA256))]))
+	}
+	return nil
+}
+
+func runAttachmentFetch(cmd *cobra.Command, args []string) error {
+	name := args[1]
+	b, beadID, err := attachmentBeads(args[0])
+	if err != nil {
+		return err
+	}
+	path, err := b.FetchAttachment(beadID, name)
+	if err != nil {
+		return err
+	}
+	if attachmentFetchPath {
+		fmt.Println(path)
+		return nil
+	}
+
+	in, err := os.Open(path) //nolint:gosec // G304: path is the verified attachment
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if attachmentFetchOut == "" {
+		_, err = io.Copy(os.Stdout, in)
+		return err
+	}
+	out, err := os.Create(attachmentFetchOut)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", attachmentFetchOut, err)
+	}
+	_, copyErr := io.Copy(out, in)
+	if closeErr := out.Close(); copyErr == nil {
+		copyErr = closeErr
+	}
+	if copyErr != nil {
+		return fmt.Errorf("writing %s: %w", attachmentFetchOut, copyErr)
+	}
+	return nil
+}
+
+// formatAttachmentSize renders a byte count for humans.
+func formatAttachmentSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fM", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fK", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/redact"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/townlog"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -21,10 +22,11 @@ import (
 
 // Audit command flags
 var (
-	auditActor string
-	auditSince string
-	auditLimit int
-	auditJSON  bool
+	auditActor      string
+	auditSince      string
+	auditLimit      int
+	auditJSON       bool
+	auditRedactions bool
 )
 
 var auditCmd = &cobra.Command{
@@ -39,6 +41,7 @@ Shows a unified timeline of work performed by an actor including:
   - Beads closed by the actor (via assignee)
   - Town log events (spawn, done, handoff, etc.)
   - Activity feed events
+  - Molecule detach/burn/squash records
 
 Examples:
   gt audit --actor=greenplace/crew/joe       # Show all work by joe
@@ -46,7 +49,8 @@ Examples:
   gt audit --actor=mayor                  # Show mayor's activity
   gt audit --since=24h                    # Show all activity in last 24h
   gt audit --actor=joe --since=1h         # Combined filters
-  gt audit --json                         # Output as JSON`,
+  gt audit --json                         # Output as JSON
+  gt audit --redactions                   # Count secrets redacted so far`,
 	RunE: runAudit,
 }
 
@@ -55,10 +59,29 @@ func init() {
 	auditCmd.Flags().StringVar(&auditSince, "since", "", "Show events since duration (e.g., 1h, 24h, 7d)")
 	auditCmd.Flags().IntVarP(&auditLimit, "limit", "n", 50, "Maximum number of entries to show")
 	auditCmd.Flags().BoolVar(&auditJSON, "json", false, "Output as JSON")
+	auditCmd.Flags().BoolVar(&auditRedactions, "redactions", false, "Show how many secrets were redacted from events, beads, and mail")
 
 	rootCmd.AddCommand(auditCmd)
 }
 
+// printRedactionCounts prints the town's redaction counts by source.
+func printRedactionCounts(townRoot string) error {
+	counts, err := redact.LoadCounts(townRoot)
+	if err != nil {
+		return err
+	}
+	if auditJSON {
+		return json.NewEncoder(os.Stdout).Encode(counts)
+	}
+	total := 0
+	for _, source := range []string{redact.SourceEvent, redact.SourceBead, redact.SourceMail, redact.SourceTranscript} {
+		fmt.Printf("%-10s %d\n", source, counts[source])
+		total += counts[source]
+	}
+	fmt.Printf("%-10s %d\n", "total", total)
+	return nil
+}
+
 // AuditEntry represents a single entry in the audit log.
 type AuditEntry struct {
 	Timestamp time.Time `json:"timestamp"`
@@ -75,6 +98,9 @@ func runAudit(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
+	if auditRedactions {
+		return printRedactionCounts(townRoot)
+	}
 
 	// Parse since duration if provided
 	var sinceTime time.Time
@@ -118,6 +144,13 @@ func runAudit(cmd *cobra.Command, args []string) error {
 	}
 	allEntries = append(allEntries, feedEntries...)
 
+	// 5. Molecule detach audit logs
+	detachEntries, err := collectDetachAudit(townRoot, auditActor, sinceTime)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not query molecule audit log: %v\n", err)
+	}
+	allEntries = append(allEntries, detachEntries...)
+
 	// Sort by timestamp (newest first)
 	sort.Slice(allEntries, func(i, j int) bool {
 		return allEntries[i].Timestamp.After(allEntries[j].Timestamp)
@@ -275,7 +308,7 @@ func collectBeadsActivity(townRoot, actor string, since time.Time) ([]AuditEntry
 		// Check created_by
 		if issue.CreatedBy != "" {
 			if actor == "" || matchesActor(issue.CreatedBy, actor) {
-				ts := parseBeadsTimestamp(issue.CreatedAt)
+				ts := issue.CreatedAt
 				if !since.IsZero() && ts.Before(since) {
 					continue
 				}
@@ -294,9 +327,9 @@ func collectBeadsActivity(townRoot, actor string, since time.Time) ([]AuditEntry
 		// Check if issue was closed and has an assignee
 		if issue.Status == "closed" && issue.Assignee != "" {
 			if actor == "" || matchesActor(issue.Assignee, actor) {
-				ts := parseBeadsTimestamp(issue.ClosedAt)
+				ts := issue.ClosedAt
 				if ts.IsZero() {
-					ts = parseBeadsTimestamp(issue.UpdatedAt)
+					ts = issue.UpdatedAt
 				}
 				if !since.IsZero() && ts.Before(since) {
 					continue
@@ -316,23 +349,6 @@ func collectBeadsActivity(townRoot, actor string, since time.Time) ([]AuditEntry
 	return entries, nil
 }
 
-// parseBeadsTimestamp parses a beads timestamp string.
-func parseBeadsTimestamp(s string) time.Time {
-	// Try various formats
-	formats := []string{
-		time.RFC3339,
-		"2006-01-02 15:04",
-		"2006-01-02T15:04:05",
-		"2006-01-02",
-	}
-	for _, format := range formats {
-		if t, err := time.Parse(format, s); err == nil {
-			return t
-		}
-	}
-	return time.Time{}
-}
-
 // collectTownlogEvents queries the town log for agent lifecycle events.
 func collectTownlogEvents(townRoot, actor string, since time.Time) ([]AuditEntry, error) {
 	var entries []AuditEntry
@@ -403,23 +419,12 @@ func formatTownlogSummary(e townlog.Event) string {
 func collectFeedEvents(townRoot, actor string, since time.Time) ([]AuditEntry, error) {
 	var entries []AuditEntry
 
-	eventsPath := filepath.Join(townRoot, events.EventsFile)
-	file, err := os.Open(eventsPath)
+	evts, err := events.ReadTown(townRoot)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil // No events file yet
-		}
 		return nil, err
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		var e events.Event
-		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
-			continue // Skip malformed lines
-		}
 
+	for _, e := range evts {
 		// Apply actor filter
 		if actor != "" && !matchesActor(e.Actor, actor) {
 			continue
@@ -445,6 +450,51 @@ func collectFeedEvents(townRoot, actor string, since time.Time) ([]AuditEntry, e
 	return entries, nil
 }
 
+// collectDetachAudit reads molecule detach records from the town's and each
+// rig's beads audit log. A log shared across rigs is read once.
+func collectDetachAudit(townRoot, actor string, since time.Time) ([]AuditEntry, error) {
+	dirs := []string{townRoot}
+	if routes, err := beads.LoadRoutes(filepath.Join(townRoot, ".beads")); err == nil {
+		for _, r := range routes {
+			if r.Path != "." {
+				dirs = append(dirs, filepath.Join(townRoot, r.Path))
+			}
+		}
+	}
+
+	var entries []AuditEntry
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		b := beads.New(dir)
+		if seen[b.AuditLogPath()] {
+			continue
+		}
+		seen[b.AuditLogPath()] = true
+
+		records, err := b.ReadAudit(beads.AuditFilter{Since: since})
+		if err != nil {
+			return entries, err
+		}
+		for _, r := range records {
+			if actor != "" && !matchesActor(r.DetachedBy, actor) {
+				continue
+			}
+			ts, _ := beads.ParseTimestamp(r.Timestamp)
+			summary := fmt.Sprintf("%s %s from %s", capitalizeFirst(r.Operation), r.DetachedMolecule, r.PinnedBeadID)
+			entries = append(entries, AuditEntry{
+				Timestamp: ts,
+				Source:    "beads",
+				Type:      "molecule_" + r.Operation,
+				Actor:     r.DetachedBy,
+				Summary:   summary,
+				Details:   r.Reason,
+				ID:        r.PinnedBeadID,
+			})
+		}
+	}
+	return entries, nil
+}
+
 // formatFeedSummary creates a readable summary from a feed event.
 func formatFeedSummary(e events.Event) string {
 	switch e.Type {
@@ -97,36 +97,6 @@ func TestMatchesActor(t *testing.T) {
 	}
 }
 
-func TestParseBeadsTimestamp(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string // Format: "2006-01-02 15:04"
-		isZero   bool
-	}{
-		{"2025-12-30T16:19:00Z", "2025-12-30 16:19", false},
-		{"2025-12-30 16:19", "2025-12-30 16:19", false},
-		{"2025-12-30", "2025-12-30 00:00", false},
-		{"invalid", "", true},
-		{"", "", true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			got := parseBeadsTimestamp(tt.input)
-			if tt.isZero {
-				if !got.IsZero() {
-					t.Errorf("parseBeadsTimestamp(%q) expected zero time, got %v", tt.input, got)
-				}
-				return
-			}
-			gotStr := got.Format("2006-01-02 15:04")
-			if gotStr != tt.expected {
-				t.Errorf("parseBeadsTimestamp(%q) = %q, want %q", tt.input, gotStr, tt.expected)
-			}
-		})
-	}
-}
-
 func TestFormatSource(t *testing.T) {
 	// Just verify it doesn't panic and returns non-empty strings
 	sources := []string{"git", "beads", "townlog", "events", "unknown"}
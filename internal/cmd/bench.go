@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/bench"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Bench command flags
+var (
+	benchIterations int
+	benchIssues     int
+	benchOnly       []string
+	benchBaseline   string
+	benchThreshold  float64
+	benchJSON       bool
+	benchNoSave     bool
+)
+
+var benchCmd = &cobra.Command{
+	Use:     "bench",
+	GroupID: GroupDiag,
+	Short:   "Run the performance benchmark suite",
+	Long: `Measure Gas Town's hot paths against a synthetic workload.
+
+Benchmarks:
+  dispatch     compute ready work from a snapshot of the workload
+  events       one locked append to an events log
+  bd_show      one bd show round trip
+  bd_list      bd list over the workload
+  sling_done   create → in_progress → close through bd
+
+Everything runs in a scratch directory; the town's beads and logs are
+not touched. bd benchmarks are skipped when bd is not installed.
+
+Each run is saved under the town state directory and compared with the
+latest run from a different gt version (or --baseline). Benchmarks whose
+median slowed by more than --threshold are reported as regressions and
+the command exits 1.
+
+Examples:
+  gt bench
+  gt bench --only bd_show,sling_done -n 50
+  gt bench --baseline ~/bench-0.2.1.json --json`,
+	RunE: runBench,
+}
+
+func init() {
+	benchCmd.Flags().IntVarP(&benchIterations, "iterations", "n", 20, "Samples per benchmark")
+	benchCmd.Flags().IntVar(&benchIssues, "issues", 200, "Synthetic workload size")
+	benchCmd.Flags().StringSliceVar(&benchOnly, "only", nil, "Run only these benchmarks")
+	benchCmd.Flags().StringVar(&benchBaseline, "baseline", "", "Compare against this saved report")
+	benchCmd.Flags().Float64Var(&benchThreshold, "threshold", bench.DefaultRegressionThreshold, "Median slowdown (fraction) counted as a regression")
+	benchCmd.Flags().BoolVar(&benchJSON, "json", false, "Output as JSON")
+	benchCmd.Flags().BoolVar(&benchNoSave, "no-save", false, "Don't save this run")
+	rootCmd.AddCommand(benchCmd)
+}
+
+// benchOutput is the --json shape of gt bench.
+type benchOutput struct {
+	Report      *bench.Report      `json:"report"`
+	SavedTo     string             `json:"saved_to,omitempty"`
+	Baseline    *bench.Report      `json:"baseline,omitempty"`
+	Comparisons []bench.Comparison `json:"comparisons,omitempty"`
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	reportDir := bench.ReportDir(config.LoadPaths(townRoot).StateDir)
+
+	if !benchJSON {
+		fmt.Printf("%s Running benchmarks (%d iterations, %d issues)...\n", style.Bold.Render("⏱"), benchIterations, benchIssues)
+	}
+	report, err := bench.Run(bench.Config{Iterations: benchIterations, Issues: benchIssues, Only: benchOnly}, Version)
+	if err != nil {
+		return err
+	}
+	report.Commit = resolveCommitHash()
+
+	out := benchOutput{Report: report}
+	if benchBaseline != "" {
+		out.Baseline, err = bench.LoadReport(benchBaseline)
+	} else {
+		out.Baseline, err = bench.LatestBaseline(reportDir, report.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("loading baseline: %w", err)
+	}
+	if out.Baseline != nil {
+		out.Comparisons = bench.Compare(out.Baseline, report, benchThreshold)
+	}
+	if !benchNoSave {
+		if out.SavedTo, err = report.Save(reportDir); err != nil {
+			return fmt.Errorf("saving report: %w", err)
+		}
+	}
+
+	if benchJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return err
+		}
+	} else {
+		printBench(out)
+	}
+
+	for _, c := range out.Comparisons {
+		if c.Regression {
+			return NewSilentExit(1)
+		}
+	}
+	return nil
+}
+
+func printBench(out benchOutput) {
+	fmt.Println()
+	fmt.Printf("  %-12s %10s %10s %10s %10s\n", "BENCHMARK", "P50", "P95", "MAX", "OPS/S")
+	for _, r := range out.Report.Results {
+		if r.Skipped != "" {
+			fmt.Printf("  %-12s %s\n", r.Name, style.Dim.Render("skipped: "+r.Skipped))
+			continue
+		}
+		fmt.Printf("  %-12s %10s %10s %10s %10.0f\n", r.Name, fmtBenchDur(r.P50), fmtBenchDur(r.P95), fmtBenchDur(r.Max), r.PerSecond)
+	}
+
+	if out.Baseline != nil {
+		fmt.Printf("\n%s vs %s (%s)\n", style.Bold.Render("Compared"), out.Baseline.Version, out.Baseline.RanAt.Format("2006-01-02"))
+		for _, c := range out.Comparisons {
+			line := fmt.Sprintf("  %-12s %10s → %-10s %+6.1f%%", c.Name, fmtBenchDur(c.Baseline), fmtBenchDur(c.Current), c.Change*100)
+			if c.Regression {
+				fmt.Printf("%s %s\n", line, style.Warning.Render("REGRESSION"))
+			} else {
+				fmt.Println(line)
+			}
+		}
+	} else {
+		fmt.Printf("\n%s\n", style.Dim.Render("No baseline from another version yet"))
+	}
+	if out.SavedTo != "" {
+		fmt.Printf("%s\n", style.Dim.Render("Saved "+out.SavedTo))
+	}
+}
+
+// fmtBenchDur renders a duration with three significant figures.
+func fmtBenchDur(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	case d >= time.Millisecond:
+		return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+	default:
+		return fmt.Sprintf("%.1fµs", float64(d)/float64(time.Microsecond))
+	}
+}
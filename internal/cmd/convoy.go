@@ -16,6 +16,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tui/convoy"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -647,11 +648,7 @@ func checkAndCloseCompletedConvoys(townBeads string) ([]struct{ ID, Title string
 
 		if allClosed {
 			// Close the convoy
-			closeArgs := []string{"close", convoy.ID, "-r", "All tracked issues completed"}
-			closeCmd := exec.Command("bd", closeArgs...)
-			closeCmd.Dir = townBeads
-
-			if err := closeCmd.Run(); err != nil {
+			if err := beads.New(townBeads).CloseWithReason("All tracked issues completed", convoy.ID); err != nil {
 				style.PrintWarning("couldn't close convoy %s: %v", convoy.ID, err)
 				continue
 			}
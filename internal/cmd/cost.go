@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/cost"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	costJSON  bool
+	costSince string
+	costToday bool
+	costTop   int
+
+	costRecordActor   string
+	costRecordBead    string
+	costRecordSession string
+	costRecordModel   string
+	costRecordInput   int64
+	costRecordOutput  int64
+	costRecordUSD     float64
+)
+
+var costCmd = &cobra.Command{
+	Use:     "cost",
+	GroupID: GroupDiag,
+	Short:   "Report token and dollar spend per bead and per agent",
+	Long: `Report what agents have spent, per bead and per agent, from the town's
+usage ledger (.runtime/usage.jsonl).
+
+Agent runners append usage records with "gt cost record" (one record per
+turn or session) or "gt cost ingest" (JSON lines). Each record is keyed
+by the agent and the bead it had hooked, so "gt cost bead <epic>" can add
+up everything the epic's work cost.
+
+Budgets are set in the town's settings/config.json:
+  {"budgets": {
+    "bead":  {"usd": 25, "action": "halt"},
+    "agent": {"usd": 100, "tokens": 20000000}}}
+
+The bead limit caps a bead's total spend; the agent limit caps an agent's
+spend per UTC day. The daemon checks them each heartbeat. A breach logs a
+budget_exceeded event; with action "halt" it also stops the work: an
+over-budget bead is set to blocked and its polecat stopped, and an
+over-budget polecat is stopped and its beads released. "gt costs" shows
+live session costs scraped from tmux instead.
+
+Examples:
+  gt cost                        # Spend by bead and agent, all time
+  gt cost --today                # Today's spend (UTC)
+  gt cost --since 168h --top 5   # Top five of the last week
+  gt cost bead gt-epic1          # An epic's spend, including its children
+  gt cost record --input-tokens 12000 --output-tokens 900 --usd 0.21`,
+	Args: cobra.NoArgs,
+	RunE: runCost,
+}
+
+var costBeadCmd = &cobra.Command{
+	Use:   "bead <id>",
+	Short: "Show a bead's spend, including its descendants",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCostBead,
+}
+
+var costRecordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Append a usage record to the ledger (called by agent runners)",
+	Long: `Append one usage record to the town's usage ledger.
+
+The actor defaults to the current agent and the bead to the bead on its
+hook, so a runner inside an agent session only passes the usage.`,
+	Args: cobra.NoArgs,
+	RunE: runCostRecord,
+}
+
+var costIngestCmd = &cobra.Command{
+	Use:   "ingest [file]",
+	Short: "Append usage records from JSON lines (default: stdin)",
+	Long: `Append usage records to the town's usage ledger, one JSON object per line:
+  {"actor": "gastown/polecats/Toast", "bead": "gt-abc", "model": "opus",
+   "input_tokens": 12000, "output_tokens": 900, "cost_usd": 0.21}
+
+"ts" (RFC 3339) defaults to now. Records without an actor are rejected,
+and nothing is written if any is.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCostIngest,
+}
+
+func init() {
+	costCmd.Flags().BoolVar(&costJSON, "json", false, "Output as JSON")
+	costCmd.Flags().StringVar(&costSince, "since", "", "Only count usage within this duration (e.g. 24h)")
+	costCmd.Flags().BoolVar(&costToday, "today", false, "Only count usage since midnight UTC")
+	costCmd.Flags().IntVar(&costTop, "top", 10, "Rows per breakdown (0 for all)")
+	costBeadCmd.Flags().BoolVar(&costJSON, "json", false, "Output as JSON")
+
+	costRecordCmd.Flags().StringVar(&costRecordActor, "actor", "", "Agent address (default: current agent)")
+	costRecordCmd.Flags().StringVar(&costRecordBead, "bead", "", "Bead the usage was for (default: hooked bead)")
+	costRecordCmd.Flags().StringVar(&costRecordSession, "session", "", "Session the usage came from")
+	costRecordCmd.Flags().StringVar(&costRecordModel, "model", "", "Model used")
+	costRecordCmd.Flags().Int64Var(&costRecordInput, "input-tokens", 0, "Input tokens")
+	costRecordCmd.Flags().Int64Var(&costRecordOutput, "output-tokens", 0, "Output tokens")
+	costRecordCmd.Flags().Float64Var(&costRecordUSD, "usd", 0, "Cost in dollars")
+
+	costCmd.AddCommand(costBeadCmd)
+	costCmd.AddCommand(costRecordCmd)
+	costCmd.AddCommand(costIngestCmd)
+	rootCmd.AddCommand(costCmd)
+}
+
+// costReportOutput is the JSON output of gt cost.
+type costReportOutput struct {
+	*cost.Report
+	Since    string        `json:"since,omitempty"`
+	Breaches []cost.Breach `json:"breaches,omitempty"`
+}
+
+func runCost(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var since time.Time
+	switch {
+	case costToday:
+		since = time.Now().UTC().Truncate(24 * time.Hour)
+	case costSince != "":
+		d, err := time.ParseDuration(costSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	records, err := cost.Read(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading usage ledger: %w", err)
+	}
+	report := cost.Aggregate(records, since)
+	var breaches []cost.Breach
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+		breaches = cost.Check(records, settings.Budgets, time.Now())
+	}
+
+	if costJSON {
+		out := costReportOutput{Report: report, Breaches: breaches}
+		if !since.IsZero() {
+			out.Since = since.Format(time.RFC3339)
+		}
+		return printCostJSON(out)
+	}
+
+	if report.Total.Records == 0 {
+		fmt.Println(style.Dim.Render("No usage recorded. Agent runners record it with 'gt cost record'."))
+		return nil
+	}
+
+	over := make(map[string]bool)
+	for _, b := range breaches {
+		over[b.Scope+":"+b.Key] = true
+	}
+
+	fmt.Printf("\n%s Spend", style.Bold.Render("💰"))
+	if !since.IsZero() {
+		fmt.Printf(" since %s", since.Local().Format("2006-01-02 15:04"))
+	}
+	fmt.Printf("\n\n%s %s\n", style.Bold.Render("Total:"), formatTotals(report.Total))
+
+	printCostBreakdown("By bead", report.ByBead, cost.ScopeBead, over)
+	printCostBreakdown("By agent", report.ByActor, cost.ScopeAgent, over)
+
+	if len(breaches) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Over budget:"))
+		for _, b := range breaches {
+			action := config.BudgetWarn
+			if b.Halts() {
+				action = config.BudgetHalt
+			}
+			fmt.Printf("  %s %s %s: %s (%s)\n", style.Warning.Render("⚠"), b.Scope, b.Key, b.Describe(), action)
+		}
+	}
+	return nil
+}
+
+// printCostBreakdown prints the costliest rows of a breakdown, marking
+// keys over their budget.
+func printCostBreakdown(title string, by map[string]cost.Totals, scope string, over map[string]bool) {
+	if len(by) == 0 {
+		return
+	}
+	keys := cost.Ranked(by)
+	fmt.Printf("\n%s\n", style.Bold.Render(title+":"))
+	for i, k := range keys {
+		if costTop > 0 && i == costTop {
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("... and %d more", len(keys)-costTop)))
+			break
+		}
+		mark := ""
+		if over[scope+":"+k] {
+			mark = " " + style.Warning.Render("over budget")
+		}
+		fmt.Printf("  %-32s %s%s\n", k, formatTotals(by[k]), mark)
+	}
+}
+
+// formatTotals renders totals as "   $1.23  45.6k tokens".
+func formatTotals(t cost.Totals) string {
+	return fmt.Sprintf("%9s  %s tokens", fmt.Sprintf("$%.2f", t.CostUSD), formatTokenCount(t.Tokens()))
+}
+
+// formatTokenCount abbreviates a token count: 950, 45.6k, 2.1M.
+func formatTokenCount(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// costBeadOutput is the JSON output of gt cost bead.
+type costBeadOutput struct {
+	Bead   string                 `json:"bead"`
+	Total  cost.Totals            `json:"total"`
+	ByBead map[string]cost.Totals `json:"by_bead"`
+}
+
+func runCostBead(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	id := args[0]
+
+	records, err := cost.Read(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading usage ledger: %w", err)
+	}
+	byBead := cost.Aggregate(records, time.Time{}).ByBead
+
+	bd := beads.New(beads.ResolveHookDir(townRoot, id, ""))
+	if _, err := bd.Show(id); err != nil {
+		return fmt.Errorf("bead %s: %w", id, err)
+	}
+	total, visited, err := cost.Rollup(byBead, id, func(parent string) ([]string, error) {
+		children, err := bd.List(beads.ListOptions{Parent: parent, Status: "all", Priority: -1, Limit: beads.NoLimit})
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(children))
+		for _, c := range children {
+			ids = append(ids, c.ID)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	spent := make(map[string]cost.Totals)
+	for _, v := range visited {
+		if t, ok := byBead[v]; ok {
+			spent[v] = t
+		}
+	}
+
+	if costJSON {
+		return printCostJSON(costBeadOutput{Bead: id, Total: total, ByBead: spent})
+	}
+
+	fmt.Printf("\n%s %s\n\n", style.Bold.Render("💰"), id)
+	fmt.Printf("%s %s\n", style.Bold.Render("Total:"), formatTotals(total))
+	if len(visited) > 1 {
+		fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("Across %d bead(s), %d with recorded usage", len(visited), len(spent))))
+	}
+	if len(spent) > 1 {
+		fmt.Printf("\n%s\n", style.Bold.Render("By bead:"))
+		for _, k := range cost.Ranked(spent) {
+			fmt.Printf("  %-32s %s\n", k, formatTotals(spent[k]))
+		}
+	}
+	return nil
+}
+
+func runCostRecord(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	u := cost.Usage{
+		Actor:        costRecordActor,
+		Bead:         costRecordBead,
+		Session:      costRecordSession,
+		Model:        costRecordModel,
+		InputTokens:  costRecordInput,
+		OutputTokens: costRecordOutput,
+		CostUSD:      costRecordUSD,
+	}
+	if u.Actor == "" || u.Bead == "" {
+		if roleInfo, err := GetRole(); err == nil {
+			if u.Actor == "" {
+				u.Actor = roleInfo.ActorString()
+			}
+			if u.Bead == "" {
+				if cwd, err := os.Getwd(); err == nil {
+					u.Bead = detectHookedBead(cwd, roleInfo)
+				}
+			}
+		}
+	}
+	if u.Session == "" {
+		u.Session = os.Getenv("GT_SESSION")
+	}
+
+	if err := cost.Append(townRoot, u); err != nil {
+		return err
+	}
+	warnOverBudget(townRoot, u.Actor, u.Bead)
+	return nil
+}
+
+func runCostIngest(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var in io.Reader = os.Stdin
+	if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	records, err := cost.Decode(in)
+	if err != nil {
+		return fmt.Errorf("reading records: %w", err)
+	}
+	if err := cost.Append(townRoot, records...); err != nil {
+		return err
+	}
+	fmt.Printf("%s Recorded %d usage record(s)\n", style.Success.Render("✓"), len(records))
+	return nil
+}
+
+// warnOverBudget tells the agent, on stderr, when its usage has put it or
+// its bead over budget. The daemon acts on the breach; this only makes
+// sure the agent hears about it first.
+func warnOverBudget(townRoot, actor, bead string) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || settings.Budgets == nil {
+		return
+	}
+	records, err := cost.Read(townRoot)
+	if err != nil {
+		return
+	}
+	for _, b := range cost.Check(records, settings.Budgets, time.Now()) {
+		if (b.Scope == cost.ScopeAgent && b.Key == actor) || (b.Scope == cost.ScopeBead && b.Key == bead) {
+			msg := fmt.Sprintf("%s %s is over budget: %s", b.Scope, b.Key, b.Describe())
+			if b.Halts() {
+				msg += " (work will be halted)"
+			}
+			fmt.Fprintf(os.Stderr, "%s %s\n", style.Warning.Render("⚠"), msg)
+		}
+	}
+}
+
+func printCostJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -35,7 +35,8 @@ var costsCmd = &cobra.Command{
 	Short:   "Show costs for running Claude sessions",
 	Long: `Display costs for Claude Code sessions in Gas Town.
 
-By default, shows live costs scraped from running tmux sessions.
+By default, shows live costs scraped from running tmux sessions. For
+recorded spend per bead and per agent, and budgets, see "gt cost".
 
 Examples:
   gt costs              # Live costs from running sessions
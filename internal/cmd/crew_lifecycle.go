@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -122,13 +123,9 @@ func runCrewRemove(cmd *cobra.Command, args []string) error {
 
 		if crewPurge {
 			// --purge: DELETE the agent bead entirely (obliterate)
-			deleteArgs := []string{"delete", agentBeadID, "--force"}
-			deleteCmd := exec.Command("bd", deleteArgs...)
-			deleteCmd.Dir = r.Path
-			if output, err := deleteCmd.CombinedOutput(); err != nil {
+			if err := beads.New(r.Path).Delete(agentBeadID); err != nil {
 				// Non-fatal: bead might not exist
-				if !strings.Contains(string(output), "no issue found") &&
-					!strings.Contains(string(output), "not found") {
+				if !errors.Is(err, beads.ErrNotFound) && !strings.Contains(err.Error(), "no issue found") {
 					style.PrintWarning("could not delete agent bead %s: %v", agentBeadID, err)
 				}
 			} else {
@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os/exec"
 	"runtime"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/tui/dashboard"
 	"github.com/steveyegge/gastown/internal/web"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -15,6 +19,7 @@ import (
 var (
 	dashboardPort int
 	dashboardOpen bool
+	dashboardTUI  bool
 )
 
 var dashboardCmd = &cobra.Command{
@@ -29,25 +34,39 @@ The dashboard shows real-time convoy status with:
 - Last activity indicator (green/yellow/red)
 - Auto-refresh every 30 seconds via htmx
 
+With --tui, show the town in the terminal instead:
+- Rigs, with ready and blocked queue depths
+- Each rig's polecats and the bead on their hook
+- The live feed (feed-visible events, as in gt feed)
+The rigs pane reloads from beads whenever a feed event arrives, and at
+least every 30 seconds; press r to reload now.
+
 Example:
   gt dashboard              # Start on default port 8080
   gt dashboard --port 3000  # Start on port 3000
-  gt dashboard --open       # Start and open browser`,
+  gt dashboard --open       # Start and open browser
+  gt dashboard --tui        # Terminal dashboard`,
 	RunE: runDashboard,
 }
 
 func init() {
 	dashboardCmd.Flags().IntVar(&dashboardPort, "port", 8080, "HTTP port to listen on")
 	dashboardCmd.Flags().BoolVar(&dashboardOpen, "open", false, "Open browser automatically")
+	dashboardCmd.Flags().BoolVar(&dashboardTUI, "tui", false, "Show a live terminal dashboard instead of serving the web one")
 	rootCmd.AddCommand(dashboardCmd)
 }
 
 func runDashboard(cmd *cobra.Command, args []string) error {
 	// Verify we're in a workspace
-	if _, err := workspace.FindFromCwdOrError(); err != nil {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
+	if dashboardTUI {
+		return runDashboardTUI(townRoot)
+	}
+
 	// Create the live convoy fetcher
 	fetcher, err := web.NewLiveConvoyFetcher()
 	if err != nil {
@@ -83,6 +102,19 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 	return server.ListenAndServe()
 }
 
+// runDashboardTUI runs the terminal dashboard until the user quits.
+func runDashboardTUI(townRoot string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := dashboard.New(townRoot, events.TailTown(ctx, townRoot))
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("running TUI: %w", err)
+	}
+	return nil
+}
+
 // openBrowser opens the specified URL in the default browser.
 func openBrowser(url string) {
 	var cmd *exec.Cmd
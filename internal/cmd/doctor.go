@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -14,6 +15,7 @@ var (
 	doctorVerbose         bool
 	doctorRig             string
 	doctorRestartSessions bool
+	doctorJSON            bool
 )
 
 var doctorCmd = &cobra.Command{
@@ -32,6 +34,11 @@ Workspace checks:
   - rigs-registry-valid      Check registered rigs exist (fixable)
   - mayor-exists             Check mayor/ directory structure
 
+Environment checks:
+  - bd-installed             Check bd is on PATH and new enough
+  - tmux-available           Check tmux is installed
+  - events-writable          Check the events log can be appended to
+
 Infrastructure checks:
   - daemon                   Check if daemon is running (fixable)
   - repo-fingerprint         Check database has valid repo fingerprint (fixable)
@@ -49,6 +56,13 @@ Clone divergence checks:
 Crew workspace checks:
   - crew-state               Validate crew worker state.json files (fixable)
   - crew-worktrees           Detect stale cross-rig worktrees (fixable)
+  - git-worktrees            Detect stale worktree registrations (fixable) and broken polecat worktrees
+
+Work state checks:
+  - hook-attachment-valid    Verify attached molecules exist and are open (fixable)
+  - hook-singleton           Ensure each agent has at most one handoff bead (fixable)
+  - orphaned-attachments     Detect handoff beads for agents that no longer exist
+  - orphaned-in-progress     Detect in_progress beads with no live assignee (fixable)
 
 Rig checks (with --rig flag):
   - rig-is-git-repo          Verify rig is a valid git repository
@@ -75,7 +89,10 @@ Patrol checks:
   - patrol-roles-have-prompts Verify role prompts exist
 
 Use --fix to attempt automatic fixes for issues that support it.
-Use --rig to check a specific rig instead of the entire workspace.`,
+Use --rig to check a specific rig instead of the entire workspace.
+Use --json for a machine-readable report: each check's name, status
+(ok, warning, error), message, details, and fix_hint, plus a summary.
+The exit status is non-zero if any check reports an error.`,
 	RunE: runDoctor,
 }
 
@@ -84,6 +101,7 @@ func init() {
 	doctorCmd.Flags().BoolVarP(&doctorVerbose, "verbose", "v", false, "Show detailed output")
 	doctorCmd.Flags().StringVar(&doctorRig, "rig", "", "Check specific rig only")
 	doctorCmd.Flags().BoolVar(&doctorRestartSessions, "restart-sessions", false, "Restart patrol sessions when fixing stale settings (use with --fix)")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output the report as JSON")
 	rootCmd.AddCommand(doctorCmd)
 }
 
@@ -108,6 +126,11 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// Register workspace-level checks first (fundamental)
 	d.RegisterAll(doctor.WorkspaceChecks()...)
 
+	// Environment checks: tools and files everything else depends on
+	d.Register(doctor.NewBdInstalledCheck())
+	d.Register(doctor.NewTmuxAvailableCheck())
+	d.Register(doctor.NewEventsWritableCheck())
+
 	// Register built-in checks
 	d.Register(doctor.NewTownGitCheck())
 	d.Register(doctor.NewDaemonCheck())
@@ -148,6 +171,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// Crew workspace checks
 	d.Register(doctor.NewCrewStateCheck())
 	d.Register(doctor.NewCrewWorktreeCheck())
+	d.Register(doctor.NewWorktreeHealthCheck())
 	d.Register(doctor.NewCommandsCheck())
 
 	// Lifecycle hygiene checks
@@ -157,6 +181,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewHookAttachmentValidCheck())
 	d.Register(doctor.NewHookSingletonCheck())
 	d.Register(doctor.NewOrphanedAttachmentsCheck())
+	d.Register(doctor.NewOrphanedWorkCheck())
 
 	// Rig-specific checks (only when --rig is specified)
 	if doctorRig != "" {
@@ -172,7 +197,15 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	}
 
 	// Print report
-	report.Print(os.Stdout, doctorVerbose)
+	if doctorJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		report.Print(os.Stdout, doctorVerbose)
+	}
 
 	// Exit with error code if there are errors
 	if report.HasErrors() {
@@ -9,11 +9,13 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/ghpr"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/transcript"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -35,6 +37,10 @@ Exit statuses:
   DEFERRED       - Work paused, issue still open
   PHASE_COMPLETE - Phase done, awaiting gate (use --phase-complete)
 
+Rigs with merge_queue.github_prs set merge through GitHub instead of the
+Refinery: the branch is pushed, a pull request is opened (or updated) and
+its URL recorded on the MR, and the MR closes when the pull request merges.
+
 Phase handoff workflow:
   When a molecule has gate steps (async waits), use --phase-complete to signal
   that the current phase is complete but work continues after the gate closes.
@@ -200,7 +206,7 @@ func runDone(cmd *cobra.Command, args []string) error {
 		}
 
 		// Check if MR bead already exists for this branch (idempotency)
-		existingMR, err := bd.FindMRForBranch(branch)
+		existingMR, err := bd.MRForBranch(branch)
 		if err != nil {
 			style.PrintWarning("could not check for existing MR: %v", err)
 			// Continue with creation attempt - Create will fail if duplicate
@@ -212,29 +218,16 @@ func runDone(cmd *cobra.Command, args []string) error {
 			fmt.Printf("%s MR already exists (idempotent)\n", style.Bold.Render("✓"))
 			fmt.Printf("  MR ID: %s\n", style.Bold.Render(mrID))
 		} else {
-			// Build MR bead title and description
-			title := fmt.Sprintf("Merge: %s", issueID)
-			description := fmt.Sprintf("branch: %s\ntarget: %s\nsource_issue: %s\nrig: %s",
-				branch, target, issueID, rigName)
-			if worker != "" {
-				description += fmt.Sprintf("\nworker: %s", worker)
-			}
-			if agentBeadID != "" {
-				description += fmt.Sprintf("\nagent_bead: %s", agentBeadID)
-			}
-
-			// Add conflict resolution tracking fields (initialized, updated by Refinery)
-			description += "\nretry_count: 0"
-			description += "\nlast_conflict_sha: null"
-			description += "\nconflict_task_id: null"
-
-			// Create MR bead (ephemeral wisp - will be cleaned up after merge)
-			mrIssue, err := bd.Create(beads.CreateOptions{
-				Title:       title,
-				Type:        "merge-request",
-				Priority:    priority,
-				Description: description,
-			})
+			// Create MR bead (ephemeral wisp - will be cleaned up after merge).
+			// Conflict tracking fields start empty and are filled in by the Refinery.
+			mrIssue, err := bd.CreateMR(beads.MRFields{
+				Branch:      branch,
+				Target:      target,
+				SourceIssue: issueID,
+				Rig:         rigName,
+				Worker:      worker,
+				AgentBead:   agentBeadID,
+			}, beads.MRCreateOptions{Priority: priority})
 			if err != nil {
 				return fmt.Errorf("creating merge request bead: %w", err)
 			}
@@ -259,7 +252,11 @@ func runDone(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Printf("  Priority: P%d\n", priority)
 		fmt.Println()
-		fmt.Printf("%s\n", style.Dim.Render("The Refinery will process your merge request."))
+		if ghpr.Enabled(filepath.Join(townRoot, rigName)) {
+			publishPR(cwd, g, bd, branch, mrID)
+		} else {
+			fmt.Printf("%s\n", style.Dim.Render("The Refinery will process your merge request."))
+		}
 	} else if exitType == ExitPhaseComplete {
 		// Phase complete - register as waiter on gate, then recycle
 		fmt.Printf("%s Phase complete, awaiting gate\n", style.Bold.Render("→"))
@@ -339,7 +336,11 @@ func runDone(cmd *cobra.Command, args []string) error {
 
 	// Log done event (townlog and activity feed)
 	_ = LogDone(townRoot, sender, issueID)
-	_ = events.LogFeed(events.TypeDone, sender, events.DonePayload(issueID, branch))
+	donePayload := events.DonePayload(issueID, branch)
+	if path := recordTranscript(cwd, townRoot, sender, issueID); path != "" {
+		donePayload["transcript"] = path
+	}
+	_ = events.LogIdempotent(events.Key(events.TypeDone, issueID), events.TypeDone, sender, donePayload, events.VisibilityDefault)
 
 	// Update agent bead state (ZFC: self-report completion)
 	updateAgentStateOnDone(cwd, townRoot, exitType, issueID)
@@ -410,6 +411,15 @@ func updateAgentStateOnDone(cwd, townRoot, exitType, _ string) { // issueID unus
 		fmt.Fprintf(os.Stderr, "Warning: couldn't clear agent %s hook: %v\n", agentBeadID, err)
 	}
 
+	// Convert a soft reservation into a claim now that current work is done
+	if exitType == ExitCompleted {
+		if r, err := bd.ClaimReservation(buildAgentIdentity(ctx)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: couldn't claim reserved work: %v\n", err)
+		} else if r != nil {
+			fmt.Printf("%s Claimed reserved work: %s\n", style.Bold.Render("→"), r.IssueID)
+		}
+	}
+
 	// Only set non-observable states - "stuck" and "awaiting-gate" are intentional
 	// agent decisions that can't be discovered from tmux. Skip "done" and "idle"
 	// since those are observable (no session = done, session + no hook = idle).
@@ -460,6 +470,39 @@ func getDispatcherFromBead(cwd, issueID string) string {
 	return fields.DispatchedBy
 }
 
+// recordTranscript links the current session's transcript to the finished
+// bead, in the transcript index and the bead's transcript field, and
+// returns its path ("" if the session isn't being recorded).
+func recordTranscript(cwd, townRoot, actor, issueID string) string {
+	if issueID == "" {
+		return ""
+	}
+	sessionName := detectCurrentTmuxSession()
+	if sessionName == "" {
+		sessionName = deriveSessionName()
+	}
+	path := transcript.Current(townRoot, sessionName)
+	if path == "" {
+		return ""
+	}
+
+	if err := transcript.Link(townRoot, transcript.Entry{Bead: issueID, Actor: actor, Session: sessionName, Path: path}); err != nil {
+		style.PrintWarning("could not index transcript: %v", err)
+	}
+	bd := beads.New(beads.ResolveBeadsDir(cwd))
+	if issue, err := bd.Show(issueID); err == nil {
+		fields := beads.ParseAttachmentFields(issue)
+		if fields == nil {
+			fields = &beads.AttachmentFields{}
+		}
+		fields.Transcript = path
+		if err := bd.StoreAttachmentFields(issue, fields); err != nil {
+			style.PrintWarning("could not record transcript on %s: %v", issueID, err)
+		}
+	}
+	return path
+}
+
 // computeCleanupStatus checks git state and returns the cleanup status.
 // Returns the most critical issue: has_unpushed > has_stash > has_uncommitted > clean
 func computeCleanupStatus(cwd string) polecat.CleanupStatus {
@@ -482,3 +525,27 @@ func computeCleanupStatus(cwd string) polecat.CleanupStatus {
 	}
 	return polecat.CleanupClean
 }
+
+// publishPR pushes branch and opens or updates the GitHub pull request for
+// an MR, for rigs that merge through GitHub. Failures are warnings: the MR
+// bead stands either way, and running gt done again retries.
+func publishPR(cwd string, g *git.Git, bd *beads.Beads, branch, mrID string) {
+	repo, err := ghpr.Repo(cwd)
+	if err != nil {
+		style.PrintWarning("could not open pull request: %v", err)
+		return
+	}
+	if err := g.Push("origin", branch, false); err != nil {
+		style.PrintWarning("could not push %s for pull request: %v", branch, err)
+		return
+	}
+	pr, err := ghpr.Publish(ghpr.GHClient{}, bd, repo, mrID)
+	if err != nil {
+		style.PrintWarning("could not open pull request: %v", err)
+		if pr == nil {
+			return
+		}
+	}
+	fmt.Printf("%s Pull request: %s\n", style.Bold.Render("✓"), pr.URL)
+	fmt.Printf("%s\n", style.Dim.Render("The MR closes when the pull request merges on GitHub."))
+}
@@ -30,6 +30,18 @@ This gracefully shuts down all infrastructure agents:
 Polecats are NOT stopped by this command - use 'gt swarm stop' or
 kill individual polecats with 'gt polecat kill'.
 
+With --drain, the halt is graceful for in-flight work too:
+
+  1. No new work is assigned (gt sling refuses; the daemon stops
+     dispatching and auto-starting agents)
+  2. Waits up to --drain-timeout for in_progress and hooked beads to be
+     done (gt done) or handed off
+  3. Snapshots every handoff bead (see gt handoff history)
+  4. Kills all rig agent sessions, polecats and crew included
+  5. Releases beads still in flight back to open, with reason "halted"
+
+'gt up' clears the draining state if a drain is interrupted.
+
 This is useful for:
   • Taking a break (stop token consumption)
   • Clean shutdown before system maintenance
@@ -41,12 +53,17 @@ var (
 	downQuiet bool
 	downForce bool
 	downAll   bool
+
+	downDrain        bool
+	downDrainTimeout time.Duration
 )
 
 func init() {
 	downCmd.Flags().BoolVarP(&downQuiet, "quiet", "q", false, "Only show errors")
 	downCmd.Flags().BoolVarP(&downForce, "force", "f", false, "Force kill without graceful shutdown")
 	downCmd.Flags().BoolVarP(&downAll, "all", "a", false, "Also kill the tmux server")
+	downCmd.Flags().BoolVar(&downDrain, "drain", false, "Let in-flight work finish first, then stop all agents and release what's left")
+	downCmd.Flags().DurationVar(&downDrainTimeout, "drain-timeout", 10*time.Minute, "How long --drain waits for in-flight work")
 	rootCmd.AddCommand(downCmd)
 }
 
@@ -58,11 +75,22 @@ func runDown(cmd *cobra.Command, args []string) error {
 
 	t := tmux.NewTmux()
 	allOK := true
+	rigs := discoverRigs(townRoot)
+
+	// 0. Drain in-flight work, then stop every rig agent
+	var drainingRigs, released []string
+	if downDrain {
+		drainingRigs, released, err = drainTown(t, townRoot, rigs, downDrainTimeout)
+		if err != nil {
+			return err
+		}
+		// Rigs stay draining until the daemon is down, so it restarts nothing
+		defer clearTownDraining(townRoot, drainingRigs)
+	}
 
 	// Stop in reverse order of startup
 
 	// 1. Stop witnesses first
-	rigs := discoverRigs(townRoot)
 	for _, rigName := range rigs {
 		sessionName := fmt.Sprintf("gt-%s-witness", rigName)
 		if err := stopSession(t, sessionName); err != nil {
@@ -120,7 +148,11 @@ func runDown(cmd *cobra.Command, args []string) error {
 		if downAll {
 			stoppedServices = append(stoppedServices, "tmux-server")
 		}
-		_ = events.LogFeed(events.TypeHalt, "gt", events.HaltPayload(stoppedServices))
+		payload := events.HaltPayload(stoppedServices)
+		if downDrain {
+			payload = events.DrainedHaltPayload(stoppedServices, released)
+		}
+		_ = events.Log(events.TypeHalt, "gt", payload, events.VisibilityDefault)
 	} else {
 		fmt.Printf("%s Some services failed to stop\n", style.Bold.Render("✗"))
 		return fmt.Errorf("not all services stopped")
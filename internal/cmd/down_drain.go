@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+// RigStatusDraining is the rig status while gt down --drain waits for
+// in-flight work. Like a parked rig, the daemon won't auto-start its
+// agents or dispatch to it, and gt sling refuses new work.
+const RigStatusDraining = "draining"
+
+// haltReleaseReason is recorded on beads a drained halt releases.
+const haltReleaseReason = "halted"
+
+// drainPollInterval is how often a drain rechecks in-flight work.
+const drainPollInterval = 5 * time.Second
+
+// drainItem is a bead in flight when a drain starts.
+type drainItem struct {
+	bd       beads.Client
+	id       string
+	assignee string
+}
+
+// inFlight reports whether a bead status means an agent is working it.
+func inFlight(status string) bool {
+	return status == "in_progress" || status == beads.StatusHooked
+}
+
+// setTownDraining marks every rig draining, except parked or docked rigs,
+// which already take no work. It returns the rigs marked.
+func setTownDraining(townRoot string, rigs []string) ([]string, error) {
+	var marked []string
+	for _, rigName := range rigs {
+		cfg := wisp.NewConfig(townRoot, rigName)
+		if cfg.GetString(RigStatusKey) != "" {
+			continue
+		}
+		if err := cfg.Set(RigStatusKey, RigStatusDraining); err != nil {
+			clearTownDraining(townRoot, marked)
+			return nil, fmt.Errorf("marking %s draining: %w", rigName, err)
+		}
+		marked = append(marked, rigName)
+	}
+	return marked, nil
+}
+
+// clearTownDraining removes the draining status from rigs.
+func clearTownDraining(townRoot string, rigs []string) {
+	for _, rigName := range rigs {
+		cfg := wisp.NewConfig(townRoot, rigName)
+		if cfg.GetString(RigStatusKey) == RigStatusDraining {
+			_ = cfg.Unset(RigStatusKey)
+		}
+	}
+}
+
+// checkNotDraining refuses new work while a drained halt is in progress.
+func checkNotDraining(townRoot string) error {
+	for _, rigName := range discoverRigs(townRoot) {
+		if wisp.NewConfig(townRoot, rigName).GetString(RigStatusKey) == RigStatusDraining {
+			return fmt.Errorf("town is draining for halt (gt down --drain); not assigning new work\n" +
+				"If no halt is running, 'gt up' clears the draining state")
+		}
+	}
+	return nil
+}
+
+// collectDrainWork lists the assigned in_progress and hooked beads of the
+// town and each rig.
+func collectDrainWork(townRoot string, rigs []string) []drainItem {
+	workDirs := []string{townRoot}
+	for _, rigName := range rigs {
+		workDirs = append(workDirs, filepath.Join(townRoot, rigName, "mayor", "rig"))
+	}
+
+	seen := make(map[string]bool)
+	var items []drainItem
+	for _, workDir := range workDirs {
+		bd := beads.New(workDir)
+		for _, status := range []string{"in_progress", beads.StatusHooked} {
+			issues, err := bd.List(beads.ListOptions{Status: status, Priority: -1, Limit: beads.NoLimit})
+			if err != nil {
+				continue
+			}
+			for _, issue := range issues {
+				if issue.Assignee == "" || seen[issue.ID] {
+					continue
+				}
+				seen[issue.ID] = true
+				items = append(items, drainItem{bd: bd, id: issue.ID, assignee: issue.Assignee})
+			}
+		}
+	}
+	return items
+}
+
+// waitForDrain polls items until each is settled, no longer in flight or
+// its assignee among those settledAgents reports done or handed off, or
+// until timeout passes. It returns the items still unsettled; progress is
+// told how many remain whenever that changes.
+func waitForDrain(items []drainItem, settledAgents func() map[string]bool, timeout, interval time.Duration, progress func(pending int)) []drainItem {
+	deadline := time.Now().Add(timeout)
+	pending := items
+	last := -1
+	for {
+		settled := settledAgents()
+		var still []drainItem
+		for _, item := range pending {
+			if settled[strings.TrimSuffix(item.assignee, "/")] {
+				continue
+			}
+			if issue, err := item.bd.Show(item.id); err == nil && !inFlight(issue.Status) {
+				continue
+			}
+			still = append(still, item)
+		}
+		pending = still
+		if len(pending) != last {
+			progress(len(pending))
+			last = len(pending)
+		}
+		if len(pending) == 0 || !time.Now().Before(deadline) {
+			return pending
+		}
+		wait := time.Until(deadline)
+		if wait > interval {
+			wait = interval
+		}
+		time.Sleep(wait)
+	}
+}
+
+// settledSince returns the agents that have logged gt done or a handoff
+// since start, read from the town's event logs, rig logs included.
+func settledSince(townRoot string, start time.Time) func() map[string]bool {
+	return func() map[string]bool {
+		settled := make(map[string]bool)
+		evts, err := events.ReadTownSince(townRoot, start.Truncate(time.Second),
+			events.Filter{Types: []string{events.TypeDone, events.TypeHandoff}})
+		if err != nil {
+			return settled
+		}
+		for _, e := range evts {
+			settled[strings.TrimSuffix(e.Actor, "/")] = true
+		}
+		return settled
+	}
+}
+
+// drainTown stops new work, waits up to timeout for in-flight beads to be
+// done or handed off, snapshots every handoff, then kills the rigs' agent
+// sessions and releases work still in flight. It returns the rigs marked
+// draining, to clear once the halt completes, and the beads released.
+func drainTown(t *tmux.Tmux, townRoot string, rigs []string, timeout time.Duration) ([]string, []string, error) {
+	start := time.Now()
+	marked, err := setTownDraining(townRoot, rigs)
+	if err != nil {
+		return nil, nil, err
+	}
+	printDownStatus("Drain", true, "no new work will be assigned")
+
+	items := collectDrainWork(townRoot, rigs)
+	if len(items) > 0 && !downQuiet {
+		fmt.Printf("Waiting up to %s for %d bead(s) in progress...\n", timeout, len(items))
+	}
+	pending := waitForDrain(items, settledSince(townRoot, start), timeout, drainPollInterval, func(n int) {
+		if !downQuiet && len(items) > 0 {
+			fmt.Printf("  %d of %d still in progress\n", n, len(items))
+		}
+	})
+	for _, item := range pending {
+		style.PrintWarning("%s still in progress for %s after %s", item.id, item.assignee, timeout)
+	}
+
+	if roles, err := beads.New(townRoot).SnapshotHandoffs(); err != nil {
+		printDownStatus("Handoffs", false, err.Error())
+	} else {
+		printDownStatus("Handoffs", true, fmt.Sprintf("snapshotted %d", len(roles)))
+	}
+
+	// Kill every rig agent (polecats, refinery, crew) so none picks the
+	// released work back up; the witnesses go too, before the town sessions.
+	if sessions, err := t.ListSessions(); err == nil {
+		for _, rigName := range rigs {
+			prefix := fmt.Sprintf("gt-%s-", rigName)
+			for _, sess := range sessions {
+				if strings.HasPrefix(sess, prefix) {
+					if err := stopSession(t, sess); err != nil {
+						printDownStatus(sess, false, err.Error())
+					}
+				}
+			}
+		}
+	}
+
+	var released []string
+	for _, item := range items {
+		issue, err := item.bd.Show(item.id)
+		if err != nil || !inFlight(issue.Status) {
+			continue
+		}
+		if err := item.bd.ReleaseWithReason(item.id, haltReleaseReason); err != nil {
+			printDownStatus(fmt.Sprintf("Release %s", item.id), false, err.Error())
+			continue
+		}
+		released = append(released, item.id)
+	}
+	if len(items) > 0 {
+		printDownStatus("Drain", true, fmt.Sprintf("%d done or handed off, %d released", len(items)-len(released), len(released)))
+	}
+	return marked, released, nil
+}
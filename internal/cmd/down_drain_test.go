@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+func TestWaitForDrain(t *testing.T) {
+	fake := beadstest.NewFakeClient()
+	fake.Add(&beads.Issue{ID: "gt-done", Status: "in_progress", Assignee: "gastown/polecats/nux"})
+	fake.Add(&beads.Issue{ID: "gt-handoff", Status: beads.StatusHooked, Assignee: "gastown/crew/joe"})
+	fake.Add(&beads.Issue{ID: "gt-stuck", Status: "in_progress", Assignee: "gastown/polecats/slit"})
+	items := []drainItem{
+		{bd: fake, id: "gt-done", assignee: "gastown/polecats/nux"},
+		{bd: fake, id: "gt-handoff", assignee: "gastown/crew/joe"},
+		{bd: fake, id: "gt-stuck", assignee: "gastown/polecats/slit"},
+	}
+
+	polls := 0
+	settled := func() map[string]bool {
+		polls++
+		if polls == 2 {
+			// nux finishes its bead, joe hands off
+			_ = fake.Close("gt-done")
+			return map[string]bool{"gastown/crew/joe": true}
+		}
+		return nil
+	}
+	var reported []int
+	pending := waitForDrain(items, settled, 50*time.Millisecond, time.Millisecond, func(n int) {
+		reported = append(reported, n)
+	})
+
+	if len(pending) != 1 || pending[0].id != "gt-stuck" {
+		t.Errorf("pending = %+v, want only gt-stuck", pending)
+	}
+	if len(reported) != 2 || reported[0] != 3 || reported[1] != 1 {
+		t.Errorf("progress = %v, want [3 1]", reported)
+	}
+
+	// Nothing in flight returns at once
+	if pending := waitForDrain(nil, settled, time.Hour, time.Hour, func(int) {}); len(pending) != 0 {
+		t.Errorf("pending = %+v, want none", pending)
+	}
+}
+
+func TestSettledSince(t *testing.T) {
+	townRoot := t.TempDir()
+	start := time.Now()
+	writeLog := func(path string, evts ...events.Event) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		var data []byte
+		for _, e := range evts {
+			line, err := json.Marshal(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = append(append(data, line...), '\n')
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before := start.Add(-time.Hour).Format(time.RFC3339)
+	after := start.Format(time.RFC3339)
+	writeLog(events.Path(townRoot),
+		events.Event{Timestamp: before, Type: events.TypeDone, Actor: "gastown/polecats/slit"},
+		events.Event{Timestamp: after, Type: events.TypeDone, Actor: "gastown/polecats/nux/"},
+		events.Event{Timestamp: after, Type: events.TypeSling, Actor: "gastown/polecats/ace"},
+	)
+	// The rig logs its own events when event_log.per_rig is set
+	writeLog(events.RigPath(townRoot, "gastown"),
+		events.Event{Timestamp: after, Type: events.TypeHandoff, Actor: "gastown/crew/joe"},
+	)
+
+	settled := settledSince(townRoot, start)()
+	want := []string{"gastown/crew/joe", "gastown/polecats/nux"}
+	if len(settled) != len(want) {
+		t.Errorf("settled = %v, want %v", settled, want)
+	}
+	for _, agent := range want {
+		if !settled[agent] {
+			t.Errorf("%s not settled: %v", agent, settled)
+		}
+	}
+}
+
+func TestTownDrainingState(t *testing.T) {
+	townRoot := t.TempDir()
+	for _, rigName := range []string{"gastown", "beads"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, rigName, "polecats"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := wisp.NewConfig(townRoot, "beads").Set(RigStatusKey, RigStatusParked); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := checkNotDraining(townRoot); err != nil {
+		t.Fatalf("checkNotDraining before drain: %v", err)
+	}
+	marked, err := setTownDraining(townRoot, discoverRigs(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(marked, ",") != "gastown" {
+		t.Errorf("marked = %v, want [gastown] (beads is parked)", marked)
+	}
+	if err := checkNotDraining(townRoot); err == nil || !strings.Contains(err.Error(), "draining") {
+		t.Errorf("checkNotDraining during drain = %v, want draining error", err)
+	}
+
+	clearTownDraining(townRoot, discoverRigs(townRoot))
+	if err := checkNotDraining(townRoot); err != nil {
+		t.Errorf("checkNotDraining after clear: %v", err)
+	}
+	if !IsRigParked(townRoot, "beads") {
+		t.Error("clearing the drain unparked beads")
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var epicProgressJSON bool
+
+var epicCmd = &cobra.Command{
+	Use:     "epic",
+	GroupID: GroupWork,
+	Short:   "Epic reporting",
+	RunE:    requireSubcommand,
+}
+
+var epicProgressCmd = &cobra.Command{
+	Use:   "progress <epic-id>...",
+	Short: "Show rollup progress of one or more epics",
+	Long: `Show child counts by status, percent complete, blocked children, and
+estimated remaining work for each epic.
+
+Remaining work sums the estimates on open children; children without an
+estimate are counted separately.
+
+Examples:
+  gt epic progress gt-epic1
+  gt epic progress gt-epic1 gt-epic2 --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runEpicProgress,
+}
+
+func init() {
+	epicProgressCmd.Flags().BoolVar(&epicProgressJSON, "json", false, "Output as JSON")
+	epicCmd.AddCommand(epicProgressCmd)
+	rootCmd.AddCommand(epicCmd)
+}
+
+func runEpicProgress(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+	bd := beads.New(cwd)
+
+	var all []*beads.EpicProgress
+	for _, id := range args {
+		p, err := bd.EpicProgress(id)
+		if err != nil {
+			return err
+		}
+		all = append(all, p)
+	}
+
+	if epicProgressJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(all)
+	}
+
+	for _, p := range all {
+		fmt.Printf("%s %s  %d/%d done (%d%%)\n", style.Bold.Render("●"), p.EpicID, p.Done, p.Total, p.Percent)
+
+		statuses := make([]string, 0, len(p.ByStatus))
+		for s := range p.ByStatus {
+			statuses = append(statuses, s)
+		}
+		sort.Strings(statuses)
+		var parts []string
+		for _, s := range statuses {
+			parts = append(parts, fmt.Sprintf("%s %d", s, p.ByStatus[s]))
+		}
+		if len(parts) > 0 {
+			fmt.Printf("  %s\n", style.Dim.Render(strings.Join(parts, " · ")))
+		}
+
+		if p.Remaining > 0 {
+			est := (time.Duration(p.RemainingMinutes) * time.Minute).String()
+			if p.Unestimated > 0 {
+				est += fmt.Sprintf(" + %d unestimated", p.Unestimated)
+			}
+			fmt.Printf("  Remaining: %d (%s)\n", p.Remaining, est)
+		}
+		if len(p.Blocked) > 0 {
+			fmt.Printf("  Blocked: %s\n", strings.Join(p.Blocked, ", "))
+		}
+	}
+	return nil
+}
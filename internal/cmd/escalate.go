@@ -160,7 +160,7 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 	if beadID != "" {
 		payload["bead"] = beadID
 	}
-	_ = events.LogFeed(events.TypeEscalationSent, agentID, payload)
+	_ = events.Log(events.TypeEscalationSent, agentID, payload, events.VisibilityDefault)
 
 	// Print confirmation with severity-appropriate styling
 	var emoji string
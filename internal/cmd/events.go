@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Events query command flags
+var (
+	eventsQuerySince      string
+	eventsQueryFrom       string
+	eventsQueryUntil      string
+	eventsQueryTypes      []string
+	eventsQueryActor      string
+	eventsQueryVisibility []string
+	eventsQueryPayload    []string
+	eventsQuerySeverity   string
+	eventsQueryTrace      string
+	eventsQueryJSON       bool
+	eventsQueryCount      bool
+	eventsQueryFollow     bool
+)
+
+var eventsCmd = &cobra.Command{
+	Use:     "events",
+	GroupID: GroupDiag,
+	Short:   "Query the raw events log",
+	Long: `Query the raw events log of the town and its rigs.
+
+Subcommands:
+  query  Filter events by time, type, actor, visibility, and payload`,
+}
+
+var eventsQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Filter raw events by time, type, actor, visibility, and payload",
+	Long: `Filter the raw events log, rotated segments and rig logs included.
+
+Filters combine with AND; repeated --type and --visibility values combine
+with OR. --actor takes an exact actor, a prefix ending in "/", or a glob
+("*/polecats/Toast"). --payload matches a top-level payload key against a
+value compared as text (numbers without trailing zeros, lists joined with
+commas).
+
+Times for --from and --until are RFC 3339 or YYYY-MM-DD (local midnight).
+--until is exclusive.
+
+Output is one line per event; --json prints one JSON object per line,
+--count prints only the number of matches, and --follow keeps printing
+new matches until interrupted.
+
+Examples:
+  gt events query --type handoff --actor '*/polecats/Toast' --since 7d --count
+  gt events query --type sling --payload target=gastown/polecats/nux
+  gt events query --from 2026-01-01 --until 2026-01-08 --json
+  gt events query --severity warn --follow`,
+	Args: cobra.NoArgs,
+	RunE: runEventsQuery,
+}
+
+func init() {
+	eventsQueryCmd.Flags().StringVar(&eventsQuerySince, "since", "", "Only events within this duration (e.g., 24h, 7d)")
+	eventsQueryCmd.Flags().StringVar(&eventsQueryFrom, "from", "", "Only events at or after this time")
+	eventsQueryCmd.Flags().StringVar(&eventsQueryUntil, "until", "", "Only events before this time")
+	eventsQueryCmd.Flags().StringSliceVar(&eventsQueryTypes, "type", nil, "Only these event types (repeatable)")
+	eventsQueryCmd.Flags().StringVar(&eventsQueryActor, "actor", "", "Only this actor, actor prefix ending in /, or glob")
+	eventsQueryCmd.Flags().StringSliceVar(&eventsQueryVisibility, "visibility", nil, "Only these visibilities: audit, feed, both (repeatable)")
+	eventsQueryCmd.Flags().StringArrayVar(&eventsQueryPayload, "payload", nil, "Only events whose payload has key=value (repeatable)")
+	eventsQueryCmd.Flags().StringVar(&eventsQuerySeverity, "severity", "", "Only events at or above this severity: debug, info, warn, error")
+	eventsQueryCmd.Flags().StringVar(&eventsQueryTrace, "trace", "", "Only events in this trace")
+	eventsQueryCmd.Flags().BoolVar(&eventsQueryJSON, "json", false, "Output one JSON object per line")
+	eventsQueryCmd.Flags().BoolVar(&eventsQueryCount, "count", false, "Print only the number of matching events")
+	eventsQueryCmd.Flags().BoolVarP(&eventsQueryFollow, "follow", "f", false, "Keep printing new matching events")
+
+	eventsCmd.AddCommand(eventsQueryCmd)
+	rootCmd.AddCommand(eventsCmd)
+}
+
+func runEventsQuery(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	q, err := buildEventsQuery(time.Now())
+	if err != nil {
+		return err
+	}
+	if eventsQueryFollow && eventsQueryCount {
+		return fmt.Errorf("--count cannot be used with --follow")
+	}
+	if eventsQueryFollow && !q.To.IsZero() {
+		return fmt.Errorf("--until cannot be used with --follow")
+	}
+
+	// Follow from before reading history so nothing in between is missed;
+	// events seen in both are printed once
+	var tail <-chan events.Event
+	if eventsQueryFollow {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		tail = events.TailTown(ctx, townRoot)
+	}
+
+	evts, err := q.Run(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+	if eventsQueryCount {
+		fmt.Println(len(evts))
+		return nil
+	}
+
+	printed := make(map[string]bool)
+	for _, e := range evts {
+		if err := printQueryEvent(e); err != nil {
+			return err
+		}
+		if e.ID != "" && eventsQueryFollow {
+			printed[e.ID] = true
+		}
+	}
+	if tail == nil {
+		if len(evts) == 0 && !eventsQueryJSON {
+			fmt.Fprintln(os.Stderr, style.Dim.Render("No matching events"))
+		}
+		return nil
+	}
+	for e := range tail {
+		if !q.Match(e) || (e.ID != "" && printed[e.ID]) {
+			continue
+		}
+		if err := printQueryEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildEventsQuery turns the query flags into an events.Query.
+func buildEventsQuery(now time.Time) (events.Query, error) {
+	return events.QueryArgs{
+		Since:      eventsQuerySince,
+		From:       eventsQueryFrom,
+		Until:      eventsQueryUntil,
+		Types:      eventsQueryTypes,
+		Actor:      eventsQueryActor,
+		Visibility: eventsQueryVisibility,
+		Payload:    eventsQueryPayload,
+		Severity:   eventsQuerySeverity,
+		Trace:      eventsQueryTrace,
+	}.Query(now)
+}
+
+func printQueryEvent(e events.Event) error {
+	if eventsQueryJSON {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	ts := e.Timestamp
+	if t, err := time.Parse(time.RFC3339, e.Timestamp); err == nil {
+		ts = t.Local().Format("2006-01-02 15:04:05")
+	}
+	fmt.Printf("%s  %-16s %-28s %s\n", style.Dim.Render(ts), e.Type, e.Actor, formatQueryPayload(e.Payload))
+	return nil
+}
+
+// formatQueryPayload renders a payload as sorted key=value pairs.
+func formatQueryPayload(payload map[string]interface{}) string {
+	keys := make([]string, 0, len(payload))
+	for k := range payload {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		v := payload[k]
+		if _, ok := v.(map[string]interface{}); ok {
+			data, _ := json.Marshal(v)
+			v = string(data)
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Explain command flags
+var (
+	explainSubject string
+	explainLimit   int
+	explainJSON    bool
+)
+
+var explainCmd = &cobra.Command{
+	Use:     "explain [decision-id]",
+	GroupID: GroupDiag,
+	Short:   "Show why an automated action was taken",
+	Long: `Show the recorded rationale for an automated decision.
+
+Automated actions (parking and waking polecats, releasing orphaned work)
+record a decision ID and a structured rationale in the events log: what
+was done, the conditions that triggered it, the inputs observed, and the
+rule that applied. Decision IDs appear in the activity feed and in
+'gt activity export'.
+
+With no ID, lists recent decisions, optionally only those about a bead,
+agent, or rig.
+
+Examples:
+  gt explain dec-20260102-a1b2c3
+  gt explain --subject gt-abc12
+  gt explain --subject Toast --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	explainCmd.Flags().StringVar(&explainSubject, "subject", "", "List decisions about a bead, agent, or rig")
+	explainCmd.Flags().IntVarP(&explainLimit, "limit", "n", 20, "Maximum decisions to list")
+	explainCmd.Flags().BoolVar(&explainJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(explainCmd)
+}
+
+// explainEntry is one decision as printed by gt explain.
+type explainEntry struct {
+	ID        string                 `json:"id"`
+	Timestamp string                 `json:"ts"`
+	Type      string                 `json:"type"`
+	Actor     string                 `json:"actor"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	Rationale *events.Rationale      `json:"rationale,omitempty"`
+}
+
+func newExplainEntry(e events.Event) explainEntry {
+	r, _ := e.Rationale()
+	payload := make(map[string]interface{})
+	for k, v := range e.Payload {
+		if k != events.PayloadDecisionID && k != events.PayloadRationale {
+			payload[k] = v
+		}
+	}
+	return explainEntry{ID: e.DecisionID(), Timestamp: e.Timestamp, Type: e.Type, Actor: e.Actor, Payload: payload, Rationale: r}
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	evts, err := events.ReadTown(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+
+	if len(args) == 1 {
+		e, ok := events.FindDecision(evts, args[0])
+		if !ok {
+			return fmt.Errorf("no decision %s in the events log", args[0])
+		}
+		entry := newExplainEntry(*e)
+		if explainJSON {
+			return printExplainJSON(entry)
+		}
+		printExplainEntry(entry)
+		return nil
+	}
+
+	decisions := events.Decisions(evts, explainSubject)
+	if explainLimit > 0 && len(decisions) > explainLimit {
+		decisions = decisions[:explainLimit]
+	}
+	entries := make([]explainEntry, 0, len(decisions))
+	for _, e := range decisions {
+		entries = append(entries, newExplainEntry(e))
+	}
+
+	if explainJSON {
+		return printExplainJSON(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Println(style.Dim.Render("No recorded decisions"))
+		return nil
+	}
+	for _, entry := range entries {
+		summary := entry.Type
+		if entry.Rationale != nil {
+			summary = entry.Rationale.Decision
+		}
+		fmt.Printf("%s  %s  %s\n", style.Bold.Render(entry.ID), style.Dim.Render(entry.Timestamp), summary)
+	}
+	return nil
+}
+
+func printExplainEntry(entry explainEntry) {
+	fmt.Printf("%s %s\n", style.Bold.Render("Decision"), entry.ID)
+	fmt.Printf("  When:  %s\n", entry.Timestamp)
+	fmt.Printf("  Who:   %s\n", entry.Actor)
+	fmt.Printf("  Event: %s\n", entry.Type)
+
+	r := entry.Rationale
+	if r == nil {
+		fmt.Println(style.Dim.Render("  (no rationale recorded)"))
+		return
+	}
+	fmt.Printf("  What:  %s\n", r.Decision)
+	if len(r.Reasons) > 0 {
+		fmt.Println("  Why:")
+		for _, reason := range r.Reasons {
+			fmt.Printf("    - %s\n", reason)
+		}
+	}
+	if r.Rule != "" {
+		fmt.Printf("  Rule:  %s\n", r.Rule)
+	}
+	if len(r.Inputs) > 0 {
+		fmt.Println("  Inputs:")
+		keys := make([]string, 0, len(r.Inputs))
+		for k := range r.Inputs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("    %s: %v\n", k, r.Inputs[k])
+		}
+	}
+	if len(r.Alternatives) > 0 {
+		fmt.Println("  Alternatives considered:")
+		for _, alt := range r.Alternatives {
+			fmt.Printf("    - %s\n", alt)
+		}
+	}
+}
+
+func printExplainJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
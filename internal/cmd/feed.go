@@ -58,6 +58,12 @@ The feed combines multiple event sources:
   - GT events: Agent activity like patrol, sling, handoff (from .events.jsonl)
   - Convoy status: In-progress and recently-landed convoys (refreshes every 10s)
 
+GT events are rendered with per-type Go templates. Override them in the
+town's settings/config.json, e.g.:
+  {"feed": {"templates": {"hook": "{{.Who}} hooked {{.P \"bead\"}}"}}}
+Templates see the event's fields (.Actor, .Type, .Payload) plus .Who (the
+actor's short name), .P "key" (a payload value), and .L "key" (a payload list).
+
 Use --plain for simple text output (wraps bd activity only).
 
 Tmux Integration:
@@ -81,6 +87,11 @@ MQ (Merge Queue) event symbols:
   ✗  merge_failed    - Merge failed (conflict, tests, etc.) (red)
   ⊘  merge_skipped   - MR skipped (already merged, etc.)
 
+Failure event symbols (shown in red):
+  ✗  sling_failed    - Work could not be slung
+  ⚔  merge_conflict  - Refinery hit a merge conflict
+  💥  agent_crash     - An agent's session died with work hooked
+
 Examples:
   gt feed                       # Launch TUI dashboard
   gt feed --plain               # Plain text output (bd activity)
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/feed"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var feedDaemonForce bool
+
+var feedDaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the feed curator in the foreground",
+	Long: `Curate the raw events log into the user-facing feed until interrupted.
+
+The gt daemon already runs the curator; use this when the daemon is not
+running, or to try out feed settings. Curation rules come from the "feed"
+section of settings/config.json:
+
+  "feed": {
+    "visibility": ["feed", "both"],
+    "types": ["sling", "done", "merged", "merge_failed"],
+    "rate_limit": "30/1m",
+    "coalesce_nudges": "5m"
+  }
+
+SIGINT or SIGTERM stops the curator after writing any coalesced nudges
+still pending.`,
+	Args: cobra.NoArgs,
+	RunE: runFeedDaemon,
+}
+
+func init() {
+	feedDaemonCmd.Flags().BoolVar(&feedDaemonForce, "force", false, "Run even if the gt daemon (and its curator) is running")
+	feedCmd.AddCommand(feedDaemonCmd)
+}
+
+func runFeedDaemon(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if running, pid, _ := daemon.IsRunning(townRoot); running && !feedDaemonForce {
+		return fmt.Errorf("gt daemon (PID %d) is already curating the feed; use --force to run another curator", pid)
+	}
+
+	rules, err := feed.LoadRules(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading feed rules: %w", err)
+	}
+	curator := feed.NewCurator(townRoot)
+	curator.SetRules(rules)
+	if err := curator.Start(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Curating %s → %s (Ctrl-C to stop)\n", events.Path(townRoot), feed.Path(townRoot))
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	<-sigChan
+
+	curator.Stop()
+	fmt.Fprintln(os.Stderr, "Feed curator stopped")
+	return nil
+}
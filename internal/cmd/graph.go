@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+var graphFormat string
+
+var graphCmd = &cobra.Command{
+	Use:     "graph [epic-id]",
+	GroupID: GroupWork,
+	Short:   "Draw the dependency graph as DOT or Mermaid",
+	Long: `Draw a dependency diagram colored by status.
+
+With an epic ID, the diagram covers the epic and everything filed under it.
+Without one, it covers the ready set and the work each ready issue unblocks.
+Arrows run from a blocker to the work it blocks.
+
+Examples:
+  gt graph gt-epic1 | dot -Tsvg > epic.svg
+  gt graph gt-epic1 --format=mermaid   # paste into Markdown docs
+  gt graph`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runGraph,
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Output format: dot or mermaid")
+	rootCmd.AddCommand(graphCmd)
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	if graphFormat != "dot" && graphFormat != "mermaid" {
+		return fmt.Errorf("unknown format %q (want dot or mermaid)", graphFormat)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+	bd := beads.New(cwd)
+
+	var g *beads.Graph
+	if len(args) == 1 {
+		g, err = bd.EpicGraph(args[0])
+	} else {
+		g, err = readySetGraph(bd)
+	}
+	if err != nil {
+		return err
+	}
+
+	if graphFormat == "mermaid" {
+		return g.RenderMermaid(os.Stdout)
+	}
+	return g.RenderDOT(os.Stdout)
+}
+
+// readySetGraph is the graph of ready issues and everything they unblock.
+func readySetGraph(bd *beads.Beads) (*beads.Graph, error) {
+	full, err := bd.Graph()
+	if err != nil {
+		return nil, err
+	}
+	ready, err := bd.Ready()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, issue := range ready {
+		ids = append(ids, issue.ID)
+		ids = append(ids, full.TransitiveDependents(issue.ID)...)
+	}
+	return full.Subgraph(ids), nil
+}
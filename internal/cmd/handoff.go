@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
@@ -39,6 +43,8 @@ Examples:
   gt handoff -c                       # Collect state into handoff message
   gt handoff crew                     # Hand off crew session
   gt handoff mayor                    # Hand off mayor session
+  gt handoff diff                     # What changed in the handoff bead
+  gt handoff history                  # Retained handoff bead versions
 
 The --collect (-c) flag gathers current state (hooked work, inbox, ready beads,
 in-progress items) and includes it in the handoff mail. This provides context
@@ -63,9 +69,187 @@ func init() {
 	handoffCmd.Flags().StringVarP(&handoffSubject, "subject", "s", "", "Subject for handoff mail (optional)")
 	handoffCmd.Flags().StringVarP(&handoffMessage, "message", "m", "", "Message body for handoff mail (optional)")
 	handoffCmd.Flags().BoolVarP(&handoffCollect, "collect", "c", false, "Auto-collect state (status, inbox, beads) into handoff message")
+	handoffHistoryCmd.Flags().IntVarP(&handoffHistoryLimit, "limit", "n", 10, "Show at most this many versions (0 for all)")
+	handoffHistoryCmd.Flags().BoolVar(&handoffHistoryFull, "full", false, "Show each version's full content")
+	handoffHistoryCmd.Flags().BoolVar(&handoffHistoryJSON, "json", false, "Output as JSON")
+	handoffCmd.AddCommand(handoffDiffCmd)
+	handoffCmd.AddCommand(handoffHistoryCmd)
+	handoffCmd.AddCommand(handoffRestoreCmd)
 	rootCmd.AddCommand(handoffCmd)
 }
 
+var handoffDiffCmd = &cobra.Command{
+	Use:   "diff [role]",
+	Short: "Show what changed in the handoff bead since the previous handoff",
+	Long: `Show a unified diff of a role's pinned handoff bead against its previous
+version, so an incoming session sees exactly what its predecessor added.
+
+Each version of the handoff content is retained under .beads/handoffs/:
+whenever the content is replaced or cleared, at every 'gt handoff', and
+when 'gt prime' starts a session. The diff runs from the latest retained
+version that differs from the current content.
+
+The role defaults to the current session's role.
+
+Examples:
+  gt handoff diff            # Your role's handoff changes
+  gt handoff diff witness    # The witness handoff changes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHandoffDiff,
+}
+
+func runHandoffDiff(cmd *cobra.Command, args []string) error {
+	townRoot, role, err := handoffTarget(args, 0)
+	if err != nil {
+		return err
+	}
+
+	diff, err := beads.New(townRoot).HandoffDiff(role)
+	if err != nil {
+		return fmt.Errorf("diffing %s handoff: %w", role, err)
+	}
+	if diff == "" {
+		fmt.Printf("No changes to the %s handoff since the previous version.\n", role)
+		return nil
+	}
+	fmt.Print(diff)
+	return nil
+}
+
+var (
+	handoffHistoryLimit int
+	handoffHistoryFull  bool
+	handoffHistoryJSON  bool
+)
+
+var handoffHistoryCmd = &cobra.Command{
+	Use:   "history [role]",
+	Short: "List retained versions of the handoff bead",
+	Long: `List the retained versions of a role's pinned handoff bead, newest first.
+
+Every version of the handoff content is kept (see gt handoff diff), so
+context lost to a bad handoff can be found here and brought back with
+gt handoff restore.
+
+The role defaults to the current session's role.
+
+Examples:
+  gt handoff history                 # Your role's last 10 versions
+  gt handoff history witness -n 0    # Every witness version
+  gt handoff history --full -n 2     # Content of the last two versions`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHandoffHistory,
+}
+
+var handoffRestoreCmd = &cobra.Command{
+	Use:   "restore <version> [role]",
+	Short: "Make a retained version the handoff content again",
+	Long: `Replace a role's handoff content with a retained version from
+gt handoff history. The content it replaces is retained as a new version,
+so a restore can itself be undone.
+
+The role defaults to the current session's role.
+
+Examples:
+  gt handoff restore 4
+  gt handoff restore 4 witness`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runHandoffRestore,
+}
+
+// handoffTarget returns the town root and the role a handoff subcommand
+// acts on: args[i] if given, else the current session's role.
+func handoffTarget(args []string, i int) (string, string, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return "", "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if len(args) > i {
+		return townRoot, args[i], nil
+	}
+	role := handoffRoleKey(townRoot)
+	if role == "" {
+		return "", "", fmt.Errorf("cannot determine role; pass one (e.g. witness)")
+	}
+	return townRoot, role, nil
+}
+
+func runHandoffHistory(cmd *cobra.Command, args []string) error {
+	townRoot, role, err := handoffTarget(args, 0)
+	if err != nil {
+		return err
+	}
+	history, err := beads.New(townRoot).HandoffHistory(role, handoffHistoryLimit)
+	if err != nil {
+		return err
+	}
+
+	if handoffHistoryJSON {
+		if history == nil {
+			history = []beads.HandoffVersion{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(history)
+	}
+	if len(history) == 0 {
+		fmt.Printf("No retained versions of the %s handoff.\n", role)
+		return nil
+	}
+	for _, v := range history {
+		when := v.Timestamp.Local().Format(time.DateTime)
+		if handoffHistoryFull {
+			fmt.Printf("%s  %s\n", style.Bold.Render(fmt.Sprintf("v%d", v.Version)), style.Dim.Render(when))
+			if v.Content == "" {
+				fmt.Println(style.Dim.Render("  (empty)"))
+			} else {
+				fmt.Println(v.Content)
+			}
+			fmt.Println()
+			continue
+		}
+		summary := style.Dim.Render("(empty)")
+		if v.Content != "" {
+			first, _, _ := strings.Cut(v.Content, "\n")
+			summary = fmt.Sprintf("%s %s", first, style.Dim.Render(fmt.Sprintf("(%d lines)", strings.Count(v.Content, "\n")+1)))
+		}
+		fmt.Printf("  %-5s %s  %s\n", fmt.Sprintf("v%d", v.Version), when, summary)
+	}
+	return nil
+}
+
+func runHandoffRestore(cmd *cobra.Command, args []string) error {
+	version, err := strconv.Atoi(strings.TrimPrefix(args[0], "v"))
+	if err != nil {
+		return fmt.Errorf("invalid version %q (see gt handoff history)", args[0])
+	}
+	townRoot, role, err := handoffTarget(args, 1)
+	if err != nil {
+		return err
+	}
+	restored, err := beads.New(townRoot).RestoreHandoffVersion(role, version)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Restored %s handoff v%d (from %s)\n", style.Bold.Render("✓"), role, restored.Version,
+		restored.Timestamp.Local().Format(time.DateTime))
+	return nil
+}
+
+// handoffRoleKey returns the role whose handoff bead this session uses
+// ("" if unknown), as gt prime looks it up.
+func handoffRoleKey(townRoot string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	info, err := GetRoleWithContext(cwd, townRoot)
+	if err != nil || info.Role == RoleUnknown {
+		return ""
+	}
+	return string(info.Role)
+}
+
 func runHandoff(cmd *cobra.Command, args []string) error {
 	// Check if we're a polecat - polecats use gt done instead
 	// GT_POLECAT is set by the session manager when starting polecat sessions
@@ -157,7 +341,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		}
 		_ = LogHandoff(townRoot, agent, handoffSubject)
 		// Also log to activity feed
-		_ = events.LogFeed(events.TypeHandoff, agent, events.HandoffPayload(handoffSubject, true))
+		_ = events.Log(events.TypeHandoff, agent, events.HandoffPayload(handoffSubject, true), events.VisibilityDefault)
 	}
 
 	// Dry run mode - show what would happen (BEFORE any side effects)
@@ -186,6 +370,13 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	// Agent liveness is observable from tmux - no need to record it in bead.
 	// "Discover, don't track" principle: reality is truth, state is derived.
 
+	// Retain the handoff bead as this session left it, for gt handoff diff
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		if role := handoffRoleKey(townRoot); role != "" {
+			_ = beads.New(townRoot).RecordHandoffVersion(role)
+		}
+	}
+
 	// Clear scrollback history before respawn (resets copy-mode from [0/N] to [0/0])
 	if err := t.ClearHistory(pane); err != nil {
 		// Non-fatal - continue with respawn even if clear fails
@@ -487,6 +678,13 @@ func handoffRemoteSession(t *tmux.Tmux, targetSession, restartCmd string) error
 		return nil
 	}
 
+	// Retain the handoff bead as this session left it, for gt handoff diff
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		if role := handoffRoleKey(townRoot); role != "" {
+			_ = beads.New(townRoot).RecordHandoffVersion(role)
+		}
+	}
+
 	// Clear scrollback history before respawn (resets copy-mode from [0/N] to [0/0])
 	if err := t.ClearHistory(targetPane); err != nil {
 		// Non-fatal - continue with respawn even if clear fails
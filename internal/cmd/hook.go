@@ -230,7 +230,7 @@ func runHook(_ *cobra.Command, args []string) error {
 	fmt.Printf("  Use 'gt hook' to see hook status\n")
 
 	// Log hook event to activity feed
-	_ = events.LogFeed(events.TypeHook, agentID, events.HookPayload(beadID))
+	_ = events.LogIdempotent(events.Key(events.TypeHook, beadID, agentID), events.TypeHook, agentID, events.HookPayload(beadID), events.VisibilityDefault)
 
 	return nil
 }
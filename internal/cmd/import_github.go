@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/ghimport"
+	"github.com/steveyegge/gastown/internal/setup"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Import-github command flags
+var (
+	importGitHubTown   string
+	importGitHubRig    string
+	importGitHubLimit  int
+	importGitHubDryRun bool
+	importGitHubJSON   bool
+)
+
+var importGitHubCmd = &cobra.Command{
+	Use:     "import-github <owner/repo>",
+	GroupID: GroupWorkspace,
+	Short:   "Onboard a GitHub-Issues project into Gas Town",
+	Long: `Onboard an existing GitHub project in one step.
+
+Creates the town (if needed) and a rig for the repository, then imports
+open GitHub milestones as epics and open issues as beads. Issues in a
+milestone become children of its epic. "bug" and "enhancement" labels set
+the bead type; labels like "P1" or "priority:high" set the priority, as
+do the town's GitHub labels under priorities.external.github.
+
+The GitHub-to-bead mapping is kept in <rig>/github.json, so running the
+command again only imports issues that are new since the last run.
+Use gt sync-github to keep beads and issues in step afterwards.
+
+Requires the gh CLI, authenticated for the repository.
+
+Examples:
+  gt import-github steveyegge/gastown
+  gt import-github https://github.com/me/app --town ~/gt --rig app
+  gt import-github me/app --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportGitHub,
+}
+
+func init() {
+	importGitHubCmd.Flags().StringVar(&importGitHubTown, "town", "", "Town location (default: current town, else $GT_TOWN_ROOT or ~/gt)")
+	importGitHubCmd.Flags().StringVar(&importGitHubRig, "rig", "", "Rig name (defaults to repo name)")
+	importGitHubCmd.Flags().IntVar(&importGitHubLimit, "limit", 1000, "Maximum number of open issues to import")
+	importGitHubCmd.Flags().BoolVar(&importGitHubDryRun, "dry-run", false, "Show what would be imported without creating anything")
+	importGitHubCmd.Flags().BoolVar(&importGitHubJSON, "json", false, "Output import result as JSON")
+	rootCmd.AddCommand(importGitHubCmd)
+}
+
+func runImportGitHub(cmd *cobra.Command, args []string) error {
+	repo, err := ghimport.RepoFromURL(args[0])
+	if err != nil {
+		return err
+	}
+	rigURL := "https://github.com/" + repo + ".git"
+
+	townRoot := expandHome(importGitHubTown)
+	if townRoot == "" {
+		if root, err := workspace.FindFromCwd(); err == nil && root != "" {
+			townRoot = root
+		} else {
+			townRoot = config.DefaultTownRoot()
+		}
+	}
+	rigName := importGitHubRig
+	if rigName == "" {
+		rigName = setup.RigNameFromURL(rigURL)
+	}
+
+	if !rigRegistered(townRoot, rigName) {
+		if importGitHubDryRun {
+			return fmt.Errorf("rig %s does not exist in %s (run without --dry-run to create it)", rigName, townRoot)
+		}
+		w := &setup.Wizard{Actions: setupActions{}, DefaultTownRoot: townRoot}
+		if !importGitHubJSON {
+			w.Out = os.Stdout
+		}
+		if _, err := w.Run(setup.Options{
+			TownRoot:      townRoot,
+			RigName:       rigName,
+			RigURL:        rigURL,
+			SkipSmokeTest: true,
+		}); err != nil {
+			return err
+		}
+	}
+
+	rigPath := filepath.Join(townRoot, rigName)
+	beadsPath := filepath.Join(rigPath, "mayor", "rig")
+	if _, err := os.Stat(beadsPath); err != nil {
+		beadsPath = rigPath
+	}
+
+	mapping, err := ghimport.LoadMapping(rigPath, repo)
+	if err != nil {
+		return err
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	im := &ghimport.Importer{
+		Fetcher: ghimport.GHFetcher{Limit: importGitHubLimit},
+		Beads:   beads.New(beadsPath),
+		DryRun:  importGitHubDryRun,
+
+		Priorities: settings.Priorities,
+	}
+	result, importErr := im.Import(repo, mapping)
+	// Save even on partial failure so a re-run doesn't duplicate beads
+	if !importGitHubDryRun && result != nil {
+		if err := mapping.Save(rigPath); err != nil {
+			return fmt.Errorf("saving %s: %w", ghimport.MappingFileName, err)
+		}
+	}
+	if importErr != nil {
+		return importErr
+	}
+
+	if importGitHubJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	verb := "Imported"
+	if importGitHubDryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("%s %s %d epic(s) and %d issue(s) from %s into rig %s\n",
+		style.Success.Render("✓"), verb, len(result.EpicsCreated), len(result.IssuesCreated),
+		style.Bold.Render(repo), style.Bold.Render(rigName))
+	if result.Skipped > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("%d already imported, skipped", result.Skipped)))
+	}
+	return nil
+}
+
+// rigRegistered reports whether rigName is in the town's rigs.json.
+func rigRegistered(townRoot, rigName string) bool {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		return false
+	}
+	_, ok := rigsConfig.Rigs[rigName]
+	return ok
+}
@@ -72,13 +72,39 @@ func init() {
 	rootCmd.AddCommand(installCmd)
 }
 
+// installOptions are the gt install flags, so other commands (gt setup)
+// can create a town without setting them.
+type installOptions struct {
+	Name       string // Town name (defaults to directory name)
+	Owner      string // Owner email (defaults to git config user.email)
+	PublicName string // Public display name (defaults to town name)
+	Force      bool   // Reinitialize an existing HQ
+	NoBeads    bool   // Skip town beads initialization
+	Git        bool   // Initialize git with .gitignore
+	GitHub     string // Create GitHub repo owner/repo
+	Public     bool   // Make the GitHub repo public
+}
+
 func runInstall(cmd *cobra.Command, args []string) error {
 	// Determine target path
 	targetPath := "."
 	if len(args) > 0 {
 		targetPath = args[0]
 	}
+	return installTown(targetPath, installOptions{
+		Name:       installName,
+		Owner:      installOwner,
+		PublicName: installPublicName,
+		Force:      installForce,
+		NoBeads:    installNoBeads,
+		Git:        installGit,
+		GitHub:     installGitHub,
+		Public:     installPublic,
+	})
+}
 
+// installTown creates a town HQ at targetPath.
+func installTown(targetPath string, opts installOptions) error {
 	// Expand ~ and resolve to absolute path
 	if targetPath[0] == '~' {
 		home, err := os.UserHomeDir()
@@ -94,13 +120,13 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Determine town name
-	townName := installName
+	townName := opts.Name
 	if townName == "" {
 		townName = filepath.Base(absPath)
 	}
 
 	// Check if already a workspace
-	if isWS, _ := workspace.IsWorkspace(absPath); isWS && !installForce {
+	if isWS, _ := workspace.IsWorkspace(absPath); isWS && !opts.Force {
 		return fmt.Errorf("directory is already a Gas Town HQ (use --force to reinitialize)")
 	}
 
@@ -110,7 +136,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Ensure beads (bd) is available before proceeding
-	if !installNoBeads {
+	if !opts.NoBeads {
 		if err := deps.EnsureBeads(true); err != nil {
 			return fmt.Errorf("beads dependency check failed: %w", err)
 		}
@@ -132,7 +158,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	fmt.Printf("   ✓ Created mayor/\n")
 
 	// Determine owner (defaults to git user.email)
-	owner := installOwner
+	owner := opts.Owner
 	if owner == "" {
 		out, err := exec.Command("git", "config", "user.email").Output()
 		if err == nil {
@@ -141,7 +167,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Determine public name (defaults to town name)
-	publicName := installPublicName
+	publicName := opts.PublicName
 	if publicName == "" {
 		publicName = townName
 	}
@@ -207,9 +233,9 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	// Initialize git BEFORE beads so that bd can compute repository fingerprint.
 	// The fingerprint is required for the daemon to start properly.
-	if installGit || installGitHub != "" {
+	if opts.Git || opts.GitHub != "" {
 		fmt.Println()
-		if err := InitGitForHarness(absPath, installGitHub, !installPublic); err != nil {
+		if err := InitGitForHarness(absPath, opts.GitHub, !opts.Public); err != nil {
 			return fmt.Errorf("git initialization failed: %w", err)
 		}
 	}
@@ -217,7 +243,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	// Initialize town-level beads database (optional)
 	// Town beads (hq- prefix) stores mayor mail, cross-rig coordination, and handoffs.
 	// Rig beads are separate and have their own prefixes.
-	if !installNoBeads {
+	if !opts.NoBeads {
 		if err := initTownBeads(absPath); err != nil {
 			fmt.Printf("   %s Could not initialize town beads: %v\n", style.Dim.Render("⚠"), err)
 		} else {
@@ -264,7 +290,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Println("Next steps:")
 	step := 1
-	if !installGit && installGitHub == "" {
+	if !opts.Git && opts.GitHub == "" {
 		fmt.Printf("  %d. Initialize git: %s\n", step, style.Dim.Render("gt git-init"))
 		step++
 	}
@@ -43,6 +43,7 @@ var (
 	mailCheckJSON     bool
 	mailCheckIdentity string
 	mailThreadJSON    bool
+	mailUnreadJSON    bool
 	mailReplySubject  string
 	mailReplyMessage  string
 
@@ -97,9 +98,12 @@ ADDRESS FORMATS:
 
 COMMANDS:
   inbox     View your inbox
+  unread    List messages you haven't read
   send      Send a message
+  broadcast Announce to every agent in a rig or the town
   read      Read a specific message
-  mark      Mark messages read/unread`,
+  reply     Reply to a message
+  thread    View a conversation`,
 }
 
 var mailSendCmd = &cobra.Command{
@@ -111,7 +115,7 @@ Addresses:
   mayor/           - Send to Mayor
   <rig>/refinery   - Send to a rig's Refinery
   <rig>/<polecat>  - Send to a specific polecat
-  <rig>/           - Broadcast to a rig
+  <rig>/           - Broadcast to a rig (see also gt mail broadcast)
   list:<name>      - Send to a mailing list (fans out to all members)
 
 Mailing lists are defined in ~/gt/config/messaging.json and allow
@@ -169,6 +173,10 @@ var mailReadCmd = &cobra.Command{
 	Short: "Read a message",
 	Long: `Read a specific message and mark it as read.
 
+Reading leaves a read receipt (you, and when) on the message; it stays in
+your inbox until you delete or archive it. Read state is per recipient:
+reading a message you were CC'd on doesn't mark it read for anyone else.
+
 The message ID can be found from 'gt mail inbox'.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMailRead,
@@ -231,18 +239,35 @@ Examples:
 }
 
 var mailThreadCmd = &cobra.Command{
-	Use:   "thread <thread-id>",
+	Use:   "thread <thread-id|message-id>",
 	Short: "View a message thread",
-	Long: `View all messages in a conversation thread.
+	Long: `View all messages in a conversation thread, read or not.
 
-Shows messages in chronological order (oldest first).
+Shows messages in conversation order: each reply follows, indented, the
+message it answers. Give a thread ID or the ID of any message in it.
 
 Examples:
-  gt mail thread thread-abc123`,
+  gt mail thread thread-abc123
+  gt mail thread hq-abc123`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMailThread,
 }
 
+var mailUnreadCmd = &cobra.Command{
+	Use:   "unread [address]",
+	Short: "List messages you haven't read",
+	Long: `List the messages in an inbox that its owner hasn't read yet.
+
+Read state is per recipient: a message stays unread for you until you
+read it ('gt mail read'), even if the other recipients have.
+
+Examples:
+  gt mail unread                   # Current context (auto-detected)
+  gt mail unread greenplace/Toast  # A polecat's unread mail`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMailUnread,
+}
+
 var mailReplyCmd = &cobra.Command{
 	Use:   "reply <message-id>",
 	Short: "Reply to a message",
@@ -430,6 +455,9 @@ func init() {
 	// Thread flags
 	mailThreadCmd.Flags().BoolVar(&mailThreadJSON, "json", false, "Output as JSON")
 
+	// Unread flags
+	mailUnreadCmd.Flags().BoolVar(&mailUnreadJSON, "json", false, "Output as JSON")
+
 	// Reply flags
 	mailReplyCmd.Flags().StringVarP(&mailReplySubject, "subject", "s", "", "Override reply subject (default: Re: <original>)")
 	mailReplyCmd.Flags().StringVarP(&mailReplyMessage, "message", "m", "", "Reply message body (required)")
@@ -451,6 +479,7 @@ func init() {
 	// Add subcommands
 	mailCmd.AddCommand(mailSendCmd)
 	mailCmd.AddCommand(mailInboxCmd)
+	mailCmd.AddCommand(mailUnreadCmd)
 	mailCmd.AddCommand(mailReadCmd)
 	mailCmd.AddCommand(mailPeekCmd)
 	mailCmd.AddCommand(mailDeleteCmd)
@@ -580,7 +609,7 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	}
 
 	// Log mail event to activity feed
-	_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
+	_ = events.Log(events.TypeMail, from, events.MailPayload(to, mailSubject), events.VisibilityDefault)
 
 	fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
 	fmt.Printf("  Subject: %s\n", mailSubject)
@@ -705,9 +734,16 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting message: %w", err)
 	}
 
-	// Note: We intentionally do NOT mark as read/ack on read.
-	// User must explicitly delete/ack the message.
-	// This preserves handoff messages for reference.
+	// Reading leaves a receipt but doesn't ack (close) the message; the user
+	// must explicitly delete/ack it. This preserves handoff messages for
+	// reference. Only recipients leave receipts.
+	if !msg.Read && msg.IsRecipient(address) {
+		if err := mailbox.MarkRead(msgID); err != nil {
+			style.PrintWarning("could not mark message as read: %v", err)
+		} else {
+			msg.Read = true
+		}
+	}
 
 	// JSON output
 	if mailReadJSON {
@@ -1199,7 +1235,7 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 }
 
 func runMailThread(cmd *cobra.Command, args []string) error {
-	threadID := args[0]
+	id := args[0]
 
 	// All mail uses town beads (two-level architecture)
 	workDir, err := findMailWorkDir()
@@ -1217,7 +1253,7 @@ func runMailThread(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting mailbox: %w", err)
 	}
 
-	messages, err := mailbox.ListByThread(threadID)
+	thread, err := mailbox.Thread(id)
 	if err != nil {
 		return fmt.Errorf("getting thread: %w", err)
 	}
@@ -1226,19 +1262,25 @@ func runMailThread(cmd *cobra.Command, args []string) error {
 	if mailThreadJSON {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
-		return enc.Encode(messages)
+		return enc.Encode(thread)
 	}
 
 	// Human-readable output
+	threadID := id
+	if len(thread) > 0 && thread[0].ThreadID != "" {
+		threadID = thread[0].ThreadID
+	}
 	fmt.Printf("%s Thread: %s (%d messages)\n\n",
-		style.Bold.Render("🧵"), threadID, len(messages))
+		style.Bold.Render("🧵"), threadID, len(thread))
 
-	if len(messages) == 0 {
+	if len(thread) == 0 {
 		fmt.Printf("  %s\n", style.Dim.Render("(no messages in thread)"))
 		return nil
 	}
 
-	for i, msg := range messages {
+	for i, entry := range thread {
+		msg := entry.Message
+		indent := strings.Repeat("  ", entry.Depth)
 		typeMarker := ""
 		if msg.Type != "" && msg.Type != mail.TypeNotification {
 			typeMarker = fmt.Sprintf(" [%s]", msg.Type)
@@ -1247,25 +1289,82 @@ func runMailThread(cmd *cobra.Command, args []string) error {
 		if msg.Priority == mail.PriorityHigh || msg.Priority == mail.PriorityUrgent {
 			priorityMarker = " " + style.Bold.Render("!")
 		}
+		readMarker := "●"
+		if msg.Read {
+			readMarker = "○"
+		}
 
 		if i > 0 {
-			fmt.Printf("  %s\n", style.Dim.Render("│"))
+			fmt.Printf("  %s%s\n", indent, style.Dim.Render("│"))
 		}
-		fmt.Printf("  %s %s%s%s\n", style.Bold.Render("●"), msg.Subject, typeMarker, priorityMarker)
-		fmt.Printf("    %s from %s to %s\n",
+		fmt.Printf("  %s%s %s%s%s\n", indent, style.Bold.Render(readMarker), msg.Subject, typeMarker, priorityMarker)
+		fmt.Printf("  %s  %s from %s to %s\n", indent,
 			style.Dim.Render(msg.ID),
 			msg.From, msg.To)
-		fmt.Printf("    %s\n",
+		fmt.Printf("  %s  %s\n", indent,
 			style.Dim.Render(msg.Timestamp.Format("2006-01-02 15:04")))
+		for _, r := range msg.ReadBy {
+			fmt.Printf("  %s  %s\n", indent,
+				style.Dim.Render(fmt.Sprintf("read by %s %s", r.Reader, r.At.Local().Format("2006-01-02 15:04"))))
+		}
 
 		if msg.Body != "" {
-			fmt.Printf("    %s\n", msg.Body)
+			fmt.Printf("  %s  %s\n", indent, msg.Body)
 		}
 	}
 
 	return nil
 }
 
+// runMailUnread lists the messages an inbox's owner hasn't read.
+func runMailUnread(cmd *cobra.Command, args []string) error {
+	address := detectSender()
+	if len(args) > 0 {
+		address = args[0]
+	}
+
+	// All mail uses town beads (two-level architecture)
+	workDir, err := findMailWorkDir()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	router := mail.NewRouter(workDir)
+	mailbox, err := router.GetMailbox(address)
+	if err != nil {
+		return fmt.Errorf("getting mailbox: %w", err)
+	}
+	messages, err := mailbox.ListUnread()
+	if err != nil {
+		return fmt.Errorf("listing messages: %w", err)
+	}
+
+	if mailUnreadJSON {
+		if messages == nil {
+			messages = []*mail.Message{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(messages)
+	}
+
+	fmt.Printf("%s Unread: %s (%d)\n\n", style.Bold.Render("📬"), address, len(messages))
+	if len(messages) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(nothing unread)"))
+		return nil
+	}
+	for _, msg := range messages {
+		priorityMarker := ""
+		if msg.Priority == mail.PriorityHigh || msg.Priority == mail.PriorityUrgent {
+			priorityMarker = " " + style.Bold.Render("!")
+		}
+		fmt.Printf("  ● %s%s\n", msg.Subject, priorityMarker)
+		fmt.Printf("    %s from %s, %s\n", style.Dim.Render(msg.ID), msg.From,
+			style.Dim.Render(msg.Timestamp.Format("2006-01-02 15:04")))
+	}
+	return nil
+}
+
 func runMailReply(cmd *cobra.Command, args []string) error {
 	msgID := args[0]
 
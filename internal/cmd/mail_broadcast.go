@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	mailBroadcastSubject string
+	mailBroadcastBody    string
+	mailBroadcastJSON    bool
+)
+
+var mailBroadcastCmd = &cobra.Command{
+	Use:   "broadcast <rig|town>",
+	Short: "Send an announcement to every agent in a rig or the town",
+	Long: `Send an announcement to every active agent in a rig, or with "town"
+to every active agent in the town.
+
+Recipients come from the agent registry (agent beads), less the sender.
+Each gets its own copy in their inbox; the copies share one thread, so
+'gt mail thread <thread-id>' shows who has read the announcement.
+
+Examples:
+  gt mail broadcast gastown -s "Freeze" -m "No merges until the release is cut"
+  gt mail broadcast town -s "Maintenance" -m "Dolt restart at 14:00"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailBroadcast,
+}
+
+func init() {
+	mailBroadcastCmd.Flags().StringVarP(&mailBroadcastSubject, "subject", "s", "", "Announcement subject (required)")
+	mailBroadcastCmd.Flags().StringVarP(&mailBroadcastBody, "message", "m", "", "Announcement body")
+	mailBroadcastCmd.Flags().BoolVar(&mailBroadcastJSON, "json", false, "Output as JSON")
+	_ = mailBroadcastCmd.MarkFlagRequired("subject")
+
+	mailCmd.AddCommand(mailBroadcastCmd)
+}
+
+func runMailBroadcast(cmd *cobra.Command, args []string) error {
+	scope := strings.TrimSuffix(args[0], "/")
+
+	workDir, err := findMailWorkDir()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	from := detectSender()
+
+	router := mail.NewRouter(workDir)
+	result, err := router.SendBroadcast(from, scope, mailBroadcastSubject, mailBroadcastBody)
+	if err != nil {
+		return fmt.Errorf("broadcasting: %w", err)
+	}
+
+	for _, recipient := range result.Delivered() {
+		_ = events.Log(events.TypeMail, from, events.MailPayload(recipient, mailBroadcastSubject), events.VisibilityDefault)
+	}
+
+	if mailBroadcastJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		delivered := result.Delivered()
+		fmt.Printf("%s Broadcast to %s: %d recipient(s)\n", style.Bold.Render("✓"), scope, len(delivered))
+		fmt.Printf("  Subject: %s\n", mailBroadcastSubject)
+		fmt.Printf("  Recipients: %s\n", strings.Join(delivered, ", "))
+		fmt.Printf("  Thread: %s\n", result.ThreadID)
+		for _, recipient := range result.Recipients {
+			if reason, failed := result.Failed[recipient]; failed {
+				style.PrintWarning("not sent to %s: %s", recipient, reason)
+			}
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
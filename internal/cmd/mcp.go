@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mcp"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var mcpRole string
+
+var mcpCmd = &cobra.Command{
+	Use:     "mcp",
+	GroupID: GroupServices,
+	Short:   "Serve gastown operations as MCP tools",
+	Long: `Expose sling, hook, mail, bead CRUD, and feed queries as Model Context
+Protocol tools, so agents can call gastown directly instead of building
+gt command lines.
+
+Tools:
+  sling         Assign work to an agent (gt sling)
+  hook          Show or attach work on your hook (gt hook)
+  mail_send     Send mail (gt mail send)
+  mail_inbox    List your inbox (gt mail inbox)
+  bead_list     List beads
+  bead_show     Show a bead
+  bead_create   Create a bead
+  bead_update   Update a bead
+  feed_query    Query the event feed (gt events query filters)
+
+Each role sees only the tools on its allowlist. Built-in defaults:
+  mayor, deacon, crew   all tools
+  witness, polecat      everything except sling
+  refinery              mail, bead list/show/update, feed_query
+  other roles           read-only: mail_inbox, bead_list, bead_show, feed_query
+Override per role in the town's settings/config.json:
+  {"mcp": {"tools": {"polecat": ["hook", "mail_send", "bead_show"]}}}
+"*" allows every tool.
+
+Register the server with Claude in .mcp.json:
+  {"mcpServers": {"gastown": {"command": "gt", "args": ["mcp", "serve"]}}}`,
+	RunE: requireSubcommand,
+}
+
+var mcpServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the MCP server on stdin/stdout",
+	Long: `Run the MCP server, speaking JSON-RPC on stdin/stdout until stdin closes.
+
+The session's role (GT_ROLE, else the working directory) picks the tool
+allowlist; --role overrides it.`,
+	RunE: runMCPServe,
+}
+
+var mcpToolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "List the MCP tools your role may call",
+	RunE:  runMCPTools,
+}
+
+func init() {
+	mcpCmd.PersistentFlags().StringVar(&mcpRole, "role", "", "Role whose allowlist applies (default: detected)")
+	mcpCmd.AddCommand(mcpServeCmd)
+	mcpCmd.AddCommand(mcpToolsCmd)
+	rootCmd.AddCommand(mcpCmd)
+}
+
+// mcpTools returns the tools the session's role may call, and the role.
+func mcpTools() ([]mcp.Tool, string, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return nil, "", fmt.Errorf("getting current directory: %w", err)
+	}
+	gt, err := os.Executable()
+	if err != nil {
+		return nil, "", fmt.Errorf("finding gt binary: %w", err)
+	}
+	router, err := beads.NewTownRouter(townRoot)
+	if err != nil {
+		return nil, "", fmt.Errorf("loading beads routes: %w", err)
+	}
+
+	role := mcpRole
+	if role == "" {
+		role = string(RoleUnknown)
+		if info, err := GetRoleWithContext(workDir, townRoot); err == nil {
+			role = string(info.Role)
+		}
+	}
+
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil, "", fmt.Errorf("loading town settings: %w", err)
+	}
+	env := mcp.Env{TownRoot: townRoot, WorkDir: workDir, Beads: router, GT: gt}
+	tools, err := mcp.Filter(mcp.Tools(env), mcp.Allowlist(role, settings.MCP))
+	if err != nil {
+		return nil, "", err
+	}
+	return tools, role, nil
+}
+
+func runMCPServe(cmd *cobra.Command, args []string) error {
+	tools, _, err := mcpTools()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return mcp.NewServer("gastown", Version, tools).Serve(ctx, os.Stdin, os.Stdout)
+}
+
+func runMCPTools(cmd *cobra.Command, args []string) error {
+	tools, role, err := mcpTools()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("MCP tools for %s:\n", role)
+	if len(tools) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, t := range tools {
+		fmt.Printf("  %-12s %s\n", t.Name, t.Description)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/metrics"
+)
+
+var (
+	metricsAddr     string
+	metricsInterval time.Duration
+)
+
+var metricsCmd = &cobra.Command{
+	Use:     "metrics",
+	GroupID: GroupDiag,
+	Short:   "Serve town health metrics for Prometheus",
+	Long: `Serve Prometheus metrics on /metrics until interrupted.
+
+Counters come from the events log (replayed from the start, then
+followed); gauges come from beads snapshots of the town and every rig,
+taken every --interval.
+
+Metrics:
+  gt_events_total{type}                    Events logged
+  gt_beads_open{store,status}              Beads not closed
+  gt_beads_blocked{store}                  Beads with an open blocker
+  gt_ready_queue_depth{store}              Open, unblocked beads
+  gt_sling_latency_seconds                 Histogram of sling → done time
+  gt_beads_snapshot_timestamp_seconds      Last snapshot per store
+  gt_beads_snapshot_failures_total{store}  Failed snapshots
+
+Examples:
+  gt metrics                      # Serve on 127.0.0.1:9464
+  gt metrics --addr=:9100 --interval=30s   # Listen on all interfaces`,
+	Args: cobra.NoArgs,
+	RunE: runMetrics,
+}
+
+func init() {
+	metricsCmd.Flags().StringVar(&metricsAddr, "addr", "127.0.0.1:9464", "Address to listen on (loopback by default; metrics name rigs and beads)")
+	metricsCmd.Flags().DurationVar(&metricsInterval, "interval", metrics.DefaultSnapshotInterval, "How often to snapshot beads")
+	rootCmd.AddCommand(metricsCmd)
+}
+
+func runMetrics(cmd *cobra.Command, args []string) error {
+	rigs, townRoot, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+
+	stores := map[string]metrics.SnapshotFunc{
+		"town": beads.New(beads.GetTownBeadsPath(townRoot)).Snapshot,
+	}
+	for _, r := range rigs {
+		stores[r.Name] = beads.New(r.BeadsPath()).Snapshot
+	}
+	collector := metrics.NewCollector(townRoot, stores)
+	collector.Interval = metricsInterval
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go collector.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector.Metrics)
+	server := &http.Server{
+		Addr:              metricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving metrics on %s/metrics (Ctrl-C to stop)\n", metricsAddr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
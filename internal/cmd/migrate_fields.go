@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var migrateFieldsExecute bool
+
+var migrateFieldsCmd = &cobra.Command{
+	Use:     "migrate-fields",
+	GroupID: GroupDiag,
+	Short:   "Move description-encoded bead fields into bd metadata",
+	Long: `Move attachment and merge-request fields out of bead descriptions.
+
+Older Gas Town versions stored structured fields (attached_molecule,
+branch, target, ...) as "key: value" lines in bead descriptions, where they
+are easily edited by accident and confused with prose. Current versions
+store them as bd metadata when bd supports it.
+
+This command rewrites every bead in the town and rig databases that still
+carries description-encoded fields. MR fields are only migrated on
+merge-request beads.
+
+Dry-run by default; use --execute to apply.
+
+Examples:
+  gt migrate-fields            # Show what would be migrated
+  gt migrate-fields --execute  # Apply the migration`,
+	RunE: runMigrateFields,
+}
+
+func init() {
+	migrateFieldsCmd.Flags().BoolVarP(&migrateFieldsExecute, "execute", "x", false, "Apply the migration (default is a dry run)")
+	rootCmd.AddCommand(migrateFieldsCmd)
+}
+
+func runMigrateFields(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	townBeadsDir := filepath.Join(townRoot, ".beads")
+	routes, err := beads.LoadRoutes(townBeadsDir)
+	if err != nil {
+		return fmt.Errorf("loading routes.jsonl: %w", err)
+	}
+
+	dbs := []struct{ name, path string }{{"town", townRoot}}
+	for _, r := range routes {
+		if r.Path != "." {
+			dbs = append(dbs, struct{ name, path string }{r.Path, filepath.Join(townRoot, r.Path)})
+		}
+	}
+
+	if !migrateFieldsExecute {
+		fmt.Println("🔍 DRY RUN: Showing what would be migrated")
+		fmt.Println("   Use --execute to apply changes")
+		fmt.Println()
+	}
+
+	total, failed := 0, 0
+	for _, db := range dbs {
+		results, err := beads.New(db.path).MigrateFieldsToMetadata(!migrateFieldsExecute)
+		if errors.Is(err, beads.ErrMetadataUnsupported) {
+			return fmt.Errorf("%s: %w (upgrade bd first)", db.name, err)
+		}
+		if err != nil {
+			style.PrintWarning("%s: %v", db.name, err)
+			continue
+		}
+		if len(results) == 0 {
+			continue
+		}
+
+		fmt.Printf("%s:\n", style.Bold.Render(db.name))
+		for _, r := range results {
+			keys := strings.Join(r.Keys, ", ")
+			if r.Error != nil {
+				failed++
+				fmt.Printf("  %s %s (%s): %v\n", style.Error.Render("✗"), r.ID, r.Kind, r.Error)
+				continue
+			}
+			total++
+			fmt.Printf("  %s %s (%s): %s\n", style.Success.Render("✓"), r.ID, r.Kind, keys)
+		}
+	}
+
+	fmt.Println()
+	verb := "Migrated"
+	if !migrateFieldsExecute {
+		verb = "Would migrate"
+	}
+	fmt.Printf("%s %d bead(s)", verb, total)
+	if failed > 0 {
+		fmt.Printf(", %d failed", failed)
+	}
+	fmt.Println()
+	if failed > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
@@ -115,7 +115,7 @@ Usage: gt mol attach-from-mail <mail-id>
 Behavior:
 1. Read mail body for attached_molecule field
 2. Attach molecule to agent's hook
-3. Mark mail as read
+3. Acknowledge the mail (removes it from the inbox)
 4. Return control for execution
 
 Example:
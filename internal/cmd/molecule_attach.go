@@ -38,8 +38,10 @@ func runMoleculeAttach(cmd *cobra.Command, args []string) error {
 
 	b := beads.New(workDir)
 
-	// Attach the molecule
-	issue, err := b.AttachMolecule(pinnedBeadID, moleculeID)
+	// Attach the molecule with audit logging
+	issue, err := b.AttachMoleculeWithAudit(pinnedBeadID, moleculeID, beads.AttachOptions{
+		Agent: detectCurrentAgent(),
+	})
 	if err != nil {
 		return fmt.Errorf("attaching molecule: %w", err)
 	}
@@ -103,15 +103,17 @@ func runMoleculeAttachFromMail(cmd *cobra.Command, args []string) error {
 	}
 
 	// Attach the molecule to the hook
-	issue, err := b.AttachMolecule(hookBead.ID, moleculeID)
+	issue, err := b.AttachMoleculeWithAudit(hookBead.ID, moleculeID, beads.AttachOptions{
+		Agent: agentIdentity,
+	})
 	if err != nil {
 		return fmt.Errorf("attaching molecule: %w", err)
 	}
 
-	// Mark mail as read
-	if err := mailbox.MarkRead(mailID); err != nil {
+	// Acknowledge the mail: its molecule is on the hook now
+	if err := mailbox.Delete(mailID); err != nil {
 		// Non-fatal: log warning but don't fail
-		style.PrintWarning("could not mark mail as read: %v", err)
+		style.PrintWarning("could not acknowledge mail: %v", err)
 	}
 
 	// Output success
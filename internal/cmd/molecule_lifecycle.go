@@ -220,7 +220,7 @@ squashed_at: %s
 		Title:       digestTitle,
 		Description: digestDesc,
 		Type:        "task",
-		Priority:    4, // P4 - backlog priority for digests
+		Priority:    beads.PriorityBacklog,
 		Actor:       target,
 	})
 	if err != nil {
@@ -184,46 +184,7 @@ func runMoleculeProgress(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Build set of closed issue IDs for dependency checking
-	closedIDs := make(map[string]bool)
-	for _, child := range children {
-		if child.Status == "closed" {
-			closedIDs[child.ID] = true
-		}
-	}
-
-	// Categorize steps
-	for _, child := range children {
-		progress.TotalSteps++
-
-		switch child.Status {
-		case "closed":
-			progress.DoneSteps++
-		case "in_progress":
-			progress.InProgress++
-		case "open":
-			// Check if all dependencies are closed
-			allDepsClosed := true
-			for _, depID := range child.DependsOn {
-				if !closedIDs[depID] {
-					allDepsClosed = false
-					break
-				}
-			}
-
-			if len(child.DependsOn) == 0 || allDepsClosed {
-				progress.ReadySteps = append(progress.ReadySteps, child.ID)
-			} else {
-				progress.BlockedSteps = append(progress.BlockedSteps, child.ID)
-			}
-		}
-	}
-
-	// Calculate completion percentage
-	if progress.TotalSteps > 0 {
-		progress.Percent = (progress.DoneSteps * 100) / progress.TotalSteps
-	}
-	progress.Complete = progress.DoneSteps == progress.TotalSteps
+	applyMoleculeProgress(&progress, children)
 
 	// JSON output
 	if moleculeJSON {
@@ -508,50 +469,23 @@ func getMoleculeProgressInfo(b *beads.Beads, moleculeRootID string) (*MoleculePr
 		}
 	}
 
-	// Build set of closed issue IDs for dependency checking
-	closedIDs := make(map[string]bool)
-	for _, child := range children {
-		if child.Status == "closed" {
-			closedIDs[child.ID] = true
-		}
-	}
-
-	// Categorize steps
-	for _, child := range children {
-		progress.TotalSteps++
-
-		switch child.Status {
-		case "closed":
-			progress.DoneSteps++
-		case "in_progress":
-			progress.InProgress++
-		case "open":
-			// Check if all dependencies are closed
-			allDepsClosed := true
-			for _, depID := range child.DependsOn {
-				if !closedIDs[depID] {
-					allDepsClosed = false
-					break
-				}
-			}
-
-			if len(child.DependsOn) == 0 || allDepsClosed {
-				progress.ReadySteps = append(progress.ReadySteps, child.ID)
-			} else {
-				progress.BlockedSteps = append(progress.BlockedSteps, child.ID)
-			}
-		}
-	}
-
-	// Calculate completion percentage
-	if progress.TotalSteps > 0 {
-		progress.Percent = (progress.DoneSteps * 100) / progress.TotalSteps
-	}
-	progress.Complete = progress.DoneSteps == progress.TotalSteps
+	applyMoleculeProgress(progress, children)
 
 	return progress, nil
 }
 
+// applyMoleculeProgress fills step counts from the molecule's children.
+func applyMoleculeProgress(progress *MoleculeProgressInfo, children []*beads.Issue) {
+	p := beads.ComputeMoleculeProgress(progress.RootID, children)
+	progress.TotalSteps = p.Total
+	progress.DoneSteps = p.Done
+	progress.InProgress = p.InProgress
+	progress.ReadySteps = p.ReadySteps
+	progress.BlockedSteps = p.BlockedSteps
+	progress.Percent = p.Percent
+	progress.Complete = p.Complete
+}
+
 // determineNextAction suggests the next action based on status.
 func determineNextAction(status MoleculeStatusInfo) string {
 	if status.Progress == nil {
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 )
@@ -138,19 +139,19 @@ func makeStepIssue(id, title, parent, status string, dependsOn []string) *beads.
 		Priority:  2,
 		Parent:    parent,
 		DependsOn: dependsOn,
-		CreatedAt: "2025-01-01T12:00:00Z",
-		UpdatedAt: "2025-01-01T12:00:00Z",
+		CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
 	}
 }
 
 func TestFindNextReadyStep(t *testing.T) {
 	tests := []struct {
-		name           string
-		moleculeID     string
-		setupFunc      func(*mockBeadsForStep)
-		wantStepID     string
-		wantComplete   bool
-		wantNilStep    bool
+		name         string
+		moleculeID   string
+		setupFunc    func(*mockBeadsForStep)
+		wantStepID   string
+		wantComplete bool
+		wantNilStep  bool
 	}{
 		{
 			name:       "no steps - molecule complete",
@@ -308,11 +309,11 @@ func TestFindNextReadyStep(t *testing.T) {
 // TestStepDoneScenarios tests complete step-done scenarios
 func TestStepDoneScenarios(t *testing.T) {
 	tests := []struct {
-		name           string
-		stepID         string
-		setupFunc      func(*mockBeadsForStep)
-		wantAction     string // "continue", "done", "no_more_ready"
-		wantNextStep   string
+		name         string
+		stepID       string
+		setupFunc    func(*mockBeadsForStep)
+		wantAction   string // "continue", "done", "no_more_ready"
+		wantNextStep string
 	}{
 		{
 			name:   "complete step, continue to next",
@@ -229,17 +229,12 @@ func runMQList(cmd *cobra.Command, args []string) error {
 }
 
 // formatMRAge formats the age of an MR from its created_at timestamp.
-func formatMRAge(createdAt string) string {
-	t, err := time.Parse(time.RFC3339, createdAt)
-	if err != nil {
-		// Try other formats
-		t, err = time.Parse("2006-01-02T15:04:05Z", createdAt)
-		if err != nil {
-			return "?"
-		}
+func formatMRAge(createdAt time.Time) string {
+	if createdAt.IsZero() {
+		return "?"
 	}
 
-	d := time.Since(t)
+	d := time.Since(createdAt)
 
 	if d < time.Minute {
 		return fmt.Sprintf("%ds", int(d.Seconds()))
@@ -264,12 +259,9 @@ func outputJSON(data interface{}) error {
 // Higher scores mean higher priority (process first).
 func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, now time.Time) float64 {
 	// Parse MR creation time
-	mrCreatedAt, err := time.Parse(time.RFC3339, issue.CreatedAt)
-	if err != nil {
-		mrCreatedAt, err = time.Parse("2006-01-02T15:04:05Z", issue.CreatedAt)
-		if err != nil {
-			mrCreatedAt = now // Fallback to now if parsing fails
-		}
+	mrCreatedAt := issue.CreatedAt
+	if mrCreatedAt.IsZero() {
+		mrCreatedAt = now // Fallback to now if bd gave no creation time
 	}
 
 	// Build score input
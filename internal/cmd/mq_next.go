@@ -92,9 +92,7 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 	if mqNextStrategy == "fifo" {
 		// FIFO: oldest first by creation time
 		sort.Slice(ready, func(i, j int) bool {
-			ti, _ := time.Parse(time.RFC3339, ready[i].CreatedAt)
-			tj, _ := time.Parse(time.RFC3339, ready[j].CreatedAt)
-			return ti.Before(tj)
+			return ready[i].CreatedAt.Before(ready[j].CreatedAt)
 		})
 	} else {
 		// Priority: highest score first
@@ -15,15 +15,15 @@ import (
 // MRStatusOutput is the JSON output structure for gt mq status.
 type MRStatusOutput struct {
 	// Core issue fields
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Status    string `json:"status"`
-	Priority  int    `json:"priority"`
-	Type      string `json:"type"`
-	Assignee  string `json:"assignee,omitempty"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	ClosedAt  string `json:"closed_at,omitempty"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Priority  int       `json:"priority"`
+	Type      string    `json:"type"`
+	Assignee  string    `json:"assignee,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	ClosedAt  time.Time `json:"closed_at,omitzero"`
 
 	// MR-specific fields
 	Branch      string `json:"branch,omitempty"`
@@ -150,14 +150,14 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 
 	// Timestamps
 	fmt.Printf("\n%s\n", style.Bold.Render("Timeline"))
-	if issue.CreatedAt != "" {
-		fmt.Printf("   Created: %s %s\n", issue.CreatedAt, formatTimeAgo(issue.CreatedAt))
+	if !issue.CreatedAt.IsZero() {
+		fmt.Printf("   Created: %s %s\n", issue.CreatedAt.Format(time.RFC3339), formatTimeAgo(issue.CreatedAt))
 	}
-	if issue.UpdatedAt != "" && issue.UpdatedAt != issue.CreatedAt {
-		fmt.Printf("   Updated: %s %s\n", issue.UpdatedAt, formatTimeAgo(issue.UpdatedAt))
+	if !issue.UpdatedAt.IsZero() && !issue.UpdatedAt.Equal(issue.CreatedAt) {
+		fmt.Printf("   Updated: %s %s\n", issue.UpdatedAt.Format(time.RFC3339), formatTimeAgo(issue.UpdatedAt))
 	}
-	if issue.ClosedAt != "" {
-		fmt.Printf("   Closed:  %s %s\n", issue.ClosedAt, formatTimeAgo(issue.ClosedAt))
+	if !issue.ClosedAt.IsZero() {
+		fmt.Printf("   Closed:  %s %s\n", issue.ClosedAt.Format(time.RFC3339), formatTimeAgo(issue.ClosedAt))
 	}
 
 	// MR-specific fields
@@ -254,26 +254,9 @@ func getStatusIcon(status string) string {
 }
 
 // formatTimeAgo formats a timestamp as a relative time string.
-func formatTimeAgo(timestamp string) string {
-	// Try parsing common formats
-	formats := []string{
-		time.RFC3339,
-		"2006-01-02T15:04:05Z",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05",
-		"2006-01-02",
-	}
-
-	var t time.Time
-	var err error
-	for _, format := range formats {
-		t, err = time.Parse(format, timestamp)
-		if err == nil {
-			break
-		}
-	}
-	if err != nil {
-		return "" // Can't parse, return empty
+func formatTimeAgo(t time.Time) string {
+	if t.IsZero() {
+		return "" // Unknown time, return empty
 	}
 
 	d := time.Since(t)
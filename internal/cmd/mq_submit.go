@@ -138,21 +138,14 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Build MR bead title and description
-	title := fmt.Sprintf("Merge: %s", issueID)
-	description := fmt.Sprintf("branch: %s\ntarget: %s\nsource_issue: %s\nrig: %s",
-		branch, target, issueID, rigName)
-	if worker != "" {
-		description += fmt.Sprintf("\nworker: %s", worker)
-	}
-
 	// Create MR bead (ephemeral wisp - will be cleaned up after merge)
-	mrIssue, err := bd.Create(beads.CreateOptions{
-		Title:       title,
-		Type:        "merge-request",
-		Priority:    priority,
-		Description: description,
-	})
+	mrIssue, err := bd.CreateMR(beads.MRFields{
+		Branch:      branch,
+		Target:      target,
+		SourceIssue: issueID,
+		Rig:         rigName,
+		Worker:      worker,
+	}, beads.MRCreateOptions{Priority: priority})
 	if err != nil {
 		return fmt.Errorf("creating merge request bead: %w", err)
 	}
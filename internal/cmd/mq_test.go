@@ -126,7 +126,8 @@ func TestFormatMRAge(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatMRAge(tt.createdAt)
+			createdAt, _ := beads.ParseTimestamp(tt.createdAt)
+			result := formatMRAge(createdAt)
 			if tt.wantOk && result == "" {
 				t.Errorf("formatMRAge() returned empty for %s", tt.createdAt)
 			}
@@ -319,7 +320,8 @@ func TestFormatTimeAgo(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := formatTimeAgo(tt.timestamp)
+			ts, _ := beads.ParseTimestamp(tt.timestamp)
+			got := formatTimeAgo(ts)
 			if tt.wantEmpty && got != "" {
 				t.Errorf("formatTimeAgo(%q) = %q, want empty", tt.timestamp, got)
 			}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/steveyegge/gastown/internal/beads"
 )
 
@@ -69,8 +71,8 @@ func makeTestIssue(id, title, issueType, status string) *beads.Issue {
 		Type:      issueType,
 		Status:    status,
 		Priority:  2,
-		CreatedAt: "2025-01-01T12:00:00Z",
-		UpdatedAt: "2025-01-01T12:00:00Z",
+		CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
 	}
 }
 
@@ -90,7 +92,7 @@ func makeTestMR(id, branch, target, worker string, status string) *beads.Issue {
 		Status:      status,
 		Priority:    2,
 		Description: desc,
-		CreatedAt:   "2025-01-01T12:00:00Z",
-		UpdatedAt:   "2025-01-01T12:00:00Z",
+		CreatedAt:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
 	}
 }
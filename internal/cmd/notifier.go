@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/notify"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var notifierCmd = &cobra.Command{
+	Use:     "notifier",
+	GroupID: GroupServices,
+	Short:   "Post feed events to Slack and Discord",
+	Long: `Post feed-visible events to Slack and Discord channels through their
+incoming webhooks, routed by event type, rig, and severity.
+
+The gt daemon runs the notifier whenever the town has notify routes;
+"gt notifier run" runs it in the foreground instead, e.g. on a machine
+without the daemon.
+
+Configure channels and routes in the town's settings/config.json:
+  {"notify": {
+    "channels": {
+      "refinery": {"type": "slack", "url": "https://hooks.slack.com/services/..."},
+      "ops": {"type": "discord", "url": "https://discord.com/api/webhooks/..."}},
+    "routes": [
+      {"types": ["merge_conflict", "merge_failed"], "channel": "refinery"},
+      {"types": ["spawn", "kill"], "channel": "ops"},
+      {"min_severity": "error", "channel": "ops"}]}}
+
+An event goes to every channel with a matching route, once. A route
+matches on all the criteria it sets (types, rig, min_severity); a route
+with none matches every feed event. Messages use the feed's templates.
+
+Examples:
+  gt notifier run          # Post events until interrupted
+  gt notifier test ops     # Send a test message to #ops`,
+	RunE: requireSubcommand,
+}
+
+var notifierRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Post events to chat until interrupted",
+	Args:  cobra.NoArgs,
+	RunE:  runNotifierRun,
+}
+
+var notifierTestCmd = &cobra.Command{
+	Use:   "test [channel...]",
+	Short: "Send a test message to channels (default: all)",
+	RunE:  runNotifierTest,
+}
+
+func init() {
+	notifierCmd.AddCommand(notifierRunCmd)
+	notifierCmd.AddCommand(notifierTestCmd)
+	rootCmd.AddCommand(notifierCmd)
+}
+
+// loadNotifier loads the current town's notifier, failing if it routes
+// nothing.
+func loadNotifier() (*notify.Notifier, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	n, err := notify.Load(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return nil, fmt.Errorf("no notify routes configured (see gt notifier --help)")
+	}
+	return n, nil
+}
+
+func runNotifierRun(cmd *cobra.Command, args []string) error {
+	n, err := loadNotifier()
+	if err != nil {
+		return err
+	}
+	n.Logf = style.PrintWarning
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("📣 Posting feed events to %d channel(s)\n", len(n.ChannelNames()))
+	fmt.Printf("   Press Ctrl+C to stop\n")
+	n.Start()
+	<-ctx.Done()
+	n.Stop()
+	return nil
+}
+
+func runNotifierTest(cmd *cobra.Command, args []string) error {
+	n, err := loadNotifier()
+	if err != nil {
+		return err
+	}
+	channels := args
+	if len(channels) == 0 {
+		channels = n.ChannelNames()
+	}
+
+	failed := 0
+	for _, name := range channels {
+		if err := n.Post(name, "Gas Town notifier test: this channel is connected."); err != nil {
+			fmt.Printf("%s %s: %v\n", style.Error.Render("✗"), name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s %s\n", style.Success.Render("✓"), name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d channel(s) failed", failed, len(channels))
+	}
+	return nil
+}
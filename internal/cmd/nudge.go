@@ -165,7 +165,7 @@ func runNudge(cmd *cobra.Command, args []string) error {
 		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
 			_ = LogNudge(townRoot, "deacon", message)
 		}
-		_ = events.LogFeed(events.TypeNudge, sender, events.NudgePayload("", "deacon", message))
+		_ = events.Log(events.TypeNudge, sender, events.NudgePayload("", "deacon", message), events.VisibilityDefault)
 		return nil
 	}
 
@@ -204,7 +204,7 @@ func runNudge(cmd *cobra.Command, args []string) error {
 		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
 			_ = LogNudge(townRoot, target, message)
 		}
-		_ = events.LogFeed(events.TypeNudge, sender, events.NudgePayload(rigName, target, message))
+		_ = events.Log(events.TypeNudge, sender, events.NudgePayload(rigName, target, message), events.VisibilityDefault)
 	} else {
 		// Raw session name (legacy)
 		exists, err := t.HasSession(target)
@@ -225,7 +225,7 @@ func runNudge(cmd *cobra.Command, args []string) error {
 		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
 			_ = LogNudge(townRoot, target, message)
 		}
-		_ = events.LogFeed(events.TypeNudge, sender, events.NudgePayload("", target, message))
+		_ = events.Log(events.TypeNudge, sender, events.NudgePayload("", target, message), events.VisibilityDefault)
 	}
 
 	return nil
@@ -331,7 +331,7 @@ func runNudgeChannel(channelName, message string) error {
 	fmt.Println()
 
 	// Log nudge event
-	_ = events.LogFeed(events.TypeNudge, sender, events.NudgePayload("", "channel:"+channelName, message))
+	_ = events.Log(events.TypeNudge, sender, events.NudgePayload("", "channel:"+channelName, message), events.VisibilityDefault)
 
 	if failed > 0 {
 		fmt.Printf("%s Channel nudge complete: %d succeeded, %d failed\n",
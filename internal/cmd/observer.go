@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// observerCommands are the commands an observer may run, by path without
+// the leading "gt". Everything else is refused: new commands stay out of
+// reach of observers until someone decides they are safe to add.
+var observerCommands = map[string]bool{
+	"status":          true,
+	"feed":            true,
+	"search":          true,
+	"query":           true,
+	"explain":         true,
+	"dashboard":       true,
+	"metrics":         true,
+	"activity export": true,
+	"events query":    true,
+	"audit":           true,
+	"costs":           true,
+	"log":             true,
+	"quality":         true,
+	"info":            true,
+	"whoami":          true,
+	"peek":            true,
+	"version":         true,
+	"help":            true,
+	"completion":      true,
+	"epic progress":   true,
+	"convoy list":     true,
+	"convoy status":   true,
+	"mq list":         true,
+	"mq status":       true,
+	"polecat list":    true,
+	"polecat status":  true,
+	"rig list":        true,
+	"rig status":      true,
+	"crew list":       true,
+	"crew status":     true,
+	"mail inbox":      true,
+	"mail search":     true,
+	"mail thread":     true,
+	"mol status":      true,
+	"mol progress":    true,
+	"mol current":     true,
+}
+
+// observerAllowed reports whether an observer may run cmd. Completion's
+// own subcommands (bash, zsh, ...) ride along with "completion".
+func observerAllowed(cmd *cobra.Command) bool {
+	path := strings.TrimPrefix(buildCommandPath(cmd), cmd.Root().Name()+" ")
+	if observerCommands[path] {
+		return true
+	}
+	if parent := cmd.Parent(); parent != nil && parent.Name() == "completion" {
+		return true
+	}
+	return false
+}
+
+// checkObserver refuses mutating commands in observer mode and makes
+// every beads handle in the process read-only, so an allowed command
+// that happens to write still can't.
+func checkObserver(cmd *cobra.Command) error {
+	townRoot, _ := workspace.FindFromCwd()
+	if !config.IsObserver(townRoot) {
+		return nil
+	}
+	beads.SetDefaultMiddleware(beads.ReadOnly())
+	if observerAllowed(cmd) {
+		return nil
+	}
+	return fmt.Errorf("'%s' is not available in observer mode (read-only); observers can use status, feed, search, and other viewing commands", buildCommandPath(cmd))
+}
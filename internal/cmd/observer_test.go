@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestObserverAllowed(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"status"}, true},
+		{[]string{"feed"}, true},
+		{[]string{"convoy", "list"}, true},
+		{[]string{"mail", "inbox"}, true},
+		{[]string{"mail", "send"}, false},
+		{[]string{"sling"}, false},
+		{[]string{"convoy", "create"}, false},
+		{[]string{"activity", "emit"}, false},
+	}
+	for _, tt := range tests {
+		cmd, _, err := rootCmd.Find(tt.args)
+		if err != nil {
+			t.Fatalf("Find(%v): %v", tt.args, err)
+		}
+		if got := observerAllowed(cmd); got != tt.want {
+			t.Errorf("observerAllowed(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestObserverCommandsExist(t *testing.T) {
+	for path := range observerCommands {
+		if path == "help" || path == "completion" {
+			continue
+		}
+		cmd, _, err := rootCmd.Find(strings.Fields(path))
+		if err != nil || buildCommandPath(cmd) != "gt "+path {
+			t.Errorf("observer command %q does not resolve to a command", path)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// haltCommands are the commands that halt rigs or the town, by path
+// without the leading "gt", guarded by the halt_rig permission. Bead
+// operations (closing epics, deleting beads, detaching molecules) are
+// guarded in the beads package, whichever command makes them.
+var haltCommands = map[string]bool{
+	"stop":         true,
+	"down":         true,
+	"shutdown":     true,
+	"rig stop":     true,
+	"rig shutdown": true,
+	"rig park":     true,
+	"rig dock":     true,
+}
+
+// permissionRole returns the role the town's permissions apply to: the
+// agent role of this session, or overseer for a human outside any agent
+// session. An agent session whose role doesn't resolve keeps its GT_ROLE,
+// so it is never mistaken for the overseer.
+func permissionRole() string {
+	addr := strings.TrimSuffix(detectSender(), "/")
+	if addr == config.RoleOverseer {
+		if envRole := os.Getenv(EnvGTRole); envRole != "" {
+			return envRole
+		}
+		return config.RoleOverseer
+	}
+	role, _, _ := parseRoleString(addr)
+	return string(role)
+}
+
+// checkPermissions enforces the town's role permissions (settings
+// "permissions"): it refuses halting commands the current role may not
+// run, and makes every beads handle in the process refuse the bead
+// operations the role may not perform.
+func checkPermissions(cmd *cobra.Command) error {
+	townRoot, _ := workspace.FindFromCwd()
+	if townRoot == "" {
+		return nil
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town permissions: %w", err)
+	}
+	if len(settings.Permissions) == 0 {
+		return nil
+	}
+	// Invalid entries are still enforced as written: an unknown role or an
+	// empty list allows nobody, so a typo restricts rather than opens up
+	if err := config.ValidatePermissions(settings.Permissions); err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", style.Warning.Render("⚠"), err)
+	}
+
+	role := permissionRole()
+	beads.EnforcePermissions(settings.Permissions, role)
+	path := strings.TrimPrefix(buildCommandPath(cmd), cmd.Root().Name()+" ")
+	if haltCommands[path] {
+		if err := settings.Permissions.Check(config.OpHaltRig, role); err != nil {
+			return fmt.Errorf("'%s': %w", buildCommandPath(cmd), err)
+		}
+	}
+	return nil
+}
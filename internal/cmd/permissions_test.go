@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHaltCommandsExist(t *testing.T) {
+	for path := range haltCommands {
+		cmd, _, err := rootCmd.Find(strings.Fields(path))
+		if err != nil || buildCommandPath(cmd) != "gt "+path {
+			t.Errorf("halt command %q does not resolve to a command", path)
+		}
+	}
+}
+
+func TestPermissionRole(t *testing.T) {
+	t.Chdir(t.TempDir())
+	tests := []struct {
+		env  string
+		want string
+	}{
+		{"", "overseer"},
+		{"mayor", "mayor"},
+		{"gastown/witness", "witness"},
+		{"gastown/refinery", "refinery"},
+		{"gastown/polecats/Toast", "polecat"},
+		{"gastown/crew/max", "crew"},
+		{"boot", "boot"},
+	}
+	for _, tt := range tests {
+		t.Setenv(EnvGTRole, tt.env)
+		if got := permissionRole(); got != tt.want {
+			t.Errorf("GT_ROLE=%q: permissionRole() = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
@@ -137,6 +137,7 @@ var (
 	polecatNukeAll            bool
 	polecatNukeDryRun         bool
 	polecatNukeForce          bool
+	polecatNukeSuccessor      string
 	polecatCheckRecoveryJSON  bool
 )
 
@@ -170,6 +171,7 @@ This is the nuclear option for post-merge cleanup. It:
   2. Deletes the git worktree (bypassing all safety checks)
   3. Deletes the polecat branch
   4. Closes the agent bead (if exists)
+  5. Reassigns or releases anything still assigned to the polecat
 
 SAFETY CHECKS: The command refuses to nuke a polecat if:
   - Worktree has unpushed/uncommitted changes
@@ -184,7 +186,8 @@ Examples:
   gt polecat nuke greenplace/Toast greenplace/Furiosa
   gt polecat nuke greenplace --all
   gt polecat nuke greenplace --all --dry-run
-  gt polecat nuke greenplace/Toast --force  # bypass safety checks`,
+  gt polecat nuke greenplace/Toast --force  # bypass safety checks
+  gt polecat nuke greenplace/Toast --successor=greenplace/polecats/Nux`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runPolecatNuke,
 }
@@ -285,6 +288,7 @@ func init() {
 	polecatNukeCmd.Flags().BoolVar(&polecatNukeAll, "all", false, "Nuke all polecats in the rig")
 	polecatNukeCmd.Flags().BoolVar(&polecatNukeDryRun, "dry-run", false, "Show what would be nuked without doing it")
 	polecatNukeCmd.Flags().BoolVarP(&polecatNukeForce, "force", "f", false, "Force nuke, bypassing all safety checks (LOSES WORK)")
+	polecatNukeCmd.Flags().StringVar(&polecatNukeSuccessor, "successor", "", "Reassign the polecat's open work to this agent (default: release to pool)")
 
 	// Check-recovery flags
 	polecatCheckRecoveryCmd.Flags().BoolVar(&polecatCheckRecoveryJSON, "json", false, "Output as JSON")
@@ -614,22 +618,10 @@ func runPolecatSync(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		// Build sync command
-		syncArgs := []string{"sync"}
-		if polecatSyncFromMain {
-			syncArgs = append(syncArgs, "--from-main")
-		}
-
 		fmt.Printf("Syncing %s/%s...\n", rigName, name)
 
-		syncCmd := exec.Command("bd", syncArgs...)
-		syncCmd.Dir = polecatDir
-		output, err := syncCmd.CombinedOutput()
-		if err != nil {
+		if err := syncBeads(polecatDir, polecatSyncFromMain); err != nil {
 			syncErrors = append(syncErrors, fmt.Sprintf("%s: %v", name, err))
-			if len(output) > 0 {
-				fmt.Printf("  %s\n", style.Dim.Render(string(output)))
-			}
 		} else {
 			fmt.Printf("  %s\n", style.Success.Render("✓ synced"))
 		}
@@ -1312,7 +1304,7 @@ func runPolecatNuke(cmd *cobra.Command, args []string) error {
 
 			// Check 2: Open MR beads for this branch
 			if infoErr == nil && polecatInfo != nil && polecatInfo.Branch != "" {
-				mr, mrErr := bd.FindMRForBranch(polecatInfo.Branch)
+				mr, mrErr := bd.MRForBranch(polecatInfo.Branch)
 				if mrErr == nil && mr != nil {
 					reasons = append(reasons, fmt.Sprintf("has open MR (%s)", mr.ID))
 				}
@@ -1412,7 +1404,7 @@ func runPolecatNuke(cmd *cobra.Command, args []string) error {
 
 			// Check 2: Open MR
 			if infoErr == nil && polecatInfo != nil && polecatInfo.Branch != "" {
-				mr, mrErr := bd.FindMRForBranch(polecatInfo.Branch)
+				mr, mrErr := bd.MRForBranch(polecatInfo.Branch)
 				if mrErr == nil && mr != nil {
 					fmt.Printf("    - Open MR: %s (%s)\n", style.Error.Render("yes"), mr.ID)
 				} else {
@@ -1489,6 +1481,28 @@ func runPolecatNuke(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  %s closed agent bead %s\n", style.Success.Render("✓"), agentBeadID)
 		}
 
+		// Step 6: Redistribute remaining assignments so nothing is stranded.
+		// Mail lives in town beads, so go through the town router, acting
+		// as the rig's witness, which owns its polecats.
+		assignee := fmt.Sprintf("%s/polecats/%s", p.rigName, p.polecatName)
+		owner := fmt.Sprintf("%s/witness", p.rigName)
+		var report *beads.RetireReport
+		retireBd, err := beads.NewTownRouter(filepath.Dir(p.r.Path), beads.WithMiddleware(beads.Actor(owner)))
+		if err == nil {
+			report, err = beads.RetireAgentWith(retireBd, assignee, beads.RetirePolicy{Successor: polecatNukeSuccessor})
+		}
+		if err != nil {
+			fmt.Printf("  %s could not redistribute assignments: %v\n", style.Warning.Render("⚠"), err)
+		} else if len(report.Actions) > 0 {
+			fmt.Printf("  %s reassigned %d, released %d bead(s)\n", style.Success.Render("✓"),
+				report.Count(beads.RetireReassigned), report.Count(beads.RetireReleased))
+			for _, a := range report.Actions {
+				if a.Action == beads.RetireFailed {
+					fmt.Printf("    %s %s: %s\n", style.Warning.Render("⚠"), a.ID, a.Error)
+				}
+			}
+		}
+
 		nuked++
 	}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
@@ -45,6 +46,46 @@ type SlingSpawnOptions struct {
 // This is used by gt sling when the target is a rig name.
 // The caller (sling) handles hook attachment and nudging.
 func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolecatInfo, error) {
+	sr, err := loadSpawnRig(rigName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Allocate a new polecat name
+	polecatName, err := sr.polecats.AllocateName()
+	if err != nil {
+		return nil, fmt.Errorf("allocating polecat name: %w", err)
+	}
+	fmt.Printf("Allocated polecat: %s\n", polecatName)
+
+	info, err := sr.spawn(polecatName, opts, func(format string, args ...interface{}) {
+		fmt.Printf(format, args...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Log spawn event to activity feed
+	if info.SessionName != "" {
+		_ = events.Log(events.TypeSpawn, "gt", events.SpawnPayload(rigName, polecatName), events.VisibilityDefault)
+	}
+	return info, nil
+}
+
+// spawnRig is a rig loaded for spawning polecats into, shared by the
+// polecats of a spawn pool.
+type spawnRig struct {
+	townRoot string
+	rig      *rig.Rig
+	polecats *polecat.Manager
+
+	// worktreeMu serializes worktree creation: git worktree add on one
+	// repo takes repo-wide locks, so only sessions start in parallel.
+	worktreeMu sync.Mutex
+}
+
+// loadSpawnRig finds the workspace and loads rigName for spawning.
+func loadSpawnRig(rigName string) (*spawnRig, error) {
 	// Find workspace
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -67,46 +108,21 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 
 	// Get polecat manager
 	polecatGit := git.NewGit(r.Path)
-	polecatMgr := polecat.NewManager(r, polecatGit)
-
-	// Allocate a new polecat name
-	polecatName, err := polecatMgr.AllocateName()
-	if err != nil {
-		return nil, fmt.Errorf("allocating polecat name: %w", err)
-	}
-	fmt.Printf("Allocated polecat: %s\n", polecatName)
-
-	// Check if polecat already exists (shouldn't happen - indicates stale state needing repair)
-	existingPolecat, err := polecatMgr.Get(polecatName)
+	return &spawnRig{
+		townRoot: townRoot,
+		rig:      r,
+		polecats: polecat.NewManager(r, polecatGit),
+	}, nil
+}
 
-	// Build add options with hook_bead set atomically at spawn time
-	addOpts := polecat.AddOptions{
-		HookBead: opts.HookBead,
-	}
+// spawn creates the named polecat's worktree and, unless naked, starts its
+// session. Progress goes through logf, so a spawn pool can label each
+// polecat's lines. It is safe to call concurrently for distinct names.
+func (sr *spawnRig) spawn(polecatName string, opts SlingSpawnOptions, logf func(format string, args ...interface{})) (*SpawnedPolecatInfo, error) {
+	rigName, r, polecatMgr := sr.rig.Name, sr.rig, sr.polecats
 
-	if err == nil {
-		// Stale state: polecat exists despite fresh name allocation - repair it
-		// Check for uncommitted work first
-		if !opts.Force {
-			pGit := git.NewGit(existingPolecat.ClonePath)
-			workStatus, checkErr := pGit.CheckUncommittedWork()
-			if checkErr == nil && !workStatus.Clean() {
-				return nil, fmt.Errorf("polecat '%s' has uncommitted work: %s\nUse --force to proceed anyway",
-					polecatName, workStatus.String())
-			}
-		}
-		fmt.Printf("Repairing stale polecat %s with fresh worktree...\n", polecatName)
-		if _, err = polecatMgr.RepairWorktreeWithOptions(polecatName, opts.Force, addOpts); err != nil {
-			return nil, fmt.Errorf("repairing stale polecat: %w", err)
-		}
-	} else if err == polecat.ErrPolecatNotFound {
-		// Create new polecat
-		fmt.Printf("Creating polecat %s...\n", polecatName)
-		if _, err = polecatMgr.AddWithOptions(polecatName, addOpts); err != nil {
-			return nil, fmt.Errorf("creating polecat: %w", err)
-		}
-	} else {
-		return nil, fmt.Errorf("getting polecat: %w", err)
+	if err := sr.addWorktree(polecatName, opts, logf); err != nil {
+		return nil, err
 	}
 
 	// Get polecat object for path info
@@ -117,18 +133,18 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 
 	// Handle naked mode (no-tmux)
 	if opts.Naked {
-		fmt.Println()
-		fmt.Printf("%s\n", style.Bold.Render("🔧 NO-TMUX MODE (--naked)"))
-		fmt.Printf("Polecat created. Agent must be started manually.\n\n")
-		fmt.Printf("To start the agent:\n")
-		fmt.Printf("  cd %s\n", polecatObj.ClonePath)
+		logf("\n")
+		logf("%s\n", style.Bold.Render("🔧 NO-TMUX MODE (--naked)"))
+		logf("Polecat created. Agent must be started manually.\n\n")
+		logf("To start the agent:\n")
+		logf("  cd %s\n", polecatObj.ClonePath)
 		// Use rig's configured agent command, unless overridden.
 		agentCmd, err := config.GetRuntimeCommandWithAgentOverride(r.Path, opts.Agent)
 		if err != nil {
 			return nil, err
 		}
-		fmt.Printf("  %s\n\n", agentCmd)
-		fmt.Printf("Agent will discover work via gt prime on startup.\n")
+		logf("  %s\n\n", agentCmd)
+		logf("Agent will discover work via gt prime on startup.\n")
 
 		return &SpawnedPolecatInfo{
 			RigName:     rigName,
@@ -140,13 +156,13 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	}
 
 	// Resolve account for Claude config
-	accountsPath := constants.MayorAccountsPath(townRoot)
+	accountsPath := constants.MayorAccountsPath(sr.townRoot)
 	claudeConfigDir, accountHandle, err := config.ResolveAccountConfigDir(accountsPath, opts.Account)
 	if err != nil {
 		return nil, fmt.Errorf("resolving account: %w", err)
 	}
 	if accountHandle != "" {
-		fmt.Printf("Using account: %s\n", accountHandle)
+		logf("Using account: %s\n", accountHandle)
 	}
 
 	// Start session
@@ -156,7 +172,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	// Check if already running
 	running, _ := polecatSessMgr.IsRunning(polecatName)
 	if !running {
-		fmt.Printf("Starting session for %s/%s...\n", rigName, polecatName)
+		logf("Starting session for %s/%s...\n", rigName, polecatName)
 		startOpts := polecat.SessionStartOptions{
 			ClaudeConfigDir: claudeConfigDir,
 		}
@@ -179,10 +195,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		return nil, fmt.Errorf("getting pane for %s: %w", sessionName, err)
 	}
 
-	fmt.Printf("%s Polecat %s spawned\n", style.Bold.Render("✓"), polecatName)
-
-	// Log spawn event to activity feed
-	_ = events.LogFeed(events.TypeSpawn, "gt", events.SpawnPayload(rigName, polecatName))
+	logf("%s Polecat %s spawned\n", style.Bold.Render("✓"), polecatName)
 
 	return &SpawnedPolecatInfo{
 		RigName:     rigName,
@@ -193,6 +206,48 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	}, nil
 }
 
+// addWorktree creates the polecat's worktree, or repairs it if the polecat
+// unexpectedly exists already.
+func (sr *spawnRig) addWorktree(polecatName string, opts SlingSpawnOptions, logf func(format string, args ...interface{})) error {
+	sr.worktreeMu.Lock()
+	defer sr.worktreeMu.Unlock()
+	polecatMgr := sr.polecats
+
+	// Check if polecat already exists (shouldn't happen - indicates stale state needing repair)
+	existingPolecat, err := polecatMgr.Get(polecatName)
+
+	// Build add options with hook_bead set atomically at spawn time
+	addOpts := polecat.AddOptions{
+		HookBead: opts.HookBead,
+	}
+
+	if err == nil {
+		// Stale state: polecat exists despite fresh name allocation - repair it
+		// Check for uncommitted work first
+		if !opts.Force {
+			pGit := git.NewGit(existingPolecat.ClonePath)
+			workStatus, checkErr := pGit.CheckUncommittedWork()
+			if checkErr == nil && !workStatus.Clean() {
+				return fmt.Errorf("polecat '%s' has uncommitted work: %s\nUse --force to proceed anyway",
+					polecatName, workStatus.String())
+			}
+		}
+		logf("Repairing stale polecat %s with fresh worktree...\n", polecatName)
+		if _, err = polecatMgr.RepairWorktreeWithOptions(polecatName, opts.Force, addOpts); err != nil {
+			return fmt.Errorf("repairing stale polecat: %w", err)
+		}
+	} else if err == polecat.ErrPolecatNotFound {
+		// Create new polecat
+		logf("Creating polecat %s...\n", polecatName)
+		if _, err = polecatMgr.AddWithOptions(polecatName, addOpts); err != nil {
+			return fmt.Errorf("creating polecat: %w", err)
+		}
+	} else {
+		return fmt.Errorf("getting polecat: %w", err)
+	}
+	return nil
+}
+
 // IsRigName checks if a target string is a rig name (not a role or path).
 // Returns the rig name and true if it's a valid rig.
 func IsRigName(target string) (string, bool) {
@@ -509,13 +509,15 @@ func outputHandoffContent(ctx RoleContext) {
 		// No handoff content
 		return
 	}
+	// Retain the content this session starts from, for gt handoff diff
+	_ = bd.RecordHandoffVersion(roleKey)
 
 	// Display handoff content
 	fmt.Println()
 	fmt.Printf("%s\n\n", style.Bold.Render("## 🤝 Handoff from Previous Session"))
 	fmt.Println(issue.Description)
 	fmt.Println()
-	fmt.Println(style.Dim.Render("(Clear with: gt rig reset --handoff)"))
+	fmt.Println(style.Dim.Render("(What changed since the last handoff: gt handoff diff; clear with: gt rig reset --handoff)"))
 }
 
 // runBdPrime runs `bd prime` and outputs the result.
@@ -1433,7 +1435,7 @@ func emitSessionEvent(ctx RoleContext) {
 
 	// Emit the event
 	payload := events.SessionPayload(sessionID, actor, topic, ctx.WorkDir)
-	_ = events.LogFeed(events.TypeSessionStart, actor, payload)
+	_ = events.Log(events.TypeSessionStart, actor, payload, events.VisibilityDefault)
 }
 
 // outputSessionMetadata prints a structured metadata line for seance discovery.
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Priority command flags
+var (
+	priorityDryRun bool
+	priorityJSON   bool
+)
+
+var priorityCmd = &cobra.Command{
+	Use:     "priority <epic-id> <priority>",
+	GroupID: GroupWork,
+	Short:   "Reprioritize an epic and all its open descendants",
+	Long: `Set the priority of an epic and every open issue beneath it.
+
+Priority may be a number (0-4), P0-P4, or a level name from the town's
+priority settings (e.g. critical, high, medium, low, backlog).
+
+Descendants labeled "pinned-priority" keep their current priority; their
+children are still updated.
+
+Examples:
+  gt priority gt-epic1 high            # Raise the whole initiative
+  gt priority gt-epic1 P3 --dry-run    # Preview the changes`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPriority,
+}
+
+var priorityAgeCmd = &cobra.Command{
+	Use:   "age",
+	Short: "Raise the priority of open beads left untouched too long",
+	Long: `Raise by one level every open bead that has sat untouched longer than
+its priority's aging threshold, and leave a comment explaining why.
+
+Thresholds come from the "aging" map in the town's priority settings, e.g.
+  "priorities": {"aging": {"backlog": "30d", "low": "14d"}}
+Without one, backlog beads age after 30 days and low beads after 14.
+Beads labeled "pinned-priority" never age.
+
+Examples:
+  gt priority age              # Age stale beads in the current rig
+  gt priority age --dry-run    # Preview the changes`,
+	Args: cobra.NoArgs,
+	RunE: runPriorityAge,
+}
+
+func init() {
+	priorityCmd.Flags().BoolVarP(&priorityDryRun, "dry-run", "n", false, "Show what would change without updating")
+	priorityCmd.Flags().BoolVar(&priorityJSON, "json", false, "Output as JSON")
+	priorityAgeCmd.Flags().BoolVarP(&priorityDryRun, "dry-run", "n", false, "Show what would change without updating")
+	priorityAgeCmd.Flags().BoolVar(&priorityJSON, "json", false, "Output as JSON")
+	priorityCmd.AddCommand(priorityAgeCmd)
+	rootCmd.AddCommand(priorityCmd)
+}
+
+func runPriority(cmd *cobra.Command, args []string) error {
+	epicID := args[0]
+
+	var levels *config.PriorityConfig
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+			levels = settings.Priorities
+		}
+	}
+	priority, ok := levels.Resolve(args[1])
+	if !ok {
+		return fmt.Errorf("invalid priority %q: want 0-4, P0-P4, or a level name", args[1])
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+
+	result, err := beads.New(cwd).PropagatePriority(epicID, priority, priorityDryRun)
+	if err != nil {
+		return err
+	}
+
+	if priorityJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	verb := "Updated"
+	if result.DryRun {
+		verb = "Would update"
+	}
+	for _, c := range result.Changed {
+		fmt.Printf("  %s %s P%d → P%d  %s\n", style.Bold.Render("✓"), c.ID, c.From, c.To, style.Dim.Render(c.Title))
+	}
+	for _, c := range result.Pinned {
+		fmt.Printf("  %s %s P%d (pinned)  %s\n", style.Dim.Render("○"), c.ID, c.From, style.Dim.Render(c.Title))
+	}
+	for _, id := range result.Failed {
+		fmt.Printf("  %s %s failed\n", style.Dim.Render("✗"), id)
+	}
+	fmt.Printf("\n%s %d issue(s) to %s; %d pinned, %d already at target\n",
+		verb, len(result.Changed), levels.Name(priority), len(result.Pinned), result.Unchanged)
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d issue(s) failed to update", len(result.Failed))
+	}
+	return nil
+}
+
+func runPriorityAge(cmd *cobra.Command, args []string) error {
+	var levels *config.PriorityConfig
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+			levels = settings.Priorities
+		}
+	}
+	policy := beads.DefaultAgingPolicy()
+	thresholds, err := levels.AgingThresholds()
+	if err != nil {
+		return err
+	}
+	if thresholds != nil {
+		policy.After = thresholds
+	}
+	policy.DryRun = priorityDryRun
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+
+	result, err := beads.New(cwd).AgePriorities(policy)
+	if err != nil {
+		return err
+	}
+
+	if priorityJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	verb := "Aged"
+	if result.DryRun {
+		verb = "Would age"
+	}
+	for _, c := range result.Aged {
+		fmt.Printf("  %s %s %s → %s  %s\n", style.Bold.Render("✓"), c.ID, levels.Name(c.From), levels.Name(c.To), style.Dim.Render(c.Title))
+	}
+	for _, id := range result.Failed {
+		fmt.Printf("  %s %s failed\n", style.Dim.Render("✗"), id)
+	}
+	fmt.Printf("\n%s %d issue(s)\n", verb, len(result.Aged))
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d issue(s) failed to update", len(result.Failed))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/quality"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Quality command flags
+var (
+	qualitySince  string
+	qualityByEpic bool
+	qualityJSON   bool
+)
+
+var qualityCmd = &cobra.Command{
+	Use:     "quality",
+	GroupID: GroupDiag,
+	Short:   "Show rework and bounce signals per agent or epic",
+	Long: `Show quality signals derived from the events log.
+
+Tracks rework per agent (or per epic with --by-epic):
+  - MRs rejected by the refinery (merge_failed)
+  - Work reopened by the refinery for rework (reopened)
+  - MRs sent back with gt mq reject (changes_requested)
+  - Bounces (a bead re-slung to a different agent)
+
+Examples:
+  gt quality                # Rework by agent
+  gt quality --by-epic      # Rework by epic
+  gt quality --since=7d     # Last week only
+  gt quality --json         # Output as JSON`,
+	RunE: runQuality,
+}
+
+func init() {
+	qualityCmd.Flags().StringVar(&qualitySince, "since", "", "Only consider events since duration (e.g., 24h, 7d)")
+	qualityCmd.Flags().BoolVar(&qualityByEpic, "by-epic", false, "Group signals by epic instead of agent")
+	qualityCmd.Flags().BoolVar(&qualityJSON, "json", false, "Output as JSON")
+
+	rootCmd.AddCommand(qualityCmd)
+}
+
+func runQuality(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var since time.Time
+	if qualitySince != "" {
+		d, err := parseDuration(qualitySince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	report, err := quality.Load(townRoot, since)
+	if err != nil {
+		return fmt.Errorf("loading events: %w", err)
+	}
+
+	if qualityJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	signals := report.ByActor
+	names := report.ActorNames()
+	label := "AGENT"
+	if qualityByEpic {
+		signals = report.ByEpic
+		names = report.EpicNames()
+		label = "EPIC"
+	}
+
+	if len(names) == 0 {
+		fmt.Printf("%s No quality signals recorded\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	table := style.NewTable(
+		style.Column{Name: label, Width: 28},
+		style.Column{Name: "DONE", Width: 5, Align: style.AlignRight},
+		style.Column{Name: "REJECTED", Width: 9, Align: style.AlignRight},
+		style.Column{Name: "REOPENED", Width: 9, Align: style.AlignRight},
+		style.Column{Name: "CHANGES", Width: 8, Align: style.AlignRight},
+		style.Column{Name: "BOUNCES", Width: 8, Align: style.AlignRight},
+		style.Column{Name: "RATE", Width: 6, Align: style.AlignRight},
+	)
+	for _, name := range names {
+		s := signals[name]
+		table.AddRow(
+			name,
+			fmt.Sprintf("%d", s.Done),
+			fmt.Sprintf("%d", s.MRsRejected),
+			fmt.Sprintf("%d", s.Reopened),
+			fmt.Sprintf("%d", s.ChangesRequested),
+			fmt.Sprintf("%d", s.Bounces),
+			fmt.Sprintf("%.2f", s.ReworkRate()),
+		)
+	}
+	fmt.Print(table.Render())
+	return nil
+}
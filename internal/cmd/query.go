@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Query command flags
+var queryJSON bool
+
+var queryCmd = &cobra.Command{
+	Use:     "query [name]",
+	GroupID: GroupWork,
+	Short:   "Run a saved issue query",
+	Long: `Run a named issue filter saved in the town settings.
+
+Saved queries are declared in a town spec and installed with gt apply
+(see "gt apply --help"). With no name, lists the saved queries.
+
+Examples:
+  gt query
+  gt query triage --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runQuery,
+}
+
+func init() {
+	queryCmd.Flags().BoolVar(&queryJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(queryCmd)
+}
+
+func runQuery(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+
+	if len(args) == 0 {
+		return listSavedQueries(settings.Queries)
+	}
+	q, ok := settings.Queries[args[0]]
+	if !ok {
+		return fmt.Errorf("no saved query %q (run 'gt query' to list them)", args[0])
+	}
+
+	workDir := townRoot
+	if q.Rig != "" {
+		workDir = filepath.Join(townRoot, q.Rig, "mayor", "rig")
+	}
+	opts := beads.ListOptions{
+		Status:   q.Status,
+		Type:     q.Type,
+		Priority: -1,
+		Assignee: q.Assignee,
+		Label:    q.Label,
+		Limit:    q.Limit,
+	}
+	if q.Priority != nil {
+		opts.Priority = *q.Priority
+	}
+	issues, err := beads.New(workDir).List(opts)
+	if err != nil {
+		return fmt.Errorf("running query %s: %w", args[0], err)
+	}
+
+	if queryJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(issues)
+	}
+	if len(issues) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No matching issues"))
+		return nil
+	}
+	for _, issue := range issues {
+		fmt.Printf("  %s  P%d  %-11s %s\n", style.Bold.Render(issue.ID), issue.Priority, issue.Status, issue.Title)
+	}
+	return nil
+}
+
+func listSavedQueries(queries map[string]*config.SavedQuery) error {
+	if queryJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(queries)
+	}
+	if len(queries) == 0 {
+		fmt.Println("No saved queries.")
+		fmt.Printf("\nDeclare them in a town spec: %s\n", style.Dim.Render("gt apply --help"))
+		return nil
+	}
+	names := make([]string, 0, len(queries))
+	for name := range queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %-16s %s\n", style.Bold.Render(name), style.Dim.Render(queries[name].Description))
+	}
+	return nil
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/steveyegge/gastown/internal/style"
 )
 
-var releaseReason string
+var (
+	releaseReason  string
+	releaseHistory bool
+)
 
 var releaseCmd = &cobra.Command{
 	Use:     "release <issue-id>...",
@@ -25,6 +28,7 @@ Examples:
   gt release gt-abc           # Release single issue
   gt release gt-abc gt-def    # Release multiple issues
   gt release gt-abc -r "worker died"  # Release with reason
+  gt release --history gt-abc         # Show past releases (bounce detection)
 
 This implements nondeterministic idempotence - work can be safely
 retried by releasing and reclaiming stuck steps.`,
@@ -33,7 +37,8 @@ retried by releasing and reclaiming stuck steps.`,
 }
 
 func init() {
-	releaseCmd.Flags().StringVarP(&releaseReason, "reason", "r", "", "Reason for releasing (added as note)")
+	releaseCmd.Flags().StringVarP(&releaseReason, "reason", "r", "", "Reason for releasing (added as note and release record)")
+	releaseCmd.Flags().BoolVar(&releaseHistory, "history", false, "Show release history instead of releasing")
 	rootCmd.AddCommand(releaseCmd)
 }
 
@@ -46,6 +51,10 @@ func runRelease(cmd *cobra.Command, args []string) error {
 
 	bd := beads.New(cwd)
 
+	if releaseHistory {
+		return showReleaseHistory(bd, args)
+	}
+
 	// Release each issue
 	var released, failed int
 	for _, id := range args {
@@ -76,3 +85,29 @@ func runRelease(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// showReleaseHistory prints past releases for each issue.
+func showReleaseHistory(bd *beads.Beads, ids []string) error {
+	for _, id := range ids {
+		hist, err := bd.ReleaseHistory(id)
+		if err != nil {
+			return fmt.Errorf("reading release history: %w", err)
+		}
+		fmt.Printf("%s %s: released %d time(s)\n", style.Bold.Render("●"), id, len(hist))
+		for _, rec := range hist {
+			from := rec.PriorAssignee
+			if from == "" {
+				from = "(unassigned)"
+			}
+			line := fmt.Sprintf("  %s  from %s", rec.Timestamp, from)
+			if rec.ReleasedBy != "" {
+				line += " by " + rec.ReleasedBy
+			}
+			if rec.Reason != "" {
+				line += style.Dim.Render(" — " + rec.Reason)
+			}
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
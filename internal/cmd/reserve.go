@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Reserve command flags
+var (
+	reserveTTL    time.Duration
+	reserveList   bool
+	reserveCancel bool
+	reserveExpire bool
+	reserveJSON   bool
+)
+
+var reserveCmd = &cobra.Command{
+	Use:     "reserve [bead-id] [agent]",
+	GroupID: GroupWork,
+	Short:   "Soft-reserve an agent's next bead",
+	Long: `Tentatively reserve a bead as an agent's next work.
+
+A reservation is not a claim: the bead stays open and unassigned, but it
+shows as "next" in gt status and other agents can't reserve it. The agent
+can read ahead while finishing its current task. When the agent runs
+'gt done', the reservation converts to a claim (in_progress, assigned) in
+a single update. Reservations that aren't claimed expire after --ttl.
+
+Each agent holds at most one reservation; reserving another bead replaces it.
+
+Examples:
+  gt reserve gt-abc gastown/polecats/Toast
+  gt reserve gt-abc gastown/polecats/Toast --ttl 4h
+  gt reserve --list
+  gt reserve --cancel gt-abc
+  gt reserve --expire          # Clear lapsed reservations`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: runReserve,
+}
+
+func init() {
+	reserveCmd.Flags().DurationVar(&reserveTTL, "ttl", beads.DefaultReservationTTL, "How long the reservation lasts if not claimed")
+	reserveCmd.Flags().BoolVar(&reserveList, "list", false, "List reservations (optionally for one agent)")
+	reserveCmd.Flags().BoolVar(&reserveCancel, "cancel", false, "Cancel the reservation on a bead")
+	reserveCmd.Flags().BoolVar(&reserveExpire, "expire", false, "Clear reservations that have lapsed")
+	reserveCmd.Flags().BoolVar(&reserveJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(reserveCmd)
+}
+
+func runReserve(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+	bd := beads.New(cwd)
+
+	switch {
+	case reserveList:
+		agent := ""
+		if len(args) > 0 {
+			agent = args[0]
+		}
+		reservations, err := bd.Reservations(agent)
+		if err != nil {
+			return err
+		}
+		return printReservations(reservations)
+
+	case reserveExpire:
+		expired, err := bd.ExpireReservations()
+		if err != nil {
+			return err
+		}
+		if reserveJSON {
+			return printReservations(expired)
+		}
+		fmt.Printf("%s Cleared %d expired reservation(s)\n", style.Success.Render("✓"), len(expired))
+		return nil
+
+	case reserveCancel:
+		if len(args) != 1 {
+			return fmt.Errorf("--cancel takes one bead ID")
+		}
+		if err := bd.CancelReservation(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("%s Cancelled reservation on %s\n", style.Success.Render("✓"), args[0])
+		return nil
+	}
+
+	if len(args) != 2 {
+		return fmt.Errorf("usage: gt reserve <bead-id> <agent>")
+	}
+	r, err := bd.Reserve(args[0], args[1], reserveTTL)
+	if err != nil {
+		return err
+	}
+	if reserveJSON {
+		return printReservations([]beads.Reservation{*r})
+	}
+	fmt.Printf("%s Reserved %s for %s until %s\n", style.Success.Render("✓"),
+		style.Bold.Render(r.IssueID), r.Agent, r.Expires.Local().Format("15:04"))
+	return nil
+}
+
+func printReservations(reservations []beads.Reservation) error {
+	if reserveJSON {
+		if reservations == nil {
+			reservations = []beads.Reservation{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reservations)
+	}
+	if len(reservations) == 0 {
+		fmt.Println(style.Dim.Render("No reservations"))
+		return nil
+	}
+	now := time.Now()
+	for _, r := range reservations {
+		expiry := "expires in " + r.Expires.Sub(now).Round(time.Minute).String()
+		if r.Expired(now) {
+			expiry = "expired"
+		}
+		fmt.Printf("  %s  %-30s %s  %s\n", style.Bold.Render(r.IssueID), r.Agent,
+			truncateWithEllipsis(r.Title, 40), style.Dim.Render(expiry))
+	}
+	return nil
+}
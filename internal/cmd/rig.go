@@ -105,6 +105,10 @@ This is the inverse of 'gt rig shutdown'. It starts:
 - The witness (if not already running)
 - The refinery (if not already running)
 
+Agents start in dependency order, the refinery once the witness is up.
+The order and how long to wait for each agent to be ready are set by
+"boot" in the rig's settings/config.json (see 'gt up').
+
 Polecats are NOT started by this command - they are spawned
 on demand when work is assigned.
 
@@ -124,6 +128,8 @@ For each rig, it starts:
 - The witness (if not already running)
 - The refinery (if not already running)
 
+As with 'gt rig boot', agents start in the rig's boot order.
+
 Polecats are NOT started by this command - they are spawned
 on demand when work is assigned.
 
@@ -305,20 +311,33 @@ func init() {
 	rigRestartCmd.Flags().BoolVar(&rigRestartNuclear, "nuclear", false, "DANGER: Bypass ALL safety checks (loses uncommitted work!)")
 }
 
-func runRigAdd(cmd *cobra.Command, args []string) error {
-	name := args[0]
-	gitURL := args[1]
-
-	// Ensure beads (bd) is available before proceeding
-	if err := deps.EnsureBeads(true); err != nil {
-		return fmt.Errorf("beads dependency check failed: %w", err)
-	}
+// rigAddOptions are the gt rig add flags, so other commands (gt setup,
+// gt apply) can add a rig without setting them.
+type rigAddOptions struct {
+	Prefix    string // Beads issue prefix (default: derived from name)
+	LocalRepo string // Local repository to share git objects from
+	Branch    string // Default branch (default: auto-detected from remote)
+}
 
+func runRigAdd(cmd *cobra.Command, args []string) error {
 	// Find workspace
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
+	return addRig(townRoot, args[0], args[1], rigAddOptions{
+		Prefix:    rigAddPrefix,
+		LocalRepo: rigAddLocalRepo,
+		Branch:    rigAddBranch,
+	})
+}
+
+// addRig adds the rig name, cloned from gitURL, to the town at townRoot.
+func addRig(townRoot, name, gitURL string, opts rigAddOptions) error {
+	// Ensure beads (bd) is available before proceeding
+	if err := deps.EnsureBeads(true); err != nil {
+		return fmt.Errorf("beads dependency check failed: %w", err)
+	}
 
 	// Load rigs config
 	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
@@ -337,8 +356,8 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Creating rig %s...\n", style.Bold.Render(name))
 	fmt.Printf("  Repository: %s\n", gitURL)
-	if rigAddLocalRepo != "" {
-		fmt.Printf("  Local repo: %s\n", rigAddLocalRepo)
+	if opts.LocalRepo != "" {
+		fmt.Printf("  Local repo: %s\n", opts.LocalRepo)
 	}
 
 	startTime := time.Now()
@@ -347,9 +366,9 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 	newRig, err := mgr.AddRig(rig.AddRigOptions{
 		Name:          name,
 		GitURL:        gitURL,
-		BeadsPrefix:   rigAddPrefix,
-		LocalRepo:     rigAddLocalRepo,
-		DefaultBranch: rigAddBranch,
+		BeadsPrefix:   opts.Prefix,
+		LocalRepo:     opts.LocalRepo,
+		DefaultBranch: opts.Branch,
 	})
 	if err != nil {
 		return fmt.Errorf("adding rig: %w", err)
@@ -748,41 +767,24 @@ func runRigBoot(cmd *cobra.Command, args []string) error {
 	var started []string
 	var skipped []string
 
+	// Start the witness, then the refinery, in the order the rig's boot
+	// config sets, each stage ready before the next
 	t := tmux.NewTmux()
-
-	// 1. Start the witness
-	// Check actual tmux session, not state file (may be stale)
-	witnessSession := fmt.Sprintf("gt-%s-witness", rigName)
-	witnessRunning, _ := t.HasSession(witnessSession)
-	if witnessRunning {
-		skipped = append(skipped, "witness (already running)")
-	} else {
-		fmt.Printf("  Starting witness...\n")
-		witMgr := witness.NewManager(r)
-		if err := witMgr.Start(false); err != nil {
-			if err == witness.ErrAlreadyRunning {
-				skipped = append(skipped, "witness (already running)")
-			} else {
-				return fmt.Errorf("starting witness: %w", err)
-			}
-		} else {
-			started = append(started, "witness")
-		}
+	outcomes, err := bootRig(t, townRoot, r, rigAgents, func(agent string) {
+		fmt.Printf("  Starting %s...\n", agent)
+	})
+	if err != nil {
+		return err
 	}
-
-	// 2. Start the refinery
-	// Check actual tmux session, not state file (may be stale)
-	refinerySession := fmt.Sprintf("gt-%s-refinery", rigName)
-	refineryRunning, _ := t.HasSession(refinerySession)
-	if refineryRunning {
-		skipped = append(skipped, "refinery (already running)")
-	} else {
-		fmt.Printf("  Starting refinery...\n")
-		refMgr := refinery.NewManager(r)
-		if err := refMgr.Start(false); err != nil { // false = background mode
-			return fmt.Errorf("starting refinery: %w", err)
+	for _, out := range outcomes {
+		switch {
+		case out.Err != nil:
+			return fmt.Errorf("starting %s: %w", out.Agent, out.Err)
+		case out.AlreadyRunning:
+			skipped = append(skipped, out.Agent+" (already running)")
+		default:
+			started = append(started, out.Agent)
 		}
-		started = append(started, "refinery")
 	}
 
 	// Report results
@@ -831,39 +833,22 @@ func runRigStart(cmd *cobra.Command, args []string) error {
 		var skipped []string
 		hasError := false
 
-		// 1. Start the witness
-		witnessSession := fmt.Sprintf("gt-%s-witness", rigName)
-		witnessRunning, _ := t.HasSession(witnessSession)
-		if witnessRunning {
-			skipped = append(skipped, "witness")
-		} else {
-			fmt.Printf("  Starting witness...\n")
-			witMgr := witness.NewManager(r)
-			if err := witMgr.Start(false); err != nil {
-				if err == witness.ErrAlreadyRunning {
-					skipped = append(skipped, "witness")
-				} else {
-					fmt.Printf("  %s Failed to start witness: %v\n", style.Warning.Render("⚠"), err)
-					hasError = true
-				}
-			} else {
-				started = append(started, "witness")
-			}
+		outcomes, err := bootRig(t, townRoot, r, rigAgents, func(agent string) {
+			fmt.Printf("  Starting %s...\n", agent)
+		})
+		if err != nil {
+			fmt.Printf("  %s %v\n", style.Warning.Render("⚠"), err)
+			hasError = true
 		}
-
-		// 2. Start the refinery
-		refinerySession := fmt.Sprintf("gt-%s-refinery", rigName)
-		refineryRunning, _ := t.HasSession(refinerySession)
-		if refineryRunning {
-			skipped = append(skipped, "refinery")
-		} else {
-			fmt.Printf("  Starting refinery...\n")
-			refMgr := refinery.NewManager(r)
-			if err := refMgr.Start(false); err != nil {
-				fmt.Printf("  %s Failed to start refinery: %v\n", style.Warning.Render("⚠"), err)
+		for _, out := range outcomes {
+			switch {
+			case out.Err != nil:
+				fmt.Printf("  %s Failed to start %s: %v\n", style.Warning.Render("⚠"), out.Agent, out.Err)
 				hasError = true
-			} else {
-				started = append(started, "refinery")
+			case out.AlreadyRunning:
+				skipped = append(skipped, out.Agent)
+			default:
+				started = append(started, out.Agent)
 			}
 		}
 
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// bootReadyPoll is how often a staged boot rechecks a session's readiness.
+const bootReadyPoll = 500 * time.Millisecond
+
+// rigAgents are the agents gt rig boot and gt rig start bring up; crew
+// and polecats join them under gt up --restore.
+var rigAgents = []string{config.BootAgentWitness, config.BootAgentRefinery}
+
+// bootStarter starts one kind of rig agent. It returns the sessions the
+// agent runs in, for the readiness check before dependents start, and
+// whether it was already running.
+type bootStarter func() (sessions []string, alreadyRunning bool, err error)
+
+// bootOutcome is how one agent fared in a staged rig boot.
+type bootOutcome struct {
+	Agent          string
+	Sessions       []string
+	AlreadyRunning bool
+	Err            error // Start failed, not ready in time, or a dependency wasn't
+}
+
+// bootStaged starts agents in the stages cfg orders them in. After each
+// stage it waits, up to cfg's ready timeout, for the stage's sessions to
+// be ready; an agent whose dependency failed or never became ready is not
+// started. onStart is called before each start. Outcomes are returned in
+// boot order.
+func bootStaged(cfg *config.BootConfig, agents []string, starters map[string]bootStarter,
+	ready func(session string, deadline time.Time) bool, onStart func(agent string)) ([]bootOutcome, error) {
+	stages, err := cfg.Stages(agents)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := cfg.ReadyTimeoutDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	failed := make(map[string]error)
+	var outcomes []bootOutcome
+	for _, stage := range stages {
+		first := len(outcomes)
+		for _, agent := range stage {
+			out := bootOutcome{Agent: agent}
+			for _, dep := range cfg.AfterFor(agent) {
+				if depErr, ok := failed[dep]; ok {
+					out.Err = fmt.Errorf("not started: %s failed (%v)", dep, depErr)
+					break
+				}
+			}
+			if out.Err == nil {
+				onStart(agent)
+				out.Sessions, out.AlreadyRunning, out.Err = starters[agent]()
+			}
+			if out.Err != nil {
+				failed[agent] = out.Err
+			}
+			outcomes = append(outcomes, out)
+		}
+
+		// Readiness gate: dependents start only once this stage is up
+		deadline := time.Now().Add(timeout)
+		for i := first; i < len(outcomes); i++ {
+			out := &outcomes[i]
+			if out.Err != nil {
+				continue
+			}
+			for _, sess := range out.Sessions {
+				if !ready(sess, deadline) {
+					out.Err = fmt.Errorf("%s not ready after %s", sess, timeout)
+					failed[out.Agent] = out.Err
+					break
+				}
+			}
+		}
+	}
+	return outcomes, nil
+}
+
+// waitAgentReady polls until an agent is running in the session, or the
+// deadline passes.
+func waitAgentReady(t *tmux.Tmux, sess string, deadline time.Time) bool {
+	for {
+		if t.IsAgentRunning(sess) {
+			return true
+		}
+		if !time.Now().Before(deadline) {
+			return false
+		}
+		time.Sleep(bootReadyPoll)
+	}
+}
+
+// bootRig starts a rig's agents in dependency order (see config.BootConfig),
+// waiting for each stage to be ready before the next.
+func bootRig(t *tmux.Tmux, townRoot string, r *rig.Rig, agents []string, onStart func(agent string)) ([]bootOutcome, error) {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(r.Path))
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return nil, fmt.Errorf("loading %s settings: %w", r.Name, err)
+	}
+	var cfg *config.BootConfig
+	if settings != nil {
+		cfg = settings.Boot
+	}
+
+	starters := map[string]bootStarter{
+		config.BootAgentWitness: func() ([]string, bool, error) {
+			sess := session.WitnessSessionName(r.Name)
+			if running, _ := t.HasSession(sess); running {
+				return []string{sess}, true, nil
+			}
+			if err := witness.NewManager(r).Start(false); err != nil {
+				if err == witness.ErrAlreadyRunning {
+					return []string{sess}, true, nil
+				}
+				return nil, false, err
+			}
+			return []string{sess}, false, nil
+		},
+		config.BootAgentRefinery: func() ([]string, bool, error) {
+			sess := session.RefinerySessionName(r.Name)
+			if running, _ := t.HasSession(sess); running {
+				return []string{sess}, true, nil
+			}
+			if err := refinery.NewManager(r).Start(false); err != nil { // false = background mode
+				if err == refinery.ErrAlreadyRunning {
+					return []string{sess}, true, nil
+				}
+				return nil, false, err
+			}
+			return []string{sess}, false, nil
+		},
+		config.BootAgentCrew: func() ([]string, bool, error) {
+			names, errs := startCrewFromSettings(townRoot, r.Name)
+			return bootSessions(names, errs, func(name string) string { return session.CrewSessionName(r.Name, name) })
+		},
+		config.BootAgentPolecats: func() ([]string, bool, error) {
+			names, errs := startPolecatsWithWork(townRoot, r.Name)
+			return bootSessions(names, errs, func(name string) string { return session.PolecatSessionName(r.Name, name) })
+		},
+	}
+
+	return bootStaged(cfg, agents, starters, func(sess string, deadline time.Time) bool {
+		return waitAgentReady(t, sess, deadline)
+	}, onStart)
+}
+
+// bootSessions turns the members a group start (crew, polecats) started
+// and failed to start into a bootStarter result.
+func bootSessions(started []string, errs map[string]error, sessionName func(string) string) ([]string, bool, error) {
+	sessions := make([]string, 0, len(started))
+	for _, name := range started {
+		sessions = append(sessions, sessionName(name))
+	}
+	if len(errs) == 0 {
+		return sessions, false, nil
+	}
+	var msgs []string
+	for name, err := range errs {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", name, err))
+	}
+	sort.Strings(msgs)
+	return sessions, false, errors.New(strings.Join(msgs, "; "))
+}
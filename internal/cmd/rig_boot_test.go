@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestBootStaged(t *testing.T) {
+	var order []string
+	starter := func(agent string, err error) bootStarter {
+		return func() ([]string, bool, error) {
+			order = append(order, agent)
+			return []string{"gt-rig-" + agent}, false, err
+		}
+	}
+	readyAll := func(string, time.Time) bool { return true }
+
+	t.Run("starts in dependency order", func(t *testing.T) {
+		order = nil
+		starters := map[string]bootStarter{
+			config.BootAgentPolecats: starter(config.BootAgentPolecats, nil),
+			config.BootAgentRefinery: starter(config.BootAgentRefinery, nil),
+			config.BootAgentWitness:  starter(config.BootAgentWitness, nil),
+		}
+		agents := []string{config.BootAgentPolecats, config.BootAgentRefinery, config.BootAgentWitness}
+		outcomes, err := bootStaged(nil, agents, starters, readyAll, func(string) {})
+		if err != nil {
+			t.Fatalf("bootStaged: %v", err)
+		}
+		want := "witness,refinery,polecats"
+		if got := strings.Join(order, ","); got != want {
+			t.Errorf("start order = %s, want %s", got, want)
+		}
+		for _, out := range outcomes {
+			if out.Err != nil {
+				t.Errorf("%s: unexpected error %v", out.Agent, out.Err)
+			}
+		}
+	})
+
+	t.Run("skips dependents of a failed agent", func(t *testing.T) {
+		order = nil
+		starters := map[string]bootStarter{
+			config.BootAgentWitness:  starter(config.BootAgentWitness, errors.New("boom")),
+			config.BootAgentRefinery: starter(config.BootAgentRefinery, nil),
+			config.BootAgentCrew:     starter(config.BootAgentCrew, nil),
+		}
+		agents := []string{config.BootAgentWitness, config.BootAgentRefinery, config.BootAgentCrew}
+		outcomes, err := bootStaged(nil, agents, starters, readyAll, func(string) {})
+		if err != nil {
+			t.Fatalf("bootStaged: %v", err)
+		}
+		if got := strings.Join(order, ","); got != "witness,crew" {
+			t.Errorf("start order = %s, want witness,crew", got)
+		}
+		for _, out := range outcomes {
+			if out.Agent == config.BootAgentRefinery && (out.Err == nil || !strings.Contains(out.Err.Error(), "witness failed")) {
+				t.Errorf("refinery error = %v, want not started after witness failed", out.Err)
+			}
+		}
+	})
+
+	t.Run("waits for readiness between stages", func(t *testing.T) {
+		order = nil
+		starters := map[string]bootStarter{
+			config.BootAgentWitness:  starter(config.BootAgentWitness, nil),
+			config.BootAgentRefinery: starter(config.BootAgentRefinery, nil),
+		}
+		var checked []string
+		notReady := func(sess string, deadline time.Time) bool {
+			checked = append(checked, sess)
+			return sess != "gt-rig-witness"
+		}
+		cfg := &config.BootConfig{ReadyTimeout: "1s"}
+		outcomes, err := bootStaged(cfg, rigAgents, starters, notReady, func(string) {})
+		if err != nil {
+			t.Fatalf("bootStaged: %v", err)
+		}
+		if got := strings.Join(order, ","); got != "witness" {
+			t.Errorf("start order = %s, want witness only", got)
+		}
+		if len(checked) != 1 || checked[0] != "gt-rig-witness" {
+			t.Errorf("readiness checked %v, want [gt-rig-witness]", checked)
+		}
+		if outcomes[0].Err == nil || !strings.Contains(outcomes[0].Err.Error(), "not ready after 1s") {
+			t.Errorf("witness error = %v, want not ready", outcomes[0].Err)
+		}
+		if outcomes[1].Err == nil {
+			t.Error("refinery started although witness wasn't ready")
+		}
+	})
+
+	t.Run("cycle is an error", func(t *testing.T) {
+		cfg := &config.BootConfig{After: map[string][]string{config.BootAgentWitness: {config.BootAgentRefinery}}}
+		if _, err := bootStaged(cfg, rigAgents, nil, readyAll, func(string) {}); err == nil {
+			t.Error("expected cycle error")
+		}
+	})
+}
@@ -146,10 +146,8 @@ func runRigDock(cmd *cobra.Command, args []string) error {
 
 	// Sync beads to propagate to other clones
 	fmt.Printf("  Syncing beads...\n")
-	syncCmd := exec.Command("bd", "sync")
-	syncCmd.Dir = r.BeadsPath()
-	if output, err := syncCmd.CombinedOutput(); err != nil {
-		fmt.Printf("  %s bd sync warning: %v\n%s", style.Warning.Render("!"), err, string(output))
+	if err := syncBeads(r.BeadsPath(), false); err != nil {
+		fmt.Printf("  %s bd sync warning: %v\n", style.Warning.Render("!"), err)
 	}
 
 	// Output
@@ -212,10 +210,8 @@ func runRigUndock(cmd *cobra.Command, args []string) error {
 
 	// Sync beads to propagate to other clones
 	fmt.Printf("  Syncing beads...\n")
-	syncCmd := exec.Command("bd", "sync")
-	syncCmd.Dir = r.BeadsPath()
-	if output, err := syncCmd.CombinedOutput(); err != nil {
-		fmt.Printf("  %s bd sync warning: %v\n%s", style.Warning.Render("!"), err, string(output))
+	if err := syncBeads(r.BeadsPath(), false); err != nil {
+		fmt.Printf("  %s bd sync warning: %v\n", style.Warning.Render("!"), err)
 	}
 
 	fmt.Printf("%s Rig %s undocked\n", style.Success.Render("✓"), rigName)
@@ -251,3 +247,16 @@ func IsRigDocked(townRoot, rigName, prefix string) bool {
 	}
 	return false
 }
+
+// syncBeads runs bd sync in dir, showing bd's progress output as it
+// arrives rather than leaving the command silent until sync finishes.
+func syncBeads(dir string, fromMain bool) error {
+	bd := beads.New(dir)
+	bd.SetProgress(func(line string) {
+		fmt.Printf("    %s\n", style.Dim.Render(line))
+	})
+	if fromMain {
+		return bd.SyncFromMain()
+	}
+	return bd.Sync()
+}
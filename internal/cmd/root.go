@@ -6,6 +6,12 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/redact"
+	"github.com/steveyegge/gastown/internal/telemetry"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var rootCmd = &cobra.Command{
@@ -16,7 +22,66 @@ var rootCmd = &cobra.Command{
 
 It coordinates agent spawning, work distribution, and communication
 across distributed teams of AI agents working on shared codebases.`,
-	PersistentPreRunE: checkBeadsDependency,
+	PersistentPreRunE: preRun,
+}
+
+// preRun enforces observer mode and role permissions, attaches the town's
+// event sinks and audit log, scrubs secrets from bead writes, then checks the beads
+// dependency.
+func preRun(cmd *cobra.Command, args []string) error {
+	if err := checkObserver(cmd); err != nil {
+		return err
+	}
+	if err := checkPermissions(cmd); err != nil {
+		return err
+	}
+	startEventSinks()
+	startAuditLog()
+	startRedaction()
+	startTelemetry(cmd, args)
+	return checkBeadsDependency(cmd, args)
+}
+
+// startTelemetry opens the command's OTel span and traces its bd calls,
+// when an OTLP endpoint is configured (see package telemetry). Execute
+// ends the span.
+func startTelemetry(cmd *cobra.Command, args []string) {
+	span := telemetry.StartCommand(buildCommandPath(cmd))
+	if span == nil {
+		return
+	}
+	span.SetAttr("gt.command", buildCommandPath(cmd))
+	span.SetAttr("gt.actor", detectActor())
+	if id := telemetry.BeadID(args); id != "" {
+		span.SetAttr("gt.bead_id", id)
+	}
+	beads.AddDefaultMiddleware(beads.Trace(telemetry.RecordBd))
+}
+
+// startEventSinks forwards this command's events to the town's remote
+// sinks, if any. Sinks are best-effort, like the events log: a bad one
+// never fails the command. Execute flushes them before exiting.
+func startEventSinks() {
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		_ = events.StartSinks(townRoot)
+	}
+}
+
+// startAuditLog points every beads handle's attach/detach audit log at the
+// location the town's storage settings name.
+func startAuditLog() {
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		beads.SetDefaultAuditLogPath(config.LoadPaths(townRoot).AuditLogPath)
+	}
+}
+
+// startRedaction scrubs secrets from every bead this command writes,
+// counting them for the town (see package redact).
+func startRedaction() {
+	townRoot, _ := workspace.FindFromCwd()
+	beads.AddDefaultMiddleware(beads.Redact(redact.ForTown(townRoot), func(n int) {
+		_ = redact.Record(townRoot, redact.SourceBead, n)
+	}))
 }
 
 // Commands that don't require beads to be installed/checked.
@@ -25,6 +90,8 @@ var beadsExemptCommands = map[string]bool{
 	"version":    true,
 	"help":       true,
 	"completion": true,
+	"bench":      true, // skips bd benchmarks itself when bd is missing
+	"record":     true, // transcript record: pipes pane output, never calls bd
 }
 
 // checkBeadsDependency verifies beads meets minimum version requirements.
@@ -45,15 +112,24 @@ func checkBeadsDependency(cmd *cobra.Command, args []string) error {
 // Execute runs the root command and returns an exit code.
 // The caller (main) should call os.Exit with this code.
 func Execute() int {
-	if err := rootCmd.Execute(); err != nil {
-		// Check for silent exit (scripting commands that signal status via exit code)
-		if code, ok := IsSilentExit(err); ok {
-			return code
-		}
-		// Other errors already printed by cobra
-		return 1
+	defer func() { _ = events.StopSinks() }()
+	err := rootCmd.Execute()
+	code := exitCode(err)
+	_ = telemetry.EndCommand(code, err)
+	return code
+}
+
+// exitCode maps a command's error to the process exit code.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	// Check for silent exit (scripting commands that signal status via exit code)
+	if code, ok := IsSilentExit(err); ok {
+		return code
 	}
-	return 0
+	// Other errors already printed by cobra
+	return 1
 }
 
 // Command group IDs - used by subcommands to organize help output
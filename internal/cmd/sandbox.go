@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/sandbox"
+)
+
+var sandboxCmd = &cobra.Command{
+	Use:     "sandbox",
+	GroupID: GroupConfig,
+	Short:   "Worktree write scoping for agents",
+	Long: `Restrict agent file writes to their assigned worktree.
+
+Enforcement is configured per rig in settings/config.json:
+
+  "sandbox": {
+    "mode": "warn",                      // off | warn | block
+    "roles": {"polecat": "block"},       // per-role overrides
+    "allow_paths": ["/tmp"]              // extra writable locations
+  }
+
+Violations are always logged as sandbox_violation events. In block mode
+the write is refused.`,
+}
+
+var sandboxCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check a pending tool write (PreToolUse hook)",
+	Long: `Check a pending file write against the agent's worktree.
+
+Reads the Claude Code PreToolUse hook payload from stdin. Exits 0 to allow
+the write, or 2 (with a reason on stderr) to block it.
+
+Non-write tools and sessions outside a Gas Town workspace are always allowed.`,
+	RunE:          runSandboxCheck,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	sandboxCmd.AddCommand(sandboxCheckCmd)
+	rootCmd.AddCommand(sandboxCmd)
+}
+
+func runSandboxCheck(cmd *cobra.Command, args []string) error {
+	input, err := sandbox.ParseHookInput(os.Stdin)
+	if err != nil {
+		// Never wedge the agent on a malformed payload
+		return nil
+	}
+	target := input.TargetPath()
+	if target == "" {
+		return nil
+	}
+
+	info, err := GetRole()
+	if err != nil || info.Home == "" || info.Rig == "" {
+		return nil
+	}
+
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(info.TownRoot, info.Rig)))
+	if err != nil {
+		settings = nil
+	}
+	var sb *config.SandboxConfig
+	if settings != nil {
+		sb = settings.Sandbox
+	}
+
+	mode := sb.ModeFor(string(info.Role))
+	if mode == config.SandboxOff {
+		return nil
+	}
+
+	cwd := input.Cwd
+	if cwd == "" {
+		cwd = info.WorkDir
+	}
+	var allow []string
+	if sb != nil {
+		allow = sb.AllowPaths
+	}
+
+	err = sandbox.Check(input.ToolName, target, cwd, info.Home, allow)
+	var v *sandbox.Violation
+	if !errors.As(err, &v) {
+		return nil
+	}
+
+	_ = events.Log(events.TypeSandboxViolation, info.ActorString(), map[string]interface{}{
+		"tool":     v.Tool,
+		"path":     v.Path,
+		"worktree": v.Worktree,
+		"mode":     mode,
+	}, events.VisibilityDefault)
+
+	if mode == config.SandboxBlock {
+		fmt.Fprintf(os.Stderr, "Blocked by gt sandbox: %v. Only write inside your own worktree.\n", v)
+		return NewSilentExit(2)
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %v\n", v)
+	return nil
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/schedule"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var scheduleJSON bool
+
+var scheduleCmd = &cobra.Command{
+	Use:     "schedule",
+	GroupID: GroupWork,
+	Short:   "List recurring bead schedules and when they next run",
+	Long: `List the town's recurring bead rules and when each next runs.
+
+Rules live in the town's settings/config.json, keyed by name:
+  {"schedules": {
+    "weekly-deps": {
+      "cron": "0 9 * * mon",
+      "title": "Weekly deps audit {date}",
+      "rig": "gastown", "priority": 2, "type": "task",
+      "assignee": "gastown/polecats/Toast"}}}
+
+cron takes five fields (minute hour day-of-month month day-of-week) or
+@hourly, @daily, @weekly, @monthly, @yearly, read in the rule's timezone
+(default: local). "{date}" in the title becomes the run's date. rig
+defaults to the town; priority to 2; type to task.
+
+The daemon creates each run's bead once, labeled schedule:<name>@<time>,
+so restarts never duplicate a run. Runs missed while the daemon was down
+collapse into one bead for the latest of them; with "catch_up": "skip" a
+late run is dropped instead. A new or re-enabled ("disabled": false) rule
+starts from now rather than backfilling.`,
+	Args: cobra.NoArgs,
+	RunE: runSchedule,
+}
+
+func init() {
+	scheduleCmd.Flags().BoolVar(&scheduleJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(scheduleCmd)
+}
+
+// scheduleStatus is one rule as gt schedule reports it.
+type scheduleStatus struct {
+	Name    string               `json:"name"`
+	Rule    *config.ScheduleRule `json:"rule"`
+	Next    *time.Time           `json:"next,omitempty"`
+	Checked *time.Time           `json:"checked,omitempty"` // Due runs up to here are handled
+	Error   string               `json:"error,omitempty"`   // Why the daemon skips the rule
+}
+
+func runSchedule(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	state, err := daemon.LoadScheduleState(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading schedule state: %w", err)
+	}
+
+	names := make([]string, 0, len(settings.Schedules))
+	for name := range settings.Schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	statuses := make([]scheduleStatus, 0, len(names))
+	for _, name := range names {
+		rule := settings.Schedules[name]
+		st := scheduleStatus{Name: name, Rule: rule}
+		if checked, ok := state.Checked[name]; ok {
+			st.Checked = &checked
+		}
+		if err := config.ValidateScheduleRule(name, rule); err != nil {
+			st.Error = err.Error()
+			statuses = append(statuses, st)
+			continue
+		}
+		c, _ := schedule.Parse(rule.Cron)
+		loc, _ := rule.Location()
+		if !rule.Disabled {
+			if next := c.Next(now.In(loc)); !next.IsZero() {
+				st.Next = &next
+			}
+		}
+		statuses = append(statuses, st)
+	}
+
+	if scheduleJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statuses)
+	}
+	if len(statuses) == 0 {
+		fmt.Println("No schedules.")
+		fmt.Printf("\nDeclare them in settings/config.json: %s\n", style.Dim.Render("gt schedule --help"))
+		return nil
+	}
+	for _, st := range statuses {
+		if st.Error != "" {
+			fmt.Printf("  %-16s %s\n", style.Bold.Render(st.Name), style.Warning.Render("invalid: "+st.Error))
+			continue
+		}
+		where := "town"
+		if st.Rule.Rig != "" {
+			where = st.Rule.Rig
+		}
+		next := "never"
+		switch {
+		case st.Rule.Disabled:
+			next = "disabled"
+		case st.Next != nil:
+			next = st.Next.Format("Mon 2006-01-02 15:04 MST")
+		}
+		fmt.Printf("  %-16s %-16s next %s\n", style.Bold.Render(st.Name), st.Rule.Cron, next)
+		fmt.Printf("  %-16s %s\n", "", style.Dim.Render(fmt.Sprintf("%s → %s, P%d", st.Rule.Title, where, st.Rule.PriorityOrDefault())))
+		if st.Checked == nil && !st.Rule.Disabled {
+			fmt.Printf("  %-16s %s\n", "", style.Dim.Render("not yet seen by the daemon"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/search"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Search command flags
+var (
+	searchSources []string
+	searchLimit   int
+	searchJSON    bool
+	searchRebuild bool
+	searchScope   string
+)
+
+var searchCmd = &cobra.Command{
+	Use:     "search <query>...",
+	GroupID: GroupDiag,
+	Short:   "Search beads, mail, events, and transcripts",
+	Long: `Search everything the town has recorded in one place.
+
+Hits are ranked across issue titles and descriptions, mail, event
+payloads, and Claude session transcripts for sessions run inside the
+town. The index lives in the town cache directory; the daemon refreshes
+it every heartbeat and each search picks up anything newer first.
+
+Sources: bead, mail, event, transcript
+
+Beads above --scope are left out of the results. Observers search at
+public scope unless they pass --scope.
+
+Examples:
+  gt search flaky auth test
+  gt search --source mail,transcript "merge conflict"
+  gt search --rebuild refinery --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().StringSliceVar(&searchSources, "source", nil, "Restrict to sources (bead, mail, event, transcript)")
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 20, "Maximum hits")
+	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "Output as JSON")
+	searchCmd.Flags().BoolVar(&searchRebuild, "rebuild", false, "Rebuild the index from scratch first")
+	searchCmd.Flags().StringVar(&searchScope, "scope", "", "Only show beads visible at this scope (public, internal, private)")
+	rootCmd.AddCommand(searchCmd)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	for _, s := range searchSources {
+		switch s {
+		case search.SourceBead, search.SourceMail, search.SourceEvent, search.SourceTranscript:
+		default:
+			return fmt.Errorf("unknown source %q (want bead, mail, event, or transcript)", s)
+		}
+	}
+
+	scope := searchScope
+	if scope == "" && config.IsObserver(townRoot) {
+		scope = beads.VisibilityPublic
+	}
+	if scope != "" && !beads.ValidVisibility(scope) {
+		return fmt.Errorf("unknown visibility scope %q", scope)
+	}
+
+	update := search.Update
+	if searchRebuild {
+		update = search.Rebuild
+	}
+	idx, err := update(townRoot)
+	if err != nil {
+		return fmt.Errorf("updating search index: %w", err)
+	}
+
+	hits := idx.Search(strings.Join(args, " "), search.Options{Sources: searchSources, Scope: scope, Limit: searchLimit})
+
+	if searchJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+	if len(hits) == 0 {
+		fmt.Println(style.Dim.Render("No matches"))
+		return nil
+	}
+	for _, h := range hits {
+		when := ""
+		if !h.Doc.Time.IsZero() {
+			when = h.Doc.Time.Format("2006-01-02 15:04")
+		}
+		heading := h.Doc.Ref
+		if h.Doc.Title != "" {
+			heading += "  " + h.Doc.Title
+		}
+		fmt.Printf("%s %s  %s\n", style.Dim.Render(fmt.Sprintf("[%s]", h.Doc.Source)), style.Bold.Render(heading), style.Dim.Render(when))
+		fmt.Printf("    %s\n", h.Snippet)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/web"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	servePort  int
+	serveHost  string
+	serveScope string
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	GroupID: GroupServices,
+	Short:   "Serve a local REST API over beads and events",
+	Long: `Start an HTTP server exposing the town's beads and events as JSON, so
+scripts, editors, and the web dashboard can integrate without shelling
+out to gt.
+
+Endpoints:
+  GET   /api/issues        List issues (?status= &type= &assignee= &label= &parent= &priority= &limit=)
+  POST  /api/issues        Create an issue ({"title": ..., "type": ..., "priority": ..., ...})
+  GET   /api/issues/{id}   Show an issue
+  PATCH /api/issues/{id}   Update an issue ({"status": ..., "assignee": ..., "add_labels": [...], ...})
+  GET   /api/events        Query events (the gt events query filters as parameters, plus ?limit=)
+  GET   /api/status        Rigs, polecats, hooked beads, and ready/blocked queue depths
+
+The convoy dashboard (gt dashboard) is served at /.
+
+Issue writes get the same validation as the CLI; a rejected request
+returns 400 with {"error": "..."}. Writes must have Content-Type
+application/json. Issues are created as the server's actor (BD_ACTOR).
+
+Only public issues are served unless --scope says otherwise; internal and
+private issues are reported as not found. The server listens on localhost
+only unless --host says otherwise, and answers only requests addressed
+to that host or to localhost. It has no authentication.
+
+Examples:
+  gt serve                                      # http://127.0.0.1:8080
+  gt serve --port 3000
+  curl localhost:8080/api/issues?status=open
+  curl localhost:8080/api/events?since=1h&type=sling
+  curl -X PATCH -H 'Content-Type: application/json' \
+       -d '{"status":"in_progress"}' localhost:8080/api/issues/gt-abc`,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "HTTP port to listen on")
+	serveCmd.Flags().StringVar(&serveHost, "host", "127.0.0.1", "Address to listen on")
+	serveCmd.Flags().StringVar(&serveScope, "scope", beads.VisibilityPublic, "Visibility scope to serve issues at (public, internal, private)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	router, err := beads.NewTownRouter(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading beads routes: %w", err)
+	}
+
+	fetcher, err := web.NewLiveConvoyFetcher()
+	if err != nil {
+		return fmt.Errorf("creating convoy fetcher: %w", err)
+	}
+	dashboard, err := web.NewConvoyHandler(fetcher)
+	if err != nil {
+		return fmt.Errorf("creating convoy handler: %w", err)
+	}
+
+	api := web.NewAPIHandler(townRoot, router)
+	if err := api.SetVisibilityScope(serveScope); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", api)
+	mux.Handle("/", dashboard)
+
+	addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+	fmt.Printf("🚚 Gas Town API serving at http://%s/api/\n", addr)
+	fmt.Printf("   Press Ctrl+C to stop\n")
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           web.RestrictHost(serveHost, mux),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	return server.ListenAndServe()
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/deps"
+	"github.com/steveyegge/gastown/internal/setup"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Setup command flags
+var (
+	setupTown      string
+	setupName      string
+	setupRig       string
+	setupRepo      string
+	setupYes       bool
+	setupSkipSmoke bool
+	setupJSON      bool
+)
+
+var setupCmd = &cobra.Command{
+	Use:     "setup",
+	GroupID: GroupWorkspace,
+	Short:   "Guided first-run setup of a new Gas Town",
+	Long: `Walk through first-run setup of a Gas Town.
+
+Steps:
+  1. Check that beads (bd) is installed and compatible
+  2. Create the town HQ (skipped if it already exists)
+  3. Add a first rig from a git URL (optional)
+  4. Create mayor and deacon handoff beads
+  5. Run a smoke test
+
+Every prompt has a flag; with --yes no prompts are shown and unset values
+use their defaults. Install scripts should use the non-interactive form.
+
+Examples:
+  gt setup                                             # Interactive
+  gt setup --yes --town ~/gt --repo git@github.com:me/app.git
+  gt setup --yes --town /srv/gt --skip-smoke --json`,
+	Args: cobra.NoArgs,
+	RunE: runSetup,
+}
+
+func init() {
+	setupCmd.Flags().StringVar(&setupTown, "town", "", "Town location (default: $GT_TOWN_ROOT or ~/gt)")
+	setupCmd.Flags().StringVar(&setupName, "name", "", "Town name (defaults to directory name)")
+	setupCmd.Flags().StringVar(&setupRig, "rig", "", "First rig name (defaults to repo name)")
+	setupCmd.Flags().StringVar(&setupRepo, "repo", "", "Git URL for the first rig")
+	setupCmd.Flags().BoolVarP(&setupYes, "yes", "y", false, "Non-interactive: accept defaults for anything not set by flags")
+	setupCmd.Flags().BoolVar(&setupSkipSmoke, "skip-smoke", false, "Skip the final smoke test")
+	setupCmd.Flags().BoolVar(&setupJSON, "json", false, "Output step results as JSON (implies --yes)")
+	rootCmd.AddCommand(setupCmd)
+}
+
+func runSetup(cmd *cobra.Command, args []string) error {
+	interactive := !setupYes && !setupJSON && stdinIsTerminal()
+
+	w := &setup.Wizard{
+		Actions:         setupActions{},
+		Interactive:     interactive,
+		In:              os.Stdin,
+		Out:             os.Stdout,
+		DefaultTownRoot: config.DefaultTownRoot(),
+	}
+	if setupJSON {
+		w.Out = nil
+	}
+
+	results, err := w.Run(setup.Options{
+		TownRoot:      expandHome(setupTown),
+		TownName:      setupName,
+		RigName:       setupRig,
+		RigURL:        setupRepo,
+		SkipSmokeTest: setupSkipSmoke,
+	})
+
+	if setupJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if encErr := enc.Encode(results); encErr != nil {
+			return encErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if !setupJSON {
+		fmt.Printf("\n%s Setup complete. Next: %s\n", style.Success.Render("✓"), style.Dim.Render("gt mayor attach"))
+	}
+	return nil
+}
+
+// setupActions implements setup.Actions using the install and rig commands.
+type setupActions struct{}
+
+func (setupActions) DetectBeads() (string, error) {
+	status, version := deps.CheckBeads()
+	if status == deps.BeadsUnknown {
+		return "unknown", nil
+	}
+	if err := deps.EnsureBeads(false); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func (setupActions) TownExists(root string) bool {
+	ok, _ := workspace.IsWorkspace(root)
+	return ok
+}
+
+func (setupActions) CreateTown(opts *setup.Options) error {
+	return installTown(opts.TownRoot, installOptions{Name: opts.TownName})
+}
+
+func (setupActions) AddRig(opts *setup.Options) error {
+	return addRig(opts.TownRoot, opts.RigName, opts.RigURL, rigAddOptions{})
+}
+
+func (setupActions) CreateHandoffBeads(opts *setup.Options) error {
+	bd := beads.New(opts.TownRoot)
+	for _, role := range []string{"mayor", "deacon"} {
+		if _, err := bd.GetOrCreateHandoffBead(role); err != nil {
+			return fmt.Errorf("creating %s handoff bead: %w", role, err)
+		}
+	}
+	return nil
+}
+
+func (setupActions) SmokeTest(opts *setup.Options) error {
+	if ok, err := workspace.IsWorkspace(opts.TownRoot); err != nil || !ok {
+		return fmt.Errorf("%s is not a Gas Town workspace", opts.TownRoot)
+	}
+	if _, err := config.LoadRigsConfig(filepath.Join(opts.TownRoot, "mayor", "rigs.json")); err != nil {
+		return fmt.Errorf("loading rigs config: %w", err)
+	}
+	if _, err := beads.New(opts.TownRoot).List(beads.ListOptions{Priority: beads.PriorityUnset}); err != nil {
+		return fmt.Errorf("querying town beads: %w", err)
+	}
+	return nil
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal.
+func stdinIsTerminal() bool {
+	fi, err := os.Stdin.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// expandHome expands a leading "~/" to the user's home directory.
+func expandHome(path string) string {
+	if len(path) < 2 || path[:2] != "~/" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -117,9 +118,16 @@ The propulsion principle: if it's on your hook, YOU RUN IT.
 
 Batch Slinging:
   gt sling gt-abc gt-def gt-ghi gastown   # Sling multiple beads to a rig
+  gt sling --epic gt-epic gastown         # Sling an epic's open children
 
   When multiple beads are provided with a rig target, each bead gets its own
-  polecat. This parallelizes work dispatch without running gt sling N times.`,
+  polecat. This parallelizes work dispatch without running gt sling N times.
+  With --epic, the batch is the epic's open children, highest priority
+  first; children already being worked are skipped.
+
+  A batch logs one sling_batch event listing what was slung and what
+  failed, instead of a feed entry per bead. A bead that fails doesn't stop
+  the rest; the command exits non-zero if any failed.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runSling,
 }
@@ -139,6 +147,7 @@ var (
 	slingAccount  string // --account: Claude Code account handle to use
 	slingAgent    string // --agent: override runtime agent for this sling/spawn
 	slingNoConvoy bool   // --no-convoy: skip auto-convoy creation
+	slingEpic     string // --epic: batch-sling the open children of this epic
 )
 
 func init() {
@@ -156,6 +165,7 @@ func init() {
 	slingCmd.Flags().StringVar(&slingAccount, "account", "", "Claude Code account handle to use")
 	slingCmd.Flags().StringVar(&slingAgent, "agent", "", "Override agent/runtime for this sling (e.g., claude, gemini, codex, or custom alias)")
 	slingCmd.Flags().BoolVar(&slingNoConvoy, "no-convoy", false, "Skip auto-convoy creation for single-issue sling")
+	slingCmd.Flags().StringVar(&slingEpic, "epic", "", "Sling the open children of this epic to the target rig (batch mode)")
 
 	rootCmd.AddCommand(slingCmd)
 }
@@ -166,6 +176,9 @@ func runSling(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("polecats cannot sling (use gt done for handoff)")
 	}
 
+	// Events from here on, and from any polecat spawned, form one trace
+	events.StartTrace()
+
 	// Get town root early - needed for BEADS_DIR when running bd commands
 	// This ensures hq-* beads are accessible even when running from polecat worktree
 	townRoot, err := workspace.FindFromCwd()
@@ -174,18 +187,42 @@ func runSling(cmd *cobra.Command, args []string) error {
 	}
 	townBeadsDir := filepath.Join(townRoot, ".beads")
 
+	// A drained halt (gt down --drain) takes no new work
+	if err := checkNotDraining(townRoot); err != nil {
+		return err
+	}
+
 	// --var is only for standalone formula mode, not formula-on-bead mode
 	if slingOnTarget != "" && len(slingVars) > 0 {
 		return fmt.Errorf("--var cannot be used with --on (formula-on-bead mode doesn't support variables)")
 	}
 
+	// Epic batch mode: gt sling --epic gt-epic gastown
+	if slingEpic != "" {
+		if len(args) != 1 {
+			return fmt.Errorf("--epic takes only a target rig: gt sling --epic <epic> <rig>")
+		}
+		rigName, isRig := IsRigName(args[0])
+		if !isRig {
+			return fmt.Errorf("--epic target must be a rig (each child gets its own polecat), got '%s'", args[0])
+		}
+		beadIDs, err := epicSlingChildren(townRoot, slingEpic)
+		if err != nil {
+			return err
+		}
+		if len(beadIDs) == 0 {
+			return fmt.Errorf("epic %s has no open children to sling", slingEpic)
+		}
+		return runBatchSling(beadIDs, rigName, slingEpic, townBeadsDir)
+	}
+
 	// Batch mode detection: multiple beads with rig target
 	// Pattern: gt sling gt-abc gt-def gt-ghi gastown
 	// When len(args) > 2 and last arg is a rig, sling each bead to its own polecat
 	if len(args) > 2 {
 		lastArg := args[len(args)-1]
 		if rigName, isRig := IsRigName(lastArg); isRig {
-			return runBatchSling(args[:len(args)-1], rigName, townBeadsDir)
+			return runBatchSling(args[:len(args)-1], rigName, "", townBeadsDir)
 		}
 	}
 
@@ -445,6 +482,7 @@ func runSling(cmd *cobra.Command, args []string) error {
 	hookCmd.Dir = beads.ResolveHookDir(townRoot, beadID, hookWorkDir)
 	hookCmd.Stderr = os.Stderr
 	if err := hookCmd.Run(); err != nil {
+		_ = events.LogError(events.TypeSlingFailed, detectActor(), err, events.SlingFailedPayload(beadID, targetAgent))
 		return fmt.Errorf("hooking bead: %w", err)
 	}
 
@@ -452,7 +490,7 @@ func runSling(cmd *cobra.Command, args []string) error {
 
 	// Log sling event to activity feed
 	actor := detectActor()
-	_ = events.LogFeed(events.TypeSling, actor, events.SlingPayload(beadID, targetAgent))
+	_ = events.LogIdempotent(events.Key(events.TypeSling, beadID, targetAgent), events.TypeSling, actor, events.SlingPayload(beadID, targetAgent), events.VisibilityDefault)
 
 	// Update agent bead's hook_bead field (ZFC: agents track their current work)
 	updateAgentHookBead(targetAgent, beadID, hookWorkDir, townBeadsDir)
@@ -499,7 +537,7 @@ func runSling(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// storeArgsInBead stores args in the bead's description using attached_args field.
+// storeArgsInBead stores args in the bead's attached_args field.
 // This enables no-tmux mode where agents discover args via gt prime / bd show.
 func storeArgsInBead(beadID, args string) error {
 	// Get the bead to preserve existing description content
@@ -528,20 +566,15 @@ func storeArgsInBead(beadID, args string) error {
 	// Set the args
 	fields.AttachedArgs = args
 
-	// Update the description
-	newDesc := beads.SetAttachmentFields(issue, fields)
-
 	// Update the bead
-	updateCmd := exec.Command("bd", "--no-daemon", "update", beadID, "--description="+newDesc)
-	updateCmd.Stderr = os.Stderr
-	if err := updateCmd.Run(); err != nil {
-		return fmt.Errorf("updating bead description: %w", err)
+	if err := beads.New("").StoreAttachmentFields(issue, fields); err != nil {
+		return fmt.Errorf("updating bead fields: %w", err)
 	}
 
 	return nil
 }
 
-// storeDispatcherInBead stores the dispatcher agent ID in the bead's description.
+// storeDispatcherInBead stores the dispatcher agent ID in the bead's dispatched_by field.
 // This enables polecats to notify the dispatcher when work is complete.
 func storeDispatcherInBead(beadID, dispatcher string) error {
 	if dispatcher == "" {
@@ -574,14 +607,9 @@ func storeDispatcherInBead(beadID, dispatcher string) error {
 	// Set the dispatcher
 	fields.DispatchedBy = dispatcher
 
-	// Update the description
-	newDesc := beads.SetAttachmentFields(issue, fields)
-
 	// Update the bead
-	updateCmd := exec.Command("bd", "update", beadID, "--description="+newDesc)
-	updateCmd.Stderr = os.Stderr
-	if err := updateCmd.Run(); err != nil {
-		return fmt.Errorf("updating bead description: %w", err)
+	if err := beads.New("").StoreAttachmentFields(issue, fields); err != nil {
+		return fmt.Errorf("updating bead fields: %w", err)
 	}
 
 	return nil
@@ -982,7 +1010,7 @@ func runSlingFormula(args []string) error {
 	actor := detectActor()
 	payload := events.SlingPayload(wispRootID, targetAgent)
 	payload["formula"] = formulaName
-	_ = events.LogFeed(events.TypeSling, actor, payload)
+	_ = events.LogIdempotent(events.Key(events.TypeSling, wispRootID, targetAgent), events.TypeSling, actor, payload, events.VisibilityDefault)
 
 	// Update agent bead's hook_bead field (ZFC: agents track their current work)
 	// Note: formula slinging uses town root as workDir (no polecat-specific path)
@@ -1377,8 +1405,10 @@ func createAutoConvoy(beadID, beadTitle string) (string, error) {
 }
 
 // runBatchSling handles slinging multiple beads to a rig.
-// Each bead gets its own freshly spawned polecat.
-func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error {
+// Each bead gets its own freshly spawned polecat. The batch is logged as
+// one sling_batch event (epic names the epic the beads came from, if any);
+// the per-bead sling events are audit-only so the feed isn't flooded.
+func runBatchSling(beadIDs []string, rigName, epic, townBeadsDir string) error {
 	// Validate all beads exist before spawning any polecats
 	for _, beadID := range beadIDs {
 		if err := verifyBeadExists(beadID); err != nil {
@@ -1388,6 +1418,9 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 
 	if slingDryRun {
 		fmt.Printf("%s Batch slinging %d beads to rig '%s':\n", style.Bold.Render("🎯"), len(beadIDs), rigName)
+		if epic != "" {
+			fmt.Printf("  Children of epic %s\n", epic)
+		}
 		for _, beadID := range beadIDs {
 			fmt.Printf("  Would spawn polecat for: %s\n", beadID)
 		}
@@ -1411,6 +1444,7 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 	// Spawn a polecat for each bead and sling it
 	for i, beadID := range beadIDs {
 		fmt.Printf("\n[%d/%d] Slinging %s...\n", i+1, len(beadIDs), beadID)
+		events.NewTrace() // One trace per bead
 
 		// Check bead status
 		info, err := getBeadInfo(beadID)
@@ -1466,16 +1500,16 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 		hookCmd.Dir = beads.ResolveHookDir(townRoot, beadID, hookWorkDir)
 		hookCmd.Stderr = os.Stderr
 		if err := hookCmd.Run(); err != nil {
-			results = append(results, slingResult{beadID: beadID, polecat: spawnInfo.PolecatName, success: false, errMsg: "hook failed"})
+			results = append(results, slingResult{beadID: beadID, polecat: spawnInfo.PolecatName, success: false, errMsg: "hook failed: " + err.Error()})
 			fmt.Printf("  %s Failed to hook bead: %v\n", style.Dim.Render("✗"), err)
 			continue
 		}
 
 		fmt.Printf("  %s Work attached to %s\n", style.Bold.Render("✓"), spawnInfo.PolecatName)
 
-		// Log sling event
+		// Log sling event (audit only; the batch event goes to the feed)
 		actor := detectActor()
-		_ = events.LogFeed(events.TypeSling, actor, events.SlingPayload(beadID, targetAgent))
+		_ = events.LogIdempotent(events.Key(events.TypeSling, beadID, targetAgent), events.TypeSling, actor, events.SlingPayload(beadID, targetAgent), events.VisibilityAudit)
 
 		// Update agent bead state
 		updateAgentHookBead(targetAgent, beadID, hookWorkDir, townBeadsDir)
@@ -1502,26 +1536,85 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 	// Wake witness and refinery once at the end
 	wakeRigAgents(rigName)
 
-	// Print summary
-	successCount := 0
+	// Log one event for the whole batch
+	var slung []string
+	failed := make(map[string]string)
 	for _, r := range results {
 		if r.success {
-			successCount++
+			slung = append(slung, r.beadID)
+		} else {
+			failed[r.beadID] = r.errMsg
 		}
 	}
+	events.NewTrace()
+	payload := events.SlingBatchPayload(rigName, epic, slung, failed)
+	if len(failed) > 0 {
+		_ = events.LogError(events.TypeSlingBatch, detectActor(), fmt.Errorf("%d of %d beads failed to sling", len(failed), len(beadIDs)), payload)
+	} else {
+		_ = events.Log(events.TypeSlingBatch, detectActor(), payload, events.VisibilityDefault)
+	}
 
-	fmt.Printf("\n%s Batch sling complete: %d/%d succeeded\n", style.Bold.Render("📊"), successCount, len(beadIDs))
-	if successCount < len(beadIDs) {
+	// Print summary
+	fmt.Printf("\n%s Batch sling complete: %d/%d succeeded\n", style.Bold.Render("📊"), len(slung), len(beadIDs))
+	if len(failed) > 0 {
 		for _, r := range results {
 			if !r.success {
 				fmt.Printf("  %s %s: %s\n", style.Dim.Render("✗"), r.beadID, r.errMsg)
 			}
 		}
+		return NewSilentExit(1)
 	}
 
 	return nil
 }
 
+// epicSlingChildren returns the children of an epic to batch-sling.
+func epicSlingChildren(townRoot, epicID string) ([]string, error) {
+	bd := beads.New(beads.ResolveHookDir(townRoot, epicID, ""))
+	if _, err := bd.Show(epicID); err != nil {
+		return nil, fmt.Errorf("epic '%s' not found: %w", epicID, err)
+	}
+	children, err := bd.List(beads.ListOptions{Parent: epicID, Status: "all", Priority: -1, Limit: beads.NoLimit})
+	if err != nil {
+		return nil, fmt.Errorf("listing children of %s: %w", epicID, err)
+	}
+	ids, skipped := selectEpicChildren(children)
+	for _, s := range skipped {
+		fmt.Printf("  %s Skipping %s\n", style.Dim.Render("○"), s)
+	}
+	return ids, nil
+}
+
+// selectEpicChildren picks the slingable children of an epic, highest
+// priority first (then by ID). Open children are slung; closed ones are
+// ignored; the rest (already hooked, in progress, blocked, ...) and nested
+// epics are skipped with a note saying why.
+func selectEpicChildren(children []*beads.Issue) (ids []string, skipped []string) {
+	var open []*beads.Issue
+	for _, c := range children {
+		switch {
+		case c.Status == "closed" || c.Status == "tombstone":
+			continue
+		case c.Status != "open":
+			skipped = append(skipped, fmt.Sprintf("%s (%s)", c.ID, c.Status))
+		case c.Type == "epic":
+			skipped = append(skipped, fmt.Sprintf("%s (nested epic; sling it with --epic)", c.ID))
+		default:
+			open = append(open, c)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool {
+		if open[i].Priority != open[j].Priority {
+			return open[i].Priority < open[j].Priority
+		}
+		return open[i].ID < open[j].ID
+	})
+	for _, c := range open {
+		ids = append(ids, c.ID)
+	}
+	return ids, skipped
+}
+
 // formatTrackBeadID formats a bead ID for use in convoy tracking dependencies.
 // Cross-rig beads (non-hq- prefixed) are formatted as external references
 // so the bd tool can resolve them when running from HQ context.
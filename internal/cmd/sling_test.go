@@ -1,6 +1,11 @@
 package cmd
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
 
 func TestParseWispIDFromJSON(t *testing.T) {
 	tests := []struct {
@@ -183,3 +188,22 @@ func TestFormatTrackBeadIDConsumerCompatibility(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectEpicChildren(t *testing.T) {
+	children := []*beads.Issue{
+		{ID: "gt-b", Status: "open", Priority: 2},
+		{ID: "gt-a", Status: "open", Priority: 2},
+		{ID: "gt-urgent", Status: "open", Priority: 0},
+		{ID: "gt-done", Status: "closed", Priority: 0},
+		{ID: "gt-busy", Status: "hooked", Priority: 1},
+		{ID: "gt-sub", Status: "open", Priority: 1, Type: "epic"},
+	}
+
+	ids, skipped := selectEpicChildren(children)
+	if got := strings.Join(ids, ","); got != "gt-urgent,gt-a,gt-b" {
+		t.Errorf("ids = %s, want gt-urgent,gt-a,gt-b", got)
+	}
+	if len(skipped) != 2 || !strings.HasPrefix(skipped[0], "gt-busy (hooked)") || !strings.HasPrefix(skipped[1], "gt-sub") {
+		t.Errorf("skipped = %v", skipped)
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	spawnCount    int
+	spawnParallel int
+	spawnNaked    bool
+	spawnAccount  string
+	spawnAgent    string
+	spawnJSON     bool
+)
+
+var spawnCmd = &cobra.Command{
+	Use:     "spawn <rig>",
+	GroupID: GroupAgents,
+	Short:   "Spawn a pool of fresh polecats in a rig",
+	Long: `Spawn N fresh polecats in a rig at once, ready for gt sling.
+
+Names are drawn from the rig's name pool, all at once so they never
+collide. Worktrees are created one at a time (git locks the repo), and
+sessions start in parallel, at most --parallel at a time. Progress lines
+are prefixed with each polecat's name.
+
+One boot event records the whole pool. The summary lists each polecat's
+session or error; the command exits non-zero if any polecat failed.
+
+Examples:
+  gt spawn gastown --count 5
+  gt spawn gastown -n 8 --parallel 2 --agent codex
+  gt spawn gastown -n 3 --naked`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSpawn,
+}
+
+func init() {
+	spawnCmd.Flags().IntVarP(&spawnCount, "count", "n", 1, "Number of polecats to spawn")
+	spawnCmd.Flags().IntVar(&spawnParallel, "parallel", 4, "Maximum polecats starting at once")
+	spawnCmd.Flags().BoolVar(&spawnNaked, "naked", false, "No-tmux mode: create worktrees, skip sessions")
+	spawnCmd.Flags().StringVar(&spawnAccount, "account", "", "Claude Code account handle to use")
+	spawnCmd.Flags().StringVar(&spawnAgent, "agent", "", "Override agent/runtime for these polecats (e.g., claude, gemini, codex, or custom alias)")
+	spawnCmd.Flags().BoolVar(&spawnJSON, "json", false, "Output as JSON")
+
+	rootCmd.AddCommand(spawnCmd)
+}
+
+// spawnPoolResult is the outcome of spawning one polecat of a pool.
+type spawnPoolResult struct {
+	Polecat   string `json:"polecat"`
+	Session   string `json:"session,omitempty"`
+	ClonePath string `json:"clone_path,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// spawnFunc spawns one named polecat, reporting progress through logf.
+type spawnFunc func(name string, logf func(format string, args ...interface{})) (*SpawnedPolecatInfo, error)
+
+func runSpawn(cmd *cobra.Command, args []string) error {
+	if spawnCount < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
+	if spawnParallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1")
+	}
+	rigName := strings.TrimSuffix(args[0], "/")
+
+	sr, err := loadSpawnRig(rigName)
+	if err != nil {
+		return err
+	}
+	if err := checkNotDraining(sr.townRoot); err != nil {
+		return err
+	}
+	names, err := sr.polecats.AllocateNames(spawnCount)
+	if err != nil {
+		return fmt.Errorf("allocating polecat names: %w", err)
+	}
+
+	var progress io.Writer = os.Stdout
+	if spawnJSON {
+		progress = io.Discard
+	}
+	fmt.Fprintf(progress, "Spawning %d polecat(s) in %s: %s\n", len(names), rigName, strings.Join(names, ", "))
+
+	opts := SlingSpawnOptions{
+		Naked:   spawnNaked,
+		Account: spawnAccount,
+		Agent:   spawnAgent,
+	}
+	results := spawnPool(names, spawnParallel, progress, func(name string, logf func(string, ...interface{})) (*SpawnedPolecatInfo, error) {
+		return sr.spawn(name, opts, logf)
+	})
+
+	var spawned []string
+	failed := make(map[string]string)
+	for _, res := range results {
+		if res.Error != "" {
+			failed[res.Polecat] = res.Error
+		} else {
+			spawned = append(spawned, res.Polecat)
+		}
+	}
+	_ = events.Log(events.TypeBoot, "gt", events.SpawnPoolPayload(rigName, spawned, failed), events.VisibilityDefault)
+
+	if spawnJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		mark := style.Bold.Render("✓")
+		if len(failed) > 0 {
+			mark = style.Error.Render("✗")
+		}
+		fmt.Printf("%s Spawned %d/%d polecat(s) in %s\n", mark, len(spawned), len(results), rigName)
+		for _, res := range results {
+			switch {
+			case res.Error != "":
+				fmt.Printf("  %s %-16s %s\n", style.Error.Render("✗"), res.Polecat, res.Error)
+			case res.Session != "":
+				fmt.Printf("  %s %-16s %s\n", style.Success.Render("✓"), res.Polecat, res.Session)
+			default:
+				fmt.Printf("  %s %-16s %s\n", style.Success.Render("✓"), res.Polecat, style.Dim.Render(res.ClonePath))
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// spawnPool spawns the named polecats, at most parallel at a time, and
+// returns their results in names order. Each polecat's progress goes to
+// out, line by line, prefixed with its name.
+func spawnPool(names []string, parallel int, out io.Writer, spawn spawnFunc) []spawnPoolResult {
+	results := make([]spawnPoolResult, len(names))
+	sem := make(chan struct{}, parallel)
+	var outMu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			prefix := fmt.Sprintf("[%s] ", name)
+			logf := func(format string, args ...interface{}) {
+				outMu.Lock()
+				defer outMu.Unlock()
+				for _, line := range strings.SplitAfter(fmt.Sprintf(format, args...), "\n") {
+					switch line {
+					case "":
+					case "\n":
+						fmt.Fprint(out, line)
+					default:
+						fmt.Fprint(out, prefix+line)
+					}
+				}
+			}
+
+			res := spawnPoolResult{Polecat: name}
+			info, err := spawn(name, logf)
+			if err != nil {
+				res.Error = err.Error()
+				logf("%s %v\n", style.Error.Render("✗"), err)
+			} else {
+				res.Session = info.SessionName
+				res.ClonePath = info.ClonePath
+			}
+			results[i] = res
+		}(i, name)
+	}
+	wg.Wait()
+	return results
+}
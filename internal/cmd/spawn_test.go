@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSpawnPoolBoundsConcurrency(t *testing.T) {
+	names := []string{"furiosa", "nux", "slit", "ace", "toast"}
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+
+	var out bytes.Buffer
+	results := spawnPool(names, 2, &out, func(name string, logf func(string, ...interface{})) (*SpawnedPolecatInfo, error) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		logf("Creating polecat %s...\n\nstarted\n", name)
+		if name == "slit" {
+			return nil, errors.New("starting session: timeout")
+		}
+		return &SpawnedPolecatInfo{PolecatName: name, SessionName: "gt-gastown-" + name}, nil
+	})
+
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak)
+	}
+	if len(results) != len(names) {
+		t.Fatalf("got %d results, want %d", len(results), len(names))
+	}
+	for i, res := range results {
+		if res.Polecat != names[i] {
+			t.Errorf("results[%d].Polecat = %q, want %q", i, res.Polecat, names[i])
+		}
+		if res.Polecat == "slit" {
+			if res.Error != "starting session: timeout" || res.Session != "" {
+				t.Errorf("slit result = %+v, want the session error", res)
+			}
+		} else if res.Error != "" || res.Session != "gt-gastown-"+res.Polecat {
+			t.Errorf("%s result = %+v, want its session", res.Polecat, res)
+		}
+	}
+
+	// Every non-blank progress line carries its polecat's prefix
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "[") {
+			t.Errorf("unprefixed progress line %q", line)
+		}
+	}
+	if !strings.Contains(out.String(), "[nux] Creating polecat nux...\n") {
+		t.Errorf("missing nux progress line in:\n%s", out.String())
+	}
+}
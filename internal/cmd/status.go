@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -61,9 +62,33 @@ type TownStatus struct {
 	Overseer *OverseerInfo  `json:"overseer,omitempty"` // Human operator
 	Agents   []AgentRuntime `json:"agents"`             // Global agents (Mayor, Deacon)
 	Rigs     []RigStatus    `json:"rigs"`
+	Drift    []BeadsDrift   `json:"drift,omitempty"` // Beads repos behind or conflicted (from daemon)
 	Summary  StatusSum      `json:"summary"`
 }
 
+// BeadsDrift is a beads repo the daemon last saw drifting from its remote.
+type BeadsDrift struct {
+	Scope     string    `json:"scope"` // Rig name, or "town"
+	Behind    int       `json:"behind"`
+	Conflicts []string  `json:"conflicts,omitempty"`
+	Since     time.Time `json:"since"`
+}
+
+// loadBeadsDrift reads the daemon's sync drift state. Drift is only known
+// while the daemon runs; errors just mean nothing to report.
+func loadBeadsDrift(townRoot string) []BeadsDrift {
+	state, err := daemon.LoadDriftState(townRoot)
+	if err != nil {
+		return nil
+	}
+	var out []BeadsDrift
+	for _, scope := range state.Drifting() {
+		rec := state.Rigs[scope]
+		out = append(out, BeadsDrift{Scope: scope, Behind: rec.Behind, Conflicts: rec.Conflicts, Since: rec.Since})
+	}
+	return out
+}
+
 // OverseerInfo represents the human operator's identity and status.
 type OverseerInfo struct {
 	Name       string `json:"name"`
@@ -75,17 +100,19 @@ type OverseerInfo struct {
 
 // AgentRuntime represents the runtime state of an agent.
 type AgentRuntime struct {
-	Name         string `json:"name"`                    // Display name (e.g., "mayor", "witness")
-	Address      string `json:"address"`                 // Full address (e.g., "greenplace/witness")
-	Session      string `json:"session"`                 // tmux session name
-	Role         string `json:"role"`                    // Role type
-	Running      bool   `json:"running"`                 // Is tmux session running?
-	HasWork      bool   `json:"has_work"`                // Has pinned work?
-	WorkTitle    string `json:"work_title,omitempty"`    // Title of pinned work
-	HookBead     string `json:"hook_bead,omitempty"`     // Pinned bead ID from agent bead
-	State        string `json:"state,omitempty"`         // Agent state from agent bead
-	UnreadMail   int    `json:"unread_mail"`             // Number of unread messages
-	FirstSubject string `json:"first_subject,omitempty"` // Subject of first unread message
+	Name          string `json:"name"`                    // Display name (e.g., "mayor", "witness")
+	Address       string `json:"address"`                 // Full address (e.g., "greenplace/witness")
+	Session       string `json:"session"`                 // tmux session name
+	Role          string `json:"role"`                    // Role type
+	Running       bool   `json:"running"`                 // Is tmux session running?
+	HasWork       bool   `json:"has_work"`                // Has pinned work?
+	WorkTitle     string `json:"work_title,omitempty"`    // Title of pinned work
+	HookBead      string `json:"hook_bead,omitempty"`     // Pinned bead ID from agent bead
+	State         string `json:"state,omitempty"`         // Agent state from agent bead
+	UnreadMail    int    `json:"unread_mail"`             // Number of unread messages
+	FirstSubject  string `json:"first_subject,omitempty"` // Subject of first unread message
+	Reserved      string `json:"reserved,omitempty"`      // Bead soft-reserved as the agent's next work
+	ReservedTitle string `json:"reserved_title,omitempty"`
 }
 
 // RigStatus represents status of a single rig.
@@ -372,6 +399,9 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 
 			// Discover runtime state for all agents in this rig
 			rs.Agents = discoverRigAgents(allSessions, r, rs.Crews, allAgentBeads, allHookBeads, mailRouter, statusFast)
+			if !statusFast {
+				attachReservations(r, rs.Agents)
+			}
 
 			// Get MQ summary if rig has a refinery
 			rs.MQ = getMQSummary(r)
@@ -395,6 +425,7 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 		}
 	}
 	status.Summary.RigCount = len(rigs)
+	status.Drift = loadBeadsDrift(townRoot)
 
 	// Output
 	if statusJSON {
@@ -424,6 +455,19 @@ func outputStatusText(status TownStatus) error {
 	fmt.Printf("%s %s\n", style.Bold.Render("Town:"), status.Name)
 	fmt.Printf("%s\n\n", style.Dim.Render(status.Location))
 
+	// Beads drift goes up top: stale beads mislead everything below
+	if len(status.Drift) > 0 {
+		fmt.Printf("%s %s\n", style.Warning.Render("⚠"), style.Bold.Render("Beads drift"))
+		for _, d := range status.Drift {
+			detail := fmt.Sprintf("%d commits behind", d.Behind)
+			if len(d.Conflicts) > 0 {
+				detail = fmt.Sprintf("%d conflicts, %s", len(d.Conflicts), detail)
+			}
+			fmt.Printf("   %s: %s %s\n", d.Scope, detail, style.Dim.Render("(since "+d.Since.Format("15:04")+")"))
+		}
+		fmt.Printf("   %s\n\n", style.Dim.Render("Run 'bd sync' in the affected beads repo"))
+	}
+
 	// Overseer info
 	if status.Overseer != nil {
 		overseerDisplay := status.Overseer.Name
@@ -612,8 +656,8 @@ func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo
 	case "muted", "paused", "degraded":
 		// Other intentional non-observable states
 		stateInfo = style.Dim.Render(fmt.Sprintf(" [%s]", beadState))
-	// Ignore observable states: "running", "idle", "dead", "done", "stopped", ""
-	// These should be derived from tmux, not bead.
+		// Ignore observable states: "running", "idle", "dead", "done", "stopped", ""
+		// These should be derived from tmux, not bead.
 	}
 
 	// Build agent bead ID using canonical naming: prefix-rig-role-name
@@ -672,6 +716,12 @@ func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo
 
 	fmt.Printf("%s  hook: %s\n", indent, hookStr)
 
+	if agent.Reserved != "" {
+		fmt.Printf("%s  next: %s %s\n", indent,
+			fmt.Sprintf("%s → %s", agent.Reserved, truncateWithEllipsis(agent.ReservedTitle, 40)),
+			style.Dim.Render("(reserved)"))
+	}
+
 	// Line 3: Mail (if any unread)
 	if agent.UnreadMail > 0 {
 		mailStr := fmt.Sprintf("📬 %d unread", agent.UnreadMail)
@@ -682,6 +732,31 @@ func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo
 	}
 }
 
+// attachReservations fills in each agent's soft-reserved next bead.
+// Reservations name polecats as rig/polecats/name; status addresses
+// them as rig/name, so both forms are matched.
+func attachReservations(r *rig.Rig, agents []AgentRuntime) {
+	reservations, err := beads.New(r.BeadsPath()).Reservations("")
+	if err != nil || len(reservations) == 0 {
+		return
+	}
+	now := time.Now()
+	byAgent := make(map[string]beads.Reservation)
+	for _, res := range reservations {
+		if res.Expired(now) {
+			continue
+		}
+		byAgent[res.Agent] = res
+		byAgent[strings.Replace(res.Agent, "/polecats/", "/", 1)] = res
+	}
+	for i := range agents {
+		if res, ok := byAgent[strings.TrimSuffix(agents[i].Address, "/")]; ok {
+			agents[i].Reserved = res.IssueID
+			agents[i].ReservedTitle = res.Title
+		}
+	}
+}
+
 // formatMQSummary formats the MQ status for verbose display
 func formatMQSummary(mq *MQSummary) string {
 	if mq == nil {
@@ -836,7 +911,7 @@ func buildStatusIndicator(agent AgentRuntime) string {
 		indicator += style.Dim.Render(" gate")
 	case "muted", "paused", "degraded":
 		indicator += style.Dim.Render(" " + beadState)
-	// Ignore observable states: running, idle, dead, done, stopped, ""
+		// Ignore observable states: running, idle, dead, done, stopped, ""
 	}
 
 	return indicator
@@ -149,7 +149,7 @@ func runStop(cmd *cobra.Command, args []string) error {
 				_ = logger.Log(townlog.EventKill, agent, "gt stop")
 
 				// Log kill event to activity feed
-				_ = events.LogFeed(events.TypeKill, "gt", events.KillPayload(r.Name, info.Polecat, "gt stop"))
+				_ = events.Log(events.TypeKill, "gt", events.KillPayload(r.Name, info.Polecat, "gt stop"), events.VisibilityDefault)
 
 				// Log captured output (truncated)
 				if len(output) > 200 {
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
@@ -807,13 +808,7 @@ func runSwarmLand(cmd *cobra.Command, args []string) error {
 	}
 
 	// Close the swarm epic in beads
-	closeArgs := []string{"close", swarmID, "--reason", "Swarm landed to main"}
-	if sessionID := os.Getenv("CLAUDE_SESSION_ID"); sessionID != "" {
-		closeArgs = append(closeArgs, "--session="+sessionID)
-	}
-	closeCmd := exec.Command("bd", closeArgs...)
-	closeCmd.Dir = foundRig.BeadsPath()
-	if err := closeCmd.Run(); err != nil {
+	if err := beads.New(foundRig.BeadsPath()).CloseWithReason("Swarm landed to main", swarmID); err != nil {
 		style.PrintWarning("couldn't close swarm epic in beads: %v", err)
 	}
 
@@ -866,13 +861,7 @@ func runSwarmCancel(cmd *cobra.Command, args []string) error {
 	}
 
 	// Close the swarm epic in beads with canceled reason
-	closeArgs := []string{"close", swarmID, "--reason", "Swarm canceled"}
-	if sessionID := os.Getenv("CLAUDE_SESSION_ID"); sessionID != "" {
-		closeArgs = append(closeArgs, "--session="+sessionID)
-	}
-	closeCmd := exec.Command("bd", closeArgs...)
-	closeCmd.Dir = foundRig.BeadsPath()
-	if err := closeCmd.Run(); err != nil {
+	if err := beads.New(foundRig.BeadsPath()).CloseWithReason("Swarm canceled", swarmID); err != nil {
 		return fmt.Errorf("closing swarm: %w", err)
 	}
 
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/ghimport"
+	"github.com/steveyegge/gastown/internal/ghsync"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Sync-github command flags
+var (
+	syncGitHubRepo   string
+	syncGitHubPrefer string
+	syncGitHubDryRun bool
+	syncGitHubJSON   bool
+)
+
+var syncGitHubCmd = &cobra.Command{
+	Use:     "sync-github [rig]",
+	GroupID: GroupWorkspace,
+	Short:   "Mirror a rig's beads to GitHub Issues and back",
+	Long: `Two-way sync between a rig's beads and its GitHub repository's issues,
+so stakeholders without gt access can follow and steer town work.
+
+New open issues become beads; new task, bug, feature, and epic beads
+become issues. For paired beads and issues, titles, descriptions,
+open/closed state, status labels, and mapped labels sync in whichever
+direction changed since the last run. Each bead carries a gh:issue:<n>
+label and each issue body ends with a hidden marker naming its bead, so
+pairs survive a lost mapping file.
+
+Runs are incremental: only issues GitHub reports as updated since the
+last sync are fetched, and only pairs whose side changed are written.
+When both sides changed and disagree, the pair is reported as a conflict
+and left alone; rerun with --prefer beads|github to resolve it.
+
+Label and status mapping is set in the town's settings/config.json:
+  {"github_sync": {
+    "status_labels": {"in_progress": "in progress", "blocked": "blocked"},
+    "labels": {"ux": "design"},
+    "types": ["task", "bug", "feature"]}}
+
+Priority syncs too when the town maps it to GitHub labels:
+  {"priorities": {"external": {"github": {"sev1": 0, "sev2": 1, "sev3": 3}}}}
+
+State is kept in <rig>/github.json, shared with gt import-github.
+Requires the gh CLI, authenticated for the repository.
+
+Examples:
+  gt sync-github gastown --repo steveyegge/gastown   # Link and sync
+  gt sync-github gastown
+  gt sync-github --dry-run
+  gt sync-github gastown --prefer github`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSyncGitHub,
+}
+
+func init() {
+	syncGitHubCmd.Flags().StringVar(&syncGitHubRepo, "repo", "", "GitHub repository (owner/name); required on the first sync")
+	syncGitHubCmd.Flags().StringVar(&syncGitHubPrefer, "prefer", "", "Resolve conflicts toward beads or github")
+	syncGitHubCmd.Flags().BoolVar(&syncGitHubDryRun, "dry-run", false, "Show what would change without writing either side")
+	syncGitHubCmd.Flags().BoolVar(&syncGitHubJSON, "json", false, "Output sync result as JSON")
+	rootCmd.AddCommand(syncGitHubCmd)
+}
+
+func runSyncGitHub(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	var rigName string
+	if len(args) > 0 {
+		rigName = args[0]
+	} else if rigName, err = inferRigFromCwd(townRoot); err != nil {
+		return fmt.Errorf("could not determine rig (pass one): %w", err)
+	}
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	repo := ""
+	if syncGitHubRepo != "" {
+		if repo, err = ghimport.RepoFromURL(syncGitHubRepo); err != nil {
+			return err
+		}
+	}
+	mapping, err := ghimport.LoadMapping(r.Path, repo)
+	if err != nil {
+		return err
+	}
+	if repo == "" {
+		if repo = mapping.Repo; repo == "" {
+			return fmt.Errorf("rig %s is not linked to a GitHub repository (pass --repo owner/name)", rigName)
+		}
+	}
+
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	// GitHub is an external tracker: only public beads may reach it
+	bd := beads.New(r.BeadsPath())
+	if err := bd.SetVisibilityScope(beads.VisibilityPublic); err != nil {
+		return err
+	}
+	s := &ghsync.Syncer{
+		GitHub: ghsync.GHClient{},
+		Beads:  bd,
+		Config: settings.GitHubSync,
+		Prefer: syncGitHubPrefer,
+		DryRun: syncGitHubDryRun,
+
+		Priorities: settings.Priorities,
+	}
+	result, syncErr := s.Sync(repo, mapping)
+	// Save even on partial failure so a re-run doesn't duplicate either side
+	if !syncGitHubDryRun && result != nil {
+		if err := mapping.Save(r.Path); err != nil {
+			return fmt.Errorf("saving %s: %w", ghimport.MappingFileName, err)
+		}
+	}
+	if syncErr != nil {
+		return syncErr
+	}
+
+	if syncGitHubJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	verb := "Synced"
+	if syncGitHubDryRun {
+		verb = "Would sync"
+	}
+	fmt.Printf("%s %s rig %s with %s\n", style.Success.Render("✓"), verb, style.Bold.Render(rigName), style.Bold.Render(repo))
+	fmt.Printf("  %d issue(s) imported, %d bead(s) exported, %d bead(s) updated, %d issue(s) updated\n",
+		len(result.Imported), len(result.Exported), len(result.BeadsUpdated), len(result.IssuesEdited))
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("\n%s %d conflict(s), left unchanged:\n", style.Warning.Render("⚠"), len(result.Conflicts))
+		for _, c := range result.Conflicts {
+			fmt.Printf("  %s ↔ #%d: %v\n", c.Bead, c.Issue, c.Fields)
+		}
+		fmt.Printf("  %s\n", style.Dim.Render("Edit one side to match, or rerun with --prefer beads|github"))
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/transcript"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	transcriptList bool
+	transcriptPath bool
+	transcriptRaw  bool
+	transcriptJSON bool
+)
+
+var transcriptCmd = &cobra.Command{
+	Use:     "transcript <bead>",
+	GroupID: GroupDiag,
+	Short:   "Show the session transcript of a bead's work",
+	Long: `Show the recorded session output of the agent that worked a bead.
+
+Polecat sessions are recorded from start to finish into transcript files
+under .runtime/transcripts/<session>/. When a polecat runs "gt done", its
+transcript is linked to the bead: in the transcript index, on the bead's
+transcript field, and in the done event. Sessions started for an issue
+are linked from the start.
+
+Secrets are redacted from pane output as it is recorded, each transcript
+is capped at 20 MB, and transcripts are removed after 30 days.
+
+By default the bead's most recent transcript is printed with terminal
+escape sequences stripped. A bead worked more than once (a respawned
+polecat, a reopened bead) has several; --list shows them all.
+
+Examples:
+  gt transcript gt-abc            # Latest transcript, as text
+  gt transcript gt-abc --list     # Every transcript linked to gt-abc
+  gt transcript gt-abc --path     # Just the file path
+  gt transcript gt-abc --raw | less -R`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTranscript,
+}
+
+// transcriptRecordCmd is what tmux pipes a session's pane output through
+// (see transcript.Start).
+var transcriptRecordCmd = &cobra.Command{
+	Use:    "record <town-root> <path>",
+	Short:  "Record pane output from stdin into a transcript",
+	Hidden: true,
+	Args:   cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return transcript.Record(args[0], args[1], os.Stdin)
+	},
+}
+
+func init() {
+	transcriptCmd.Flags().BoolVar(&transcriptList, "list", false, "List all transcripts linked to the bead")
+	transcriptCmd.Flags().BoolVar(&transcriptPath, "path", false, "Print the transcript path instead of its contents")
+	transcriptCmd.Flags().BoolVar(&transcriptRaw, "raw", false, "Keep terminal escape sequences")
+	transcriptCmd.Flags().BoolVar(&transcriptJSON, "json", false, "Output the list as JSON (with --list)")
+	transcriptCmd.AddCommand(transcriptRecordCmd)
+	rootCmd.AddCommand(transcriptCmd)
+}
+
+func runTranscript(cmd *cobra.Command, args []string) error {
+	beadID := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	entries, err := beadTranscripts(townRoot, beadID)
+	if err != nil {
+		return err
+	}
+
+	if transcriptList {
+		if transcriptJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(entries)
+		}
+		if len(entries) == 0 {
+			fmt.Printf("No transcripts linked to %s\n", beadID)
+			return nil
+		}
+		for _, e := range entries {
+			when := "-"
+			if !e.Timestamp.IsZero() {
+				when = e.Timestamp.Local().Format(time.DateTime)
+			}
+			fmt.Printf("%s  %s  %s\n", when, style.Dim.Render(e.Actor), e.Path)
+		}
+		return nil
+	}
+
+	if len(entries) == 0 {
+		return fmt.Errorf("no transcript linked to %s", beadID)
+	}
+	latest := entries[len(entries)-1]
+	if transcriptPath {
+		fmt.Println(latest.Path)
+		return nil
+	}
+
+	data, err := os.ReadFile(latest.Path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("transcript %s no longer exists (transcripts are removed after %d days)", latest.Path, int(transcript.Retention.Hours()/24))
+	}
+	if err != nil {
+		return fmt.Errorf("reading transcript: %w", err)
+	}
+	if !transcriptRaw {
+		data = transcript.Clean(data)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// beadTranscripts returns the transcripts linked to a bead, oldest first.
+// The index is authoritative; the bead's transcript field covers a link
+// made in another town's index or one lost with .runtime.
+func beadTranscripts(townRoot, beadID string) ([]transcript.Entry, error) {
+	entries, err := transcript.ForBead(townRoot, beadID)
+	if err != nil {
+		return nil, fmt.Errorf("reading transcript index: %w", err)
+	}
+
+	issue, err := beads.New(beads.ResolveHookDir(townRoot, beadID, "")).Show(beadID)
+	if err != nil {
+		return entries, nil
+	}
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil || fields.Transcript == "" {
+		return entries, nil
+	}
+	for _, e := range entries {
+		if e.Path == fields.Transcript {
+			return entries, nil
+		}
+	}
+	return append(entries, transcript.Entry{Bead: beadID, Actor: issue.Assignee, Path: fields.Transcript}), nil
+}
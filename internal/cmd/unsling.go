@@ -168,7 +168,7 @@ func runUnsling(cmd *cobra.Command, args []string) error {
 	}
 
 	// Log unhook event
-	_ = events.LogFeed(events.TypeUnhook, agentID, events.UnhookPayload(hookedBeadID))
+	_ = events.Log(events.TypeUnhook, agentID, events.UnhookPayload(hookedBeadID), events.VisibilityDefault)
 
 	fmt.Printf("%s Work removed from hook\n", style.Bold.Render("✓"))
 	fmt.Printf("  Agent %s hook cleared (was: %s)\n", agentID, hookedBeadID)
@@ -17,10 +17,8 @@ import (
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mayor"
 	"github.com/steveyegge/gastown/internal/polecat"
-	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
-	"github.com/steveyegge/gastown/internal/witness"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -46,6 +44,14 @@ Use --restore to also start:
   • Crew       - Per rig settings (settings/config.json crew.startup)
   • Polecats   - Those with pinned beads (work attached)
 
+Within each rig, agents start in dependency order: the refinery once the
+witness is ready, polecats once the refinery is. Override the order and
+the readiness timeout in the rig's settings/config.json:
+
+  "boot": {"after": {"crew": ["witness"]}, "ready_timeout": "90s"}
+
+An agent whose dependency fails to start or become ready is skipped.
+
 Running 'gt up' multiple times is safe - it only starts services that
 aren't already running.`,
 	RunE: runUp,
@@ -70,6 +76,9 @@ func runUp(cmd *cobra.Command, args []string) error {
 
 	allOK := true
 
+	// Resuming ends any drained halt that was interrupted
+	clearTownDraining(townRoot, discoverRigs(townRoot))
+
 	// 1. Daemon (Go process)
 	if err := ensureDaemon(townRoot); err != nil {
 		printStatus("Daemon", false, err.Error())
@@ -107,72 +116,29 @@ func runUp(cmd *cobra.Command, args []string) error {
 		printStatus("Mayor", true, mayorMgr.SessionName())
 	}
 
-	// 4. Witnesses (one per rig)
+	// 4-7. Witnesses and refineries, plus crew and polecats with pinned
+	// work (if --restore), in each rig's boot order
 	rigs := discoverRigs(townRoot)
+	agents := rigAgents
+	if upRestore {
+		agents = config.BootAgents
+	}
+	t := tmux.NewTmux()
 	for _, rigName := range rigs {
 		_, r, err := getRig(rigName)
 		if err != nil {
-			printStatus(fmt.Sprintf("Witness (%s)", rigName), false, err.Error())
+			printStatus(fmt.Sprintf("Rig (%s)", rigName), false, err.Error())
 			allOK = false
 			continue
 		}
-
-		mgr := witness.NewManager(r)
-		if err := mgr.Start(false); err != nil {
-			if err == witness.ErrAlreadyRunning {
-				printStatus(fmt.Sprintf("Witness (%s)", rigName), true, mgr.SessionName())
-			} else {
-				printStatus(fmt.Sprintf("Witness (%s)", rigName), false, err.Error())
-				allOK = false
-			}
-		} else {
-			printStatus(fmt.Sprintf("Witness (%s)", rigName), true, mgr.SessionName())
-		}
-	}
-
-	// 5. Refineries (one per rig)
-	for _, rigName := range rigs {
-		_, r, err := getRig(rigName)
+		outcomes, err := bootRig(t, townRoot, r, agents, func(string) {})
 		if err != nil {
-			printStatus(fmt.Sprintf("Refinery (%s)", rigName), false, err.Error())
+			printStatus(fmt.Sprintf("Rig (%s)", rigName), false, err.Error())
 			allOK = false
 			continue
 		}
-
-		mgr := refinery.NewManager(r)
-		if err := mgr.Start(false); err != nil {
-			if err == refinery.ErrAlreadyRunning {
-				printStatus(fmt.Sprintf("Refinery (%s)", rigName), true, mgr.SessionName())
-			} else {
-				printStatus(fmt.Sprintf("Refinery (%s)", rigName), false, err.Error())
-				allOK = false
-			}
-		} else {
-			printStatus(fmt.Sprintf("Refinery (%s)", rigName), true, mgr.SessionName())
-		}
-	}
-
-	// 6. Crew (if --restore)
-	if upRestore {
-		for _, rigName := range rigs {
-			crewStarted, crewErrors := startCrewFromSettings(townRoot, rigName)
-			for _, name := range crewStarted {
-				printStatus(fmt.Sprintf("Crew (%s/%s)", rigName, name), true, fmt.Sprintf("gt-%s-crew-%s", rigName, name))
-			}
-			for name, err := range crewErrors {
-				printStatus(fmt.Sprintf("Crew (%s/%s)", rigName, name), false, err.Error())
-				allOK = false
-			}
-		}
-
-		// 7. Polecats with pinned work (if --restore)
-		for _, rigName := range rigs {
-			polecatsStarted, polecatErrors := startPolecatsWithWork(townRoot, rigName)
-			for _, name := range polecatsStarted {
-				printStatus(fmt.Sprintf("Polecat (%s/%s)", rigName, name), true, fmt.Sprintf("gt-%s-polecat-%s", rigName, name))
-			}
-			for name, err := range polecatErrors {
-				printStatus(fmt.Sprintf("Polecat (%s/%s)", rigName, name), false, err.Error())
+		for _, out := range outcomes {
+			if !printBootOutcome(rigName, out) {
 				allOK = false
 			}
 		}
@@ -187,7 +153,7 @@ func runUp(cmd *cobra.Command, args []string) error {
 			startedServices = append(startedServices, fmt.Sprintf("%s/witness", rigName))
 			startedServices = append(startedServices, fmt.Sprintf("%s/refinery", rigName))
 		}
-		_ = events.LogFeed(events.TypeBoot, "gt", events.BootPayload("town", startedServices))
+		_ = events.Log(events.TypeBoot, "gt", events.BootPayload("town", startedServices), events.VisibilityDefault)
 	} else {
 		fmt.Printf("%s Some services failed to start\n", style.Bold.Render("✗"))
 		return fmt.Errorf("not all services started")
@@ -196,6 +162,38 @@ func runUp(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printBootOutcome prints how one rig agent fared and reports whether it
+// came up.
+func printBootOutcome(rigName string, out bootOutcome) bool {
+	switch out.Agent {
+	case config.BootAgentCrew, config.BootAgentPolecats:
+		// One line per session the group started
+		label := fmt.Sprintf("%s (%s)", bootAgentLabels[out.Agent], rigName)
+		for _, sess := range out.Sessions {
+			printStatus(label, true, sess)
+		}
+		if out.Err != nil {
+			printStatus(label, false, out.Err.Error())
+		}
+	default:
+		label := fmt.Sprintf("%s (%s)", bootAgentLabels[out.Agent], rigName)
+		if out.Err != nil {
+			printStatus(label, false, out.Err.Error())
+		} else {
+			printStatus(label, true, strings.Join(out.Sessions, ", "))
+		}
+	}
+	return out.Err == nil
+}
+
+// bootAgentLabels names the rig agents in gt up's status lines.
+var bootAgentLabels = map[string]string{
+	config.BootAgentWitness:  "Witness",
+	config.BootAgentRefinery: "Refinery",
+	config.BootAgentCrew:     "Crew",
+	config.BootAgentPolecats: "Polecat",
+}
+
 func printStatus(name string, ok bool, detail string) {
 	if upQuiet && ok {
 		return
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/schedule"
 )
 
 var (
@@ -211,6 +214,278 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if c.Sandbox != nil {
+		if err := validateSandboxConfig(c.Sandbox); err != nil {
+			return err
+		}
+	}
+	if c.Boot != nil {
+		if err := validateBootConfig(c.Boot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrInvalidPriority indicates a priority level outside bd's range.
+var ErrInvalidPriority = errors.New("invalid priority level")
+
+// validatePriorityConfig checks that all levels and mappings use bd values.
+func validatePriorityConfig(c *PriorityConfig) error {
+	for _, l := range c.Levels {
+		if l.Name == "" {
+			return fmt.Errorf("%w: level %d has no name", ErrInvalidPriority, l.Value)
+		}
+		if l.Value < MinPriority || l.Value > MaxPriority {
+			return fmt.Errorf("%w: %s=%d (want %d-%d)", ErrInvalidPriority, l.Name, l.Value, MinPriority, MaxPriority)
+		}
+	}
+	for tracker, m := range c.External {
+		for label, v := range m {
+			if v < MinPriority || v > MaxPriority {
+				return fmt.Errorf("%w: %s %q=%d (want %d-%d)", ErrInvalidPriority, tracker, label, v, MinPriority, MaxPriority)
+			}
+		}
+	}
+	_, err := c.AgingThresholds()
+	return err
+}
+
+// validateFeedConfig validates a FeedConfig. Nil is valid.
+func validateFeedConfig(c *FeedConfig) error {
+	if c == nil {
+		return nil
+	}
+	for _, v := range c.Visibility {
+		if v != "audit" && v != "feed" && v != "both" {
+			return fmt.Errorf("invalid feed visibility %q (want audit, feed, or both)", v)
+		}
+	}
+	if _, _, err := c.RateLimitSpec(); err != nil {
+		return err
+	}
+	_, err := c.NudgeCoalesceWindow(0)
+	return err
+}
+
+// validateEventVisibility validates the event_log.visibility overrides.
+func validateEventVisibility(c *EventLogConfig) error {
+	if c == nil {
+		return nil
+	}
+	for typ, v := range c.Visibility {
+		if v != "audit" && v != "feed" && v != "both" {
+			return fmt.Errorf("invalid event_log visibility %q for %s (want audit, feed, or both)", v, typ)
+		}
+	}
+	return nil
+}
+
+// validateRedactionConfig checks that the redaction patterns compile.
+func validateRedactionConfig(c *RedactionConfig) error {
+	if c == nil {
+		return nil
+	}
+	for _, p := range c.Patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// validateGitHubSyncConfig checks that no two mappings claim the same
+// GitHub label, which would make the reverse mapping ambiguous.
+func validateGitHubSyncConfig(c *GitHubSyncConfig) error {
+	if c == nil {
+		return nil
+	}
+	claimed := make(map[string]string)
+	claim := func(kind, key, label string) error {
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("github_sync.%s: %q maps to an empty label", kind, key)
+		}
+		if prev, ok := claimed[label]; ok {
+			return fmt.Errorf("github_sync.%s: %q and %s both map to label %q", kind, key, prev, label)
+		}
+		claimed[label] = fmt.Sprintf("%q", key)
+		return nil
+	}
+	for _, status := range sortedKeys(c.StatusLabels) {
+		if status == "closed" {
+			return fmt.Errorf("github_sync.status_labels: closed beads sync as closed issues and take no label")
+		}
+		if err := claim("status_labels", status, c.StatusLabels[status]); err != nil {
+			return err
+		}
+	}
+	for _, label := range sortedKeys(c.Labels) {
+		if err := claim("labels", label, c.Labels[label]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns m's keys in order, for deterministic error messages.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateNotifyConfig checks that every channel is a usable webhook and
+// every route names one.
+func validateNotifyConfig(c *NotifyConfig) error {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Channels))
+	for name := range c.Channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ch := c.Channels[name]
+		if ch.Type != NotifySlack && ch.Type != NotifyDiscord {
+			return fmt.Errorf("notify.channels.%s: unknown type %q (want slack or discord)", name, ch.Type)
+		}
+		if ch.URL == "" {
+			return fmt.Errorf("notify.channels.%s: needs a webhook url", name)
+		}
+	}
+	for i, r := range c.Routes {
+		if _, ok := c.Channels[r.Channel]; !ok {
+			return fmt.Errorf("notify.routes[%d]: unknown channel %q", i, r.Channel)
+		}
+		switch r.MinSeverity {
+		case "", "debug", "info", "warn", "error":
+		default:
+			return fmt.Errorf("notify.routes[%d]: invalid min_severity %q (want debug, info, warn, or error)", i, r.MinSeverity)
+		}
+	}
+	return nil
+}
+
+// validateBudgetConfig checks that limits are non-negative and actions known.
+func validateBudgetConfig(c *BudgetConfig) error {
+	if c == nil {
+		return nil
+	}
+	for _, l := range []struct {
+		name  string
+		limit *BudgetLimit
+	}{{"bead", c.Bead}, {"agent", c.Agent}} {
+		if l.limit == nil {
+			continue
+		}
+		if l.limit.USD < 0 || l.limit.Tokens < 0 {
+			return fmt.Errorf("budgets.%s: limits must not be negative", l.name)
+		}
+		switch l.limit.Action {
+		case "", BudgetWarn, BudgetHalt:
+		default:
+			return fmt.Errorf("budgets.%s: invalid action %q (want warn or halt)", l.name, l.limit.Action)
+		}
+	}
+	return nil
+}
+
+// validateSchedules checks every schedule rule (see ValidateScheduleRule).
+func validateSchedules(rules map[string]*ScheduleRule) error {
+	for name, r := range rules {
+		if err := ValidateScheduleRule(name, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateScheduleRule checks that a rule has a usable name and title, a
+// cron that parses, a known zone and catch-up policy, and a valid
+// priority.
+func ValidateScheduleRule(name string, r *ScheduleRule) error {
+	if name == "" || strings.ContainsAny(name, ", @\t\n") {
+		return fmt.Errorf("schedules: invalid rule name %q (no spaces, commas or @)", name)
+	}
+	if r == nil {
+		return fmt.Errorf("schedules.%s: empty rule", name)
+	}
+	if strings.TrimSpace(r.Title) == "" {
+		return fmt.Errorf("schedules.%s: title is required", name)
+	}
+	if _, err := schedule.Parse(r.Cron); err != nil {
+		return fmt.Errorf("schedules.%s: %w", name, err)
+	}
+	if _, err := r.Location(); err != nil {
+		return fmt.Errorf("schedules.%s: invalid timezone %q", name, r.Timezone)
+	}
+	if p := r.PriorityOrDefault(); p < MinPriority || p > MaxPriority {
+		return fmt.Errorf("schedules.%s: priority %d out of range %d-%d", name, p, MinPriority, MaxPriority)
+	}
+	switch r.CatchUp {
+	case "", CatchUpLatest, CatchUpSkip:
+	default:
+		return fmt.Errorf("schedules.%s: invalid catch_up %q (want latest or skip)", name, r.CatchUp)
+	}
+	return nil
+}
+
+// validateEventSinks validates the configured event sinks.
+func validateEventSinks(sinks []EventSinkConfig) error {
+	for i, c := range sinks {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("event_sinks[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ErrInvalidSandboxMode indicates an unknown sandbox enforcement mode.
+var ErrInvalidSandboxMode = errors.New("invalid sandbox mode")
+
+// validateSandboxConfig validates a SandboxConfig.
+func validateSandboxConfig(c *SandboxConfig) error {
+	valid := func(mode string) bool {
+		switch mode {
+		case "", SandboxOff, SandboxWarn, SandboxBlock:
+			return true
+		}
+		return false
+	}
+	if !valid(c.Mode) {
+		return fmt.Errorf("%w: %q", ErrInvalidSandboxMode, c.Mode)
+	}
+	for role, mode := range c.Roles {
+		if !valid(mode) {
+			return fmt.Errorf("%w: %q for role %s", ErrInvalidSandboxMode, mode, role)
+		}
+	}
+	return nil
+}
+
+// ErrInvalidBootConfig indicates unknown agents or a cycle in boot ordering.
+var ErrInvalidBootConfig = errors.New("invalid boot config")
+
+// validateBootConfig checks that boot ordering names only rig agents and
+// has no dependency cycle.
+func validateBootConfig(c *BootConfig) error {
+	for agent, after := range c.After {
+		for _, name := range append([]string{agent}, after...) {
+			if !slices.Contains(BootAgents, name) {
+				return fmt.Errorf("%w: unknown agent %q (want one of %s)", ErrInvalidBootConfig, name, strings.Join(BootAgents, ", "))
+			}
+		}
+	}
+	if _, err := c.Stages(BootAgents); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBootConfig, err)
+	}
+	if _, err := c.ReadyTimeoutDuration(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBootConfig, err)
+	}
 	return nil
 }
 
@@ -426,6 +701,34 @@ func validateDaemonPatrolConfig(c *DaemonPatrolConfig) error {
 	if c.Version > CurrentDaemonPatrolConfigVersion {
 		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentDaemonPatrolConfigVersion)
 	}
+	if c.NudgeEscalation != nil {
+		if err := validateNudgeEscalationConfig(c.NudgeEscalation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrInvalidNudgeEscalation indicates an invalid nudge escalation policy.
+var ErrInvalidNudgeEscalation = errors.New("invalid nudge escalation policy")
+
+func validateNudgeEscalationConfig(c *NudgeEscalationConfig) error {
+	if c.Wait != "" {
+		if d, err := time.ParseDuration(c.Wait); err != nil || d <= 0 {
+			return fmt.Errorf("%w: wait %q is not a positive duration", ErrInvalidNudgeEscalation, c.Wait)
+		}
+	}
+	if c.Backoff != 0 && c.Backoff < 1 {
+		return fmt.Errorf("%w: backoff %v is below 1", ErrInvalidNudgeEscalation, c.Backoff)
+	}
+	if c.Renudges != nil && *c.Renudges < 0 {
+		return fmt.Errorf("%w: renudges %d is negative", ErrInvalidNudgeEscalation, *c.Renudges)
+	}
+	switch c.Final {
+	case "", NudgeActionKill, NudgeActionRespawn:
+	default:
+		return fmt.Errorf("%w: final %q (want %q, %q or empty)", ErrInvalidNudgeEscalation, c.Final, NudgeActionKill, NudgeActionRespawn)
+	}
 	return nil
 }
 
@@ -761,6 +1064,20 @@ func LoadOrCreateTownSettings(path string) (*TownSettings, error) {
 	if err := json.Unmarshal(data, &settings); err != nil {
 		return nil, err
 	}
+	if settings.Priorities != nil {
+		if err := validatePriorityConfig(settings.Priorities); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateGitHubSyncConfig(settings.GitHubSync); err != nil {
+		return nil, err
+	}
+	if err := validateNotifyConfig(settings.Notify); err != nil {
+		return nil, err
+	}
+	if err := validateBudgetConfig(settings.Budgets); err != nil {
+		return nil, err
+	}
 	return &settings, nil
 }
 
@@ -772,6 +1089,41 @@ func SaveTownSettings(path string, settings *TownSettings) error {
 	if settings.Version > CurrentTownSettingsVersion {
 		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, settings.Version, CurrentTownSettingsVersion)
 	}
+	if settings.Priorities != nil {
+		if err := validatePriorityConfig(settings.Priorities); err != nil {
+			return err
+		}
+	}
+	if err := validateFeedConfig(settings.Feed); err != nil {
+		return err
+	}
+	if _, err := settings.EventLog.RetentionWindow(); err != nil {
+		return err
+	}
+	if err := validateEventVisibility(settings.EventLog); err != nil {
+		return err
+	}
+	if err := validateEventSinks(settings.EventSinks); err != nil {
+		return err
+	}
+	if err := validateRedactionConfig(settings.Redaction); err != nil {
+		return err
+	}
+	if err := validateGitHubSyncConfig(settings.GitHubSync); err != nil {
+		return err
+	}
+	if err := validateNotifyConfig(settings.Notify); err != nil {
+		return err
+	}
+	if err := validateBudgetConfig(settings.Budgets); err != nil {
+		return err
+	}
+	if err := validateSchedules(settings.Schedules); err != nil {
+		return err
+	}
+	if err := ValidatePermissions(settings.Permissions); err != nil {
+		return err
+	}
 
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
@@ -1151,7 +1503,8 @@ func BuildAgentStartupCommandWithAgentOverride(role, bdActor, rigPath, prompt, a
 }
 
 // BuildPolecatStartupCommand builds the startup command for a polecat.
-// Sets GT_ROLE, GT_RIG, GT_POLECAT, BD_ACTOR, and GIT_AUTHOR_NAME.
+// Sets GT_ROLE, GT_RIG, GT_POLECAT, BD_ACTOR, and GIT_AUTHOR_NAME, and
+// passes on the caller's event trace (see addTraceEnv).
 func BuildPolecatStartupCommand(rigName, polecatName, rigPath, prompt string) string {
 	bdActor := fmt.Sprintf("%s/polecats/%s", rigName, polecatName)
 	envVars := map[string]string{
@@ -1161,6 +1514,7 @@ func BuildPolecatStartupCommand(rigName, polecatName, rigPath, prompt string) st
 		"BD_ACTOR":        bdActor,
 		"GIT_AUTHOR_NAME": polecatName,
 	}
+	addTraceEnv(envVars)
 	return BuildStartupCommand(envVars, rigPath, prompt)
 }
 
@@ -1174,6 +1528,7 @@ func BuildPolecatStartupCommandWithAgentOverride(rigName, polecatName, rigPath,
 		"BD_ACTOR":        bdActor,
 		"GIT_AUTHOR_NAME": polecatName,
 	}
+	addTraceEnv(envVars)
 	return BuildStartupCommandWithAgentOverride(envVars, rigPath, prompt, agentOverride)
 }
 
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"os"
+	"os/user"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -310,6 +312,33 @@ func TestRigSettingsValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "boot after unknown agent",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Boot:    &BootConfig{After: map[string][]string{"refinery": {"mayor"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "boot dependency cycle",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Boot:    &BootConfig{After: map[string][]string{"witness": {"polecats"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid boot ready_timeout",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Boot:    &BootConfig{ReadyTimeout: "soon"},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -912,6 +941,16 @@ func TestBuildPolecatStartupCommand(t *testing.T) {
 	if !strings.Contains(cmd, "BD_ACTOR=gastown/polecats/toast") {
 		t.Error("expected BD_ACTOR in command")
 	}
+	if strings.Contains(cmd, EnvTraceID) {
+		t.Error("unexpected trace env outside a trace")
+	}
+
+	t.Setenv(EnvTraceID, "abc123")
+	t.Setenv(EnvParentEvent, "def456")
+	cmd = BuildPolecatStartupCommand("gastown", "toast", "", "")
+	if !strings.Contains(cmd, "GT_TRACE_ID=abc123") || !strings.Contains(cmd, "GT_PARENT_EVENT=def456") {
+		t.Errorf("expected trace env in command, got %q", cmd)
+	}
 }
 
 func TestBuildCrewStartupCommand(t *testing.T) {
@@ -1293,6 +1332,27 @@ func TestDaemonPatrolConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid nudge escalation",
+			config: &DaemonPatrolConfig{
+				NudgeEscalation: &NudgeEscalationConfig{Enabled: true, Wait: "5m", Backoff: 1.5, Final: NudgeActionRespawn},
+			},
+			wantErr: false,
+		},
+		{
+			name: "nudge escalation with unknown final step",
+			config: &DaemonPatrolConfig{
+				NudgeEscalation: &NudgeEscalationConfig{Enabled: true, Final: "restart"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nudge escalation with shrinking backoff",
+			config: &DaemonPatrolConfig{
+				NudgeEscalation: &NudgeEscalationConfig{Enabled: true, Backoff: 0.5},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1305,6 +1365,33 @@ func TestDaemonPatrolConfigValidation(t *testing.T) {
 	}
 }
 
+func TestNudgeEscalationPolicy(t *testing.T) {
+	var nilCfg *NudgeEscalationConfig
+	if got := strings.Join(nilCfg.Steps(), ","); got != "renudge,renudge,notify" {
+		t.Errorf("default Steps() = %s", got)
+	}
+	if got := nilCfg.WaitBefore(2); got != 40*time.Minute {
+		t.Errorf("default WaitBefore(2) = %v, want 40m", got)
+	}
+	if got := nilCfg.Horizon(); got != 70*time.Minute {
+		t.Errorf("default Horizon() = %v, want 1h10m", got)
+	}
+
+	none := 0
+	cfg := &NudgeEscalationConfig{Wait: "1m", Backoff: 3, Renudges: &none, Final: NudgeActionKill}
+	if got := strings.Join(cfg.Steps(), ","); got != "notify,kill" {
+		t.Errorf("Steps() = %s, want notify,kill", got)
+	}
+	for step, want := range []time.Duration{time.Minute, 3 * time.Minute, 9 * time.Minute} {
+		if got := cfg.WaitBefore(step); got != want {
+			t.Errorf("WaitBefore(%d) = %v, want %v", step, got, want)
+		}
+	}
+	if got := cfg.Horizon(); got != 4*time.Minute {
+		t.Errorf("Horizon() = %v, want 4m", got)
+	}
+}
+
 func TestLoadDaemonPatrolConfigNotFound(t *testing.T) {
 	_, err := LoadDaemonPatrolConfig("/nonexistent/path.json")
 	if err == nil {
@@ -1558,3 +1645,255 @@ func TestSaveTownSettings(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateEventSinks(t *testing.T) {
+	valid := []EventSinkConfig{
+		{Type: EventSinkWebhook, URL: "https://ops.example.com/gt"},
+		{Type: EventSinkKafka, URL: "http://kafka-rest:8082", Topic: "gt-events"},
+		{Type: EventSinkSyslog},
+		{Type: EventSinkSyslog, Address: "udp://logs:514"},
+	}
+	if err := validateEventSinks(valid); err != nil {
+		t.Errorf("valid sinks: %v", err)
+	}
+	for _, bad := range []EventSinkConfig{
+		{Type: EventSinkWebhook},
+		{Type: EventSinkKafka, URL: "http://kafka-rest:8082"},
+		{Type: EventSinkSyslog, Address: "logs:514"},
+		{Type: "carrier-pigeon"},
+	} {
+		if err := validateEventSinks([]EventSinkConfig{bad}); err == nil {
+			t.Errorf("%+v: expected an error", bad)
+		}
+	}
+}
+
+// TestLoadTownSettingsBadSection checks that a section only its consumer
+// can use doesn't keep every other command from loading the settings.
+func TestLoadTownSettingsBadSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"type": "town-settings", "version": 1, "default_agent": "codex",
+		"event_sinks": [{"type": "carrier-pigeon"}],
+		"feed": {"rate_limit": "often"},
+		"event_log": {"retention": "a while", "visibility": {"done": "loud"}},
+		"redaction": {"patterns": ["("]},
+		"schedules": {"broken": {"cron": "every morning", "title": "Broken"}},
+		"permissions": {"launch_missiles": ["overseer"]}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := LoadOrCreateTownSettings(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateTownSettings: %v", err)
+	}
+	if settings.DefaultAgent != "codex" {
+		t.Errorf("DefaultAgent = %q", settings.DefaultAgent)
+	}
+	if err := SaveTownSettings(path, settings); err == nil {
+		t.Error("SaveTownSettings accepted an invalid section")
+	}
+}
+
+func TestValidateGitHubSyncConfig(t *testing.T) {
+	valid := &GitHubSyncConfig{
+		StatusLabels: map[string]string{"in_progress": "wip"},
+		Labels:       map[string]string{"ux": "design", "security": "security"},
+	}
+	if err := validateGitHubSyncConfig(valid); err != nil {
+		t.Errorf("valid config: %v", err)
+	}
+	for _, bad := range []*GitHubSyncConfig{
+		{StatusLabels: map[string]string{"closed": "done"}},
+		{Labels: map[string]string{"ux": " "}},
+		{StatusLabels: map[string]string{"blocked": "design"}, Labels: map[string]string{"ux": "design"}},
+	} {
+		if err := validateGitHubSyncConfig(bad); err == nil {
+			t.Errorf("%+v: expected an error", bad)
+		}
+	}
+}
+
+func TestValidateNotifyConfig(t *testing.T) {
+	channels := map[string]NotifyChannel{
+		"ops":      {Type: NotifySlack, URL: "https://hooks.slack.com/x"},
+		"refinery": {Type: NotifyDiscord, URL: "https://discord.com/api/webhooks/x"},
+	}
+	valid := &NotifyConfig{Channels: channels, Routes: []NotifyRoute{
+		{Channel: "refinery", Types: []string{"merge_conflict"}},
+		{Channel: "ops", MinSeverity: "warn"},
+	}}
+	if err := validateNotifyConfig(valid); err != nil {
+		t.Errorf("valid config: %v", err)
+	}
+	for _, bad := range []*NotifyConfig{
+		{Channels: map[string]NotifyChannel{"x": {Type: "irc", URL: "irc://x"}}},
+		{Channels: map[string]NotifyChannel{"x": {Type: NotifySlack}}},
+		{Channels: channels, Routes: []NotifyRoute{{Channel: "nowhere"}}},
+		{Channels: channels, Routes: []NotifyRoute{{Channel: "ops", MinSeverity: "loud"}}},
+	} {
+		if err := validateNotifyConfig(bad); err == nil {
+			t.Errorf("%+v: expected an error", bad)
+		}
+	}
+}
+
+func TestValidateBudgetConfig(t *testing.T) {
+	valid := &BudgetConfig{
+		Bead:  &BudgetLimit{USD: 20, Action: BudgetHalt},
+		Agent: &BudgetLimit{Tokens: 5_000_000},
+	}
+	if err := validateBudgetConfig(valid); err != nil {
+		t.Errorf("valid config: %v", err)
+	}
+	for _, bad := range []*BudgetConfig{
+		{Bead: &BudgetLimit{USD: -1}},
+		{Agent: &BudgetLimit{USD: 5, Action: "panic"}},
+	} {
+		if err := validateBudgetConfig(bad); err == nil {
+			t.Errorf("%+v: expected an error", bad)
+		}
+	}
+}
+
+func TestValidateSchedules(t *testing.T) {
+	p0 := 0
+	valid := map[string]*ScheduleRule{
+		"weekly-deps": {Cron: "0 9 * * mon", Title: "Weekly deps audit", Priority: &p0, Timezone: "UTC"},
+		"nightly":     {Cron: "@daily", Title: "Nightly sweep {date}", CatchUp: CatchUpSkip},
+	}
+	if err := validateSchedules(valid); err != nil {
+		t.Errorf("valid schedules: %v", err)
+	}
+	p9 := 9
+	for name, bad := range map[string]*ScheduleRule{
+		"no-title":   {Cron: "@daily"},
+		"bad-cron":   {Cron: "0 25 * * *", Title: "x"},
+		"bad-zone":   {Cron: "@daily", Title: "x", Timezone: "Mars/Olympus"},
+		"bad-prio":   {Cron: "@daily", Title: "x", Priority: &p9},
+		"bad-catch":  {Cron: "@daily", Title: "x", CatchUp: "all"},
+		"bad name":   {Cron: "@daily", Title: "x"},
+		"empty-rule": nil,
+	} {
+		if err := validateSchedules(map[string]*ScheduleRule{name: bad}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestValidatePermissions(t *testing.T) {
+	valid := Permissions{
+		OpCloseEpic: {RoleOverseer, "mayor"},
+		OpHaltRig:   {AnyRole},
+	}
+	if err := ValidatePermissions(valid); err != nil {
+		t.Errorf("valid permissions: %v", err)
+	}
+	for name, bad := range map[string]Permissions{
+		"unknown op":   {"merge": {"refinery"}},
+		"unknown role": {OpDeleteBead: {"dog"}},
+		"no roles":     {OpDeleteBead: {}},
+	} {
+		if err := ValidatePermissions(bad); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPermissionsCheck(t *testing.T) {
+	p := Permissions{OpCloseEpic: {RoleOverseer, "mayor"}, OpHaltRig: {AnyRole}}
+	if err := p.Check(OpCloseEpic, "mayor"); err != nil {
+		t.Errorf("mayor close_epic: %v", err)
+	}
+	if err := p.Check(OpHaltRig, "polecat"); err != nil {
+		t.Errorf("polecat halt_rig (*): %v", err)
+	}
+	if err := p.Check(OpDeleteBead, "polecat"); err != nil {
+		t.Errorf("polecat delete_bead (unlisted): %v", err)
+	}
+	err := p.Check(OpCloseEpic, "polecat")
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("polecat close_epic err = %v, want ErrPermissionDenied", err)
+	}
+	if !strings.Contains(err.Error(), "overseer, mayor") {
+		t.Errorf("error %q should name the allowed roles", err)
+	}
+	if err := Permissions(nil).Check(OpCloseEpic, "polecat"); err != nil {
+		t.Errorf("nil permissions: %v", err)
+	}
+}
+
+func TestBootStages(t *testing.T) {
+	tests := []struct {
+		name   string
+		boot   *BootConfig
+		agents []string
+		want   string
+	}{
+		{"defaults", nil, BootAgents, "witness crew | refinery | polecats"},
+		{"refinery alone", nil, []string{BootAgentRefinery}, "refinery"},
+		{"polecats without refinery", nil, []string{BootAgentWitness, BootAgentPolecats}, "witness polecats"},
+		{"override", &BootConfig{After: map[string][]string{
+			BootAgentRefinery: {},
+			BootAgentCrew:     {BootAgentPolecats},
+		}}, BootAgents, "witness refinery | polecats | crew"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stages, err := tt.boot.Stages(tt.agents)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, stage := range stages {
+				got = append(got, strings.Join(stage, " "))
+			}
+			if strings.Join(got, " | ") != tt.want {
+				t.Errorf("Stages = %q, want %q", strings.Join(got, " | "), tt.want)
+			}
+		})
+	}
+
+	cyclic := &BootConfig{After: map[string][]string{BootAgentWitness: {BootAgentRefinery}}}
+	if _, err := cyclic.Stages(BootAgents); err == nil || !strings.Contains(err.Error(), "cycle among witness, refinery, polecats") {
+		t.Errorf("cyclic Stages error = %v", err)
+	}
+}
+
+func TestIsObserver(t *testing.T) {
+	u, err := user.Current()
+	if err != nil {
+		t.Skip("no current user")
+	}
+	townRoot := t.TempDir()
+	path := TownSettingsPath(townRoot)
+	write := func(data string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv(EnvObserver, "")
+	if IsObserver(townRoot) {
+		t.Error("observer with no settings")
+	}
+	t.Setenv(EnvObserver, "1")
+	if !IsObserver(townRoot) {
+		t.Error("GT_OBSERVER=1 did not force observer mode")
+	}
+
+	write(`{"observers": ["` + u.Username + `"]}`)
+	t.Setenv(EnvObserver, "0")
+	if !IsObserver(townRoot) {
+		t.Error("GT_OBSERVER=0 lifted the configured observer list")
+	}
+
+	write(`{"observers": [`)
+	t.Setenv(EnvObserver, "")
+	if !IsObserver(townRoot) {
+		t.Error("unreadable settings should fail closed")
+	}
+}
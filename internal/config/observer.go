@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+)
+
+// EnvObserver forces observer (read-only) mode when set to a true value,
+// e.g. GT_OBSERVER=1 in a stakeholder's shell profile. A false value does
+// not lift the town's own observer list.
+const EnvObserver = "GT_OBSERVER"
+
+// IsObserver reports whether gt should run read-only for the current user:
+// either EnvObserver is set, or the user is listed in the town's
+// settings/config.json "observers". It fails closed: when the settings or
+// the current user can't be read, the user is treated as an observer.
+func IsObserver(townRoot string) bool {
+	if v, err := strconv.ParseBool(os.Getenv(EnvObserver)); err == nil && v {
+		return true
+	}
+	if townRoot == "" {
+		return false
+	}
+	settings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot))
+	if err != nil {
+		return true
+	}
+	if len(settings.Observers) == 0 {
+		return false
+	}
+	u, err := user.Current()
+	if err != nil {
+		return true
+	}
+	return slices.Contains(settings.Observers, u.Username)
+}
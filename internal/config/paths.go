@@ -0,0 +1,138 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Default file names for town-level logs.
+const (
+	DefaultEventsFile = ".events.jsonl"
+	DefaultFeedFile   = ".feed.jsonl"
+	DefaultAuditLog   = "audit.log"
+)
+
+// EnvTownRoot overrides the default town location used by gt install.
+const EnvTownRoot = "GT_TOWN_ROOT"
+
+// Paths is the resolved storage layout for a town.
+// Use LoadPaths rather than joining file names onto the town root at each
+// call site, so settings/config.json overrides apply everywhere.
+type Paths struct {
+	TownRoot   string
+	EventsFile string
+	FeedFile   string
+	AuditLog   string // file name within .beads/, or an absolute path
+	StateDir   string
+	CacheDir   string
+}
+
+// AuditLogPath returns the audit log location for a beads directory.
+func (p *Paths) AuditLogPath(beadsDir string) string {
+	if filepath.IsAbs(p.AuditLog) {
+		return p.AuditLog
+	}
+	return filepath.Join(beadsDir, p.AuditLog)
+}
+
+var (
+	pathsMu    sync.Mutex
+	pathsCache = make(map[string]*Paths)
+)
+
+// LoadPaths returns the storage layout for a town, applying any overrides
+// from the town settings file. Results are cached per town root.
+func LoadPaths(townRoot string) *Paths {
+	pathsMu.Lock()
+	defer pathsMu.Unlock()
+
+	if p, ok := pathsCache[townRoot]; ok {
+		return p
+	}
+
+	var storage *StorageConfig
+	if settings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot)); err == nil {
+		storage = settings.Storage
+	}
+	p := ResolvePaths(townRoot, storage)
+	pathsCache[townRoot] = p
+	return p
+}
+
+// ResetPathsCache clears cached layouts (for tests and after settings edits).
+func ResetPathsCache() {
+	pathsMu.Lock()
+	defer pathsMu.Unlock()
+	pathsCache = make(map[string]*Paths)
+}
+
+// ResolvePaths computes the storage layout from an optional StorageConfig.
+func ResolvePaths(townRoot string, storage *StorageConfig) *Paths {
+	if storage == nil {
+		storage = &StorageConfig{}
+	}
+	townName := filepath.Base(townRoot)
+
+	resolve := func(path, def string) string {
+		if path == "" {
+			return def
+		}
+		path = expandPath(path)
+		if filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(townRoot, path)
+	}
+
+	auditLog := storage.AuditLog
+	if auditLog == "" {
+		auditLog = DefaultAuditLog
+	} else {
+		auditLog = expandPath(auditLog)
+	}
+
+	return &Paths{
+		TownRoot:   townRoot,
+		EventsFile: resolve(storage.EventsFile, filepath.Join(townRoot, DefaultEventsFile)),
+		FeedFile:   resolve(storage.FeedFile, filepath.Join(townRoot, DefaultFeedFile)),
+		AuditLog:   auditLog,
+		StateDir:   resolve(storage.StateDir, filepath.Join(XDGStateHome(), "gastown", townName)),
+		CacheDir:   resolve(storage.CacheDir, filepath.Join(XDGCacheHome(), "gastown", townName)),
+	}
+}
+
+// DefaultTownRoot returns the default town location: $GT_TOWN_ROOT if set,
+// otherwise ~/gt.
+func DefaultTownRoot() string {
+	if root := os.Getenv(EnvTownRoot); root != "" {
+		return expandPath(root)
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "gt")
+}
+
+// XDGConfigHome returns $XDG_CONFIG_HOME or ~/.config.
+func XDGConfigHome() string {
+	return xdgDir("XDG_CONFIG_HOME", ".config")
+}
+
+// XDGStateHome returns $XDG_STATE_HOME or ~/.local/state.
+func XDGStateHome() string {
+	return xdgDir("XDG_STATE_HOME", filepath.Join(".local", "state"))
+}
+
+// XDGCacheHome returns $XDG_CACHE_HOME or ~/.cache.
+func XDGCacheHome() string {
+	return xdgDir("XDG_CACHE_HOME", ".cache")
+}
+
+// xdgDir returns the XDG base directory from env, falling back to ~/<def>.
+// Per the spec, relative values in the environment are ignored.
+func xdgDir(env, def string) string {
+	if dir := os.Getenv(env); dir != "" && filepath.IsAbs(dir) {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, def)
+}
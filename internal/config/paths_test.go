@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePathsDefaults(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/xdg/state")
+	t.Setenv("XDG_CACHE_HOME", "/xdg/cache")
+
+	p := ResolvePaths("/home/u/gt", nil)
+
+	if p.EventsFile != "/home/u/gt/.events.jsonl" {
+		t.Errorf("EventsFile = %q", p.EventsFile)
+	}
+	if p.FeedFile != "/home/u/gt/.feed.jsonl" {
+		t.Errorf("FeedFile = %q", p.FeedFile)
+	}
+	if p.StateDir != "/xdg/state/gastown/gt" {
+		t.Errorf("StateDir = %q", p.StateDir)
+	}
+	if p.CacheDir != "/xdg/cache/gastown/gt" {
+		t.Errorf("CacheDir = %q", p.CacheDir)
+	}
+	if got := p.AuditLogPath("/home/u/gt/rig/.beads"); got != "/home/u/gt/rig/.beads/audit.log" {
+		t.Errorf("AuditLogPath = %q", got)
+	}
+}
+
+func TestResolvePathsOverrides(t *testing.T) {
+	p := ResolvePaths("/town", &StorageConfig{
+		EventsFile: "logs/events.jsonl",
+		FeedFile:   "/var/log/gt/feed.jsonl",
+		AuditLog:   "/var/log/gt/audit.log",
+	})
+
+	if p.EventsFile != "/town/logs/events.jsonl" {
+		t.Errorf("relative EventsFile = %q", p.EventsFile)
+	}
+	if p.FeedFile != "/var/log/gt/feed.jsonl" {
+		t.Errorf("absolute FeedFile = %q", p.FeedFile)
+	}
+	if got := p.AuditLogPath("/town/rig/.beads"); got != "/var/log/gt/audit.log" {
+		t.Errorf("shared AuditLogPath = %q", got)
+	}
+}
+
+func TestXDGIgnoresRelative(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "relative/dir")
+	home, _ := os.UserHomeDir()
+	if got := XDGConfigHome(); got != filepath.Join(home, ".config") {
+		t.Errorf("XDGConfigHome() = %q, want fallback", got)
+	}
+}
+
+func TestLoadPathsFromSettings(t *testing.T) {
+	ResetPathsCache()
+	defer ResetPathsCache()
+
+	town := t.TempDir()
+	settings := NewTownSettings()
+	settings.Storage = &StorageConfig{EventsFile: "custom.jsonl"}
+	if err := SaveTownSettings(TownSettingsPath(town), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := LoadPaths(town).EventsFile; got != filepath.Join(town, "custom.jsonl") {
+		t.Errorf("EventsFile = %q", got)
+	}
+}
+
+func TestDefaultTownRoot(t *testing.T) {
+	t.Setenv(EnvTownRoot, "/opt/town")
+	if got := DefaultTownRoot(); got != "/opt/town" {
+		t.Errorf("DefaultTownRoot() = %q", got)
+	}
+}
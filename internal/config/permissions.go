@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Operations the town's permissions can restrict to certain roles.
+const (
+	OpCloseEpic      = "close_epic"
+	OpDeleteBead     = "delete_bead"
+	OpDetachMolecule = "detach_molecule"
+	OpHaltRig        = "halt_rig"
+)
+
+// RoleOverseer is the role permissions use for the human operator: gt run
+// outside any agent session.
+const RoleOverseer = "overseer"
+
+// AnyRole in an operation's role list allows every role.
+const AnyRole = "*"
+
+// permissionOps and permissionRoles are the operations and roles
+// permissions may name.
+var (
+	permissionOps   = []string{OpCloseEpic, OpDeleteBead, OpDetachMolecule, OpHaltRig}
+	permissionRoles = []string{RoleOverseer, "mayor", "deacon", "witness", "refinery", "polecat", "crew", AnyRole}
+)
+
+// ErrPermissionDenied is returned when the acting role may not perform a
+// restricted operation.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Permissions maps operations to the roles allowed to perform them (town
+// settings "permissions"), e.g. {"close_epic": ["overseer", "mayor"]}. An
+// operation that isn't listed is open to every role.
+type Permissions map[string][]string
+
+// Restricts reports whether op is limited to some roles.
+func (p Permissions) Restricts(op string) bool {
+	_, ok := p[op]
+	return ok
+}
+
+// Allows reports whether role may perform op.
+func (p Permissions) Allows(op, role string) bool {
+	roles, ok := p[op]
+	if !ok {
+		return true
+	}
+	return slices.Contains(roles, role) || slices.Contains(roles, AnyRole)
+}
+
+// Check returns an error wrapping ErrPermissionDenied, naming the roles
+// that are allowed, if role may not perform op.
+func (p Permissions) Check(op, role string) error {
+	if p.Allows(op, role) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s may not %s (allowed: %s)", ErrPermissionDenied, role, op, strings.Join(p[op], ", "))
+}
+
+// ValidatePermissions checks that permissions name only known operations
+// and roles, and that no operation is locked away from every role.
+func ValidatePermissions(p Permissions) error {
+	for op, roles := range p {
+		if !slices.Contains(permissionOps, op) {
+			return fmt.Errorf("permissions: unknown operation %q (want one of %s)", op, strings.Join(permissionOps, ", "))
+		}
+		if len(roles) == 0 {
+			return fmt.Errorf("permissions.%s: list at least one role", op)
+		}
+		for _, role := range roles {
+			if !slices.Contains(permissionRoles, role) {
+				return fmt.Errorf("permissions.%s: unknown role %q", op, role)
+			}
+		}
+	}
+	return nil
+}
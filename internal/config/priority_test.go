@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPriorityConfigResolve(t *testing.T) {
+	c := &PriorityConfig{Levels: []PriorityLevel{
+		{Value: 0, Name: "fire"},
+		{Value: 2, Name: "normal"},
+	}}
+
+	if v, ok := c.Resolve("FIRE"); !ok || v != 0 {
+		t.Errorf("Resolve(FIRE) = %d, %v", v, ok)
+	}
+	if v, ok := c.Resolve("P3"); !ok || v != 3 {
+		t.Errorf("Resolve(P3) = %d, %v", v, ok)
+	}
+	if _, ok := c.Resolve("P9"); ok {
+		t.Error("Resolve(P9) should fail")
+	}
+	if got := c.Name(3); got != "P3" {
+		t.Errorf("Name(3) = %q, want P3", got)
+	}
+
+	var nilConfig *PriorityConfig
+	if got := nilConfig.Name(4); got != "backlog" {
+		t.Errorf("default Name(4) = %q, want backlog", got)
+	}
+}
+
+func TestPriorityConfigExternal(t *testing.T) {
+	c := &PriorityConfig{External: map[string]map[string]int{
+		"jira": {"Highest": 0, "High": 1, "Medium": 2, "Low": 4},
+	}}
+
+	if v, ok := c.FromExternal("jira", "high"); !ok || v != 1 {
+		t.Errorf("FromExternal(high) = %d, %v", v, ok)
+	}
+	if got, _ := c.ToExternal("jira", 2); got != "Medium" {
+		t.Errorf("ToExternal(2) = %q, want Medium", got)
+	}
+	// No exact match for 3: round toward the more urgent label.
+	if got, _ := c.ToExternal("jira", 3); got != "Medium" {
+		t.Errorf("ToExternal(3) = %q, want Medium", got)
+	}
+	if _, ok := c.ToExternal("linear", 2); ok {
+		t.Error("unknown tracker should not map")
+	}
+}
+
+func TestValidatePriorityConfig(t *testing.T) {
+	bad := &PriorityConfig{Levels: []PriorityLevel{{Value: 7, Name: "someday"}}}
+	if err := validatePriorityConfig(bad); !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("expected ErrInvalidPriority, got %v", err)
+	}
+	if err := validatePriorityConfig(DefaultPriorityConfig()); err != nil {
+		t.Errorf("default config invalid: %v", err)
+	}
+}
+
+func TestPriorityConfigAgingThresholds(t *testing.T) {
+	c := &PriorityConfig{Aging: map[string]string{"backlog": "30d", "P3": "72h"}}
+	got, err := c.AgingThresholds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[4] != 30*24*time.Hour || got[3] != 72*time.Hour || len(got) != 2 {
+		t.Errorf("AgingThresholds = %v", got)
+	}
+
+	for _, bad := range []map[string]string{
+		{"someday": "30d"},
+		{"critical": "1d"},
+		{"low": "soon"},
+		{"low": "-1d"},
+	} {
+		c := &PriorityConfig{Aging: bad}
+		if _, err := c.AgingThresholds(); !errors.Is(err, ErrInvalidPriority) {
+			t.Errorf("AgingThresholds(%v) err = %v, want ErrInvalidPriority", bad, err)
+		}
+	}
+
+	var none *PriorityConfig
+	if got, err := none.AgingThresholds(); got != nil || err != nil {
+		t.Errorf("nil config = %v, %v", got, err)
+	}
+}
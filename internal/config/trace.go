@@ -0,0 +1,22 @@
+package config
+
+import "os"
+
+// Event trace propagation. A traced gt command exports these so that the
+// processes and agent sessions it starts log into the same trace; see
+// events.StartTrace.
+const (
+	EnvTraceID     = "GT_TRACE_ID"
+	EnvParentEvent = "GT_PARENT_EVENT"
+)
+
+// addTraceEnv copies the current trace, if any, into a session's startup
+// environment.
+func addTraceEnv(envVars map[string]string) {
+	if trace := os.Getenv(EnvTraceID); trace != "" {
+		envVars[EnvTraceID] = trace
+		if parent := os.Getenv(EnvParentEvent); parent != "" {
+			envVars[EnvParentEvent] = parent
+		}
+	}
+}
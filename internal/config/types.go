@@ -2,7 +2,11 @@
 package config
 
 import (
+	"fmt"
+	"math"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -48,6 +52,613 @@ type TownSettings struct {
 	// Values override or extend the built-in presets.
 	// Example: {"gemini": {"command": "/custom/path/to/gemini"}}
 	Agents map[string]*RuntimeConfig `json:"agents,omitempty"`
+
+	// Storage overrides where Gas Town keeps its logs and runtime state.
+	// Unset fields use the defaults documented on StorageConfig.
+	Storage *StorageConfig `json:"storage,omitempty"`
+
+	// Priorities renames priority levels and maps external tracker scales.
+	// Nil uses DefaultPriorityConfig.
+	Priorities *PriorityConfig `json:"priorities,omitempty"`
+
+	// Observers lists OS usernames that run gt as read-only observers:
+	// they can view status, feed, and beads but cannot change anything.
+	// See IsObserver.
+	Observers []string `json:"observers,omitempty"`
+
+	// Queries are named issue filters run with "gt query <name>".
+	Queries map[string]*SavedQuery `json:"queries,omitempty"`
+
+	// Feed tunes how the feed curator turns raw events into the feed.
+	// Nil uses the curator's defaults.
+	Feed *FeedConfig `json:"feed,omitempty"`
+
+	// EventLog controls rotation and retention of the raw events log.
+	// Nil uses the defaults documented on EventLogConfig.
+	EventLog *EventLogConfig `json:"event_log,omitempty"`
+
+	// EventSinks forward every logged event to remote systems in addition
+	// to the local events log.
+	EventSinks []EventSinkConfig `json:"event_sinks,omitempty"`
+
+	// Redaction controls scrubbing of secrets from event payloads, bead
+	// text, and mail. Nil uses the built-in rules only.
+	Redaction *RedactionConfig `json:"redaction,omitempty"`
+
+	// MCP configures gt mcp serve, the MCP server agents call gastown
+	// through. Nil uses the built-in per-role tool allowlists.
+	MCP *MCPConfig `json:"mcp,omitempty"`
+
+	// GitHubSync configures gt sync-github, which mirrors rig beads to
+	// GitHub Issues and back. Nil uses the defaults on GitHubSyncConfig.
+	GitHubSync *GitHubSyncConfig `json:"github_sync,omitempty"`
+
+	// Notify routes feed events to Slack and Discord channels. Nil posts
+	// nothing.
+	Notify *NotifyConfig `json:"notify,omitempty"`
+
+	// Budgets caps spend recorded in the usage ledger (see gt cost), per
+	// bead and per agent. Nil sets no limits.
+	Budgets *BudgetConfig `json:"budgets,omitempty"`
+
+	// Schedules are recurring beads the daemon creates on a cron
+	// schedule, keyed by rule name. See ScheduleRule.
+	Schedules map[string]*ScheduleRule `json:"schedules,omitempty"`
+
+	// Permissions limits closing epics, deleting beads, detaching
+	// molecules and halting rigs to the roles listed. Operations not
+	// listed stay open to every role. See Permissions.
+	Permissions Permissions `json:"permissions,omitempty"`
+}
+
+// Notification channel types.
+const (
+	NotifySlack   = "slack"
+	NotifyDiscord = "discord"
+)
+
+// NotifyConfig configures the notifier, which posts feed-visible events
+// to chat webhooks. It runs inside the gt daemon, or on its own via
+// "gt notifier run".
+type NotifyConfig struct {
+	// Channels are the webhooks to post to, by a name routes refer to.
+	// Example: {"refinery": {"type": "slack", "url": "https://hooks.slack.com/..."}}
+	Channels map[string]NotifyChannel `json:"channels,omitempty"`
+
+	// Routes pick the channels for each event. An event goes to every
+	// channel with a matching route, once; events no route matches are
+	// not posted.
+	Routes []NotifyRoute `json:"routes,omitempty"`
+}
+
+// NotifyChannel is one chat webhook.
+type NotifyChannel struct {
+	// Type is slack or discord.
+	Type string `json:"type"`
+
+	// URL is the channel's incoming webhook URL.
+	URL string `json:"url"`
+}
+
+// NotifyRoute sends matching events to a channel. Empty criteria match
+// everything.
+type NotifyRoute struct {
+	// Channel names the channel in NotifyConfig.Channels.
+	Channel string `json:"channel"`
+
+	// Types matches these event types, e.g. ["merge_conflict", "merge_failed"].
+	Types []string `json:"types,omitempty"`
+
+	// Rig matches events from this rig's agents.
+	Rig string `json:"rig,omitempty"`
+
+	// MinSeverity matches events at or above debug, info, warn, or error.
+	MinSeverity string `json:"min_severity,omitempty"`
+}
+
+// Budget actions.
+const (
+	BudgetWarn = "warn"
+	BudgetHalt = "halt"
+)
+
+// BudgetConfig sets spending limits. The daemon checks them against the
+// usage ledger each heartbeat and acts once per breach.
+type BudgetConfig struct {
+	// Bead limits the total spend on one bead, across all agents.
+	Bead *BudgetLimit `json:"bead,omitempty"`
+
+	// Agent limits one agent's spend per day (UTC).
+	Agent *BudgetLimit `json:"agent,omitempty"`
+}
+
+// BudgetLimit is a spending limit. Zero fields are unlimited; the limit is
+// breached when either is exceeded.
+type BudgetLimit struct {
+	// USD is the spend limit in dollars.
+	USD float64 `json:"usd,omitempty"`
+
+	// Tokens limits input plus output tokens.
+	Tokens int64 `json:"tokens,omitempty"`
+
+	// Action is warn (the default: log a budget_exceeded event) or halt
+	// (also stop the work: a bead is set to blocked and its polecat
+	// stopped; a polecat is stopped and its work released).
+	Action string `json:"action,omitempty"`
+}
+
+// Halts reports whether breaching the limit halts work. Nil-safe.
+func (l *BudgetLimit) Halts() bool {
+	return l != nil && l.Action == BudgetHalt
+}
+
+// Schedule catch-up policies (ScheduleRule.CatchUp).
+const (
+	CatchUpLatest = "latest"
+	CatchUpSkip   = "skip"
+)
+
+// DefaultSchedulePriority is the priority of scheduled beads that don't set one.
+const DefaultSchedulePriority = 2
+
+// ScheduleRule creates a bead on a cron schedule, e.g. a weekly
+// dependency audit. The daemon creates each due run's bead once, even
+// across restarts.
+type ScheduleRule struct {
+	// Cron is when the rule is due: five cron fields ("0 9 * * mon") or
+	// @hourly, @daily, @weekly, @monthly, @yearly.
+	Cron string `json:"cron"`
+
+	// Timezone is the IANA zone the cron is read in. Default: the
+	// daemon's local time.
+	Timezone string `json:"timezone,omitempty"`
+
+	// Title of the created bead. "{date}" is replaced with the run's date
+	// (2006-01-02).
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Rig         string   `json:"rig,omitempty"`  // rig to create the bead in (default: town)
+	Type        string   `json:"type,omitempty"` // default: task
+	Priority    *int     `json:"priority,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+
+	// CatchUp decides what happens to runs missed while the daemon was
+	// down: latest (the default) creates one bead for the most recent
+	// missed run; skip creates none and waits for the next run.
+	CatchUp string `json:"catch_up,omitempty"`
+
+	// Disabled pauses the rule without deleting it.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// PriorityOrDefault returns the rule's priority, or DefaultSchedulePriority.
+func (r *ScheduleRule) PriorityOrDefault() int {
+	if r.Priority == nil {
+		return DefaultSchedulePriority
+	}
+	return *r.Priority
+}
+
+// Location returns the zone the rule's cron is read in.
+func (r *ScheduleRule) Location() (*time.Location, error) {
+	if r.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(r.Timezone)
+}
+
+// MCPConfig overrides which MCP tools each role may call.
+type MCPConfig struct {
+	// Tools maps a role (mayor, polecat, ...) to the tools it may call,
+	// replacing that role's built-in allowlist. "*" allows every tool.
+	// Example: {"polecat": ["hook", "mail_send", "bead_show"]}
+	Tools map[string][]string `json:"tools,omitempty"`
+}
+
+// GitHubSyncConfig maps bead fields onto GitHub issue labels for
+// gt sync-github. Titles, descriptions, and open/closed state always sync.
+type GitHubSyncConfig struct {
+	// StatusLabels maps open bead statuses to the GitHub label that shows
+	// them. Closed beads sync as closed issues and need no label.
+	// Default: DefaultGitHubStatusLabels.
+	StatusLabels map[string]string `json:"status_labels,omitempty"`
+
+	// Labels maps bead labels to GitHub labels. Only listed labels sync;
+	// gastown's own bookkeeping labels stay out of GitHub.
+	// Example: {"ux": "design", "security": "security"}
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Types lists the bead types mirrored to GitHub. Beads imported from
+	// GitHub sync regardless of type. Default: task, bug, feature, epic.
+	Types []string `json:"types,omitempty"`
+}
+
+// DefaultGitHubStatusLabels shows in-flight bead states on GitHub.
+var DefaultGitHubStatusLabels = map[string]string{
+	"in_progress": "in progress",
+	"blocked":     "blocked",
+}
+
+// GitHubStatusLabels returns the status label mapping, defaulted. Nil-safe.
+func (c *GitHubSyncConfig) GitHubStatusLabels() map[string]string {
+	if c == nil || c.StatusLabels == nil {
+		return DefaultGitHubStatusLabels
+	}
+	return c.StatusLabels
+}
+
+// SyncTypes returns the bead types to mirror, defaulted. Nil-safe.
+func (c *GitHubSyncConfig) SyncTypes() []string {
+	if c == nil || len(c.Types) == 0 {
+		return []string{"task", "bug", "feature", "epic"}
+	}
+	return c.Types
+}
+
+// RedactionConfig extends or disables the built-in secret redaction
+// (AWS keys, tokens, private keys; see package redact).
+type RedactionConfig struct {
+	// Disabled turns redaction off entirely.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Patterns are extra regular expressions whose matches are redacted.
+	Patterns []string `json:"patterns,omitempty"`
+
+	// Keywords redact the value assigned to these names, e.g. "db_pass"
+	// redacts the value in "db_pass=hunter2" or "DB_PASS: hunter2".
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// Event sink types.
+const (
+	EventSinkWebhook = "webhook"
+	EventSinkSyslog  = "syslog"
+	EventSinkKafka   = "kafka"
+)
+
+// EventSinkConfig configures one remote event sink.
+type EventSinkConfig struct {
+	// Type is webhook, syslog, or kafka.
+	Type string `json:"type"`
+
+	// URL is the webhook endpoint (each event is POSTed as JSON), or for
+	// kafka the base URL of a Kafka REST proxy.
+	URL string `json:"url,omitempty"`
+
+	// Headers are added to webhook and kafka requests, e.g. Authorization.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Topic is the kafka topic to produce to.
+	Topic string `json:"topic,omitempty"`
+
+	// Address is the syslog server as "udp://host:514", "tcp://host:514",
+	// or "unix:///dev/log". Default: the local syslog socket.
+	Address string `json:"address,omitempty"`
+
+	// Types, if set, forwards only events of these types.
+	Types []string `json:"types,omitempty"`
+}
+
+// Validate checks that the sink names a known type and has the settings
+// that type needs.
+func (c EventSinkConfig) Validate() error {
+	switch c.Type {
+	case EventSinkWebhook:
+		if c.URL == "" {
+			return fmt.Errorf("webhook needs a url")
+		}
+	case EventSinkKafka:
+		if c.URL == "" || c.Topic == "" {
+			return fmt.Errorf("kafka needs a REST proxy url and a topic")
+		}
+	case EventSinkSyslog:
+		if c.Address != "" && !strings.Contains(c.Address, "://") {
+			return fmt.Errorf("invalid syslog address %q (want udp://, tcp://, or unix://)", c.Address)
+		}
+	default:
+		return fmt.Errorf("unknown type %q (want webhook, syslog, or kafka)", c.Type)
+	}
+	return nil
+}
+
+// Event log rotation defaults.
+const (
+	DefaultEventLogMaxSizeMB = 50
+	DefaultEventLogRetention = 90 * 24 * time.Hour
+)
+
+// EventLogConfig controls when the events log is rotated into gzipped
+// segments and how long those segments are kept.
+type EventLogConfig struct {
+	// MaxSizeMB rotates the log once it grows past this many megabytes.
+	// Default: 50. Negative disables size-based rotation.
+	MaxSizeMB int `json:"max_size_mb,omitempty"`
+
+	// Daily also rotates on the first write of each UTC day.
+	Daily bool `json:"daily,omitempty"`
+
+	// Retention deletes rotated segments older than this, e.g. "30d" or
+	// "720h". Default: 90d. "0" keeps every segment.
+	Retention string `json:"retention,omitempty"`
+
+	// PerRig writes each rig's events to <town>/<rig>/.events.jsonl instead
+	// of the town log, so one busy rig can't drown out the others and each
+	// log can carry its own permissions. Town-wide reads merge all logs.
+	PerRig bool `json:"per_rig,omitempty"`
+
+	// Visibility overrides the visibility of event types logged without an
+	// explicit one: event type → audit, feed, or both. Use it to promote an
+	// event type to the feed or demote it to the audit log.
+	Visibility map[string]string `json:"visibility,omitempty"`
+}
+
+// MaxBytes returns the size that triggers rotation, or 0 if size-based
+// rotation is off.
+func (c *EventLogConfig) MaxBytes() int64 {
+	mb := DefaultEventLogMaxSizeMB
+	if c != nil && c.MaxSizeMB != 0 {
+		mb = c.MaxSizeMB
+	}
+	if mb < 0 {
+		return 0
+	}
+	return int64(mb) << 20
+}
+
+// RetentionWindow parses Retention. Zero means keep every segment.
+func (c *EventLogConfig) RetentionWindow() (time.Duration, error) {
+	if c == nil || c.Retention == "" {
+		return DefaultEventLogRetention, nil
+	}
+	if c.Retention == "0" {
+		return 0, nil
+	}
+	d, err := parseDays(c.Retention)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid event_log retention %q (want a duration like 30d or 720h, or 0)", c.Retention)
+	}
+	return d, nil
+}
+
+// FeedConfig holds the feed curation rules. Unset fields keep the defaults
+// noted on each.
+type FeedConfig struct {
+	// Visibility lists the event visibilities copied to the feed.
+	// Default: feed, both.
+	Visibility []string `json:"visibility,omitempty"`
+
+	// Types, if set, is a whitelist: events of other types are dropped.
+	Types []string `json:"types,omitempty"`
+
+	// RateLimit caps feed events per actor as "<count>/<window>", e.g.
+	// "30/1m". Events over the limit are dropped and counted on the
+	// actor's next feed event. Default: unlimited.
+	RateLimit string `json:"rate_limit,omitempty"`
+
+	// CoalesceNudges folds repeated nudges of one target within this
+	// window into a single follow-up entry, e.g. "5m". "0" disables.
+	// Default: 5m.
+	CoalesceNudges string `json:"coalesce_nudges,omitempty"`
+
+	// Templates overrides the one-line rendering of event types in the
+	// feed and dashboard: event type → Go text/template, e.g.
+	// {"hook": "{{.Who}} hooked {{.P \"bead\"}}"}. See feed.DefaultTemplates.
+	Templates map[string]string `json:"templates,omitempty"`
+}
+
+// RateLimitSpec parses RateLimit. A zero count means unlimited.
+func (c *FeedConfig) RateLimitSpec() (int, time.Duration, error) {
+	if c == nil || c.RateLimit == "" {
+		return 0, 0, nil
+	}
+	count, window, ok := strings.Cut(c.RateLimit, "/")
+	n, err := strconv.Atoi(count)
+	if !ok || err != nil || n <= 0 {
+		return 0, 0, fmt.Errorf("invalid feed rate_limit %q (want <count>/<window>, e.g. 30/1m)", c.RateLimit)
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return 0, 0, fmt.Errorf("invalid feed rate_limit %q (want <count>/<window>, e.g. 30/1m)", c.RateLimit)
+	}
+	return n, d, nil
+}
+
+// NudgeCoalesceWindow parses CoalesceNudges, returning def when unset.
+func (c *FeedConfig) NudgeCoalesceWindow(def time.Duration) (time.Duration, error) {
+	if c == nil || c.CoalesceNudges == "" {
+		return def, nil
+	}
+	if c.CoalesceNudges == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.CoalesceNudges)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid feed coalesce_nudges %q (want a duration like 5m, or 0)", c.CoalesceNudges)
+	}
+	return d, nil
+}
+
+// SavedQuery is a named filter over the town's beads. Empty fields don't
+// filter; a nil Priority matches every priority.
+type SavedQuery struct {
+	Description string `json:"description,omitempty"`
+	Rig         string `json:"rig,omitempty"`    // rig whose beads to query (default: town)
+	Status      string `json:"status,omitempty"` // "open", "closed", "all"
+	Type        string `json:"type,omitempty"`
+	Priority    *int   `json:"priority,omitempty"`
+	Assignee    string `json:"assignee,omitempty"`
+	Label       string `json:"label,omitempty"`
+	Limit       int    `json:"limit,omitempty"`
+}
+
+// Bounds of bd's priority scale.
+const (
+	MinPriority = 0
+	MaxPriority = 4
+)
+
+// PriorityLevel names one bd priority value (0 = most urgent, 4 = backlog).
+type PriorityLevel struct {
+	Value       int    `json:"value"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PriorityConfig defines a town's priority vocabulary.
+type PriorityConfig struct {
+	// Levels names the priority values. A value may have several names;
+	// the first listed is used for display.
+	Levels []PriorityLevel `json:"levels,omitempty"`
+
+	// External maps tracker name → external priority label → bd value,
+	// e.g. {"jira": {"Highest": 0, "High": 1, "Medium": 2, "Low": 3, "Lowest": 4}}.
+	External map[string]map[string]int `json:"external,omitempty"`
+
+	// Aging maps a level (name, "P<n>", or "<n>") to how long an open bead
+	// may sit untouched at that level before "gt priority age" raises it
+	// one level. Durations are Go durations or "<n>d", e.g.
+	// {"backlog": "30d", "low": "14d"}. Empty uses the built-in policy.
+	Aging map[string]string `json:"aging,omitempty"`
+}
+
+// DefaultPriorityConfig returns the built-in P0–P4 level names.
+func DefaultPriorityConfig() *PriorityConfig {
+	return &PriorityConfig{
+		Levels: []PriorityLevel{
+			{Value: 0, Name: "critical", Description: "Drop everything"},
+			{Value: 1, Name: "high", Description: "Next up"},
+			{Value: 2, Name: "medium", Description: "Normal work"},
+			{Value: 3, Name: "low", Description: "When there is time"},
+			{Value: 4, Name: "backlog", Description: "Someday"},
+		},
+	}
+}
+
+// Resolve converts a level name, "P<n>", or "<n>" to a bd priority value.
+func (c *PriorityConfig) Resolve(s string) (int, bool) {
+	if c == nil {
+		c = DefaultPriorityConfig()
+	}
+	v := strings.TrimSpace(s)
+	for _, l := range c.Levels {
+		if strings.EqualFold(l.Name, v) {
+			return l.Value, true
+		}
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(v), "p"))
+	if err != nil || n < MinPriority || n > MaxPriority {
+		return 0, false
+	}
+	return n, true
+}
+
+// Name returns the display name for a priority value, or "P<n>" if unnamed.
+func (c *PriorityConfig) Name(value int) string {
+	if c == nil {
+		c = DefaultPriorityConfig()
+	}
+	for _, l := range c.Levels {
+		if l.Value == value {
+			return l.Name
+		}
+	}
+	return fmt.Sprintf("P%d", value)
+}
+
+// AgingThresholds resolves Aging to bd priority values. It returns nil
+// when no aging is configured.
+func (c *PriorityConfig) AgingThresholds() (map[int]time.Duration, error) {
+	if c == nil || len(c.Aging) == 0 {
+		return nil, nil
+	}
+	names := c
+	if len(c.Levels) == 0 {
+		names = nil // Aging-only settings still use the built-in level names
+	}
+	out := make(map[int]time.Duration, len(c.Aging))
+	for level, after := range c.Aging {
+		p, ok := names.Resolve(level)
+		if !ok {
+			return nil, fmt.Errorf("%w: aging level %q", ErrInvalidPriority, level)
+		}
+		if p == MinPriority {
+			return nil, fmt.Errorf("%w: aging level %q is already the most urgent", ErrInvalidPriority, level)
+		}
+		d, err := parseDays(after)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%w: aging %q=%q (want a positive duration like 14d or 72h)", ErrInvalidPriority, level, after)
+		}
+		out[p] = d
+	}
+	return out, nil
+}
+
+// parseDays parses a Go duration, also accepting whole days as "<n>d".
+func parseDays(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// FromExternal maps an external tracker's priority label to a bd value.
+// Label matching is case-insensitive.
+func (c *PriorityConfig) FromExternal(tracker, label string) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+	for ext, v := range c.External[tracker] {
+		if strings.EqualFold(ext, label) {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// ToExternal maps a bd value to an external tracker's label. When several
+// labels share a value, the alphabetically first is returned so the result
+// is stable. If no label has the exact value, the nearest more-urgent label
+// is used so work is never silently deprioritized.
+func (c *PriorityConfig) ToExternal(tracker string, value int) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	best, bestValue := "", -1
+	for ext, v := range c.External[tracker] {
+		if v > value {
+			continue
+		}
+		if v > bestValue || (v == bestValue && ext < best) {
+			best, bestValue = ext, v
+		}
+	}
+	return best, best != ""
+}
+
+// StorageConfig controls the on-disk layout of town-level logs and state.
+// Relative paths are resolved against the town root; "~/" expands to $HOME.
+type StorageConfig struct {
+	// EventsFile is the raw events log. Default: <town>/.events.jsonl
+	EventsFile string `json:"events_file,omitempty"`
+
+	// FeedFile is the curated feed. Default: <town>/.feed.jsonl
+	FeedFile string `json:"feed_file,omitempty"`
+
+	// AuditLog is the molecule audit log name inside each .beads directory,
+	// or an absolute path to share one log across rigs. Default: audit.log
+	AuditLog string `json:"audit_log,omitempty"`
+
+	// StateDir holds per-machine runtime state that should not live in the
+	// town tree. Default: $XDG_STATE_HOME/gastown/<town-name>
+	StateDir string `json:"state_dir,omitempty"`
+
+	// CacheDir holds disposable caches. Default: $XDG_CACHE_HOME/gastown/<town-name>
+	CacheDir string `json:"cache_dir,omitempty"`
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -69,10 +680,199 @@ type DaemonConfig struct {
 // DaemonPatrolConfig represents the daemon patrol configuration (mayor/daemon.json).
 // This configures how patrols are triggered and managed.
 type DaemonPatrolConfig struct {
-	Type      string                  `json:"type"`                // "daemon-patrol-config"
-	Version   int                     `json:"version"`             // schema version
-	Heartbeat *HeartbeatConfig        `json:"heartbeat,omitempty"` // heartbeat settings
-	Patrols   map[string]PatrolConfig `json:"patrols,omitempty"`   // named patrol configurations
+	Type      string                  `json:"type"`                 // "daemon-patrol-config"
+	Version   int                     `json:"version"`              // schema version
+	Heartbeat *HeartbeatConfig        `json:"heartbeat,omitempty"`  // heartbeat settings
+	Patrols   map[string]PatrolConfig `json:"patrols,omitempty"`    // named patrol configurations
+	IdlePark  *IdleParkConfig         `json:"idle_park,omitempty"`  // auto-parking of idle polecats
+	SyncDrift *SyncDriftConfig        `json:"sync_drift,omitempty"` // beads sync drift alerts
+	Watchdog  *WatchdogConfig         `json:"watchdog,omitempty"`   // reaping of dead polecats' claims
+	Dispatch  *DispatchConfig         `json:"dispatch,omitempty"`   // automatic assignment of ready work
+
+	NudgeEscalation *NudgeEscalationConfig `json:"nudge_escalation,omitempty"` // follow-up on nudges polecats don't answer
+}
+
+// IdleParkConfig controls automatic parking of idle polecats by the daemon.
+// A parked polecat's session is stopped but its worktree is kept; it is
+// restarted when its rig has unassigned ready work.
+type IdleParkConfig struct {
+	Enabled bool   `json:"enabled"`
+	After   string `json:"after,omitempty"` // idle threshold, e.g. "30m" (default: DefaultIdleParkAfter)
+}
+
+// DefaultIdleParkAfter is the idle threshold used when IdleParkConfig.After is empty.
+const DefaultIdleParkAfter = 30 * time.Minute
+
+// Threshold returns the parsed idle threshold, or DefaultIdleParkAfter.
+func (c *IdleParkConfig) Threshold() time.Duration {
+	if c == nil || c.After == "" {
+		return DefaultIdleParkAfter
+	}
+	d, err := time.ParseDuration(c.After)
+	if err != nil || d <= 0 {
+		return DefaultIdleParkAfter
+	}
+	return d
+}
+
+// SyncDriftConfig controls the daemon's beads sync monitoring, which is on
+// by default. A beads repo is drifting when it is more than Behind commits
+// behind its remote or has sync conflicts.
+type SyncDriftConfig struct {
+	Disabled bool `json:"disabled,omitempty"`
+	Behind   int  `json:"behind,omitempty"` // commits-behind threshold (default: DefaultSyncDriftBehind)
+}
+
+// DefaultSyncDriftBehind is the threshold used when SyncDriftConfig.Behind is unset.
+const DefaultSyncDriftBehind = 10
+
+// WatchdogConfig controls the daemon's watchdog, which is on by default.
+// Each heartbeat it releases in_progress and hooked beads held by polecats
+// that are gone (no session and no worktree) or whose agent process has
+// exited, once they have stayed that way for the grace period.
+type WatchdogConfig struct {
+	Disabled bool   `json:"disabled,omitempty"`
+	Grace    string `json:"grace,omitempty"` // how long a worker must stay dead, e.g. "10m" (default: DefaultWatchdogGrace)
+
+	// Respawn replaces dead workers instead of only releasing their work:
+	// a polecat whose agent exited is restarted in place, keeping its
+	// hook, and work of a polecat that is gone is slung to a fresh one.
+	Respawn bool `json:"respawn,omitempty"`
+}
+
+// DefaultWatchdogGrace is the grace period used when WatchdogConfig.Grace is empty.
+const DefaultWatchdogGrace = 5 * time.Minute
+
+// GracePeriod returns the parsed grace period, or DefaultWatchdogGrace. Nil-safe.
+func (c *WatchdogConfig) GracePeriod() time.Duration {
+	if c == nil || c.Grace == "" {
+		return DefaultWatchdogGrace
+	}
+	d, err := time.ParseDuration(c.Grace)
+	if err != nil || d < 0 {
+		return DefaultWatchdogGrace
+	}
+	return d
+}
+
+// DispatchConfig controls the daemon's dispatcher, which assigns ready,
+// unassigned beads to idle polecats each heartbeat: highest priority
+// first, to polecats with a live agent and fewer than WIP claimed beads.
+type DispatchConfig struct {
+	Enabled bool `json:"enabled"`
+	WIP     int  `json:"wip,omitempty"` // claimed beads per polecat (default: DefaultDispatchWIP)
+
+	// Affinity restricts polecats to bead types, keyed by "<rig>/<name>"
+	// or a bare polecat name. Listed polecats take only their types and
+	// are preferred for them; unlisted polecats take any type.
+	Affinity map[string][]string `json:"affinity,omitempty"`
+}
+
+// DefaultDispatchWIP is the per-polecat limit used when DispatchConfig.WIP is unset.
+const DefaultDispatchWIP = 1
+
+// WIPLimit returns the per-polecat claim limit, or DefaultDispatchWIP. Nil-safe.
+func (c *DispatchConfig) WIPLimit() int {
+	if c == nil || c.WIP <= 0 {
+		return DefaultDispatchWIP
+	}
+	return c.WIP
+}
+
+// AffinityFor returns the bead types polecat name of rig is restricted
+// to, or nil if it takes any type.
+func (c *DispatchConfig) AffinityFor(rig, name string) []string {
+	if c == nil {
+		return nil
+	}
+	if types, ok := c.Affinity[rig+"/"+name]; ok {
+		return types
+	}
+	return c.Affinity[name]
+}
+
+// Nudge escalation actions, in the order a policy takes them.
+const (
+	NudgeActionRenudge = "renudge"
+	NudgeActionNotify  = "notify"
+	NudgeActionKill    = "kill"
+	NudgeActionRespawn = "respawn"
+)
+
+// NudgeEscalationConfig controls the daemon's escalation of nudges a
+// polecat doesn't answer. A polecat that logs no events within Wait of a
+// nudge is nudged again, more urgently each time, Renudges times; then
+// the overseer is notified; then, with Final set, the polecat is killed
+// or respawned. Each wait is Backoff times the one before.
+type NudgeEscalationConfig struct {
+	Enabled  bool    `json:"enabled"`
+	Wait     string  `json:"wait,omitempty"`     // silence before the first step, e.g. "10m" (default: DefaultNudgeEscalationWait)
+	Backoff  float64 `json:"backoff,omitempty"`  // wait multiplier per step (default: DefaultNudgeEscalationBackoff)
+	Renudges *int    `json:"renudges,omitempty"` // nudges before notifying the overseer (default: DefaultNudgeEscalationRenudges)
+	Final    string  `json:"final,omitempty"`    // last step after notifying: "kill", "respawn" or "" for none
+}
+
+// Defaults for unset NudgeEscalationConfig fields.
+const (
+	DefaultNudgeEscalationWait     = 10 * time.Minute
+	DefaultNudgeEscalationBackoff  = 2.0
+	DefaultNudgeEscalationRenudges = 2
+)
+
+// Steps returns the escalation's actions in order. Nil-safe.
+func (c *NudgeEscalationConfig) Steps() []string {
+	n := DefaultNudgeEscalationRenudges
+	if c != nil && c.Renudges != nil {
+		n = max(*c.Renudges, 0)
+	}
+	steps := make([]string, 0, n+2)
+	for range n {
+		steps = append(steps, NudgeActionRenudge)
+	}
+	steps = append(steps, NudgeActionNotify)
+	if c != nil && c.Final != "" {
+		steps = append(steps, c.Final)
+	}
+	return steps
+}
+
+// Horizon returns how long after a nudge the escalation's last step comes
+// due, if every step is taken on time. Nil-safe.
+func (c *NudgeEscalationConfig) Horizon() time.Duration {
+	var total time.Duration
+	for step := range c.Steps() {
+		total += c.WaitBefore(step)
+	}
+	return total
+}
+
+// WaitBefore returns how long a polecat must stay silent before step
+// (0-based) is taken: Wait for the first, growing by Backoff after.
+// Nil-safe.
+func (c *NudgeEscalationConfig) WaitBefore(step int) time.Duration {
+	wait, backoff := DefaultNudgeEscalationWait, DefaultNudgeEscalationBackoff
+	if c != nil {
+		if d, err := time.ParseDuration(c.Wait); err == nil && d > 0 {
+			wait = d
+		}
+		if c.Backoff >= 1 {
+			backoff = c.Backoff
+		}
+	}
+	return time.Duration(float64(wait) * math.Pow(backoff, float64(step)))
+}
+
+// Enabled reports whether drift monitoring is on (a nil config means yes).
+func (c *SyncDriftConfig) Enabled() bool {
+	return c == nil || !c.Disabled
+}
+
+// Threshold returns the commits-behind threshold, or DefaultSyncDriftBehind.
+func (c *SyncDriftConfig) Threshold() int {
+	if c == nil || c.Behind <= 0 {
+		return DefaultSyncDriftBehind
+	}
+	return c.Behind
 }
 
 // HeartbeatConfig represents heartbeat settings for daemon.
@@ -188,6 +988,8 @@ type RigSettings struct {
 	Namepool   *NamepoolConfig   `json:"namepool,omitempty"`    // polecat name pool settings
 	Crew       *CrewConfig       `json:"crew,omitempty"`        // crew startup settings
 	Runtime    *RuntimeConfig    `json:"runtime,omitempty"`     // LLM runtime settings (deprecated: use Agent)
+	Sandbox    *SandboxConfig    `json:"sandbox,omitempty"`     // worktree write scoping
+	Boot       *BootConfig       `json:"boot,omitempty"`        // agent start ordering
 
 	// Agent selects which agent preset to use for this rig.
 	// Can be a built-in preset ("claude", "gemini", "codex")
@@ -358,6 +1160,12 @@ type MergeQueueConfig struct {
 
 	// MaxConcurrent is the maximum number of concurrent merges.
 	MaxConcurrent int `json:"max_concurrent"`
+
+	// GitHubPRs merges through GitHub instead of the Refinery: gt done
+	// pushes the branch and opens a pull request for each MR, and the
+	// daemon closes the MR once the pull request merges. Needs the gh CLI
+	// and a GitHub origin.
+	GitHubPRs bool `json:"github_prs,omitempty"`
 }
 
 // OnConflict strategy constants.
@@ -405,6 +1213,159 @@ func DefaultNamepoolConfig() *NamepoolConfig {
 	}
 }
 
+// Sandbox enforcement modes.
+const (
+	SandboxOff   = "off"   // No checks
+	SandboxWarn  = "warn"  // Log violations, allow the write
+	SandboxBlock = "block" // Log violations and refuse the write
+)
+
+// SandboxConfig restricts agent writes to their assigned worktree.
+// Enforcement happens in a PreToolUse hook (gt sandbox check) before
+// file-writing tools run.
+type SandboxConfig struct {
+	// Mode is the default enforcement mode: "off", "warn", or "block".
+	// Default is "warn".
+	Mode string `json:"mode,omitempty"`
+
+	// Roles overrides Mode per role (e.g., {"polecat": "block", "crew": "off"}).
+	Roles map[string]string `json:"roles,omitempty"`
+
+	// AllowPaths are extra absolute paths (or prefixes) writable by any role,
+	// such as shared scratch directories.
+	AllowPaths []string `json:"allow_paths,omitempty"`
+}
+
+// DefaultSandboxConfig returns a SandboxConfig with sensible defaults.
+func DefaultSandboxConfig() *SandboxConfig {
+	return &SandboxConfig{
+		Mode: SandboxWarn,
+	}
+}
+
+// ModeFor returns the enforcement mode for a role.
+func (c *SandboxConfig) ModeFor(role string) string {
+	if c == nil {
+		return SandboxWarn
+	}
+	if mode, ok := c.Roles[role]; ok && mode != "" {
+		return mode
+	}
+	if c.Mode == "" {
+		return SandboxWarn
+	}
+	return c.Mode
+}
+
+// Rig agents that boot ordering applies to.
+const (
+	BootAgentWitness  = "witness"
+	BootAgentRefinery = "refinery"
+	BootAgentCrew     = "crew"
+	BootAgentPolecats = "polecats"
+)
+
+// BootAgents lists the rig agents in their default boot order.
+var BootAgents = []string{BootAgentWitness, BootAgentRefinery, BootAgentCrew, BootAgentPolecats}
+
+// DefaultBootReadyTimeout is how long boot waits for a stage's agents to
+// be ready when ReadyTimeout is unset.
+const DefaultBootReadyTimeout = 60 * time.Second
+
+// BootConfig orders the start of a rig's agents. Agents start in stages:
+// an agent starts once every agent it comes after is running and ready.
+type BootConfig struct {
+	// After maps an agent to the agents that must be ready before it
+	// starts, e.g. {"refinery": ["witness"]}. An agent listed here
+	// replaces its default; an empty list starts it in the first stage.
+	// Default: refinery after witness, polecats after refinery.
+	After map[string][]string `json:"after,omitempty"`
+
+	// ReadyTimeout bounds the wait for a stage's agents to be ready, e.g.
+	// "90s". Agents after one that isn't ready by then are not started.
+	// Default: 60s.
+	ReadyTimeout string `json:"ready_timeout,omitempty"`
+}
+
+// DefaultBootAfter returns the default boot dependencies: the refinery
+// after the witness, polecats after the refinery.
+func DefaultBootAfter() map[string][]string {
+	return map[string][]string{
+		BootAgentRefinery: {BootAgentWitness},
+		BootAgentPolecats: {BootAgentRefinery},
+	}
+}
+
+// AfterFor returns the agents that must be ready before agent starts.
+func (c *BootConfig) AfterFor(agent string) []string {
+	if c != nil {
+		if after, ok := c.After[agent]; ok {
+			return after
+		}
+	}
+	return DefaultBootAfter()[agent]
+}
+
+// ReadyTimeoutDuration parses ReadyTimeout, returning
+// DefaultBootReadyTimeout when unset.
+func (c *BootConfig) ReadyTimeoutDuration() (time.Duration, error) {
+	if c == nil || c.ReadyTimeout == "" {
+		return DefaultBootReadyTimeout, nil
+	}
+	d, err := time.ParseDuration(c.ReadyTimeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid boot ready_timeout %q (want a duration like 90s)", c.ReadyTimeout)
+	}
+	return d, nil
+}
+
+// Stages orders agents for boot, in topological order: each stage holds
+// the agents whose dependencies all start in earlier stages, in the order
+// given. Dependencies on agents not being booted are ignored, so booting
+// the refinery alone doesn't wait for a witness. A dependency cycle is an
+// error.
+func (c *BootConfig) Stages(agents []string) ([][]string, error) {
+	booting := make(map[string]bool, len(agents))
+	for _, agent := range agents {
+		booting[agent] = true
+	}
+
+	placed := make(map[string]bool, len(agents))
+	var stages [][]string
+	for len(placed) < len(booting) {
+		var stage []string
+		for _, agent := range agents {
+			if placed[agent] || slices.Contains(stage, agent) {
+				continue
+			}
+			ready := true
+			for _, dep := range c.AfterFor(agent) {
+				if booting[dep] && !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				stage = append(stage, agent)
+			}
+		}
+		if len(stage) == 0 {
+			var stuck []string
+			for _, agent := range agents {
+				if !placed[agent] && !slices.Contains(stuck, agent) {
+					stuck = append(stuck, agent)
+				}
+			}
+			return nil, fmt.Errorf("boot dependency cycle among %s", strings.Join(stuck, ", "))
+		}
+		for _, agent := range stage {
+			placed[agent] = true
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
 // AccountsConfig represents Claude Code account configuration (mayor/accounts.json).
 // This enables Gas Town to manage multiple Claude Code accounts with easy switching.
 type AccountsConfig struct {
@@ -0,0 +1,86 @@
+package cost
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Budget scopes.
+const (
+	ScopeBead  = "bead"
+	ScopeAgent = "agent"
+)
+
+// Breach is a budget a bead or agent has gone over.
+type Breach struct {
+	Scope string             `json:"scope"`         // ScopeBead or ScopeAgent
+	Key   string             `json:"key"`           // Bead ID or agent address
+	Day   string             `json:"day,omitempty"` // UTC day an agent breach is for
+	Spent Totals             `json:"spent"`
+	Limit config.BudgetLimit `json:"limit"`
+}
+
+// ID identifies the breach; an agent's breach is new each day.
+func (b Breach) ID() string {
+	if b.Day != "" {
+		return b.Scope + ":" + b.Key + ":" + b.Day
+	}
+	return b.Scope + ":" + b.Key
+}
+
+// Halts reports whether the breached limit halts work.
+func (b Breach) Halts() bool {
+	return b.Limit.Halts()
+}
+
+// Describe says how far over the limit the spend is, e.g.
+// "$12.40 of $10.00".
+func (b Breach) Describe() string {
+	var parts []string
+	if b.Limit.USD > 0 && b.Spent.CostUSD > b.Limit.USD {
+		parts = append(parts, fmt.Sprintf("$%.2f of $%.2f", b.Spent.CostUSD, b.Limit.USD))
+	}
+	if b.Limit.Tokens > 0 && b.Spent.Tokens() > b.Limit.Tokens {
+		parts = append(parts, fmt.Sprintf("%d of %d tokens", b.Spent.Tokens(), b.Limit.Tokens))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Exceeds reports whether t is over the limit. A nil limit never is.
+func Exceeds(t Totals, l *config.BudgetLimit) bool {
+	if l == nil {
+		return false
+	}
+	return (l.USD > 0 && t.CostUSD > l.USD) || (l.Tokens > 0 && t.Tokens() > l.Tokens)
+}
+
+// Check returns the budgets the records breach as of now: beads over the
+// per-bead limit on all their usage, and agents over the per-agent limit
+// on today's (UTC). Sorted by ID.
+func Check(records []Usage, cfg *config.BudgetConfig, now time.Time) []Breach {
+	if cfg == nil {
+		return nil
+	}
+	var out []Breach
+	if cfg.Bead != nil {
+		for id, t := range Aggregate(records, time.Time{}).ByBead {
+			if Exceeds(t, cfg.Bead) {
+				out = append(out, Breach{Scope: ScopeBead, Key: id, Spent: t, Limit: *cfg.Bead})
+			}
+		}
+	}
+	if cfg.Agent != nil {
+		day := now.UTC().Truncate(24 * time.Hour)
+		for actor, t := range Aggregate(records, day).ByActor {
+			if Exceeds(t, cfg.Agent) {
+				out = append(out, Breach{Scope: ScopeAgent, Key: actor, Day: day.Format("2006-01-02"), Spent: t, Limit: *cfg.Agent})
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID() < out[j].ID() })
+	return out
+}
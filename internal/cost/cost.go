@@ -0,0 +1,216 @@
+// Package cost tracks what agents spend. Agent runners append usage
+// records to the town's usage ledger, keyed by agent and the bead the
+// agent had hooked; reports aggregate them per bead and per agent, and
+// budgets (see Check) cap them.
+package cost
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/flock"
+)
+
+// Usage is one usage record: the tokens and dollars an agent spent,
+// typically over one turn or session.
+type Usage struct {
+	Timestamp    time.Time `json:"ts"`
+	Actor        string    `json:"actor"`          // Agent address, e.g. gastown/polecats/Toast
+	Bead         string    `json:"bead,omitempty"` // Bead the agent had hooked
+	Session      string    `json:"session,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	InputTokens  int64     `json:"input_tokens,omitempty"`
+	OutputTokens int64     `json:"output_tokens,omitempty"`
+	CostUSD      float64   `json:"cost_usd,omitempty"`
+}
+
+// Validate checks that a record can be attributed and is not negative.
+func (u Usage) Validate() error {
+	if u.Actor == "" {
+		return errors.New("usage record needs an actor")
+	}
+	if u.InputTokens < 0 || u.OutputTokens < 0 || u.CostUSD < 0 {
+		return errors.New("usage record has negative usage")
+	}
+	return nil
+}
+
+// LedgerPath returns the location of a town's usage ledger.
+func LedgerPath(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "usage.jsonl")
+}
+
+// Append adds records to the town's ledger, stamping any without a
+// timestamp with the current time. Nothing is written if a record is
+// invalid.
+func Append(townRoot string, records ...Usage) error {
+	var data []byte
+	now := time.Now().UTC()
+	for i, u := range records {
+		if err := u.Validate(); err != nil {
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+		if u.Timestamp.IsZero() {
+			u.Timestamp = now
+		}
+		line, err := json.Marshal(u)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	path := LedgerPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return flock.AppendFile(path, data, 0644)
+}
+
+// Read returns the town's ledger in order (empty if missing). Malformed
+// lines are skipped.
+func Read(townRoot string) ([]Usage, error) {
+	f, err := os.Open(LedgerPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
+
+// Decode reads usage records, one JSON object per line, skipping blank
+// and malformed lines.
+func Decode(r io.Reader) ([]Usage, error) {
+	var out []Usage
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var u Usage
+		if err := json.Unmarshal(line, &u); err != nil {
+			continue
+		}
+		out = append(out, u)
+	}
+	return out, scanner.Err()
+}
+
+// Totals is aggregated usage.
+type Totals struct {
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	Records      int     `json:"records"`
+}
+
+// Add adds one record to the totals.
+func (t *Totals) Add(u Usage) {
+	t.InputTokens += u.InputTokens
+	t.OutputTokens += u.OutputTokens
+	t.CostUSD += u.CostUSD
+	t.Records++
+}
+
+// Merge adds other totals to these.
+func (t *Totals) Merge(o Totals) {
+	t.InputTokens += o.InputTokens
+	t.OutputTokens += o.OutputTokens
+	t.CostUSD += o.CostUSD
+	t.Records += o.Records
+}
+
+// Tokens returns input plus output tokens.
+func (t Totals) Tokens() int64 {
+	return t.InputTokens + t.OutputTokens
+}
+
+// Report is usage aggregated per bead and per agent. Records without a
+// bead count toward their agent and the total only.
+type Report struct {
+	Total   Totals            `json:"total"`
+	ByBead  map[string]Totals `json:"by_bead"`
+	ByActor map[string]Totals `json:"by_actor"`
+}
+
+// Aggregate totals the records at or after since (all of them if since is
+// zero).
+func Aggregate(records []Usage, since time.Time) *Report {
+	r := &Report{ByBead: make(map[string]Totals), ByActor: make(map[string]Totals)}
+	for _, u := range records {
+		if !since.IsZero() && u.Timestamp.Before(since) {
+			continue
+		}
+		r.Total.Add(u)
+		actor := r.ByActor[u.Actor]
+		actor.Add(u)
+		r.ByActor[u.Actor] = actor
+		if u.Bead != "" {
+			bead := r.ByBead[u.Bead]
+			bead.Add(u)
+			r.ByBead[u.Bead] = bead
+		}
+	}
+	return r
+}
+
+// Ranked returns the keys of a breakdown, most expensive first (then by
+// tokens, then by name).
+func Ranked(by map[string]Totals) []string {
+	keys := make([]string, 0, len(by))
+	for k := range by {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := by[keys[i]], by[keys[j]]
+		if a.CostUSD != b.CostUSD {
+			return a.CostUSD > b.CostUSD
+		}
+		if a.Tokens() != b.Tokens() {
+			return a.Tokens() > b.Tokens()
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// Rollup totals a bead and all its descendants, as given by children, so
+// an epic's cost includes the work under it. Each bead is counted once.
+func Rollup(byBead map[string]Totals, root string, children func(id string) ([]string, error)) (Totals, []string, error) {
+	var total Totals
+	var visited []string
+	seen := make(map[string]bool)
+	queue := []string{root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		visited = append(visited, id)
+		total.Merge(byBead[id])
+
+		kids, err := children(id)
+		if err != nil {
+			return total, visited, fmt.Errorf("listing children of %s: %w", id, err)
+		}
+		queue = append(queue, kids...)
+	}
+	return total, visited, nil
+}
@@ -0,0 +1,136 @@
+package cost
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestAppendRead(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if got, err := Read(townRoot); err != nil || len(got) != 0 {
+		t.Fatalf("empty ledger = %v, %v", got, err)
+	}
+	err := Append(townRoot,
+		Usage{Actor: "gastown/polecats/Toast", Bead: "gt-1", InputTokens: 100, OutputTokens: 20, CostUSD: 0.5},
+		Usage{Actor: "mayor", CostUSD: 0.25},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Append(townRoot, Usage{Bead: "gt-1"}); err == nil {
+		t.Error("record without actor accepted")
+	}
+
+	// A torn line doesn't hide the records around it
+	f, err := os.OpenFile(LedgerPath(townRoot), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{\"actor\": \n")
+	_ = f.Close()
+	if err := Append(townRoot, Usage{Actor: "mayor", CostUSD: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("records = %+v", got)
+	}
+	if got[0].Timestamp.IsZero() || got[0].Bead != "gt-1" || got[0].InputTokens != 100 {
+		t.Errorf("first record = %+v", got[0])
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	now := time.Now()
+	records := []Usage{
+		{Timestamp: now.Add(-48 * time.Hour), Actor: "gastown/polecats/Toast", Bead: "gt-1", CostUSD: 2},
+		{Timestamp: now, Actor: "gastown/polecats/Toast", Bead: "gt-1", InputTokens: 10, OutputTokens: 5, CostUSD: 1},
+		{Timestamp: now, Actor: "gastown/polecats/Ace", Bead: "gt-2", CostUSD: 4},
+		{Timestamp: now, Actor: "mayor", CostUSD: 0.5},
+	}
+
+	r := Aggregate(records, time.Time{})
+	if r.Total.CostUSD != 7.5 || r.Total.Records != 4 {
+		t.Errorf("total = %+v", r.Total)
+	}
+	if r.ByBead["gt-1"].CostUSD != 3 || r.ByBead["gt-1"].Tokens() != 15 {
+		t.Errorf("gt-1 = %+v", r.ByBead["gt-1"])
+	}
+	if _, ok := r.ByBead[""]; ok {
+		t.Error("unattributed usage counted as a bead")
+	}
+	if got := Ranked(r.ByActor); strings.Join(got, ",") != "gastown/polecats/Ace,gastown/polecats/Toast,mayor" {
+		t.Errorf("ranked actors = %v", got)
+	}
+
+	recent := Aggregate(records, now.Add(-time.Hour))
+	if recent.ByBead["gt-1"].CostUSD != 1 {
+		t.Errorf("recent gt-1 = %+v", recent.ByBead["gt-1"])
+	}
+}
+
+func TestRollup(t *testing.T) {
+	byBead := map[string]Totals{
+		"gt-epic": {CostUSD: 1, Records: 1},
+		"gt-a":    {CostUSD: 2, Records: 1},
+		"gt-a1":   {CostUSD: 3, Records: 1},
+	}
+	tree := map[string][]string{
+		"gt-epic": {"gt-a", "gt-b"},
+		"gt-a":    {"gt-a1", "gt-epic"}, // A cycle must not double count
+	}
+	total, visited, err := Rollup(byBead, "gt-epic", func(id string) ([]string, error) {
+		return tree[id], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.CostUSD != 6 || total.Records != 3 {
+		t.Errorf("rollup total = %+v", total)
+	}
+	if len(visited) != 4 {
+		t.Errorf("visited = %v", visited)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	records := []Usage{
+		{Timestamp: yesterday, Actor: "gastown/polecats/Toast", Bead: "gt-1", CostUSD: 8},
+		{Timestamp: now, Actor: "gastown/polecats/Toast", Bead: "gt-1", CostUSD: 3},
+		{Timestamp: now, Actor: "gastown/polecats/Ace", Bead: "gt-2", OutputTokens: 2000},
+	}
+	cfg := &config.BudgetConfig{
+		Bead:  &config.BudgetLimit{USD: 10, Action: config.BudgetHalt},
+		Agent: &config.BudgetLimit{USD: 5, Tokens: 1000},
+	}
+
+	got := Check(records, cfg, now)
+	if len(got) != 2 {
+		t.Fatalf("breaches = %+v", got)
+	}
+	// Toast spent $11 on gt-1 in total but only $3 today
+	if got[0].ID() != "agent:gastown/polecats/Ace:2026-10-15" || got[0].Halts() {
+		t.Errorf("agent breach = %+v", got[0])
+	}
+	if got[0].Describe() != "2000 of 1000 tokens" {
+		t.Errorf("agent breach described as %q", got[0].Describe())
+	}
+	if got[1].ID() != "bead:gt-1" || !got[1].Halts() || got[1].Describe() != "$11.00 of $10.00" {
+		t.Errorf("bead breach = %+v (%s)", got[1], got[1].Describe())
+	}
+
+	if Check(records, nil, now) != nil {
+		t.Error("nil budgets breached")
+	}
+}
@@ -0,0 +1,205 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/cost"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// BudgetState records the budget breaches already acted on, keyed by
+// cost.Breach.ID, so each is reported (and halted) once.
+type BudgetState struct {
+	Handled map[string]time.Time `json:"handled"`
+}
+
+// BudgetStateFile returns the path of the budget enforcer's state.
+func BudgetStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "budgets.json")
+}
+
+// LoadBudgetState loads the budget state (empty if missing).
+func LoadBudgetState(townRoot string) (*BudgetState, error) {
+	state := &BudgetState{Handled: make(map[string]time.Time)}
+	data, err := os.ReadFile(BudgetStateFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Handled == nil {
+		state.Handled = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// SaveBudgetState writes the budget state.
+func SaveBudgetState(townRoot string, state *BudgetState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(BudgetStateFile(townRoot), data, 0644) //nolint:gosec // G306: state file is non-sensitive
+}
+
+// enforceBudgets checks the usage ledger against the town's budgets and
+// acts on each new breach: a budget_exceeded event, and with action halt,
+// stopping the work. Breaches that have cleared (a raised limit, a new
+// day) are forgotten so they can fire again. Disabled unless budgets are
+// set in the town settings.
+func (d *Daemon) enforceBudgets() {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(d.config.TownRoot))
+	if err != nil || settings.Budgets == nil {
+		return
+	}
+	records, err := cost.Read(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to read usage ledger: %v", err)
+		return
+	}
+	state, err := LoadBudgetState(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to load budget state: %v", err)
+		return
+	}
+
+	now := time.Now()
+	handled := make(map[string]time.Time)
+	for _, b := range cost.Check(records, settings.Budgets, now) {
+		if at, ok := state.Handled[b.ID()]; ok {
+			handled[b.ID()] = at
+			continue
+		}
+		d.actOnBreach(b)
+		handled[b.ID()] = now
+	}
+
+	if len(handled) == 0 && len(state.Handled) == 0 {
+		return
+	}
+	state.Handled = handled
+	if err := SaveBudgetState(d.config.TownRoot, state); err != nil {
+		d.logger.Printf("Warning: failed to save budget state: %v", err)
+	}
+}
+
+// actOnBreach reports a breach and, if its limit halts, stops the work.
+func (d *Daemon) actOnBreach(b cost.Breach) {
+	action := config.BudgetWarn
+	reasons := []string{fmt.Sprintf("%s %s spent %s", b.Scope, b.Key, b.Describe())}
+	var halted []string
+	if b.Halts() {
+		action = config.BudgetHalt
+		var err error
+		halted, err = d.haltForBudget(b)
+		if err != nil {
+			d.logger.Printf("Budget: halting %s %s: %v", b.Scope, b.Key, err)
+			reasons = append(reasons, "halt incomplete: "+err.Error())
+		}
+	}
+	d.logger.Printf("Budget: %s %s over budget (%s), action %s", b.Scope, b.Key, b.Describe(), action)
+
+	payload := map[string]interface{}{
+		"scope": b.Scope, "key": b.Key, "spent": b.Describe(), "action": action,
+		"cost_usd": b.Spent.CostUSD, "tokens": b.Spent.Tokens(),
+	}
+	if len(halted) > 0 {
+		payload["halted"] = halted
+	}
+	rule := "budgets." + b.Scope
+	if b.Scope == cost.ScopeAgent {
+		rule += " (per UTC day " + b.Day + ")"
+	}
+	_, _ = events.LogDecision(events.TypeBudgetExceeded, "daemon", payload, events.VisibilityDefault, events.Rationale{
+		Decision: action + " " + b.Key,
+		Reasons:  reasons,
+		Inputs:   map[string]interface{}{"usd_limit": b.Limit.USD, "token_limit": b.Limit.Tokens, "records": b.Spent.Records},
+		Rule:     rule,
+	})
+}
+
+// haltForBudget stops the work behind a halting breach and returns what it
+// stopped. An over-budget bead is set to blocked, leaving the ready queue
+// until someone reopens it, and the polecat working it is stopped. An
+// over-budget polecat is stopped and its beads released. Only polecats
+// are stopped; other agents' breaches are reported but left running.
+func (d *Daemon) haltForBudget(b cost.Breach) ([]string, error) {
+	var halted []string
+	switch b.Scope {
+	case cost.ScopeBead:
+		bd := beads.New(beads.ResolveHookDir(d.config.TownRoot, b.Key, ""))
+		issue, err := bd.Show(b.Key)
+		if err != nil {
+			return nil, err
+		}
+		if rigName, name, ok := parsePolecatAddress(issue.Assignee); ok {
+			d.stopPolecatForBudget(rigName, name)
+			halted = append(halted, issue.Assignee)
+		}
+		status := "blocked"
+		if err := bd.Update(b.Key, beads.UpdateOptions{Status: &status}); err != nil {
+			return halted, err
+		}
+		halted = append(halted, b.Key)
+		_, _ = bd.AddComment(b.Key, "daemon", "Halted: over budget ("+b.Describe()+"). Raise budgets.bead or reopen to continue.")
+		return halted, nil
+
+	case cost.ScopeAgent:
+		rigName, name, ok := parsePolecatAddress(b.Key)
+		if !ok {
+			return nil, nil
+		}
+		d.stopPolecatForBudget(rigName, name)
+		halted = append(halted, b.Key)
+
+		bd := beads.New(filepath.Join(d.config.TownRoot, rigName, "mayor", "rig"))
+		for _, status := range []string{"in_progress", beads.StatusHooked} {
+			issues, err := bd.List(beads.ListOptions{Status: status, Assignee: b.Key, Priority: -1})
+			if err != nil {
+				return halted, err
+			}
+			for _, issue := range issues {
+				if err := bd.ReleaseWithReason(issue.ID, "budget: "+b.Key+" over budget"); err != nil {
+					return halted, err
+				}
+				halted = append(halted, issue.ID)
+			}
+		}
+	}
+	return halted, nil
+}
+
+// stopPolecatForBudget kills a polecat's session and clears its hook, so
+// the crash check doesn't restart it.
+func (d *Daemon) stopPolecatForBudget(rigName, name string) {
+	sessionName := session.PolecatSessionName(rigName, name)
+	if alive, _ := d.tmux.HasSession(sessionName); alive {
+		if err := d.tmux.KillSession(sessionName); err != nil {
+			d.logger.Printf("Budget: stopping %s failed: %v", sessionName, err)
+		}
+	}
+	if err := beads.New(d.config.TownRoot).ClearHookBead(beads.PolecatBeadID(rigName, name)); err != nil {
+		d.logger.Printf("Budget: clearing hook of %s/%s failed: %v", rigName, name, err)
+	}
+}
+
+// parsePolecatAddress splits "<rig>/polecats/<name>".
+func parsePolecatAddress(addr string) (rigName, name string, ok bool) {
+	parts := strings.Split(addr, "/")
+	if len(parts) != 3 || parts[1] != "polecats" || parts[0] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[2], true
+}
@@ -0,0 +1,43 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParsePolecatAddress(t *testing.T) {
+	if rig, name, ok := parsePolecatAddress("gastown/polecats/Toast"); !ok || rig != "gastown" || name != "Toast" {
+		t.Errorf("polecat address = %q %q %v", rig, name, ok)
+	}
+	for _, addr := range []string{"mayor", "gastown/witness", "gastown/crew/joe", "gastown/polecats/", ""} {
+		if _, _, ok := parsePolecatAddress(addr); ok {
+			t.Errorf("%q parsed as a polecat", addr)
+		}
+	}
+}
+
+func TestBudgetState_RoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "daemon"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := LoadBudgetState(townRoot)
+	if err != nil || len(state.Handled) != 0 {
+		t.Fatalf("empty state = %+v, %v", state, err)
+	}
+	at := time.Now().UTC().Truncate(time.Second)
+	state.Handled["bead:gt-1"] = at
+	if err := SaveBudgetState(townRoot, state); err != nil {
+		t.Fatal(err)
+	}
+	state, err = LoadBudgetState(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Handled["bead:gt-1"].Equal(at) {
+		t.Errorf("handled = %+v", state.Handled)
+	}
+}
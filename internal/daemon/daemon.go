@@ -13,18 +13,22 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/boot"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/feed"
+	"github.com/steveyegge/gastown/internal/flock"
+	"github.com/steveyegge/gastown/internal/notify"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/search"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/witness"
 )
@@ -34,14 +38,20 @@ import (
 // This is recovery-focused: normal wake is handled by feed subscription (bd activity --follow).
 // The daemon is the safety net for dead sessions, GUPP violations, and orphaned work.
 type Daemon struct {
-	config  *Config
-	tmux    *tmux.Tmux
-	logger  *log.Logger
-	ctx     context.Context
-	cancel  context.CancelFunc
-	curator *feed.Curator
+	config   *Config
+	tmux     *tmux.Tmux
+	logger   *log.Logger
+	ctx      context.Context
+	cancel   context.CancelFunc
+	curator  *feed.Curator
+	notifier *notify.Notifier // Nil unless the town routes events to chat
+	beads    *beads.Beads     // Town beads, cached across heartbeats (see townBeads)
 }
 
+// townBeadsCacheTTL bounds how stale the daemon's view of town beads
+// (role configs and the like) may get between heartbeats.
+const townBeadsCacheTTL = 30 * time.Second
+
 // New creates a new daemon instance.
 func New(config *Config) (*Daemon, error) {
 	// Ensure daemon directory exists
@@ -65,9 +75,26 @@ func New(config *Config) (*Daemon, error) {
 		logger: logger,
 		ctx:    ctx,
 		cancel: cancel,
+		beads:  beads.New(config.TownRoot, beads.WithCache(townBeadsCacheTTL)),
 	}, nil
 }
 
+// updateSearchIndex incrementally indexes new beads, mail, events, and
+// transcripts so `gt search` stays fast. Failures are logged, not fatal.
+func (d *Daemon) updateSearchIndex() {
+	if _, err := search.Update(d.config.TownRoot); err != nil {
+		d.logger.Printf("Warning: search index update failed: %v", err)
+	}
+}
+
+// townBeads returns the daemon's cached town beads client.
+func (d *Daemon) townBeads() *beads.Beads {
+	if d.beads == nil {
+		return beads.New(d.config.TownRoot)
+	}
+	return d.beads
+}
+
 // Run starts the daemon main loop.
 func (d *Daemon) Run() error {
 	d.logger.Printf("Daemon starting (PID %d)", os.Getpid())
@@ -75,7 +102,6 @@ func (d *Daemon) Run() error {
 	// Acquire exclusive lock to prevent multiple daemons from running.
 	// This prevents the TOCTOU race condition where multiple concurrent starts
 	// can all pass the IsRunning() check before any writes the PID file.
-	// Uses gofrs/flock for cross-platform compatibility (Unix + Windows).
 	lockFile := filepath.Join(d.config.TownRoot, "daemon", "daemon.lock")
 	fileLock := flock.New(lockFile)
 
@@ -124,6 +150,20 @@ func (d *Daemon) Run() error {
 		d.logger.Println("Feed curator started")
 	}
 
+	// Start the chat notifier, if the town routes events to Slack/Discord
+	if n, err := notify.Load(d.config.TownRoot); err != nil {
+		d.logger.Printf("Warning: failed to start notifier: %v", err)
+	} else if n != nil {
+		d.notifier = n
+		d.notifier.Logf = d.logger.Printf
+		d.notifier.Start()
+		d.logger.Println("Notifier started")
+	}
+
+	// Reconcile beads state against sessions/worktrees before the first
+	// heartbeat, so crash recovery works from accurate claims.
+	d.reconcile()
+
 	// Initial heartbeat
 	d.heartbeat(state)
 
@@ -205,6 +245,33 @@ func (d *Daemon) heartbeat(state *State) {
 	// This validates tmux sessions are still alive for polecats with work-on-hook
 	d.checkPolecatSessionHealth()
 
+	// 9. Park idle polecats and wake parked ones when work arrives (opt-in)
+	d.manageIdlePolecats()
+
+	// 10. Keep the town-wide search index current
+	d.updateSearchIndex()
+
+	// 11. Watch beads sync drift (behind remote, conflicts)
+	d.checkSyncDrift()
+
+	// 12. Close MRs whose GitHub pull requests merged (github_prs rigs)
+	d.reconcileGitHubPRs()
+
+	// 13. Reap dead polecats: release (or respawn) work nobody is doing
+	d.runWatchdog()
+
+	// 14. Dispatch ready work to idle polecats (opt-in)
+	d.runDispatch()
+
+	// 15. Report (and halt) spending over budget
+	d.enforceBudgets()
+
+	// 16. Create scheduled beads that have come due
+	d.runSchedules()
+
+	// 17. Escalate nudges polecats don't answer (opt-in)
+	d.escalateNudges()
+
 	// Update state
 	state.LastHeartbeat = time.Now()
 	state.HeartbeatCount++
@@ -405,6 +472,10 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 		d.logger.Printf("Skipping witness auto-start for %s: %s", rigName, reason)
 		return
 	}
+	if ready, waiting := d.bootDepsReady(rigName, config.BootAgentWitness); !ready {
+		d.logger.Printf("Deferring witness auto-start for %s: waiting for %s", rigName, waiting)
+		return
+	}
 
 	// Manager.Start() handles: zombie detection, session creation, env vars, theming,
 	// WaitForClaudeReady, and crucially - startup/propulsion nudges (GUPP).
@@ -444,6 +515,10 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 		d.logger.Printf("Skipping refinery auto-start for %s: %s", rigName, reason)
 		return
 	}
+	if ready, waiting := d.bootDepsReady(rigName, config.BootAgentRefinery); !ready {
+		d.logger.Printf("Deferring refinery auto-start for %s: waiting for %s", rigName, waiting)
+		return
+	}
 
 	// Manager.Start() handles: zombie detection, session creation, env vars, theming,
 	// WaitForClaudeReady, and crucially - startup/propulsion nudges (GUPP).
@@ -466,6 +541,32 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 	d.logger.Printf("Refinery session for %s started successfully", rigName)
 }
 
+// bootDepsReady reports whether the agents the rig's boot config starts
+// agent after (by default, the refinery after the witness) are running,
+// and if not, which one it's waiting for. The daemon leaves agent for a
+// later heartbeat rather than start it out of order.
+func (d *Daemon) bootDepsReady(rigName, agent string) (bool, string) {
+	var boot *config.BootConfig
+	if settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(d.config.TownRoot, rigName))); err == nil {
+		boot = settings.Boot
+	}
+	for _, dep := range boot.AfterFor(agent) {
+		var sess string
+		switch dep {
+		case config.BootAgentWitness:
+			sess = session.WitnessSessionName(rigName)
+		case config.BootAgentRefinery:
+			sess = session.RefinerySessionName(rigName)
+		default:
+			continue // Crew and polecats aren't the daemon's to wait on
+		}
+		if !d.tmux.IsAgentRunning(sess) {
+			return false, dep
+		}
+	}
+	return true, ""
+}
+
 // getKnownRigs returns list of registered rig names.
 func (d *Daemon) getKnownRigs() []string {
 	rigsPath := filepath.Join(d.config.TownRoot, "mayor", "rigs.json")
@@ -506,6 +607,8 @@ func (d *Daemon) isRigOperational(rigName string) (bool, string) {
 		return false, "rig is parked"
 	case "docked":
 		return false, "rig is docked"
+	case "draining":
+		return false, "rig is draining for halt"
 	}
 
 	// Check auto_restart config
@@ -590,6 +693,11 @@ func (d *Daemon) shutdown(state *State) error { //nolint:unparam // error return
 		d.logger.Println("Feed curator stopped")
 	}
 
+	if d.notifier != nil {
+		d.notifier.Stop()
+		d.logger.Println("Notifier stopped")
+	}
+
 	state.Running = false
 	if err := SaveState(d.config.TownRoot, state); err != nil {
 		d.logger.Printf("Warning: failed to save final state: %v", err)
@@ -746,6 +854,8 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	// Polecat has work but session is dead - this is a crash!
 	d.logger.Printf("CRASH DETECTED: polecat %s/%s has hook_bead=%s but session %s is dead",
 		rigName, polecatName, info.HookBead, sessionName)
+	_ = events.LogError(events.TypeAgentCrash, "daemon", fmt.Errorf("session %s is dead", sessionName),
+		events.AgentCrashPayload(rigName, rigName+"/polecats/"+polecatName, info.HookBead))
 
 	// Auto-restart the polecat
 	if err := d.restartPolecatSession(rigName, polecatName, sessionName); err != nil {
@@ -802,6 +912,11 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 	agentID := fmt.Sprintf("%s/%s", rigName, polecatName)
 	_ = d.tmux.SetPaneDiedHook(sessionName, agentID)
 
+	// Record the restarted session in a fresh transcript
+	if _, err := transcript.Start(d.tmux, d.config.TownRoot, sessionName); err != nil {
+		d.logger.Printf("Warning: failed to record transcript of %s: %v", sessionName, err)
+	}
+
 	// Launch Claude with environment exported inline
 	// Pass rigPath so rig agent settings are honored (not town-level defaults)
 	rigPath := filepath.Join(d.config.TownRoot, rigName)
@@ -0,0 +1,249 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+// dispatchRule describes the assignment policy in dispatch events.
+const dispatchRule = "highest priority first; type specialists, then least-loaded polecat (mayor/daemon.json dispatch)"
+
+// dispatchable reports whether a ready bead is work a polecat can take:
+// unassigned, not held by a live reservation (the reserving agent claims
+// it itself), and not an agent, message, or container bead.
+func dispatchable(issue *beads.Issue) bool {
+	if issue.Assignee != "" {
+		return false
+	}
+	if r, ok := beads.ParseReservation(issue); ok && !r.Expired(time.Now()) {
+		return false
+	}
+	switch issue.Type {
+	case "agent", "role", "rig", beads.TypeMessage, "molecule", "epic", "convoy":
+		return false
+	}
+	return true
+}
+
+// dispatchWorker is an idle polecat as the dispatcher sees it.
+type dispatchWorker struct {
+	name  string
+	load  int      // Beads it already holds
+	types []string // Bead types it is restricted to; nil takes any
+}
+
+// dispatchAssignment is one bead planned for one polecat.
+type dispatchAssignment struct {
+	bead    *beads.Issue
+	worker  string
+	load    int      // The worker's load before this bead
+	reasons []string // Why this worker, for the event's rationale
+	others  []string // Other workers that could have taken it
+}
+
+// planDispatch assigns ready beads to workers: highest priority (then
+// oldest) first, each to an eligible worker under the wip limit,
+// preferring workers whose affinity names the bead's type, then the
+// least loaded. Beads no worker may take while capacity remains are
+// returned as unmatched. Pure so the policy can be tested without bd.
+func planDispatch(ready []*beads.Issue, workers []dispatchWorker, wip int) ([]dispatchAssignment, []*beads.Issue) {
+	var queue []*beads.Issue
+	for _, issue := range ready {
+		if dispatchable(issue) {
+			queue = append(queue, issue)
+		}
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].Priority != queue[j].Priority {
+			return queue[i].Priority < queue[j].Priority
+		}
+		if !queue[i].CreatedAt.Equal(queue[j].CreatedAt) {
+			return queue[i].CreatedAt.Before(queue[j].CreatedAt)
+		}
+		return queue[i].ID < queue[j].ID
+	})
+
+	pool := append([]dispatchWorker(nil), workers...)
+	sort.Slice(pool, func(i, j int) bool { return pool[i].name < pool[j].name })
+
+	var assigned []dispatchAssignment
+	var unmatched []*beads.Issue
+	for _, issue := range queue {
+		var eligible []int
+		capacity := false
+		for i, w := range pool {
+			if w.load >= wip {
+				continue
+			}
+			capacity = true
+			if w.types == nil || slices.Contains(w.types, issue.Type) {
+				eligible = append(eligible, i)
+			}
+		}
+		if !capacity {
+			break
+		}
+		if len(eligible) == 0 {
+			unmatched = append(unmatched, issue)
+			continue
+		}
+
+		best := eligible[0]
+		for _, i := range eligible[1:] {
+			if betterWorker(pool[i], pool[best]) {
+				best = i
+			}
+		}
+		w := pool[best]
+
+		reasons := []string{fmt.Sprintf("P%d %s, next in priority order", issue.Priority, issue.Type)}
+		if w.types != nil {
+			reasons = append(reasons, fmt.Sprintf("%s has affinity for %s", w.name, issue.Type))
+		}
+		reasons = append(reasons, fmt.Sprintf("%s holds %d of %d bead(s)", w.name, w.load, wip))
+		var others []string
+		for _, i := range eligible {
+			if i != best {
+				others = append(others, pool[i].name)
+			}
+		}
+
+		assigned = append(assigned, dispatchAssignment{bead: issue, worker: w.name, load: w.load, reasons: reasons, others: others})
+		pool[best].load++
+	}
+	return assigned, unmatched
+}
+
+// betterWorker reports whether a should take work over b, both eligible:
+// specialists before generalists, then the less loaded, then by name.
+func betterWorker(a, b dispatchWorker) bool {
+	if (a.types != nil) != (b.types != nil) {
+		return a.types != nil
+	}
+	if a.load != b.load {
+		return a.load < b.load
+	}
+	return a.name < b.name
+}
+
+// runDispatch assigns each rig's ready work to its idle polecats, claiming
+// each bead atomically before slinging it. Disabled unless
+// dispatch.enabled is set in mayor/daemon.json.
+func (d *Daemon) runDispatch() {
+	cfg, err := config.LoadDaemonPatrolConfig(config.DaemonPatrolConfigPath(d.config.TownRoot))
+	if err != nil || cfg.Dispatch == nil || !cfg.Dispatch.Enabled {
+		return
+	}
+	wip := cfg.Dispatch.WIPLimit()
+
+	for _, rigName := range d.getKnownRigs() {
+		// Parked, docked, and draining rigs (gt down --drain) take no new work
+		switch wisp.NewConfig(d.config.TownRoot, rigName).GetString("status") {
+		case "parked", "docked", "draining":
+			continue
+		}
+		snap, err := d.snapshotRig(rigName)
+		if err != nil {
+			d.logger.Printf("Dispatch for %s failed: %v", rigName, err)
+			continue
+		}
+
+		// Polecats whose agent exited are the watchdog's, not ours
+		var workers []dispatchWorker
+		for name, alive := range snap.sessions {
+			if !alive || len(snap.claims[name]) >= wip {
+				continue
+			}
+			if !d.tmux.IsAgentRunning(session.PolecatSessionName(rigName, name)) {
+				continue
+			}
+			workers = append(workers, dispatchWorker{
+				name:  name,
+				load:  len(snap.claims[name]),
+				types: cfg.Dispatch.AffinityFor(rigName, name),
+			})
+		}
+		if len(workers) == 0 {
+			continue
+		}
+
+		bd := beads.New(filepath.Join(d.config.TownRoot, rigName, "mayor", "rig"))
+		ready, err := bd.Ready()
+		if err != nil {
+			d.logger.Printf("Dispatch for %s failed: listing ready work: %v", rigName, err)
+			continue
+		}
+
+		assigned, unmatched := planDispatch(ready, workers, wip)
+		for _, a := range assigned {
+			d.dispatchBead(bd, rigName, a, wip)
+		}
+		for _, issue := range unmatched {
+			logDispatchSkipped(rigName, issue.ID, "", "no idle polecat has affinity for "+issue.Type)
+		}
+	}
+}
+
+// dispatchBead claims a's bead for its polecat and slings it there. A bead
+// claimed by someone else first is skipped; one that fails to sling is
+// released again so it stays in the ready queue.
+func (d *Daemon) dispatchBead(bd *beads.Beads, rigName string, a dispatchAssignment, wip int) {
+	id := a.bead.ID
+	agent := fmt.Sprintf("%s/polecats/%s", rigName, a.worker)
+
+	if err := bd.Claim(id, agent); err != nil {
+		reason := fmt.Sprintf("claim failed: %v", err)
+		if errors.Is(err, beads.ErrAlreadyClaimed) {
+			reason = "claimed by another agent first"
+		}
+		d.logger.Printf("Dispatch: skipped %s for %s: %s", id, agent, reason)
+		logDispatchSkipped(rigName, id, agent, reason)
+		return
+	}
+
+	cmd := exec.Command("gt", "sling", id, agent) //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	if out, err := cmd.CombinedOutput(); err != nil {
+		d.logger.Printf("Dispatch: slinging %s to %s failed: %v: %s", id, agent, err, out)
+		if err := bd.ReleaseWithReason(id, "dispatch: sling to "+agent+" failed"); err != nil {
+			d.logger.Printf("Dispatch: releasing %s failed: %v", id, err)
+		}
+		logDispatchSkipped(rigName, id, agent, fmt.Sprintf("sling failed: %v", err))
+		return
+	}
+
+	d.logger.Printf("Dispatched %s (P%d %s) to %s", id, a.bead.Priority, a.bead.Type, agent)
+	_, _ = events.LogDecision(events.TypeDispatched, "daemon", map[string]interface{}{
+		"rig": rigName, "bead": id, "agent": agent,
+	}, events.VisibilityDefault, events.Rationale{
+		Decision:     "dispatched " + id + " to " + agent,
+		Reasons:      a.reasons,
+		Inputs:       map[string]interface{}{"priority": a.bead.Priority, "type": a.bead.Type, "load": a.load, "wip": wip},
+		Alternatives: a.others,
+		Rule:         dispatchRule,
+	})
+}
+
+// logDispatchSkipped records a ready bead the dispatcher did not assign.
+func logDispatchSkipped(rigName, id, agent, reason string) {
+	payload := map[string]interface{}{"rig": rigName, "bead": id, "reason": reason}
+	if agent != "" {
+		payload["agent"] = agent
+	}
+	_, _ = events.LogDecision(events.TypeDispatchSkipped, "daemon", payload, events.VisibilityDefault, events.Rationale{
+		Decision: "left " + id + " in the ready queue",
+		Reasons:  []string{reason},
+		Rule:     dispatchRule,
+	})
+}
@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+func TestPlanDispatch(t *testing.T) {
+	now := time.Now()
+	ready := []*beads.Issue{
+		{ID: "gt-low", Type: "task", Priority: 3, CreatedAt: now},
+		{ID: "gt-bug", Type: "bug", Priority: 1, CreatedAt: now},
+		{ID: "gt-old", Type: "task", Priority: 2, CreatedAt: now.Add(-time.Hour)},
+		{ID: "gt-new", Type: "task", Priority: 2, CreatedAt: now},
+		{ID: "gt-taken", Type: "task", Priority: 0, Assignee: "gastown/polecats/Toast"},
+		{ID: "gt-epic", Type: "epic", Priority: 0},
+		{ID: "gt-reserved", Type: "task", Priority: 0, Labels: []string{
+			beads.LabelReservedFor + ":gastown/polecats/Nux",
+			beads.LabelReservedUntil + ":" + strconv.FormatInt(now.Add(time.Hour).Unix(), 10),
+		}},
+	}
+	workers := []dispatchWorker{
+		{name: "Toast"},
+		{name: "Ace", load: 1},
+		{name: "Bugsy", types: []string{"bug"}},
+	}
+
+	got, unmatched := planDispatch(ready, workers, 2)
+	want := []struct{ bead, worker string }{
+		{"gt-bug", "Bugsy"}, // Specialist over generalists
+		{"gt-old", "Toast"}, // Least loaded generalist
+		{"gt-new", "Ace"},   // Tie on load broken by name
+		{"gt-low", "Toast"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("assignments = %d, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].bead.ID != w.bead || got[i].worker != w.worker {
+			t.Errorf("assignment %d = %s → %s, want %s → %s", i, got[i].bead.ID, got[i].worker, w.bead, w.worker)
+		}
+	}
+	if len(unmatched) != 0 {
+		t.Errorf("unmatched = %+v", unmatched)
+	}
+	if got[0].load != 0 || len(got[0].others) != 2 {
+		t.Errorf("bug assignment = %+v", got[0])
+	}
+}
+
+func TestPlanDispatch_Limits(t *testing.T) {
+	ready := []*beads.Issue{
+		{ID: "gt-1", Type: "feature", Priority: 1},
+		{ID: "gt-2", Type: "task", Priority: 2},
+		{ID: "gt-3", Type: "task", Priority: 3},
+	}
+	workers := []dispatchWorker{
+		{name: "Bugsy", types: []string{"bug"}},
+		{name: "Busy", load: 1},
+		{name: "Toast"},
+	}
+
+	// Toast fills up on the feature; the bug specialist can't take tasks
+	got, unmatched := planDispatch(ready, workers, 1)
+	if len(got) != 1 || got[0].bead.ID != "gt-1" || got[0].worker != "Toast" {
+		t.Fatalf("assignments = %+v", got)
+	}
+	if len(unmatched) != 2 || unmatched[0].ID != "gt-2" {
+		t.Errorf("unmatched = %+v", unmatched)
+	}
+
+	// Only the bug specialist has room: the tasks can't go to it
+	got, unmatched = planDispatch(ready, workers[:2], 1)
+	if len(got) != 0 || len(unmatched) != 3 {
+		t.Errorf("assignments = %+v, unmatched = %+v", got, unmatched)
+	}
+
+	// No capacity at all: nothing is unmatched, just waiting
+	got, unmatched = planDispatch(ready, workers[1:2], 1)
+	if len(got) != 0 || len(unmatched) != 0 {
+		t.Errorf("assignments = %+v, unmatched = %+v", got, unmatched)
+	}
+}
+
+func TestDispatchConfig(t *testing.T) {
+	var nilCfg *config.DispatchConfig
+	if got := nilCfg.WIPLimit(); got != config.DefaultDispatchWIP {
+		t.Errorf("nil wip = %d", got)
+	}
+	if nilCfg.AffinityFor("gastown", "Toast") != nil {
+		t.Error("nil config has affinity")
+	}
+
+	cfg := &config.DispatchConfig{WIP: 3, Affinity: map[string][]string{
+		"Toast":         {"bug"},
+		"gastown/Toast": {"docs"},
+	}}
+	if got := cfg.WIPLimit(); got != 3 {
+		t.Errorf("wip = %d", got)
+	}
+	if got := cfg.AffinityFor("gastown", "Toast"); len(got) != 1 || got[0] != "docs" {
+		t.Errorf("rig affinity = %v", got)
+	}
+	if got := cfg.AffinityFor("beads", "Toast"); len(got) != 1 || got[0] != "bug" {
+		t.Errorf("bare affinity = %v", got)
+	}
+}
+
+func TestRunDispatch_SkipsStoppedRigs(t *testing.T) {
+	townRoot := t.TempDir()
+	mayorDir := filepath.Join(townRoot, "mayor")
+	if err := os.MkdirAll(mayorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"rigs.json":   `{"rigs": {"parked": {}, "docked": {}, "draining": {}, "norestart": {}}}`,
+		"daemon.json": `{"dispatch": {"enabled": true}}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(mayorDir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, status := range []string{"parked", "docked", "draining"} {
+		if err := wisp.NewConfig(townRoot, status).Set("status", status); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// auto_restart governs restarting agents, not handing out work
+	if err := wisp.NewConfig(townRoot, "norestart").Set("auto_restart", false); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	d := &Daemon{config: &Config{TownRoot: townRoot}, logger: log.New(&logs, "", 0), tmux: tmux.NewTmux()}
+	d.runDispatch()
+
+	for _, rig := range []string{"parked", "docked", "draining"} {
+		if strings.Contains(logs.String(), "Dispatch for "+rig) {
+			t.Errorf("dispatch reached the %s rig; log:\n%s", rig, logs.String())
+		}
+	}
+	if !strings.Contains(logs.String(), "Dispatch for norestart") {
+		t.Errorf("dispatch skipped a rig with auto_restart disabled; log:\n%s", logs.String())
+	}
+	if strings.Contains(logs.String(), "Skipping dispatch") {
+		t.Errorf("stopped rigs logged on every heartbeat; log:\n%s", logs.String())
+	}
+}
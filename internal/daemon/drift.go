@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// DriftTownScope is the DriftState key for the town-level beads.
+const DriftTownScope = "town"
+
+// DriftRecord is the last observed sync status of one beads repo.
+type DriftRecord struct {
+	Behind    int       `json:"behind"`
+	Ahead     int       `json:"ahead"`
+	Conflicts []string  `json:"conflicts,omitempty"`
+	Drifting  bool      `json:"drifting"`
+	Since     time.Time `json:"since,omitempty"` // When drift began (zero if not drifting)
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// DriftState is the daemon's view of beads sync drift, keyed by rig name
+// (or DriftTownScope). gt status reads it to surface drift without
+// running bd itself.
+type DriftState struct {
+	Rigs map[string]DriftRecord `json:"rigs"`
+}
+
+// DriftStateFile returns the path of the sync drift state.
+func DriftStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "sync-drift.json")
+}
+
+// LoadDriftState loads the sync drift state (empty if missing).
+func LoadDriftState(townRoot string) (*DriftState, error) {
+	state := &DriftState{Rigs: make(map[string]DriftRecord)}
+	data, err := os.ReadFile(DriftStateFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Rigs == nil {
+		state.Rigs = make(map[string]DriftRecord)
+	}
+	return state, nil
+}
+
+// SaveDriftState writes the sync drift state.
+func SaveDriftState(townRoot string, state *DriftState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(DriftStateFile(townRoot), data, 0644) //nolint:gosec // G306: state file is non-sensitive
+}
+
+// Drifting returns the scopes currently drifting, sorted.
+func (s *DriftState) Drifting() []string {
+	var out []string
+	for scope, rec := range s.Rigs {
+		if rec.Drifting {
+			out = append(out, scope)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// driftTransition folds a fresh sync status into the previous record and
+// returns the new record plus the event to emit, if any. Events fire only
+// on changes: entering drift, new or different conflicts, and recovery.
+// Pure so the policy can be tested without bd.
+func driftTransition(prev DriftRecord, cur *beads.SyncStatus, threshold int, now time.Time) (DriftRecord, string) {
+	rec := DriftRecord{
+		Behind:    cur.Behind,
+		Ahead:     cur.Ahead,
+		Conflicts: cur.Conflicts,
+		Drifting:  len(cur.Conflicts) > 0 || cur.Behind > threshold,
+		CheckedAt: now,
+	}
+	if rec.Drifting {
+		rec.Since = now
+		if prev.Drifting {
+			rec.Since = prev.Since
+		}
+	}
+
+	switch {
+	case len(rec.Conflicts) > 0 && !slices.Equal(rec.Conflicts, prev.Conflicts):
+		return rec, events.TypeSyncConflict
+	case rec.Drifting && !prev.Drifting:
+		return rec, events.TypeSyncDrift
+	case !rec.Drifting && prev.Drifting:
+		return rec, events.TypeSyncRecovered
+	}
+	return rec, ""
+}
+
+// checkSyncDrift polls bd sync status for the town and every rig, records
+// the result for gt status, and emits feed events when drift starts,
+// conflicts appear, or drift clears. On by default; set
+// sync_drift.disabled in mayor/daemon.json to turn it off.
+func (d *Daemon) checkSyncDrift() {
+	cfg, err := config.LoadDaemonPatrolConfig(config.DaemonPatrolConfigPath(d.config.TownRoot))
+	if err == nil && !cfg.SyncDrift.Enabled() {
+		return
+	}
+	threshold := config.DefaultSyncDriftBehind
+	if err == nil {
+		threshold = cfg.SyncDrift.Threshold()
+	}
+
+	state, err := LoadDriftState(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to load sync drift state: %v", err)
+		return
+	}
+
+	scopes := map[string]*beads.Beads{DriftTownScope: d.townBeads()}
+	for _, rigName := range d.getKnownRigs() {
+		scopes[rigName] = beads.New(filepath.Join(d.config.TownRoot, rigName, "mayor", "rig"))
+	}
+
+	now := time.Now()
+	for scope, bd := range scopes {
+		status, err := bd.SyncStatus()
+		if err != nil {
+			d.logger.Printf("Sync status for %s failed: %v", scope, err)
+			continue
+		}
+		rec, eventType := driftTransition(state.Rigs[scope], status, threshold, now)
+		state.Rigs[scope] = rec
+		if eventType == "" {
+			continue
+		}
+		d.logger.Printf("Beads %s: %s (behind %d, %d conflicts)", eventType, scope, rec.Behind, len(rec.Conflicts))
+		_ = events.Log(eventType, "daemon", events.SyncDriftPayload(scope, rec.Behind, rec.Ahead, rec.Conflicts), events.VisibilityDefault)
+	}
+
+	for scope := range state.Rigs {
+		if _, ok := scopes[scope]; !ok {
+			delete(state.Rigs, scope)
+		}
+	}
+	if err := SaveDriftState(d.config.TownRoot, state); err != nil {
+		d.logger.Printf("Warning: failed to save sync drift state: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestDriftTransition(t *testing.T) {
+	t0 := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(3 * time.Minute)
+
+	steps := []struct {
+		status    beads.SyncStatus
+		wantEvent string
+		wantSince time.Time
+	}{
+		{beads.SyncStatus{Behind: 3}, "", time.Time{}},
+		{beads.SyncStatus{Behind: 12}, events.TypeSyncDrift, t0},
+		{beads.SyncStatus{Behind: 15}, "", t0},
+		{beads.SyncStatus{Behind: 15, Conflicts: []string{"gt-1"}}, events.TypeSyncConflict, t0},
+		{beads.SyncStatus{Behind: 15, Conflicts: []string{"gt-1"}}, "", t0},
+		{beads.SyncStatus{}, events.TypeSyncRecovered, time.Time{}},
+		{beads.SyncStatus{Conflicts: []string{"gt-2"}}, events.TypeSyncConflict, t1},
+	}
+
+	var rec DriftRecord
+	for i, step := range steps {
+		now := t0
+		if i == len(steps)-1 {
+			now = t1
+		}
+		var ev string
+		rec, ev = driftTransition(rec, &step.status, 10, now)
+		if ev != step.wantEvent {
+			t.Errorf("step %d: event = %q, want %q", i, ev, step.wantEvent)
+		}
+		if !rec.Since.Equal(step.wantSince) {
+			t.Errorf("step %d: since = %v, want %v", i, rec.Since, step.wantSince)
+		}
+	}
+}
+
+func TestDriftStateRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "daemon"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := LoadDriftState(townRoot)
+	if err != nil || len(state.Rigs) != 0 {
+		t.Fatalf("empty load = %+v, %v", state, err)
+	}
+	state.Rigs["gastown"] = DriftRecord{Behind: 20, Drifting: true}
+	state.Rigs[DriftTownScope] = DriftRecord{Behind: 1}
+	state.Rigs["beads"] = DriftRecord{Conflicts: []string{"bd-1"}, Drifting: true}
+	if err := SaveDriftState(townRoot, state); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadDriftState(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded.Drifting(), []string{"beads", "gastown"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Drifting() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,316 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// NudgeEscalation tracks the escalation of a polecat's unanswered nudge.
+type NudgeEscalation struct {
+	NudgedAt time.Time `json:"nudged_at"` // The nudge being escalated
+	Step     int       `json:"step"`      // Steps taken so far
+	StepAt   time.Time `json:"step_at"`   // When the last step, or the nudge, happened
+}
+
+// EscalationState is the escalations in progress, keyed by "<rig>/<name>",
+// so a policy's waits survive daemon restarts.
+type EscalationState struct {
+	Polecats map[string]NudgeEscalation `json:"polecats"`
+}
+
+// EscalationStateFile returns the path of the nudge escalation state.
+func EscalationStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "escalations.json")
+}
+
+// LoadEscalationState loads the nudge escalation state (empty if missing).
+func LoadEscalationState(townRoot string) (*EscalationState, error) {
+	state := &EscalationState{Polecats: make(map[string]NudgeEscalation)}
+	data, err := os.ReadFile(EscalationStateFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Polecats == nil {
+		state.Polecats = make(map[string]NudgeEscalation)
+	}
+	return state, nil
+}
+
+// SaveEscalationState writes the nudge escalation state.
+func SaveEscalationState(townRoot string, state *EscalationState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(EscalationStateFile(townRoot), data, 0644) //nolint:gosec // G306: state file is non-sensitive
+}
+
+// nudgeActivity is what the event log says about a polecat: when it was
+// last nudged, and when it last logged an event of its own.
+type nudgeActivity struct {
+	nudged time.Time
+	active time.Time
+}
+
+// nudgeActivityByPolecat collects each polecat's nudgeActivity from the
+// town's events, keyed by "<rig>/<name>".
+func nudgeActivityByPolecat(evts []events.Event) map[string]nudgeActivity {
+	out := make(map[string]nudgeActivity)
+	for _, e := range evts {
+		t, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		if key := polecatKey(e.Actor); key != "" {
+			act := out[key]
+			if t.After(act.active) {
+				act.active = t
+				out[key] = act
+			}
+		}
+		if e.Type != events.TypeNudge && e.Type != events.TypePolecatNudged {
+			continue
+		}
+		rig, _ := e.Payload["rig"].(string)
+		target, _ := e.Payload["target"].(string)
+		key := nudgeTargetKey(rig, target)
+		if key == "" || key == polecatKey(e.Actor) {
+			continue
+		}
+		act := out[key]
+		if t.After(act.nudged) {
+			act.nudged = t
+			out[key] = act
+		}
+	}
+	return out
+}
+
+// polecatKey returns "<rig>/<name>" for a polecat's actor, either
+// "<rig>/polecats/<name>" or "<rig>/<name>", or "" for other actors.
+func polecatKey(actor string) string {
+	parts := strings.Split(strings.TrimSuffix(actor, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[1] == "polecats":
+		return parts[0] + "/" + parts[2]
+	case len(parts) == 2 && parts[1] != "witness" && parts[1] != "refinery":
+		return parts[0] + "/" + parts[1]
+	}
+	return ""
+}
+
+// nudgeTargetKey returns "<rig>/<name>" for a nudge aimed at a polecat:
+// an address, a session name, or (for polecat_nudged events) a bare name
+// in rig. Other targets give "".
+func nudgeTargetKey(rig, target string) string {
+	if id, err := session.ParseSessionName(target); err == nil {
+		if id.Role == session.RolePolecat {
+			return id.Rig + "/" + id.Name
+		}
+		return ""
+	}
+	if !strings.Contains(target, "/") {
+		if rig == "" || target == "" {
+			return ""
+		}
+		target = rig + "/" + target
+	}
+	return polecatKey(target)
+}
+
+// nextEscalation advances a polecat's escalation: nil once it has logged
+// an event since the nudge, else the escalation with the action due now
+// taken, if any. A newer nudge restarts the escalation. Pure so the
+// policy can be tested without tmux or an event log.
+func nextEscalation(policy *config.NudgeEscalationConfig, esc *NudgeEscalation, act nudgeActivity, now time.Time) (*NudgeEscalation, string) {
+	if act.nudged.IsZero() || act.active.After(act.nudged) {
+		return nil, ""
+	}
+	next := NudgeEscalation{NudgedAt: act.nudged, StepAt: act.nudged}
+	if esc != nil && !act.nudged.After(esc.NudgedAt) {
+		next = *esc
+	}
+
+	steps := policy.Steps()
+	if next.Step >= len(steps) || now.Sub(next.StepAt) < policy.WaitBefore(next.Step) {
+		return &next, ""
+	}
+	action := steps[next.Step]
+	next.Step++
+	next.StepAt = now
+	return &next, action
+}
+
+// escalateNudges follows up on nudges polecats don't answer, stepping
+// through the nudge_escalation policy in mayor/daemon.json: nudging
+// again, notifying the overseer, then killing or respawning. Each step
+// is recorded as a nudge_escalated event. Disabled unless
+// nudge_escalation.enabled is set.
+func (d *Daemon) escalateNudges() {
+	cfg, err := config.LoadDaemonPatrolConfig(config.DaemonPatrolConfigPath(d.config.TownRoot))
+	if err != nil || cfg.NudgeEscalation == nil || !cfg.NudgeEscalation.Enabled {
+		return
+	}
+	policy := cfg.NudgeEscalation
+
+	state, err := LoadEscalationState(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to load escalation state: %v", err)
+		return
+	}
+	now := time.Now()
+	evts, err := events.ReadTownSince(d.config.TownRoot, now.Add(-eventWindow(cfg)), events.Filter{})
+	if err != nil {
+		d.logger.Printf("Nudge escalation: reading events failed: %v", err)
+		return
+	}
+
+	live := make(map[string]bool)
+	if sessions, err := d.tmux.ListSessions(); err == nil {
+		for _, s := range sessions {
+			if id, err := session.ParseSessionName(s); err == nil && id.Role == session.RolePolecat {
+				live[id.Rig+"/"+id.Name] = true
+			}
+		}
+	}
+
+	activity := nudgeActivityByPolecat(evts)
+	keys := make([]string, 0, len(activity))
+	for key := range activity {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	escalations := make(map[string]NudgeEscalation)
+	for _, key := range keys {
+		prev, tracked := state.Polecats[key]
+		if !live[key] {
+			// Killed, parked or gone: hold its place rather than restart it
+			if tracked {
+				escalations[key] = prev
+			}
+			continue
+		}
+		var esc *NudgeEscalation
+		if tracked {
+			esc = &prev
+		}
+		next, action := nextEscalation(policy, esc, activity[key], now)
+		if next == nil {
+			continue
+		}
+		if action != "" {
+			if err := d.takeEscalationStep(key, action, next, policy, activity[key]); err != nil {
+				d.logger.Printf("Nudge escalation: %s of %s failed: %v", action, key, err)
+				if esc == nil {
+					continue
+				}
+				next = esc // Retry the step next heartbeat
+			}
+		}
+		escalations[key] = *next
+	}
+
+	state.Polecats = escalations
+	if err := SaveEscalationState(d.config.TownRoot, state); err != nil {
+		d.logger.Printf("Warning: failed to save escalation state: %v", err)
+	}
+}
+
+// takeEscalationStep takes action, step esc.Step of the policy, on the
+// polecat key ("<rig>/<name>") and records it.
+func (d *Daemon) takeEscalationStep(key, action string, esc *NudgeEscalation, policy *config.NudgeEscalationConfig, act nudgeActivity) error {
+	rigName, name := filepath.Dir(key), filepath.Base(key)
+	agent := fmt.Sprintf("%s/polecats/%s", rigName, name)
+	sessionName := session.PolecatSessionName(rigName, name)
+	silentFor := esc.StepAt.Sub(esc.NudgedAt).Round(time.Minute)
+
+	var decision string
+	switch action {
+	case config.NudgeActionRenudge:
+		steps := policy.Steps()
+		last := esc.Step < len(steps) && steps[esc.Step] != config.NudgeActionRenudge
+		msg := escalationNudgeMessage(esc.Step, last, silentFor)
+		if err := d.tmux.NudgeSession(sessionName, msg); err != nil {
+			return err
+		}
+		decision = "nudged " + agent + " again"
+	case config.NudgeActionNotify:
+		subject := fmt.Sprintf("[high] %s is not responding to nudges", agent)
+		body := fmt.Sprintf(`Polecat %s has logged no events for %s since it was nudged at %s.
+
+Escalation step %d of %d (%s).
+
+Check on it: gt peek %s`,
+			agent, silentFor, esc.NudgedAt.Format(time.RFC3339), esc.Step, len(policy.Steps()), strings.Join(policy.Steps(), " → "), key)
+		cmd := exec.Command("gt", "mail", "send", "overseer", "-s", subject, "-m", body, "--priority", "1") //nolint:gosec // G204: args are constructed internally
+		cmd.Dir = d.config.TownRoot
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%v: %s", err, out)
+		}
+		decision = "notified the overseer about " + agent
+	case config.NudgeActionKill:
+		if err := d.tmux.KillSession(sessionName); err != nil {
+			return err
+		}
+		decision = "killed " + agent
+	case config.NudgeActionRespawn:
+		if err := d.tmux.KillSession(sessionName); err != nil {
+			return err
+		}
+		if err := d.restartPolecatSession(rigName, name, sessionName); err != nil {
+			return err
+		}
+		decision = "respawned " + agent
+	default:
+		return fmt.Errorf("unknown escalation action %q", action)
+	}
+
+	d.logger.Printf("Nudge escalation: %s (silent %s since nudge)", decision, silentFor)
+	lastEvent := "never"
+	if !act.active.IsZero() {
+		lastEvent = act.active.Format(time.RFC3339)
+	}
+	_, _ = events.LogDecision(events.TypeNudgeEscalated, "daemon",
+		events.NudgeEscalationPayload(rigName, name, esc.Step, action, silentFor), events.VisibilityDefault, events.Rationale{
+			Decision: decision,
+			Reasons:  []string{fmt.Sprintf("no events from %s for %s after a nudge", agent, silentFor)},
+			Inputs:   map[string]interface{}{"nudged_at": esc.NudgedAt.Format(time.RFC3339), "last_event": lastEvent},
+			Rule:     fmt.Sprintf("nudge_escalation step %d of %d (%s)", esc.Step, len(policy.Steps()), strings.Join(policy.Steps(), " → ")),
+		})
+	return nil
+}
+
+// escalationNudgeMessage is the text of the n-th re-nudge, more urgent
+// each time; the last warns that the overseer is told next.
+func escalationNudgeMessage(n int, last bool, silentFor time.Duration) string {
+	var msg string
+	switch n {
+	case 1:
+		msg = fmt.Sprintf("[from daemon] REMINDER: no activity from you in %s since you were nudged. "+
+			"Check your hook (gt hook) and carry on, or run gt done / gt handoff.", silentFor)
+	default:
+		msg = fmt.Sprintf("[from daemon] URGENT (%d): still no activity from you after %s. "+
+			"Respond now: continue your hooked work, or gt done / gt handoff.", n, silentFor)
+	}
+	if last {
+		msg += " The overseer will be notified if you don't respond."
+	}
+	return msg
+}
@@ -0,0 +1,85 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestNudgeActivityByPolecat(t *testing.T) {
+	ts := func(min int) string {
+		return time.Date(2026, 1, 1, 12, min, 0, 0, time.UTC).Format(time.RFC3339)
+	}
+	evts := []events.Event{
+		{Timestamp: ts(0), Type: events.TypeDone, Actor: "gastown/polecats/nux"},
+		{Timestamp: ts(1), Type: events.TypeNudge, Actor: "mayor", Payload: events.NudgePayload("gastown", "gastown/nux", "status?")},
+		{Timestamp: ts(2), Type: events.TypeNudge, Actor: "mayor", Payload: events.NudgePayload("", "gt-gastown-slit", "status?")},
+		{Timestamp: ts(3), Type: events.TypeHook, Actor: "gastown/slit"},
+		{Timestamp: ts(4), Type: events.TypePolecatNudged, Actor: "gastown/witness", Payload: events.NudgePayload("gastown", "ace", "idle")},
+		{Timestamp: ts(5), Type: events.TypeNudge, Actor: "mayor", Payload: events.NudgePayload("gastown", "gastown/crew/joe", "hi")},
+		{Timestamp: ts(6), Type: events.TypeNudge, Actor: "mayor", Payload: events.NudgePayload("gastown", "gastown/witness", "hi")},
+	}
+
+	got := nudgeActivityByPolecat(evts)
+	at := func(min int) time.Time { return time.Date(2026, 1, 1, 12, min, 0, 0, time.UTC) }
+	want := map[string]nudgeActivity{
+		"gastown/nux":  {nudged: at(1), active: at(0)},
+		"gastown/slit": {nudged: at(2), active: at(3)},
+		"gastown/ace":  {nudged: at(4)},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got activity for %d polecats, want %d: %+v", len(got), len(want), got)
+	}
+	for key, w := range want {
+		if g := got[key]; !g.nudged.Equal(w.nudged) || !g.active.Equal(w.active) {
+			t.Errorf("%s: got %+v, want %+v", key, g, w)
+		}
+	}
+}
+
+func TestNextEscalation(t *testing.T) {
+	nudged := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	renudges := 1
+	policy := &config.NudgeEscalationConfig{Enabled: true, Wait: "10m", Backoff: 2, Renudges: &renudges, Final: config.NudgeActionRespawn}
+	silent := nudgeActivity{nudged: nudged, active: nudged.Add(-time.Hour)}
+
+	// Steps come due after 10m, 20m and 40m of further silence
+	var esc *NudgeEscalation
+	var taken []string
+	now := nudged
+	for range 90 {
+		now = now.Add(time.Minute)
+		var action string
+		esc, action = nextEscalation(policy, esc, silent, now)
+		if esc == nil {
+			t.Fatalf("escalation ended at %v while the polecat was silent", now)
+		}
+		if action != "" {
+			taken = append(taken, now.Sub(nudged).String()+" "+action)
+		}
+	}
+	want := []string{"10m0s renudge", "30m0s notify", "1h10m0s respawn"}
+	if len(taken) != len(want) {
+		t.Fatalf("steps taken = %v, want %v", taken, want)
+	}
+	for i := range want {
+		if taken[i] != want[i] {
+			t.Errorf("step %d = %q, want %q", i, taken[i], want[i])
+		}
+	}
+
+	// An event from the polecat ends the escalation
+	answered := nudgeActivity{nudged: nudged, active: nudged.Add(15 * time.Minute)}
+	if next, action := nextEscalation(policy, esc, answered, now); next != nil || action != "" {
+		t.Errorf("answered nudge: got %+v, %q; want nil", next, action)
+	}
+
+	// A newer nudge starts over
+	renudged := nudgeActivity{nudged: now, active: silent.active}
+	next, action := nextEscalation(policy, esc, renudged, now.Add(10*time.Minute))
+	if next == nil || next.Step != 1 || action != config.NudgeActionRenudge {
+		t.Errorf("new nudge: got %+v, %q; want step 1 renudge", next, action)
+	}
+}
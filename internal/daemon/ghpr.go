@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/ghpr"
+)
+
+// reconcileGitHubPRs closes MR beads whose GitHub pull requests have
+// merged or closed, for rigs with merge_queue.github_prs set. Their
+// Refinery skips such MRs, so this is what finishes them.
+func (d *Daemon) reconcileGitHubPRs() {
+	for _, rigName := range d.getKnownRigs() {
+		rigPath := filepath.Join(d.config.TownRoot, rigName)
+		if !ghpr.Enabled(rigPath) {
+			continue
+		}
+		bd := beads.New(filepath.Join(rigPath, "mayor", "rig"))
+		mrs, err := bd.ListOpenMRs("")
+		if err != nil {
+			d.logger.Printf("GitHub PRs for %s: listing MRs failed: %v", rigName, err)
+			continue
+		}
+		for _, o := range ghpr.Reconcile(ghpr.GHClient{}, bd, mrs) {
+			if o.Error != nil {
+				d.logger.Printf("GitHub PRs for %s: %s (%s): %v", rigName, o.MR, o.PRURL, o.Error)
+				continue
+			}
+			d.logger.Printf("GitHub PRs for %s: %s %s, closed MR %s", rigName, o.PRURL, o.State, o.MR)
+			if o.State == ghpr.StateMerged {
+				_ = events.Log(events.TypeMerged, "daemon", events.MergePayload(o.MR, o.Worker, o.Branch, ""), events.VisibilityDefault)
+			} else {
+				_ = events.Log(events.TypeMergeSkipped, "daemon", events.MergePayload(o.MR, o.Worker, o.Branch, "pull request closed unmerged"), events.VisibilityDefault)
+			}
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// ParkedPolecat records a polecat whose session was stopped for idleness.
+type ParkedPolecat struct {
+	Rig      string    `json:"rig"`
+	Name     string    `json:"name"`
+	ParkedAt time.Time `json:"parked_at"`
+	IdleFor  string    `json:"idle_for"`
+}
+
+// ParkedState is the set of parked polecats, keyed by "<rig>/<name>".
+type ParkedState struct {
+	Parked map[string]ParkedPolecat `json:"parked"`
+}
+
+// ParkedStateFile returns the path of the parked polecat registry.
+func ParkedStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "parked.json")
+}
+
+// LoadParkedState loads the parked polecat registry (empty if missing).
+func LoadParkedState(townRoot string) (*ParkedState, error) {
+	state := &ParkedState{Parked: make(map[string]ParkedPolecat)}
+	data, err := os.ReadFile(ParkedStateFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Parked == nil {
+		state.Parked = make(map[string]ParkedPolecat)
+	}
+	return state, nil
+}
+
+// SaveParkedState writes the parked polecat registry.
+func SaveParkedState(townRoot string, state *ParkedState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ParkedStateFile(townRoot), data, 0644) //nolint:gosec // G306: state file is non-sensitive
+}
+
+// idleCandidates returns polecats to park: live session, no claimed work,
+// and no activity within threshold. Polecats with unknown activity are
+// left alone. Pure so the policy can be tested without tmux or bd.
+func idleCandidates(snap rigSnapshot, lastActive map[string]time.Time, now time.Time, threshold time.Duration) []string {
+	var out []string
+	for name, alive := range snap.sessions {
+		if !alive || len(snap.claims[name]) > 0 {
+			continue
+		}
+		last, ok := lastActive[name]
+		if !ok || now.Sub(last) < threshold {
+			continue
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// wakeCandidates picks which parked polecats to restart for readyCount
+// pieces of unassigned work, longest-parked first.
+func wakeCandidates(parked []ParkedPolecat, readyCount int) []ParkedPolecat {
+	if readyCount <= 0 || len(parked) == 0 {
+		return nil
+	}
+	sorted := append([]ParkedPolecat(nil), parked...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ParkedAt.Before(sorted[j].ParkedAt) })
+	if readyCount < len(sorted) {
+		sorted = sorted[:readyCount]
+	}
+	return sorted
+}
+
+// manageIdlePolecats parks idle polecats and wakes parked ones when their
+// rig has ready, unassigned work. Disabled unless idle_park.enabled is set
+// in mayor/daemon.json.
+func (d *Daemon) manageIdlePolecats() {
+	cfg, err := config.LoadDaemonPatrolConfig(config.DaemonPatrolConfigPath(d.config.TownRoot))
+	if err != nil || cfg.IdlePark == nil || !cfg.IdlePark.Enabled {
+		return
+	}
+	threshold := cfg.IdlePark.Threshold()
+
+	state, err := LoadParkedState(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to load parked state: %v", err)
+		return
+	}
+
+	now := time.Now()
+	lastEvent := d.lastEventByActor(now.Add(-eventWindow(cfg)))
+	changed := false
+
+	for _, rigName := range d.getKnownRigs() {
+		snap, err := d.snapshotRig(rigName)
+		if err != nil {
+			d.logger.Printf("Idle check for %s failed: %v", rigName, err)
+			continue
+		}
+
+		// Wake first so a rig with fresh work doesn't park and wake in one pass
+		var parked []ParkedPolecat
+		for _, p := range state.Parked {
+			if p.Rig == rigName {
+				parked = append(parked, p)
+			}
+		}
+		if len(parked) > 0 {
+			ready := d.readyWorkCount(rigName)
+			for _, p := range wakeCandidates(parked, ready) {
+				sessionName := fmt.Sprintf("gt-%s-%s", p.Rig, p.Name)
+				if err := d.restartPolecatSession(p.Rig, p.Name, sessionName); err != nil {
+					d.logger.Printf("Error waking parked polecat %s/%s: %v", p.Rig, p.Name, err)
+					continue
+				}
+				delete(state.Parked, p.Rig+"/"+p.Name)
+				changed = true
+				d.logger.Printf("Woke parked polecat %s/%s (ready work available)", p.Rig, p.Name)
+				_, _ = events.LogDecision(events.TypePolecatWoken, "daemon", map[string]interface{}{
+					"rig": p.Rig, "polecat": p.Name,
+				}, events.VisibilityDefault, events.Rationale{
+					Decision: "woke " + p.Rig + "/" + p.Name,
+					Reasons:  []string{fmt.Sprintf("%d unassigned ready item(s) in %s", ready, p.Rig)},
+					Inputs:   map[string]interface{}{"parked_at": p.ParkedAt.Format(time.RFC3339), "ready": ready},
+					Rule:     "longest-parked polecats wake first, one per ready item",
+				})
+			}
+		}
+
+		lastActive := make(map[string]time.Time)
+		for name := range snap.sessions {
+			if t, ok := d.sessionActivity(fmt.Sprintf("gt-%s-%s", rigName, name)); ok {
+				lastActive[name] = t
+			}
+			for _, actor := range []string{rigName + "/polecats/" + name, rigName + "/" + name} {
+				if t, ok := lastEvent[actor]; ok && t.After(lastActive[name]) {
+					lastActive[name] = t
+				}
+			}
+		}
+
+		for _, name := range idleCandidates(snap, lastActive, now, threshold) {
+			sessionName := fmt.Sprintf("gt-%s-%s", rigName, name)
+			if err := d.tmux.KillSession(sessionName); err != nil {
+				d.logger.Printf("Error parking %s: %v", sessionName, err)
+				continue
+			}
+			idleFor := now.Sub(lastActive[name]).Round(time.Minute).String()
+			state.Parked[rigName+"/"+name] = ParkedPolecat{Rig: rigName, Name: name, ParkedAt: now, IdleFor: idleFor}
+			changed = true
+			d.logger.Printf("Parked idle polecat %s/%s (idle %s)", rigName, name, idleFor)
+			_, _ = events.LogDecision(events.TypePolecatParked, "daemon", map[string]interface{}{
+				"rig": rigName, "polecat": name, "idle_for": idleFor,
+			}, events.VisibilityDefault, events.Rationale{
+				Decision: "parked " + rigName + "/" + name,
+				Reasons:  []string{"no activity for " + idleFor, "no claimed work"},
+				Inputs:   map[string]interface{}{"last_active": lastActive[name].Format(time.RFC3339)},
+				Rule:     "idle_park.after=" + threshold.String(),
+			})
+		}
+	}
+
+	if changed {
+		if err := SaveParkedState(d.config.TownRoot, state); err != nil {
+			d.logger.Printf("Warning: failed to save parked state: %v", err)
+		}
+	}
+}
+
+// sessionActivity returns the tmux session's last activity time.
+func (d *Daemon) sessionActivity(sessionName string) (time.Time, bool) {
+	info, err := d.tmux.GetSessionInfo(sessionName)
+	if err != nil || info.Activity == "" {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(info.Activity), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
+
+// eventWindowSlack pads eventWindow for escalation steps taken a few
+// heartbeats late, or retried after a failure.
+const eventWindowSlack = time.Hour
+
+// eventWindow is how far back the idle and nudge escalation patrols read
+// the town's events: past the idle threshold and a nudge's whole
+// escalation, so neither rereads the full history every heartbeat.
+func eventWindow(cfg *config.DaemonPatrolConfig) time.Duration {
+	return max(cfg.IdlePark.Threshold(), cfg.NudgeEscalation.Horizon()+eventWindowSlack)
+}
+
+// lastEventByActor returns the most recent event time per actor, from
+// events since the given time.
+func (d *Daemon) lastEventByActor(since time.Time) map[string]time.Time {
+	last := make(map[string]time.Time)
+	evts, err := events.ReadTownSince(d.config.TownRoot, since, events.Filter{})
+	if err != nil {
+		return last
+	}
+	for _, e := range evts {
+		t, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		if t.After(last[e.Actor]) {
+			last[e.Actor] = t
+		}
+	}
+	return last
+}
+
+// readyWorkCount counts unassigned, unblocked work items in a rig.
+func (d *Daemon) readyWorkCount(rigName string) int {
+	bd := beads.New(filepath.Join(d.config.TownRoot, rigName, "mayor", "rig"))
+	ready, err := bd.Ready()
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, issue := range ready {
+		if dispatchable(issue) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestIdleCandidates(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	snap := rigSnapshot{
+		rig:      "gastown",
+		claims:   map[string][]string{"Busy": {"gt-1"}},
+		sessions: map[string]bool{"Busy": true, "Idle": true, "Recent": true, "Unknown": true, "Dead": false},
+	}
+	lastActive := map[string]time.Time{
+		"Busy":   now.Add(-2 * time.Hour),
+		"Idle":   now.Add(-45 * time.Minute),
+		"Recent": now.Add(-5 * time.Minute),
+		"Dead":   now.Add(-2 * time.Hour),
+	}
+
+	got := idleCandidates(snap, lastActive, now, 30*time.Minute)
+	if len(got) != 1 || got[0] != "Idle" {
+		t.Errorf("idleCandidates = %v, want [Idle]", got)
+	}
+}
+
+func TestWakeCandidates(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	parked := []ParkedPolecat{
+		{Rig: "gastown", Name: "B", ParkedAt: base.Add(time.Hour)},
+		{Rig: "gastown", Name: "A", ParkedAt: base},
+		{Rig: "gastown", Name: "C", ParkedAt: base.Add(2 * time.Hour)},
+	}
+
+	got := wakeCandidates(parked, 2)
+	if len(got) != 2 || got[0].Name != "A" || got[1].Name != "B" {
+		t.Errorf("wakeCandidates = %+v", got)
+	}
+	if got := wakeCandidates(parked, 0); got != nil {
+		t.Errorf("no ready work should wake nobody, got %+v", got)
+	}
+}
+
+func TestParkedStateRoundTrip(t *testing.T) {
+	town := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(town, "daemon"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := LoadParkedState(town)
+	if err != nil || len(state.Parked) != 0 {
+		t.Fatalf("LoadParkedState on empty town = %+v, %v", state, err)
+	}
+	state.Parked["gastown/Toast"] = ParkedPolecat{Rig: "gastown", Name: "Toast"}
+	if err := SaveParkedState(town, state); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadParkedState(town)
+	if err != nil || loaded.Parked["gastown/Toast"].Name != "Toast" {
+		t.Errorf("round trip = %+v, %v", loaded, err)
+	}
+}
+
+func TestEventWindow(t *testing.T) {
+	if got := eventWindow(&config.DaemonPatrolConfig{}); got != 70*time.Minute+eventWindowSlack {
+		t.Errorf("default window = %v, want the escalation horizon plus slack", got)
+	}
+	cfg := &config.DaemonPatrolConfig{IdlePark: &config.IdleParkConfig{After: "6h"}}
+	if got := eventWindow(cfg); got != 6*time.Hour {
+		t.Errorf("window = %v, want the 6h idle threshold", got)
+	}
+}
+
+func TestLastEventByActorWindow(t *testing.T) {
+	town := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Second)
+	line := func(age time.Duration, actor string) string {
+		return fmt.Sprintf(`{"ts":%q,"type":"hook","actor":%q}`+"\n", now.Add(-age).Format(time.RFC3339), actor)
+	}
+	data := line(3*time.Hour, "gastown/polecats/Toast") + line(3*time.Hour, "gastown/polecats/Nux") +
+		line(10*time.Minute, "gastown/polecats/Nux")
+	if err := os.WriteFile(events.Path(town), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Daemon{config: &Config{TownRoot: town}, logger: log.New(io.Discard, "", 0)}
+	last := d.lastEventByActor(now.Add(-time.Hour))
+	if _, ok := last["gastown/polecats/Toast"]; ok {
+		t.Errorf("event outside the window was read: %v", last)
+	}
+	if got := last["gastown/polecats/Nux"]; !got.Equal(now.Add(-10 * time.Minute)) {
+		t.Errorf("Nux last event = %v, want %v", got, now.Add(-10*time.Minute))
+	}
+}
@@ -275,7 +275,7 @@ func (d *Daemon) getRoleConfigForIdentity(identity string) (*beads.RoleConfig, *
 	}
 
 	// Look up role bead
-	b := beads.New(d.config.TownRoot)
+	b := d.townBeads()
 
 	roleBeadID := beads.RoleBeadIDTown(parsed.RoleType)
 	roleConfig, err := b.GetRoleConfig(roleBeadID)
@@ -0,0 +1,230 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// Reconciliation finding kinds.
+const (
+	// FindingReleased: a claim whose agent has neither a session nor a
+	// worktree. The bead is released back to the pool.
+	FindingReleased = "released"
+
+	// FindingDeadSession: a claim whose agent has a worktree but no session.
+	// Left for crash recovery (checkPolecatHealth) to restart.
+	FindingDeadSession = "dead_session"
+
+	// FindingIdle: a live agent session with no claimed work.
+	FindingIdle = "idle"
+
+	// FindingOrphanWorktree: a worktree with no session and no claimed work.
+	FindingOrphanWorktree = "orphan_worktree"
+)
+
+// ReconcileFinding is one discrepancy between beads state and reality.
+type ReconcileFinding struct {
+	Kind   string `json:"kind"`
+	Rig    string `json:"rig"`
+	Agent  string `json:"agent"`
+	Bead   string `json:"bead,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ReconcileReport is the result of a startup reconciliation pass.
+type ReconcileReport struct {
+	StartedAt time.Time          `json:"started_at"`
+	Duration  string             `json:"duration"`
+	Findings  []ReconcileFinding `json:"findings"`
+	Errors    []string           `json:"errors,omitempty"`
+}
+
+// ReconcileReportFile returns the path where the last reconciliation report is saved.
+func ReconcileReportFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "reconcile.json")
+}
+
+// rigSnapshot is the observed state of one rig, gathered before reconciling.
+type rigSnapshot struct {
+	rig       string
+	claims    map[string][]string // polecat name → claimed (in_progress/hooked) bead IDs
+	sessions  map[string]bool     // polecat name → tmux session alive
+	worktrees map[string]bool     // polecat name → worktree directory exists
+}
+
+// reconcileSnapshot compares claims against live sessions and worktrees.
+// It is pure so the policy can be tested without bd or tmux.
+func reconcileSnapshot(s rigSnapshot) []ReconcileFinding {
+	names := make(map[string]bool)
+	for n := range s.claims {
+		names[n] = true
+	}
+	for n := range s.sessions {
+		names[n] = true
+	}
+	for n := range s.worktrees {
+		names[n] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for n := range names {
+		sorted = append(sorted, n)
+	}
+	sort.Strings(sorted)
+
+	var findings []ReconcileFinding
+	for _, name := range sorted {
+		agent := fmt.Sprintf("%s/polecats/%s", s.rig, name)
+		claims := s.claims[name]
+		alive := s.sessions[name]
+		hasWorktree := s.worktrees[name]
+
+		switch {
+		case len(claims) > 0 && !alive && !hasWorktree:
+			for _, id := range claims {
+				findings = append(findings, ReconcileFinding{
+					Kind: FindingReleased, Rig: s.rig, Agent: agent, Bead: id,
+					Detail: "claimed by agent with no session or worktree",
+				})
+			}
+		case len(claims) > 0 && !alive:
+			for _, id := range claims {
+				findings = append(findings, ReconcileFinding{
+					Kind: FindingDeadSession, Rig: s.rig, Agent: agent, Bead: id,
+					Detail: "worktree present but session dead",
+				})
+			}
+		case len(claims) == 0 && alive:
+			findings = append(findings, ReconcileFinding{
+				Kind: FindingIdle, Rig: s.rig, Agent: agent,
+				Detail: "session alive with no claimed work",
+			})
+		case len(claims) == 0 && hasWorktree:
+			findings = append(findings, ReconcileFinding{
+				Kind: FindingOrphanWorktree, Rig: s.rig, Agent: agent,
+				Detail: "worktree with no session and no claimed work",
+			})
+		}
+	}
+	return findings
+}
+
+// reconcile cross-checks beads state against sessions and worktrees across
+// all rigs, releases claims held by agents that no longer exist, and saves
+// a report. Run once at daemon startup: state drifts across restarts.
+func (d *Daemon) reconcile() *ReconcileReport {
+	report := &ReconcileReport{StartedAt: time.Now()}
+
+	for _, rigName := range d.getKnownRigs() {
+		snap, err := d.snapshotRig(rigName)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", rigName, err))
+			continue
+		}
+
+		bd := beads.New(filepath.Join(d.config.TownRoot, rigName, "mayor", "rig"))
+		for _, f := range reconcileSnapshot(snap) {
+			if f.Kind == FindingReleased {
+				if err := bd.ReleaseWithReason(f.Bead, "reconcile: agent "+f.Agent+" no longer exists"); err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("releasing %s: %v", f.Bead, err))
+					continue
+				}
+				_, _ = events.LogDecision(events.TypeAutoReleased, "daemon", map[string]interface{}{
+					"rig": rigName, "bead": f.Bead, "agent": f.Agent,
+				}, events.VisibilityDefault, events.Rationale{
+					Decision: "released " + f.Bead + " back to the ready pool",
+					Reasons:  []string{"assignee " + f.Agent + " has no session and no worktree"},
+					Inputs:   map[string]interface{}{"detail": f.Detail},
+					Rule:     "daemon startup reconciliation",
+				})
+			}
+			report.Findings = append(report.Findings, f)
+		}
+	}
+
+	report.Duration = time.Since(report.StartedAt).Round(time.Millisecond).String()
+
+	counts := make(map[string]int)
+	for _, f := range report.Findings {
+		counts[f.Kind]++
+	}
+	d.logger.Printf("Reconciliation complete: %d released, %d dead sessions, %d idle, %d orphan worktrees, %d errors",
+		counts[FindingReleased], counts[FindingDeadSession], counts[FindingIdle], counts[FindingOrphanWorktree], len(report.Errors))
+
+	if data, err := json.MarshalIndent(report, "", "  "); err == nil {
+		if err := os.WriteFile(ReconcileReportFile(d.config.TownRoot), data, 0644); err != nil {
+			d.logger.Printf("Warning: failed to save reconcile report: %v", err)
+		}
+	}
+
+	_ = events.Log(events.TypeReconcile, "daemon", map[string]interface{}{
+		"released":         counts[FindingReleased],
+		"dead_sessions":    counts[FindingDeadSession],
+		"idle":             counts[FindingIdle],
+		"orphan_worktrees": counts[FindingOrphanWorktree],
+		"errors":           len(report.Errors),
+	}, events.VisibilityDefault)
+
+	return report
+}
+
+// snapshotRig gathers claims, sessions, and worktrees for a rig's polecats.
+// A rig without a polecats directory has no worktrees; any other failure
+// to read worktrees, sessions, or claims is returned, since a partial
+// snapshot would make live polecats look dead or orphaned.
+func (d *Daemon) snapshotRig(rigName string) (rigSnapshot, error) {
+	snap := rigSnapshot{
+		rig:       rigName,
+		claims:    make(map[string][]string),
+		sessions:  make(map[string]bool),
+		worktrees: make(map[string]bool),
+	}
+
+	entries, err := os.ReadDir(filepath.Join(d.config.TownRoot, rigName, "polecats"))
+	if err != nil && !os.IsNotExist(err) {
+		return snap, fmt.Errorf("reading polecat worktrees: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			snap.worktrees[e.Name()] = true
+		}
+	}
+
+	sessions, err := d.tmux.ListSessions()
+	if err != nil {
+		return snap, fmt.Errorf("listing sessions: %w", err)
+	}
+	for _, s := range sessions {
+		id, err := session.ParseSessionName(s)
+		if err != nil || id.Role != session.RolePolecat || id.Rig != rigName {
+			continue
+		}
+		snap.sessions[id.Name] = true
+	}
+
+	bd := beads.New(filepath.Join(d.config.TownRoot, rigName, "mayor", "rig"))
+	assigneePrefix := rigName + "/polecats/"
+	for _, status := range []string{"in_progress", beads.StatusHooked} {
+		issues, err := bd.List(beads.ListOptions{Status: status, Priority: -1, Limit: beads.NoLimit})
+		if err != nil {
+			return snap, fmt.Errorf("listing %s beads: %w", status, err)
+		}
+		for _, issue := range issues {
+			name, ok := strings.CutPrefix(issue.Assignee, assigneePrefix)
+			if !ok || name == "" {
+				continue
+			}
+			snap.claims[name] = append(snap.claims[name], issue.ID)
+		}
+	}
+
+	return snap, nil
+}
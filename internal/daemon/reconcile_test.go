@@ -0,0 +1,106 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestReconcileSnapshot(t *testing.T) {
+	snap := rigSnapshot{
+		rig: "gastown",
+		claims: map[string][]string{
+			"Gone":   {"gt-1"},
+			"Dead":   {"gt-2"},
+			"Active": {"gt-3"},
+		},
+		sessions: map[string]bool{
+			"Active": true,
+			"Idle":   true,
+		},
+		worktrees: map[string]bool{
+			"Dead":   true,
+			"Active": true,
+			"Idle":   true,
+			"Stray":  true,
+		},
+	}
+
+	got := make(map[string]ReconcileFinding)
+	for _, f := range reconcileSnapshot(snap) {
+		got[f.Agent] = f
+	}
+
+	want := map[string]string{
+		"gastown/polecats/Gone":  FindingReleased,
+		"gastown/polecats/Dead":  FindingDeadSession,
+		"gastown/polecats/Idle":  FindingIdle,
+		"gastown/polecats/Stray": FindingOrphanWorktree,
+	}
+	for agent, kind := range want {
+		f, ok := got[agent]
+		if !ok {
+			t.Errorf("missing finding for %s", agent)
+			continue
+		}
+		if f.Kind != kind {
+			t.Errorf("%s: kind = %q, want %q", agent, f.Kind, kind)
+		}
+	}
+	if _, ok := got["gastown/polecats/Active"]; ok {
+		t.Error("healthy agent should produce no finding")
+	}
+	if got["gastown/polecats/Gone"].Bead != "gt-1" {
+		t.Errorf("released bead = %q, want gt-1", got["gastown/polecats/Gone"].Bead)
+	}
+}
+
+func TestReconcileReportFile(t *testing.T) {
+	want := filepath.Join("/tmp/town", "daemon", "reconcile.json")
+	if got := ReconcileReportFile("/tmp/town"); got != want {
+		t.Errorf("ReconcileReportFile() = %q, want %q", got, want)
+	}
+}
+
+func TestSnapshotRigErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script fake tmux not supported on windows")
+	}
+	// A tmux that fails outright, not just reports no server
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "tmux"), []byte("#!/bin/sh\necho 'permission denied' >&2\nexit 1\n"), 0755); err != nil { //nolint:gosec // test script must be executable
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	townRoot := t.TempDir()
+	d := &Daemon{
+		config: &Config{TownRoot: townRoot},
+		logger: log.New(io.Discard, "", 0),
+		tmux:   tmux.NewTmux(),
+	}
+
+	if err := os.MkdirAll(filepath.Join(townRoot, "gastown", "polecats", "nux"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.snapshotRig("gastown"); err == nil || !strings.Contains(err.Error(), "listing sessions") {
+		t.Errorf("tmux failure: err = %v, want listing sessions error", err)
+	}
+
+	// polecats exists but can't be read as a directory
+	if err := os.MkdirAll(filepath.Join(townRoot, "beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "beads", "polecats"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.snapshotRig("beads"); err == nil || !strings.Contains(err.Error(), "polecat worktrees") {
+		t.Errorf("unreadable polecats: err = %v, want worktrees error", err)
+	}
+}
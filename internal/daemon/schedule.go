@@ -0,0 +1,229 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/schedule"
+)
+
+// scheduleOnTime is how late a run may be found and still count as on
+// time. Runs found later were missed while the daemon was down, and rules
+// with catch_up skip drop them.
+const scheduleOnTime = 15 * time.Minute
+
+// ScheduleState records, per schedule rule, the time up to which its due
+// runs have been handled, so a restart neither repeats nor loses runs.
+type ScheduleState struct {
+	Checked map[string]time.Time `json:"checked"`
+}
+
+// ScheduleStateFile returns the path of the scheduler's state.
+func ScheduleStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "schedules.json")
+}
+
+// LoadScheduleState loads the scheduler state (empty if missing).
+func LoadScheduleState(townRoot string) (*ScheduleState, error) {
+	state := &ScheduleState{Checked: make(map[string]time.Time)}
+	data, err := os.ReadFile(ScheduleStateFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Checked == nil {
+		state.Checked = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// SaveScheduleState writes the scheduler state.
+func SaveScheduleState(townRoot string, state *ScheduleState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ScheduleStateFile(townRoot), data, 0644) //nolint:gosec // G306: state file is non-sensitive
+}
+
+// scheduleDecision is what a rule calls for at one heartbeat.
+type scheduleDecision struct {
+	due     time.Time // Run to create; zero if none is due
+	missed  int       // Earlier due runs folded into this one
+	skipped bool      // The run is late and the rule skips late runs
+}
+
+// decideSchedule works out which run of a rule, if any, is due between
+// checked and now. Runs missed while the daemon was down collapse into
+// the latest one, so an outage creates at most one bead per rule; with
+// catch_up skip a late run creates none. Pure so the policy can be
+// tested without bd.
+func decideSchedule(rule *config.ScheduleRule, c *schedule.Cron, checked, now time.Time) scheduleDecision {
+	due, earlier, ok := c.Missed(checked, now)
+	if !ok {
+		return scheduleDecision{}
+	}
+	dec := scheduleDecision{due: due, missed: earlier}
+	if rule.CatchUp == config.CatchUpSkip && now.Sub(due) > scheduleOnTime {
+		dec.skipped = true
+	}
+	return dec
+}
+
+// scheduleRunLabel identifies the bead created for one run of a rule, so
+// a run is never created twice even if the state file is lost.
+func scheduleRunLabel(name string, due time.Time) string {
+	return "schedule:" + name + "@" + due.UTC().Format("200601021504")
+}
+
+// scheduleTitle expands "{date}" in the rule's title to the run's date.
+func scheduleTitle(rule *config.ScheduleRule, due time.Time) string {
+	return strings.ReplaceAll(rule.Title, "{date}", due.Format("2006-01-02"))
+}
+
+// runSchedules creates the beads of schedule rules that have come due
+// (town settings "schedules"). A new rule starts counting from now, and a
+// disabled one is kept current, so neither backfills old runs. A failed
+// creation is retried next heartbeat.
+func (d *Daemon) runSchedules() {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(d.config.TownRoot))
+	if err != nil || len(settings.Schedules) == 0 {
+		return
+	}
+	state, err := LoadScheduleState(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to load schedule state: %v", err)
+		return
+	}
+
+	names := make([]string, 0, len(settings.Schedules))
+	for name := range settings.Schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	checked := make(map[string]time.Time, len(names))
+	for _, name := range names {
+		rule := settings.Schedules[name]
+		if err := config.ValidateScheduleRule(name, rule); err != nil {
+			d.logger.Printf("Skipping schedule: %v", err)
+			continue
+		}
+		last, seen := state.Checked[name]
+		checked[name] = now
+		if !seen || rule.Disabled {
+			continue
+		}
+		c, _ := schedule.Parse(rule.Cron)
+		loc, _ := rule.Location()
+
+		dec := decideSchedule(rule, c, last.In(loc), now.In(loc))
+		switch {
+		case dec.due.IsZero():
+		case dec.skipped:
+			d.logScheduleSkipped(name, rule, dec)
+		default:
+			if err := d.createScheduledBead(name, rule, dec); err != nil {
+				d.logger.Printf("Schedule %s: creating run due %s: %v", name, dec.due.Format(time.RFC3339), err)
+				checked[name] = last
+			}
+		}
+	}
+
+	state.Checked = checked
+	if err := SaveScheduleState(d.config.TownRoot, state); err != nil {
+		d.logger.Printf("Warning: failed to save schedule state: %v", err)
+	}
+}
+
+// createScheduledBead creates the bead for a rule's due run, unless a
+// bead for that run already exists.
+func (d *Daemon) createScheduledBead(name string, rule *config.ScheduleRule, dec scheduleDecision) error {
+	dir := d.config.TownRoot
+	if rule.Rig != "" {
+		dir = filepath.Join(d.config.TownRoot, rule.Rig, "mayor", "rig")
+	}
+	bd := beads.New(dir)
+
+	runLabel := scheduleRunLabel(name, dec.due)
+	existing, err := bd.List(beads.ListOptions{Label: runLabel, Status: "all", Priority: -1})
+	if err != nil {
+		return fmt.Errorf("checking for existing run: %w", err)
+	}
+	if len(existing) > 0 {
+		d.logger.Printf("Schedule %s: run due %s already created as %s", name, dec.due.Format(time.RFC3339), existing[0].ID)
+		return nil
+	}
+
+	issueType := rule.Type
+	if issueType == "" {
+		issueType = "task"
+	}
+	desc := rule.Description
+	if desc != "" {
+		desc += "\n\n"
+	}
+	desc += fmt.Sprintf("Created by schedule %q (%s) for the run due %s.", name, rule.Cron, dec.due.Format(time.RFC3339))
+	if dec.missed > 0 {
+		desc += fmt.Sprintf(" Also covers %d earlier run(s) missed while the daemon was down.", dec.missed)
+	}
+	labels := append(append([]string(nil), rule.Labels...), "schedule:"+name, runLabel)
+
+	title := scheduleTitle(rule, dec.due)
+	issue, err := bd.Create(beads.CreateOptions{
+		Title:       title,
+		Type:        issueType,
+		Priority:    rule.PriorityOrDefault(),
+		Description: desc,
+		Assignee:    rule.Assignee,
+		Labels:      labels,
+		Actor:       "daemon",
+	})
+	if err != nil {
+		return err
+	}
+
+	d.logger.Printf("Schedule %s: created %s (%s)", name, issue.ID, title)
+	reasons := []string{fmt.Sprintf("%s is due at %s", rule.Cron, dec.due.Format(time.RFC3339))}
+	if dec.missed > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d earlier run(s) missed while the daemon was down; caught up with one bead", dec.missed))
+	}
+	payload := map[string]interface{}{"schedule": name, "bead": issue.ID, "title": title, "due": dec.due.Format(time.RFC3339)}
+	if rule.Assignee != "" {
+		payload["assignee"] = rule.Assignee
+	}
+	_, _ = events.LogDecision(events.TypeScheduled, "daemon", payload, events.VisibilityDefault, events.Rationale{
+		Decision: "created " + issue.ID,
+		Reasons:  reasons,
+		Inputs:   map[string]interface{}{"cron": rule.Cron, "missed": dec.missed, "catch_up": rule.CatchUp},
+		Rule:     "schedules." + name,
+	})
+	return nil
+}
+
+// logScheduleSkipped records a late run a catch_up skip rule dropped.
+func (d *Daemon) logScheduleSkipped(name string, rule *config.ScheduleRule, dec scheduleDecision) {
+	d.logger.Printf("Schedule %s: skipping late run due %s (catch_up skip)", name, dec.due.Format(time.RFC3339))
+	_, _ = events.LogDecision(events.TypeScheduleSkipped, "daemon", map[string]interface{}{
+		"schedule": name, "due": dec.due.Format(time.RFC3339),
+	}, events.VisibilityDefault, events.Rationale{
+		Decision: "skipped run of " + name,
+		Reasons:  []string{fmt.Sprintf("run due %s was missed while the daemon was down", dec.due.Format(time.RFC3339))},
+		Inputs:   map[string]interface{}{"cron": rule.Cron, "missed": dec.missed + 1},
+		Rule:     "schedules." + name + " catch_up skip",
+	})
+}
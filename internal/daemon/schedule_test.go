@@ -0,0 +1,101 @@
+package daemon
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/schedule"
+)
+
+func TestDecideSchedule(t *testing.T) {
+	c, err := schedule.Parse("0 9 * * mon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	latest := &config.ScheduleRule{Cron: "0 9 * * mon", Title: "Weekly deps audit"}
+	skip := &config.ScheduleRule{Cron: "0 9 * * mon", Title: "Weekly deps audit", CatchUp: config.CatchUpSkip}
+
+	// Not yet due
+	if dec := decideSchedule(latest, c, monday.Add(-time.Hour), monday.Add(-time.Minute)); !dec.due.IsZero() {
+		t.Errorf("due early: %+v", dec)
+	}
+
+	// Found on the next heartbeat
+	dec := decideSchedule(latest, c, monday.Add(-3*time.Minute), monday.Add(2*time.Minute))
+	if !dec.due.Equal(monday) || dec.missed != 0 || dec.skipped {
+		t.Errorf("on time = %+v", dec)
+	}
+	if dec := decideSchedule(skip, c, monday.Add(-3*time.Minute), monday.Add(2*time.Minute)); dec.skipped {
+		t.Errorf("on-time run skipped: %+v", dec)
+	}
+
+	// Down for three weeks: one bead for the latest run, or none with skip
+	down := monday.Add(-14 * 24 * time.Hour).Add(-time.Hour)
+	dec = decideSchedule(latest, c, down, monday.Add(5*time.Hour))
+	if !dec.due.Equal(monday) || dec.missed != 2 || dec.skipped {
+		t.Errorf("catch-up = %+v", dec)
+	}
+	if dec := decideSchedule(skip, c, down, monday.Add(5*time.Hour)); !dec.skipped {
+		t.Errorf("late run not skipped: %+v", dec)
+	}
+
+	// Already handled: nothing due again
+	if dec := decideSchedule(latest, c, monday.Add(2*time.Minute), monday.Add(5*time.Hour)); !dec.due.IsZero() {
+		t.Errorf("run repeated: %+v", dec)
+	}
+}
+
+func TestScheduleRunLabel(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	due := time.Date(2026, 10, 19, 9, 0, 0, 0, ny)
+	if got := scheduleRunLabel("weekly-deps", due); got != "schedule:weekly-deps@202610191300" {
+		t.Errorf("label = %q", got)
+	}
+	rule := &config.ScheduleRule{Title: "Deps audit {date}"}
+	if got := scheduleTitle(rule, due); got != "Deps audit 2026-10-19" {
+		t.Errorf("title = %q", got)
+	}
+}
+
+func TestRunSchedulesSkipsInvalidRule(t *testing.T) {
+	townRoot := t.TempDir()
+	settings := `{"schedules": {
+		"standup": {"cron": "0 9 * * 1-5", "title": "Standup"},
+		"broken": {"cron": "every morning", "title": "Broken"}}}`
+	for _, dir := range []string{"settings", "daemon"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(config.TownSettingsPath(townRoot), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	d := &Daemon{config: &Config{TownRoot: townRoot}, logger: log.New(&logs, "", 0)}
+
+	d.runSchedules()
+
+	state, err := LoadScheduleState(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := state.Checked["standup"]; !ok {
+		t.Error("valid rule not checked alongside an invalid one")
+	}
+	if _, ok := state.Checked["broken"]; ok {
+		t.Error("invalid rule checked")
+	}
+	if !strings.Contains(logs.String(), "schedules.broken") {
+		t.Errorf("log = %q, want the invalid rule reported", logs.String())
+	}
+}
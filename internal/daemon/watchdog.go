@@ -0,0 +1,203 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// WatchdogState records when each dead worker was first seen, keyed by
+// "<rig>/<name>", so the grace period survives daemon restarts.
+type WatchdogState struct {
+	Dead map[string]time.Time `json:"dead"`
+}
+
+// WatchdogStateFile returns the path of the watchdog's state.
+func WatchdogStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "watchdog.json")
+}
+
+// LoadWatchdogState loads the watchdog state (empty if missing).
+func LoadWatchdogState(townRoot string) (*WatchdogState, error) {
+	state := &WatchdogState{Dead: make(map[string]time.Time)}
+	data, err := os.ReadFile(WatchdogStateFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Dead == nil {
+		state.Dead = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// SaveWatchdogState writes the watchdog state.
+func SaveWatchdogState(townRoot string, state *WatchdogState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(WatchdogStateFile(townRoot), data, 0644) //nolint:gosec // G306: state file is non-sensitive
+}
+
+// deadWorker is a polecat holding claims with no live agent behind them.
+type deadWorker struct {
+	name   string
+	claims []string
+	gone   bool   // No session and no worktree; else the agent process exited
+	reason string // Why the worker counts as dead, for notes and events
+}
+
+// deadWorkers returns the polecats in s whose claims no agent is working:
+// gone entirely, or with a session whose agent process has exited
+// (running reports which sessions still run one). A worktree without a
+// session is left to checkPolecatHealth, which restarts it. Pure so the
+// policy can be tested without tmux or bd.
+func deadWorkers(s rigSnapshot, running map[string]bool) []deadWorker {
+	var out []deadWorker
+	for name, claims := range s.claims {
+		if len(claims) == 0 {
+			continue
+		}
+		switch {
+		case !s.sessions[name] && !s.worktrees[name]:
+			out = append(out, deadWorker{name: name, claims: claims, gone: true,
+				reason: "polecat has no session and no worktree"})
+		case s.sessions[name] && !running[name]:
+			out = append(out, deadWorker{name: name, claims: claims,
+				reason: "agent process exited in a live session"})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+// runWatchdog reaps dead polecats: claims whose worker has stayed dead for
+// the grace period are released to the ready pool (or, with respawn, the
+// worker is replaced). On unless watchdog.disabled is set in
+// mayor/daemon.json.
+func (d *Daemon) runWatchdog() {
+	var wd *config.WatchdogConfig
+	if cfg, err := config.LoadDaemonPatrolConfig(config.DaemonPatrolConfigPath(d.config.TownRoot)); err == nil {
+		wd = cfg.Watchdog
+	}
+	if wd != nil && wd.Disabled {
+		return
+	}
+	grace := wd.GracePeriod()
+	respawn := wd != nil && wd.Respawn
+
+	state, err := LoadWatchdogState(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to load watchdog state: %v", err)
+		return
+	}
+
+	now := time.Now()
+	dead := make(map[string]time.Time)
+	for _, rigName := range d.getKnownRigs() {
+		snap, err := d.snapshotRig(rigName)
+		if err != nil {
+			d.logger.Printf("Watchdog for %s failed: %v", rigName, err)
+			// Keep this rig's suspects rather than restart their grace
+			for key, first := range state.Dead {
+				if filepath.Dir(key) == rigName {
+					dead[key] = first
+				}
+			}
+			continue
+		}
+
+		running := make(map[string]bool)
+		for name, alive := range snap.sessions {
+			if alive && len(snap.claims[name]) > 0 {
+				running[name] = d.tmux.IsAgentRunning(session.PolecatSessionName(rigName, name))
+			}
+		}
+
+		for _, w := range deadWorkers(snap, running) {
+			key := rigName + "/" + w.name
+			first, seen := state.Dead[key]
+			if !seen {
+				first = now
+				d.logger.Printf("Watchdog: %s/polecats/%s looks dead (%s); reaping after %v", rigName, w.name, w.reason, grace)
+			}
+			if now.Sub(first) < grace {
+				dead[key] = first
+				continue
+			}
+			d.reapWorker(rigName, w, respawn)
+		}
+	}
+
+	state.Dead = dead
+	if err := SaveWatchdogState(d.config.TownRoot, state); err != nil {
+		d.logger.Printf("Warning: failed to save watchdog state: %v", err)
+	}
+}
+
+// reapWorker recovers a dead worker's claims. With respawn, a worker whose
+// agent exited is restarted in place and keeps its hook; otherwise, or if
+// the restart fails, its claims are released, and with respawn each
+// released bead is slung to a fresh polecat.
+func (d *Daemon) reapWorker(rigName string, w deadWorker, respawn bool) {
+	agent := fmt.Sprintf("%s/polecats/%s", rigName, w.name)
+
+	if respawn && !w.gone {
+		sessionName := session.PolecatSessionName(rigName, w.name)
+		err := d.restartPolecatSession(rigName, w.name, sessionName)
+		if err == nil {
+			d.logger.Printf("Watchdog: restarted %s (%s)", agent, w.reason)
+			_ = events.LogError(events.TypeAgentCrash, "daemon", errors.New(w.reason),
+				events.AgentCrashPayload(rigName, agent, w.claims[0]))
+			return
+		}
+		d.logger.Printf("Watchdog: restarting %s failed, releasing its work: %v", agent, err)
+	}
+
+	bd := beads.New(filepath.Join(d.config.TownRoot, rigName, "mayor", "rig"))
+	for _, id := range w.claims {
+		if err := bd.ReleaseWithReason(id, "watchdog: "+w.reason); err != nil {
+			d.logger.Printf("Watchdog: releasing %s from %s failed: %v", id, agent, err)
+			continue
+		}
+		d.logger.Printf("Watchdog: released %s from %s (%s)", id, agent, w.reason)
+
+		resling := respawn && w.gone
+		var alternatives []string
+		if !respawn {
+			alternatives = []string{"replace the worker (watchdog.respawn is off)"}
+		}
+		_, _ = events.LogDecision(events.TypeAutoReleased, "daemon", map[string]interface{}{
+			"rig": rigName, "bead": id, "agent": agent, "reason": w.reason, "respawn": resling,
+		}, events.VisibilityDefault, events.Rationale{
+			Decision:     "released " + id + " back to the ready pool",
+			Reasons:      []string{w.reason},
+			Alternatives: alternatives,
+			Rule:         "daemon watchdog (mayor/daemon.json watchdog)",
+		})
+
+		if resling {
+			cmd := exec.Command("gt", "sling", id, rigName) //nolint:gosec // G204: args are constructed internally
+			cmd.Dir = d.config.TownRoot
+			if out, err := cmd.CombinedOutput(); err != nil {
+				d.logger.Printf("Watchdog: re-slinging %s to %s failed: %v: %s", id, rigName, err, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestDeadWorkers(t *testing.T) {
+	snap := rigSnapshot{
+		rig: "gastown",
+		claims: map[string][]string{
+			"Gone":    {"gt-1", "gt-2"},
+			"Zombie":  {"gt-3"},
+			"Crashed": {"gt-4"},
+			"Working": {"gt-5"},
+		},
+		sessions:  map[string]bool{"Zombie": true, "Working": true, "Idle": true},
+		worktrees: map[string]bool{"Zombie": true, "Crashed": true, "Working": true, "Idle": true},
+	}
+	running := map[string]bool{"Working": true}
+
+	got := deadWorkers(snap, running)
+	if len(got) != 2 {
+		t.Fatalf("dead workers = %+v", got)
+	}
+	if got[0].name != "Gone" || !got[0].gone || len(got[0].claims) != 2 {
+		t.Errorf("gone worker = %+v", got[0])
+	}
+	if got[1].name != "Zombie" || got[1].gone {
+		t.Errorf("zombie worker = %+v", got[1])
+	}
+}
+
+func TestWatchdogConfig_GracePeriod(t *testing.T) {
+	var nilCfg *config.WatchdogConfig
+	if got := nilCfg.GracePeriod(); got != config.DefaultWatchdogGrace {
+		t.Errorf("nil grace = %v", got)
+	}
+	if got := (&config.WatchdogConfig{Grace: "10m"}).GracePeriod(); got != 10*time.Minute {
+		t.Errorf("10m grace = %v", got)
+	}
+	if got := (&config.WatchdogConfig{Grace: "soon"}).GracePeriod(); got != config.DefaultWatchdogGrace {
+		t.Errorf("invalid grace = %v", got)
+	}
+}
+
+func TestWatchdogState_RoundTrip(t *testing.T) {
+	town := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(town, "daemon"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	state, err := LoadWatchdogState(town)
+	if err != nil || len(state.Dead) != 0 {
+		t.Fatalf("empty state = %+v, %v", state, err)
+	}
+
+	seen := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	state.Dead["gastown/Toast"] = seen
+	if err := SaveWatchdogState(town, state); err != nil {
+		t.Fatal(err)
+	}
+	if state, err = LoadWatchdogState(town); err != nil || !state.Dead["gastown/Toast"].Equal(seen) {
+		t.Errorf("reloaded state = %+v, %v", state, err)
+	}
+}
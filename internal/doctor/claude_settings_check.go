@@ -327,6 +327,7 @@ func (c *ClaudeSettingsCheck) checkSettings(path, _ string) []string {
 	// 2. PATH export in hooks
 	// 3. Stop hook with gt costs record (for autonomous)
 	// 4. gt nudge deacon session-started in SessionStart
+	// 5. PreToolUse hook with gt sandbox check (worktree write sandbox)
 
 	// Check enabledPlugins
 	if _, ok := actual["enabledPlugins"]; !ok {
@@ -354,6 +355,11 @@ func (c *ClaudeSettingsCheck) checkSettings(path, _ string) []string {
 		missing = append(missing, "Stop hook")
 	}
 
+	// Check PreToolUse hook runs the write sandbox (for all roles)
+	if !c.hookHasPattern(hooks, "PreToolUse", "gt sandbox check") {
+		missing = append(missing, "sandbox hook")
+	}
+
 	return missing
 }
 
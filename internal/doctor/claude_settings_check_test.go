@@ -67,6 +67,17 @@ func createValidSettings(t *testing.T, path string) {
 					},
 				},
 			},
+			"PreToolUse": []any{
+				map[string]any{
+					"matcher": "Write|Edit|MultiEdit|NotebookEdit",
+					"hooks": []any{
+						map[string]any{
+							"type":    "command",
+							"command": "gt sandbox check",
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -117,6 +128,17 @@ func createStaleSettings(t *testing.T, path string, missingElements ...string) {
 					},
 				},
 			},
+			"PreToolUse": []any{
+				map[string]any{
+					"matcher": "Write|Edit|MultiEdit|NotebookEdit",
+					"hooks": []any{
+						map[string]any{
+							"type":    "command",
+							"command": "gt sandbox check",
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -159,6 +181,9 @@ func createStaleSettings(t *testing.T, path string, missingElements ...string) {
 		case "Stop":
 			hooks := settings["hooks"].(map[string]any)
 			delete(hooks, "Stop")
+		case "PreToolUse":
+			hooks := settings["hooks"].(map[string]any)
+			delete(hooks, "PreToolUse")
 		}
 	}
 
@@ -401,6 +426,33 @@ func TestClaudeSettingsCheck_MissingStopHook(t *testing.T) {
 	}
 }
 
+func TestClaudeSettingsCheck_MissingSandboxHook(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create stale settings missing the PreToolUse sandbox hook (at correct location)
+	mayorSettings := filepath.Join(tmpDir, "mayor", ".claude", "settings.json")
+	createStaleSettings(t, mayorSettings, "PreToolUse")
+
+	check := NewClaudeSettingsCheck()
+	ctx := &CheckContext{TownRoot: tmpDir}
+
+	result := check.Run(ctx)
+
+	if result.Status != StatusError {
+		t.Errorf("expected StatusError for missing sandbox hook, got %v", result.Status)
+	}
+	found := false
+	for _, d := range result.Details {
+		if strings.Contains(d, "sandbox hook") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected details to mention sandbox hook, got %v", result.Details)
+	}
+}
+
 func TestClaudeSettingsCheck_WrongLocationWitness(t *testing.T) {
 	tmpDir := t.TempDir()
 	rigName := "testrig"
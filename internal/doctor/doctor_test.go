@@ -2,6 +2,7 @@ package doctor
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 )
 
@@ -358,3 +359,31 @@ func TestFixableCheck(t *testing.T) {
 		t.Error("FixableCheck.CanFix() should return true")
 	}
 }
+
+func TestReport_JSON(t *testing.T) {
+	report := NewReport()
+	report.Add(&CheckResult{Name: "a", Status: StatusOK, Message: "fine"})
+	report.Add(&CheckResult{Name: "b", Status: StatusError, Message: "broken", FixHint: "fix it"})
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Checks []struct {
+			Name    string `json:"name"`
+			Status  string `json:"status"`
+			FixHint string `json:"fix_hint"`
+		} `json:"checks"`
+		Summary ReportSummary `json:"summary"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Checks) != 2 || got.Checks[0].Status != "ok" || got.Checks[1].Status != "error" || got.Checks[1].FixHint != "fix it" {
+		t.Errorf("checks = %+v", got.Checks)
+	}
+	if got.Summary.Errors != 1 || got.Summary.Total != 2 {
+		t.Errorf("summary = %+v", got.Summary)
+	}
+}
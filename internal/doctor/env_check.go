@@ -0,0 +1,162 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/deps"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// BdInstalledCheck verifies that bd is on PATH and new enough for Gas Town.
+type BdInstalledCheck struct {
+	BaseCheck
+}
+
+// NewBdInstalledCheck creates a new bd installation check.
+func NewBdInstalledCheck() *BdInstalledCheck {
+	return &BdInstalledCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "bd-installed",
+			CheckDescription: "Check bd (beads) is installed and compatible",
+		},
+	}
+}
+
+// Run checks for bd and its version.
+func (c *BdInstalledCheck) Run(ctx *CheckContext) *CheckResult {
+	status, version := deps.CheckBeads()
+	switch status {
+	case deps.BeadsNotFound:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "bd not found in PATH",
+			FixHint: "Install with 'go install " + deps.BeadsInstallPath + "'",
+		}
+	case deps.BeadsTooOld:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("bd %s is too old (minimum %s)", version, deps.MinBeadsVersion),
+			FixHint: "Upgrade with 'go install " + deps.BeadsInstallPath + "'",
+		}
+	case deps.BeadsUnknown:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "bd found but its version could not be determined",
+			FixHint: "Run 'bd version' to check the installation",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: "bd " + version,
+	}
+}
+
+// TmuxAvailableCheck verifies that tmux, which hosts every agent session,
+// is installed.
+type TmuxAvailableCheck struct {
+	BaseCheck
+}
+
+// NewTmuxAvailableCheck creates a new tmux availability check.
+func NewTmuxAvailableCheck() *TmuxAvailableCheck {
+	return &TmuxAvailableCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "tmux-available",
+			CheckDescription: "Check tmux is installed",
+		},
+	}
+}
+
+// Run checks for tmux on PATH.
+func (c *TmuxAvailableCheck) Run(ctx *CheckContext) *CheckResult {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "tmux not found in PATH (agents cannot run)",
+			FixHint: "Install tmux with your package manager, e.g. 'brew install tmux' or 'apt install tmux'",
+		}
+	}
+	out, err := exec.Command("tmux", "-V").Output()
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "tmux found but 'tmux -V' failed",
+			Details: []string{err.Error()},
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: strings.TrimSpace(string(out)),
+	}
+}
+
+// EventsWritableCheck verifies that the town's events logs can be appended
+// to. Event writes are best-effort, so an unwritable log silently empties
+// the feed rather than failing commands.
+type EventsWritableCheck struct {
+	BaseCheck
+}
+
+// NewEventsWritableCheck creates a new events log writability check.
+func NewEventsWritableCheck() *EventsWritableCheck {
+	return &EventsWritableCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "events-writable",
+			CheckDescription: "Check the events log is writable",
+		},
+	}
+}
+
+// Run checks each events log, or its directory if the log doesn't exist yet.
+func (c *EventsWritableCheck) Run(ctx *CheckContext) *CheckResult {
+	var details []string
+	for _, path := range events.LogPaths(ctx.TownRoot) {
+		if err := checkAppendable(path); err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", path, err))
+		}
+	}
+	if len(details) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("%d events log(s) not writable", len(details)),
+			Details: details,
+			FixHint: "Fix ownership or permissions so the user running gt can write them",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: "Events log is writable",
+	}
+}
+
+// checkAppendable reports whether path can be appended to, without
+// creating it: a missing file only needs a writable directory.
+func checkAppendable(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err == nil {
+		return f.Close()
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	probe, err := os.CreateTemp(filepath.Dir(path), ".doctor-probe-*")
+	if err != nil {
+		return err
+	}
+	_ = probe.Close()
+	return os.Remove(probe.Name())
+}
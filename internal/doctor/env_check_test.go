@@ -0,0 +1,33 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEventsWritableCheck(t *testing.T) {
+	town := t.TempDir()
+	ctx := &CheckContext{TownRoot: town}
+	check := NewEventsWritableCheck()
+
+	// No log yet: a writable town root is enough, and nothing is created
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("missing log: %+v", result)
+	}
+	entries, _ := os.ReadDir(town)
+	if len(entries) != 0 {
+		t.Errorf("check left files behind: %v", entries)
+	}
+
+	if os.Geteuid() == 0 {
+		t.Skip("root can write read-only files")
+	}
+	log := filepath.Join(town, ".events.jsonl")
+	if err := os.WriteFile(log, nil, 0444); err != nil {
+		t.Fatal(err)
+	}
+	if result := check.Run(ctx); result.Status != StatusError || len(result.Details) != 1 {
+		t.Errorf("read-only log: %+v", result)
+	}
+}
@@ -119,7 +119,7 @@ func (c *PatrolMoleculesExistCheck) Fix(ctx *CheckContext) error {
 				"--type=molecule",
 				"--title="+mol,
 				"--description="+desc,
-				"--priority=2",
+				fmt.Sprintf("--priority=%d", beads.PriorityMedium),
 			)
 			cmd.Dir = rigPath
 			if err := cmd.Run(); err != nil {
@@ -2,6 +2,7 @@
 package doctor
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -36,6 +37,12 @@ func (s CheckStatus) String() string {
 	}
 }
 
+// MarshalJSON encodes a status as "ok", "warning", or "error", for
+// gt doctor --json.
+func (s CheckStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToLower(s.String()))
+}
+
 // CheckContext provides context for running checks.
 type CheckContext struct {
 	TownRoot        string // Root directory of the Gas Town workspace
@@ -55,11 +62,11 @@ func (ctx *CheckContext) RigPath() string {
 
 // CheckResult represents the outcome of a health check.
 type CheckResult struct {
-	Name    string      // Check name
-	Status  CheckStatus // Result status
-	Message string      // Primary result message
-	Details []string    // Additional information
-	FixHint string      // Suggestion if not auto-fixable
+	Name    string      `json:"name"`               // Check name
+	Status  CheckStatus `json:"status"`             // Result status
+	Message string      `json:"message"`            // Primary result message
+	Details []string    `json:"details,omitempty"`  // Additional information
+	FixHint string      `json:"fix_hint,omitempty"` // Suggestion if not auto-fixable
 }
 
 // Check defines the interface for a health check.
@@ -83,17 +90,17 @@ type Check interface {
 
 // ReportSummary summarizes the results of all checks.
 type ReportSummary struct {
-	Total    int
-	OK       int
-	Warnings int
-	Errors   int
+	Total    int `json:"total"`
+	OK       int `json:"ok"`
+	Warnings int `json:"warnings"`
+	Errors   int `json:"errors"`
 }
 
 // Report contains all check results and a summary.
 type Report struct {
-	Timestamp time.Time
-	Checks    []*CheckResult
-	Summary   ReportSummary
+	Timestamp time.Time      `json:"timestamp"`
+	Checks    []*CheckResult `json:"checks"`
+	Summary   ReportSummary  `json:"summary"`
 }
 
 // NewReport creates an empty report with the current timestamp.
@@ -0,0 +1,121 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// OrphanedWorkCheck detects in_progress beads that nobody is working on:
+// claimed by a polecat or crew member whose directory is gone, or work
+// items left in progress with no assignee. They never reach the ready
+// queue again on their own.
+type OrphanedWorkCheck struct {
+	FixableCheck
+	orphans []orphanedClaim // Cached for Fix
+}
+
+type orphanedClaim struct {
+	beadID  string
+	title   string
+	workDir string // Where to run bd for this bead
+	reason  string
+}
+
+// unassignedWorkTypes are the bead types that need an assignee while in
+// progress. Epics, molecules, and agent beads legitimately go without.
+var unassignedWorkTypes = []string{"task", "bug", "feature", "chore"}
+
+// NewOrphanedWorkCheck creates a new orphaned in_progress beads check.
+func NewOrphanedWorkCheck() *OrphanedWorkCheck {
+	return &OrphanedWorkCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "orphaned-in-progress",
+				CheckDescription: "Detect in_progress beads with no live assignee",
+			},
+		},
+	}
+}
+
+// Run lists each rig's in_progress beads and checks their assignees.
+func (c *OrphanedWorkCheck) Run(ctx *CheckContext) *CheckResult {
+	c.orphans = nil
+
+	for _, rigPath := range findAllRigs(ctx.TownRoot) {
+		workDir := filepath.Join(rigPath, "mayor", "rig")
+		issues, err := beads.New(workDir).List(beads.ListOptions{Status: "in_progress", Priority: -1, Limit: beads.NoLimit})
+		if err != nil {
+			continue // beads-database reports unreadable stores
+		}
+		c.orphans = append(c.orphans, orphanedClaims(rigPath, workDir, issues)...)
+	}
+
+	if len(c.orphans) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No orphaned in_progress beads",
+		}
+	}
+
+	details := make([]string, 0, len(c.orphans))
+	for _, o := range c.orphans {
+		details = append(details, fmt.Sprintf("%s %q: %s", o.beadID, o.title, o.reason))
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("Found %d orphaned in_progress bead(s)", len(c.orphans)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to release them to the ready queue, or reassign with 'gt sling <id> <agent>'",
+	}
+}
+
+// Fix releases orphaned beads back to open, unassigned.
+func (c *OrphanedWorkCheck) Fix(ctx *CheckContext) error {
+	var errs []string
+	for _, o := range c.orphans {
+		if err := beads.New(o.workDir).ReleaseWithReason(o.beadID, "doctor: "+o.reason); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", o.beadID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("releasing: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// orphanedClaims picks the orphans out of a rig's in_progress beads.
+// Assignees other than the rig's polecats and crew (witness, refinery,
+// town agents) are not judged.
+func orphanedClaims(rigPath, workDir string, issues []*beads.Issue) []orphanedClaim {
+	rigName := filepath.Base(rigPath)
+	var out []orphanedClaim
+	for _, issue := range issues {
+		reason := ""
+		switch {
+		case issue.Assignee == "":
+			if slices.Contains(unassignedWorkTypes, issue.Type) {
+				reason = "in progress with no assignee"
+			}
+		case strings.HasPrefix(issue.Assignee, rigName+"/polecats/"):
+			name := strings.TrimPrefix(issue.Assignee, rigName+"/polecats/")
+			if !dirExists(filepath.Join(rigPath, "polecats", name)) {
+				reason = "assignee " + issue.Assignee + " no longer exists"
+			}
+		case strings.HasPrefix(issue.Assignee, rigName+"/crew/"):
+			name := strings.TrimPrefix(issue.Assignee, rigName+"/crew/")
+			if !dirExists(filepath.Join(rigPath, "crew", name)) {
+				reason = "assignee " + issue.Assignee + " no longer exists"
+			}
+		}
+		if reason != "" {
+			out = append(out, orphanedClaim{beadID: issue.ID, title: issue.Title, workDir: workDir, reason: reason})
+		}
+	}
+	return out
+}
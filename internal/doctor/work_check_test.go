@@ -0,0 +1,41 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestOrphanedClaims(t *testing.T) {
+	rigPath := filepath.Join(t.TempDir(), "gastown")
+	for _, dir := range []string{"polecats/nux", "crew/joe"} {
+		if err := os.MkdirAll(filepath.Join(rigPath, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	issues := []*beads.Issue{
+		{ID: "gt-1", Type: "task", Assignee: "gastown/polecats/nux"},
+		{ID: "gt-2", Type: "task", Assignee: "gastown/polecats/gone"},
+		{ID: "gt-3", Type: "bug", Assignee: "gastown/crew/joe"},
+		{ID: "gt-4", Type: "bug", Assignee: "gastown/crew/max"},
+		{ID: "gt-5", Type: "task"},
+		{ID: "gt-6", Type: "epic"},
+		{ID: "gt-7", Type: "task", Assignee: "gastown/witness"},
+	}
+	var got []string
+	for _, o := range orphanedClaims(rigPath, "/work", issues) {
+		got = append(got, o.beadID)
+	}
+	want := []string{"gt-2", "gt-4", "gt-5"}
+	if len(got) != len(want) {
+		t.Fatalf("orphans = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("orphans = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// WorktreeHealthCheck detects git worktrees that have come apart from their
+// repository: registrations whose directory was deleted (stale), and
+// polecat directories whose .git pointer leads nowhere (broken). Stale
+// registrations block re-creating a polecat on the same branch.
+type WorktreeHealthCheck struct {
+	FixableCheck
+	staleRepos []*git.Git // Repos with stale registrations, cached for Fix
+}
+
+// NewWorktreeHealthCheck creates a new worktree health check.
+func NewWorktreeHealthCheck() *WorktreeHealthCheck {
+	return &WorktreeHealthCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "git-worktrees",
+				CheckDescription: "Detect stale and broken git worktrees",
+			},
+		},
+	}
+}
+
+// Run checks each rig's repository and polecat worktrees.
+func (c *WorktreeHealthCheck) Run(ctx *CheckContext) *CheckResult {
+	c.staleRepos = nil
+
+	var stale, broken []string
+	for _, rigPath := range findAllRigs(ctx.TownRoot) {
+		rigName := filepath.Base(rigPath)
+
+		if repo := rigRepo(rigPath); repo != nil {
+			worktrees, err := repo.WorktreeList()
+			if err == nil {
+				found := false
+				for _, wt := range worktrees {
+					if _, err := os.Stat(wt.Path); os.IsNotExist(err) {
+						stale = append(stale, fmt.Sprintf("%s: %s (branch %s) no longer exists", rigName, wt.Path, wt.Branch))
+						found = true
+					}
+				}
+				if found {
+					c.staleRepos = append(c.staleRepos, repo)
+				}
+			}
+		}
+
+		entries, _ := os.ReadDir(filepath.Join(rigPath, "polecats"))
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			if reason := brokenWorktree(filepath.Join(rigPath, "polecats", e.Name())); reason != "" {
+				broken = append(broken, fmt.Sprintf("%s/polecats/%s: %s", rigName, e.Name(), reason))
+			}
+		}
+	}
+
+	if len(stale) == 0 && len(broken) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "All worktrees healthy",
+		}
+	}
+
+	result := &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d stale, %d broken worktree(s)", len(stale), len(broken)),
+		Details: append(stale, broken...),
+		FixHint: "Run 'gt doctor --fix' to prune stale worktrees",
+	}
+	if len(broken) > 0 {
+		result.FixHint = "Run 'gt doctor --fix' to prune stale worktrees; remove broken polecats with 'gt polecat nuke <rig>/<name>'"
+	}
+	return result
+}
+
+// Fix prunes stale worktree registrations. Broken worktrees may hold
+// uncommitted work and are left for a human.
+func (c *WorktreeHealthCheck) Fix(ctx *CheckContext) error {
+	for _, repo := range c.staleRepos {
+		if err := repo.WorktreePrune(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rigRepo returns the repository a rig's worktrees hang off: the shared
+// bare repo, else mayor/rig. Nil if the rig has neither.
+func rigRepo(rigPath string) *git.Git {
+	bare := filepath.Join(rigPath, ".repo.git")
+	if info, err := os.Stat(bare); err == nil && info.IsDir() {
+		return git.NewGitWithDir(bare, "")
+	}
+	mayor := filepath.Join(rigPath, "mayor", "rig")
+	if _, err := os.Stat(filepath.Join(mayor, ".git")); err == nil {
+		return git.NewGit(mayor)
+	}
+	return nil
+}
+
+// brokenWorktree explains why dir is not a usable git checkout, or
+// returns "" if it is one.
+func brokenWorktree(dir string) string {
+	dotGit := filepath.Join(dir, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "no .git"
+	}
+	if info.IsDir() {
+		return "" // A full clone
+	}
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return fmt.Sprintf("unreadable .git: %v", err)
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ".git file has no gitdir"
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+	if _, err := os.Stat(gitDir); err != nil {
+		return "gitdir " + gitDir + " is missing"
+	}
+	return ""
+}
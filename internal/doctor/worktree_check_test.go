@@ -0,0 +1,57 @@
+package doctor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorktreeHealthCheck(t *testing.T) {
+	town := t.TempDir()
+	rigPath := filepath.Join(town, "gastown")
+	repo := filepath.Join(rigPath, "mayor", "rig")
+	gitRun := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(repo, "init", "-q")
+	gitRun(repo, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "--allow-empty", "-m", "init")
+	if err := os.MkdirAll(filepath.Join(rigPath, "polecats"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(repo, "worktree", "add", "-q", "-b", "polecat/nux", filepath.Join(rigPath, "polecats", "nux"))
+	gitRun(repo, "worktree", "add", "-q", "-b", "polecat/ace", filepath.Join(rigPath, "polecats", "ace"))
+
+	ctx := &CheckContext{TownRoot: town}
+	check := NewWorktreeHealthCheck()
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Fatalf("healthy worktrees: %+v", result)
+	}
+
+	// nux deleted behind git's back; ace's registration lost
+	if err := os.RemoveAll(filepath.Join(rigPath, "polecats", "nux")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(repo, ".git", "worktrees", "ace")); err != nil {
+		t.Fatal(err)
+	}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning || len(result.Details) != 2 {
+		t.Fatalf("damaged worktrees: %+v", result)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if result = check.Run(ctx); len(result.Details) != 1 {
+		t.Errorf("after fix, want only the broken worktree left: %+v", result)
+	}
+}
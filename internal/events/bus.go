@@ -0,0 +1,178 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultQueueSize is the queue depth for async subscribers when none is given.
+const DefaultQueueSize = 256
+
+// Handler consumes events delivered by a Bus.
+type Handler func(Event)
+
+// SyncHandler consumes events inline on the publisher's goroutine.
+// Its error is returned from Publish.
+type SyncHandler func(Event) error
+
+// Bus fans published events out to subscribers.
+//
+// Async subscribers each get a bounded queue drained by their own goroutine;
+// when a queue is full the event is dropped for that subscriber (and counted)
+// rather than blocking the publisher, so a slow sink never stalls a gt
+// command. Sync subscribers run inline and are reserved for sinks that must
+// complete before a short-lived process exits, such as the events file.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[string]*subscription
+}
+
+type subscription struct {
+	name    string
+	sync    SyncHandler
+	async   Handler
+	queue   chan Event
+	done    chan struct{}
+	dropped atomic.Int64
+}
+
+// NewBus creates an empty bus.
+func NewBus() *Bus {
+	return &Bus{subs: make(map[string]*subscription)}
+}
+
+// Subscribe registers an async handler with a bounded queue.
+// Subscribing again under the same name replaces the previous handler.
+// The returned function unsubscribes and waits for the queue to drain.
+func (b *Bus) Subscribe(name string, queueSize int, h Handler) func() {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	sub := &subscription{
+		name:  name,
+		async: h,
+		queue: make(chan Event, queueSize),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer close(sub.done)
+		for e := range sub.queue {
+			h(e)
+		}
+	}()
+	b.add(sub)
+	return func() { b.remove(name, sub) }
+}
+
+// SubscribeSync registers a handler that runs inline during Publish.
+func (b *Bus) SubscribeSync(name string, h SyncHandler) func() {
+	sub := &subscription{name: name, sync: h}
+	b.add(sub)
+	return func() { b.remove(name, sub) }
+}
+
+// Publish delivers an event to all subscribers. Sync handlers run first, in
+// no particular order; the first error among them is returned. Async
+// subscribers whose queues are full miss the event.
+func (b *Bus) Publish(e Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var firstErr error
+	for _, sub := range b.subs {
+		if sub.sync == nil {
+			continue
+		}
+		if err := sub.sync(e); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, sub := range b.subs {
+		if sub.async == nil {
+			continue
+		}
+		select {
+		case sub.queue <- e:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+	return firstErr
+}
+
+// Dropped returns how many events the named subscriber has missed because
+// its queue was full.
+func (b *Bus) Dropped(name string) int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if sub, ok := b.subs[name]; ok {
+		return sub.dropped.Load()
+	}
+	return 0
+}
+
+// Close unsubscribes everything, draining async queues.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = make(map[string]*subscription)
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.stop()
+	}
+}
+
+func (b *Bus) add(sub *subscription) {
+	b.mu.Lock()
+	old := b.subs[sub.name]
+	b.subs[sub.name] = sub
+	b.mu.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+}
+
+func (b *Bus) remove(name string, sub *subscription) {
+	b.mu.Lock()
+	if b.subs[name] != sub {
+		b.mu.Unlock()
+		return // Already replaced or removed
+	}
+	delete(b.subs, name)
+	b.mu.Unlock()
+
+	sub.stop()
+}
+
+// stop closes an async subscription's queue and waits for it to drain.
+// Must be called without holding the bus lock.
+func (s *subscription) stop() {
+	if s.queue == nil {
+		return
+	}
+	close(s.queue)
+	<-s.done
+}
+
+// fileSinkName is the subscription name of the events file writer.
+const fileSinkName = "file"
+
+// defaultBus is the process-wide bus used by Log. The events file is a
+// sync subscriber so events survive short-lived gt commands.
+var defaultBus = func() *Bus {
+	b := NewBus()
+	b.SubscribeSync(fileSinkName, write)
+	return b
+}()
+
+// Default returns the process-wide event bus.
+func Default() *Bus {
+	return defaultBus
+}
+
+// Subscribe registers an async handler on the default bus.
+func Subscribe(name string, queueSize int, h Handler) func() {
+	return defaultBus.Subscribe(name, queueSize, h)
+}
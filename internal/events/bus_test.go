@@ -0,0 +1,70 @@
+package events
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestBusFanOut(t *testing.T) {
+	b := NewBus()
+	defer b.Close()
+
+	var mu sync.Mutex
+	var got []string
+	unsubscribe := b.Subscribe("async", 8, func(e Event) {
+		mu.Lock()
+		got = append(got, e.Type)
+		mu.Unlock()
+	})
+
+	var syncSeen int
+	b.SubscribeSync("sync", func(e Event) error {
+		syncSeen++
+		return nil
+	})
+
+	for _, typ := range []string{TypeSling, TypeDone} {
+		if err := b.Publish(Event{Type: typ}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	unsubscribe() // drains the queue
+
+	if syncSeen != 2 {
+		t.Errorf("sync handler saw %d events, want 2", syncSeen)
+	}
+	if len(got) != 2 || got[0] != TypeSling || got[1] != TypeDone {
+		t.Errorf("async handler saw %v", got)
+	}
+}
+
+func TestBusSlowSubscriberDrops(t *testing.T) {
+	b := NewBus()
+	defer b.Close()
+
+	release := make(chan struct{})
+	b.Subscribe("slow", 1, func(Event) { <-release })
+
+	// First event is taken by the handler, second fills the queue,
+	// the rest must be dropped without blocking.
+	for i := 0; i < 5; i++ {
+		_ = b.Publish(Event{Type: TypeNudge})
+	}
+	if d := b.Dropped("slow"); d < 3 {
+		t.Errorf("Dropped = %d, want >= 3", d)
+	}
+	close(release)
+}
+
+func TestBusSyncError(t *testing.T) {
+	b := NewBus()
+	defer b.Close()
+
+	want := errors.New("disk full")
+	b.SubscribeSync("file", func(Event) error { return want })
+
+	if err := b.Publish(Event{}); !errors.Is(err, want) {
+		t.Errorf("Publish error = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,116 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Payload keys for decision events.
+const (
+	PayloadDecisionID = "decision_id"
+	PayloadRationale  = "rationale"
+)
+
+// Rationale explains an automated decision: what was chosen, why, and on
+// what inputs. It is stored in the payload of the event that records the
+// decision and retrieved with gt explain <decision-id>.
+type Rationale struct {
+	Decision     string                 `json:"decision"`               // what was done, e.g. "parked gastown/Toast"
+	Reasons      []string               `json:"reasons"`                // the conditions that triggered it
+	Inputs       map[string]interface{} `json:"inputs,omitempty"`       // observed values the rule used
+	Alternatives []string               `json:"alternatives,omitempty"` // options considered and not taken
+	Rule         string                 `json:"rule,omitempty"`         // policy or config that applied
+}
+
+// NewDecisionID returns a short unique ID such as "dec-20260102-a1b2c3".
+func NewDecisionID() string {
+	var b [3]byte
+	_, _ = rand.Read(b[:])
+	return fmt.Sprintf("dec-%s-%s", time.Now().UTC().Format("20060102"), hex.EncodeToString(b[:]))
+}
+
+// LogDecision logs an event carrying a decision ID and its rationale, and
+// returns the ID. The payload map is not modified.
+func LogDecision(eventType, actor string, payload map[string]interface{}, visibility string, r Rationale) (string, error) {
+	id := NewDecisionID()
+	p := make(map[string]interface{}, len(payload)+2)
+	for k, v := range payload {
+		p[k] = v
+	}
+	p[PayloadDecisionID] = id
+	p[PayloadRationale] = r
+	return id, Log(eventType, actor, p, visibility)
+}
+
+// DecisionID returns the decision ID recorded on an event, if any.
+func (e Event) DecisionID() string {
+	id, _ := e.Payload[PayloadDecisionID].(string)
+	return id
+}
+
+// Rationale decodes the rationale recorded on an event. Events read back
+// from disk hold it as a generic map, so it is round-tripped through JSON.
+func (e Event) Rationale() (*Rationale, bool) {
+	raw, ok := e.Payload[PayloadRationale]
+	if !ok {
+		return nil, false
+	}
+	if r, ok := raw.(Rationale); ok {
+		return &r, true
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false
+	}
+	var r Rationale
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, false
+	}
+	return &r, true
+}
+
+// FindDecision returns the event recording decision id.
+func FindDecision(evts []Event, id string) (*Event, bool) {
+	for i := len(evts) - 1; i >= 0; i-- {
+		if evts[i].DecisionID() == id {
+			return &evts[i], true
+		}
+	}
+	return nil, false
+}
+
+// Decisions returns decision events, optionally limited to those whose
+// payload mentions subject (a bead, agent, or MR ID), newest first.
+func Decisions(evts []Event, subject string) []Event {
+	var out []Event
+	for i := len(evts) - 1; i >= 0; i-- {
+		e := evts[i]
+		if e.DecisionID() == "" {
+			continue
+		}
+		if subject != "" && !mentions(e, subject) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// mentions reports whether any string payload value equals subject.
+func mentions(e Event, subject string) bool {
+	if e.Actor == subject {
+		return true
+	}
+	for k, v := range e.Payload {
+		if k == PayloadRationale {
+			continue
+		}
+		if s, ok := v.(string); ok && s == subject {
+			return true
+		}
+	}
+	return false
+}
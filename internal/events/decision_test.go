@@ -0,0 +1,52 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDecisionRoundTrip(t *testing.T) {
+	r := Rationale{
+		Decision: "parked gastown/Toast",
+		Reasons:  []string{"no activity for 45m", "no claimed work"},
+		Inputs:   map[string]interface{}{"idle_for": "45m"},
+		Rule:     "idle_park.after=30m",
+	}
+	e := Event{Type: TypePolecatParked, Actor: "daemon", Payload: map[string]interface{}{
+		"rig": "gastown", "polecat": "Toast", PayloadDecisionID: "dec-1", PayloadRationale: r,
+	}}
+
+	// Simulate reading back from the events file.
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read Event
+	if err := json.Unmarshal(data, &read); err != nil {
+		t.Fatal(err)
+	}
+
+	found, ok := FindDecision([]Event{{Type: TypeSling}, read}, "dec-1")
+	if !ok {
+		t.Fatal("decision not found")
+	}
+	got, ok := found.Rationale()
+	if !ok || got.Decision != r.Decision || len(got.Reasons) != 2 || got.Inputs["idle_for"] != "45m" {
+		t.Errorf("Rationale = %+v", got)
+	}
+
+	if got := Decisions([]Event{read}, "Toast"); len(got) != 1 {
+		t.Errorf("Decisions(Toast) = %d, want 1", len(got))
+	}
+	if got := Decisions([]Event{read}, "Nux"); len(got) != 0 {
+		t.Errorf("Decisions(Nux) = %d, want 0", len(got))
+	}
+}
+
+func TestNewDecisionID(t *testing.T) {
+	a, b := NewDecisionID(), NewDecisionID()
+	if a == b || !strings.HasPrefix(a, "dec-") {
+		t.Errorf("NewDecisionID = %q, %q", a, b)
+	}
+}
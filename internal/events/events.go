@@ -1,17 +1,23 @@
 // Package events provides event logging for the gt activity feed.
 //
-// Events are written to ~/gt/.events.jsonl (raw audit log) and later
+// Events are published on an in-process Bus; the file sink writes them to
+// ~/gt/.events.jsonl (raw audit log), and they are later
 // curated by the feed daemon into ~/.feed.jsonl (user-facing).
+// With event_log.per_rig set, a rig's events go to ~/gt/<rig>/.events.jsonl
+// instead, and town-wide reads (ReadTown, TailTown) merge the logs.
 package events
 
 import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/flock"
+	"github.com/steveyegge/gastown/internal/redact"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -23,6 +29,20 @@ type Event struct {
 	Actor      string                 `json:"actor"`
 	Payload    map[string]interface{} `json:"payload,omitempty"`
 	Visibility string                 `json:"visibility"`
+
+	// Severity is one of the Severity constants. Empty on events logged
+	// before severities; see Level.
+	Severity string `json:"severity,omitempty"`
+
+	// ID identifies the event; TraceID and ParentID place it in a trace
+	// (see Span). All three are empty on events logged before tracing.
+	ID       string `json:"id,omitempty"`
+	TraceID  string `json:"trace_id,omitempty"`
+	ParentID string `json:"parent_id,omitempty"`
+
+	// IdempotencyKey names the action an event records, so a retried
+	// command logs it once (see LogIdempotent). Empty for most events.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // Visibility levels for events.
@@ -30,6 +50,18 @@ const (
 	VisibilityAudit = "audit" // Only in raw events log
 	VisibilityFeed  = "feed"  // Appears in curated feed
 	VisibilityBoth  = "both"  // Both audit and feed
+
+	// VisibilityDefault asks Log for the town's policy for the event type;
+	// see VisibilityFor.
+	VisibilityDefault = ""
+)
+
+// Severity levels for events, least severe first.
+const (
+	SeverityDebug = "debug"
+	SeverityInfo  = "info"
+	SeverityWarn  = "warn"
+	SeverityError = "error"
 )
 
 // Common event types for gt commands.
@@ -46,6 +78,12 @@ const (
 	TypeBoot    = "boot"
 	TypeHalt    = "halt"
 
+	// Batch sling (one event for the whole batch)
+	TypeSlingBatch = "sling_batch"
+
+	// Mail read receipts (one per recipient per message)
+	TypeMailRead = "mail_read"
+
 	// Session events (for seance discovery)
 	TypeSessionStart = "session_start"
 	TypeSessionEnd   = "session_end"
@@ -62,27 +100,178 @@ const (
 	TypeMerged       = "merged"
 	TypeMergeFailed  = "merge_failed"
 	TypeMergeSkipped = "merge_skipped"
+
+	// Rework events (quality signals)
+	TypeReopened         = "reopened"
+	TypeChangesRequested = "changes_requested"
+
+	// Sandbox enforcement
+	TypeSandboxViolation = "sandbox_violation"
+
+	// Daemon startup reconciliation
+	TypeReconcile    = "reconcile"
+	TypeAutoReleased = "auto_released"
+
+	// Idle polecat parking
+	TypePolecatParked = "polecat_parked"
+	TypePolecatWoken  = "polecat_woken"
+
+	// Automatic work dispatch
+	TypeDispatched      = "dispatched"
+	TypeDispatchSkipped = "dispatch_skipped"
+
+	// Scheduled beads (emitted by daemon per run of a schedule rule)
+	TypeScheduled       = "scheduled"
+	TypeScheduleSkipped = "schedule_skipped"
+
+	// Spending budgets (emitted by daemon once per breach)
+	TypeBudgetExceeded = "budget_exceeded"
+
+	// Nudge escalation (emitted by daemon per step for a silent polecat)
+	TypeNudgeEscalated = "nudge_escalated"
+
+	// Beads sync drift (emitted by daemon on state changes)
+	TypeSyncDrift     = "sync_drift"
+	TypeSyncConflict  = "sync_conflict"
+	TypeSyncRecovered = "sync_recovered"
+
+	// Failures (logged with LogError)
+	TypeSlingFailed   = "sling_failed"
+	TypeMergeConflict = "merge_conflict"
+	TypeAgentCrash    = "agent_crash"
 )
 
-// EventsFile is the name of the raw events log.
-const EventsFile = ".events.jsonl"
+// DefaultSeverity is the severity Log gives an event of this type.
+func DefaultSeverity(eventType string) string {
+	switch eventType {
+	case TypeSlingFailed, TypeMergeConflict, TypeAgentCrash, TypeMergeFailed:
+		return SeverityError
+	case TypeSyncConflict, TypeSandboxViolation, TypeEscalationSent, TypeBudgetExceeded, TypeNudgeEscalated:
+		return SeverityWarn
+	}
+	return SeverityInfo
+}
+
+// SeverityRank orders severities: debug 0, info 1, warn 2, error 3.
+// Unknown severities rank as info.
+func SeverityRank(severity string) int {
+	switch severity {
+	case SeverityDebug:
+		return 0
+	case SeverityWarn:
+		return 2
+	case SeverityError:
+		return 3
+	}
+	return 1
+}
+
+// Level returns the event's severity, or its type's default for events
+// logged without one.
+func (e Event) Level() string {
+	if e.Severity != "" {
+		return e.Severity
+	}
+	return DefaultSeverity(e.Type)
+}
+
+// EventsFile is the default name of the raw events log.
+// Use Path to honor storage overrides in town settings.
+const EventsFile = config.DefaultEventsFile
+
+// Path returns the events log location for a town.
+func Path(townRoot string) string {
+	return config.LoadPaths(townRoot).EventsFile
+}
 
 // mutex protects concurrent writes to the events file.
 var mutex sync.Mutex
 
-// Log writes an event to the events log.
-// The event is appended to ~/gt/.events.jsonl.
-// Returns nil if logging fails (events are best-effort).
+// Log publishes an event on the default bus.
+// The file sink appends it to ~/gt/.events.jsonl.
+// Returns nil if logging fails (events are best-effort). An event whose
+// payload fails its schema (see Validate) is not published; it goes to
+// the rejects file and the *SchemaError is returned.
+//
+// With VisibilityDefault the visibility comes from the town's policy for
+// the event type (see VisibilityFor).
+//
+// Inside a trace (see StartTrace) the event joins it, and becomes the
+// parent of the process's next event.
 func Log(eventType, actor string, payload map[string]interface{}, visibility string) error {
-	event := Event{
+	span := processSpan()
+	return logInSpan(newEvent(eventType, actor, payload, visibility, span), span)
+}
+
+// LogIdempotent is Log for an action that may be retried. An event with
+// the same key logged within DedupeWindow, by this process or another, is
+// taken to be the same action and this one is dropped.
+func LogIdempotent(key, eventType, actor string, payload map[string]interface{}, visibility string) error {
+	span := processSpan()
+	event := newEvent(eventType, actor, payload, visibility, span)
+	event.IdempotencyKey = key
+	return logInSpan(event, span)
+}
+
+// LogError logs a failure: an event with SeverityError whose payload
+// carries err's message under "error", visible per the town's policy
+// (audit and feed by default). payload is not modified.
+func LogError(eventType, actor string, err error, payload map[string]interface{}) error {
+	withErr := make(map[string]interface{}, len(payload)+1)
+	for k, v := range payload {
+		withErr[k] = v
+	}
+	if err != nil {
+		withErr["error"] = err.Error()
+	}
+	span := processSpan()
+	event := newEvent(eventType, actor, withErr, VisibilityDefault, span)
+	event.Severity = SeverityError
+	return logInSpan(event, span)
+}
+
+// logInSpan publishes an event created in the process span, making it
+// the parent of the process's next event.
+func logInSpan(event Event, span Span) error {
+	err := publish(event)
+	if span.TraceID != "" && err == nil {
+		setProcessSpan(Span{TraceID: span.TraceID, ParentID: event.ID})
+	}
+	return err
+}
+
+func newEvent(eventType, actor string, payload map[string]interface{}, visibility string, span Span) Event {
+	return Event{
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 		Source:     "gt",
 		Type:       eventType,
 		Actor:      actor,
 		Payload:    payload,
-		Visibility: visibility,
+		Visibility: resolveVisibility(eventType, visibility),
+		Severity:   DefaultSeverity(eventType),
+		ID:         NewID(),
+		TraceID:    span.TraceID,
+		ParentID:   span.ParentID,
 	}
-	return write(event)
+}
+
+// publish redacts secrets from an event's payload (see package redact),
+// validates it, and publishes it on the default bus, unless it duplicates
+// a recent one (see LogIdempotent).
+func publish(event Event) error {
+	townRoot, _ := workspace.FindFromCwd()
+	var n int
+	event.Payload, n = redact.ForTown(townRoot).Payload(event.Payload)
+	_ = redact.Record(townRoot, redact.SourceEvent, n)
+
+	if err := Validate(event); err != nil {
+		reject(event, err)
+		return err
+	}
+	if isDuplicate(event, time.Now()) {
+		return nil
+	}
+	return defaultBus.Publish(event)
 }
 
 // LogFeed is a convenience wrapper for feed-visible events.
@@ -95,7 +284,7 @@ func LogAudit(eventType, actor string, payload map[string]interface{}) error {
 	return Log(eventType, actor, payload, VisibilityAudit)
 }
 
-// write appends an event to the events file.
+// write appends an event to the events file. It is the default bus's file sink.
 func write(event Event) error {
 	// Find town root
 	townRoot, err := workspace.FindFromCwd()
@@ -104,7 +293,7 @@ func write(event Event) error {
 		return nil
 	}
 
-	eventsPath := filepath.Join(townRoot, EventsFile)
+	eventsPath := logPathFor(townRoot, event)
 
 	// Marshal event to JSON
 	data, err := json.Marshal(event)
@@ -113,81 +302,77 @@ func write(event Event) error {
 	}
 	data = append(data, '\n')
 
-	// Append to file with proper locking
+	// Append under the in-process mutex and the cross-process file lock
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	f, err := os.OpenFile(eventsPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
-	if err != nil {
-		return fmt.Errorf("opening events file: %w", err)
-	}
-	defer f.Close()
+	// Rotation is best-effort: a failure must not lose the event
+	_, _ = Rotate(eventsPath, cachedPolicy(townRoot), time.Now())
 
-	if _, err := f.Write(data); err != nil {
+	if err := flock.AppendFile(eventsPath, data, 0644); err != nil {
 		return fmt.Errorf("writing event: %w", err)
 	}
 
 	return nil
 }
 
-// Payload helpers for common event structures.
+// Payload helpers for common event structures. Each builds the typed
+// payload (see payloads.go) and encodes it for Log.
 
 // SlingPayload creates a payload for sling events.
 func SlingPayload(beadID, target string) map[string]interface{} {
-	return map[string]interface{}{
-		"bead":   beadID,
-		"target": target,
-	}
+	return Encode(SlingEvent{Bead: beadID, Target: target})
 }
 
 // HookPayload creates a payload for hook events.
 func HookPayload(beadID string) map[string]interface{} {
-	return map[string]interface{}{
-		"bead": beadID,
-	}
+	return Encode(HookEvent{Bead: beadID})
 }
 
 // HandoffPayload creates a payload for handoff events.
 func HandoffPayload(subject string, toSession bool) map[string]interface{} {
-	p := map[string]interface{}{
-		"to_session": toSession,
-	}
-	if subject != "" {
-		p["subject"] = subject
-	}
-	return p
+	return Encode(HandoffEvent{ToSession: toSession, Subject: subject})
 }
 
 // DonePayload creates a payload for done events.
 func DonePayload(beadID, branch string) map[string]interface{} {
-	return map[string]interface{}{
-		"bead":   beadID,
-		"branch": branch,
-	}
+	return Encode(DoneEvent{Bead: beadID, Branch: branch})
 }
 
 // MailPayload creates a payload for mail events.
 func MailPayload(to, subject string) map[string]interface{} {
-	return map[string]interface{}{
-		"to":      to,
-		"subject": subject,
-	}
+	return Encode(MailEvent{To: to, Subject: subject})
+}
+
+// MailReadPayload creates a payload for mail_read events; the actor is
+// the recipient who read the message.
+func MailReadPayload(message, from, subject, thread string) map[string]interface{} {
+	return Encode(MailReadEvent{Message: message, From: from, Subject: subject, Thread: thread})
 }
 
 // SpawnPayload creates a payload for spawn events.
 func SpawnPayload(rig, polecat string) map[string]interface{} {
-	return map[string]interface{}{
-		"rig":     rig,
-		"polecat": polecat,
-	}
+	return Encode(SpawnEvent{Rig: rig, Polecat: polecat})
 }
 
 // BootPayload creates a payload for rig boot events.
 func BootPayload(rig string, agents []string) map[string]interface{} {
-	return map[string]interface{}{
-		"rig":    rig,
-		"agents": agents,
+	return Encode(BootEvent{Rig: rig, Agents: agents})
+}
+
+// SpawnPoolPayload creates a payload for the boot event of a spawn pool:
+// the polecats started in rig and, per polecat, the error of any that
+// failed.
+func SpawnPoolPayload(rig string, spawned []string, failed map[string]string) map[string]interface{} {
+	e := BootEvent{Rig: rig, Agents: spawned, Errors: failed}
+	if e.Agents == nil {
+		e.Agents = []string{}
 	}
+	for name := range failed {
+		e.Failed = append(e.Failed, name)
+	}
+	sort.Strings(e.Failed)
+	return Encode(e)
 }
 
 // MergePayload creates a payload for merge queue events.
@@ -196,82 +381,103 @@ func BootPayload(rig string, agents []string) map[string]interface{} {
 // branch: source branch being merged
 // reason: failure reason (for merge_failed/merge_skipped events)
 func MergePayload(mrID, worker, branch, reason string) map[string]interface{} {
-	p := map[string]interface{}{
-		"mr":     mrID,
-		"worker": worker,
-		"branch": branch,
-	}
-	if reason != "" {
-		p["reason"] = reason
-	}
-	return p
+	return Encode(MergeEvent{MR: mrID, Worker: worker, Branch: branch, Reason: reason})
+}
+
+// ReworkPayload creates a payload for reopened/changes_requested events.
+// beadID: the issue being reworked
+// epic: parent epic of the issue (optional, enables per-epic rollups)
+// worker: agent whose work was sent back (optional, defaults to the actor)
+// reason: why the work was sent back
+func ReworkPayload(beadID, epic, worker, reason string) map[string]interface{} {
+	return Encode(ReworkEvent{Bead: beadID, Epic: epic, Worker: worker, Reason: reason})
 }
 
 // PatrolPayload creates a payload for patrol start/complete events.
 func PatrolPayload(rig string, polecatCount int, message string) map[string]interface{} {
-	p := map[string]interface{}{
-		"rig":           rig,
-		"polecat_count": polecatCount,
-	}
-	if message != "" {
-		p["message"] = message
-	}
-	return p
+	return Encode(PatrolEvent{Rig: rig, PolecatCount: polecatCount, Message: message})
 }
 
 // PolecatCheckPayload creates a payload for polecat check events.
 func PolecatCheckPayload(rig, polecat, status, issue string) map[string]interface{} {
-	p := map[string]interface{}{
-		"rig":     rig,
-		"polecat": polecat,
-		"status":  status,
-	}
-	if issue != "" {
-		p["issue"] = issue
-	}
-	return p
+	return Encode(PolecatCheckEvent{Rig: rig, Polecat: polecat, Status: status, Issue: issue})
+}
+
+// SyncDriftPayload creates a payload for beads sync drift events.
+// Scope is a rig name, or "town" for the town-level beads.
+func SyncDriftPayload(scope string, behind, ahead int, conflicts []string) map[string]interface{} {
+	return Encode(SyncDriftEvent{Rig: scope, Behind: behind, Ahead: ahead, Conflicts: conflicts})
 }
 
 // NudgePayload creates a payload for nudge events.
 func NudgePayload(rig, target, reason string) map[string]interface{} {
-	return map[string]interface{}{
-		"rig":    rig,
-		"target": target,
-		"reason": reason,
-	}
+	return Encode(NudgeEvent{Rig: rig, Target: target, Reason: reason})
 }
 
 // EscalationPayload creates a payload for escalation events.
 func EscalationPayload(rig, target, to, reason string) map[string]interface{} {
-	return map[string]interface{}{
-		"rig":    rig,
-		"target": target,
-		"to":     to,
-		"reason": reason,
-	}
+	return Encode(EscalationEvent{Rig: rig, Target: target, To: to, Reason: reason})
+}
+
+// NudgeEscalationPayload creates a payload for nudge_escalated events:
+// step (1-based) of the escalation of rig's silent polecat, the action
+// taken, and how long it has been silent since the nudge.
+func NudgeEscalationPayload(rig, polecat string, step int, action string, silentFor time.Duration) map[string]interface{} {
+	return Encode(NudgeEscalationEvent{Rig: rig, Polecat: polecat, Step: step, Action: action, SilentFor: silentFor.String()})
 }
 
 // UnhookPayload creates a payload for unhook events.
 func UnhookPayload(beadID string) map[string]interface{} {
-	return map[string]interface{}{
-		"bead": beadID,
-	}
+	return Encode(HookEvent{Bead: beadID})
 }
 
 // KillPayload creates a payload for kill events.
 func KillPayload(rig, target, reason string) map[string]interface{} {
-	return map[string]interface{}{
-		"rig":    rig,
-		"target": target,
-		"reason": reason,
+	return Encode(KillEvent{Rig: rig, Target: target, Reason: reason})
+}
+
+// SlingBatchPayload creates a payload for sling_batch events: the beads
+// slung to target and, per bead, the error of any that failed.
+func SlingBatchPayload(target, epic string, slung []string, failed map[string]string) map[string]interface{} {
+	e := SlingBatchEvent{Target: target, Epic: epic, Beads: slung, Errors: failed}
+	if e.Beads == nil {
+		e.Beads = []string{}
+	}
+	for id := range failed {
+		e.Failed = append(e.Failed, id)
 	}
+	sort.Strings(e.Failed)
+	return Encode(e)
+}
+
+// SlingFailedPayload creates a payload for sling_failed events; LogError
+// adds the error.
+func SlingFailedPayload(beadID, target string) map[string]interface{} {
+	return Encode(SlingFailedEvent{Bead: beadID, Target: target})
+}
+
+// MergeConflictPayload creates a payload for merge_conflict events; LogError
+// adds the error.
+func MergeConflictPayload(mrID, worker, branch string, files []string) map[string]interface{} {
+	return Encode(MergeConflictEvent{MR: mrID, Worker: worker, Branch: branch, Files: files})
+}
+
+// AgentCrashPayload creates a payload for agent_crash events; LogError adds
+// the error. beadID is the work the agent had hooked, if any.
+func AgentCrashPayload(rig, agent, beadID string) map[string]interface{} {
+	return Encode(AgentCrashEvent{Rig: rig, Agent: agent, Bead: beadID})
 }
 
 // HaltPayload creates a payload for halt events.
 func HaltPayload(services []string) map[string]interface{} {
-	return map[string]interface{}{
-		"services": services,
-	}
+	return Encode(HaltEvent{Services: services})
+}
+
+// DrainedHaltPayload creates a payload for halt events of a drained halt:
+// the services stopped and the beads released because their work was
+// still in flight.
+func DrainedHaltPayload(services, released []string) map[string]interface{} {
+	return Encode(HaltEvent{Services: services, Drained: true, Released: released})
 }
 
 // SessionPayload creates a payload for session start/end events.
@@ -280,16 +486,11 @@ func HaltPayload(services []string) map[string]interface{} {
 // topic: What the session is working on
 // cwd: Working directory
 func SessionPayload(sessionID, role, topic, cwd string) map[string]interface{} {
-	p := map[string]interface{}{
-		"session_id": sessionID,
-		"role":       role,
-		"actor_pid":  fmt.Sprintf("%s-%d", role, os.Getpid()),
-	}
-	if topic != "" {
-		p["topic"] = topic
-	}
-	if cwd != "" {
-		p["cwd"] = cwd
-	}
-	return p
+	return Encode(SessionEvent{
+		SessionID: sessionID,
+		Role:      role,
+		ActorPID:  fmt.Sprintf("%s-%d", role, os.Getpid()),
+		Topic:     topic,
+		Cwd:       cwd,
+	})
 }
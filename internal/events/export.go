@@ -0,0 +1,206 @@
+package events
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Export formats.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// ExportColumns is the stable column schema for exported events.
+// Columns are only ever appended, so notebooks keyed on name or position
+// keep working across versions. Commonly-queried payload fields are lifted
+// into their own columns; the full payload is kept as JSON in "payload".
+var ExportColumns = []string{
+	"ts",
+	"source",
+	"type",
+	"actor",
+	"visibility",
+	"rig",
+	"bead",
+	"target",
+	"reason",
+	"payload",
+}
+
+// ExportFilter selects which events are exported.
+type ExportFilter struct {
+	Since time.Time // Zero means no lower bound
+	Types []string  // Empty means all types
+}
+
+// Match reports whether an event passes the filter.
+func (f ExportFilter) Match(e Event) bool {
+	if !f.Since.IsZero() {
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil || ts.Before(f.Since) {
+			return false
+		}
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if e.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadFile parses a JSONL events log, rotated segments included, skipping
+// malformed lines. A missing log yields no events and no error.
+func ReadFile(path string) ([]Event, error) {
+	return readHistory(path, time.Time{})
+}
+
+// readHistory parses the log at path, skipping segments rotated before
+// since.
+func readHistory(path string, since time.Time) ([]Event, error) {
+	var evts []Event
+	err := scanHistory(path, since, func(e Event) error {
+		evts = append(evts, e)
+		return nil
+	})
+	return evts, err
+}
+
+// scanHistory streams the log at path to fn, oldest first, skipping
+// segments rotated before since and malformed lines. It stops at fn's
+// first error and returns it. A missing log has no events.
+func scanHistory(path string, since time.Time, fn func(Event) error) error {
+	file, err := openHistory(path, since)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // Skip malformed lines
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Export writes events matching filter to w in the given format.
+// Returns the number of events written.
+func Export(w io.Writer, evts []Event, format string, filter ExportFilter) (int, error) {
+	switch format {
+	case FormatCSV:
+		return exportCSV(w, evts, filter)
+	case FormatJSONL:
+		return exportJSONL(w, evts, filter)
+	default:
+		return 0, fmt.Errorf("unsupported export format %q (want %s or %s)", format, FormatCSV, FormatJSONL)
+	}
+}
+
+// Row flattens an event into values aligned with ExportColumns.
+func Row(e Event) []string {
+	payload := ""
+	if len(e.Payload) > 0 {
+		// json.Marshal sorts map keys, so the payload column is deterministic.
+		if data, err := json.Marshal(e.Payload); err == nil {
+			payload = string(data)
+		}
+	}
+	return []string{
+		e.Timestamp,
+		e.Source,
+		e.Type,
+		e.Actor,
+		e.Visibility,
+		payloadString(e.Payload, "rig"),
+		firstPayloadString(e.Payload, "bead", "issue", "mr"),
+		payloadString(e.Payload, "target"),
+		payloadString(e.Payload, "reason"),
+		payload,
+	}
+}
+
+func exportCSV(w io.Writer, evts []Event, filter ExportFilter) (int, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ExportColumns); err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range evts {
+		if !filter.Match(e) {
+			continue
+		}
+		if err := cw.Write(Row(e)); err != nil {
+			return n, err
+		}
+		n++
+	}
+	cw.Flush()
+	return n, cw.Error()
+}
+
+// exportJSONL writes one flat object per event keyed by ExportColumns.
+func exportJSONL(w io.Writer, evts []Event, filter ExportFilter) (int, error) {
+	enc := json.NewEncoder(w)
+	n := 0
+	for _, e := range evts {
+		if !filter.Match(e) {
+			continue
+		}
+		row := Row(e)
+		obj := make(map[string]string, len(ExportColumns))
+		for i, col := range ExportColumns {
+			obj[col] = row[i]
+		}
+		if err := enc.Encode(obj); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// payloadString returns a payload field as a string, formatting non-string
+// scalars and JSON-encoding anything else.
+func payloadString(payload map[string]interface{}, key string) string {
+	v, ok := payload[key]
+	if !ok || v == nil {
+		return ""
+	}
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64, int, bool:
+		return fmt.Sprint(val)
+	default:
+		data, _ := json.Marshal(val)
+		return string(data)
+	}
+}
+
+func firstPayloadString(payload map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if s := payloadString(payload, k); s != "" {
+			return s
+		}
+	}
+	return ""
+}
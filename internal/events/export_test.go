@@ -0,0 +1,70 @@
+package events
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleEvents() []Event {
+	return []Event{
+		{Timestamp: "2026-01-01T10:00:00Z", Source: "gt", Type: TypeSling, Actor: "mayor",
+			Payload: SlingPayload("gt-1", "gastown/Toast"), Visibility: VisibilityFeed},
+		{Timestamp: "2026-01-02T10:00:00Z", Source: "gt", Type: TypeNudge, Actor: "gastown/witness",
+			Payload: map[string]interface{}{"rig": "gastown", "target": "Toast", "reason": "idle, \"stuck\""}, Visibility: VisibilityAudit},
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Export(&buf, sampleEvents(), FormatCSV, ExportFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("wrote %d events, want 2", n)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if strings.Join(records[0], ",") != strings.Join(ExportColumns, ",") {
+		t.Errorf("header = %v", records[0])
+	}
+	if records[1][6] != "gt-1" || records[1][7] != "gastown/Toast" {
+		t.Errorf("sling row = %v", records[1])
+	}
+	if records[2][5] != "gastown" || records[2][8] != `idle, "stuck"` {
+		t.Errorf("nudge row = %v", records[2])
+	}
+}
+
+func TestExportJSONLFilter(t *testing.T) {
+	var buf bytes.Buffer
+	since, _ := time.Parse(time.RFC3339, "2026-01-02T00:00:00Z")
+	n, err := Export(&buf, sampleEvents(), FormatJSONL, ExportFilter{Since: since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("wrote %d events, want 1", n)
+	}
+
+	var row map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &row); err != nil {
+		t.Fatal(err)
+	}
+	if row["type"] != TypeNudge || row["target"] != "Toast" {
+		t.Errorf("row = %v", row)
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	if _, err := Export(&bytes.Buffer{}, nil, "parquet", ExportFilter{}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// DedupeWindow is how long an idempotency key suppresses repeats.
+const DedupeWindow = 5 * time.Minute
+
+// dedupeScanBytes is how much of the end of the events log is searched
+// for a key logged by another process.
+const dedupeScanBytes = 256 * 1024
+
+// Key builds an idempotency key from an event type and the values that
+// identify the action, e.g. Key(TypeSling, bead, target).
+func Key(eventType string, parts ...string) string {
+	return eventType + ":" + strings.Join(parts, ":")
+}
+
+// recentKeys remembers the idempotency keys this process logged.
+var recentKeys = struct {
+	sync.Mutex
+	m map[string]time.Time
+}{m: make(map[string]time.Time)}
+
+// isDuplicate reports whether an event's idempotency key was logged within
+// DedupeWindow of now, and records it if not. Events without a key are
+// never duplicates.
+func isDuplicate(e Event, now time.Time) bool {
+	key := e.IdempotencyKey
+	if key == "" {
+		return false
+	}
+
+	recentKeys.Lock()
+	defer recentKeys.Unlock()
+	for k, at := range recentKeys.m {
+		if now.Sub(at) >= DedupeWindow {
+			delete(recentKeys.m, k)
+		}
+	}
+	if _, ok := recentKeys.m[key]; ok {
+		return true
+	}
+
+	// A retry is usually a new process, so look in the log too
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		if loggedSince(logPathFor(townRoot, e), key, now.Add(-DedupeWindow)) {
+			return true
+		}
+	}
+	recentKeys.m[key] = now
+	return false
+}
+
+// loggedSince reports whether the tail of the log at path has an event
+// with the idempotency key, timestamped at or after since.
+func loggedSince(path, key string, since time.Time) bool {
+	f, err := os.Open(path) //nolint:gosec // G304: path is the configured events log
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	offset := info.Size() - dedupeScanBytes
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return false
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return false
+	}
+
+	needle, _ := json.Marshal(key)
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if !bytes.Contains(line, needle) {
+			continue
+		}
+		var e Event
+		if json.Unmarshal(line, &e) != nil || e.IdempotencyKey != key {
+			continue // Possibly a line cut by the offset
+		}
+		if ts, err := time.Parse(time.RFC3339, e.Timestamp); err == nil && !ts.Before(since) {
+			return true
+		}
+	}
+	return false
+}
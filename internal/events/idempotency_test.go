@@ -0,0 +1,71 @@
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func resetRecentKeys(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		recentKeys.Lock()
+		recentKeys.m = make(map[string]time.Time)
+		recentKeys.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestLogIdempotentInProcess(t *testing.T) {
+	got := captureEvents(t)
+	resetRecentKeys(t)
+
+	key := Key(TypeSling, "gt-1", "gastown/polecats/nux")
+	if key != "sling:gt-1:gastown/polecats/nux" {
+		t.Errorf("Key = %q", key)
+	}
+	for i := 0; i < 2; i++ {
+		if err := LogIdempotent(key, TypeSling, "mayor", SlingPayload("gt-1", "gastown/polecats/nux"), VisibilityFeed); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = LogIdempotent(Key(TypeSling, "gt-2", "gastown/polecats/nux"), TypeSling, "mayor", SlingPayload("gt-2", "gastown/polecats/nux"), VisibilityFeed)
+
+	if len(*got) != 2 || (*got)[0].IdempotencyKey != key {
+		t.Errorf("logged %+v, want the first gt-1 sling and the gt-2 sling", *got)
+	}
+}
+
+func TestLogIdempotentAcrossProcesses(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+	resetRecentKeys(t)
+
+	// Another process logged these: one just now, one outside the window
+	now := time.Now().UTC()
+	writeEvents(t, Path(townRoot), []Event{
+		{Timestamp: now.Add(-DedupeWindow - time.Minute).Format(time.RFC3339), Type: TypeDone, IdempotencyKey: Key(TypeDone, "gt-old")},
+		{Timestamp: now.Format(time.RFC3339), Type: TypeDone, IdempotencyKey: Key(TypeDone, "gt-1")},
+	})
+
+	if !isDuplicate(Event{IdempotencyKey: Key(TypeDone, "gt-1")}, now) {
+		t.Error("key logged by another process was not a duplicate")
+	}
+	if isDuplicate(Event{IdempotencyKey: Key(TypeDone, "gt-old")}, now) {
+		t.Error("key logged outside the window was a duplicate")
+	}
+	if !isDuplicate(Event{IdempotencyKey: Key(TypeDone, "gt-old")}, now) {
+		t.Error("key just logged by this process was not a duplicate")
+	}
+	if isDuplicate(Event{}, now) {
+		t.Error("event without a key was a duplicate")
+	}
+}
@@ -0,0 +1,243 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Typed payloads for the common event types. The payload helpers build
+// these and Encode them into the map form events carry, so the wire format
+// is unchanged; readers use Decode instead of looking keys up by hand.
+
+// SlingEvent is the payload of sling events.
+type SlingEvent struct {
+	Bead   string `json:"bead"`
+	Target string `json:"target"`
+}
+
+// HookEvent is the payload of hook and unhook events.
+type HookEvent struct {
+	Bead string `json:"bead"`
+}
+
+// HandoffEvent is the payload of handoff events.
+type HandoffEvent struct {
+	ToSession bool   `json:"to_session"`
+	Subject   string `json:"subject,omitempty"`
+}
+
+// DoneEvent is the payload of done events.
+type DoneEvent struct {
+	Bead       string `json:"bead"`
+	Branch     string `json:"branch"`
+	Transcript string `json:"transcript,omitempty"` // Session transcript of the work, if recorded
+}
+
+// MailEvent is the payload of mail events.
+type MailEvent struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+}
+
+// MailReadEvent is the payload of mail_read events.
+type MailReadEvent struct {
+	Message string `json:"message"`
+	From    string `json:"from"`
+	Subject string `json:"subject"`
+	Thread  string `json:"thread,omitempty"`
+}
+
+// SpawnEvent is the payload of spawn events.
+type SpawnEvent struct {
+	Rig     string `json:"rig"`
+	Polecat string `json:"polecat"`
+}
+
+// BootEvent is the payload of rig boot events.
+type BootEvent struct {
+	Rig    string            `json:"rig"`
+	Agents []string          `json:"agents"`
+	Failed []string          `json:"failed,omitempty"` // Agents that failed to start, sorted
+	Errors map[string]string `json:"errors,omitempty"` // Error per failed agent
+}
+
+// MergeEvent is the payload of merge queue events.
+type MergeEvent struct {
+	MR     string `json:"mr"`
+	Worker string `json:"worker"`
+	Branch string `json:"branch"`
+	Reason string `json:"reason,omitempty"` // merge_failed/merge_skipped only
+	Epic   string `json:"epic,omitempty"`   // Epic of the MR's work item, if known
+}
+
+// ReworkEvent is the payload of reopened and changes_requested events.
+type ReworkEvent struct {
+	Bead   string `json:"bead"`
+	Epic   string `json:"epic,omitempty"`
+	Worker string `json:"worker,omitempty"` // Whose work was sent back
+	Reason string `json:"reason,omitempty"`
+}
+
+// PatrolEvent is the payload of patrol start/complete events.
+type PatrolEvent struct {
+	Rig          string `json:"rig"`
+	PolecatCount int    `json:"polecat_count"`
+	Message      string `json:"message,omitempty"`
+}
+
+// PolecatCheckEvent is the payload of polecat check events.
+type PolecatCheckEvent struct {
+	Rig     string `json:"rig"`
+	Polecat string `json:"polecat"`
+	Status  string `json:"status"`
+	Issue   string `json:"issue,omitempty"`
+}
+
+// SyncDriftEvent is the payload of beads sync drift events. Rig is a rig
+// name, or "town" for the town-level beads.
+type SyncDriftEvent struct {
+	Rig       string   `json:"rig"`
+	Behind    int      `json:"behind"`
+	Ahead     int      `json:"ahead"`
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// NudgeEvent is the payload of nudge events.
+type NudgeEvent struct {
+	Rig    string `json:"rig"`
+	Target string `json:"target"`
+	Reason string `json:"reason"`
+}
+
+// EscalationEvent is the payload of escalation events.
+type EscalationEvent struct {
+	Rig    string `json:"rig"`
+	Target string `json:"target"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+// NudgeEscalationEvent is the payload of nudge_escalated events.
+type NudgeEscalationEvent struct {
+	Rig       string `json:"rig"`
+	Polecat   string `json:"polecat"`
+	Step      int    `json:"step"`
+	Action    string `json:"action"`     // renudge, notify, kill or respawn
+	SilentFor string `json:"silent_for"` // Since the nudge being escalated
+}
+
+// KillEvent is the payload of kill events.
+type KillEvent struct {
+	Rig    string `json:"rig"`
+	Target string `json:"target"`
+	Reason string `json:"reason"`
+}
+
+// SlingBatchEvent is the payload of sling_batch events.
+type SlingBatchEvent struct {
+	Target string            `json:"target"`
+	Epic   string            `json:"epic,omitempty"`   // Epic whose children were slung, if any
+	Beads  []string          `json:"beads"`            // Beads slung
+	Failed []string          `json:"failed,omitempty"` // Beads that failed, sorted
+	Errors map[string]string `json:"errors,omitempty"` // Error per failed bead
+}
+
+// SlingFailedEvent is the payload of sling_failed events.
+type SlingFailedEvent struct {
+	Bead   string `json:"bead"`
+	Target string `json:"target"`
+	Error  string `json:"error"`
+}
+
+// MergeConflictEvent is the payload of merge_conflict events.
+type MergeConflictEvent struct {
+	MR     string   `json:"mr"`
+	Worker string   `json:"worker"`
+	Branch string   `json:"branch"`
+	Files  []string `json:"files,omitempty"` // Conflicting files, when known
+	Error  string   `json:"error"`
+}
+
+// AgentCrashEvent is the payload of agent_crash events.
+type AgentCrashEvent struct {
+	Rig   string `json:"rig"`
+	Agent string `json:"agent"`
+	Bead  string `json:"bead,omitempty"` // Hooked work at the time of the crash
+	Error string `json:"error"`
+}
+
+// HaltEvent is the payload of halt events.
+type HaltEvent struct {
+	Services []string `json:"services"`
+	Drained  bool     `json:"drained,omitempty"`  // Halted with --drain
+	Released []string `json:"released,omitempty"` // Beads released back to open by the drain
+}
+
+// SessionEvent is the payload of session start/end events.
+type SessionEvent struct {
+	SessionID string `json:"session_id"`
+	Role      string `json:"role"`
+	ActorPID  string `json:"actor_pid"`
+	Topic     string `json:"topic,omitempty"`
+	Cwd       string `json:"cwd,omitempty"`
+}
+
+// Encode converts a typed payload struct into the map an Event carries,
+// keyed by the fields' json names and honoring omitempty. Values keep
+// their Go types, so in-process subscribers see what was logged.
+func Encode(v interface{}) map[string]interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("events.Encode: want a struct, got %T", v))
+	}
+	rt := rv.Type()
+	m := make(map[string]interface{}, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fv := rv.Field(i)
+		if strings.Contains(opts, "omitempty") && isEmpty(fv) {
+			continue
+		}
+		m[name] = fv.Interface()
+	}
+	return m
+}
+
+// isEmpty matches encoding/json's omitempty rule.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+// Decode fills out, a pointer to a typed payload struct, from an event's
+// payload, whether it was logged in-process or read back from disk.
+func Decode(ev Event, out interface{}) error {
+	data, err := json.Marshal(ev.Payload)
+	if err != nil {
+		return fmt.Errorf("decoding %s payload: %w", ev.Type, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decoding %s payload: %w", ev.Type, err)
+	}
+	return nil
+}
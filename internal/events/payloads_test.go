@@ -0,0 +1,70 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEncodeMatchesWireFormat(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		got  map[string]interface{}
+		want map[string]interface{}
+	}{
+		{"sling", SlingPayload("gt-1", "gastown/polecats/nux"),
+			map[string]interface{}{"bead": "gt-1", "target": "gastown/polecats/nux"}},
+		{"handoff without subject", HandoffPayload("", true),
+			map[string]interface{}{"to_session": true}},
+		{"handoff with subject", HandoffPayload("ctx full", false),
+			map[string]interface{}{"to_session": false, "subject": "ctx full"}},
+		{"sync drift without conflicts", SyncDriftPayload("town", 2, 0, nil),
+			map[string]interface{}{"rig": "town", "behind": 2, "ahead": 0}},
+		{"boot", BootPayload("gastown", []string{"witness"}),
+			map[string]interface{}{"rig": "gastown", "agents": []string{"witness"}}},
+		{"spawn pool", SpawnPoolPayload("gastown", nil, map[string]string{"nux": "no pane", "ace": "no repo"}),
+			map[string]interface{}{"rig": "gastown", "agents": []string{}, "failed": []string{"ace", "nux"},
+				"errors": map[string]string{"nux": "no pane", "ace": "no repo"}}},
+		{"nudge escalation", NudgeEscalationPayload("gastown", "nux", 2, "notify", 30*time.Minute),
+			map[string]interface{}{"rig": "gastown", "polecat": "nux", "step": 2, "action": "notify", "silent_for": "30m0s"}},
+	} {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: got %#v, want %#v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	ev := Event{Type: TypeMergeFailed, Payload: MergePayload("gt-mr1", "nux", "polecat/nux", "conflict")}
+	want := MergeEvent{MR: "gt-mr1", Worker: "nux", Branch: "polecat/nux", Reason: "conflict"}
+
+	var inProcess MergeEvent
+	if err := Decode(ev, &inProcess); err != nil {
+		t.Fatal(err)
+	}
+	if inProcess != want {
+		t.Errorf("in-process: got %+v, want %+v", inProcess, want)
+	}
+
+	// Read back from disk, numbers and slices arrive as float64 and []interface{}
+	data, err := json.Marshal(Event{Type: TypePatrolStarted, Payload: PatrolPayload("gastown", 3, "")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read Event
+	if err := json.Unmarshal(data, &read); err != nil {
+		t.Fatal(err)
+	}
+	var patrol PatrolEvent
+	if err := Decode(read, &patrol); err != nil {
+		t.Fatal(err)
+	}
+	if patrol != (PatrolEvent{Rig: "gastown", PolecatCount: 3}) {
+		t.Errorf("from disk: got %+v", patrol)
+	}
+
+	if err := Decode(Event{Payload: map[string]interface{}{"polecat_count": "three"}}, &patrol); err == nil {
+		t.Error("expected an error for a mistyped field")
+	}
+}
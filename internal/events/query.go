@@ -0,0 +1,192 @@
+package events
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query selects raw events by time range and fields. The zero Query
+// matches everything.
+type Query struct {
+	Filter // Types, Actor, FeedOnly, TraceID, MinSeverity
+
+	From time.Time // Only events at or after From; zero for no lower bound
+	To   time.Time // Only events before To; zero for no upper bound
+
+	// ActorGlob matches the actor as a path.Match pattern, e.g.
+	// "*/polecats/Toast". Applied in addition to Filter.Actor.
+	ActorGlob string
+
+	Visibility []string          // Empty means any visibility
+	Payload    map[string]string // Payload key → required value, compared as text
+}
+
+// ParsePayloadMatch parses a "key=value" payload condition.
+func ParsePayloadMatch(s string) (key, value string, err error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("invalid payload filter %q: want key=value", s)
+	}
+	return key, value, nil
+}
+
+// Match reports whether an event passes the query. An event whose
+// timestamp can't be parsed fails any time bound.
+func (q Query) Match(e Event) bool {
+	if !q.Filter.Match(e) {
+		return false
+	}
+	if !q.From.IsZero() || !q.To.IsZero() {
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil || (!q.From.IsZero() && ts.Before(q.From)) || (!q.To.IsZero() && !ts.Before(q.To)) {
+			return false
+		}
+	}
+	if q.ActorGlob != "" {
+		if ok, _ := path.Match(q.ActorGlob, e.Actor); !ok {
+			return false
+		}
+	}
+	if len(q.Visibility) > 0 && !slices.Contains(q.Visibility, e.Visibility) {
+		return false
+	}
+	for key, want := range q.Payload {
+		v, ok := e.Payload[key]
+		if !ok || payloadText(v) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// payloadText formats a payload value for comparison with a query value:
+// strings as is, numbers without trailing zeros, lists comma-separated.
+func payloadText(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case []interface{}:
+		parts := make([]string, len(x))
+		for i, item := range x {
+			parts[i] = payloadText(item)
+		}
+		return strings.Join(parts, ",")
+	case []string:
+		return strings.Join(x, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+// Run returns the matching events from all of a town's logs, oldest first.
+func (q Query) Run(townRoot string) ([]Event, error) {
+	evts, err := ReadTownSince(townRoot, q.From, q.Filter)
+	if err != nil {
+		return nil, err
+	}
+	out := evts[:0]
+	for _, e := range evts {
+		if q.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// QueryArgs is a query as text, the way gt events query takes it as
+// flags and gt serve as URL parameters.
+type QueryArgs struct {
+	Since      string   // Duration back from now, e.g. "24h" or "7d"
+	From       string   // RFC 3339 time or YYYY-MM-DD (local midnight)
+	Until      string   // Same forms as From
+	Types      []string // Empty means all types
+	Actor      string   // Exact actor, prefix ending in "/", or glob
+	Visibility []string // audit, feed, both
+	Payload    []string // key=value conditions
+	Severity   string   // Minimum severity
+	Trace      string
+}
+
+// Query validates the arguments and builds the query they describe.
+// Since and From together use the later bound.
+func (a QueryArgs) Query(now time.Time) (Query, error) {
+	q := Query{Visibility: a.Visibility}
+	q.Types = a.Types
+	q.TraceID = a.Trace
+
+	if strings.ContainsAny(a.Actor, "*?[") {
+		q.ActorGlob = a.Actor
+	} else {
+		q.Actor = a.Actor
+	}
+
+	if a.Severity != "" {
+		switch a.Severity {
+		case SeverityDebug, SeverityInfo, SeverityWarn, SeverityError:
+			q.MinSeverity = a.Severity
+		default:
+			return q, fmt.Errorf("invalid severity %q: want debug, info, warn, or error", a.Severity)
+		}
+	}
+
+	if a.Since != "" {
+		d, err := parseAge(a.Since)
+		if err != nil {
+			return q, fmt.Errorf("invalid since duration: %w", err)
+		}
+		q.From = now.Add(-d)
+	}
+	if a.From != "" {
+		t, err := parseQueryTime(a.From)
+		if err != nil {
+			return q, fmt.Errorf("invalid from: %w", err)
+		}
+		if t.After(q.From) {
+			q.From = t
+		}
+	}
+	if a.Until != "" {
+		t, err := parseQueryTime(a.Until)
+		if err != nil {
+			return q, fmt.Errorf("invalid until: %w", err)
+		}
+		q.To = t
+	}
+
+	for _, p := range a.Payload {
+		key, value, err := ParsePayloadMatch(p)
+		if err != nil {
+			return q, err
+		}
+		if q.Payload == nil {
+			q.Payload = make(map[string]string)
+		}
+		q.Payload[key] = value
+	}
+	return q, nil
+}
+
+// parseAge parses a Go duration, or whole days as "7d".
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid days format: %s", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// parseQueryTime parses an RFC 3339 time or a YYYY-MM-DD date (local midnight).
+func parseQueryTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", s, time.Local)
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	townRoot := t.TempDir()
+	writeEvents(t, Path(townRoot), []Event{
+		ev(0, TypeHandoff, "gastown/polecats/Toast", HandoffPayload("ctx full", true)),
+		ev(1, TypeHandoff, "gastown/polecats/nux", HandoffPayload("", true)),
+		ev(2, TypeSling, "mayor", SlingPayload("gt-1", "gastown/polecats/Toast")),
+		ev(3, TypeHandoff, "beads/polecats/Toast", HandoffPayload("", false)),
+		ev(4, TypePatrolStarted, "gastown/witness", PatrolPayload("gastown", 3, "")),
+	})
+
+	tests := []struct {
+		name string
+		q    Query
+		want int
+	}{
+		{"everything", Query{}, 5},
+		{"type and glob actor", Query{Filter: Filter{Types: []string{TypeHandoff}}, ActorGlob: "*/polecats/Toast"}, 2},
+		{"time range", Query{From: at(1), To: at(3)}, 2},
+		{"payload string", Query{Payload: map[string]string{"target": "gastown/polecats/Toast"}}, 1},
+		{"payload bool", Query{Payload: map[string]string{"to_session": "false"}}, 1},
+		{"payload number", Query{Payload: map[string]string{"polecat_count": "3"}}, 1},
+		{"missing payload key", Query{Payload: map[string]string{"bead": "gt-2"}}, 0},
+		{"visibility", Query{Visibility: []string{VisibilityFeed}}, 0},
+	}
+	for _, tt := range tests {
+		got, err := tt.q.Run(townRoot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("%s: %d events, want %d", tt.name, len(got), tt.want)
+		}
+	}
+
+	if _, _, err := ParsePayloadMatch("novalue"); err == nil {
+		t.Error("ParsePayloadMatch accepted a condition without =")
+	}
+	if k, v, err := ParsePayloadMatch("reason=a=b"); err != nil || k != "reason" || v != "a=b" {
+		t.Errorf("ParsePayloadMatch = %q, %q, %v", k, v, err)
+	}
+}
+
+func TestQueryArgs(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	q, err := QueryArgs{
+		Since:    "2d",
+		From:     "2026-01-01T00:00:00Z", // Earlier than since; since wins
+		Until:    "2026-01-10T00:00:00Z",
+		Actor:    "*/polecats/Toast",
+		Payload:  []string{"bead=gt-1"},
+		Severity: SeverityWarn,
+	}.Query(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(-48 * time.Hour); !q.From.Equal(want) {
+		t.Errorf("From = %v, want %v", q.From, want)
+	}
+	if q.To.IsZero() || q.ActorGlob != "*/polecats/Toast" || q.Actor != "" {
+		t.Errorf("query = %+v", q)
+	}
+	if q.Payload["bead"] != "gt-1" || q.MinSeverity != SeverityWarn {
+		t.Errorf("query = %+v", q)
+	}
+
+	for _, bad := range []QueryArgs{
+		{Since: "two days"},
+		{From: "yesterday"},
+		{Until: "2026-13-01"},
+		{Severity: "fatal"},
+		{Payload: []string{"bead"}},
+	} {
+		if _, err := bad.Query(now); err == nil {
+			t.Errorf("%+v: want error", bad)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// TailInterval is how often Tail polls the events log for new lines.
+const TailInterval = 250 * time.Millisecond
+
+// Filter selects events for ReadSince and Tail. The zero Filter matches
+// everything.
+type Filter struct {
+	Types    []string // Empty means all types
+	Actor    string   // Exact actor, or a prefix ending in "/" (e.g. "gastown/")
+	FeedOnly bool     // Only feed-visible events (feed or both)
+	TraceID  string   // Only events in this trace
+
+	// MinSeverity keeps events at or above this severity (see Level).
+	MinSeverity string
+}
+
+// Match reports whether an event passes the filter.
+func (f Filter) Match(e Event) bool {
+	if f.FeedOnly && e.Visibility != VisibilityFeed && e.Visibility != VisibilityBoth {
+		return false
+	}
+	if f.TraceID != "" && e.TraceID != f.TraceID {
+		return false
+	}
+	if f.MinSeverity != "" && SeverityRank(e.Level()) < SeverityRank(f.MinSeverity) {
+		return false
+	}
+	if f.Actor != "" {
+		if strings.HasSuffix(f.Actor, "/") {
+			if !strings.HasPrefix(e.Actor, f.Actor) {
+				return false
+			}
+		} else if e.Actor != f.Actor {
+			return false
+		}
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if e.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadSince returns the current town's events at or after t that match
+// filter, oldest first, from the town log and every rig log (see
+// ReadTownSince). A zero t reads the whole log.
+func ReadSince(t time.Time, filter Filter) ([]Event, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, err
+	}
+	return ReadTownSince(townRoot, t, filter)
+}
+
+// ReadFileSince is ReadSince for an explicit events file, reading rotated
+// segments that may hold events after t. Events with an unparseable
+// timestamp are dropped unless t is zero.
+func ReadFileSince(path string, t time.Time, filter Filter) ([]Event, error) {
+	all, err := readHistory(path, t)
+	if err != nil {
+		return nil, err
+	}
+	var out []Event
+	for _, e := range all {
+		if !t.IsZero() {
+			ts, err := time.Parse(time.RFC3339, e.Timestamp)
+			if err != nil || ts.Before(t) {
+				continue
+			}
+		}
+		if filter.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// Tail follows the current town's events logs; see TailTown.
+func Tail(ctx context.Context) (<-chan Event, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, err
+	}
+	return TailTown(ctx, townRoot), nil
+}
+
+// TailFile streams events appended to path from now on, until ctx is
+// done, when the channel is closed. It polls every TailInterval, waits for
+// the file if it doesn't exist yet, starts over when the file is replaced
+// or truncated (rotation), and holds back a partial last line until its
+// newline arrives. Malformed lines are skipped.
+func TailFile(ctx context.Context, path string) <-chan Event {
+	return tailPaths(ctx, func() []string { return []string{path} })
+}
+
+// rediscoverPolls is how many polls tailPaths waits between looking for
+// new files to follow.
+const rediscoverPolls = 4
+
+// tailPaths follows every file discover returns, looking again every
+// rediscoverPolls polls. Files found after the first look are new, so
+// they are read from the start.
+func tailPaths(ctx context.Context, discover func() []string) <-chan Event {
+	ch := make(chan Event, 100)
+	tailers := make(map[string]*tailer)
+	var order []string
+	for _, path := range discover() {
+		t := &tailer{path: path}
+		t.open(true) // Before returning, so nothing written after the call is missed
+		tailers[path] = t
+		order = append(order, path)
+	}
+	go func() {
+		defer close(ch)
+		defer func() {
+			for _, t := range tailers {
+				t.close()
+			}
+		}()
+
+		ticker := time.NewTicker(TailInterval)
+		defer ticker.Stop()
+		for polls := 1; ; polls++ {
+			var batch []Event
+			for _, path := range order {
+				batch = append(batch, tailers[path].poll()...)
+			}
+			if len(order) > 1 {
+				sortByTime(batch)
+			}
+			for _, e := range batch {
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if polls%rediscoverPolls == 0 {
+				for _, path := range discover() {
+					if tailers[path] == nil {
+						tailers[path] = &tailer{path: path}
+						order = append(order, path)
+					}
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+// tailer tracks one followed file across polls.
+type tailer struct {
+	path    string
+	file    *os.File
+	info    os.FileInfo
+	offset  int64
+	partial []byte
+}
+
+// open (re)opens the file, at its end if atEnd, else at its start.
+func (t *tailer) open(atEnd bool) {
+	t.close()
+	f, err := os.Open(t.path) //nolint:gosec // G304: path is the configured events log
+	if err != nil {
+		return
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return
+	}
+	t.file, t.info, t.offset = f, info, 0
+	if atEnd {
+		t.offset = info.Size()
+	}
+}
+
+func (t *tailer) close() {
+	if t.file != nil {
+		_ = t.file.Close()
+		t.file = nil
+	}
+	t.partial = nil
+}
+
+// poll returns the complete events written since the last poll.
+func (t *tailer) poll() []Event {
+	cur, err := os.Stat(t.path)
+	switch {
+	case err != nil:
+		return nil // Missing (mid-rotation or not yet created); keep waiting
+	case t.file == nil, !os.SameFile(cur, t.info), cur.Size() < t.offset:
+		// New, rotated, or truncated: everything in it is unread. Finish
+		// a rotated-away file first; a truncated one has nothing left.
+		var out []Event
+		if t.file != nil && !os.SameFile(cur, t.info) {
+			out = t.read()
+		}
+		t.open(false)
+		if t.file == nil {
+			return out
+		}
+		return append(out, t.read()...)
+	}
+	return t.read()
+}
+
+// read parses the complete lines between the offset and the file's end.
+func (t *tailer) read() []Event {
+	if _, err := t.file.Seek(t.offset, io.SeekStart); err != nil {
+		return nil
+	}
+	data, err := io.ReadAll(t.file)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	t.offset += int64(len(data))
+	data = append(t.partial, data...)
+
+	end := bytes.LastIndexByte(data, '\n')
+	t.partial = append([]byte(nil), data[end+1:]...)
+	var out []Event
+	scanner := bufio.NewScanner(bytes.NewReader(data[:end+1]))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
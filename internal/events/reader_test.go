@@ -0,0 +1,108 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeEvents(t *testing.T, path string, evts []Event) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, e := range evts {
+		if err := enc.Encode(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadFileSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".events.jsonl")
+	writeEvents(t, path, sampleEvents())
+
+	since := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	got, err := ReadFileSince(path, since, Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Type != TypeNudge {
+		t.Errorf("since %s: got %+v", since, got)
+	}
+
+	for _, tt := range []struct {
+		filter Filter
+		want   int
+	}{
+		{Filter{}, 2},
+		{Filter{FeedOnly: true}, 1},
+		{Filter{Actor: "gastown/"}, 1},
+		{Filter{Actor: "gastown"}, 0},
+		{Filter{Types: []string{TypeSling, TypeDone}}, 1},
+	} {
+		got, err := ReadFileSince(path, time.Time{}, tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("%+v matched %d events, want %d", tt.filter, len(got), tt.want)
+		}
+	}
+}
+
+func TestTailFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".events.jsonl")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The file doesn't exist yet; Tail waits for it.
+	ch := TailFile(ctx, path)
+	next := func() Event {
+		t.Helper()
+		select {
+		case e := <-ch:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+			return Event{}
+		}
+	}
+
+	writeEvents(t, path, []Event{{Type: "first"}})
+	if e := next(); e.Type != "first" {
+		t.Errorf("got %q, want first", e.Type)
+	}
+
+	// A partial line is held back until it is finished.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"type":"spl`)
+	time.Sleep(2 * TailInterval)
+	_, _ = f.WriteString("it\"}\n")
+	_ = f.Close()
+	if e := next(); e.Type != "split" {
+		t.Errorf("got %q, want split", e.Type)
+	}
+
+	// Rotation: the log is replaced by a new, shorter file.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	writeEvents(t, path, []Event{{Type: "rotated"}})
+	if e := next(); e.Type != "rotated" {
+		t.Errorf("got %q, want rotated", e.Type)
+	}
+
+	cancel()
+	for range ch {
+	}
+}
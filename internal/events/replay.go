@@ -0,0 +1,197 @@
+package events
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// ErrStopReplay may be returned by a Replay handler to end the replay
+// early without error.
+var ErrStopReplay = errors.New("stop replay")
+
+// Replay feeds the current town's events logged in [from, to) to handler,
+// oldest first, including those in rotated segments and rig logs. A zero
+// from or to leaves that end open. Replay stops at the handler's first
+// error and returns it (nil for ErrStopReplay).
+func Replay(from, to time.Time, handler func(Event) error) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	paths := LogPaths(townRoot)
+	if len(paths) == 1 {
+		return ReplayFile(paths[0], from, to, handler)
+	}
+	// Rig logs must be merged, so read them whole rather than streaming
+	evts, err := ReadTownSince(townRoot, from, Filter{})
+	if err != nil {
+		return err
+	}
+	for _, e := range evts {
+		if !to.IsZero() {
+			if ts, err := time.Parse(time.RFC3339, e.Timestamp); err != nil || !ts.Before(to) {
+				continue
+			}
+		}
+		if err := handler(e); err != nil {
+			if errors.Is(err, ErrStopReplay) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayFile is Replay for an explicit events file. Events whose timestamp
+// can't be parsed are skipped unless the range is fully open.
+func ReplayFile(path string, from, to time.Time, handler func(Event) error) error {
+	err := scanHistory(path, from, func(e Event) error {
+		if !from.IsZero() || !to.IsZero() {
+			ts, err := time.Parse(time.RFC3339, e.Timestamp)
+			if err != nil || (!from.IsZero() && ts.Before(from)) || (!to.IsZero() && !ts.Before(to)) {
+				return nil
+			}
+		}
+		return handler(e)
+	})
+	if errors.Is(err, ErrStopReplay) {
+		return nil
+	}
+	return err
+}
+
+// Hold is a span of time during which an agent held a bead.
+type Hold struct {
+	Bead   string
+	Holder string
+	From   time.Time
+	To     time.Time // Zero if still held when the replay ended
+	Began  string    // Event type that started the hold (sling or hook)
+	Ended  string    // Event type that ended it (unhook, done, auto_released, or a re-sling/hook); empty if still held
+}
+
+// Held reports whether the hold covers t.
+func (h Hold) Held(t time.Time) bool {
+	return !t.Before(h.From) && (h.To.IsZero() || t.Before(h.To))
+}
+
+// Holdings projects "who held which bead when" from the event stream.
+// Feed it events in order with Apply, typically as a Replay handler:
+//
+//	h := events.NewHoldings()
+//	err := events.Replay(from, to, h.Apply)
+//
+// Slings and hooks start a hold (ending any previous holder's); unhooks,
+// dones, and automatic releases end it.
+type Holdings struct {
+	holds []Hold
+	open  map[string]int // Bead → index of its current hold
+}
+
+// NewHoldings returns an empty projection.
+func NewHoldings() *Holdings {
+	return &Holdings{open: make(map[string]int)}
+}
+
+// Apply updates the projection with one event. Events that don't change
+// who holds a bead are ignored. It never fails; the error return lets it
+// be passed to Replay directly.
+func (h *Holdings) Apply(e Event) error {
+	ts, err := time.Parse(time.RFC3339, e.Timestamp)
+	if err != nil {
+		return nil
+	}
+	switch e.Type {
+	case TypeSling:
+		var p SlingEvent
+		if Decode(e, &p) == nil && p.Bead != "" && p.Target != "" {
+			h.take(p.Bead, p.Target, e.Type, ts)
+		}
+	case TypeHook:
+		var p HookEvent
+		if Decode(e, &p) == nil && p.Bead != "" && e.Actor != "" {
+			h.take(p.Bead, e.Actor, e.Type, ts)
+		}
+	case TypeUnhook, TypeDone, TypeAutoReleased:
+		var p HookEvent // All three carry the bead under "bead"
+		if Decode(e, &p) == nil && p.Bead != "" {
+			h.release(p.Bead, e.Type, ts)
+		}
+	}
+	return nil
+}
+
+// take gives bead to holder, ending the current hold unless it is the
+// same holder's (a hook right after a sling continues the sling's hold).
+func (h *Holdings) take(bead, holder, eventType string, at time.Time) {
+	if i, ok := h.open[bead]; ok {
+		if h.holds[i].Holder == holder {
+			return
+		}
+		h.release(bead, eventType, at)
+	}
+	h.open[bead] = len(h.holds)
+	h.holds = append(h.holds, Hold{Bead: bead, Holder: holder, From: at, Began: eventType})
+}
+
+func (h *Holdings) release(bead, eventType string, at time.Time) {
+	i, ok := h.open[bead]
+	if !ok {
+		return
+	}
+	h.holds[i].To, h.holds[i].Ended = at, eventType
+	delete(h.open, bead)
+}
+
+// Holds returns every hold, in the order they began.
+func (h *Holdings) Holds() []Hold {
+	return append([]Hold(nil), h.holds...)
+}
+
+// History returns a bead's holds, in the order they began.
+func (h *Holdings) History(bead string) []Hold {
+	var out []Hold
+	for _, hold := range h.holds {
+		if hold.Bead == bead {
+			out = append(out, hold)
+		}
+	}
+	return out
+}
+
+// HolderAt returns who held bead at t, or "" if nobody did.
+func (h *Holdings) HolderAt(bead string, t time.Time) string {
+	for _, hold := range h.History(bead) {
+		if hold.Held(t) {
+			return hold.Holder
+		}
+	}
+	return ""
+}
+
+// Current returns each bead still held when the replay ended, mapped to
+// its holder. Comparing it with beads' assignees shows what a crash left
+// inconsistent.
+func (h *Holdings) Current() map[string]string {
+	out := make(map[string]string, len(h.open))
+	for bead, i := range h.open {
+		out[bead] = h.holds[i].Holder
+	}
+	return out
+}
+
+// HeldBy returns the beads holder held at t, sorted.
+func (h *Holdings) HeldBy(holder string, t time.Time) []string {
+	var out []string
+	for _, hold := range h.holds {
+		if hold.Holder == holder && hold.Held(t) {
+			out = append(out, hold.Bead)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
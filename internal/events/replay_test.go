@@ -0,0 +1,95 @@
+package events
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func at(minute int) time.Time {
+	return time.Date(2026, 3, 1, 12, minute, 0, 0, time.UTC)
+}
+
+func ev(minute int, typ, actor string, payload map[string]interface{}) Event {
+	return Event{Timestamp: at(minute).Format(time.RFC3339), Type: typ, Actor: actor, Payload: payload}
+}
+
+func TestReplayFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".events.jsonl")
+	writeEvents(t, path, []Event{
+		ev(0, TypeSling, "mayor", SlingPayload("gt-1", "gastown/polecats/nux")),
+		ev(5, TypeMail, "mayor", MailPayload("nux", "hi")),
+		ev(10, TypeDone, "gastown/polecats/nux", DonePayload("gt-1", "polecat/nux")),
+		{Timestamp: "garbage", Type: TypeMail},
+	})
+
+	var types []string
+	collect := func(e Event) error { types = append(types, e.Type); return nil }
+	if err := ReplayFile(path, at(5), at(10), collect); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(types, []string{TypeMail}) {
+		t.Errorf("[5, 10) replayed %v", types)
+	}
+
+	types = nil
+	if err := ReplayFile(path, time.Time{}, time.Time{}, collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(types) != 4 {
+		t.Errorf("open range replayed %v", types)
+	}
+
+	n := 0
+	err := ReplayFile(path, time.Time{}, time.Time{}, func(Event) error {
+		n++
+		return ErrStopReplay
+	})
+	if err != nil || n != 1 {
+		t.Errorf("ErrStopReplay: err %v after %d events", err, n)
+	}
+	boom := errors.New("boom")
+	if err := ReplayFile(path, time.Time{}, time.Time{}, func(Event) error { return boom }); err != boom {
+		t.Errorf("handler error = %v", err)
+	}
+}
+
+func TestHoldings(t *testing.T) {
+	nux, toast := "gastown/polecats/nux", "gastown/polecats/toast"
+	h := NewHoldings()
+	for _, e := range []Event{
+		ev(0, TypeSling, "mayor", SlingPayload("gt-1", nux)),
+		ev(1, TypeHook, nux, HookPayload("gt-1")), // Same holder: no new hold
+		ev(2, TypeSling, "mayor", SlingPayload("gt-2", nux)),
+		ev(5, TypeSling, "mayor", SlingPayload("gt-1", toast)), // Re-sling moves it
+		ev(8, TypeDone, toast, DonePayload("gt-1", "polecat/toast")),
+		ev(9, TypeAutoReleased, "daemon", map[string]interface{}{"rig": "gastown", "bead": "gt-3", "agent": nux}), // Never held
+	} {
+		_ = h.Apply(e)
+	}
+
+	if got := h.History("gt-1"); len(got) != 2 ||
+		got[0].Holder != nux || !got[0].To.Equal(at(5)) || got[0].Ended != TypeSling ||
+		got[1].Holder != toast || !got[1].To.Equal(at(8)) || got[1].Ended != TypeDone {
+		t.Errorf("gt-1 history = %+v", got)
+	}
+	for _, tt := range []struct {
+		bead   string
+		minute int
+		want   string
+	}{
+		{"gt-1", 0, nux}, {"gt-1", 4, nux}, {"gt-1", 5, toast}, {"gt-1", 8, ""}, {"gt-2", 30, nux},
+	} {
+		if got := h.HolderAt(tt.bead, at(tt.minute)); got != tt.want {
+			t.Errorf("HolderAt(%s, :%02d) = %q, want %q", tt.bead, tt.minute, got, tt.want)
+		}
+	}
+	if got := h.HeldBy(nux, at(3)); !reflect.DeepEqual(got, []string{"gt-1", "gt-2"}) {
+		t.Errorf("HeldBy(nux, :03) = %v", got)
+	}
+	if got := h.Current(); !reflect.DeepEqual(got, map[string]string{"gt-2": nux}) {
+		t.Errorf("Current = %v", got)
+	}
+}
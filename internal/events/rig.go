@@ -0,0 +1,167 @@
+package events
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Rig returns the rig an event belongs to: its payload's "rig", else the
+// first segment of a rig-scoped actor ("gastown/polecats/nux" → "gastown").
+// Empty for town-level events. The name is not checked against the town's
+// registered rigs.
+func (e Event) Rig() string {
+	if rig, ok := e.Payload["rig"].(string); ok && rig != "" {
+		return rig
+	}
+	if rig, _, ok := strings.Cut(e.Actor, "/"); ok {
+		return rig
+	}
+	return ""
+}
+
+// RigPath returns the location of a rig's events log when events are
+// logged per rig (see config.EventLogConfig.PerRig).
+func RigPath(townRoot, rig string) string {
+	return filepath.Join(townRoot, rig, EventsFile)
+}
+
+// LogPaths returns every events log of a town: the town log first (whether
+// or not it exists yet), then each rig log that exists or has rotated
+// segments, sorted by path.
+func LogPaths(townRoot string) []string {
+	town := Path(townRoot)
+	paths := []string{town}
+	seen := map[string]bool{town: true}
+
+	rigLogs, _ := filepath.Glob(filepath.Join(globEscape(townRoot), "*", EventsFile))
+	segments, _ := filepath.Glob(filepath.Join(globEscape(townRoot), "*", EventsFile+".*.gz"))
+	for _, m := range segments {
+		rigLogs = append(rigLogs, filepath.Join(filepath.Dir(m), EventsFile))
+	}
+	sort.Strings(rigLogs)
+	for _, p := range rigLogs {
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// perRigTowns caches each town's event_log.per_rig setting for the write path.
+var perRigTowns sync.Map // townRoot → bool
+
+func perRig(townRoot string) bool {
+	if v, ok := perRigTowns.Load(townRoot); ok {
+		return v.(bool)
+	}
+	on := false
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil && settings.EventLog != nil {
+		on = settings.EventLog.PerRig
+	}
+	perRigTowns.Store(townRoot, on)
+	return on
+}
+
+// logPathFor returns where the file sink writes event: its rig's log if
+// the town logs per rig and the rig is registered, else the town log.
+func logPathFor(townRoot string, event Event) string {
+	if rig := event.Rig(); rig != "" && perRig(townRoot) && registeredRig(townRoot, rig) {
+		return RigPath(townRoot, rig)
+	}
+	return Path(townRoot)
+}
+
+// registeredRig reports whether rig is in the town's rigs.json. Actors like
+// "deacon/dogs/alpha" look rig-scoped but belong to the town.
+func registeredRig(townRoot, rig string) bool {
+	rigs, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return false
+	}
+	_, ok := rigs.Rigs[rig]
+	return ok
+}
+
+// ReadTown returns every event in a town's logs, merged oldest first.
+func ReadTown(townRoot string) ([]Event, error) {
+	return ReadTownSince(townRoot, time.Time{}, Filter{})
+}
+
+// ReadTownSince is ReadFileSince across all of a town's logs (see
+// LogPaths), merged oldest first. Events with equal timestamps keep the
+// order of LogPaths.
+func ReadTownSince(townRoot string, t time.Time, filter Filter) ([]Event, error) {
+	var logs [][]Event
+	for _, path := range LogPaths(townRoot) {
+		evts, err := ReadFileSince(path, t, filter)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, evts)
+	}
+	return mergeLogs(logs), nil
+}
+
+// TailTown follows all of a town's logs, including rig logs created after
+// it starts, like TailFile. Events arriving in the same poll are sent in
+// timestamp order.
+func TailTown(ctx context.Context, townRoot string) <-chan Event {
+	return tailPaths(ctx, func() []string { return LogPaths(townRoot) })
+}
+
+// mergeLogs merges logs that are each in chronological order into one.
+// An unparseable timestamp sorts as the zero time.
+func mergeLogs(logs [][]Event) []Event {
+	switch len(logs) {
+	case 0:
+		return nil
+	case 1:
+		return logs[0]
+	}
+
+	times := make([][]time.Time, len(logs))
+	n := 0
+	for i, log := range logs {
+		times[i] = make([]time.Time, len(log))
+		for j, e := range log {
+			times[i][j], _ = time.Parse(time.RFC3339, e.Timestamp)
+		}
+		n += len(log)
+	}
+
+	out := make([]Event, 0, n)
+	next := make([]int, len(logs))
+	for len(out) < n {
+		best := -1
+		for i := range logs {
+			if next[i] == len(logs[i]) {
+				continue
+			}
+			if best < 0 || times[i][next[i]].Before(times[best][next[best]]) {
+				best = i
+			}
+		}
+		out = append(out, logs[best][next[best]])
+		next[best]++
+	}
+	return out
+}
+
+// sortByTime orders a batch of events by timestamp, keeping the order of
+// equal ones. An unparseable timestamp sorts as the zero time.
+func sortByTime(evts []Event) {
+	times := make(map[string]time.Time, len(evts))
+	for _, e := range evts {
+		times[e.Timestamp], _ = time.Parse(time.RFC3339, e.Timestamp)
+	}
+	sort.SliceStable(evts, func(i, j int) bool {
+		return times[evts[i].Timestamp].Before(times[evts[j].Timestamp])
+	})
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// perRigTown creates a town with one registered rig, gastown, that logs
+// events per rig.
+func perRigTown(t *testing.T) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	files := map[string]string{
+		"mayor/town.json":      `{"name":"test"}`,
+		"mayor/rigs.json":      `{"version":1,"rigs":{"gastown":{"git_url":"x"}}}`,
+		"settings/config.json": `{"event_log":{"per_rig":true}}`,
+	}
+	for name, data := range files {
+		path := filepath.Join(townRoot, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return townRoot
+}
+
+func TestEventRig(t *testing.T) {
+	tests := []struct {
+		event Event
+		want  string
+	}{
+		{Event{Actor: "gastown/polecats/nux"}, "gastown"},
+		{Event{Actor: "mayor", Payload: map[string]interface{}{"rig": "beads"}}, "beads"},
+		{Event{Actor: "mayor"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.event.Rig(); got != tt.want {
+			t.Errorf("%+v: Rig() = %q, want %q", tt.event, got, tt.want)
+		}
+	}
+}
+
+func TestWritePerRig(t *testing.T) {
+	townRoot := perRigTown(t)
+	t.Chdir(townRoot)
+
+	for _, e := range []Event{
+		ev(0, TypeSling, "mayor", SlingPayload("gt-1", "gastown/polecats/nux")),
+		ev(1, TypeHook, "gastown/polecats/nux", HookPayload("gt-1")),
+		ev(2, TypeMail, "deacon/dogs/alpha", MailPayload("mayor", "hi")), // Not a rig
+		ev(3, TypeDone, "gastown/polecats/nux", DonePayload("gt-1", "polecat/nux")),
+	} {
+		if err := write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	town, _ := ReadFile(Path(townRoot))
+	rig, _ := ReadFile(RigPath(townRoot, "gastown"))
+	if len(town) != 2 || len(rig) != 2 {
+		t.Fatalf("town log has %d events, rig log %d; want 2 and 2", len(town), len(rig))
+	}
+
+	want := []string{Path(townRoot), RigPath(townRoot, "gastown")}
+	if got := LogPaths(townRoot); !reflect.DeepEqual(got, want) {
+		t.Errorf("LogPaths = %v, want %v", got, want)
+	}
+
+	merged, err := ReadTown(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, e := range merged {
+		types = append(types, e.Type)
+	}
+	if want := []string{TypeSling, TypeHook, TypeMail, TypeDone}; !reflect.DeepEqual(types, want) {
+		t.Errorf("merged = %v, want %v", types, want)
+	}
+
+	hooks, err := ReadTownSince(townRoot, at(1), Filter{Actor: "gastown/"})
+	if err != nil || len(hooks) != 2 {
+		t.Errorf("ReadTownSince = %v, %v; want the hook and done", hooks, err)
+	}
+}
+
+func TestMergeLogs(t *testing.T) {
+	a := []Event{ev(0, "a0", "", nil), ev(2, "a2", "", nil), ev(2, "a2b", "", nil)}
+	b := []Event{ev(1, "b1", "", nil), ev(2, "b2", "", nil), ev(3, "b3", "", nil)}
+	var types []string
+	for _, e := range mergeLogs([][]Event{a, nil, b}) {
+		types = append(types, e.Type)
+	}
+	// Ties keep log order, and each log's own order
+	if want := []string{"a0", "b1", "a2", "a2b", "b2", "b3"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("merged = %v, want %v", types, want)
+	}
+}
+
+func TestTailTownFindsNewRigLogs(t *testing.T) {
+	townRoot := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := TailTown(ctx, townRoot)
+
+	if err := os.MkdirAll(filepath.Join(townRoot, "gastown"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeEvents(t, RigPath(townRoot, "gastown"), []Event{{Type: "rig"}})
+	writeEvents(t, Path(townRoot), []Event{{Type: "town"}})
+
+	seen := make(map[string]bool)
+	deadline := time.After(5 * time.Second)
+	for len(seen) < 2 {
+		select {
+		case e := <-ch:
+			seen[e.Type] = true
+		case <-deadline:
+			t.Fatalf("saw only %v", seen)
+		}
+	}
+}
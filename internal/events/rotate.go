@@ -0,0 +1,274 @@
+package events
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/flock"
+)
+
+// segmentStamp is the time layout in rotated segment names:
+// <events file>.<stamp>.gz, stamped with the rotation time (UTC).
+const segmentStamp = "20060102-150405.000"
+
+// RotationPolicy says when the events log is rotated and how long rotated
+// segments are kept.
+type RotationPolicy struct {
+	MaxSize   int64         // Rotate once the log exceeds this many bytes; 0 disables
+	Daily     bool          // Rotate when the log was last written on an earlier UTC day
+	Retention time.Duration // Delete segments rotated longer ago than this; 0 keeps all
+}
+
+// LoadRotationPolicy reads a town's event_log settings.
+func LoadRotationPolicy(townRoot string) (RotationPolicy, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return RotationPolicy{}, err
+	}
+	retention, err := settings.EventLog.RetentionWindow()
+	if err != nil {
+		return RotationPolicy{}, err
+	}
+	p := RotationPolicy{MaxSize: settings.EventLog.MaxBytes(), Retention: retention}
+	if settings.EventLog != nil {
+		p.Daily = settings.EventLog.Daily
+	}
+	return p, nil
+}
+
+// policies caches rotation policies per town for the write path.
+var policies sync.Map // townRoot → RotationPolicy
+
+func cachedPolicy(townRoot string) RotationPolicy {
+	if p, ok := policies.Load(townRoot); ok {
+		return p.(RotationPolicy)
+	}
+	p, err := LoadRotationPolicy(townRoot)
+	if err != nil {
+		p = RotationPolicy{MaxSize: (*config.EventLogConfig)(nil).MaxBytes(), Retention: config.DefaultEventLogRetention}
+	}
+	policies.Store(townRoot, p)
+	return p
+}
+
+// Rotate moves the log at path into a gzipped segment beside it if policy
+// calls for it, then prunes segments past the retention window. It holds
+// the log's append lock throughout, so no event is lost or split. Returns
+// the new segment's path, or "" if nothing was rotated (in which case
+// nothing is pruned either; see PruneSegments).
+func Rotate(path string, policy RotationPolicy, now time.Time) (string, error) {
+	if !policy.due(path, now) {
+		return "", nil
+	}
+
+	var segment string
+	err := flock.With(path, flock.DefaultTimeout, func() error {
+		if !policy.due(path, now) { // Another process got here first
+			return nil
+		}
+		var err error
+		segment, err = rotateLocked(path, now)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return segment, PruneSegments(path, policy.Retention, now)
+}
+
+// due reports whether the log at path should be rotated now.
+func (p RotationPolicy) due(path string, now time.Time) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() == 0 {
+		return false
+	}
+	if p.MaxSize > 0 && info.Size() > p.MaxSize {
+		return true
+	}
+	if p.Daily {
+		y1, m1, d1 := info.ModTime().UTC().Date()
+		y2, m2, d2 := now.UTC().Date()
+		return y1 != y2 || m1 != m2 || d1 != d2
+	}
+	return false
+}
+
+// rotateLocked moves the log aside and compresses it into a new segment;
+// the next append starts a fresh log. The caller holds the log's lock.
+func rotateLocked(path string, now time.Time) (string, error) {
+	segment := segmentPath(path, now)
+	if _, err := os.Stat(segment); err == nil {
+		return "", fmt.Errorf("segment %s already exists", segment)
+	}
+
+	// Rename rather than truncate, so tailers see a new file and can
+	// finish reading the old one
+	moved := segment + ".src"
+	if err := os.Rename(path, moved); err != nil {
+		return "", err
+	}
+	src, err := os.Open(moved) //nolint:gosec // G304: path is the configured events log
+	if err != nil {
+		_ = os.Rename(moved, path)
+		return "", err
+	}
+	defer src.Close()
+
+	tmp := segment + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // G302: events are non-sensitive operational data
+	if err != nil {
+		_ = os.Rename(moved, path)
+		return "", fmt.Errorf("creating segment: %w", err)
+	}
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, segment)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		_ = os.Rename(moved, path) // We hold the lock, so nothing has appended to a new log
+		return "", fmt.Errorf("writing segment: %w", err)
+	}
+	_ = os.Remove(moved)
+	return segment, nil
+}
+
+func segmentPath(path string, at time.Time) string {
+	return path + "." + at.UTC().Format(segmentStamp) + ".gz"
+}
+
+// Segments returns the rotated segments of the log at path, oldest first.
+func Segments(path string) ([]string, error) {
+	matches, err := filepath.Glob(globEscape(path) + ".*.gz")
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, m := range matches {
+		if _, ok := segmentTime(path, m); ok {
+			out = append(out, m)
+		}
+	}
+	sort.Strings(out) // Stamps sort chronologically
+	return out, nil
+}
+
+// segmentTime parses the rotation time from a segment name.
+func segmentTime(path, segment string) (time.Time, bool) {
+	stamp := strings.TrimSuffix(strings.TrimPrefix(segment, path+"."), ".gz")
+	if len(stamp) < len(segmentStamp) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(segmentStamp, stamp[:len(segmentStamp)])
+	return t, err == nil
+}
+
+// PruneSegments deletes segments rotated more than retention before now.
+func PruneSegments(path string, retention time.Duration, now time.Time) error {
+	if retention <= 0 {
+		return nil
+	}
+	segments, err := Segments(path)
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-retention)
+	var errs []error
+	for _, s := range segments {
+		if t, ok := segmentTime(path, s); ok && t.Before(cutoff) {
+			if err := os.Remove(s); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// OpenHistory returns the whole event history of the log at path: its
+// rotated segments, oldest first, followed by the live file. If neither
+// exists the error satisfies os.IsNotExist.
+func OpenHistory(path string) (io.ReadCloser, error) {
+	return openHistory(path, time.Time{})
+}
+
+// openHistory is OpenHistory skipping segments rotated before since,
+// which hold only older events.
+func openHistory(path string, since time.Time) (io.ReadCloser, error) {
+	segments, err := Segments(path)
+	if err != nil {
+		return nil, err
+	}
+	h := &history{}
+	for _, s := range segments {
+		if t, ok := segmentTime(path, s); ok && !since.IsZero() && t.Before(since) {
+			continue
+		}
+		f, err := os.Open(s) //nolint:gosec // G304: segment of the configured events log
+		if err != nil {
+			_ = h.Close()
+			return nil, err
+		}
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			_ = f.Close()
+			continue // Skip a corrupt segment rather than losing all history
+		}
+		h.closers = append(h.closers, zr, f)
+		h.readers = append(h.readers, zr)
+	}
+
+	live, err := os.Open(path) //nolint:gosec // G304: path is the configured events log
+	switch {
+	case err == nil:
+		h.closers = append(h.closers, live)
+		h.readers = append(h.readers, live)
+	case !os.IsNotExist(err) || len(segments) == 0:
+		_ = h.Close()
+		return nil, err
+	}
+	h.Reader = io.MultiReader(h.readers...)
+	return h, nil
+}
+
+// history chains segment and live readers.
+type history struct {
+	io.Reader
+	readers []io.Reader
+	closers []io.Closer
+}
+
+func (h *history) Close() error {
+	var errs []error
+	for _, c := range h.closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// globEscape escapes glob metacharacters in a literal path.
+func globEscape(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
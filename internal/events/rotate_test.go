@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotateAndReadHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".events.jsonl")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	policy := RotationPolicy{MaxSize: 10, Retention: 30 * 24 * time.Hour}
+
+	if seg, err := Rotate(path, policy, now); err != nil || seg != "" {
+		t.Fatalf("Rotate(missing log) = %q, %v", seg, err)
+	}
+
+	writeEvents(t, path, []Event{{Type: "a", Timestamp: "2026-03-01T11:00:00Z"}})
+	seg, err := Rotate(path, policy, now)
+	if err != nil || seg == "" {
+		t.Fatalf("Rotate = %q, %v; want a segment", seg, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("live log still present after rotation: %v", err)
+	}
+	writeEvents(t, path, []Event{{Type: "b", Timestamp: "2026-03-01T13:00:00Z"}})
+
+	all, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].Type != "a" || all[1].Type != "b" {
+		t.Errorf("ReadFile across segments = %+v", all)
+	}
+	recent, err := ReadFileSince(path, now.Add(time.Minute), Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recent) != 1 || recent[0].Type != "b" {
+		t.Errorf("ReadFileSince = %+v, want only b", recent)
+	}
+
+	// A month later the first segment falls out of retention.
+	later := now.Add(31 * 24 * time.Hour)
+	if _, err := Rotate(path, policy, later); err != nil {
+		t.Fatal(err)
+	}
+	segments, err := Segments(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 || segments[0] == seg {
+		t.Errorf("segments after retention = %v", segments)
+	}
+}
+
+func TestRotateDaily(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".events.jsonl")
+	writeEvents(t, path, []Event{{Type: "a"}})
+	yesterday := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, yesterday, yesterday); err != nil {
+		t.Fatal(err)
+	}
+	policy := RotationPolicy{Daily: true}
+
+	if seg, _ := Rotate(path, policy, yesterday.Add(30*time.Minute)); seg != "" {
+		t.Errorf("rotated within the same day: %s", seg)
+	}
+	if seg, err := Rotate(path, policy, yesterday.Add(2*time.Hour)); err != nil || seg == "" {
+		t.Errorf("Rotate next day = %q, %v; want a segment", seg, err)
+	}
+}
+
+func TestTailFileFollowsRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".events.jsonl")
+	writeEvents(t, path, []Event{{Type: "old"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := TailFile(ctx, path)
+
+	// Written and rotated away before the tailer polls: still delivered.
+	writeEvents(t, path, []Event{{Type: "last"}})
+	if _, err := Rotate(path, RotationPolicy{MaxSize: 1}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	writeEvents(t, path, []Event{{Type: "first"}})
+
+	for _, want := range []string{"last", "first"} {
+		select {
+		case e := <-ch:
+			if e.Type != want {
+				t.Errorf("got %q, want %q", e.Type, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+}
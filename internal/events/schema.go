@@ -0,0 +1,209 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/flock"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Kind is the JSON type a payload value must have.
+type Kind string
+
+// Payload value kinds.
+const (
+	KindString Kind = "string"
+	KindNumber Kind = "number"
+	KindBool   Kind = "bool"
+	KindList   Kind = "list"
+	KindObject Kind = "object"
+)
+
+// Schema describes the payload an event type must carry. Keys not listed
+// are allowed, so emitters can attach extra context.
+type Schema struct {
+	Required map[string]Kind
+}
+
+// SchemaOf derives a schema from a typed payload struct: every field
+// without omitempty is required, with the kind of its Go type.
+func SchemaOf(payload interface{}) Schema {
+	rt := reflect.TypeOf(payload)
+	if rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	s := Schema{Required: make(map[string]Kind)}
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" || strings.Contains(opts, "omitempty") {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Required[name] = kindOfType(f.Type)
+	}
+	return s
+}
+
+// schemas is the registry consulted by Validate.
+var schemas = struct {
+	sync.RWMutex
+	m map[string]Schema
+}{m: map[string]Schema{
+	TypeSling:            SchemaOf(SlingEvent{}),
+	TypeSlingBatch:       SchemaOf(SlingBatchEvent{}),
+	TypeHook:             SchemaOf(HookEvent{}),
+	TypeUnhook:           SchemaOf(HookEvent{}),
+	TypeHandoff:          SchemaOf(HandoffEvent{}),
+	TypeDone:             SchemaOf(DoneEvent{}),
+	TypeMail:             SchemaOf(MailEvent{}),
+	TypeMailRead:         SchemaOf(MailReadEvent{}),
+	TypeSpawn:            SchemaOf(SpawnEvent{}),
+	TypeKill:             SchemaOf(KillEvent{}),
+	TypeNudge:            SchemaOf(NudgeEvent{}),
+	TypeBoot:             SchemaOf(BootEvent{}),
+	TypeHalt:             SchemaOf(HaltEvent{}),
+	TypeSessionStart:     SchemaOf(SessionEvent{}),
+	TypeSessionEnd:       SchemaOf(SessionEvent{}),
+	TypePatrolStarted:    SchemaOf(PatrolEvent{}),
+	TypePatrolComplete:   SchemaOf(PatrolEvent{}),
+	TypePolecatChecked:   SchemaOf(PolecatCheckEvent{}),
+	TypePolecatNudged:    SchemaOf(NudgeEvent{}),
+	TypeEscalationSent:   SchemaOf(EscalationEvent{}),
+	TypeNudgeEscalated:   SchemaOf(NudgeEscalationEvent{}),
+	TypeReopened:         SchemaOf(ReworkEvent{}),
+	TypeChangesRequested: SchemaOf(ReworkEvent{}),
+	TypeSyncDrift:        SchemaOf(SyncDriftEvent{}),
+	TypeSyncConflict:     SchemaOf(SyncDriftEvent{}),
+	TypeSyncRecovered:    SchemaOf(SyncDriftEvent{}),
+	TypeSlingFailed:      SchemaOf(SlingFailedEvent{}),
+	TypeMergeConflict:    SchemaOf(MergeConflictEvent{}),
+	TypeAgentCrash:       SchemaOf(AgentCrashEvent{}),
+	// Merge queue events are left out: gt activity emit builds their
+	// payloads from whichever flags were given.
+}}
+
+// RegisterSchema sets the payload schema for an event type, replacing any
+// previous one.
+func RegisterSchema(eventType string, s Schema) {
+	schemas.Lock()
+	defer schemas.Unlock()
+	schemas.m[eventType] = s
+}
+
+// LookupSchema returns the payload schema registered for an event type.
+func LookupSchema(eventType string) (Schema, bool) {
+	schemas.RLock()
+	defer schemas.RUnlock()
+	s, ok := schemas.m[eventType]
+	return s, ok
+}
+
+// SchemaError reports an event whose payload doesn't match its schema.
+type SchemaError struct {
+	Type     string
+	Problems []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("malformed %s event: %s", e.Type, strings.Join(e.Problems, "; "))
+}
+
+// Validate checks an event's payload against the schema registered for its
+// type. Events of unregistered types always pass. Payload values may be Go
+// values (as logged) or decoded JSON (as read back).
+func Validate(e Event) error {
+	s, ok := LookupSchema(e.Type)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(s.Required))
+	for k := range s.Required {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		v, ok := e.Payload[key]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("missing %q", key))
+		case !hasKind(v, s.Required[key]):
+			problems = append(problems, fmt.Sprintf("%q is %T, want %s", key, v, s.Required[key]))
+		}
+	}
+	if len(problems) > 0 {
+		return &SchemaError{Type: e.Type, Problems: problems}
+	}
+	return nil
+}
+
+func kindOfType(t reflect.Type) Kind {
+	switch t.Kind() {
+	case reflect.String:
+		return KindString
+	case reflect.Bool:
+		return KindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return KindNumber
+	case reflect.Slice, reflect.Array:
+		return KindList
+	case reflect.Map, reflect.Struct, reflect.Interface, reflect.Pointer:
+		return KindObject
+	}
+	return ""
+}
+
+// hasKind reports whether v is of kind k. A nil list or object is allowed,
+// as JSON encodes it null.
+func hasKind(v interface{}, k Kind) bool {
+	if v == nil {
+		return k == KindList || k == KindObject
+	}
+	if _, ok := v.(json.Number); ok {
+		return k == KindNumber
+	}
+	return kindOfType(reflect.TypeOf(v)) == k
+}
+
+// RejectsPath returns where a town's malformed events are set aside:
+// beside the events log, e.g. .events.rejects.jsonl.
+func RejectsPath(townRoot string) string {
+	return strings.TrimSuffix(Path(townRoot), ".jsonl") + ".rejects.jsonl"
+}
+
+// Reject is a malformed event as recorded in the rejects file.
+type Reject struct {
+	Timestamp string `json:"ts"`
+	Error     string `json:"error"`
+	Event     Event  `json:"event"`
+}
+
+// reject records a malformed event in the current town's rejects file, so
+// it is kept for debugging without reaching the events log.
+func reject(event Event, cause error) {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return
+	}
+	data, err := json.Marshal(Reject{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Error:     cause.Error(),
+		Event:     event,
+	})
+	if err != nil {
+		return
+	}
+	_ = flock.AppendFile(RejectsPath(townRoot), append(data, '\n'), 0644)
+}
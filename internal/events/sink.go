@@ -0,0 +1,197 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Sink forwards events to somewhere other than the local events log.
+type Sink interface {
+	Send(Event) error
+	Close() error
+}
+
+// Sink dispatch tuning.
+const (
+	SinkQueueSize    = 1024                   // Events buffered per sink before it misses some
+	SinkFlushTimeout = 500 * time.Millisecond // How long StopSinks holds up gt's exit for queued events
+)
+
+// Dispatcher fans a bus's events out to sinks. Each sink has its own queue
+// and goroutine, so a slow or failing sink delays no command and no other
+// sink; it just misses events (see Bus.Dropped) or counts failures.
+type Dispatcher struct {
+	bus   *Bus
+	mu    sync.Mutex
+	sinks []*dispatchedSink
+}
+
+type dispatchedSink struct {
+	name        string
+	sink        Sink
+	unsubscribe func()
+	failures    atomic.Int64
+}
+
+// NewDispatcher creates a dispatcher for bus.
+func NewDispatcher(bus *Bus) *Dispatcher {
+	return &Dispatcher{bus: bus}
+}
+
+// Add subscribes a sink under name. If types is non-empty, only events of
+// those types are sent to it.
+func (d *Dispatcher) Add(name string, sink Sink, types []string) {
+	ds := &dispatchedSink{name: name, sink: sink}
+	ds.unsubscribe = d.bus.Subscribe("sink:"+name, SinkQueueSize, func(e Event) {
+		if len(types) > 0 && !slices.Contains(types, e.Type) {
+			return
+		}
+		if err := sink.Send(e); err != nil {
+			ds.failures.Add(1)
+		}
+	})
+	d.mu.Lock()
+	d.sinks = append(d.sinks, ds)
+	d.mu.Unlock()
+}
+
+// Failures returns how many events the named sink failed to send.
+func (d *Dispatcher) Failures(name string) int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, ds := range d.sinks {
+		if ds.name == name {
+			return ds.failures.Load()
+		}
+	}
+	return 0
+}
+
+// Close unsubscribes every sink, gives queued events up to timeout to be
+// sent, and closes the sinks. Events still queued after that are lost.
+func (d *Dispatcher) Close(timeout time.Duration) error {
+	d.mu.Lock()
+	sinks := d.sinks
+	d.sinks = nil
+	d.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		var errs []error
+		for _, ds := range sinks {
+			ds.unsubscribe() // Waits for the queue to drain
+			errs = append(errs, ds.sink.Close())
+		}
+		done <- errors.Join(errs...)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("event sinks still flushing after %s", timeout)
+	}
+}
+
+// NewSink builds the sink a town config entry describes.
+func NewSink(cfg config.EventSinkConfig) (Sink, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Type {
+	case config.EventSinkWebhook:
+		return NewWebhookSink(cfg.URL, cfg.Headers), nil
+	case config.EventSinkKafka:
+		return NewKafkaSink(cfg.URL, cfg.Topic, cfg.Headers), nil
+	case config.EventSinkSyslog:
+		return NewSyslogSink(cfg.Address)
+	}
+	return nil, fmt.Errorf("unknown event sink type %q", cfg.Type)
+}
+
+// lazySink builds its sink on the first event it is sent. That runs on the
+// dispatcher's goroutine, so a command that emits nothing never connects
+// and none waits on a slow dial (syslog connects when built).
+type lazySink struct {
+	cfg config.EventSinkConfig
+
+	mu   sync.Mutex
+	sink Sink
+	err  error
+}
+
+func (l *lazySink) Send(e Event) error {
+	l.mu.Lock()
+	if l.sink == nil && l.err == nil {
+		l.sink, l.err = NewSink(l.cfg)
+	}
+	sink, err := l.sink, l.err
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return sink.Send(e)
+}
+
+func (l *lazySink) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.sink == nil {
+		return nil
+	}
+	return l.sink.Close()
+}
+
+// sinks is the dispatcher started on the default bus by StartSinks.
+var sinks struct {
+	sync.Mutex
+	d *Dispatcher
+}
+
+// StartSinks attaches a town's configured event sinks to the default bus,
+// once per process. Each sink connects when it is sent its first event.
+// Misconfigured sinks are skipped; their errors are returned together.
+func StartSinks(townRoot string) error {
+	sinks.Lock()
+	defer sinks.Unlock()
+	if sinks.d != nil {
+		return nil
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return err
+	}
+	if len(settings.EventSinks) == 0 {
+		return nil
+	}
+
+	d := NewDispatcher(defaultBus)
+	var errs []error
+	for i, cfg := range settings.EventSinks {
+		if err := cfg.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("event_sinks[%d]: %w", i, err))
+			continue
+		}
+		d.Add(fmt.Sprintf("%d-%s", i, cfg.Type), &lazySink{cfg: cfg}, cfg.Types)
+	}
+	sinks.d = d
+	return errors.Join(errs...)
+}
+
+// StopSinks flushes and detaches the sinks started by StartSinks, waiting
+// at most SinkFlushTimeout. gt calls it before exiting.
+func StopSinks() error {
+	sinks.Lock()
+	d := sinks.d
+	sinks.d = nil
+	sinks.Unlock()
+	if d == nil {
+		return nil
+	}
+	return d.Close(SinkFlushTimeout)
+}
@@ -0,0 +1,206 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SinkTimeout bounds each delivery to a remote sink.
+const SinkTimeout = 5 * time.Second
+
+// httpSink POSTs events as JSON.
+type httpSink struct {
+	url         string
+	contentType string
+	headers     map[string]string
+	body        func(Event) ([]byte, error)
+	client      *http.Client
+}
+
+// NewWebhookSink POSTs each event as a JSON object to url.
+func NewWebhookSink(url string, headers map[string]string) Sink {
+	return &httpSink{
+		url:         url,
+		contentType: "application/json",
+		headers:     headers,
+		body:        func(e Event) ([]byte, error) { return json.Marshal(e) },
+		client:      &http.Client{Timeout: SinkTimeout},
+	}
+}
+
+// NewKafkaSink produces each event to topic through the Kafka REST proxy
+// (v2 API) at proxyURL, keyed by actor so one actor's events stay in order.
+func NewKafkaSink(proxyURL, topic string, headers map[string]string) Sink {
+	type record struct {
+		Key   string `json:"key"`
+		Value Event  `json:"value"`
+	}
+	return &httpSink{
+		url:         strings.TrimSuffix(proxyURL, "/") + "/topics/" + url.PathEscape(topic),
+		contentType: "application/vnd.kafka.json.v2+json",
+		headers:     headers,
+		body: func(e Event) ([]byte, error) {
+			return json.Marshal(map[string][]record{"records": {{Key: e.Actor, Value: e}}})
+		},
+		client: &http.Client{Timeout: SinkTimeout},
+	}
+}
+
+func (s *httpSink) Send(e Event) error {
+	data, err := s.body(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", s.contentType)
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("POST %s: %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// syslogSink writes RFC 5424 messages, one event per message with the
+// event JSON as the body and the event type as MSGID.
+type syslogSink struct {
+	network, addr string
+	hostname      string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// localSyslogSockets are tried in order when no address is configured.
+var localSyslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// NewSyslogSink connects to the syslog server at address ("udp://host:514",
+// "tcp://host:514", or "unix:///dev/log"), or to the local syslog socket
+// if address is empty.
+func NewSyslogSink(address string) (Sink, error) {
+	s := &syslogSink{}
+	s.hostname, _ = os.Hostname()
+	if address == "" {
+		for _, path := range localSyslogSockets {
+			if _, err := os.Stat(path); err == nil {
+				s.network, s.addr = "unix", path
+				break
+			}
+		}
+		if s.addr == "" {
+			return nil, fmt.Errorf("no local syslog socket found")
+		}
+	} else {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, fmt.Errorf("parsing syslog address: %w", err)
+		}
+		s.network, s.addr = u.Scheme, u.Host
+		if u.Scheme == "unix" {
+			s.addr = u.Path
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// connect dials the server; unix sockets are usually datagram sockets.
+// The caller holds s.mu.
+func (s *syslogSink) connect() error {
+	var err error
+	if s.network == "unix" {
+		if s.conn, err = net.DialTimeout("unixgram", s.addr, SinkTimeout); err == nil {
+			return nil
+		}
+	}
+	s.conn, err = net.DialTimeout(s.network, s.addr, SinkTimeout)
+	return err
+}
+
+// syslogPriority is facility local0 with the syslog severity matching the
+// event's level.
+func syslogPriority(e Event) int {
+	const local0 = 16
+	severity := 6 // Informational
+	switch e.Level() {
+	case SeverityDebug:
+		severity = 7
+	case SeverityWarn:
+		severity = 4
+	case SeverityError:
+		severity = 3
+	}
+	return local0*8 + severity
+}
+
+func (s *syslogSink) Send(e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	ts := e.Timestamp
+	if ts == "" {
+		ts = "-"
+	}
+	msgID := e.Type
+	if msgID == "" {
+		msgID = "-"
+	}
+	msg := fmt.Sprintf("<%d>1 %s %s gt %d %s - %s\n", syslogPriority(e), ts, s.hostname, os.Getpid(), msgID, data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			if err := s.connect(); err != nil {
+				return err
+			}
+		}
+		_ = s.conn.SetWriteDeadline(time.Now().Add(SinkTimeout))
+		if _, err = io.WriteString(s.conn, msg); err == nil || attempt > 0 {
+			return err
+		}
+		// The server may have restarted; reconnect once
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
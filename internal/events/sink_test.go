@@ -0,0 +1,186 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	got    []string
+	fail   bool
+	closed bool
+}
+
+func (s *recordingSink) Send(e Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return io.ErrUnexpectedEOF
+	}
+	s.got = append(s.got, e.Type)
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	bus := NewBus()
+	defer bus.Close()
+	d := NewDispatcher(bus)
+
+	all, slings, broken := &recordingSink{}, &recordingSink{}, &recordingSink{fail: true}
+	d.Add("all", all, nil)
+	d.Add("slings", slings, []string{TypeSling})
+	d.Add("broken", broken, nil)
+
+	for _, typ := range []string{TypeSling, TypeDone, TypeSling} {
+		_ = bus.Publish(Event{Type: typ})
+	}
+	if err := d.Close(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(all.got, ",") != "sling,done,sling" {
+		t.Errorf("all sink got %v", all.got)
+	}
+	if strings.Join(slings.got, ",") != "sling,sling" {
+		t.Errorf("filtered sink got %v", slings.got)
+	}
+	if !all.closed || !broken.closed {
+		t.Error("sinks not closed")
+	}
+}
+
+func TestWebhookAndKafkaSinks(t *testing.T) {
+	var mu sync.Mutex
+	bodies := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies[r.URL.Path] = r.Header.Get("Content-Type") + " " + r.Header.Get("Authorization") + " " + string(data)
+		mu.Unlock()
+		if r.URL.Path == "/fail" {
+			http.Error(w, "nope", http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	e := Event{Type: TypeDone, Actor: "gastown/polecats/nux", Payload: DonePayload("gt-1", "polecat/nux")}
+	headers := map[string]string{"Authorization": "Bearer s3cret"}
+
+	if err := NewWebhookSink(srv.URL+"/hook", headers).Send(e); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewKafkaSink(srv.URL+"/", "gt events", nil).Send(e); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewWebhookSink(srv.URL+"/fail", nil).Send(e); err == nil {
+		t.Error("expected an error for a 502")
+	}
+
+	if got := bodies["/hook"]; !strings.HasPrefix(got, "application/json Bearer s3cret {") || !strings.Contains(got, `"bead":"gt-1"`) {
+		t.Errorf("webhook request = %s", got)
+	}
+	kafka := bodies["/topics/gt events"]
+	if !strings.HasPrefix(kafka, "application/vnd.kafka.json.v2+json") {
+		t.Fatalf("kafka request = %q", kafka)
+	}
+	var payload struct {
+		Records []struct {
+			Key   string
+			Value Event
+		}
+	}
+	if err := json.Unmarshal([]byte(kafka[strings.Index(kafka, "{"):]), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if len(payload.Records) != 1 || payload.Records[0].Key != e.Actor || payload.Records[0].Value.Type != TypeDone {
+		t.Errorf("kafka records = %+v", payload.Records)
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("no UDP:", err)
+	}
+	defer conn.Close()
+
+	sink, err := NewSyslogSink("udp://" + conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	if err := sink.Send(Event{Timestamp: "2026-01-02T03:04:05Z", Type: TypeSling, Actor: "mayor"}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := string(buf[:n])
+	if !strings.HasPrefix(msg, "<134>1 2026-01-02T03:04:05Z ") || !strings.Contains(msg, " gt ") ||
+		!strings.Contains(msg, " sling - {") {
+		t.Errorf("syslog message = %q", msg)
+	}
+}
+
+func TestLazySinkConnectsOnFirstEvent(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("no TCP:", err)
+	}
+	defer ln.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		if conn, err := ln.Accept(); err == nil {
+			accepted <- conn
+		}
+	}()
+
+	sink := &lazySink{cfg: config.EventSinkConfig{Type: config.EventSinkSyslog, Address: "tcp://" + ln.Addr().String()}}
+	select {
+	case <-accepted:
+		t.Fatal("sink connected before any event")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := sink.Send(Event{Type: TypeSling}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case conn := <-accepted:
+		conn.Close()
+	case <-time.After(2 * time.Second):
+		t.Fatal("sink never connected")
+	}
+	if err := sink.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLazySinkInvalidConfig(t *testing.T) {
+	sink := &lazySink{cfg: config.EventSinkConfig{Type: config.EventSinkWebhook}}
+	if err := sink.Send(Event{Type: TypeSling}); err == nil {
+		t.Error("webhook without a url sent an event")
+	}
+	if err := sink.Close(); err != nil {
+		t.Error(err)
+	}
+}
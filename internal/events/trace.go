@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Span places an event in a trace: the events of one unit of work (e.g. a
+// bead's sling → spawn → hook → done) share a TraceID, and each names the
+// event that caused it in ParentID.
+type Span struct {
+	TraceID  string
+	ParentID string
+}
+
+// NewID returns a random event or trace ID.
+func NewID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a context whose events LogContext places in span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span carried by ctx, falling back to the
+// process's span (see StartTrace).
+func SpanFromContext(ctx context.Context) Span {
+	if s, ok := ctx.Value(spanKey{}).(Span); ok && s.TraceID != "" {
+		return s
+	}
+	return processSpan()
+}
+
+// process holds the span events logged without a context join. It starts
+// from the environment, so a trace continues into processes and agent
+// sessions started by a traced command.
+var process struct {
+	sync.Mutex
+	span   Span
+	loaded bool
+}
+
+func processSpan() Span {
+	process.Lock()
+	defer process.Unlock()
+	if !process.loaded {
+		process.span = Span{
+			TraceID:  os.Getenv(config.EnvTraceID),
+			ParentID: os.Getenv(config.EnvParentEvent),
+		}
+		process.loaded = true
+	}
+	return process.span
+}
+
+// StartTrace makes the rest of this process (and anything it starts) one
+// trace, continuing the inherited trace if there is one. Commands that
+// begin a unit of work, such as gt sling, call it first.
+func StartTrace() Span {
+	if span := processSpan(); span.TraceID != "" {
+		return span
+	}
+	return NewTrace()
+}
+
+// NewTrace starts a fresh trace for the rest of this process, for commands
+// that handle several units of work in turn (e.g. batch sling).
+func NewTrace() Span {
+	span := Span{TraceID: NewID()}
+	setProcessSpan(span)
+	return span
+}
+
+// setProcessSpan updates the process span and the environment handed to
+// child processes.
+func setProcessSpan(span Span) {
+	process.Lock()
+	process.span, process.loaded = span, true
+	process.Unlock()
+	_ = os.Setenv(config.EnvTraceID, span.TraceID)
+	_ = os.Setenv(config.EnvParentEvent, span.ParentID)
+}
+
+// LogContext is Log placing the event in ctx's span. The returned context
+// carries the event as parent, so events caused by it can be chained.
+func LogContext(ctx context.Context, eventType, actor string, payload map[string]interface{}, visibility string) (context.Context, error) {
+	span := SpanFromContext(ctx)
+	event := newEvent(eventType, actor, payload, visibility, span)
+	err := publish(event)
+	if span.TraceID == "" {
+		return ctx, err
+	}
+	return ContextWithSpan(ctx, Span{TraceID: span.TraceID, ParentID: event.ID}), err
+}
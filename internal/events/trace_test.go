@@ -0,0 +1,116 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// captureEvents records events published on the default bus during a test,
+// with tracing state reset before and after.
+func captureEvents(t *testing.T) *[]Event {
+	t.Helper()
+	t.Chdir(t.TempDir()) // Not a workspace, so nothing reaches an events file
+	t.Setenv(config.EnvTraceID, "")
+	t.Setenv(config.EnvParentEvent, "")
+	resetProcess := func() {
+		process.Lock()
+		process.span, process.loaded = Span{}, false
+		process.Unlock()
+	}
+	resetProcess()
+	t.Cleanup(resetProcess)
+
+	var got []Event
+	t.Cleanup(Default().SubscribeSync("trace-test", func(e Event) error {
+		got = append(got, e)
+		return nil
+	}))
+	return &got
+}
+
+func TestLogChainsProcessTrace(t *testing.T) {
+	got := captureEvents(t)
+
+	_ = LogFeed(TypeMail, "mayor", MailPayload("nux", "hi"))
+	span := StartTrace()
+	_ = LogFeed(TypeSpawn, "gt", SpawnPayload("gastown", "nux"))
+	_ = LogFeed(TypeSling, "mayor", SlingPayload("gt-1", "gastown/polecats/nux"))
+
+	if len(*got) != 3 {
+		t.Fatalf("got %d events", len(*got))
+	}
+	untraced, spawn, sling := (*got)[0], (*got)[1], (*got)[2]
+	if untraced.ID == "" || untraced.TraceID != "" {
+		t.Errorf("event before StartTrace: %+v", untraced)
+	}
+	if spawn.TraceID != span.TraceID || spawn.ParentID != "" {
+		t.Errorf("spawn = %+v, want root of trace %s", spawn, span.TraceID)
+	}
+	if sling.TraceID != span.TraceID || sling.ParentID != spawn.ID {
+		t.Errorf("sling = %+v, want child of %s", sling, spawn.ID)
+	}
+
+	// Child processes inherit the trace through the environment
+	if os.Getenv(config.EnvTraceID) != span.TraceID || os.Getenv(config.EnvParentEvent) != sling.ID {
+		t.Errorf("environment not updated: %s=%q %s=%q", config.EnvTraceID, os.Getenv(config.EnvTraceID),
+			config.EnvParentEvent, os.Getenv(config.EnvParentEvent))
+	}
+	if s := StartTrace(); s.TraceID != span.TraceID {
+		t.Errorf("StartTrace within a trace = %+v", s)
+	}
+	if s := NewTrace(); s.TraceID == span.TraceID || s.ParentID != "" {
+		t.Errorf("NewTrace = %+v", s)
+	}
+}
+
+func TestLogContext(t *testing.T) {
+	got := captureEvents(t)
+
+	ctx := ContextWithSpan(context.Background(), Span{TraceID: "t1"})
+	ctx, _ = LogContext(ctx, TypeHook, "nux", HookPayload("gt-1"), VisibilityFeed)
+	_, _ = LogContext(ctx, TypeDone, "nux", DonePayload("gt-1", "polecat/nux"), VisibilityFeed)
+
+	hook, done := (*got)[0], (*got)[1]
+	if hook.TraceID != "t1" || done.TraceID != "t1" || done.ParentID != hook.ID {
+		t.Errorf("hook = %+v, done = %+v", hook, done)
+	}
+	if !(Filter{TraceID: "t1"}).Match(done) || (Filter{TraceID: "t2"}).Match(done) {
+		t.Error("Filter.TraceID mismatch")
+	}
+}
+
+func TestLogError(t *testing.T) {
+	got := captureEvents(t)
+
+	payload := SlingFailedPayload("gt-1", "gastown/polecats/nux")
+	if err := LogError(TypeSlingFailed, "mayor", errors.New("bd exploded"), payload); err != nil {
+		t.Fatal(err)
+	}
+	_ = LogFeed(TypeMail, "mayor", MailPayload("nux", "hi"))
+
+	if len(*got) != 2 {
+		t.Fatalf("got %d events", len(*got))
+	}
+	failed, mail := (*got)[0], (*got)[1]
+	if failed.Severity != SeverityError || failed.Visibility != VisibilityBoth || failed.Payload["error"] != "bd exploded" {
+		t.Errorf("LogError event = %+v", failed)
+	}
+	if payload["error"] != "" {
+		t.Errorf("LogError modified the caller's payload: %v", payload)
+	}
+	if mail.Severity != SeverityInfo {
+		t.Errorf("mail severity = %q, want info", mail.Severity)
+	}
+
+	if (Event{Type: TypeMergeFailed}).Level() != SeverityError || (Event{Type: TypeMail}).Level() != SeverityInfo {
+		t.Error("Level doesn't fall back to the type's default")
+	}
+	f := Filter{MinSeverity: SeverityWarn}
+	if !f.Match(failed) || f.Match(mail) {
+		t.Error("MinSeverity filter mismatched")
+	}
+}
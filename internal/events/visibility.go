@@ -0,0 +1,122 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// defaultVisibility is the visibility policy for events logged with
+// VisibilityDefault. Towns override it per type with event_log.visibility.
+var defaultVisibility = map[string]string{
+	TypeSling:   VisibilityFeed,
+	TypeHook:    VisibilityFeed,
+	TypeUnhook:  VisibilityFeed,
+	TypeHandoff: VisibilityFeed,
+	TypeDone:    VisibilityFeed,
+	TypeMail:    VisibilityFeed,
+	TypeSpawn:   VisibilityFeed,
+	TypeKill:    VisibilityFeed,
+	TypeNudge:   VisibilityFeed,
+	TypeBoot:    VisibilityFeed,
+	TypeHalt:    VisibilityFeed,
+
+	TypeSlingBatch: VisibilityFeed,
+
+	TypeMailRead: VisibilityAudit,
+
+	TypeSessionStart: VisibilityFeed,
+	TypeSessionEnd:   VisibilityAudit,
+
+	TypePatrolStarted:  VisibilityFeed,
+	TypePolecatChecked: VisibilityAudit,
+	TypePolecatNudged:  VisibilityFeed,
+	TypeEscalationSent: VisibilityFeed,
+	TypePatrolComplete: VisibilityFeed,
+
+	TypeMergeStarted: VisibilityFeed,
+	TypeMerged:       VisibilityFeed,
+	TypeMergeFailed:  VisibilityFeed,
+	TypeMergeSkipped: VisibilityFeed,
+
+	TypeReopened:         VisibilityBoth,
+	TypeChangesRequested: VisibilityBoth,
+
+	TypeSandboxViolation: VisibilityAudit,
+
+	TypeReconcile:    VisibilityAudit,
+	TypeAutoReleased: VisibilityBoth,
+
+	TypePolecatParked: VisibilityFeed,
+	TypePolecatWoken:  VisibilityFeed,
+
+	TypeDispatched:      VisibilityFeed,
+	TypeDispatchSkipped: VisibilityAudit,
+
+	TypeScheduled:       VisibilityFeed,
+	TypeScheduleSkipped: VisibilityAudit,
+
+	TypeBudgetExceeded: VisibilityBoth,
+
+	TypeNudgeEscalated: VisibilityBoth,
+
+	TypeSyncDrift:     VisibilityFeed,
+	TypeSyncConflict:  VisibilityFeed,
+	TypeSyncRecovered: VisibilityFeed,
+
+	TypeSlingFailed:   VisibilityBoth,
+	TypeMergeConflict: VisibilityBoth,
+	TypeAgentCrash:    VisibilityBoth,
+}
+
+// DefaultVisibility is the built-in visibility for an event type. Types
+// without a policy are audit-only until promoted.
+func DefaultVisibility(eventType string) string {
+	if v, ok := defaultVisibility[eventType]; ok {
+		return v
+	}
+	return VisibilityAudit
+}
+
+// VisibilityFor returns the visibility a town gives an event type: its
+// event_log.visibility override, else DefaultVisibility.
+func VisibilityFor(townRoot, eventType string) string {
+	if v, ok := visibilityOverrides(townRoot)[eventType]; ok {
+		return v
+	}
+	return DefaultVisibility(eventType)
+}
+
+// overrides caches each town's event_log.visibility for the write path.
+var overrides sync.Map // townRoot → map[string]string
+
+func visibilityOverrides(townRoot string) map[string]string {
+	if townRoot == "" {
+		return nil
+	}
+	if v, ok := overrides.Load(townRoot); ok {
+		return v.(map[string]string)
+	}
+	// An override naming an unknown visibility is skipped, so the type
+	// keeps its default
+	m := make(map[string]string)
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil && settings.EventLog != nil {
+		for typ, v := range settings.EventLog.Visibility {
+			if v == VisibilityAudit || v == VisibilityFeed || v == VisibilityBoth {
+				m[typ] = v
+			}
+		}
+	}
+	overrides.Store(townRoot, m)
+	return m
+}
+
+// resolveVisibility applies the policy to a visibility passed to Log.
+func resolveVisibility(eventType, visibility string) string {
+	if visibility != VisibilityDefault {
+		return visibility
+	}
+	townRoot, _ := workspace.FindFromCwd()
+	return VisibilityFor(townRoot, eventType)
+}
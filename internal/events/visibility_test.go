@@ -0,0 +1,51 @@
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVisibilityPolicy(t *testing.T) {
+	got := captureEvents(t)
+
+	// Outside a workspace the built-in policy applies
+	_ = Log(TypeSling, "mayor", SlingPayload("gt-1", "gastown/polecats/nux"), VisibilityDefault)
+	_ = Log(TypeReconcile, "daemon", nil, VisibilityDefault)
+	_ = Log("custom", "mayor", nil, VisibilityDefault)
+	_ = Log(TypeReconcile, "daemon", nil, VisibilityFeed) // Explicit wins
+
+	want := []string{VisibilityFeed, VisibilityAudit, VisibilityAudit, VisibilityFeed}
+	if len(*got) != len(want) {
+		t.Fatalf("logged %d events, want %d", len(*got), len(want))
+	}
+	for i, e := range *got {
+		if e.Visibility != want[i] {
+			t.Errorf("%s: visibility %q, want %q", e.Type, e.Visibility, want[i])
+		}
+	}
+}
+
+func TestVisibilityForTownOverrides(t *testing.T) {
+	townRoot := t.TempDir()
+	settings := `{"event_log":{"visibility":{"reconcile":"feed","nudge":"audit","done":"loud"}}}`
+	if err := os.MkdirAll(filepath.Join(townRoot, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "settings", "config.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		TypeReconcile:   VisibilityFeed,              // Promoted
+		TypeNudge:       VisibilityAudit,             // Demoted
+		TypeSling:       VisibilityFeed,              // Default
+		TypeSlingFailed: VisibilityBoth,              // Default
+		TypeDone:        DefaultVisibility(TypeDone), // Invalid override ignored
+	}
+	for typ, want := range tests {
+		if got := VisibilityFor(townRoot, typ); got != want {
+			t.Errorf("VisibilityFor(%s) = %q, want %q", typ, got, want)
+		}
+	}
+}
@@ -2,28 +2,35 @@
 //
 // The curator:
 // 1. Tails ~/gt/.events.jsonl (raw events)
-// 2. Filters by visibility tag (drops audit-only events)
-// 3. Deduplicates repeated updates (5 molecule updates → "agent active")
-// 4. Aggregates related events (3 issues closed → "batch complete")
-// 5. Writes curated events to ~/gt/.feed.jsonl
+// 2. Filters by visibility tag and type whitelist (see Rules)
+// 3. Deduplicates retries and repeated updates (5 molecule updates → "agent active")
+// 4. Coalesces repeated nudges and rate-limits noisy actors, never failures
+// 5. Aggregates related events (3 issues closed → "batch complete")
+// 6. Writes curated events to ~/gt/.feed.jsonl
+//
+// It runs inside the gt daemon, or on its own via "gt feed daemon".
 package feed
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/flock"
 )
 
-// FeedFile is the name of the curated feed file.
-const FeedFile = ".feed.jsonl"
+// FeedFile is the default name of the curated feed file.
+// Use Path to honor storage overrides in town settings.
+const FeedFile = config.DefaultFeedFile
+
+// Path returns the curated feed location for a town.
+func Path(townRoot string) string {
+	return config.LoadPaths(townRoot).FeedFile
+}
 
 // FeedEvent is the structure of events written to the feed.
 type FeedEvent struct {
@@ -33,21 +40,29 @@ type FeedEvent struct {
 	Actor     string                 `json:"actor"`
 	Summary   string                 `json:"summary"`
 	Payload   map[string]interface{} `json:"payload,omitempty"`
-	Count     int                    `json:"count,omitempty"` // For aggregated events
+	Count     int                    `json:"count,omitempty"`      // For aggregated events
+	Dropped   int                    `json:"suppressed,omitempty"` // Events from this actor rate-limited since its last entry
+	Severity  string                 `json:"severity,omitempty"`   // Set for warnings and errors, so readers can highlight them
 }
 
 // Curator manages the feed curation process.
 type Curator struct {
-	townRoot string
-	ctx      context.Context
-	cancel   context.CancelFunc
-	wg       sync.WaitGroup
+	townRoot  string
+	rules     Rules
+	formatter *Formatter
+	now       func() time.Time
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
 
 	// Deduplication state
 	mu          sync.Mutex
 	recentDone  map[string]time.Time     // actor → last done time (dedupe repeated done events)
 	recentSling map[string][]slingRecord // actor → recent slings (aggregate)
 	recentMail  map[string]int           // actor → mail count in window (aggregate)
+	rates       map[string]*rateWindow   // actor → events in the current rate window
+	nudges      map[string]*nudgeGroup   // nudge target → nudges being coalesced
+	seenKeys    map[string]time.Time     // idempotency key → when it was first seen
 }
 
 type slingRecord struct {
@@ -55,6 +70,21 @@ type slingRecord struct {
 	ts     time.Time
 }
 
+// rateWindow counts one actor's feed events against Rules.RateLimit.
+type rateWindow struct {
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// nudgeGroup collects repeat nudges of one target after the first was
+// written.
+type nudgeGroup struct {
+	first time.Time
+	last  events.Event
+	extra int
+}
+
 // Deduplication/aggregation settings
 const (
 	// Dedupe window for repeated done events from same actor
@@ -70,104 +100,235 @@ const (
 	minAggregateCount = 3
 )
 
-// NewCurator creates a new feed curator.
+// NewCurator creates a new feed curator using the town's feed rules and
+// templates. Invalid settings fall back to the defaults; see LoadRules and
+// LoadFormatter.
 func NewCurator(townRoot string) *Curator {
 	ctx, cancel := context.WithCancel(context.Background())
+	rules, err := LoadRules(townRoot)
+	if err != nil {
+		rules = DefaultRules()
+	}
+	formatter, _ := LoadFormatter(townRoot)
 	return &Curator{
 		townRoot:    townRoot,
+		rules:       rules,
+		formatter:   formatter,
+		now:         time.Now,
 		ctx:         ctx,
 		cancel:      cancel,
 		recentDone:  make(map[string]time.Time),
 		recentSling: make(map[string][]slingRecord),
 		recentMail:  make(map[string]int),
+		rates:       make(map[string]*rateWindow),
+		nudges:      make(map[string]*nudgeGroup),
+		seenKeys:    make(map[string]time.Time),
 	}
 }
 
-// Start begins the curator goroutine.
-func (c *Curator) Start() error {
-	eventsPath := filepath.Join(c.townRoot, events.EventsFile)
-
-	// Open events file, creating if needed
-	file, err := os.OpenFile(eventsPath, os.O_RDONLY|os.O_CREATE, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
-	if err != nil {
-		return fmt.Errorf("opening events file: %w", err)
-	}
+// SetRules replaces the curation rules. Call it before Start.
+func (c *Curator) SetRules(r Rules) {
+	c.rules = r
+}
 
-	// Seek to end to only process new events
-	if _, err := file.Seek(0, io.SeekEnd); err != nil {
-		_ = file.Close() //nolint:gosec // G104: best effort cleanup on error
-		return fmt.Errorf("seeking to end: %w", err)
-	}
+// Start begins the curator goroutine, following events appended from now on.
+func (c *Curator) Start() error {
+	evts := events.TailTown(c.ctx, c.townRoot)
 
 	c.wg.Add(1)
-	go c.run(file)
+	go c.run(evts)
 
 	return nil
 }
 
-// Stop gracefully stops the curator.
+// Stop gracefully stops the curator, writing any coalesced nudges still
+// pending so nothing is lost on shutdown.
 func (c *Curator) Stop() {
 	c.cancel()
 	c.wg.Wait()
 }
 
 // run is the main curator loop.
-func (c *Curator) run(file *os.File) {
+func (c *Curator) run(evts <-chan events.Event) {
 	defer c.wg.Done()
-	defer file.Close()
-
-	reader := bufio.NewReader(file)
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
 
 	// Cleanup ticker for stale aggregation state
 	cleanupTicker := time.NewTicker(time.Minute)
 	defer cleanupTicker.Stop()
 
+	// Flush ticker for coalesced nudges whose window has closed
+	flushTicker := time.NewTicker(time.Second)
+	defer flushTicker.Stop()
+
 	for {
 		select {
 		case <-c.ctx.Done():
+			c.flushNudges(true)
 			return
 
 		case <-cleanupTicker.C:
 			c.cleanupStaleState()
 
-		case <-ticker.C:
-			// Read available lines
-			for {
-				line, err := reader.ReadString('\n')
-				if err != nil {
-					break // No more data available
-				}
-				c.processLine(line)
+		case <-flushTicker.C:
+			c.flushNudges(false)
+
+		case e, ok := <-evts:
+			if !ok {
+				c.flushNudges(true)
+				return
 			}
+			c.processEvent(&e)
 		}
 	}
 }
 
-// processLine processes a single line from the events file.
-func (c *Curator) processLine(line string) {
-	if line == "" || line == "\n" {
+// processEvent applies the curation rules to one raw event.
+func (c *Curator) processEvent(rawEvent *events.Event) {
+	// Filter by visibility and type
+	if !c.rules.admits(rawEvent) {
 		return
 	}
 
-	var rawEvent events.Event
-	if err := json.Unmarshal([]byte(line), &rawEvent); err != nil {
-		return // Skip malformed lines
+	// Drop retries of an action already in the feed
+	if c.seenKey(rawEvent) {
+		return
 	}
 
-	// Filter by visibility - only process feed-visible events
-	if rawEvent.Visibility != events.VisibilityFeed && rawEvent.Visibility != events.VisibilityBoth {
+	// Fold repeat nudges of the same target
+	if c.coalesceNudge(rawEvent) {
+		return
+	}
+
+	// Failures skip deduplication and rate limiting: they are what the
+	// feed is for
+	if events.SeverityRank(rawEvent.Level()) >= events.SeverityRank(events.SeverityWarn) {
+		c.writeFeedEvent(rawEvent, 0)
 		return
 	}
 
 	// Apply deduplication and aggregation
-	if c.shouldDedupe(&rawEvent) {
+	if c.shouldDedupe(rawEvent) {
+		return
+	}
+
+	dropped, ok := c.rateLimit(rawEvent.Actor)
+	if !ok {
 		return
 	}
 
 	// Write to feed
-	c.writeFeedEvent(&rawEvent)
+	c.writeFeedEvent(rawEvent, dropped)
+}
+
+// seenKey reports whether an event's idempotency key was seen within
+// events.DedupeWindow, and records it if not. Log drops most duplicates
+// itself; this catches retries that raced each other.
+func (c *Curator) seenKey(event *events.Event) bool {
+	if event.IdempotencyKey == "" {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if at, ok := c.seenKeys[event.IdempotencyKey]; ok && now.Sub(at) < events.DedupeWindow {
+		return true
+	}
+	c.seenKeys[event.IdempotencyKey] = now
+	return false
+}
+
+// rateLimit charges one event to actor. It reports false if the actor is
+// over Rules.RateLimit, and otherwise how many of its events were dropped
+// since its last feed entry.
+func (c *Curator) rateLimit(actor string) (int, bool) {
+	if c.rules.RateLimit <= 0 {
+		return 0, true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	w := c.rates[actor]
+	if w == nil {
+		w = &rateWindow{start: now}
+		c.rates[actor] = w
+	}
+	if now.Sub(w.start) >= c.rules.RateWindow {
+		w.start, w.count = now, 0
+	}
+	if w.count >= c.rules.RateLimit {
+		w.dropped++
+		return 0, false
+	}
+	w.count++
+	dropped := w.dropped
+	w.dropped = 0
+	return dropped, true
+}
+
+// coalesceNudge reports whether a nudge repeats one already written for
+// its target within Rules.NudgeCoalesce, and so should be held back. The
+// held-back nudges are written as one entry when the window closes.
+func (c *Curator) coalesceNudge(event *events.Event) bool {
+	if c.rules.NudgeCoalesce <= 0 || !isNudge(event) {
+		return false
+	}
+	target := nudgeTarget(event)
+
+	c.mu.Lock()
+	now := c.now()
+	g := c.nudges[target]
+	if g != nil && now.Sub(g.first) < c.rules.NudgeCoalesce {
+		g.extra++
+		g.last = *event
+		c.mu.Unlock()
+		return true
+	}
+	c.nudges[target] = &nudgeGroup{first: now, last: *event}
+	c.mu.Unlock()
+
+	if g != nil && g.extra > 0 {
+		c.writeNudgeSummary(target, g)
+	}
+	return false
+}
+
+// flushNudges writes the summaries of coalesced nudges whose window has
+// closed, or of all of them if force is set.
+func (c *Curator) flushNudges(force bool) {
+	c.mu.Lock()
+	now := c.now()
+	due := make(map[string]*nudgeGroup)
+	for target, g := range c.nudges {
+		if force || now.Sub(g.first) >= c.rules.NudgeCoalesce {
+			delete(c.nudges, target)
+			if g.extra > 0 {
+				due[target] = g
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	for target, g := range due {
+		c.writeNudgeSummary(target, g)
+	}
+}
+
+// writeNudgeSummary records the nudges folded into g.
+func (c *Curator) writeNudgeSummary(target string, g *nudgeGroup) {
+	times := "times"
+	if g.extra == 1 {
+		times = "time"
+	}
+	c.appendFeed(FeedEvent{
+		Timestamp: g.last.Timestamp,
+		Source:    g.last.Source,
+		Type:      g.last.Type,
+		Actor:     g.last.Actor,
+		Summary:   fmt.Sprintf("%s nudged %s %d more %s", g.last.Actor, target, g.extra, times),
+		Payload:   g.last.Payload,
+		Count:     g.extra,
+	})
 }
 
 // shouldDedupe checks if an event should be deduplicated.
@@ -176,7 +337,7 @@ func (c *Curator) shouldDedupe(event *events.Event) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := time.Now()
+	now := c.now()
 
 	switch event.Type {
 	case events.TypeDone:
@@ -220,7 +381,7 @@ func (c *Curator) shouldDedupe(event *events.Event) bool {
 
 // pruneRecords removes records older than the window.
 func (c *Curator) pruneRecords(records []slingRecord, window time.Duration) []slingRecord {
-	now := time.Now()
+	now := c.now()
 	result := make([]slingRecord, 0, len(records))
 	for _, r := range records {
 		if now.Sub(r.ts) < window {
@@ -235,7 +396,7 @@ func (c *Curator) cleanupStaleState() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := time.Now()
+	now := c.now()
 	staleThreshold := 5 * time.Minute
 
 	// Clean stale done records
@@ -253,12 +414,27 @@ func (c *Curator) cleanupStaleState() {
 		}
 	}
 
+	// Clean expired idempotency keys
+	for key, at := range c.seenKeys {
+		if now.Sub(at) >= events.DedupeWindow {
+			delete(c.seenKeys, key)
+		}
+	}
+
+	// Clean idle rate windows
+	for actor, w := range c.rates {
+		if now.Sub(w.start) > staleThreshold && w.dropped == 0 {
+			delete(c.rates, actor)
+		}
+	}
+
 	// Reset mail counts
 	c.recentMail = make(map[string]int)
 }
 
-// writeFeedEvent writes a curated event to the feed file.
-func (c *Curator) writeFeedEvent(event *events.Event) {
+// writeFeedEvent writes a curated event to the feed file, noting how many
+// of the actor's events were dropped by the rate limit before it.
+func (c *Curator) writeFeedEvent(event *events.Event, dropped int) {
 	feedEvent := FeedEvent{
 		Timestamp: event.Timestamp,
 		Source:    event.Source,
@@ -266,6 +442,10 @@ func (c *Curator) writeFeedEvent(event *events.Event) {
 		Actor:     event.Actor,
 		Summary:   c.generateSummary(event),
 		Payload:   event.Payload,
+		Dropped:   dropped,
+	}
+	if level := event.Level(); events.SeverityRank(level) > events.SeverityRank(events.SeverityInfo) {
+		feedEvent.Severity = level
 	}
 
 	// Check for aggregation opportunity
@@ -278,75 +458,23 @@ func (c *Curator) writeFeedEvent(event *events.Event) {
 	}
 	c.mu.Unlock()
 
+	c.appendFeed(feedEvent)
+}
+
+// appendFeed appends one entry to the feed file.
+func (c *Curator) appendFeed(feedEvent FeedEvent) {
 	data, err := json.Marshal(feedEvent)
 	if err != nil {
 		return
 	}
 	data = append(data, '\n')
 
-	feedPath := filepath.Join(c.townRoot, FeedFile)
-	f, err := os.OpenFile(feedPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: feed file is non-sensitive operational data
-	if err != nil {
-		return
-	}
-	defer f.Close()
-
-	_, _ = f.Write(data)
+	feedPath := Path(c.townRoot)
+	_ = flock.AppendFile(feedPath, data, 0644)
 }
 
-// generateSummary creates a human-readable summary of an event.
+// generateSummary creates a human-readable summary of an event using the
+// town's feed templates.
 func (c *Curator) generateSummary(event *events.Event) string {
-	switch event.Type {
-	case events.TypeSling:
-		if target, ok := event.Payload["target"].(string); ok {
-			if bead, ok := event.Payload["bead"].(string); ok {
-				return fmt.Sprintf("%s assigned %s to %s", event.Actor, bead, target)
-			}
-		}
-		return fmt.Sprintf("%s dispatched work", event.Actor)
-
-	case events.TypeDone:
-		if bead, ok := event.Payload["bead"].(string); ok {
-			return fmt.Sprintf("%s completed work on %s", event.Actor, bead)
-		}
-		return fmt.Sprintf("%s signaled done", event.Actor)
-
-	case events.TypeHandoff:
-		return fmt.Sprintf("%s handed off to fresh session", event.Actor)
-
-	case events.TypeMail:
-		if to, ok := event.Payload["to"].(string); ok {
-			if subj, ok := event.Payload["subject"].(string); ok {
-				return fmt.Sprintf("%s → %s: %s", event.Actor, to, subj)
-			}
-		}
-		return fmt.Sprintf("%s sent mail", event.Actor)
-
-	case events.TypePatrolStarted:
-		if rig, ok := event.Payload["rig"].(string); ok {
-			return fmt.Sprintf("%s patrol started for %s", event.Actor, rig)
-		}
-		return fmt.Sprintf("%s started patrol", event.Actor)
-
-	case events.TypePatrolComplete:
-		if msg, ok := event.Payload["message"].(string); ok {
-			return msg
-		}
-		return fmt.Sprintf("%s completed patrol", event.Actor)
-
-	case events.TypeMerged:
-		if worker, ok := event.Payload["worker"].(string); ok {
-			return fmt.Sprintf("Merged work from %s", worker)
-		}
-		return "Work merged"
-
-	case events.TypeMergeFailed:
-		if reason, ok := event.Payload["reason"].(string); ok {
-			return fmt.Sprintf("Merge failed: %s", reason)
-		}
-		return "Merge failed"
-
-	default:
-		return fmt.Sprintf("%s: %s", event.Actor, event.Type)
-	}
+	return c.formatter.Format(*event)
 }
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
 )
 
@@ -194,3 +196,157 @@ func TestCurator_GeneratesSummary(t *testing.T) {
 		}
 	}
 }
+
+// readFeed returns the entries written to a town's feed.
+func readFeed(t *testing.T, townRoot string) []FeedEvent {
+	t.Helper()
+	data, err := os.ReadFile(Path(townRoot))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	var out []FeedEvent
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var e FeedEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func TestCurator_Rules(t *testing.T) {
+	town := t.TempDir()
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCurator(town)
+	c.now = func() time.Time { return clock }
+	rules := DefaultRules()
+	rules.Types = []string{events.TypeSling, events.TypeNudge}
+	rules.RateLimit, rules.RateWindow = 2, time.Minute
+	rules.NudgeCoalesce = 0
+	c.SetRules(rules)
+
+	sling := func(actor string) *events.Event {
+		return &events.Event{Type: events.TypeSling, Actor: actor, Visibility: events.VisibilityFeed}
+	}
+	c.processEvent(&events.Event{Type: events.TypeHandoff, Actor: "mayor", Visibility: events.VisibilityFeed})
+	c.processEvent(&events.Event{Type: events.TypeSling, Actor: "mayor", Visibility: events.VisibilityAudit})
+	for i := 0; i < 4; i++ {
+		c.processEvent(sling("mayor"))
+	}
+	c.processEvent(sling("deacon"))
+	clock = clock.Add(time.Minute)
+	c.processEvent(sling("mayor"))
+
+	got := readFeed(t, town)
+	if len(got) != 4 {
+		t.Fatalf("feed has %d entries, want 4: %+v", len(got), got)
+	}
+	if got[0].Actor != "mayor" || got[1].Actor != "mayor" || got[2].Actor != "deacon" {
+		t.Errorf("entries = %+v", got)
+	}
+	if last := got[3]; last.Actor != "mayor" || last.Dropped != 2 {
+		t.Errorf("next window entry = %+v, want mayor with 2 suppressed", last)
+	}
+}
+
+func TestCurator_CoalescesNudges(t *testing.T) {
+	town := t.TempDir()
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCurator(town)
+	c.now = func() time.Time { return clock }
+	c.SetRules(DefaultRules())
+
+	nudge := func(target string) *events.Event {
+		return &events.Event{Type: events.TypeNudge, Actor: "gastown/witness", Visibility: events.VisibilityFeed,
+			Payload: events.NudgePayload("gastown", target, "idle")}
+	}
+	c.processEvent(nudge("Toast"))
+	c.processEvent(nudge("Toast"))
+	c.processEvent(nudge("Nux"))
+	clock = clock.Add(time.Minute)
+	c.processEvent(nudge("Toast"))
+
+	if got := readFeed(t, town); len(got) != 2 {
+		t.Fatalf("within window: %d entries, want 2 (first nudge of each target)", len(got))
+	}
+
+	c.flushNudges(false)
+	if got := readFeed(t, town); len(got) != 2 {
+		t.Fatalf("flushed before window closed: %+v", got)
+	}
+	clock = clock.Add(DefaultNudgeCoalesce)
+	c.flushNudges(false)
+	got := readFeed(t, town)
+	if len(got) != 3 || got[2].Count != 2 || !strings.Contains(got[2].Summary, "gastown/Toast 2 more times") {
+		t.Errorf("after window: %+v", got)
+	}
+}
+
+func TestRulesFromConfig(t *testing.T) {
+	r, err := RulesFromConfig(&config.FeedConfig{RateLimit: "30/1m", CoalesceNudges: "0", Types: []string{"sling"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.RateLimit != 30 || r.RateWindow != time.Minute || r.NudgeCoalesce != 0 || len(r.Visibility) != 2 {
+		t.Errorf("rules = %+v", r)
+	}
+	for _, bad := range []*config.FeedConfig{{RateLimit: "30"}, {RateLimit: "x/1m"}, {CoalesceNudges: "soon"}, {Visibility: []string{"public"}}} {
+		if _, err := RulesFromConfig(bad); err == nil {
+			t.Errorf("RulesFromConfig(%+v) should fail", bad)
+		}
+	}
+}
+
+func TestCurator_PrioritizesFailures(t *testing.T) {
+	town := t.TempDir()
+	c := NewCurator(town)
+	rules := DefaultRules()
+	rules.RateLimit, rules.RateWindow = 1, time.Hour
+	c.SetRules(rules)
+
+	crash := func() *events.Event {
+		payload := events.AgentCrashPayload("gastown", "gastown/polecats/nux", "gt-1")
+		payload["error"] = "session gt-gastown-nux is dead"
+		return &events.Event{Type: events.TypeAgentCrash, Actor: "daemon", Visibility: events.VisibilityBoth,
+			Severity: events.SeverityError, Payload: payload}
+	}
+	c.processEvent(&events.Event{Type: events.TypeHandoff, Actor: "daemon", Visibility: events.VisibilityFeed})
+	c.processEvent(crash())
+	c.processEvent(crash())
+
+	got := readFeed(t, town)
+	if len(got) != 3 {
+		t.Fatalf("feed has %d entries, want 3 (failures are not rate-limited): %+v", len(got), got)
+	}
+	if got[0].Severity != "" {
+		t.Errorf("info entry has severity %q", got[0].Severity)
+	}
+	if crash := got[1]; crash.Severity != events.SeverityError || crash.Summary != "gastown/polecats/nux crashed while working on gt-1" {
+		t.Errorf("crash entry = %+v", crash)
+	}
+}
+
+func TestCurator_DropsRetries(t *testing.T) {
+	town := t.TempDir()
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCurator(town)
+	c.now = func() time.Time { return clock }
+
+	sling := func(bead string) *events.Event {
+		return &events.Event{Type: events.TypeSling, Actor: "mayor", Visibility: events.VisibilityFeed,
+			Payload: events.SlingPayload(bead, "gastown/polecats/nux"), IdempotencyKey: events.Key(events.TypeSling, bead)}
+	}
+	c.processEvent(sling("gt-1"))
+	c.processEvent(sling("gt-1"))
+	c.processEvent(sling("gt-2"))
+	clock = clock.Add(events.DedupeWindow)
+	c.processEvent(sling("gt-1"))
+
+	if got := readFeed(t, town); len(got) != 3 {
+		t.Errorf("feed has %d entries, want 3 (the retry within the window dropped): %+v", len(got), got)
+	}
+}
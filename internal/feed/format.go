@@ -0,0 +1,183 @@
+package feed
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// DefaultTemplates render the common event types as one-line summaries.
+// Templates are Go text/templates executed against a TemplateData; town
+// settings can override any of them, or add templates for other types
+// (feed.templates).
+var DefaultTemplates = map[string]string{
+	events.TypeSling:   `{{if and (.P "bead") (.P "target")}}{{.Actor}} assigned {{.P "bead"}} to {{.P "target"}}{{else}}{{.Actor}} dispatched work{{end}}`,
+	events.TypeHook:    `{{if .P "bead"}}{{.Actor}} hooked {{.P "bead"}}{{else}}{{.Actor}} hooked work{{end}}`,
+	events.TypeUnhook:  `{{if .P "bead"}}{{.Actor}} unhooked {{.P "bead"}}{{else}}{{.Actor}} unhooked work{{end}}`,
+	events.TypeDone:    `{{if .P "bead"}}{{.Actor}} completed work on {{.P "bead"}}{{else}}{{.Actor}} signaled done{{end}}`,
+	events.TypeHandoff: `{{.Actor}} handed off to fresh session`,
+	events.TypeMail:    `{{if and (.P "to") (.P "subject")}}{{.Actor}} → {{.P "to"}}: {{.P "subject"}}{{else}}{{.Actor}} sent mail{{end}}`,
+	events.TypeSpawn:   `{{if .P "polecat"}}Spawned polecat {{.P "rig"}}/{{.P "polecat"}}{{else}}{{.Actor}} spawned a polecat{{end}}`,
+	events.TypeKill:    `{{if .P "target"}}Killed {{.P "target"}}{{with .P "reason"}} ({{.}}){{end}}{{else}}{{.Actor}} killed a session{{end}}`,
+	events.TypeNudge:   `{{if .P "target"}}{{.Actor}} nudged {{.P "target"}}{{with .P "reason"}}: {{.}}{{end}}{{else}}{{.Actor}} sent a nudge{{end}}`,
+	events.TypeBoot:    `{{if .P "rig"}}Booted {{.P "rig"}}{{with .L "agents"}} ({{join . ", "}}){{end}}{{with .L "failed"}} ({{len .}} failed: {{join . ", "}}){{end}}{{else}}{{.Actor}} booted{{end}}`,
+	events.TypeHalt:    `{{with .L "services"}}Halted {{join . ", "}}{{else}}{{$.Actor}} halted{{end}}{{if .P "drained"}} after draining{{with .L "released"}} (released {{join . ", "}}){{end}}{{end}}`,
+
+	events.TypePatrolStarted:  `{{if .P "rig"}}{{.Actor}} patrol started for {{.P "rig"}}{{else}}{{.Actor}} started patrol{{end}}`,
+	events.TypePatrolComplete: `{{with .P "message"}}{{.}}{{else}}{{$.Actor}} completed patrol{{end}}`,
+	events.TypePolecatNudged:  `{{if .P "target"}}{{.Actor}} nudged {{.P "target"}}{{with .P "reason"}}: {{.}}{{end}}{{else}}{{.Actor}} nudged a polecat{{end}}`,
+	events.TypeEscalationSent: `{{if and (.P "target") (.P "to")}}{{.Actor}} escalated {{.P "target"}} to {{.P "to"}}{{with .P "reason"}}: {{.}}{{end}}{{else}}{{.Actor}} sent an escalation{{end}}`,
+
+	events.TypeMerged:      `{{with .P "worker"}}Merged work from {{.}}{{else}}Work merged{{end}}`,
+	events.TypeMergeFailed: `{{with .P "reason"}}Merge failed: {{.}}{{else}}Merge failed{{end}}`,
+
+	events.TypeSlingBatch:    `{{if .P "target"}}{{.Actor}} slung {{len (.L "beads")}} beads{{with .P "epic"}} of {{.}}{{end}} to {{.P "target"}}{{with .L "failed"}} ({{len .}} failed: {{join . ", "}}){{end}}{{else}}{{.Actor}} slung a batch of work{{end}}`,
+	events.TypeSlingFailed:   `{{if and (.P "bead") (.P "target")}}{{.Actor}} failed to sling {{.P "bead"}} to {{.P "target"}}: {{.P "error"}}{{else}}{{.Actor}} failed to sling work{{end}}`,
+	events.TypeMergeConflict: `{{if .P "branch"}}Merge conflict on {{.P "branch"}}{{with .L "files"}} in {{join . ", "}}{{end}}{{else}}Merge conflict{{end}}`,
+	events.TypeAgentCrash:    `{{if .P "agent"}}{{.P "agent"}} crashed{{with .P "bead"}} while working on {{.}}{{end}}{{else}}Agent crashed{{end}}`,
+
+	events.TypeDispatched:     `{{if and (.P "bead") (.P "agent")}}Dispatched {{.P "bead"}} to {{.P "agent"}}{{else}}{{.Actor}} dispatched work{{end}}`,
+	events.TypeScheduled:      `{{if .P "bead"}}Schedule {{.P "schedule"}} created {{.P "bead"}}{{with .P "title"}}: {{.}}{{end}}{{with .P "assignee"}} for {{.}}{{end}}{{else}}Scheduled work created{{end}}`,
+	events.TypeBudgetExceeded: `{{if .P "key"}}{{.P "key"}} is over budget{{with .P "spent"}} ({{.}}){{end}}{{if eq (.P "action") "halt"}}: halted{{end}}{{else}}Budget exceeded{{end}}`,
+	events.TypeNudgeEscalated: `{{if .P "polecat"}}{{.P "rig"}}/{{.P "polecat"}} silent {{.P "silent_for"}} after a nudge: {{if eq (.P "action") "renudge"}}nudged again{{else if eq (.P "action") "notify"}}overseer notified{{else if eq (.P "action") "kill"}}killed{{else if eq (.P "action") "respawn"}}respawned{{else}}{{.P "action"}}{{end}}{{else}}Nudge escalated{{end}}`,
+	events.TypeAutoReleased:   `{{if .P "bead"}}Released {{.P "bead"}}{{with .P "agent"}} from {{.}}{{end}}{{with .P "reason"}} ({{.}}){{end}}{{else}}{{.Actor}} released work{{end}}`,
+}
+
+// fallbackTemplate renders types without a template.
+const fallbackTemplate = `{{.Actor}}: {{.Type}}`
+
+// TemplateData is what a feed template is executed against: the event's
+// fields (.Actor, .Type, .Payload, ...) plus helpers.
+type TemplateData struct {
+	events.Event
+}
+
+// P returns a payload value as text, or "" if it is missing.
+func (d TemplateData) P(key string) string {
+	v, ok := d.Payload[key]
+	if !ok || v == nil {
+		return ""
+	}
+	if list := d.L(key); list != nil {
+		return strings.Join(list, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+// L returns a list payload value as text, or nil if it is missing or not
+// a list.
+func (d TemplateData) L(key string) []string {
+	switch list := d.Payload[key].(type) {
+	case []string:
+		return list
+	case []interface{}:
+		out := make([]string, len(list))
+		for i, v := range list {
+			out[i] = fmt.Sprint(v)
+		}
+		return out
+	}
+	return nil
+}
+
+// Who returns the last segment of the actor: "Toast" for
+// "gastown/polecats/Toast".
+func (d TemplateData) Who() string {
+	return d.Actor[strings.LastIndex(d.Actor, "/")+1:]
+}
+
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// Formatter renders events as one-line human-readable strings.
+type Formatter struct {
+	templates map[string]*template.Template
+	fallback  *template.Template
+}
+
+// NewFormatter builds a formatter from DefaultTemplates with overrides
+// (event type → template source) applied over them.
+func NewFormatter(overrides map[string]string) (*Formatter, error) {
+	f := &Formatter{
+		templates: make(map[string]*template.Template),
+		fallback:  template.Must(template.New("fallback").Parse(fallbackTemplate)),
+	}
+	sources := make(map[string]string, len(DefaultTemplates)+len(overrides))
+	for typ, src := range DefaultTemplates {
+		sources[typ] = src
+	}
+	for typ, src := range overrides {
+		sources[typ] = src
+	}
+
+	types := make([]string, 0, len(sources))
+	for typ := range sources {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		tmpl, err := template.New(typ).Funcs(templateFuncs).Parse(sources[typ])
+		if err != nil {
+			return nil, fmt.Errorf("feed template for %s: %w", typ, err)
+		}
+		f.templates[typ] = tmpl
+	}
+	return f, nil
+}
+
+// DefaultFormatter returns a formatter using only DefaultTemplates.
+func DefaultFormatter() *Formatter {
+	f, err := NewFormatter(nil)
+	if err != nil {
+		panic(err) // DefaultTemplates are fixed; covered by tests
+	}
+	return f
+}
+
+// LoadFormatter builds the formatter for a town, applying its
+// feed.templates. On invalid settings it returns DefaultFormatter and the
+// error.
+func LoadFormatter(townRoot string) (*Formatter, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return DefaultFormatter(), err
+	}
+	if settings.Feed == nil || len(settings.Feed.Templates) == 0 {
+		return DefaultFormatter(), nil
+	}
+	f, err := NewFormatter(settings.Feed.Templates)
+	if err != nil {
+		return DefaultFormatter(), err
+	}
+	return f, nil
+}
+
+// Format renders an event on one line. A template that fails or renders
+// nothing falls back to "<actor>: <type>".
+func (f *Formatter) Format(e events.Event) string {
+	data := TemplateData{Event: e}
+	tmpl := f.templates[e.Type]
+	if tmpl != nil {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err == nil {
+			if line := oneLine(b.String()); line != "" {
+				return line
+			}
+		}
+	}
+	var b strings.Builder
+	_ = f.fallback.Execute(&b, data)
+	return oneLine(b.String())
+}
+
+// oneLine collapses whitespace runs, newlines included, to single spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,135 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestFormatter_Defaults(t *testing.T) {
+	f := DefaultFormatter()
+
+	tests := []struct {
+		event events.Event
+		want  string
+	}{
+		{
+			events.Event{Type: events.TypeHook, Actor: "gastown/polecats/Toast", Payload: events.HookPayload("gt-abc")},
+			"gastown/polecats/Toast hooked gt-abc",
+		},
+		{
+			events.Event{Type: events.TypeBoot, Actor: "mayor", Payload: events.BootPayload("gastown", []string{"witness", "refinery"})},
+			"Booted gastown (witness, refinery)",
+		},
+		{
+			// Decoded from JSON, lists are []interface{}
+			events.Event{Type: events.TypeMergeConflict, Payload: map[string]interface{}{"branch": "polecat/nux", "files": []interface{}{"a.go", "b.go"}}},
+			"Merge conflict on polecat/nux in a.go, b.go",
+		},
+		{
+			events.Event{Type: events.TypeKill, Actor: "deacon", Payload: events.KillPayload("gastown", "nux", "")},
+			"Killed nux",
+		},
+		{
+			events.Event{Type: events.TypeSling, Actor: "mayor"},
+			"mayor dispatched work",
+		},
+		{
+			events.Event{Type: events.TypeSlingBatch, Actor: "mayor", Payload: events.SlingBatchPayload("gastown", "gt-epic", []string{"gt-a", "gt-b"}, map[string]string{"gt-c": "spawn failed"})},
+			"mayor slung 2 beads of gt-epic to gastown (1 failed: gt-c)",
+		},
+		{
+			events.Event{Type: events.TypeBoot, Actor: "gt", Payload: events.SpawnPoolPayload("gastown", []string{"furiosa", "nux"}, map[string]string{"slit": "starting session: timeout"})},
+			"Booted gastown (furiosa, nux) (1 failed: slit)",
+		},
+		{
+			events.Event{Type: events.TypeHalt, Actor: "gt", Payload: events.DrainedHaltPayload([]string{"daemon", "mayor"}, []string{"gt-a"})},
+			"Halted daemon, mayor after draining (released gt-a)",
+		},
+		{
+			events.Event{Type: events.TypeNudgeEscalated, Actor: "daemon", Payload: events.NudgeEscalationPayload("gastown", "nux", 3, "respawn", time.Hour)},
+			"gastown/nux silent 1h0m0s after a nudge: respawned",
+		},
+		{
+			events.Event{Type: "custom", Actor: "mayor"},
+			"mayor: custom",
+		},
+	}
+	for _, tt := range tests {
+		if got := f.Format(tt.event); got != tt.want {
+			t.Errorf("Format(%s) = %q, want %q", tt.event.Type, got, tt.want)
+		}
+	}
+
+	for typ, src := range DefaultTemplates {
+		if src == "" {
+			t.Errorf("empty default template for %s", typ)
+		}
+	}
+}
+
+func TestFormatter_Overrides(t *testing.T) {
+	f, err := NewFormatter(map[string]string{
+		events.TypeHook: `{{.Who}} hooked {{.P "bead"}} ({{.P "priority"}} {{.P "kind"}})`,
+		"deploy":        "{{.Who}} deployed\n{{.P \"version\"}}",
+		events.TypeDone: `{{.P "missing"}}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hook := events.Event{Type: events.TypeHook, Actor: "gastown/polecats/Toast",
+		Payload: map[string]interface{}{"bead": "gt-abc", "priority": "P1", "kind": "bug"}}
+	if got, want := f.Format(hook), "Toast hooked gt-abc (P1 bug)"; got != want {
+		t.Errorf("hook = %q, want %q", got, want)
+	}
+
+	deploy := events.Event{Type: "deploy", Actor: "mayor", Payload: map[string]interface{}{"version": 2.0}}
+	if got, want := f.Format(deploy), "mayor deployed 2"; got != want {
+		t.Errorf("deploy = %q, want %q", got, want)
+	}
+
+	// A template that renders nothing falls back
+	done := events.Event{Type: events.TypeDone, Actor: "gastown/polecats/nux"}
+	if got, want := f.Format(done), "gastown/polecats/nux: done"; got != want {
+		t.Errorf("done = %q, want %q", got, want)
+	}
+
+	// Types not overridden keep their defaults
+	sling := events.Event{Type: events.TypeSling, Actor: "mayor", Payload: events.SlingPayload("gt-1", "gastown/polecats/nux")}
+	if got, want := f.Format(sling), "mayor assigned gt-1 to gastown/polecats/nux"; got != want {
+		t.Errorf("sling = %q, want %q", got, want)
+	}
+
+	if _, err := NewFormatter(map[string]string{"hook": "{{.Who"}); err == nil {
+		t.Error("NewFormatter accepted an unparseable template")
+	}
+}
+
+func TestLoadFormatter(t *testing.T) {
+	town := t.TempDir()
+	settings := `{"feed":{"templates":{"handoff":"{{.Who}} cycled"}}}`
+	if err := os.MkdirAll(filepath.Join(town, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(town, "settings", "config.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := LoadFormatter(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := events.Event{Type: events.TypeHandoff, Actor: "gastown/witness"}
+	if got, want := f.Format(e), "witness cycled"; got != want {
+		t.Errorf("Format = %q, want %q", got, want)
+	}
+
+	// The curator renders summaries with the same templates
+	if got, want := NewCurator(town).generateSummary(&e), "witness cycled"; got != want {
+		t.Errorf("generateSummary = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package feed
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// DefaultNudgeCoalesce is how long repeated nudges of one target are
+// folded together when the town settings don't say.
+const DefaultNudgeCoalesce = 5 * time.Minute
+
+// Rules decide which raw events reach the feed.
+type Rules struct {
+	Visibility []string // Visibilities copied to the feed
+	Types      []string // Type whitelist; empty allows every type
+
+	// RateLimit caps feed events per actor per RateWindow; zero is
+	// unlimited. Dropped events are counted on the actor's next entry.
+	RateLimit  int
+	RateWindow time.Duration
+
+	// NudgeCoalesce folds repeat nudges of a target within this window
+	// into one follow-up entry; zero writes every nudge.
+	NudgeCoalesce time.Duration
+}
+
+// DefaultRules copies feed-visible events of every type, unthrottled,
+// coalescing nudges over DefaultNudgeCoalesce.
+func DefaultRules() Rules {
+	return Rules{
+		Visibility:    []string{events.VisibilityFeed, events.VisibilityBoth},
+		NudgeCoalesce: DefaultNudgeCoalesce,
+	}
+}
+
+// RulesFromConfig applies a town's feed settings over DefaultRules.
+func RulesFromConfig(cfg *config.FeedConfig) (Rules, error) {
+	r := DefaultRules()
+	if cfg == nil {
+		return r, nil
+	}
+	for _, v := range cfg.Visibility {
+		if v != events.VisibilityAudit && v != events.VisibilityFeed && v != events.VisibilityBoth {
+			return r, fmt.Errorf("invalid feed visibility %q (want audit, feed, or both)", v)
+		}
+	}
+	if len(cfg.Visibility) > 0 {
+		r.Visibility = cfg.Visibility
+	}
+	r.Types = cfg.Types
+	n, window, err := cfg.RateLimitSpec()
+	if err != nil {
+		return r, err
+	}
+	r.RateLimit, r.RateWindow = n, window
+	if r.NudgeCoalesce, err = cfg.NudgeCoalesceWindow(DefaultNudgeCoalesce); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// LoadRules reads the feed rules from a town's settings.
+func LoadRules(townRoot string) (Rules, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return DefaultRules(), err
+	}
+	return RulesFromConfig(settings.Feed)
+}
+
+// admits reports whether an event's visibility and type pass the rules.
+func (r Rules) admits(e *events.Event) bool {
+	if !slices.Contains(r.Visibility, e.Visibility) {
+		return false
+	}
+	return len(r.Types) == 0 || slices.Contains(r.Types, e.Type)
+}
+
+// isNudge reports whether an event is a nudge subject to coalescing.
+func isNudge(e *events.Event) bool {
+	return e.Type == events.TypeNudge || e.Type == events.TypePolecatNudged
+}
+
+// nudgeTarget identifies who was nudged, for coalescing.
+func nudgeTarget(e *events.Event) string {
+	for _, key := range []string{"target", "polecat"} {
+		if s, ok := e.Payload[key].(string); ok && s != "" {
+			if rig, ok := e.Payload["rig"].(string); ok && rig != "" {
+				return rig + "/" + s
+			}
+			return s
+		}
+	}
+	return e.Actor
+}
@@ -0,0 +1,192 @@
+// Package flock provides advisory cross-process file locks.
+//
+// Locks are OS-level (flock on Unix, LockFileEx on Windows) via
+// gofrs/flock, so a crashed holder's lock is released by the kernel. The
+// lock file also records the holder's PID, host, and acquisition time;
+// that record is used to break locks left behind on filesystems where
+// advisory locks are unreliable (e.g. some network mounts) and to report
+// who is holding a lock on timeout.
+//
+// Use AppendFile for the common case of appending records to a shared log
+// (events, audit) from several gt processes at once.
+package flock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	gflock "github.com/gofrs/flock"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// ErrTimeout is returned when a lock can't be acquired in time.
+var ErrTimeout = errors.New("timed out waiting for lock")
+
+// DefaultTimeout is how long AppendFile and With wait for a lock.
+const DefaultTimeout = 5 * time.Second
+
+// DefaultStaleAfter is how old a dead holder's record must be before the
+// lock is broken.
+const DefaultStaleAfter = 30 * time.Second
+
+// retryInterval is the polling interval while waiting for a lock.
+const retryInterval = 10 * time.Millisecond
+
+// Holder describes the process holding a lock.
+type Holder struct {
+	PID        int       `json:"pid"`
+	Hostname   string    `json:"hostname,omitempty"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// IsStale reports whether the holder is a dead process on this host that
+// acquired the lock more than staleAfter ago. Holders on other hosts are
+// never considered stale: their PIDs can't be checked.
+func (h *Holder) IsStale(staleAfter time.Duration) bool {
+	host, _ := os.Hostname()
+	if h.Hostname != "" && h.Hostname != host {
+		return false
+	}
+	return time.Since(h.AcquiredAt) > staleAfter && !util.ProcessExists(h.PID)
+}
+
+// Lock is an advisory lock on a file.
+type Lock struct {
+	path string
+	fl   *gflock.Flock
+
+	// StaleAfter controls stale-lock breaking; zero uses DefaultStaleAfter.
+	StaleAfter time.Duration
+}
+
+// New returns a lock using path as the lock file.
+func New(path string) *Lock {
+	return &Lock{path: path, fl: gflock.New(path)}
+}
+
+// For returns a lock guarding the data file at path, using "<path>.lock".
+func For(path string) *Lock {
+	return New(path + ".lock")
+}
+
+// Path returns the lock file path.
+func (l *Lock) Path() string {
+	return l.path
+}
+
+// TryLock attempts to take the lock without waiting.
+func (l *Lock) TryLock() (bool, error) {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return false, fmt.Errorf("creating lock directory: %w", err)
+	}
+	ok, err := l.fl.TryLock()
+	if err != nil || !ok {
+		return ok, err
+	}
+	l.writeHolder()
+	return true, nil
+}
+
+// Lock waits up to timeout for the lock. If the wait times out and the
+// recorded holder is stale, the lock file is replaced and acquisition is
+// retried once. A timeout error names the holder when known.
+func (l *Lock) Lock(timeout time.Duration) error {
+	if ok, err := l.wait(timeout); err != nil || ok {
+		return err
+	}
+
+	staleAfter := l.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+	holder, _ := l.Holder()
+	if holder != nil && holder.IsStale(staleAfter) {
+		_ = os.Remove(l.path)
+		l.fl = gflock.New(l.path)
+		if ok, err := l.TryLock(); err != nil || ok {
+			return err
+		}
+	}
+
+	if holder != nil {
+		return fmt.Errorf("%w %s (held by pid %d since %s)", ErrTimeout, l.path, holder.PID, holder.AcquiredAt.Format(time.RFC3339))
+	}
+	return fmt.Errorf("%w %s", ErrTimeout, l.path)
+}
+
+func (l *Lock) wait(timeout time.Duration) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := l.TryLock()
+		if err != nil || ok {
+			return ok, err
+		}
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+		time.Sleep(retryInterval)
+	}
+}
+
+// Unlock releases the lock. The lock file is left in place.
+func (l *Lock) Unlock() error {
+	return l.fl.Unlock()
+}
+
+// Holder reads the holder record from the lock file.
+func (l *Lock) Holder() (*Holder, error) {
+	data, err := os.ReadFile(l.path) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var h Holder
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// writeHolder records this process as the holder (best-effort).
+func (l *Lock) writeHolder() {
+	host, _ := os.Hostname()
+	data, err := json.Marshal(Holder{PID: os.Getpid(), Hostname: host, AcquiredAt: time.Now()})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(l.path, data, 0644) //nolint:gosec // G306: lock record is non-sensitive
+}
+
+// With runs fn while holding the lock for the data file at path.
+func With(path string, timeout time.Duration, fn func() error) error {
+	l := For(path)
+	if err := l.Lock(timeout); err != nil {
+		return err
+	}
+	defer func() { _ = l.Unlock() }()
+	return fn()
+}
+
+// AppendFile appends data to path under its lock, creating the file with
+// perm if needed. Concurrent appenders in other processes can't interleave
+// partial records.
+func AppendFile(path string, data []byte, perm os.FileMode) error {
+	return With(path, DefaultTimeout, func() error {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, perm) //nolint:gosec // G304: path is constructed internally
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(data); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	})
+}
@@ -0,0 +1,137 @@
+package flock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockExclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.lock")
+	a, b := New(path), New(path)
+
+	if err := a.Lock(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	err := b.Lock(50 * time.Millisecond)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("second Lock = %v, want ErrTimeout", err)
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf("pid %d", os.Getpid())) {
+		t.Errorf("timeout error doesn't name holder: %v", err)
+	}
+
+	if err := a.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Lock(time.Second); err != nil {
+		t.Errorf("Lock after release = %v", err)
+	}
+	_ = b.Unlock()
+}
+
+func TestHolderIsStale(t *testing.T) {
+	host, _ := os.Hostname()
+	old := time.Now().Add(-time.Hour)
+
+	if !(&Holder{PID: 999999999, Hostname: host, AcquiredAt: old}).IsStale(time.Minute) {
+		t.Error("dead old holder should be stale")
+	}
+	if (&Holder{PID: os.Getpid(), Hostname: host, AcquiredAt: old}).IsStale(time.Minute) {
+		t.Error("live holder should not be stale")
+	}
+	if (&Holder{PID: 999999999, Hostname: host, AcquiredAt: time.Now()}).IsStale(time.Minute) {
+		t.Error("recent holder should not be stale")
+	}
+	if (&Holder{PID: 999999999, Hostname: "elsewhere.invalid", AcquiredAt: old}).IsStale(time.Minute) {
+		t.Error("holder on another host should not be stale")
+	}
+}
+
+func TestAppendFileConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+	line := strings.Repeat("x", 4096) + "\n"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := AppendFile(path, []byte(line), 0644); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 20 {
+		t.Fatalf("got %d lines, want 20", len(lines))
+	}
+	for _, l := range lines {
+		if l+"\n" != line {
+			t.Fatal("interleaved write detected")
+		}
+	}
+}
+
+// TestAppendFileHelperProcess is not a real test: it is the child process
+// for TestAppendFileCrossProcess.
+func TestAppendFileHelperProcess(t *testing.T) {
+	path := os.Getenv("GT_FLOCK_APPEND_PATH")
+	if path == "" {
+		t.Skip("helper process only")
+	}
+	line := strings.Repeat(os.Getenv("GT_FLOCK_APPEND_CHAR"), 64*1024) + "\n"
+	for i := 0; i < 10; i++ {
+		if err := AppendFile(path, []byte(line), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAppendFileCrossProcess(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns processes")
+	}
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+
+	var cmds []*exec.Cmd
+	for _, c := range []string{"a", "b", "c", "d"} {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestAppendFileHelperProcess$") //nolint:gosec // G204: re-exec of the test binary
+		cmd.Env = append(os.Environ(), "GT_FLOCK_APPEND_PATH="+path, "GT_FLOCK_APPEND_CHAR="+c)
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	for _, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("helper process: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 40 {
+		t.Fatalf("got %d lines, want 40", len(lines))
+	}
+	for _, l := range lines {
+		if len(l) != 64*1024 || strings.Trim(l, l[:1]) != "" {
+			t.Fatal("interleaved write detected")
+		}
+	}
+}
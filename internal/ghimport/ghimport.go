@@ -0,0 +1,334 @@
+// Package ghimport imports open GitHub issues and milestones into beads.
+//
+// It is the onboarding path for projects that track work only in GitHub:
+// milestones become epics, issues become tasks (parented to their
+// milestone's epic), and a mapping file records which bead came from which
+// GitHub number so re-running the import is idempotent.
+package ghimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Labels added to imported beads.
+const (
+	LabelImported = "gh:imported"
+	LabelIssue    = "gh:issue" // gh:issue:<number>
+	LabelEpic     = "gh:milestone"
+)
+
+// PriorityTracker is the tracker name GitHub priority labels are
+// configured under in the town's priorities.external table.
+const PriorityTracker = "github"
+
+// MappingFileName is the bridge mapping file kept in the rig root.
+const MappingFileName = "github.json"
+
+// Milestone is an open GitHub milestone.
+type Milestone struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// Issue is an open GitHub issue.
+type Issue struct {
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	URL       string     `json:"url"`
+	Labels    []string   `json:"labels"`
+	Milestone *Milestone `json:"milestone,omitempty"`
+}
+
+// Fetcher reads open issues and milestones from a repository.
+type Fetcher interface {
+	Milestones(repo string) ([]Milestone, error)
+	Issues(repo string) ([]Issue, error)
+}
+
+// Mapping links GitHub numbers to bead IDs for one repository.
+type Mapping struct {
+	Repo       string            `json:"repo"`
+	Issues     map[string]string `json:"issues"`     // issue number -> bead ID
+	Milestones map[string]string `json:"milestones"` // milestone number -> epic bead ID
+
+	// Maintained by gt sync-github (package ghsync).
+	LastSync time.Time             `json:"last_sync,omitzero"` // newest GitHub updated_at seen
+	Synced   map[string]SyncRecord `json:"synced,omitempty"`   // issue number -> last agreed state
+}
+
+// SyncRecord holds both sides' update times from the last sync in which
+// a bead and its issue agreed. A side updated after its time has changed.
+type SyncRecord struct {
+	BeadUpdated  time.Time `json:"bead_updated"`
+	IssueUpdated time.Time `json:"issue_updated"`
+}
+
+// MappingPath returns the mapping file path for a rig.
+func MappingPath(rigPath string) string {
+	return filepath.Join(rigPath, MappingFileName)
+}
+
+// LoadMapping loads a rig's mapping, returning an empty one if missing.
+func LoadMapping(rigPath, repo string) (*Mapping, error) {
+	m := &Mapping{Repo: repo, Issues: map[string]string{}, Milestones: map[string]string{}, Synced: map[string]SyncRecord{}}
+	data, err := os.ReadFile(MappingPath(rigPath)) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", MappingFileName, err)
+	}
+	if m.Repo != "" && repo != "" && m.Repo != repo {
+		return nil, fmt.Errorf("rig is already mapped to %s", m.Repo)
+	}
+	if m.Issues == nil {
+		m.Issues = map[string]string{}
+	}
+	if m.Milestones == nil {
+		m.Milestones = map[string]string{}
+	}
+	if m.Synced == nil {
+		m.Synced = map[string]SyncRecord{}
+	}
+	return m, nil
+}
+
+// Save writes the mapping to the rig root.
+func (m *Mapping) Save(rigPath string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(MappingPath(rigPath), data, 0644) //nolint:gosec // G306: mapping is non-sensitive
+}
+
+// Result summarizes an import.
+type Result struct {
+	Repo              string   `json:"repo"`
+	EpicsCreated      []string `json:"epics_created"`
+	IssuesCreated     []string `json:"issues_created"`
+	Skipped           int      `json:"skipped"` // already imported
+	MilestonesScanned int      `json:"milestones_scanned"`
+	IssuesScanned     int      `json:"issues_scanned"`
+}
+
+// Importer creates beads from GitHub data.
+type Importer struct {
+	Fetcher Fetcher
+	Beads   beads.Client
+	DryRun  bool
+
+	// Priorities maps priority labels to bd values; nil uses the
+	// built-in levels.
+	Priorities *config.PriorityConfig
+}
+
+// Import brings open milestones and issues from repo into beads, updating
+// mapping in place. Items already in mapping are skipped.
+func (im *Importer) Import(repo string, mapping *Mapping) (*Result, error) {
+	milestones, err := im.Fetcher.Milestones(repo)
+	if err != nil {
+		return nil, fmt.Errorf("fetching milestones: %w", err)
+	}
+	issues, err := im.Fetcher.Issues(repo)
+	if err != nil {
+		return nil, fmt.Errorf("fetching issues: %w", err)
+	}
+	sort.Slice(milestones, func(i, j int) bool { return milestones[i].Number < milestones[j].Number })
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Number < issues[j].Number })
+
+	result := &Result{Repo: repo, MilestonesScanned: len(milestones), IssuesScanned: len(issues)}
+	mapping.Repo = repo
+
+	for _, ms := range milestones {
+		key := strconv.Itoa(ms.Number)
+		if _, ok := mapping.Milestones[key]; ok {
+			result.Skipped++
+			continue
+		}
+		opts := beads.CreateOptions{
+			Title:       ms.Title,
+			Type:        "epic",
+			Priority:    beads.PriorityMedium,
+			Description: ms.Description,
+			Labels:      []string{LabelImported, fmt.Sprintf("%s:%d", LabelEpic, ms.Number)},
+		}
+		id, err := im.create(opts)
+		if err != nil {
+			return result, fmt.Errorf("importing milestone %q: %w", ms.Title, err)
+		}
+		mapping.Milestones[key] = id
+		result.EpicsCreated = append(result.EpicsCreated, id)
+	}
+
+	for _, gi := range issues {
+		key := strconv.Itoa(gi.Number)
+		if _, ok := mapping.Issues[key]; ok {
+			result.Skipped++
+			continue
+		}
+		opts := IssueCreateOptions(gi, im.Priorities)
+		if gi.Milestone != nil {
+			opts.Parent = mapping.Milestones[strconv.Itoa(gi.Milestone.Number)]
+		}
+		id, err := im.create(opts)
+		if err != nil {
+			return result, fmt.Errorf("importing issue #%d: %w", gi.Number, err)
+		}
+		mapping.Issues[key] = id
+		result.IssuesCreated = append(result.IssuesCreated, id)
+	}
+
+	return result, nil
+}
+
+func (im *Importer) create(opts beads.CreateOptions) (string, error) {
+	if im.DryRun {
+		return "(dry-run)", nil
+	}
+	issue, err := im.Beads.Create(opts)
+	if err != nil {
+		return "", err
+	}
+	return issue.ID, nil
+}
+
+// IssueCreateOptions maps a GitHub issue to bead creation options. Type
+// comes from "bug"/"enhancement" labels and priority from labels such as
+// "P1" or "priority:high", resolved through priorities (the town's
+// GitHub labels first, then its level names); other labels are carried
+// over unchanged.
+func IssueCreateOptions(gi Issue, priorities *config.PriorityConfig) beads.CreateOptions {
+	opts := beads.CreateOptions{
+		Title:    gi.Title,
+		Type:     "task",
+		Priority: beads.PriorityMedium,
+		Labels:   []string{LabelImported, fmt.Sprintf("%s:%d", LabelIssue, gi.Number)},
+	}
+
+	desc := strings.TrimSpace(gi.Body)
+	if gi.URL != "" {
+		desc = strings.TrimSpace(desc + "\n\nImported from " + gi.URL)
+	}
+	opts.Description = desc
+
+	for _, label := range gi.Labels {
+		l := strings.ToLower(label)
+		switch l {
+		case "bug":
+			opts.Type = "bug"
+			continue
+		case "enhancement", "feature":
+			opts.Type = "feature"
+			continue
+		}
+		if p, ok := priorities.FromExternal(PriorityTracker, label); ok {
+			opts.Priority = p
+			continue
+		}
+		if p, ok := priorities.Resolve(strings.TrimPrefix(l, "priority:")); ok {
+			opts.Priority = p
+			continue
+		}
+		opts.Labels = append(opts.Labels, label)
+	}
+	return opts
+}
+
+// GHFetcher reads from GitHub using the gh CLI.
+type GHFetcher struct {
+	Limit int // max issues to fetch (default 1000)
+}
+
+// Milestones lists open milestones via the GitHub API.
+func (f GHFetcher) Milestones(repo string) ([]Milestone, error) {
+	out, err := gh("api", "--paginate", fmt.Sprintf("repos/%s/milestones?state=open", repo))
+	if err != nil {
+		return nil, err
+	}
+	var ms []Milestone
+	if err := json.Unmarshal(out, &ms); err != nil {
+		return nil, fmt.Errorf("parsing milestones: %w", err)
+	}
+	return ms, nil
+}
+
+// Issues lists open issues (excluding pull requests).
+func (f GHFetcher) Issues(repo string) ([]Issue, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	out, err := gh("issue", "list", "--repo", repo, "--state", "open",
+		"--limit", strconv.Itoa(limit), "--json", "number,title,body,url,labels,milestone")
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Issue
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("parsing issues: %w", err)
+	}
+	issues := make([]Issue, 0, len(raw))
+	for _, r := range raw {
+		gi := r.Issue
+		gi.Labels = nil
+		for _, l := range r.Labels {
+			gi.Labels = append(gi.Labels, l.Name)
+		}
+		issues = append(issues, gi)
+	}
+	return issues, nil
+}
+
+func gh(args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return nil, fmt.Errorf("gh CLI not found: install from https://cli.github.com")
+	}
+	cmd := exec.Command("gh", args...) //nolint:gosec // G204: args are constructed internally
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("gh %s: %s", args[0], strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("gh %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// RepoFromURL extracts "owner/name" from a GitHub URL or returns s if it
+// is already in that form.
+func RepoFromURL(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "/")
+	s = strings.TrimSuffix(s, ".git")
+	for _, prefix := range []string{"https://github.com/", "http://github.com/", "git@github.com:", "ssh://git@github.com/"} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("not a GitHub repository: %q (want owner/name)", s)
+	}
+	return s, nil
+}
@@ -0,0 +1,103 @@
+package ghimport
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+type fakeFetcher struct {
+	milestones []Milestone
+	issues     []Issue
+}
+
+func (f fakeFetcher) Milestones(string) ([]Milestone, error) { return f.milestones, nil }
+func (f fakeFetcher) Issues(string) ([]Issue, error)         { return f.issues, nil }
+
+func TestImport(t *testing.T) {
+	v1 := &Milestone{Number: 1, Title: "v1.0"}
+	fetcher := fakeFetcher{
+		milestones: []Milestone{*v1},
+		issues: []Issue{
+			{Number: 12, Title: "Crash on start", Labels: []string{"bug", "P1", "ui"}, Milestone: v1},
+			{Number: 7, Title: "Add dark mode", Labels: []string{"enhancement"}},
+		},
+	}
+	fake := beadstest.NewFakeClient()
+	mapping, _ := LoadMapping(t.TempDir(), "me/app")
+	im := &Importer{Fetcher: fetcher, Beads: fake}
+
+	result, err := im.Import("me/app", mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.EpicsCreated) != 1 || len(result.IssuesCreated) != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	epicID := mapping.Milestones["1"]
+	crash, err := fake.Show(mapping.Issues["12"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crash.Type != "bug" || crash.Priority != beads.PriorityHigh || crash.Parent != epicID {
+		t.Errorf("crash bead = %+v (epic %s)", crash, epicID)
+	}
+
+	// Re-running imports nothing new.
+	result, err = im.Import("me/app", mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.IssuesCreated) != 0 || result.Skipped != 3 {
+		t.Errorf("second import = %+v", result)
+	}
+}
+
+func TestIssueCreateOptions(t *testing.T) {
+	opts := IssueCreateOptions(Issue{Number: 3, Title: "t", Body: "body", URL: "https://github.com/me/app/issues/3",
+		Labels: []string{"priority:critical", "docs"}}, nil)
+	if opts.Priority != beads.PriorityCritical || opts.Type != "task" {
+		t.Errorf("opts = %+v", opts)
+	}
+	want := []string{LabelImported, "gh:issue:3", "docs"}
+	if len(opts.Labels) != len(want) {
+		t.Fatalf("labels = %v, want %v", opts.Labels, want)
+	}
+	for i := range want {
+		if opts.Labels[i] != want[i] {
+			t.Errorf("labels = %v, want %v", opts.Labels, want)
+		}
+	}
+}
+
+func TestIssueCreateOptions_TownPriorities(t *testing.T) {
+	priorities := &config.PriorityConfig{
+		Levels:   []config.PriorityLevel{{Name: "now", Value: 0}, {Name: "soon", Value: 2}},
+		External: map[string]map[string]int{PriorityTracker: {"Sev1": 0, "Sev3": 3}},
+	}
+	for label, want := range map[string]int{"sev3": 3, "priority:soon": 2, "P1": 1} {
+		opts := IssueCreateOptions(Issue{Number: 1, Title: "t", Labels: []string{label}}, priorities)
+		if opts.Priority != want || len(opts.Labels) != 2 {
+			t.Errorf("%s: priority %d, labels %v; want %d and no carried label", label, opts.Priority, opts.Labels, want)
+		}
+	}
+}
+
+func TestRepoFromURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"me/app":                         "me/app",
+		"https://github.com/me/app":      "me/app",
+		"https://github.com/me/app.git/": "me/app",
+		"git@github.com:me/app.git":      "me/app",
+	} {
+		if got, err := RepoFromURL(in); err != nil || got != want {
+			t.Errorf("RepoFromURL(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := RepoFromURL("https://gitlab.com/me/app"); err == nil {
+		t.Error("expected error for non-GitHub URL")
+	}
+}
@@ -0,0 +1,291 @@
+// Package ghpr merges work through GitHub pull requests instead of the
+// Refinery, for rigs with merge_queue.github_prs set.
+//
+// gt done files an MR bead as usual and then calls Publish, which opens a
+// pull request from the MR's Branch into its Target (or updates the one
+// already open) and records its URL in the MR's pr_url field. The daemon
+// calls Reconcile on open MRs: when a pull request merges, its MR is
+// closed as merged with the merge commit and its source issue is closed,
+// as the Refinery would; when it is closed unmerged, the MR is closed as
+// rejected.
+package ghpr
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/ghimport"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// Pull request states, as gh reports them.
+const (
+	StateOpen   = "OPEN"
+	StateClosed = "CLOSED"
+	StateMerged = "MERGED"
+)
+
+// PR is a GitHub pull request.
+type PR struct {
+	Number      int    `json:"number"`
+	URL         string `json:"url"`
+	State       string `json:"state"`
+	MergeCommit string `json:"merge_commit,omitempty"` // Set once merged
+}
+
+// PRInput is what Publish writes to a pull request.
+type PRInput struct {
+	Head  string
+	Base  string
+	Title string
+	Body  string
+}
+
+// GitHub reads and writes a repository's pull requests.
+type GitHub interface {
+	// FindPR returns the open pull request from branch, or nil.
+	FindPR(repo, branch string) (*PR, error)
+	ViewPR(url string) (*PR, error)
+	CreatePR(repo string, in PRInput) (*PR, error)
+	EditPR(url string, in PRInput) (*PR, error)
+}
+
+// MRStore is the beads access Publish and Reconcile need.
+// *beads.Beads implements it.
+type MRStore interface {
+	Show(id string) (*beads.Issue, error)
+	StoreMRFields(issue *beads.Issue, fields *beads.MRFields) error
+	CloseMR(id, mergeCommit, reason string) error
+	CloseWithReason(reason string, ids ...string) error
+	UpdateAgentActiveMR(agentBeadID, mrID string) error
+}
+
+var _ MRStore = (*beads.Beads)(nil)
+
+// Enabled reports whether a rig merges through GitHub pull requests.
+func Enabled(rigPath string) bool {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	return err == nil && settings.MergeQueue != nil && settings.MergeQueue.GitHubPRs
+}
+
+// Repo returns the "owner/name" of the GitHub repository behind a
+// clone's origin remote.
+func Repo(workDir string) (string, error) {
+	url, err := git.NewGit(workDir).RemoteURL("origin")
+	if err != nil {
+		return "", fmt.Errorf("reading origin: %w", err)
+	}
+	return ghimport.RepoFromURL(url)
+}
+
+// Publish opens or updates the pull request for an MR and records its
+// URL on the MR. The title and description come from the source issue when
+// it can be read and is public.
+func Publish(gh GitHub, store MRStore, repo, mrID string) (*PR, error) {
+	mr, err := store.Show(mrID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching MR %s: %w", mrID, err)
+	}
+	fields := beads.ParseMRFields(mr)
+	if fields == nil || fields.Branch == "" || fields.Target == "" {
+		return nil, fmt.Errorf("MR %s has no branch and target", mrID)
+	}
+
+	in := PRInput{Head: fields.Branch, Base: fields.Target, Title: mr.Title, Body: prBody(mr.ID, fields, nil)}
+	if fields.SourceIssue != "" {
+		// Internal and private beads keep the MR's own title and a body
+		// without their description: the PR is public on GitHub
+		if src, err := store.Show(fields.SourceIssue); err == nil && beads.VisibleTo(src, beads.VisibilityPublic) {
+			in.Title = src.Title
+			in.Body = prBody(mr.ID, fields, src)
+		}
+	}
+
+	var pr *PR
+	if fields.PRURL != "" {
+		if pr, err = gh.ViewPR(fields.PRURL); err != nil {
+			return nil, err
+		}
+		if pr.State != StateOpen {
+			pr = nil // Superseded; open a fresh one
+		}
+	}
+	if pr == nil {
+		if pr, err = gh.FindPR(repo, fields.Branch); err != nil {
+			return nil, err
+		}
+	}
+	if pr == nil {
+		pr, err = gh.CreatePR(repo, in)
+	} else {
+		pr, err = gh.EditPR(pr.URL, in)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if fields.PRURL != pr.URL {
+		fields.PRURL = pr.URL
+		if err := store.StoreMRFields(mr, fields); err != nil {
+			return pr, fmt.Errorf("recording PR URL on %s: %w", mrID, err)
+		}
+	}
+	return pr, nil
+}
+
+// prBody describes an MR for reviewers on GitHub.
+func prBody(mrID string, fields *beads.MRFields, src *beads.Issue) string {
+	var b strings.Builder
+	if src != nil && strings.TrimSpace(src.Description) != "" {
+		b.WriteString(strings.TrimSpace(src.Description))
+		b.WriteString("\n\n---\n")
+	}
+	fmt.Fprintf(&b, "Gas Town MR: `%s`\n", mrID)
+	if fields.SourceIssue != "" {
+		fmt.Fprintf(&b, "Source issue: `%s`\n", fields.SourceIssue)
+	}
+	if fields.Worker != "" {
+		fmt.Fprintf(&b, "Worker: %s\n", fields.Worker)
+	}
+	return b.String()
+}
+
+// Outcome is what Reconcile did with one MR.
+type Outcome struct {
+	MR          string `json:"mr"`
+	Worker      string `json:"worker,omitempty"`
+	Branch      string `json:"branch,omitempty"`
+	PRURL       string `json:"pr_url"`
+	State       string `json:"state"` // StateMerged or StateClosed
+	MergeCommit string `json:"merge_commit,omitempty"`
+	Error       error  `json:"-"`
+}
+
+// Reconcile closes the MRs among mrs whose pull requests have merged or
+// closed. MRs without a PR URL are skipped. Per-MR failures are recorded
+// in the outcomes and don't stop the others; cleanup after a merge (the
+// source issue, the agent's active_mr) is best effort.
+func Reconcile(gh GitHub, store MRStore, mrs []*beads.Issue) []Outcome {
+	var out []Outcome
+	for _, mr := range mrs {
+		fields := beads.ParseMRFields(mr)
+		if fields == nil || fields.PRURL == "" {
+			continue
+		}
+		pr, err := gh.ViewPR(fields.PRURL)
+		if err != nil {
+			out = append(out, Outcome{MR: mr.ID, PRURL: fields.PRURL, Error: err})
+			continue
+		}
+		switch pr.State {
+		case StateMerged:
+			err = store.CloseMR(mr.ID, pr.MergeCommit, "merged")
+			if err == nil && fields.SourceIssue != "" {
+				_ = store.CloseWithReason(fmt.Sprintf("Merged in %s", mr.ID), fields.SourceIssue)
+			}
+			if err == nil && fields.AgentBead != "" {
+				_ = store.UpdateAgentActiveMR(fields.AgentBead, "")
+			}
+		case StateClosed:
+			err = store.CloseMR(mr.ID, "", "rejected")
+		default:
+			continue
+		}
+		out = append(out, Outcome{MR: mr.ID, Worker: fields.Worker, Branch: fields.Branch, PRURL: pr.URL,
+			State: pr.State, MergeCommit: pr.MergeCommit, Error: err})
+	}
+	return out
+}
+
+// GHClient talks to GitHub through the gh CLI.
+type GHClient struct{}
+
+const prJSONFields = "number,url,state,mergeCommit"
+
+// ghPR is a pull request as gh --json prints it.
+type ghPR struct {
+	Number      int    `json:"number"`
+	URL         string `json:"url"`
+	State       string `json:"state"`
+	MergeCommit *struct {
+		OID string `json:"oid"`
+	} `json:"mergeCommit"`
+}
+
+func (p ghPR) pr() *PR {
+	pr := &PR{Number: p.Number, URL: p.URL, State: p.State}
+	if p.MergeCommit != nil {
+		pr.MergeCommit = p.MergeCommit.OID
+	}
+	return pr
+}
+
+// FindPR returns the open pull request from branch, or nil.
+func (GHClient) FindPR(repo, branch string) (*PR, error) {
+	out, err := gh("pr", "list", "--repo", repo, "--head", branch, "--state", "open", "--json", prJSONFields, "--limit", "1")
+	if err != nil {
+		return nil, err
+	}
+	var prs []ghPR
+	if err := json.Unmarshal(out, &prs); err != nil {
+		return nil, fmt.Errorf("parsing pull requests: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0].pr(), nil
+}
+
+// ViewPR fetches a pull request by URL.
+func (GHClient) ViewPR(url string) (*PR, error) {
+	out, err := gh("pr", "view", url, "--json", prJSONFields)
+	if err != nil {
+		return nil, err
+	}
+	var p ghPR
+	if err := json.Unmarshal(out, &p); err != nil {
+		return nil, fmt.Errorf("parsing pull request: %w", err)
+	}
+	return p.pr(), nil
+}
+
+// CreatePR opens a pull request.
+func (c GHClient) CreatePR(repo string, in PRInput) (*PR, error) {
+	out, err := gh("pr", "create", "--repo", repo, "--head", in.Head, "--base", in.Base, "--title", in.Title, "--body", in.Body)
+	if err != nil {
+		return nil, err
+	}
+	// gh prints the new pull request's URL last
+	lines := strings.Fields(strings.TrimSpace(string(out)))
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("gh pr create printed no URL")
+	}
+	return c.ViewPR(lines[len(lines)-1])
+}
+
+// EditPR updates a pull request's base, title, and body.
+func (c GHClient) EditPR(url string, in PRInput) (*PR, error) {
+	if _, err := gh("pr", "edit", url, "--base", in.Base, "--title", in.Title, "--body", in.Body); err != nil {
+		return nil, err
+	}
+	return c.ViewPR(url)
+}
+
+func gh(args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return nil, fmt.Errorf("gh CLI not found: install from https://cli.github.com")
+	}
+	cmd := exec.Command("gh", args...) //nolint:gosec // G204: args are constructed internally
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("gh %s %s: %s", args[0], args[1], strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("gh %s %s: %w", args[0], args[1], err)
+	}
+	return out, nil
+}
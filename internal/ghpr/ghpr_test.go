@@ -0,0 +1,168 @@
+package ghpr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+type fakeGitHub struct {
+	prs     map[string]*PR // URL -> PR
+	heads   map[string]string
+	created int
+	edits   []PRInput
+}
+
+func newFakeGitHub() *fakeGitHub {
+	return &fakeGitHub{prs: map[string]*PR{}, heads: map[string]string{}}
+}
+
+func (f *fakeGitHub) FindPR(_, branch string) (*PR, error) {
+	if pr := f.prs[f.heads[branch]]; pr != nil && pr.State == StateOpen {
+		return pr, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeGitHub) ViewPR(url string) (*PR, error) {
+	if pr := f.prs[url]; pr != nil {
+		c := *pr
+		return &c, nil
+	}
+	return nil, fmt.Errorf("no such PR %s", url)
+}
+
+func (f *fakeGitHub) CreatePR(repo string, in PRInput) (*PR, error) {
+	f.created++
+	pr := &PR{Number: f.created, URL: fmt.Sprintf("https://github.com/%s/pull/%d", repo, f.created), State: StateOpen}
+	f.prs[pr.URL] = pr
+	f.heads[in.Head] = pr.URL
+	f.edits = append(f.edits, in)
+	return pr, nil
+}
+
+func (f *fakeGitHub) EditPR(url string, in PRInput) (*PR, error) {
+	f.edits = append(f.edits, in)
+	return f.ViewPR(url)
+}
+
+// fakeStore keeps MR fields in descriptions, as bd without metadata does.
+type fakeStore struct {
+	issues  map[string]*beads.Issue
+	reasons map[string]string
+}
+
+func (s *fakeStore) Show(id string) (*beads.Issue, error) {
+	if issue := s.issues[id]; issue != nil {
+		c := *issue
+		return &c, nil
+	}
+	return nil, beads.ErrNotFound
+}
+
+func (s *fakeStore) StoreMRFields(issue *beads.Issue, fields *beads.MRFields) error {
+	s.issues[issue.ID].Description = beads.SetMRFields(issue, fields)
+	return nil
+}
+
+func (s *fakeStore) CloseMR(id, mergeCommit, reason string) error {
+	fields := beads.ParseMRFields(s.issues[id])
+	fields.MergeCommit, fields.CloseReason = mergeCommit, reason
+	s.issues[id].Description = beads.SetMRFields(s.issues[id], fields)
+	return s.CloseWithReason(reason, id)
+}
+
+func (s *fakeStore) CloseWithReason(reason string, ids ...string) error {
+	for _, id := range ids {
+		s.issues[id].Status = "closed"
+		s.reasons[id] = reason
+	}
+	return nil
+}
+
+func (s *fakeStore) UpdateAgentActiveMR(string, string) error { return nil }
+
+func TestPublishAndReconcile(t *testing.T) {
+	gh := newFakeGitHub()
+	store := &fakeStore{reasons: map[string]string{}, issues: map[string]*beads.Issue{
+		"gt-abc": {ID: "gt-abc", Title: "Fix login", Description: "Users can't log in"},
+		"gt-mr1": {ID: "gt-mr1", Title: "Merge: gt-abc", Description: beads.FormatMRFields(&beads.MRFields{
+			Branch: "polecat/nux/gt-abc", Target: "main", SourceIssue: "gt-abc", Worker: "nux"})},
+	}}
+
+	pr, err := Publish(gh, store, "me/app", "gt-mr1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := gh.edits[0]
+	if in.Head != "polecat/nux/gt-abc" || in.Base != "main" || in.Title != "Fix login" || !strings.Contains(in.Body, "Users can't log in") {
+		t.Errorf("PR input = %+v", in)
+	}
+	mr, _ := store.Show("gt-mr1")
+	if got := beads.ParseMRFields(mr).PRURL; got != pr.URL {
+		t.Errorf("pr_url = %q, want %q", got, pr.URL)
+	}
+
+	// Publishing again updates the same PR
+	if again, err := Publish(gh, store, "me/app", "gt-mr1"); err != nil || again.URL != pr.URL || gh.created != 1 {
+		t.Errorf("republish = %+v, %v (%d created)", again, err, gh.created)
+	}
+
+	// Still open: nothing to do
+	mrs := []*beads.Issue{mr}
+	if out := Reconcile(gh, store, mrs); len(out) != 0 {
+		t.Errorf("open PR outcomes = %+v", out)
+	}
+
+	gh.prs[pr.URL].State, gh.prs[pr.URL].MergeCommit = StateMerged, "abc123"
+	out := Reconcile(gh, store, mrs)
+	if len(out) != 1 || out[0].State != StateMerged || out[0].Error != nil {
+		t.Fatalf("merged outcomes = %+v", out)
+	}
+	mr, _ = store.Show("gt-mr1")
+	fields := beads.ParseMRFields(mr)
+	if mr.Status != "closed" || fields.MergeCommit != "abc123" || fields.CloseReason != "merged" {
+		t.Errorf("merged MR = %+v, fields %+v", mr, fields)
+	}
+	if src, _ := store.Show("gt-abc"); src.Status != "closed" || store.reasons["gt-abc"] != "Merged in gt-mr1" {
+		t.Errorf("source issue = %+v (reason %q)", src, store.reasons["gt-abc"])
+	}
+}
+
+func TestPublish_NonPublicSource(t *testing.T) {
+	gh := newFakeGitHub()
+	store := &fakeStore{reasons: map[string]string{}, issues: map[string]*beads.Issue{
+		"gt-sec": {ID: "gt-sec", Title: "Rotate leaked key", Description: "The key is in ops/keys",
+			Labels: []string{beads.VisibilityLabel(beads.VisibilityInternal)}},
+		"gt-mr1": {ID: "gt-mr1", Title: "Merge: gt-sec", Description: beads.FormatMRFields(&beads.MRFields{
+			Branch: "polecat/nux/gt-sec", Target: "main", SourceIssue: "gt-sec", Worker: "nux"})},
+	}}
+
+	if _, err := Publish(gh, store, "me/app", "gt-mr1"); err != nil {
+		t.Fatal(err)
+	}
+	in := gh.edits[0]
+	if in.Title != "Merge: gt-sec" || strings.Contains(in.Body, "ops/keys") {
+		t.Errorf("internal source issue published: %+v", in)
+	}
+}
+
+func TestReconcile_ClosedUnmerged(t *testing.T) {
+	gh := newFakeGitHub()
+	gh.prs["https://github.com/me/app/pull/9"] = &PR{Number: 9, URL: "https://github.com/me/app/pull/9", State: StateClosed}
+	mr := &beads.Issue{ID: "gt-mr2", Description: beads.FormatMRFields(&beads.MRFields{
+		Branch: "b", Target: "main", SourceIssue: "gt-src", PRURL: "https://github.com/me/app/pull/9"})}
+	store := &fakeStore{reasons: map[string]string{}, issues: map[string]*beads.Issue{
+		"gt-mr2": mr, "gt-src": {ID: "gt-src"}, "gt-nopr": {ID: "gt-nopr"},
+	}}
+
+	out := Reconcile(gh, store, []*beads.Issue{mr, store.issues["gt-nopr"]})
+	if len(out) != 1 || store.reasons["gt-mr2"] != "rejected" {
+		t.Errorf("outcomes = %+v, reasons %v", out, store.reasons)
+	}
+	if store.issues["gt-src"].Status == "closed" {
+		t.Error("source issue closed for an unmerged PR")
+	}
+}
@@ -0,0 +1,575 @@
+// Package ghsync mirrors a rig's beads to GitHub Issues and back, so
+// people without gt access can follow and steer town work from GitHub.
+//
+// Each bead is paired with one issue. The pairing is recorded on both
+// sides: the bead carries a gh:issue:<number> label and the issue body
+// ends with a hidden <!-- gastown:<bead-id> --> marker. The rig's
+// github.json mapping (see ghimport.Mapping) caches the pairs and the
+// update times from the last sync.
+//
+// Syncs are incremental. GitHub is asked only for issues updated since
+// the last sync, and a pair is written only when one side's UpdatedAt
+// moved past its recorded time. When both sides changed and disagree the
+// pair is reported as a conflict and left alone, unless a preferred side
+// is given.
+package ghsync
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/ghimport"
+)
+
+// Sides a conflict can be resolved toward.
+const (
+	PreferBeads  = "beads"
+	PreferGitHub = "github"
+)
+
+// markerRe finds the bead cross-reference at the end of an issue body.
+var markerRe = regexp.MustCompile(`\s*<!-- gastown:([A-Za-z0-9._-]+) -->\s*$`)
+
+// importedRe matches the provenance line ghimport appends to descriptions.
+var importedRe = regexp.MustCompile(`\s*Imported from https://github\.com/\S+\s*$`)
+
+// Marker returns the hidden cross-reference appended to an issue body.
+func Marker(beadID string) string {
+	return "<!-- gastown:" + beadID + " -->"
+}
+
+// BeadRef returns the bead ID an issue body refers to, or "".
+func BeadRef(body string) string {
+	if m := markerRe.FindStringSubmatch(body); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// Conflict is a pair whose sides both changed and disagree.
+type Conflict struct {
+	Bead   string   `json:"bead"`
+	Issue  int      `json:"issue"`
+	Fields []string `json:"fields"`
+}
+
+// Link is a newly paired bead and issue. In a dry run the side that
+// would be created is left zero.
+type Link struct {
+	Bead  string `json:"bead,omitempty"`
+	Issue int    `json:"issue,omitempty"`
+}
+
+// Result summarizes a sync.
+type Result struct {
+	Repo         string     `json:"repo"`
+	Imported     []Link     `json:"imported"`      // New issues that became beads
+	Exported     []Link     `json:"exported"`      // New beads that became issues
+	BeadsUpdated []string   `json:"beads_updated"` // Pulled from GitHub
+	IssuesEdited []int      `json:"issues_edited"` // Pushed from beads
+	Conflicts    []Conflict `json:"conflicts"`
+}
+
+// Syncer reconciles a rig's beads with a GitHub repository.
+type Syncer struct {
+	GitHub GitHub
+	Beads  beads.Client
+	Config *config.GitHubSyncConfig
+
+	// Priorities maps bead priorities to and from GitHub labels through
+	// its "github" external table. Without one, priority isn't synced.
+	Priorities *config.PriorityConfig
+
+	// Prefer resolves conflicts toward PreferBeads or PreferGitHub.
+	// Empty reports conflicts without writing either side.
+	Prefer string
+
+	DryRun bool
+}
+
+// Sync reconciles beads with repo, updating mapping in place. On error
+// the mapping still reflects every pair synced before the failure.
+func (s *Syncer) Sync(repo string, mapping *ghimport.Mapping) (*Result, error) {
+	if s.Prefer != "" && s.Prefer != PreferBeads && s.Prefer != PreferGitHub {
+		return nil, fmt.Errorf("invalid preferred side %q (want %s or %s)", s.Prefer, PreferBeads, PreferGitHub)
+	}
+	changed, err := s.GitHub.IssuesSince(repo, mapping.LastSync)
+	if err != nil {
+		return nil, fmt.Errorf("fetching issues: %w", err)
+	}
+	all, err := s.Beads.List(beads.ListOptions{Status: "all", Priority: -1, Limit: beads.NoLimit})
+	if err != nil {
+		return nil, fmt.Errorf("listing beads: %w", err)
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Number < changed[j].Number })
+
+	run := &syncRun{Syncer: s, repo: repo, mapping: mapping, result: &Result{Repo: repo},
+		beads: make(map[string]*beads.Issue, len(all)), issueOf: make(map[string]int), beadOf: make(map[int]string)}
+	mapping.Repo = repo
+	for _, b := range all {
+		run.beads[b.ID] = b
+		if n := labeledIssue(b); n > 0 {
+			run.issueOf[b.ID], run.beadOf[n] = n, b.ID
+		}
+	}
+	for key, id := range mapping.Issues {
+		if n, err := strconv.Atoi(key); err == nil {
+			run.issueOf[id], run.beadOf[n] = n, id
+		}
+	}
+
+	// GitHub side first: everything that changed there since last time
+	done := make(map[string]bool)
+	lastSeen := mapping.LastSync
+	for i := range changed {
+		gi := &changed[i]
+		id, err := run.pullIssue(gi)
+		if err != nil {
+			return run.result, err
+		}
+		done[id] = true
+		if gi.UpdatedAt.After(lastSeen) {
+			lastSeen = gi.UpdatedAt
+		}
+	}
+
+	// Then beads that changed without their issue changing
+	for _, b := range all {
+		if done[b.ID] || !run.syncable(b) {
+			continue
+		}
+		if err := run.pushBead(b); err != nil {
+			return run.result, err
+		}
+	}
+
+	if !s.DryRun {
+		mapping.LastSync = lastSeen
+	}
+	return run.result, nil
+}
+
+// syncRun is the state of one Sync call.
+type syncRun struct {
+	*Syncer
+	repo    string
+	mapping *ghimport.Mapping
+	result  *Result
+	beads   map[string]*beads.Issue
+	issueOf map[string]int // Bead ID -> issue number
+	beadOf  map[int]string // Issue number -> bead ID
+}
+
+// pullIssue handles an issue that changed on GitHub and returns the ID of
+// the bead it is paired with, if any.
+func (r *syncRun) pullIssue(gi *Issue) (string, error) {
+	id, ok := r.beadOf[gi.Number]
+	if ok {
+		r.link(gi.Number, id)
+	} else {
+		// Unpaired on our side: trust the body's marker, else import
+		if ref := BeadRef(gi.Body); ref != "" && r.beads[ref] != nil {
+			id = ref
+			r.link(gi.Number, id)
+		} else if gi.State == "open" {
+			return r.createBead(gi)
+		} else {
+			return "", nil
+		}
+	}
+	b := r.beads[id]
+	if b == nil || !beads.VisibleTo(b, beads.VisibilityPublic) {
+		return id, nil // Bead deleted or made non-public; leave the issue as is
+	}
+	return id, r.reconcile(b, gi)
+}
+
+// pushBead handles a bead whose issue did not change on GitHub.
+func (r *syncRun) pushBead(b *beads.Issue) error {
+	n, ok := r.issueOf[b.ID]
+	if !ok {
+		if b.Status == "closed" {
+			return nil
+		}
+		return r.openIssue(b)
+	}
+	rec, recorded := r.mapping.Synced[strconv.Itoa(n)]
+	if recorded && !b.UpdatedAt.After(rec.BeadUpdated) {
+		return nil
+	}
+	gi, err := r.GitHub.Issue(r.repo, n)
+	if err != nil {
+		return fmt.Errorf("fetching issue #%d: %w", n, err)
+	}
+	r.link(n, b.ID)
+	return r.reconcile(b, gi)
+}
+
+// reconcile brings a paired bead and issue into agreement.
+func (r *syncRun) reconcile(b *beads.Issue, gi *Issue) error {
+	key := strconv.Itoa(gi.Number)
+	bv, gv := r.beadView(b), r.issueView(gi)
+	diff := bv.diff(gv)
+	if len(diff) == 0 {
+		if BeadRef(gi.Body) != b.ID {
+			return r.push(b, gi, bv) // Agreeing, but the issue lacks its marker
+		}
+		r.record(key, b.UpdatedAt, gi.UpdatedAt)
+		return nil
+	}
+
+	rec, recorded := r.mapping.Synced[key]
+	beadChanged := b.UpdatedAt.After(rec.BeadUpdated)
+	issueChanged := gi.UpdatedAt.After(rec.IssueUpdated)
+	pull := issueChanged && !beadChanged
+	switch {
+	case !recorded:
+		// First sync of an imported or relinked pair: newer side wins
+		pull = gi.UpdatedAt.After(b.UpdatedAt)
+	case beadChanged && issueChanged:
+		if r.Prefer == "" {
+			r.result.Conflicts = append(r.result.Conflicts, Conflict{Bead: b.ID, Issue: gi.Number, Fields: diff})
+			return nil
+		}
+		pull = r.Prefer == PreferGitHub
+	}
+
+	if pull {
+		return r.pull(b, gi, bv, gv)
+	}
+	return r.push(b, gi, bv)
+}
+
+// pull writes the issue's state onto the bead.
+func (r *syncRun) pull(b *beads.Issue, gi *Issue, bv, gv view) error {
+	r.result.BeadsUpdated = append(r.result.BeadsUpdated, b.ID)
+	if r.DryRun {
+		return nil
+	}
+
+	var opts beads.UpdateOptions
+	if gv.Title != bv.Title {
+		opts.Title = &gv.Title
+	}
+	if gv.Body != bv.Body {
+		opts.Description = &gv.Body
+	}
+	if gv.Priority != "" && gv.Priority != bv.Priority {
+		if p, ok := r.Priorities.FromExternal(ghimport.PriorityTracker, gv.Priority); ok {
+			opts.Priority = &p
+		}
+	}
+	for beadLabel, ghLabel := range r.labelMap() {
+		has, want := slices.Contains(b.Labels, beadLabel), slices.Contains(gv.Labels, ghLabel)
+		if want && !has {
+			opts.AddLabels = append(opts.AddLabels, beadLabel)
+		} else if has && !want {
+			opts.RemoveLabels = append(opts.RemoveLabels, beadLabel)
+		}
+	}
+	sort.Strings(opts.AddLabels)
+	sort.Strings(opts.RemoveLabels)
+	if !gv.Closed {
+		status := gv.Status
+		if status == "" && (bv.Closed || bv.Status != "") {
+			status = "open"
+		}
+		if status != "" && status != b.Status {
+			opts.Status = &status
+		}
+	}
+
+	if opts.Title != nil || opts.Description != nil || opts.Status != nil || opts.Priority != nil || len(opts.AddLabels)+len(opts.RemoveLabels) > 0 {
+		if err := r.Beads.Update(b.ID, opts); err != nil {
+			return fmt.Errorf("updating %s from #%d: %w", b.ID, gi.Number, err)
+		}
+	}
+	if gv.Closed && !bv.Closed {
+		if err := r.Beads.CloseWithReason(fmt.Sprintf("closed on GitHub (#%d)", gi.Number), b.ID); err != nil {
+			return fmt.Errorf("closing %s from #%d: %w", b.ID, gi.Number, err)
+		}
+	}
+	return r.recordFresh(b.ID, gi)
+}
+
+// push writes the bead's state onto the issue.
+func (r *syncRun) push(b *beads.Issue, gi *Issue, bv view) error {
+	r.result.IssuesEdited = append(r.result.IssuesEdited, gi.Number)
+	if r.DryRun {
+		return nil
+	}
+	edited, err := r.GitHub.EditIssue(r.repo, gi.Number, r.edit(b.ID, bv, gi.Labels))
+	if err != nil {
+		return fmt.Errorf("updating #%d from %s: %w", gi.Number, b.ID, err)
+	}
+	r.record(strconv.Itoa(gi.Number), b.UpdatedAt, edited.UpdatedAt)
+	return nil
+}
+
+// createBead imports a new GitHub issue and marks the issue with it.
+func (r *syncRun) createBead(gi *Issue) (string, error) {
+	if r.DryRun {
+		r.result.Imported = append(r.result.Imported, Link{Issue: gi.Number})
+		return "", nil
+	}
+
+	// Managed labels come over under their bead names; the rest as is
+	managed := r.managedLabels()
+	var unmanaged []string
+	for _, l := range gi.Labels {
+		if !managed[l] && !r.priorityLabel(l) {
+			unmanaged = append(unmanaged, l)
+		}
+	}
+	opts := ghimport.IssueCreateOptions(ghimport.Issue{Number: gi.Number, Title: gi.Title, URL: gi.URL, Labels: unmanaged}, r.Priorities)
+	gv := r.issueView(gi)
+	opts.Description = gv.Body
+	if p, ok := r.Priorities.FromExternal(ghimport.PriorityTracker, gv.Priority); ok {
+		opts.Priority = p
+	}
+	for _, beadLabel := range sortedKeys(r.labelMap()) {
+		if slices.Contains(gi.Labels, r.labelMap()[beadLabel]) {
+			opts.Labels = append(opts.Labels, beadLabel)
+		}
+	}
+	b, err := r.Beads.Create(opts)
+	if err != nil {
+		return "", fmt.Errorf("importing issue #%d: %w", gi.Number, err)
+	}
+	r.result.Imported = append(r.result.Imported, Link{Bead: b.ID, Issue: gi.Number})
+	r.link(gi.Number, b.ID)
+
+	if status := gv.Status; status != "" {
+		if err := r.Beads.Update(b.ID, beads.UpdateOptions{Status: &status}); err != nil {
+			return b.ID, fmt.Errorf("setting status of %s: %w", b.ID, err)
+		}
+	}
+	return b.ID, r.recordFresh(b.ID, gi)
+}
+
+// openIssue creates the GitHub issue for a new bead and labels the bead.
+func (r *syncRun) openIssue(b *beads.Issue) error {
+	if r.DryRun {
+		r.result.Exported = append(r.result.Exported, Link{Bead: b.ID})
+		return nil
+	}
+	gi, err := r.GitHub.CreateIssue(r.repo, r.edit(b.ID, r.beadView(b), nil))
+	if err != nil {
+		return fmt.Errorf("opening issue for %s: %w", b.ID, err)
+	}
+	r.result.Exported = append(r.result.Exported, Link{Bead: b.ID, Issue: gi.Number})
+	r.link(gi.Number, b.ID)
+	if err := r.Beads.Update(b.ID, beads.UpdateOptions{AddLabels: []string{issueLabel(gi.Number)}}); err != nil {
+		return fmt.Errorf("labeling %s: %w", b.ID, err)
+	}
+	return r.recordFresh(b.ID, gi)
+}
+
+// edit builds the issue fields for a bead view. Labels the sync doesn't
+// manage are kept from current.
+func (r *syncRun) edit(beadID string, bv view, current []string) IssueEdit {
+	managed := r.managedLabels()
+	e := IssueEdit{Title: bv.Title, State: "open", Labels: []string{}}
+	if bv.Closed {
+		e.State = "closed"
+	}
+	e.Body = Marker(beadID)
+	if bv.Body != "" {
+		e.Body = bv.Body + "\n\n" + e.Body
+	}
+	for _, l := range current {
+		if !managed[l] && !r.priorityLabel(l) {
+			e.Labels = append(e.Labels, l)
+		}
+	}
+	e.Labels = append(e.Labels, bv.Labels...)
+	if bv.Priority != "" {
+		e.Labels = append(e.Labels, bv.Priority)
+	}
+	if bv.Status != "" {
+		e.Labels = append(e.Labels, r.Config.GitHubStatusLabels()[bv.Status])
+	}
+	return e
+}
+
+func (r *syncRun) link(number int, beadID string) {
+	if r.DryRun {
+		return
+	}
+	r.mapping.Issues[strconv.Itoa(number)] = beadID
+	r.issueOf[beadID] = number
+}
+
+func (r *syncRun) record(key string, beadUpdated, issueUpdated time.Time) {
+	if r.DryRun {
+		return
+	}
+	r.mapping.Synced[key] = ghimport.SyncRecord{BeadUpdated: beadUpdated, IssueUpdated: issueUpdated}
+}
+
+// recordFresh records a pair after writing the bead, re-reading it for
+// its new UpdatedAt. An issue without the bead's marker gets it first.
+func (r *syncRun) recordFresh(beadID string, gi *Issue) error {
+	fresh, err := r.Beads.Show(beadID)
+	if err != nil {
+		return fmt.Errorf("reading back %s: %w", beadID, err)
+	}
+	if BeadRef(gi.Body) != beadID {
+		edited, err := r.GitHub.EditIssue(r.repo, gi.Number, r.edit(beadID, r.beadView(fresh), gi.Labels))
+		if err != nil {
+			return fmt.Errorf("marking #%d with %s: %w", gi.Number, beadID, err)
+		}
+		gi = edited
+	}
+	r.record(strconv.Itoa(gi.Number), fresh.UpdatedAt, gi.UpdatedAt)
+	return nil
+}
+
+// syncable reports whether a bead belongs on GitHub: it is public, and
+// already paired or a work bead of a synced type. Internal and private
+// beads never leave the town, even once paired.
+func (r *syncRun) syncable(b *beads.Issue) bool {
+	if !beads.VisibleTo(b, beads.VisibilityPublic) {
+		return false
+	}
+	if _, ok := r.issueOf[b.ID]; ok {
+		return true
+	}
+	if b.Status == beads.StatusPinned {
+		return false
+	}
+	return slices.Contains(r.Config.SyncTypes(), b.Type)
+}
+
+// managedLabels is the set of GitHub labels the sync owns.
+func (r *syncRun) managedLabels() map[string]bool {
+	managed := make(map[string]bool)
+	for _, l := range r.labelMap() {
+		managed[l] = true
+	}
+	for _, l := range r.Config.GitHubStatusLabels() {
+		managed[l] = true
+	}
+	return managed
+}
+
+// priorityLabel reports whether l is a GitHub priority label the sync owns.
+func (r *syncRun) priorityLabel(l string) bool {
+	_, ok := r.Priorities.FromExternal(ghimport.PriorityTracker, l)
+	return ok
+}
+
+func (r *syncRun) labelMap() map[string]string {
+	if r.Config == nil {
+		return nil
+	}
+	return r.Config.Labels
+}
+
+// view is the part of a bead or issue that syncs, in GitHub's terms.
+type view struct {
+	Title    string
+	Body     string
+	Closed   bool
+	Status   string   // Open bead status shown by a label; "" for plain open
+	Priority string   // GitHub priority label; "" when priorities aren't synced
+	Labels   []string // Synced GitHub labels, sorted
+}
+
+func (r *syncRun) beadView(b *beads.Issue) view {
+	v := view{Title: b.Title, Body: strings.TrimSpace(importedRe.ReplaceAllString(b.Description, "")), Closed: b.Status == "closed"}
+	if !v.Closed && r.Config.GitHubStatusLabels()[b.Status] != "" {
+		v.Status = b.Status
+	}
+	v.Priority, _ = r.Priorities.ToExternal(ghimport.PriorityTracker, b.Priority)
+	for _, l := range b.Labels {
+		if gh, ok := r.labelMap()[l]; ok {
+			v.Labels = append(v.Labels, gh)
+		}
+	}
+	sort.Strings(v.Labels)
+	return v
+}
+
+func (r *syncRun) issueView(gi *Issue) view {
+	v := view{Title: gi.Title, Body: strings.TrimSpace(markerRe.ReplaceAllString(gi.Body, "")), Closed: gi.State == "closed"}
+	statusLabels := r.Config.GitHubStatusLabels()
+	for _, status := range sortedKeys(statusLabels) {
+		if !v.Closed && slices.Contains(gi.Labels, statusLabels[status]) {
+			v.Status = status
+			break
+		}
+	}
+	// In the bead's terms, so label case and aliases don't show as a diff;
+	// of several priority labels the most urgent wins
+	best := -1
+	for _, l := range gi.Labels {
+		if p, ok := r.Priorities.FromExternal(ghimport.PriorityTracker, l); ok && (best < 0 || p < best) {
+			best = p
+			v.Priority, _ = r.Priorities.ToExternal(ghimport.PriorityTracker, p)
+		}
+	}
+	for _, gh := range r.labelMap() {
+		if slices.Contains(gi.Labels, gh) {
+			v.Labels = append(v.Labels, gh)
+		}
+	}
+	sort.Strings(v.Labels)
+	return v
+}
+
+// diff names the fields on which two views disagree.
+func (v view) diff(o view) []string {
+	var fields []string
+	if v.Title != o.Title {
+		fields = append(fields, "title")
+	}
+	if v.Body != o.Body {
+		fields = append(fields, "description")
+	}
+	if v.Closed != o.Closed {
+		fields = append(fields, "state")
+	} else if v.Status != o.Status {
+		fields = append(fields, "status")
+	}
+	if v.Priority != o.Priority {
+		fields = append(fields, "priority")
+	}
+	if !slices.Equal(v.Labels, o.Labels) {
+		fields = append(fields, "labels")
+	}
+	return fields
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// issueLabel is the bead-side cross-reference to an issue.
+func issueLabel(number int) string {
+	return fmt.Sprintf("%s:%d", ghimport.LabelIssue, number)
+}
+
+// labeledIssue returns the issue number from a bead's gh:issue label, or 0.
+func labeledIssue(b *beads.Issue) int {
+	for _, l := range b.Labels {
+		if rest, ok := strings.CutPrefix(l, ghimport.LabelIssue+":"); ok {
+			if n, err := strconv.Atoi(rest); err == nil {
+				return n
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,235 @@
+package ghsync
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/ghimport"
+)
+
+// fakeGitHub is an in-memory repository whose clock ticks a minute per write.
+type fakeGitHub struct {
+	issues map[int]*Issue
+	clock  time.Time
+	writes int
+}
+
+func newFakeGitHub() *fakeGitHub {
+	return &fakeGitHub{issues: map[int]*Issue{}, clock: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeGitHub) put(gi Issue) *Issue {
+	f.clock = f.clock.Add(time.Minute)
+	gi.UpdatedAt = f.clock
+	f.issues[gi.Number] = &gi
+	c := gi
+	return &c
+}
+
+func (f *fakeGitHub) IssuesSince(_ string, since time.Time) ([]Issue, error) {
+	var out []Issue
+	for _, gi := range f.issues {
+		if !gi.UpdatedAt.Before(since) {
+			out = append(out, *gi)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeGitHub) Issue(_ string, number int) (*Issue, error) {
+	c := *f.issues[number]
+	return &c, nil
+}
+
+func (f *fakeGitHub) CreateIssue(_ string, e IssueEdit) (*Issue, error) {
+	f.writes++
+	return f.put(Issue{Number: len(f.issues) + 1, Title: e.Title, Body: e.Body, State: "open", Labels: e.Labels}), nil
+}
+
+func (f *fakeGitHub) EditIssue(_ string, number int, e IssueEdit) (*Issue, error) {
+	f.writes++
+	return f.put(Issue{Number: number, Title: e.Title, Body: e.Body, State: e.State, Labels: e.Labels}), nil
+}
+
+func TestSync(t *testing.T) {
+	gh := newFakeGitHub()
+	gh.put(Issue{Number: 1, Title: "Crash on start", Body: "Stack trace", State: "open", Labels: []string{"bug", "in progress"}})
+	bd := beadstest.NewFakeClient()
+	bd.Add(&beads.Issue{ID: "gt-local", Title: "Redo onboarding", Type: "feature", Labels: []string{"ux"}})
+	bd.Add(&beads.Issue{ID: "gt-agent", Title: "Polecat nux", Type: "agent"})
+
+	mapping, _ := ghimport.LoadMapping(t.TempDir(), "me/app")
+	s := &Syncer{GitHub: gh, Beads: bd, Config: &config.GitHubSyncConfig{Labels: map[string]string{"ux": "design"}}}
+
+	result, err := s.Sync("me/app", mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Imported) != 1 || len(result.Exported) != 1 || len(result.Conflicts) != 0 {
+		t.Fatalf("first sync = %+v", result)
+	}
+
+	// Issue #1 became a bug bead in progress, and its body points back
+	crashID := result.Imported[0].Bead
+	crash, _ := bd.Show(crashID)
+	if crash.Type != "bug" || crash.Status != "in_progress" || !slices.Contains(crash.Labels, "gh:issue:1") {
+		t.Errorf("imported bead = %+v", crash)
+	}
+	if BeadRef(gh.issues[1].Body) != crashID || !strings.HasPrefix(gh.issues[1].Body, "Stack trace") {
+		t.Errorf("issue #1 body = %q", gh.issues[1].Body)
+	}
+
+	// gt-local became issue #2 with its mapped label; the agent bead stayed home
+	local, _ := bd.Show("gt-local")
+	exported := gh.issues[result.Exported[0].Issue]
+	if exported == nil || BeadRef(exported.Body) != "gt-local" || !slices.Equal(exported.Labels, []string{"design"}) {
+		t.Errorf("exported issue = %+v", exported)
+	}
+	if !slices.Contains(local.Labels, issueLabel(exported.Number)) || len(gh.issues) != 2 {
+		t.Errorf("local bead = %+v, %d issues", local, len(gh.issues))
+	}
+
+	// Nothing changed: nothing written
+	writes := gh.writes
+	if result, err = s.Sync("me/app", mapping); err != nil {
+		t.Fatal(err)
+	}
+	if gh.writes != writes || len(result.BeadsUpdated)+len(result.IssuesEdited)+len(result.Imported)+len(result.Exported) != 0 {
+		t.Errorf("idle sync = %+v (%d writes)", result, gh.writes-writes)
+	}
+
+	// One side changed: it wins
+	gh.put(Issue{Number: 1, Title: "Crash on cold start", Body: gh.issues[1].Body, State: "closed", Labels: gh.issues[1].Labels})
+	title := "Redo onboarding flow"
+	if err := bd.Update("gt-local", beads.UpdateOptions{Title: &title, RemoveLabels: []string{"ux"}}); err != nil {
+		t.Fatal(err)
+	}
+	if result, err = s.Sync("me/app", mapping); err != nil {
+		t.Fatal(err)
+	}
+	crash, _ = bd.Show(crashID)
+	if crash.Title != "Crash on cold start" || crash.Status != "closed" {
+		t.Errorf("pulled bead = %+v", crash)
+	}
+	if exported = gh.issues[exported.Number]; exported.Title != title || len(exported.Labels) != 0 {
+		t.Errorf("pushed issue = %+v", exported)
+	}
+
+	// Both sides changed: conflict, until a side is preferred
+	gh.put(Issue{Number: exported.Number, Title: "Onboarding v2", Body: exported.Body, State: "open"})
+	title = "Onboarding rework"
+	if err := bd.Update("gt-local", beads.UpdateOptions{Title: &title}); err != nil {
+		t.Fatal(err)
+	}
+	if result, err = s.Sync("me/app", mapping); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Bead != "gt-local" || !slices.Equal(result.Conflicts[0].Fields, []string{"title"}) {
+		t.Fatalf("conflicts = %+v", result.Conflicts)
+	}
+	if result, err = s.Sync("me/app", mapping); err != nil || len(result.Conflicts) != 1 {
+		t.Fatalf("conflict should persist: %+v, %v", result, err)
+	}
+
+	s.Prefer = PreferGitHub
+	if result, err = s.Sync("me/app", mapping); err != nil {
+		t.Fatal(err)
+	}
+	if local, _ = bd.Show("gt-local"); local.Title != "Onboarding v2" || len(result.Conflicts) != 0 {
+		t.Errorf("resolved bead = %+v, result %+v", local, result)
+	}
+}
+
+func TestSync_Relink(t *testing.T) {
+	// A lost mapping is rebuilt from the markers and labels
+	gh := newFakeGitHub()
+	gh.put(Issue{Number: 4, Title: "Docs", Body: "Write docs\n\n" + Marker("gt-docs"), State: "open"})
+	bd := beadstest.NewFakeClient()
+	bd.Add(&beads.Issue{ID: "gt-docs", Title: "Docs", Description: "Write docs", Type: "task"})
+
+	mapping, _ := ghimport.LoadMapping(t.TempDir(), "me/app")
+	result, err := (&Syncer{GitHub: gh, Beads: bd}).Sync("me/app", mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Imported)+len(result.Exported) != 0 || mapping.Issues["4"] != "gt-docs" || gh.writes != 0 {
+		t.Errorf("relink = %+v, mapping %v, %d writes", result, mapping.Issues, gh.writes)
+	}
+}
+
+func TestSync_SkipsNonPublicBeads(t *testing.T) {
+	gh := newFakeGitHub()
+	gh.put(Issue{Number: 1, Title: "Login bug", Body: "Old body\n\n" + Marker("gt-paired"), State: "open"})
+	bd := beadstest.NewFakeClient()
+	bd.Add(&beads.Issue{ID: "gt-incident", Title: "Credential leak", Type: "bug",
+		Labels: []string{beads.VisibilityLabel(beads.VisibilityPrivate)}})
+	bd.Add(&beads.Issue{ID: "gt-ops", Title: "Rotate keys", Type: "task",
+		Labels: []string{beads.VisibilityLabel(beads.VisibilityInternal)}})
+	// Paired before it was made private: its issue must not be updated either
+	bd.Add(&beads.Issue{ID: "gt-paired", Title: "Login bug (reporter: alice)", Type: "bug",
+		Labels: []string{"gh:issue:1", beads.VisibilityLabel(beads.VisibilityPrivate)}})
+
+	mapping, _ := ghimport.LoadMapping(t.TempDir(), "me/app")
+	result, err := (&Syncer{GitHub: gh, Beads: bd, Prefer: PreferBeads}).Sync("me/app", mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gh.writes != 0 || len(result.Exported)+len(result.IssuesEdited) != 0 {
+		t.Errorf("non-public beads reached GitHub: %+v, %d writes", result, gh.writes)
+	}
+	if gh.issues[1].Title != "Login bug" {
+		t.Errorf("paired issue = %+v", gh.issues[1])
+	}
+}
+
+func TestSync_Priorities(t *testing.T) {
+	gh := newFakeGitHub()
+	gh.put(Issue{Number: 1, Title: "Outage", State: "open", Labels: []string{"sev1", "ops"}})
+	bd := beadstest.NewFakeClient()
+	bd.Add(&beads.Issue{ID: "gt-local", Title: "Tidy logs", Type: "task", Priority: beads.PriorityMedium})
+
+	mapping, _ := ghimport.LoadMapping(t.TempDir(), "me/app")
+	s := &Syncer{GitHub: gh, Beads: bd, Priorities: &config.PriorityConfig{
+		External: map[string]map[string]int{ghimport.PriorityTracker: {"Sev1": 0, "Sev2": 1, "Sev3": 3}}}}
+	result, err := s.Sync("me/app", mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The imported bead takes the label's priority without carrying the label
+	outage, _ := bd.Show(result.Imported[0].Bead)
+	if outage.Priority != 0 || slices.Contains(outage.Labels, "sev1") {
+		t.Errorf("imported bead = %+v", outage)
+	}
+	// A P2 bead exports under the nearest more-urgent label
+	exported := gh.issues[result.Exported[0].Issue]
+	if !slices.Equal(exported.Labels, []string{"Sev2"}) {
+		t.Errorf("exported labels = %v, want [Sev2]", exported.Labels)
+	}
+	if labels := gh.issues[1].Labels; !slices.Contains(labels, "ops") || len(labels) != 2 {
+		t.Errorf("issue #1 labels = %v, want ops and one priority label", labels)
+	}
+
+	// Relabeling on GitHub pulls the new priority
+	gh.put(Issue{Number: 1, Title: "Outage", Body: gh.issues[1].Body, State: "open", Labels: []string{"ops", "Sev3"}})
+	if _, err := s.Sync("me/app", mapping); err != nil {
+		t.Fatal(err)
+	}
+	if outage, _ = bd.Show(outage.ID); outage.Priority != 3 {
+		t.Errorf("pulled priority = %d, want 3", outage.Priority)
+	}
+}
+
+func TestBeadRef(t *testing.T) {
+	if got := BeadRef("body\n\n" + Marker("gt-abc.1")); got != "gt-abc.1" {
+		t.Errorf("BeadRef = %q", got)
+	}
+	if got := BeadRef("mentions <!-- gastown:gt-x --> mid-body\nmore"); got != "" {
+		t.Errorf("BeadRef of a non-trailing marker = %q", got)
+	}
+}
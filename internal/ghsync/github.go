@@ -0,0 +1,153 @@
+package ghsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Issue is a GitHub issue as the sync sees it.
+type Issue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"` // "open" or "closed"
+	URL       string    `json:"url"`
+	Labels    []string  `json:"labels"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IssueEdit is the full set of fields the sync writes to an issue.
+type IssueEdit struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	State  string   `json:"state,omitempty"`
+	Labels []string `json:"labels"`
+}
+
+// GitHub reads and writes a repository's issues.
+type GitHub interface {
+	// IssuesSince lists open and closed issues (not pull requests)
+	// updated at or after since; a zero since lists every issue.
+	IssuesSince(repo string, since time.Time) ([]Issue, error)
+	Issue(repo string, number int) (*Issue, error)
+	CreateIssue(repo string, edit IssueEdit) (*Issue, error)
+	EditIssue(repo string, number int, edit IssueEdit) (*Issue, error)
+}
+
+// GHClient talks to GitHub through the gh CLI's REST passthrough.
+type GHClient struct{}
+
+// restIssue is an issue as the REST API returns it.
+type restIssue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	HTMLURL   string    `json:"html_url"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest json.RawMessage `json:"pull_request,omitempty"`
+}
+
+func (r restIssue) issue() *Issue {
+	gi := &Issue{Number: r.Number, Title: r.Title, Body: r.Body, State: r.State, URL: r.HTMLURL, UpdatedAt: r.UpdatedAt}
+	for _, l := range r.Labels {
+		gi.Labels = append(gi.Labels, l.Name)
+	}
+	return gi
+}
+
+// IssuesSince lists issues updated since the given time, oldest pages
+// first as GitHub returns them.
+func (GHClient) IssuesSince(repo string, since time.Time) ([]Issue, error) {
+	q := url.Values{"state": {"all"}, "per_page": {"100"}}
+	if !since.IsZero() {
+		q.Set("since", since.UTC().Format(time.RFC3339))
+	}
+	out, err := ghAPI("GET", fmt.Sprintf("repos/%s/issues?%s", repo, q.Encode()), nil, "--paginate")
+	if err != nil {
+		return nil, err
+	}
+
+	// --paginate prints one JSON array per page
+	var issues []Issue
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var page []restIssue
+		if err := dec.Decode(&page); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parsing issues: %w", err)
+		}
+		for _, r := range page {
+			if r.PullRequest == nil {
+				issues = append(issues, *r.issue())
+			}
+		}
+	}
+	return issues, nil
+}
+
+// Issue fetches one issue.
+func (GHClient) Issue(repo string, number int) (*Issue, error) {
+	return issueCall("GET", fmt.Sprintf("repos/%s/issues/%d", repo, number), nil)
+}
+
+// CreateIssue opens an issue. GitHub ignores State on create.
+func (GHClient) CreateIssue(repo string, edit IssueEdit) (*Issue, error) {
+	edit.State = ""
+	return issueCall("POST", fmt.Sprintf("repos/%s/issues", repo), edit)
+}
+
+// EditIssue overwrites an issue's title, body, state, and labels.
+func (GHClient) EditIssue(repo string, number int, edit IssueEdit) (*Issue, error) {
+	return issueCall("PATCH", fmt.Sprintf("repos/%s/issues/%d", repo, number), edit)
+}
+
+func issueCall(method, path string, body interface{}) (*Issue, error) {
+	out, err := ghAPI(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	var r restIssue
+	if err := json.Unmarshal(out, &r); err != nil {
+		return nil, fmt.Errorf("parsing issue: %w", err)
+	}
+	return r.issue(), nil
+}
+
+// ghAPI runs "gh api", sending body (if any) as the JSON request body.
+func ghAPI(method, path string, body interface{}, extra ...string) ([]byte, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return nil, fmt.Errorf("gh CLI not found: install from https://cli.github.com")
+	}
+	args := append([]string{"api", "-X", method, path}, extra...)
+	var stdin io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--input", "-")
+		stdin = bytes.NewReader(data)
+	}
+	cmd := exec.Command("gh", args...) //nolint:gosec // G204: args are constructed internally
+	cmd.Stdin = stdin
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("gh api %s %s: %s", method, path, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("gh api %s %s: %w", method, path, err)
+	}
+	return out, nil
+}
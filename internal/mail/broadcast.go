@@ -0,0 +1,100 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BroadcastTown is the broadcast scope that reaches every active agent in
+// the town; any other scope names a rig.
+const BroadcastTown = "town"
+
+// BroadcastResult reports how a broadcast fanned out.
+type BroadcastResult struct {
+	Scope      string            `json:"scope"`
+	ThreadID   string            `json:"thread_id"`        // Shared by every recipient's copy
+	Recipients []string          `json:"recipients"`       // Addresses the broadcast was sent to
+	Failed     map[string]string `json:"failed,omitempty"` // Recipient → send error
+}
+
+// Delivered returns the recipients whose copy was sent.
+func (b *BroadcastResult) Delivered() []string {
+	var delivered []string
+	for _, addr := range b.Recipients {
+		if _, failed := b.Failed[addr]; !failed {
+			delivered = append(delivered, addr)
+		}
+	}
+	return delivered
+}
+
+// SendBroadcast sends an announcement from from to every active agent in
+// scope: BroadcastTown for the whole town, or a rig name. Recipients come
+// from the agent registry (agent beads), less the sender. Each recipient
+// gets its own message bead, so each reads and deletes its copy
+// independently; the copies share one thread, whose view shows who has
+// read it.
+//
+// An error is returned if no recipient could be resolved or none of the
+// copies was sent; copies that failed alongside ones that were sent are
+// reported in the result's Failed.
+func (r *Router) SendBroadcast(from, scope, subject, body string) (*BroadcastResult, error) {
+	if scope == "" {
+		return nil, errors.New("broadcast scope required (a rig name or \"town\")")
+	}
+	agents, err := r.queryAgents("")
+	if err != nil {
+		return nil, err
+	}
+	recipients := broadcastRecipients(agents, scope, from)
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no agents to broadcast to in %s", scope)
+	}
+
+	msg := NewMessage(from, "", subject, body)
+	msg.Wisp = false // Announcements outlive the session that reads them
+	r.redact(msg)
+
+	result := &BroadcastResult{Scope: scope, ThreadID: msg.ThreadID, Recipients: recipients}
+	for _, recipient := range recipients {
+		msgCopy := *msg
+		msgCopy.To = recipient
+		if err := r.sendToSingle(&msgCopy); err != nil {
+			if result.Failed == nil {
+				result.Failed = make(map[string]string)
+			}
+			result.Failed[recipient] = err.Error()
+		}
+	}
+
+	if len(result.Failed) == len(recipients) {
+		var errs []string
+		for _, recipient := range recipients {
+			errs = append(errs, fmt.Sprintf("%s: %s", recipient, result.Failed[recipient]))
+		}
+		return result, fmt.Errorf("broadcast to %s failed: %s", scope, strings.Join(errs, "; "))
+	}
+	return result, nil
+}
+
+// broadcastRecipients returns the sorted, distinct addresses of the agents
+// in scope, leaving out the sender and agent beads that have no address.
+func broadcastRecipients(agents []*agentBead, scope, from string) []string {
+	seen := make(map[string]bool)
+	var recipients []string
+	for _, agent := range agents {
+		if scope != BroadcastTown && !agentInRig(agent, scope) {
+			continue
+		}
+		addr := agentBeadToAddress(agent)
+		if addr == "" || seen[addr] || isSelfMail(from, addr) {
+			continue
+		}
+		seen[addr] = true
+		recipients = append(recipients, addr)
+	}
+	sort.Strings(recipients)
+	return recipients
+}
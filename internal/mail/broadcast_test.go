@@ -0,0 +1,86 @@
+package mail
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func testAgent(id, role, rig string) *agentBead {
+	return &agentBead{
+		ID:          id,
+		Status:      "open",
+		Description: beads.FormatAgentDescription(id, &beads.AgentFields{RoleType: role, Rig: rig}),
+	}
+}
+
+func TestBroadcastRecipients(t *testing.T) {
+	agents := []*agentBead{
+		testAgent("hq-mayor", "mayor", ""),
+		testAgent("hq-deacon", "deacon", ""),
+		testAgent("gt-gastown-witness", "witness", "gastown"),
+		testAgent("gt-gastown-polecat-Toast", "polecat", "gastown"),
+		testAgent("gt-gas-witness", "witness", "gas"),
+		testAgent("gt-gas-witness", "witness", "gas"), // Listed twice
+		testAgent("bd-other-witness", "witness", "gastown"),
+	}
+
+	tests := []struct {
+		name  string
+		scope string
+		from  string
+		want  []string
+	}{
+		{
+			name:  "town reaches every agent",
+			scope: BroadcastTown,
+			from:  "overseer",
+			want:  []string{"deacon/", "gas/witness", "gastown/Toast", "gastown/witness", "mayor/"},
+		},
+		{
+			name:  "rig matches exactly, not by prefix",
+			scope: "gas",
+			from:  "overseer",
+			want:  []string{"gas/witness"},
+		},
+		{
+			name:  "sender is left out",
+			scope: "gastown",
+			from:  "gastown/witness",
+			want:  []string{"gastown/Toast"},
+		},
+		{
+			name:  "town sender with trailing slash is left out",
+			scope: BroadcastTown,
+			from:  "mayor",
+			want:  []string{"deacon/", "gas/witness", "gastown/Toast", "gastown/witness"},
+		},
+		{
+			name:  "unknown rig",
+			scope: "nope",
+			from:  "overseer",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := broadcastRecipients(agents, tt.scope, tt.from)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("broadcastRecipients(%q, %q) = %v, want %v", tt.scope, tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBroadcastResultDelivered(t *testing.T) {
+	result := &BroadcastResult{
+		Recipients: []string{"gastown/Toast", "gastown/witness", "mayor/"},
+		Failed:     map[string]string{"gastown/witness": "bd down"},
+	}
+	want := []string{"gastown/Toast", "mayor/"}
+	if got := result.Delivered(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Delivered() = %v, want %v", got, want)
+	}
+}
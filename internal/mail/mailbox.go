@@ -9,9 +9,12 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/flock"
 )
 
 // timeNow is a function that returns the current time. It can be overridden in tests.
@@ -139,7 +142,7 @@ func (m *Mailbox) listFromDir(beadsDir string) ([]*Message, error) {
 
 	// Query for CC'd messages (open only)
 	for _, identity := range identities {
-		ccMsgs, err := m.queryMessages(beadsDir, "--label", "cc:"+identity, "open")
+		ccMsgs, err := m.queryMessages(beadsDir, "--label", beads.LabelCC+identity, "open")
 		if err != nil {
 			lastErr = err
 		} else {
@@ -180,10 +183,11 @@ func (m *Mailbox) identityVariants() []string {
 // queryMessages runs a bd list query with the given filter flag and value.
 func (m *Mailbox) queryMessages(beadsDir, filterFlag, filterValue, status string) ([]*Message, error) {
 	args := []string{"list",
-		"--type", "message",
+		"--type", beads.TypeMessage,
 		filterFlag, filterValue,
 		"--status", status,
 		"--json",
+		"--limit=0", // Get all
 	}
 
 	stdout, err := runBdCommand(args, m.workDir, beadsDir)
@@ -204,12 +208,20 @@ func (m *Mailbox) queryMessages(beadsDir, filterFlag, filterValue, status string
 	// Convert to GGT messages - wisp status comes from beads issue.wisp field
 	var messages []*Message
 	for _, bm := range beadsMsgs {
-		messages = append(messages, bm.ToMessage())
+		messages = append(messages, m.withReadState(bm.ToMessage()))
 	}
 
 	return messages, nil
 }
 
+// withReadState marks msg read if it carries this mailbox's read receipt.
+func (m *Mailbox) withReadState(msg *Message) *Message {
+	if _, ok := msg.ReceiptFor(m.identity); ok {
+		msg.Read = true
+	}
+	return msg
+}
+
 func (m *Mailbox) listLegacy() ([]*Message, error) {
 	file, err := os.Open(m.path)
 	if err != nil {
@@ -247,23 +259,19 @@ func (m *Mailbox) listLegacy() ([]*Message, error) {
 	return messages, nil
 }
 
-// ListUnread returns unread (open) messages.
+// ListUnread returns the messages this mailbox's owner hasn't read.
 func (m *Mailbox) ListUnread() ([]*Message, error) {
-	if m.legacy {
-		all, err := m.List()
-		if err != nil {
-			return nil, err
-		}
-		var unread []*Message
-		for _, msg := range all {
-			if !msg.Read {
-				unread = append(unread, msg)
-			}
+	all, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	var unread []*Message
+	for _, msg := range all {
+		if !msg.Read {
+			unread = append(unread, msg)
 		}
-		return unread, nil
 	}
-	// For beads, inbox only returns open (unread) messages
-	return m.List()
+	return unread, nil
 }
 
 // Get returns a message by ID.
@@ -276,7 +284,11 @@ func (m *Mailbox) Get(id string) (*Message, error) {
 
 func (m *Mailbox) getBeads(id string) (*Message, error) {
 	// Single DB query - wisps and persistent messages in same store
-	return m.getFromDir(id, m.beadsDir)
+	msg, err := m.getFromDir(id, m.beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	return m.withReadState(msg), nil
 }
 
 // getFromDir retrieves a message from a beads directory.
@@ -317,17 +329,50 @@ func (m *Mailbox) getLegacy(id string) (*Message, error) {
 	return nil, ErrMessageNotFound
 }
 
-// MarkRead marks a message as read.
+// MarkRead marks a message as read by this mailbox's owner and logs a
+// mail_read event. In beads the read is recorded as a receipt (reader and
+// time) on the message rather than by closing it, so every recipient,
+// CC'd ones included, has their own read state, and a read message stays
+// in the inbox until it is deleted.
 func (m *Mailbox) MarkRead(id string) error {
+	var msg *Message
+	var err error
 	if m.legacy {
-		return m.markReadLegacy(id)
+		msg, err = m.markReadLegacy(id)
+	} else {
+		msg, err = m.markReadBeads(id)
+	}
+	if err != nil || msg == nil {
+		return err
 	}
-	return m.markReadBeads(id)
+
+	reader := identityToAddress(m.identity)
+	if m.legacy {
+		reader = msg.To
+	}
+	_ = events.Log(events.TypeMailRead, reader, events.MailReadPayload(msg.ID, msg.From, msg.Subject, msg.ThreadID), events.VisibilityDefault)
+	return nil
 }
 
-func (m *Mailbox) markReadBeads(id string) error {
-	// Single DB - wisps and persistent messages in same store
-	return m.closeInDir(id, m.beadsDir)
+// markReadBeads adds this mailbox's read receipt to a message. It returns
+// the message, or nil if it was already read.
+func (m *Mailbox) markReadBeads(id string) (*Message, error) {
+	msg, err := m.getBeads(id)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Read {
+		return nil, nil
+	}
+
+	args := []string{"update", id, "--add-label=" + readReceiptLabel(m.identity, timeNow())}
+	if _, err := runBdCommand(args, m.workDir, m.beadsDir); err != nil {
+		if bdErr, ok := err.(*bdError); ok && bdErr.ContainsError("not found") {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	return msg, nil
 }
 
 // closeInDir closes a message in a specific beads directory.
@@ -349,25 +394,29 @@ func (m *Mailbox) closeInDir(id, beadsDir string) error {
 	return nil
 }
 
-func (m *Mailbox) markReadLegacy(id string) error {
-	messages, err := m.List()
-	if err != nil {
-		return err
-	}
-
-	found := false
-	for _, msg := range messages {
-		if msg.ID == id {
-			msg.Read = true
-			found = true
+func (m *Mailbox) markReadLegacy(id string) (*Message, error) {
+	var read *Message
+	err := m.updateLegacy(func(messages []*Message) ([]*Message, error) {
+		found := false
+		for _, msg := range messages {
+			if msg.ID == id {
+				if !msg.Read {
+					msg.Read = true
+					read = msg
+				}
+				found = true
+			}
 		}
-	}
 
-	if !found {
-		return ErrMessageNotFound
+		if !found {
+			return nil, ErrMessageNotFound
+		}
+		return messages, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return m.rewriteLegacy(messages)
+	return read, nil
 }
 
 // MarkUnread marks a message as unread (reopens in beads).
@@ -378,39 +427,48 @@ func (m *Mailbox) MarkUnread(id string) error {
 	return m.markUnreadBeads(id)
 }
 
+// markUnreadBeads removes this mailbox's read receipt from a message, and
+// reopens it if it was acknowledged (closed).
 func (m *Mailbox) markUnreadBeads(id string) error {
-	args := []string{"reopen", id}
-
-	_, err := runBdCommand(args, m.workDir, m.beadsDir)
+	msg, err := m.getFromDir(id, m.beadsDir)
 	if err != nil {
-		if bdErr, ok := err.(*bdError); ok && bdErr.ContainsError("not found") {
-			return ErrMessageNotFound
-		}
 		return err
 	}
 
+	var cmds [][]string
+	if r, ok := msg.ReceiptFor(m.identity); ok {
+		cmds = append(cmds, []string{"update", id, "--remove-label=" + r.label})
+	}
+	if msg.Read { // Closed, before receipts are considered
+		cmds = append(cmds, []string{"reopen", id})
+	}
+	for _, args := range cmds {
+		if _, err := runBdCommand(args, m.workDir, m.beadsDir); err != nil {
+			if bdErr, ok := err.(*bdError); ok && bdErr.ContainsError("not found") {
+				return ErrMessageNotFound
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (m *Mailbox) markUnreadLegacy(id string) error {
-	messages, err := m.List()
-	if err != nil {
-		return err
-	}
-
-	found := false
-	for _, msg := range messages {
-		if msg.ID == id {
-			msg.Read = false
-			found = true
+	return m.updateLegacy(func(messages []*Message) ([]*Message, error) {
+		found := false
+		for _, msg := range messages {
+			if msg.ID == id {
+				msg.Read = false
+				found = true
+			}
 		}
-	}
-
-	if !found {
-		return ErrMessageNotFound
-	}
 
-	return m.rewriteLegacy(messages)
+		if !found {
+			return nil, ErrMessageNotFound
+		}
+		return messages, nil
+	})
 }
 
 // Delete removes a message.
@@ -418,30 +476,26 @@ func (m *Mailbox) Delete(id string) error {
 	if m.legacy {
 		return m.deleteLegacy(id)
 	}
-	return m.MarkRead(id) // beads: just acknowledge/close
+	return m.closeInDir(id, m.beadsDir) // beads: just acknowledge/close
 }
 
 func (m *Mailbox) deleteLegacy(id string) error {
-	messages, err := m.List()
-	if err != nil {
-		return err
-	}
-
-	var filtered []*Message
-	found := false
-	for _, msg := range messages {
-		if msg.ID == id {
-			found = true
-		} else {
-			filtered = append(filtered, msg)
+	return m.updateLegacy(func(messages []*Message) ([]*Message, error) {
+		var filtered []*Message
+		found := false
+		for _, msg := range messages {
+			if msg.ID == id {
+				found = true
+			} else {
+				filtered = append(filtered, msg)
+			}
 		}
-	}
 
-	if !found {
-		return ErrMessageNotFound
-	}
-
-	return m.rewriteLegacy(filtered)
+		if !found {
+			return nil, ErrMessageNotFound
+		}
+		return filtered, nil
+	})
 }
 
 // Archive moves a message to the archive file and removes it from inbox.
@@ -479,20 +533,13 @@ func (m *Mailbox) appendToArchive(msg *Message) error {
 		return err
 	}
 
-	// Open for append
-	file, err := os.OpenFile(archivePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: archive is non-sensitive operational data
-	if err != nil {
-		return err
-	}
-	defer func() { _ = file.Close() }()
-
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
 
-	_, err = file.WriteString(string(data) + "\n")
-	return err
+	// Append under the file lock; other agents may archive concurrently
+	return flock.AppendFile(archivePath, append(data, '\n'), 0644)
 }
 
 // ListArchived returns all messages in the archive file.
@@ -685,15 +732,10 @@ func (m *Mailbox) Count() (total, unread int, err error) {
 	}
 
 	total = len(messages)
-	if m.legacy {
-		for _, msg := range messages {
-			if !msg.Read {
-				unread++
-			}
+	for _, msg := range messages {
+		if !msg.Read {
+			unread++
 		}
-	} else {
-		// For beads, inbox only returns unread
-		unread = total
 	}
 
 	return total, unread, nil
@@ -715,23 +757,33 @@ func (m *Mailbox) appendLegacy(msg *Message) error {
 		return err
 	}
 
-	// Open for append
-	file, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = file.Close() }() // non-fatal: OS will close on exit
-
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
 
-	_, err = file.WriteString(string(data) + "\n")
-	return err
+	// Append under the file lock; several senders may deliver at once
+	return flock.AppendFile(m.path, append(data, '\n'), 0600)
 }
 
-// rewriteLegacy rewrites the mailbox with the given messages.
+// updateLegacy rewrites the mailbox with what fn makes of its messages.
+// It holds the lock appendLegacy takes from the read to the rename, so a
+// message delivered meanwhile isn't lost.
+func (m *Mailbox) updateLegacy(fn func([]*Message) ([]*Message, error)) error {
+	return flock.With(m.path, flock.DefaultTimeout, func() error {
+		messages, err := m.listLegacy()
+		if err != nil {
+			return err
+		}
+		if messages, err = fn(messages); err != nil {
+			return err
+		}
+		return m.rewriteLegacy(messages)
+	})
+}
+
+// rewriteLegacy rewrites the mailbox with the given messages. The caller
+// holds the mailbox lock (see updateLegacy).
 func (m *Mailbox) rewriteLegacy(messages []*Message) error {
 	// Sort by timestamp (oldest first for JSONL)
 	sort.Slice(messages, func(i, j int) bool {
@@ -748,8 +800,8 @@ func (m *Mailbox) rewriteLegacy(messages []*Message) error {
 	for _, msg := range messages {
 		data, err := json.Marshal(msg)
 		if err != nil {
-			_ = file.Close()         // best-effort cleanup
-			_ = os.Remove(tmpPath)   // best-effort cleanup
+			_ = file.Close()       // best-effort cleanup
+			_ = os.Remove(tmpPath) // best-effort cleanup
 			return err
 		}
 		_, _ = file.WriteString(string(data) + "\n") // non-fatal: partial write is acceptable
@@ -821,3 +873,86 @@ func (m *Mailbox) listByThreadLegacy(threadID string) ([]*Message, error) {
 
 	return thread, nil
 }
+
+// ThreadEntry is one message of a thread with its depth in the reply
+// chain: 0 for a message that answers nothing in the thread, 1 for a reply
+// to it, and so on.
+type ThreadEntry struct {
+	*Message
+	Depth int `json:"depth"`
+}
+
+// Thread returns the conversation id belongs to, read or not, in
+// conversation order: each reply follows the message it answers. id is a
+// thread ID or the ID of any message in the thread.
+func (m *Mailbox) Thread(id string) ([]ThreadEntry, error) {
+	threadID := id
+	if !strings.HasPrefix(id, "thread-") {
+		msg, err := m.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if msg.ThreadID == "" {
+			return []ThreadEntry{{Message: msg}}, nil
+		}
+		threadID = msg.ThreadID
+	}
+
+	var messages []*Message
+	var err error
+	if m.legacy {
+		messages, err = m.listByThreadLegacy(threadID)
+	} else {
+		messages, err = m.queryMessages(m.beadsDir, "--label", beads.LabelThread+threadID, "all")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conversationOrder(messages), nil
+}
+
+// conversationOrder arranges a thread depth-first along its reply chains,
+// so each reply follows the message it answers; messages answering the
+// same one, and messages answering nothing in the thread, go oldest first.
+func conversationOrder(messages []*Message) []ThreadEntry {
+	sorted := append([]*Message(nil), messages...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	inThread := make(map[string]bool, len(sorted))
+	for _, msg := range sorted {
+		inThread[msg.ID] = true
+	}
+	replies := make(map[string][]*Message)
+	var roots []*Message
+	for _, msg := range sorted {
+		if msg.ReplyTo != "" && msg.ReplyTo != msg.ID && inThread[msg.ReplyTo] {
+			replies[msg.ReplyTo] = append(replies[msg.ReplyTo], msg)
+		} else {
+			roots = append(roots, msg)
+		}
+	}
+
+	ordered := make([]ThreadEntry, 0, len(sorted))
+	seen := make(map[string]bool, len(sorted))
+	var walk func(msg *Message, depth int)
+	walk = func(msg *Message, depth int) {
+		if seen[msg.ID] {
+			return
+		}
+		seen[msg.ID] = true
+		ordered = append(ordered, ThreadEntry{Message: msg, Depth: depth})
+		for _, reply := range replies[msg.ID] {
+			walk(reply, depth+1)
+		}
+	}
+	for _, msg := range roots {
+		walk(msg, 0)
+	}
+	// Messages in a reply cycle are reachable from no root; keep them
+	for _, msg := range sorted {
+		walk(msg, 0)
+	}
+	return ordered
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 )
@@ -134,6 +136,7 @@ func TestMailboxLegacyGet(t *testing.T) {
 
 func TestMailboxLegacyMarkRead(t *testing.T) {
 	tmpDir := t.TempDir()
+	t.Chdir(tmpDir) // Not a workspace, so no read receipt reaches an events file
 	m := NewMailbox(tmpDir)
 
 	msg := &Message{
@@ -203,6 +206,44 @@ func TestMailboxLegacyDelete(t *testing.T) {
 	}
 }
 
+func TestMailboxLegacyDeleteKeepsConcurrentAppends(t *testing.T) {
+	m := NewMailbox(t.TempDir())
+	const n = 20
+	for i := 0; i < n; i++ {
+		if err := m.Append(&Message{ID: fmt.Sprintf("old-%02d", i), Timestamp: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			if err := m.Append(&Message{ID: fmt.Sprintf("new-%02d", i), Timestamp: time.Now()}); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			if err := m.Delete(fmt.Sprintf("old-%02d", i)); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	wg.Wait()
+
+	messages, err := m.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != n {
+		t.Errorf("got %d messages, want the %d appended during the deletes", len(messages), n)
+	}
+}
+
 func TestMailboxLegacyCount(t *testing.T) {
 	tmpDir := t.TempDir()
 	m := NewMailbox(tmpDir)
@@ -386,6 +427,7 @@ func TestNewMailboxWithBeadsDir(t *testing.T) {
 
 func TestMailboxLegacyMultipleOperations(t *testing.T) {
 	tmpDir := t.TempDir()
+	t.Chdir(tmpDir) // Not a workspace, so no read receipt reaches an events file
 	m := NewMailbox(tmpDir)
 
 	// Append multiple messages
@@ -482,6 +524,7 @@ func TestMailboxLegacyDeleteAll(t *testing.T) {
 
 func TestMailboxLegacyMarkReadTwice(t *testing.T) {
 	tmpDir := t.TempDir()
+	t.Chdir(tmpDir) // Not a workspace, so no read receipt reaches an events file
 	m := NewMailbox(tmpDir)
 
 	msg := &Message{ID: "msg-001", Read: false}
@@ -507,3 +550,57 @@ func TestMailboxLegacyMarkReadTwice(t *testing.T) {
 	}
 }
 
+
+func TestConversationOrder(t *testing.T) {
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	msg := func(id, replyTo string, minute int) *Message {
+		return &Message{ID: id, ReplyTo: replyTo, ThreadID: "thread-1", Timestamp: base.Add(time.Duration(minute) * time.Minute)}
+	}
+	// root ── a ── a1
+	//     └── b
+	// orphan (answers a message outside the thread)
+	thread := []*Message{
+		msg("b", "root", 3),
+		msg("a1", "a", 4),
+		msg("root", "", 0),
+		msg("orphan", "gone", 2),
+		msg("a", "root", 1),
+	}
+
+	var got []string
+	for _, e := range conversationOrder(thread) {
+		got = append(got, fmt.Sprintf("%s:%d", e.ID, e.Depth))
+	}
+	want := []string{"root:0", "a:1", "a1:2", "b:1", "orphan:0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("conversationOrder = %v, want %v", got, want)
+	}
+
+	// A reply cycle must neither loop nor drop messages
+	cycle := []*Message{msg("x", "y", 0), msg("y", "x", 1)}
+	if n := len(conversationOrder(cycle)); n != 2 {
+		t.Errorf("cycle: got %d entries, want 2", n)
+	}
+}
+
+func TestMailboxLegacyThread(t *testing.T) {
+	m := NewMailbox(t.TempDir())
+	base := time.Now().Add(-time.Hour)
+	for i, msg := range []*Message{
+		{ID: "msg-1", ThreadID: "thread-x", Timestamp: base},
+		{ID: "msg-2", ThreadID: "thread-x", ReplyTo: "msg-1", Timestamp: base.Add(time.Minute)},
+		{ID: "msg-3", ThreadID: "thread-y", Timestamp: base.Add(2 * time.Minute)},
+	} {
+		if err := m.Append(msg); err != nil {
+			t.Fatalf("Append %d: %v", i, err)
+		}
+	}
+
+	thread, err := m.Thread("msg-2")
+	if err != nil {
+		t.Fatalf("Thread: %v", err)
+	}
+	if len(thread) != 2 || thread[0].ID != "msg-1" || thread[1].ID != "msg-2" || thread[1].Depth != 1 {
+		t.Errorf("Thread(msg-2) = %+v", thread)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/redact"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
@@ -288,12 +290,25 @@ type agentBead struct {
 //   - gt-gastown-witness → gastown/witness
 //   - gt-gastown-crew-max → gastown/max
 //   - gt-gastown-polecat-Toast → gastown/Toast
+//   - hq-mayor → mayor/, hq-deacon → deacon/ (town beads)
+//   - hq-dog-alpha → deacon/dogs/alpha
 func agentBeadToAddress(bead *agentBead) string {
 	if bead == nil {
 		return ""
 	}
 
 	id := bead.ID
+	if rest, ok := strings.CutPrefix(id, beads.TownBeadsPrefix+"-"); ok {
+		// Town agent beads: hq-<role> or hq-dog-<name> (hq-<role>-role
+		// beads define roles, not agents)
+		if name, ok := strings.CutPrefix(rest, "dog-"); ok {
+			return "deacon/dogs/" + name
+		}
+		if rest == "" || strings.HasSuffix(rest, "-role") {
+			return ""
+		}
+		return rest + "/"
+	}
 	if !strings.HasPrefix(id, "gt-") {
 		return "" // Not a valid agent bead ID
 	}
@@ -404,11 +419,8 @@ func (r *Router) resolveAgentsByRole(roleType, rig string) ([]string, error) {
 	var addresses []string
 	for _, agent := range agents {
 		// Filter by rig if specified
-		if rig != "" {
-			// Check if agent's description contains matching rig
-			if !strings.Contains(agent.Description, "rig: "+rig) {
-				continue
-			}
+		if rig != "" && !agentInRig(agent, rig) {
+			continue
 		}
 		if addr := agentBeadToAddress(agent); addr != "" {
 			addresses = append(addresses, addr)
@@ -429,6 +441,10 @@ func (r *Router) resolveAgentsByRig(rig string) ([]string, error) {
 
 	var addresses []string
 	for _, agent := range agents {
+		// The query matches by substring, so "rig: gas" also finds gastown
+		if !agentInRig(agent, rig) {
+			continue
+		}
 		if addr := agentBeadToAddress(agent); addr != "" {
 			addresses = append(addresses, addr)
 		}
@@ -437,6 +453,11 @@ func (r *Router) resolveAgentsByRig(rig string) ([]string, error) {
 	return addresses, nil
 }
 
+// agentInRig reports whether an agent bead's description places it in rig.
+func agentInRig(bead *agentBead, rig string) bool {
+	return beads.ParseAgentFields(bead.Description).Rig == rig
+}
+
 // queryAgents queries agent beads using bd list with description filtering.
 func (r *Router) queryAgents(descContains string) ([]*agentBead, error) {
 	beadsDir := r.resolveBeadsDir("")
@@ -499,7 +520,11 @@ func (r *Router) shouldBeWisp(msg *Message) bool {
 // Supports single-copy delivery for:
 // - Queues (queue:name) - stores single message for worker claiming
 // - Announces (announce:name) - bulletin board, no claiming, retention-limited
+// Secrets in the subject and body are redacted in place first (see package
+// redact).
 func (r *Router) Send(msg *Message) error {
+	r.redact(msg)
+
 	// Check for mailing list address
 	if isListAddress(msg.To) {
 		return r.sendToList(msg)
@@ -524,6 +549,15 @@ func (r *Router) Send(msg *Message) error {
 	return r.sendToSingle(msg)
 }
 
+// redact redacts secrets in the message's subject and body in place.
+func (r *Router) redact(msg *Message) {
+	rd := redact.ForTown(r.townRoot)
+	var n, m int
+	msg.Subject, n = rd.String(msg.Subject)
+	msg.Body, m = rd.String(msg.Body)
+	_ = redact.Record(r.townRoot, redact.SourceMail, n+m)
+}
+
 // sendToGroup resolves a @group address and sends individual messages to each member.
 func (r *Router) sendToGroup(msg *Message) error {
 	group := parseGroupAddress(msg.To)
@@ -564,39 +598,8 @@ func (r *Router) sendToSingle(msg *Message) error {
 	// Convert addresses to beads identities
 	toIdentity := addressToIdentity(msg.To)
 
-	// Build labels for from/thread/reply-to/cc
-	var labels []string
-	labels = append(labels, "from:"+msg.From)
-	if msg.ThreadID != "" {
-		labels = append(labels, "thread:"+msg.ThreadID)
-	}
-	if msg.ReplyTo != "" {
-		labels = append(labels, "reply-to:"+msg.ReplyTo)
-	}
-	// Add CC labels (one per recipient)
-	for _, cc := range msg.CC {
-		ccIdentity := addressToIdentity(cc)
-		labels = append(labels, "cc:"+ccIdentity)
-	}
-
 	// Build command: bd create <subject> --type=message --assignee=<recipient> -d <body>
-	args := []string{"create", msg.Subject,
-		"--type", "message",
-		"--assignee", toIdentity,
-		"-d", msg.Body,
-	}
-
-	// Add priority flag
-	beadsPriority := PriorityToBeads(msg.Priority)
-	args = append(args, "--priority", fmt.Sprintf("%d", beadsPriority))
-
-	// Add labels
-	if len(labels) > 0 {
-		args = append(args, "--labels", strings.Join(labels, ","))
-	}
-
-	// Add actor for attribution (sender identity)
-	args = append(args, "--actor", msg.From)
+	args := messageCreateArgs(beadsMessage(msg, toIdentity).CreateOptions())
 
 	// Add --ephemeral flag for ephemeral messages (stored in single DB, filtered from JSONL export)
 	if r.shouldBeWisp(msg) {
@@ -618,6 +621,41 @@ func (r *Router) sendToSingle(msg *Message) error {
 	return nil
 }
 
+// beadsMessage returns msg as a beads message assigned to assignee, with
+// CC addresses converted to beads identities.
+func beadsMessage(msg *Message, assignee string) *beads.Message {
+	cc := make([]string, 0, len(msg.CC))
+	for _, addr := range msg.CC {
+		cc = append(cc, addressToIdentity(addr))
+	}
+	return &beads.Message{
+		From:     msg.From,
+		To:       assignee,
+		Subject:  msg.Subject,
+		Body:     msg.Body,
+		Priority: PriorityToBeads(msg.Priority),
+		ThreadID: msg.ThreadID,
+		ReplyTo:  msg.ReplyTo,
+		CC:       cc,
+	}
+}
+
+// messageCreateArgs returns the bd create arguments for a message's
+// create options (see beads.Message.CreateOptions).
+func messageCreateArgs(opts beads.CreateOptions) []string {
+	args := []string{"create", opts.Title,
+		"--type", opts.Type,
+		"--assignee", opts.Assignee,
+		"-d", opts.Description,
+		"--priority", fmt.Sprintf("%d", opts.Priority),
+	}
+	if len(opts.Labels) > 0 {
+		args = append(args, "--labels", strings.Join(opts.Labels, ","))
+	}
+	// Actor for attribution (sender identity)
+	return append(args, "--actor", opts.Actor)
+}
+
 // sendToList expands a mailing list and sends individual copies to each recipient.
 // Each recipient gets their own message copy with the same content.
 // Returns a ListDeliveryResult with details about the fan-out.
@@ -674,40 +712,12 @@ func (r *Router) sendToQueue(msg *Message) error {
 		return err
 	}
 
-	// Build labels for from/thread/reply-to/cc plus queue metadata
-	var labels []string
-	labels = append(labels, "from:"+msg.From)
-	labels = append(labels, "queue:"+queueName)
-	if msg.ThreadID != "" {
-		labels = append(labels, "thread:"+msg.ThreadID)
-	}
-	if msg.ReplyTo != "" {
-		labels = append(labels, "reply-to:"+msg.ReplyTo)
-	}
-	for _, cc := range msg.CC {
-		ccIdentity := addressToIdentity(cc)
-		labels = append(labels, "cc:"+ccIdentity)
-	}
-
 	// Build command: bd create <subject> --type=message --assignee=queue:<name> -d <body>
-	// Use queue:<name> as assignee so inbox queries can filter by queue
-	args := []string{"create", msg.Subject,
-		"--type", "message",
-		"--assignee", msg.To, // queue:name
-		"-d", msg.Body,
-	}
-
-	// Add priority flag
-	beadsPriority := PriorityToBeads(msg.Priority)
-	args = append(args, "--priority", fmt.Sprintf("%d", beadsPriority))
-
-	// Add labels (includes queue name for filtering)
-	if len(labels) > 0 {
-		args = append(args, "--labels", strings.Join(labels, ","))
-	}
-
-	// Add actor for attribution (sender identity)
-	args = append(args, "--actor", msg.From)
+	// Use queue:<name> as assignee so inbox queries can filter by queue,
+	// and label it with the queue name for filtering
+	opts := beadsMessage(msg, msg.To).CreateOptions()
+	opts.Labels = append(opts.Labels, "queue:"+queueName)
+	args := messageCreateArgs(opts)
 
 	// Queue messages are never ephemeral - they need to persist until claimed
 	// (deliberately not checking shouldBeWisp)
@@ -745,40 +755,12 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 		}
 	}
 
-	// Build labels for from/thread/reply-to/cc plus announce metadata
-	var labels []string
-	labels = append(labels, "from:"+msg.From)
-	labels = append(labels, "announce:"+announceName)
-	if msg.ThreadID != "" {
-		labels = append(labels, "thread:"+msg.ThreadID)
-	}
-	if msg.ReplyTo != "" {
-		labels = append(labels, "reply-to:"+msg.ReplyTo)
-	}
-	for _, cc := range msg.CC {
-		ccIdentity := addressToIdentity(cc)
-		labels = append(labels, "cc:"+ccIdentity)
-	}
-
 	// Build command: bd create <subject> --type=message --assignee=announce:<name> -d <body>
-	// Use announce:<name> as assignee so queries can filter by channel
-	args := []string{"create", msg.Subject,
-		"--type", "message",
-		"--assignee", msg.To, // announce:name
-		"-d", msg.Body,
-	}
-
-	// Add priority flag
-	beadsPriority := PriorityToBeads(msg.Priority)
-	args = append(args, "--priority", fmt.Sprintf("%d", beadsPriority))
-
-	// Add labels (includes announce name for filtering)
-	if len(labels) > 0 {
-		args = append(args, "--labels", strings.Join(labels, ","))
-	}
-
-	// Add actor for attribution (sender identity)
-	args = append(args, "--actor", msg.From)
+	// Use announce:<name> as assignee so queries can filter by channel,
+	// and label it with the channel name for filtering
+	opts := beadsMessage(msg, msg.To).CreateOptions()
+	opts.Labels = append(opts.Labels, "announce:"+announceName)
+	args := messageCreateArgs(opts)
 
 	// Announce messages are never ephemeral - they need to persist for readers
 	// (deliberately not checking shouldBeWisp)
@@ -699,6 +699,21 @@ func TestAgentBeadToAddress(t *testing.T) {
 			bead: &agentBead{ID: "gt-gastown-polecat-my-agent"},
 			want: "gastown/my-agent",
 		},
+		{
+			name: "town beads mayor",
+			bead: &agentBead{ID: "hq-mayor"},
+			want: "mayor/",
+		},
+		{
+			name: "town beads dog",
+			bead: &agentBead{ID: "hq-dog-alpha"},
+			want: "deacon/dogs/alpha",
+		},
+		{
+			name: "town beads role bead (not an agent)",
+			bead: &agentBead{ID: "hq-witness-role"},
+			want: "",
+		},
 		{
 			name: "non-gt prefix (invalid)",
 			bead: &agentBead{ID: "bd-gastown-witness"},
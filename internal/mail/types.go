@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
 )
 
 // Priority levels for messages.
@@ -78,9 +80,15 @@ type Message struct {
 	// Timestamp is when the message was sent.
 	Timestamp time.Time `json:"timestamp"`
 
-	// Read indicates if the message has been read (closed in beads).
+	// Read indicates if the mailbox's owner has read the message: it
+	// carries their read receipt, or it was acknowledged (closed in beads).
 	Read bool `json:"read"`
 
+	// ReadBy holds the read receipts of the recipients who have read the
+	// message. Read state is per recipient: a CC'd recipient reading a
+	// message doesn't mark it read for the primary recipient.
+	ReadBy []ReadReceipt `json:"read_by,omitempty"`
+
 	// Priority is the message priority.
 	Priority Priority `json:"priority"`
 
@@ -109,6 +117,66 @@ type Message struct {
 	CC []string `json:"cc,omitempty"`
 }
 
+// ReadReceipt records that a recipient read a message.
+type ReadReceipt struct {
+	Reader string    `json:"reader"` // Recipient address
+	At     time.Time `json:"at"`
+
+	label string // Beads label the receipt was parsed from
+}
+
+// readReceiptPrefix starts the labels that carry read receipts in beads:
+// "read-by:<identity>@<time>".
+const readReceiptPrefix = "read-by:"
+
+// receiptTimeFormat is the UTC time format of read receipt labels.
+const receiptTimeFormat = "20060102T150405Z"
+
+// readReceiptLabel returns the beads label recording that identity read a
+// message at the given time.
+func readReceiptLabel(identity string, at time.Time) string {
+	return readReceiptPrefix + identity + "@" + at.UTC().Format(receiptTimeFormat)
+}
+
+// parseReadReceipt parses a read receipt label.
+func parseReadReceipt(label string) (ReadReceipt, bool) {
+	identity, stamp, ok := strings.Cut(strings.TrimPrefix(label, readReceiptPrefix), "@")
+	if !ok || identity == "" {
+		return ReadReceipt{}, false
+	}
+	at, err := time.Parse(receiptTimeFormat, stamp)
+	if err != nil {
+		return ReadReceipt{}, false
+	}
+	return ReadReceipt{Reader: identityToAddress(identity), At: at, label: label}, true
+}
+
+// ReceiptFor returns the read receipt address left on the message, if any.
+func (msg *Message) ReceiptFor(address string) (ReadReceipt, bool) {
+	identity := addressToIdentity(address)
+	for _, r := range msg.ReadBy {
+		if addressToIdentity(r.Reader) == identity {
+			return r, true
+		}
+	}
+	return ReadReceipt{}, false
+}
+
+// IsRecipient reports whether address is the message's recipient or one
+// of its CC recipients.
+func (msg *Message) IsRecipient(address string) bool {
+	identity := addressToIdentity(address)
+	if addressToIdentity(msg.To) == identity {
+		return true
+	}
+	for _, cc := range msg.CC {
+		if addressToIdentity(cc) == identity {
+			return true
+		}
+	}
+	return false
+}
+
 // NewMessage creates a new message with a generated ID and thread ID.
 func NewMessage(from, to, subject, body string) *Message {
 	return &Message{
@@ -174,7 +242,7 @@ type BeadsMessage struct {
 	Priority    int       `json:"priority"`    // 0=urgent, 1=high, 2=normal, 3=low
 	Status      string    `json:"status"`      // open=unread, closed=read
 	CreatedAt   time.Time `json:"created_at"`
-	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, msg-type:X, cc:X)
+	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, msg-type:X, cc:X, read-by:X@T)
 	Pinned      bool      `json:"pinned,omitempty"`
 	Wisp        bool      `json:"wisp,omitempty"` // Ephemeral message (filtered from JSONL export)
 
@@ -184,21 +252,26 @@ type BeadsMessage struct {
 	replyTo  string
 	msgType  string
 	cc       []string // CC recipients
+	readBy   []ReadReceipt
 }
 
 // ParseLabels extracts metadata from the labels array.
 func (bm *BeadsMessage) ParseLabels() {
 	for _, label := range bm.Labels {
-		if strings.HasPrefix(label, "from:") {
-			bm.sender = strings.TrimPrefix(label, "from:")
-		} else if strings.HasPrefix(label, "thread:") {
-			bm.threadID = strings.TrimPrefix(label, "thread:")
-		} else if strings.HasPrefix(label, "reply-to:") {
-			bm.replyTo = strings.TrimPrefix(label, "reply-to:")
-		} else if strings.HasPrefix(label, "msg-type:") {
-			bm.msgType = strings.TrimPrefix(label, "msg-type:")
-		} else if strings.HasPrefix(label, "cc:") {
-			bm.cc = append(bm.cc, strings.TrimPrefix(label, "cc:"))
+		if strings.HasPrefix(label, beads.LabelFrom) {
+			bm.sender = strings.TrimPrefix(label, beads.LabelFrom)
+		} else if strings.HasPrefix(label, beads.LabelThread) {
+			bm.threadID = strings.TrimPrefix(label, beads.LabelThread)
+		} else if strings.HasPrefix(label, beads.LabelReplyTo) {
+			bm.replyTo = strings.TrimPrefix(label, beads.LabelReplyTo)
+		} else if strings.HasPrefix(label, beads.LabelMsgType) {
+			bm.msgType = strings.TrimPrefix(label, beads.LabelMsgType)
+		} else if strings.HasPrefix(label, beads.LabelCC) {
+			bm.cc = append(bm.cc, strings.TrimPrefix(label, beads.LabelCC))
+		} else if strings.HasPrefix(label, readReceiptPrefix) {
+			if r, ok := parseReadReceipt(label); ok {
+				bm.readBy = append(bm.readBy, r)
+			}
 		}
 	}
 }
@@ -226,11 +299,11 @@ func (bm *BeadsMessage) ToMessage() *Message {
 	// Convert beads priority (0=urgent, 1=high, 2=normal, 3=low) to GGT Priority
 	var priority Priority
 	switch bm.Priority {
-	case 0:
+	case beads.PriorityCritical:
 		priority = PriorityUrgent
-	case 1:
+	case beads.PriorityHigh:
 		priority = PriorityHigh
-	case 3:
+	case beads.PriorityLow:
 		priority = PriorityLow
 	default:
 		priority = PriorityNormal
@@ -263,6 +336,7 @@ func (bm *BeadsMessage) ToMessage() *Message {
 		ReplyTo:   bm.replyTo,
 		Wisp:      bm.Wisp,
 		CC:        ccAddrs,
+		ReadBy:    bm.readBy,
 	}
 }
 
@@ -271,13 +345,13 @@ func (bm *BeadsMessage) ToMessage() *Message {
 func PriorityToBeads(p Priority) int {
 	switch p {
 	case PriorityUrgent:
-		return 0
+		return beads.PriorityCritical
 	case PriorityHigh:
-		return 1
+		return beads.PriorityHigh
 	case PriorityLow:
-		return 3
+		return beads.PriorityLow
 	default:
-		return 2 // normal
+		return beads.PriorityMedium
 	}
 }
 
@@ -349,3 +349,36 @@ func TestBeadsMessageToMessageEmptyLabels(t *testing.T) {
 		t.Errorf("ThreadID should be empty, got %q", msg.ThreadID)
 	}
 }
+
+func TestBeadsMessageReadReceipts(t *testing.T) {
+	at := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	bm := BeadsMessage{
+		ID:       "hq-cc",
+		Status:   "open",
+		Assignee: "gastown/Toast",
+		Labels: []string{
+			"from:mayor/",
+			"cc:gastown/polecats/nux",
+			readReceiptLabel("gastown/nux", at),
+			"read-by:garbage",
+		},
+	}
+
+	msg := bm.ToMessage()
+	if len(msg.ReadBy) != 1 {
+		t.Fatalf("ReadBy = %+v, want one receipt", msg.ReadBy)
+	}
+	r, ok := msg.ReceiptFor("gastown/polecats/nux")
+	if !ok || !r.At.Equal(at) || r.Reader != "gastown/nux" {
+		t.Errorf("ReceiptFor(nux) = %+v, %v", r, ok)
+	}
+	if _, ok := msg.ReceiptFor("gastown/Toast"); ok {
+		t.Error("the primary recipient has no receipt")
+	}
+	if msg.Read {
+		t.Error("an open message is unread until its reader is known")
+	}
+	if !msg.IsRecipient("gastown/Toast") || !msg.IsRecipient("gastown/nux") || msg.IsRecipient("mayor/") {
+		t.Error("IsRecipient should cover To and CC only")
+	}
+}
@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// AllTools in an allowlist allows every tool.
+const AllTools = "*"
+
+// DefaultAllowlists are the tools each role may call unless the town's
+// mcp.tools setting says otherwise. Polecats work their own hook and don't
+// dispatch; the refinery and witness also leave dispatch to the mayor.
+var DefaultAllowlists = map[string][]string{
+	"mayor":    {AllTools},
+	"deacon":   {AllTools},
+	"crew":     {AllTools},
+	"witness":  {ToolHook, ToolMailSend, ToolMailInbox, ToolBeadList, ToolBeadShow, ToolBeadCreate, ToolBeadUpdate, ToolFeedQuery},
+	"refinery": {ToolMailSend, ToolMailInbox, ToolBeadList, ToolBeadShow, ToolBeadUpdate, ToolFeedQuery},
+	"polecat":  {ToolHook, ToolMailSend, ToolMailInbox, ToolBeadList, ToolBeadShow, ToolBeadCreate, ToolBeadUpdate, ToolFeedQuery},
+}
+
+// readOnlyTools are allowed for roles with no allowlist, including
+// sessions whose role can't be detected.
+var readOnlyTools = []string{ToolMailInbox, ToolBeadList, ToolBeadShow, ToolFeedQuery}
+
+// Allowlist returns the tool names role may call: the town's mcp.tools
+// entry for the role, else DefaultAllowlists, else read-only tools.
+func Allowlist(role string, c *config.MCPConfig) []string {
+	if c != nil {
+		if tools, ok := c.Tools[role]; ok {
+			return tools
+		}
+	}
+	if tools, ok := DefaultAllowlists[role]; ok {
+		return tools
+	}
+	return readOnlyTools
+}
+
+// Filter returns the tools named in allowed, in the order of tools. It is
+// an error for allowed to name a tool that doesn't exist, so a typo in
+// settings doesn't silently take a tool away.
+func Filter(tools []Tool, allowed []string) ([]Tool, error) {
+	for _, name := range allowed {
+		if name != AllTools && !slices.ContainsFunc(tools, func(t Tool) bool { return t.Name == name }) {
+			return nil, fmt.Errorf("unknown MCP tool %q in allowlist", name)
+		}
+	}
+	if slices.Contains(allowed, AllTools) {
+		return tools, nil
+	}
+	var out []Tool
+	for _, t := range tools {
+		if slices.Contains(allowed, t.Name) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
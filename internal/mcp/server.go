@@ -0,0 +1,214 @@
+// Package mcp serves gastown operations as Model Context Protocol tools,
+// so agents can sling, hook, mail, and work beads through typed tool calls
+// instead of composing gt command lines.
+//
+// The server speaks JSON-RPC 2.0 over stdio, one message per line, and
+// implements the tools capability only. Which tools a session sees
+// depends on its role (see Allowlist).
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// ProtocolVersion is the newest MCP revision the server speaks.
+const ProtocolVersion = "2025-06-18"
+
+// supportedVersions are the revisions the server accepts from a client.
+// The tools surface is the same in all of them.
+var supportedVersions = map[string]bool{
+	"2025-06-18": true,
+	"2025-03-26": true,
+	"2024-11-05": true,
+}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// request is a JSON-RPC request, or a notification if ID is absent.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// content is one item of a tool result; the server only returns text.
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// toolResult is the result of tools/call. A tool that ran and failed is
+// a result with IsError set, so the model sees the reason.
+type toolResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Server dispatches MCP requests to a fixed set of tools.
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+	byName  map[string]Tool
+
+	mu  sync.Mutex // Serializes writes
+	out io.Writer
+}
+
+// NewServer returns a server offering tools, identified to clients as
+// name at version.
+func NewServer(name, version string, tools []Tool) *Server {
+	s := &Server{name: name, version: version, tools: tools, byName: make(map[string]Tool, len(tools))}
+	for _, t := range tools {
+		s.byName[t.Name] = t
+	}
+	return s
+}
+
+// Serve reads requests from r and writes responses to w until r is
+// exhausted or ctx is done. Requests are handled one at a time.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = w
+	in := bufio.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, err := in.ReadBytes('\n')
+		if len(line) > 0 {
+			s.handleLine(ctx, line)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handleLine(ctx context.Context, line []byte) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		s.reply(json.RawMessage("null"), nil, &rpcError{Code: codeParseError, Message: err.Error()})
+		return
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if req.ID != nil {
+			s.reply(req.ID, nil, &rpcError{Code: codeInvalidRequest, Message: "not a JSON-RPC 2.0 request"})
+		}
+		return
+	}
+
+	result, rerr := s.handle(ctx, req)
+	if req.ID == nil {
+		return // Notifications get no response
+	}
+	s.reply(req.ID, result, rerr)
+}
+
+// handle runs one request and returns its result or error.
+func (s *Server) handle(ctx context.Context, req request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &p)
+		version := ProtocolVersion
+		if supportedVersions[p.ProtocolVersion] {
+			version = p.ProtocolVersion
+		}
+		return map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		}, nil
+
+	case "ping":
+		return map[string]interface{}{}, nil
+
+	case "tools/list":
+		list := make([]map[string]interface{}, 0, len(s.tools))
+		for _, t := range s.tools {
+			list = append(list, map[string]interface{}{
+				"name":        t.Name,
+				"description": t.Description,
+				"inputSchema": t.InputSchema,
+			})
+		}
+		return map[string]interface{}{"tools": list}, nil
+
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		t, ok := s.byName[p.Name]
+		if !ok {
+			return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", p.Name)}
+		}
+		args := p.Arguments
+		if len(args) == 0 || string(args) == "null" {
+			args = json.RawMessage("{}")
+		}
+		text, err := t.Run(ctx, args)
+		if err != nil {
+			return toolResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		return toolResult{Content: []content{{Type: "text", Text: text}}}, nil
+	}
+
+	if strings.HasPrefix(req.Method, "notifications/") {
+		return nil, nil // initialized, cancelled, ...: nothing to do
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+}
+
+func (s *Server) reply(id json.RawMessage, result interface{}, rerr *rpcError) {
+	resp := response{JSONRPC: "2.0", ID: id, Result: result, Error: rerr}
+	if rerr == nil && result == nil {
+		resp.Result = map[string]interface{}{}
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: codeInternalError, Message: err.Error()}})
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.out.Write(append(data, '\n'))
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// roundTrip sends each request line through a server and returns the
+// decoded responses.
+func roundTrip(t *testing.T, s *Server, lines ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	var resps []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var r map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("bad response %q: %v", line, err)
+		}
+		resps = append(resps, r)
+	}
+	return resps
+}
+
+func TestServer_Protocol(t *testing.T) {
+	env := Env{TownRoot: t.TempDir(), Beads: beadstest.NewFakeClient()}
+	tools, err := Filter(Tools(env), Allowlist("polecat", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer("gastown", "test", tools)
+
+	resps := roundTrip(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"sling","arguments":{"bead":"gt-1"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"bogus"}`,
+		`not json`,
+	)
+	if len(resps) != 5 {
+		t.Fatalf("got %d responses, want 5 (notifications get none): %v", len(resps), resps)
+	}
+
+	init := resps[0]["result"].(map[string]interface{})
+	if init["protocolVersion"] != "2024-11-05" {
+		t.Errorf("protocolVersion = %v, want the client's", init["protocolVersion"])
+	}
+
+	listed := resps[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	names := make(map[string]bool)
+	for _, tool := range listed {
+		names[tool.(map[string]interface{})["name"].(string)] = true
+	}
+	if names[ToolSling] || !names[ToolHook] || !names[ToolBeadCreate] {
+		t.Errorf("polecat tools = %v", names)
+	}
+
+	// Tools off the allowlist don't exist for this session
+	if e, ok := resps[2]["error"].(map[string]interface{}); !ok || e["code"].(float64) != codeInvalidParams {
+		t.Errorf("sling call = %v, want invalid params", resps[2])
+	}
+	if e, ok := resps[3]["error"].(map[string]interface{}); !ok || e["code"].(float64) != codeMethodNotFound {
+		t.Errorf("bogus method = %v, want method not found", resps[3])
+	}
+	if e, ok := resps[4]["error"].(map[string]interface{}); !ok || e["code"].(float64) != codeParseError {
+		t.Errorf("bad json = %v, want parse error", resps[4])
+	}
+}
+
+// callTool calls a tool through the server and returns its text and
+// whether it failed.
+func callTool(t *testing.T, s *Server, name, args string) (string, bool) {
+	t.Helper()
+	resps := roundTrip(t, s, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"`+name+`","arguments":`+args+`}}`)
+	result, ok := resps[0]["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("%s: %v", name, resps[0])
+	}
+	text := result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	isError, _ := result["isError"].(bool)
+	return text, isError
+}
+
+func TestTools_Beads(t *testing.T) {
+	env := Env{TownRoot: t.TempDir(), Beads: beadstest.NewFakeClient()}
+	s := NewServer("gastown", "test", Tools(env))
+
+	text, failed := callTool(t, s, ToolBeadCreate, `{"title":"Fix login","type":"bug","priority":1}`)
+	if failed || !strings.Contains(text, `"id": "gt-1"`) {
+		t.Fatalf("create = %q (failed %v)", text, failed)
+	}
+	if text, failed = callTool(t, s, ToolBeadUpdate, `{"id":"gt-1","status":"in_progress","assignee":"gastown/nux"}`); failed || !strings.Contains(text, "in_progress") {
+		t.Errorf("update = %q (failed %v)", text, failed)
+	}
+	if text, failed = callTool(t, s, ToolBeadList, `{"assignee":"gastown/nux"}`); failed || !strings.HasPrefix(text, "gt-1\tin_progress\tP1\tbug\tFix login\t@gastown/nux") {
+		t.Errorf("list = %q (failed %v)", text, failed)
+	}
+
+	// Validation failures come back as tool errors the model can read
+	for _, tc := range []struct{ name, args, want string }{
+		{ToolBeadCreate, `{"title":"x","priority":7}`, "must be 0-4"},
+		{ToolBeadUpdate, `{"id":"gt-1","status":"finished"}`, "unknown status"},
+		{ToolBeadShow, `{"id":"gt-1","verbose":true}`, "unknown field"},
+		{ToolSling, `{"bead":"--dry-run"}`, "must not start with -"},
+	} {
+		text, failed := callTool(t, s, tc.name, tc.args)
+		if !failed || !strings.Contains(text, tc.want) {
+			t.Errorf("%s %s = %q (failed %v), want error containing %q", tc.name, tc.args, text, failed, tc.want)
+		}
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	tools := Tools(Env{})
+	cfg := &config.MCPConfig{Tools: map[string][]string{"polecat": {ToolHook}, "dog": {AllTools}}}
+
+	tests := []struct {
+		role string
+		cfg  *config.MCPConfig
+		want int
+	}{
+		{"mayor", nil, len(tools)},
+		{"polecat", nil, len(DefaultAllowlists["polecat"])},
+		{"polecat", cfg, 1},      // Town override replaces the default
+		{"dog", cfg, len(tools)}, // Town adds a role
+		{"unknown", nil, len(readOnlyTools)},
+	}
+	for _, tt := range tests {
+		got, err := Filter(tools, Allowlist(tt.role, tt.cfg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("%s: %d tools, want %d", tt.role, len(got), tt.want)
+		}
+	}
+
+	if _, err := Filter(tools, []string{"hoook"}); err == nil {
+		t.Error("Filter accepted an unknown tool name")
+	}
+}
@@ -0,0 +1,456 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	gtfeed "github.com/steveyegge/gastown/internal/feed"
+)
+
+// Tool is one operation the server offers.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]interface{} // JSON Schema for the arguments
+
+	// Run performs the call. args is the JSON arguments object. An error
+	// is reported to the model as a failed tool result.
+	Run func(ctx context.Context, args json.RawMessage) (string, error)
+}
+
+// Tool names.
+const (
+	ToolSling      = "sling"
+	ToolHook       = "hook"
+	ToolMailSend   = "mail_send"
+	ToolMailInbox  = "mail_inbox"
+	ToolBeadList   = "bead_list"
+	ToolBeadShow   = "bead_show"
+	ToolBeadCreate = "bead_create"
+	ToolBeadUpdate = "bead_update"
+	ToolFeedQuery  = "feed_query"
+)
+
+// defaultFeedLimit is how many events feed_query returns without a limit.
+const defaultFeedLimit = 50
+
+// Env is what the gastown tools act on.
+type Env struct {
+	TownRoot string
+	WorkDir  string       // Where gt runs for sling, hook, and mail
+	Beads    beads.Client // Usually the town's router
+	GT       string       // Path to the gt binary
+}
+
+// Tools returns every gastown tool, bound to env.
+//
+// Sling, hook, and mail run gt itself, so they behave exactly as on the
+// command line (identity detection, events, notifications); the session's
+// environment carries GT_ROLE and friends through. Beads and feed tools
+// call the libraries directly, with the same validation as the CLI.
+func Tools(env Env) []Tool {
+	return []Tool{
+		{
+			Name:        ToolSling,
+			Description: "Assign a bead (or formula) to an agent and start it working, as gt sling does. Without a target the work goes to your own hook.",
+			InputSchema: object(map[string]interface{}{
+				"bead":    str("Bead ID or formula name"),
+				"target":  str("Agent or rig to sling to, e.g. gastown/nux or gastown (new polecat)"),
+				"subject": str("Context subject for the work"),
+				"message": str("Context message for the work"),
+				"create":  boolean("Create the polecat if it doesn't exist"),
+			}, "bead"),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct {
+					Bead, Target, Subject, Message string
+					Create                         bool
+				}
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				if err := checkPositional("bead", a.Bead); err != nil {
+					return "", err
+				}
+				if err := checkPositional("target", a.Target); err != nil {
+					return "", err
+				}
+				args := []string{"sling", a.Bead}
+				if a.Target != "" {
+					args = append(args, a.Target)
+				}
+				args = appendFlag(args, "--subject", a.Subject)
+				args = appendFlag(args, "--message", a.Message)
+				if a.Create {
+					args = append(args, "--create")
+				}
+				return env.gt(ctx, args...)
+			},
+		},
+		{
+			Name:        ToolHook,
+			Description: "Show what is on your hook, or attach a bead to it, as gt hook does.",
+			InputSchema: object(map[string]interface{}{
+				"bead":  str("Bead to attach; omit to show the current hook"),
+				"force": boolean("Replace an existing incomplete hooked bead"),
+			}),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct {
+					Bead  string
+					Force bool
+				}
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				if err := checkPositional("bead", a.Bead); err != nil {
+					return "", err
+				}
+				args := []string{"hook"}
+				if a.Bead != "" {
+					args = append(args, a.Bead)
+				}
+				if a.Force {
+					args = append(args, "--force")
+				}
+				return env.gt(ctx, args...)
+			},
+		},
+		{
+			Name:        ToolMailSend,
+			Description: "Send mail to an agent, list, queue, or group address, as gt mail send does.",
+			InputSchema: object(map[string]interface{}{
+				"to":       str("Recipient address, e.g. mayor/, gastown/witness, list:oncall"),
+				"subject":  str("Message subject"),
+				"body":     str("Message body"),
+				"priority": integer("0=urgent, 1=high, 2=normal (default), 3=low, 4=backlog"),
+				"reply_to": str("ID of the message this replies to"),
+				"cc":       strList("CC recipients"),
+			}, "to", "subject"),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct {
+					To, Subject, Body string
+					Priority          *int
+					ReplyTo           string `json:"reply_to"`
+					CC                []string
+				}
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				if err := checkPositional("to", a.To); err != nil {
+					return "", err
+				}
+				args := []string{"mail", "send", a.To, "--subject=" + a.Subject}
+				args = appendFlag(args, "--message", a.Body)
+				args = appendFlag(args, "--reply-to", a.ReplyTo)
+				if a.Priority != nil {
+					args = append(args, "--priority="+strconv.Itoa(*a.Priority))
+				}
+				for _, cc := range a.CC {
+					args = append(args, "--cc="+cc)
+				}
+				return env.gt(ctx, args...)
+			},
+		},
+		{
+			Name:        ToolMailInbox,
+			Description: "List the messages in your inbox as JSON.",
+			InputSchema: object(map[string]interface{}{
+				"unread": boolean("Only unread messages"),
+			}),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct{ Unread bool }
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				args := []string{"mail", "inbox", "--json"}
+				if a.Unread {
+					args = append(args, "--unread")
+				}
+				return env.gt(ctx, args...)
+			},
+		},
+		{
+			Name:        ToolBeadList,
+			Description: "List beads, one per line: ID, status, priority, type, title, assignee. Without a status, lists open work.",
+			InputSchema: object(map[string]interface{}{
+				"status":   str("open, in_progress, blocked, closed, hooked, or all"),
+				"type":     str("Issue type, e.g. task, bug, feature"),
+				"assignee": str("Assignee, e.g. gastown/nux"),
+				"label":    str("Only beads with this label"),
+				"parent":   str("Only children of this bead"),
+				"priority": integer("Only this priority (0-4)"),
+				"limit":    integer("Maximum beads to return"),
+			}),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct {
+					Status, Type, Assignee, Label, Parent string
+					Priority                              *int
+					Limit                                 int
+				}
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				opts := beads.ListOptions{Status: a.Status, Type: a.Type, Assignee: a.Assignee,
+					Label: a.Label, Parent: a.Parent, Priority: -1, Limit: a.Limit}
+				if a.Priority != nil {
+					opts.Priority = *a.Priority
+				}
+				issues, err := env.Beads.List(opts)
+				if err != nil {
+					return "", err
+				}
+				if len(issues) == 0 {
+					return "No matching beads.", nil
+				}
+				var b strings.Builder
+				for _, issue := range issues {
+					fmt.Fprintf(&b, "%s\t%s\tP%d\t%s\t%s", issue.ID, issue.Status, issue.Priority, issue.Type, issue.Title)
+					if issue.Assignee != "" {
+						fmt.Fprintf(&b, "\t@%s", issue.Assignee)
+					}
+					b.WriteString("\n")
+				}
+				return b.String(), nil
+			},
+		},
+		{
+			Name:        ToolBeadShow,
+			Description: "Show a bead in full as JSON.",
+			InputSchema: object(map[string]interface{}{
+				"id": str("Bead ID"),
+			}, "id"),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct{ ID string }
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				if err := beads.ValidateID(a.ID); err != nil {
+					return "", err
+				}
+				issue, err := env.Beads.Show(a.ID)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(issue)
+			},
+		},
+		{
+			Name:        ToolBeadCreate,
+			Description: "Create a bead and return it as JSON.",
+			InputSchema: object(map[string]interface{}{
+				"title":       str("Title"),
+				"type":        str("Issue type: task (default), bug, feature, epic, chore, ..."),
+				"priority":    integer("0 (critical) to 4 (backlog)"),
+				"description": str("Description"),
+				"parent":      str("Parent bead ID"),
+				"assignee":    str("Assignee, e.g. gastown/nux"),
+				"labels":      strList("Labels"),
+			}, "title"),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct {
+					Title, Type, Description, Parent, Assignee string
+					Priority                                   *int
+					Labels                                     []string
+				}
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				opts := beads.CreateOptions{Title: a.Title, Type: a.Type, Priority: -1, Description: a.Description,
+					Parent: a.Parent, Assignee: a.Assignee, Labels: a.Labels}
+				if a.Priority != nil {
+					opts.Priority = *a.Priority
+				}
+				if err := opts.Validate(); err != nil {
+					return "", err
+				}
+				issue, err := env.Beads.Create(opts)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(issue)
+			},
+		},
+		{
+			Name:        ToolBeadUpdate,
+			Description: "Update a bead's fields and return it as JSON. Omitted fields are unchanged.",
+			InputSchema: object(map[string]interface{}{
+				"id":            str("Bead ID"),
+				"title":         str("New title"),
+				"status":        str("open, in_progress, blocked, deferred, closed, pinned, or hooked"),
+				"priority":      integer("0 (critical) to 4 (backlog)"),
+				"description":   str("New description"),
+				"assignee":      str("New assignee; empty string to unassign"),
+				"add_labels":    strList("Labels to add"),
+				"remove_labels": strList("Labels to remove"),
+			}, "id"),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct {
+					ID                         string
+					Title, Status, Description *string
+					Assignee                   *string
+					Priority                   *int
+					AddLabels                  []string `json:"add_labels"`
+					RemoveLabels               []string `json:"remove_labels"`
+				}
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				if err := beads.ValidateID(a.ID); err != nil {
+					return "", err
+				}
+				opts := beads.UpdateOptions{Title: a.Title, Status: a.Status, Priority: a.Priority,
+					Description: a.Description, Assignee: a.Assignee, AddLabels: a.AddLabels, RemoveLabels: a.RemoveLabels}
+				if err := opts.Validate(); err != nil {
+					return "", err
+				}
+				if err := env.Beads.Update(a.ID, opts); err != nil {
+					return "", err
+				}
+				issue, err := env.Beads.Show(a.ID)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(issue)
+			},
+		},
+		{
+			Name:        ToolFeedQuery,
+			Description: "Query the town's event feed, newest last, one event per line. Filters match gt events query.",
+			InputSchema: object(map[string]interface{}{
+				"since":    str("Only events within this duration, e.g. 30m, 24h, 7d"),
+				"from":     str("Only events at or after this time (RFC 3339 or YYYY-MM-DD)"),
+				"until":    str("Only events before this time"),
+				"types":    strList("Only these event types, e.g. sling, hook, done"),
+				"actor":    str("Only this actor, a prefix ending in /, or a glob"),
+				"severity": str("Minimum severity: debug, info, warn, error"),
+				"payload":  strList("Payload conditions as key=value"),
+				"all":      boolean("Include audit-only events, not just the feed"),
+				"limit":    integer(fmt.Sprintf("Most recent events to return (default %d)", defaultFeedLimit)),
+			}),
+			Run: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var a struct {
+					Since, From, Until, Actor, Severity string
+					Types, Payload                      []string
+					All                                 bool
+					Limit                               int
+				}
+				if err := decodeArgs(raw, &a); err != nil {
+					return "", err
+				}
+				qa := events.QueryArgs{Since: a.Since, From: a.From, Until: a.Until, Types: a.Types,
+					Actor: a.Actor, Severity: a.Severity, Payload: a.Payload}
+				q, err := qa.Query(time.Now())
+				if err != nil {
+					return "", err
+				}
+				q.FeedOnly = !a.All
+				evts, err := q.Run(env.TownRoot)
+				if err != nil {
+					return "", err
+				}
+				limit := a.Limit
+				if limit <= 0 {
+					limit = defaultFeedLimit
+				}
+				if len(evts) > limit {
+					evts = evts[len(evts)-limit:]
+				}
+				if len(evts) == 0 {
+					return "No matching events.", nil
+				}
+				f, _ := gtfeed.LoadFormatter(env.TownRoot) // Falls back to the default templates
+				var b strings.Builder
+				for _, e := range evts {
+					fmt.Fprintf(&b, "%s\t%s\t%s\n", e.Timestamp, e.Type, f.Format(e))
+				}
+				return b.String(), nil
+			},
+		},
+	}
+}
+
+// gt runs a gt subcommand in the work directory and returns its output.
+func (env Env) gt(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, env.GT, args...) //nolint:gosec // G204: gt is this binary; args come from typed tool arguments
+	cmd.Dir = env.WorkDir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	text := strings.TrimSpace(out.String())
+	if err != nil {
+		if text == "" {
+			return "", fmt.Errorf("gt %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("gt %s failed: %s", args[0], text)
+	}
+	return text, nil
+}
+
+// decodeArgs decodes tool arguments, rejecting unknown ones so a
+// misspelled argument fails instead of being ignored.
+func decodeArgs(raw json.RawMessage, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// appendFlag appends flag and value if value is set.
+func appendFlag(args []string, flag, value string) []string {
+	if value == "" {
+		return args
+	}
+	return append(args, flag+"="+value)
+}
+
+// checkPositional rejects a positional argument gt would read as a flag.
+func checkPositional(name, value string) error {
+	if strings.HasPrefix(value, "-") {
+		return fmt.Errorf("invalid %s %q: must not start with -", name, value)
+	}
+	return nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// JSON Schema helpers.
+
+func object(props map[string]interface{}, required ...string) map[string]interface{} {
+	s := map[string]interface{}{"type": "object", "properties": props, "additionalProperties": false}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func str(desc string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": desc}
+}
+
+func integer(desc string) map[string]interface{} {
+	return map[string]interface{}{"type": "integer", "description": desc}
+}
+
+func boolean(desc string) map[string]interface{} {
+	return map[string]interface{}{"type": "boolean", "description": desc}
+}
+
+func strList(desc string) map[string]interface{} {
+	return map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": desc}
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// DefaultSnapshotInterval is how often beads stores are snapshotted.
+const DefaultSnapshotInterval = time.Minute
+
+// SnapshotFunc reads a consistent view of one beads store.
+type SnapshotFunc func() (*beads.Snapshot, error)
+
+// Collector feeds Metrics from a town's events log and beads stores.
+type Collector struct {
+	Metrics  *Metrics
+	Interval time.Duration // Between beads snapshots; 0 means DefaultSnapshotInterval
+
+	townRoot string
+	stores   map[string]SnapshotFunc
+}
+
+// NewCollector collects from the events logs of the town at townRoot and
+// the given beads stores, keyed by the store label reported on the beads
+// metrics (e.g. "town" or a rig name).
+func NewCollector(townRoot string, stores map[string]SnapshotFunc) *Collector {
+	return &Collector{Metrics: New(), townRoot: townRoot, stores: stores}
+}
+
+// Run replays the events logs' history into the counters, then follows
+// them and snapshots the beads stores until ctx is done.
+func (c *Collector) Run(ctx context.Context) {
+	interval := c.Interval
+	if interval <= 0 {
+		interval = DefaultSnapshotInterval
+	}
+
+	// Tail before reading history so nothing written in between is missed;
+	// an event in both is counted twice at worst, never dropped.
+	tail := events.TailTown(ctx, c.townRoot)
+	if history, err := events.ReadTown(c.townRoot); err == nil {
+		for _, e := range history {
+			c.Metrics.ObserveEvent(e)
+		}
+	}
+	c.snapshot()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-tail:
+			if !ok {
+				return
+			}
+			c.Metrics.ObserveEvent(e)
+		case <-ticker.C:
+			c.snapshot()
+		}
+	}
+}
+
+func (c *Collector) snapshot() {
+	for store, fn := range c.stores {
+		snap, err := fn()
+		if err != nil {
+			c.Metrics.SnapshotFailed(store)
+			continue
+		}
+		c.Metrics.ObserveSnapshot(store, snap)
+	}
+}
@@ -0,0 +1,239 @@
+// Package metrics exposes town health as Prometheus metrics: counters fed
+// by the events log and gauges refreshed from periodic beads snapshots,
+// served in the Prometheus text format on /metrics.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// SlingLatencyBuckets are the upper bounds, in seconds, of the sling
+// latency histogram: one minute to one day.
+var SlingLatencyBuckets = []float64{60, 300, 900, 1800, 3600, 2 * 3600, 4 * 3600, 8 * 3600, 24 * 3600}
+
+// maxPendingSlings bounds how many slung-but-not-done beads are tracked
+// for latency; the oldest are forgotten first.
+const maxPendingSlings = 10000
+
+// Metrics holds the current values. All methods are safe for concurrent use.
+type Metrics struct {
+	mu sync.Mutex
+
+	eventsByType map[string]float64
+
+	// Per beads store ("town" or a rig name)
+	beadsByStatus map[string]map[string]float64
+	blocked       map[string]float64
+	ready         map[string]float64
+	lastSnapshot  map[string]time.Time
+	snapshotFails map[string]float64
+
+	// Sling latency: time from a bead's sling to its done
+	slungAt      map[string]time.Time
+	latency      []uint64 // Cumulative-by-bucket is computed on output
+	latencySum   float64
+	latencyCount uint64
+}
+
+// New returns empty metrics.
+func New() *Metrics {
+	return &Metrics{
+		eventsByType:  make(map[string]float64),
+		beadsByStatus: make(map[string]map[string]float64),
+		blocked:       make(map[string]float64),
+		ready:         make(map[string]float64),
+		lastSnapshot:  make(map[string]time.Time),
+		snapshotFails: make(map[string]float64),
+		slungAt:       make(map[string]time.Time),
+		latency:       make([]uint64, len(SlingLatencyBuckets)),
+	}
+}
+
+// ObserveEvent counts an event and tracks sling latency.
+func (m *Metrics) ObserveEvent(e events.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.eventsByType[e.Type]++
+
+	ts, err := time.Parse(time.RFC3339, e.Timestamp)
+	if err != nil {
+		return
+	}
+	switch e.Type {
+	case events.TypeSling:
+		var p events.SlingEvent
+		if events.Decode(e, &p) == nil && p.Bead != "" {
+			if len(m.slungAt) >= maxPendingSlings {
+				m.forgetOldestSling()
+			}
+			m.slungAt[p.Bead] = ts
+		}
+	case events.TypeDone:
+		var p events.DoneEvent
+		if events.Decode(e, &p) != nil {
+			return
+		}
+		slung, ok := m.slungAt[p.Bead]
+		if !ok {
+			return
+		}
+		delete(m.slungAt, p.Bead)
+		secs := ts.Sub(slung).Seconds()
+		if secs < 0 {
+			return
+		}
+		for i, le := range SlingLatencyBuckets {
+			if secs <= le {
+				m.latency[i]++
+				break
+			}
+		}
+		m.latencySum += secs
+		m.latencyCount++
+	}
+}
+
+func (m *Metrics) forgetOldestSling() {
+	var oldest string
+	var at time.Time
+	for id, t := range m.slungAt {
+		if oldest == "" || t.Before(at) {
+			oldest, at = id, t
+		}
+	}
+	delete(m.slungAt, oldest)
+}
+
+// ObserveSnapshot replaces the beads gauges of one store.
+func (m *Metrics) ObserveSnapshot(store string, snap *beads.Snapshot) {
+	byStatus := make(map[string]float64)
+	for _, issue := range snap.Issues() {
+		if issue.Status != "closed" {
+			byStatus[issue.Status]++
+		}
+	}
+	blocked, ready := float64(len(snap.Blocked())), float64(len(snap.Ready()))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.beadsByStatus[store] = byStatus
+	m.blocked[store] = blocked
+	m.ready[store] = ready
+	m.lastSnapshot[store] = snap.TakenAt
+}
+
+// SnapshotFailed counts a failed snapshot of a store. Its gauges keep
+// their last values; gt_beads_snapshot_timestamp_seconds shows how stale.
+func (m *Metrics) SnapshotFailed(store string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshotFails[store]++
+}
+
+// WriteText writes every metric in the Prometheus text exposition format.
+func (m *Metrics) WriteText(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	family(&b, "gt_events_total", "counter", "Events logged, by type.")
+	for _, typ := range sortedKeys(m.eventsByType) {
+		sample(&b, "gt_events_total", m.eventsByType[typ], "type", typ)
+	}
+
+	family(&b, "gt_beads_open", "gauge", "Beads not closed, by store and status.")
+	for _, store := range sortedKeys(m.beadsByStatus) {
+		for _, status := range sortedKeys(m.beadsByStatus[store]) {
+			sample(&b, "gt_beads_open", m.beadsByStatus[store][status], "store", store, "status", status)
+		}
+	}
+	family(&b, "gt_beads_blocked", "gauge", "Beads not closed with an open blocker, by store.")
+	for _, store := range sortedKeys(m.blocked) {
+		sample(&b, "gt_beads_blocked", m.blocked[store], "store", store)
+	}
+	family(&b, "gt_ready_queue_depth", "gauge", "Open, unblocked beads, by store.")
+	for _, store := range sortedKeys(m.ready) {
+		sample(&b, "gt_ready_queue_depth", m.ready[store], "store", store)
+	}
+	family(&b, "gt_beads_snapshot_timestamp_seconds", "gauge", "When each store was last snapshotted.")
+	for _, store := range sortedKeys(m.lastSnapshot) {
+		sample(&b, "gt_beads_snapshot_timestamp_seconds", float64(m.lastSnapshot[store].Unix()), "store", store)
+	}
+	family(&b, "gt_beads_snapshot_failures_total", "counter", "Failed beads snapshots, by store.")
+	for _, store := range sortedKeys(m.snapshotFails) {
+		sample(&b, "gt_beads_snapshot_failures_total", m.snapshotFails[store], "store", store)
+	}
+
+	family(&b, "gt_sling_latency_seconds", "histogram", "Time from a bead being slung to its done.")
+	var cumulative uint64
+	for i, le := range SlingLatencyBuckets {
+		cumulative += m.latency[i]
+		sample(&b, "gt_sling_latency_seconds_bucket", float64(cumulative), "le", formatFloat(le))
+	}
+	sample(&b, "gt_sling_latency_seconds_bucket", float64(m.latencyCount), "le", "+Inf")
+	sample(&b, "gt_sling_latency_seconds_sum", m.latencySum)
+	sample(&b, "gt_sling_latency_seconds_count", float64(m.latencyCount))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WriteText(w)
+}
+
+func family(b *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes one sample; labels alternate names and values.
+func sample(b *strings.Builder, name string, value float64, labels ...string) {
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(b, "%s=%q", labels[i], escapeLabel(labels[i+1]))
+		}
+		b.WriteByte('}')
+	}
+	fmt.Fprintf(b, " %s\n", formatFloat(value))
+}
+
+// escapeLabel leaves only the escapes %q produces that Prometheus accepts
+// (backslash, quote, newline) by replacing other control characters.
+func escapeLabel(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' && r != '\n' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
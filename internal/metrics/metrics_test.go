@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func event(ts time.Time, typ string, payload map[string]interface{}) events.Event {
+	return events.Event{Timestamp: ts.UTC().Format(time.RFC3339), Type: typ, Payload: payload}
+}
+
+func TestMetricsText(t *testing.T) {
+	m := New()
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	m.ObserveEvent(event(start, events.TypeSling, events.SlingPayload("gt-1", "gastown/polecats/nux")))
+	m.ObserveEvent(event(start.Add(20*time.Minute), events.TypeDone, events.DonePayload("gt-1", "polecat/nux")))
+	m.ObserveEvent(event(start, events.TypeDone, events.DonePayload("gt-unslung", "b"))) // No latency sample
+
+	m.ObserveSnapshot("gastown", beads.NewSnapshot([]*beads.Issue{
+		{ID: "gt-a", Status: "open"},
+		{ID: "gt-b", Status: "open", DependsOn: []string{"gt-c"}},
+		{ID: "gt-c", Status: "in_progress"},
+		{ID: "gt-d", Status: "closed"},
+	}, start))
+	m.SnapshotFailed("town")
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	text := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE gt_events_total counter\n",
+		`gt_events_total{type="done"} 2` + "\n",
+		`gt_events_total{type="sling"} 1` + "\n",
+		`gt_beads_open{store="gastown",status="in_progress"} 1` + "\n",
+		`gt_beads_open{store="gastown",status="open"} 2` + "\n",
+		`gt_beads_blocked{store="gastown"} 1` + "\n",
+		`gt_ready_queue_depth{store="gastown"} 1` + "\n",
+		`gt_beads_snapshot_failures_total{store="town"} 1` + "\n",
+		`gt_sling_latency_seconds_bucket{le="900"} 0` + "\n",
+		`gt_sling_latency_seconds_bucket{le="1800"} 1` + "\n",
+		`gt_sling_latency_seconds_bucket{le="+Inf"} 1` + "\n",
+		"gt_sling_latency_seconds_sum 1200\n",
+		"gt_sling_latency_seconds_count 1\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestCollector(t *testing.T) {
+	townRoot := t.TempDir()
+	path := filepath.Join(townRoot, ".events.jsonl")
+	now := time.Now()
+	appendEvent := func(e events.Event) {
+		data, _ := json.Marshal(e)
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		_, _ = f.Write(append(data, '\n'))
+	}
+	appendEvent(event(now, events.TypeSling, events.SlingPayload("gt-1", "nux")))
+
+	c := NewCollector(townRoot, map[string]SnapshotFunc{
+		"town": func() (*beads.Snapshot, error) {
+			return beads.NewSnapshot([]*beads.Issue{{ID: "hq-1", Status: "open"}}, now), nil
+		},
+		"broken": func() (*beads.Snapshot, error) { return nil, errors.New("bd exploded") },
+	})
+	c.Interval = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() { c.Run(ctx); close(done) }()
+	defer func() { cancel(); <-done }()
+
+	waitFor := func(want ...string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			var b strings.Builder
+			_ = c.Metrics.WriteText(&b)
+			text := b.String()
+			missing := false
+			for _, w := range want {
+				missing = missing || !strings.Contains(text, w)
+			}
+			if !missing {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("collector did not catch up:\n%s", text)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	// History and the first snapshot, then a live event
+	waitFor(`gt_events_total{type="sling"} 1`, `gt_ready_queue_depth{store="town"} 1`,
+		`gt_beads_snapshot_failures_total{store="broken"} 1`)
+	appendEvent(event(now, events.TypeDone, events.DonePayload("gt-1", "b")))
+	waitFor(`gt_events_total{type="done"} 1`, "gt_sling_latency_seconds_count 1")
+}
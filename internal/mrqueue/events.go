@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/flock"
 )
 
 // EventType represents the type of MQ lifecycle event.
@@ -78,13 +80,7 @@ func (l *EventLogger) LogEvent(event Event) error {
 	}
 
 	// Append to log file
-	f, err := os.OpenFile(l.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return fmt.Errorf("opening event log: %w", err)
-	}
-	defer f.Close()
-
-	if _, err := f.Write(append(data, '\n')); err != nil {
+	if err := flock.AppendFile(l.logPath, append(data, '\n'), 0600); err != nil {
 		return fmt.Errorf("writing event: %w", err)
 	}
 
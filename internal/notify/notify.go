@@ -0,0 +1,252 @@
+// Package notify posts feed-visible events to Slack and Discord channels.
+//
+// The town's notify settings name the channels (incoming webhooks) and the
+// routes that pick channels for each event, so e.g. merge conflicts go to
+// #refinery and spawns to #ops. Messages are the event's feed line, as
+// rendered by the town's feed templates.
+//
+// The notifier runs inside the gt daemon, or on its own via
+// "gt notifier run".
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/feed"
+)
+
+// discordLimit is the most characters Discord accepts in a message.
+const discordLimit = 2000
+
+// Notifier routes events to chat channels.
+type Notifier struct {
+	// Logf reports failed posts; nil discards them.
+	Logf func(format string, args ...interface{})
+
+	// Beads looks up the beads events refer to. Chat channels are
+	// external, so New scopes it to public beads, and events about a bead
+	// it can't show aren't posted. Nil posts every event.
+	Beads beads.Client
+
+	townRoot  string
+	config    *config.NotifyConfig
+	formatter *feed.Formatter
+	client    *http.Client
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New returns a notifier for the town at townRoot. formatter renders
+// messages; nil uses feed.DefaultFormatter.
+func New(townRoot string, cfg *config.NotifyConfig, formatter *feed.Formatter) *Notifier {
+	if formatter == nil {
+		formatter = feed.DefaultFormatter()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bd := beads.New(townRoot)
+	_ = bd.SetVisibilityScope(beads.VisibilityPublic)
+	return &Notifier{
+		Beads:     bd,
+		townRoot:  townRoot,
+		config:    cfg,
+		formatter: formatter,
+		client:    &http.Client{Timeout: events.SinkTimeout},
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Load returns a notifier configured from the town's settings, or nil if
+// the town routes no events.
+func Load(townRoot string) (*Notifier, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	if settings.Notify == nil || len(settings.Notify.Routes) == 0 {
+		return nil, nil
+	}
+	formatter, _ := feed.LoadFormatter(townRoot) // Falls back to the defaults
+	return New(townRoot, settings.Notify, formatter), nil
+}
+
+// Start follows the town's events logs, posting new feed-visible events
+// until Stop.
+func (n *Notifier) Start() {
+	evts := events.TailTown(n.ctx, n.townRoot)
+	filter := events.Filter{FeedOnly: true}
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		for {
+			select {
+			case <-n.ctx.Done():
+				return
+			case e, ok := <-evts:
+				if !ok {
+					return
+				}
+				if !filter.Match(e) {
+					continue
+				}
+				if err := n.Notify(e); err != nil && n.Logf != nil {
+					n.Logf("notify: %s event: %v", e.Type, err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops following events and waits for any post in flight.
+func (n *Notifier) Stop() {
+	n.cancel()
+	n.wg.Wait()
+}
+
+// Channels returns the names of the channels an event routes to, in
+// route order without repeats.
+func (n *Notifier) Channels(e events.Event) []string {
+	var out []string
+	for _, r := range n.config.Routes {
+		if matches(r, e) && !slices.Contains(out, r.Channel) {
+			out = append(out, r.Channel)
+		}
+	}
+	return out
+}
+
+func matches(r config.NotifyRoute, e events.Event) bool {
+	if len(r.Types) > 0 && !slices.Contains(r.Types, e.Type) {
+		return false
+	}
+	if r.Rig != "" && e.Rig() != r.Rig {
+		return false
+	}
+	if r.MinSeverity != "" && events.SeverityRank(e.Level()) < events.SeverityRank(r.MinSeverity) {
+		return false
+	}
+	return true
+}
+
+// Notify posts an event to every channel it routes to. A failed channel
+// doesn't stop the others.
+func (n *Notifier) Notify(e events.Event) error {
+	if public, err := n.public(e); !public {
+		return err
+	}
+	text := n.Message(e)
+	var errs []error
+	for _, name := range n.Channels(e) {
+		if err := n.Post(name, text); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// public reports whether every bead e refers to is public. A bead that
+// can't be looked up counts as hidden, with the error, so a bd failure
+// never leaks one.
+func (n *Notifier) public(e events.Event) (bool, error) {
+	if n.Beads == nil {
+		return true, nil
+	}
+	for _, key := range []string{"bead", "epic"} {
+		id, _ := e.Payload[key].(string)
+		if id == "" {
+			continue
+		}
+		if _, err := n.Beads.Show(id); err != nil {
+			if errors.Is(err, beads.ErrNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("checking visibility of %s: %w", id, err)
+		}
+	}
+	return true, nil
+}
+
+// Message renders an event as a chat message: its feed line, marked for
+// warnings and errors.
+func (n *Notifier) Message(e events.Event) string {
+	line := n.formatter.Format(e)
+	switch e.Level() {
+	case events.SeverityError:
+		return "🚨 " + line
+	case events.SeverityWarn:
+		return "⚠️ " + line
+	}
+	return line
+}
+
+// ChannelNames returns the configured channel names, sorted.
+func (n *Notifier) ChannelNames() []string {
+	names := make([]string, 0, len(n.config.Channels))
+	for name := range n.config.Channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Post sends text to the named channel.
+func (n *Notifier) Post(name, text string) error {
+	ch, ok := n.config.Channels[name]
+	if !ok {
+		return fmt.Errorf("unknown channel %q", name)
+	}
+	body, err := payload(ch.Type, text)
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(ch.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// payload builds the webhook request body for a channel type. Text from
+// event payloads is escaped so it can't mention or ping anyone.
+func payload(typ, text string) ([]byte, error) {
+	switch typ {
+	case config.NotifySlack:
+		return json.Marshal(map[string]string{"text": slackEscape(text)})
+	case config.NotifyDiscord:
+		if r := []rune(text); len(r) > discordLimit {
+			text = string(r[:discordLimit-1]) + "…"
+		}
+		return json.Marshal(map[string]interface{}{
+			"content":          text,
+			"allowed_mentions": map[string][]string{"parse": {}},
+		})
+	}
+	return nil, fmt.Errorf("unknown channel type %q", typ)
+}
+
+// slackEscape escapes the characters Slack treats as markup for links and
+// mentions.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
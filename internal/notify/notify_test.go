@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// webhook records the JSON bodies POSTed to it.
+type webhook struct {
+	*httptest.Server
+	mu     sync.Mutex
+	bodies []map[string]interface{}
+}
+
+func newWebhook(t *testing.T) *webhook {
+	w := &webhook{}
+	w.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.mu.Lock()
+		w.bodies = append(w.bodies, body)
+		w.mu.Unlock()
+	}))
+	t.Cleanup(w.Close)
+	return w
+}
+
+func TestNotify_Routes(t *testing.T) {
+	refinery, ops := newWebhook(t), newWebhook(t)
+	n := New(t.TempDir(), &config.NotifyConfig{
+		Channels: map[string]config.NotifyChannel{
+			"refinery": {Type: config.NotifySlack, URL: refinery.URL},
+			"ops":      {Type: config.NotifyDiscord, URL: ops.URL},
+		},
+		Routes: []config.NotifyRoute{
+			{Channel: "refinery", Types: []string{events.TypeMergeConflict, events.TypeMerged}},
+			{Channel: "ops", Types: []string{events.TypeSpawn}},
+			{Channel: "ops", MinSeverity: events.SeverityError},
+		},
+	}, nil)
+
+	conflict := events.Event{Type: events.TypeMergeConflict, Actor: "gastown/refinery",
+		Payload: map[string]interface{}{"branch": "polecat/<nux>"}}
+	if got := n.Channels(conflict); !slices.Equal(got, []string{"refinery", "ops"}) {
+		t.Errorf("merge_conflict routes to %v", got)
+	}
+	spawn := events.Event{Type: events.TypeSpawn, Actor: "gastown/witness"}
+	if got := n.Channels(spawn); !slices.Equal(got, []string{"ops"}) {
+		t.Errorf("spawn routes to %v", got)
+	}
+	if got := n.Channels(events.Event{Type: events.TypeNudge, Actor: "mayor"}); len(got) != 0 {
+		t.Errorf("nudge routes to %v", got)
+	}
+
+	for _, e := range []events.Event{conflict, spawn} {
+		if err := n.Notify(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(refinery.bodies) != 1 || len(ops.bodies) != 2 {
+		t.Fatalf("posted %d to refinery, %d to ops", len(refinery.bodies), len(ops.bodies))
+	}
+	text, _ := refinery.bodies[0]["text"].(string)
+	if !strings.HasPrefix(text, "🚨 ") || strings.Contains(text, "<") {
+		t.Errorf("slack text = %q", text)
+	}
+	if content, _ := ops.bodies[1]["content"].(string); content != n.Message(spawn) || ops.bodies[1]["allowed_mentions"] == nil {
+		t.Errorf("discord body = %v", ops.bodies[1])
+	}
+}
+
+func TestNotify_FailedChannel(t *testing.T) {
+	good := newWebhook(t)
+	bad := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		http.Error(rw, "gone", http.StatusNotFound)
+	}))
+	defer bad.Close()
+	n := New(t.TempDir(), &config.NotifyConfig{
+		Channels: map[string]config.NotifyChannel{
+			"bad":  {Type: config.NotifySlack, URL: bad.URL},
+			"good": {Type: config.NotifySlack, URL: good.URL},
+		},
+		Routes: []config.NotifyRoute{{Channel: "bad"}, {Channel: "good"}},
+	}, nil)
+
+	err := n.Notify(events.Event{Type: events.TypeSpawn, Actor: "gastown/witness"})
+	if err == nil || !strings.Contains(err.Error(), "bad") {
+		t.Errorf("err = %v", err)
+	}
+	if len(good.bodies) != 1 {
+		t.Errorf("good channel got %d posts", len(good.bodies))
+	}
+}
+
+func TestNotify_SkipsNonPublicBeads(t *testing.T) {
+	ops := newWebhook(t)
+	n := New(t.TempDir(), &config.NotifyConfig{
+		Channels: map[string]config.NotifyChannel{"ops": {Type: config.NotifySlack, URL: ops.URL}},
+		Routes:   []config.NotifyRoute{{Channel: "ops"}},
+	}, nil)
+	bd := beadstest.NewFakeClient()
+	bd.Add(&beads.Issue{ID: "gt-pub", Title: "Fix login"})
+	bd.Add(&beads.Issue{ID: "gt-sec", Title: "Credential leak", Labels: []string{beads.VisibilityLabel(beads.VisibilityPrivate)}})
+	bd.Scope = beads.VisibilityPublic
+	n.Beads = bd
+
+	for _, id := range []string{"gt-pub", "gt-sec"} {
+		if err := n.Notify(events.Event{Type: events.TypeSling, Actor: "mayor", Payload: events.SlingPayload(id, "gastown")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(ops.bodies) != 1 || !strings.Contains(ops.bodies[0]["text"].(string), "gt-pub") {
+		t.Errorf("posted %v, want only the public bead's event", ops.bodies)
+	}
+}
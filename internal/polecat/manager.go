@@ -387,6 +387,32 @@ func (m *Manager) AllocateName() (string, error) {
 	return name, nil
 }
 
+// AllocateNames allocates n distinct names from the pool at once, for
+// spawning several polecats together. Names allocated one at a time
+// before their worktrees exist would collide: each AllocateName
+// reconciles the pool with the polecats on disk, freeing the last one.
+func (m *Manager) AllocateNames(n int) ([]string, error) {
+	m.ReconcilePool()
+
+	names := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		name, err := m.namePool.Allocate()
+		if err != nil {
+			for _, allocated := range names {
+				m.namePool.Release(allocated)
+			}
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err := m.namePool.Save(); err != nil {
+		return nil, fmt.Errorf("saving pool state: %w", err)
+	}
+
+	return names, nil
+}
+
 // ReleaseName releases a name back to the pool.
 // This is called when a polecat is removed.
 func (m *Manager) ReleaseName(name string) {
@@ -583,7 +609,7 @@ func (m *Manager) SetState(name string, state State) error {
 
 	// Find the issue assigned to this polecat
 	assignee := m.assigneeID(name)
-	issue, err := m.beads.GetAssignedIssue(assignee)
+	issue, err := m.beads.SelectAssigned(assignee, beads.DefaultSelection)
 	if err != nil {
 		// If beads is not available, treat as no-op (state can't be changed)
 		return nil
@@ -617,20 +643,17 @@ func (m *Manager) SetState(name string, state State) error {
 	return nil
 }
 
-// AssignIssue assigns an issue to a polecat by setting the issue's assignee in beads.
+// AssignIssue assigns an issue to a polecat by claiming it in beads.
+// Returns an error wrapping beads.ErrAlreadyClaimed if another agent got
+// there first.
 func (m *Manager) AssignIssue(name, issue string) error {
 	if !m.exists(name) {
 		return ErrPolecatNotFound
 	}
 
-	// Set the issue's assignee to this polecat
-	assignee := m.assigneeID(name)
-	status := "in_progress"
-	if err := m.beads.Update(issue, beads.UpdateOptions{
-		Assignee: &assignee,
-		Status:   &status,
-	}); err != nil {
-		return fmt.Errorf("setting issue assignee: %w", err)
+	// Claim the issue for this polecat (assignee + in_progress, atomically)
+	if err := m.beads.Claim(issue, m.assigneeID(name)); err != nil {
+		return fmt.Errorf("claiming issue: %w", err)
 	}
 
 	return nil
@@ -647,7 +670,7 @@ func (m *Manager) ClearIssue(name string) error {
 
 	// Find the issue assigned to this polecat
 	assignee := m.assigneeID(name)
-	issue, err := m.beads.GetAssignedIssue(assignee)
+	issue, err := m.beads.SelectAssigned(assignee, beads.DefaultSelection)
 	if err != nil {
 		// If beads is not available, treat as no-op
 		return nil
@@ -686,7 +709,7 @@ func (m *Manager) loadFromBeads(name string) (*Polecat, error) {
 
 	// Query beads for assigned issue
 	assignee := m.assigneeID(name)
-	issue, beadsErr := m.beads.GetAssignedIssue(assignee)
+	issue, beadsErr := m.beads.SelectAssigned(assignee, beads.DefaultSelection)
 	if beadsErr != nil {
 		// If beads query fails, return basic polecat info as working
 		// (assume polecat is doing something if it exists)
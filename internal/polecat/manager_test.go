@@ -268,3 +268,31 @@ func TestClearIssueWithoutAssignment(t *testing.T) {
 // We no longer write CLAUDE.md to worktrees - Gas Town context is injected
 // ephemerally via SessionStart hook (gt prime) to prevent leaking internal
 // architecture into project repos.
+
+func TestAllocateNamesDistinct(t *testing.T) {
+	root := t.TempDir()
+	// furiosa already exists, so its name stays taken
+	if err := os.MkdirAll(filepath.Join(root, "polecats", "furiosa"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	r := &rig.Rig{
+		Name: "test-rig",
+		Path: root,
+	}
+	m := NewManager(r, git.NewGit(root))
+
+	names, err := m.AllocateNames(3)
+	if err != nil {
+		t.Fatalf("AllocateNames: %v", err)
+	}
+	if len(names) != 3 {
+		t.Fatalf("names = %v, want 3", names)
+	}
+	seen := map[string]bool{"furiosa": true}
+	for _, name := range names {
+		if seen[name] {
+			t.Errorf("name %q allocated twice or while in use: %v", name, names)
+		}
+		seen[name] = true
+	}
+}
@@ -141,8 +141,8 @@ func CheckInboxForSpawns(townRoot string) ([]*PendingSpawn, error) {
 		pending = append(pending, ps)
 		existing[msg.ID] = true
 
-		// Mark message as read (non-fatal: message tracking)
-		_ = mailbox.MarkRead(msg.ID)
+		// Acknowledge the message so it leaves the inbox (non-fatal: message tracking)
+		_ = mailbox.Delete(msg.ID)
 	}
 
 	// Save updated pending list
@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 )
 
 // debugSession logs non-fatal errors during session startup when GT_DEBUG_SESSION=1.
@@ -178,6 +179,18 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	agentID := fmt.Sprintf("%s/%s", m.rig.Name, polecat)
 	debugSession("SetPaneDiedHook", m.tmux.SetPaneDiedHook(sessionID, agentID))
 
+	// Record the session's output, linked to its issue (non-fatal)
+	transcriptPath, err := transcript.Start(m.tmux, townRoot, sessionID)
+	debugSession("transcript.Start", err)
+	if err == nil && opts.Issue != "" {
+		debugSession("transcript.Link", transcript.Link(townRoot, transcript.Entry{
+			Bead:    opts.Issue,
+			Actor:   fmt.Sprintf("%s/polecats/%s", m.rig.Name, polecat),
+			Session: sessionID,
+			Path:    transcriptPath,
+		}))
+	}
+
 	// Send initial command with env vars exported inline
 	command := opts.Command
 	if command == "" {
@@ -0,0 +1,188 @@
+// Package quality derives rework and bounce signals from the events log.
+//
+// Signals tracked per actor and per epic:
+//   - MRs rejected (merge_failed events attributed to the submitting worker)
+//   - Work reopened by the refinery for rework (reopened events)
+//   - MRs rejected with gt mq reject (changes_requested events)
+//   - Bounces (the same bead slung to more than one agent)
+//
+// The goal is to make persistent quality problems visible early, before they
+// show up as a backlog of broken merges.
+package quality
+
+import (
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Signals holds rework counters for a single actor or epic.
+type Signals struct {
+	Done             int `json:"done"`
+	MRsRejected      int `json:"mrs_rejected"`
+	Reopened         int `json:"reopened"`
+	ChangesRequested int `json:"changes_requested"`
+	Bounces          int `json:"bounces"`
+}
+
+// Rework returns the total number of rework events.
+func (s *Signals) Rework() int {
+	return s.MRsRejected + s.Reopened + s.ChangesRequested
+}
+
+// ReworkRate returns rework events per completed unit of work.
+// Returns 0 when nothing has been completed.
+func (s *Signals) ReworkRate() float64 {
+	if s.Done == 0 {
+		return 0
+	}
+	return float64(s.Rework()) / float64(s.Done)
+}
+
+// Report aggregates quality signals across the town.
+type Report struct {
+	Since   time.Time           `json:"since,omitempty"`
+	ByActor map[string]*Signals `json:"by_actor"`
+	ByEpic  map[string]*Signals `json:"by_epic"`
+}
+
+// ActorNames returns actor names sorted by rework count (highest first).
+func (r *Report) ActorNames() []string {
+	return sortedKeys(r.ByActor)
+}
+
+// EpicNames returns epic IDs sorted by rework count (highest first).
+func (r *Report) EpicNames() []string {
+	return sortedKeys(r.ByEpic)
+}
+
+// Analyze computes quality signals from a slice of events.
+// Events before since are ignored (zero since means no lower bound).
+func Analyze(evts []events.Event, since time.Time) *Report {
+	report := &Report{
+		Since:   since,
+		ByActor: make(map[string]*Signals),
+		ByEpic:  make(map[string]*Signals),
+	}
+
+	// bead → set of targets it has been slung to
+	slungTo := make(map[string]map[string]bool)
+
+	for _, e := range evts {
+		if !since.IsZero() {
+			ts, err := time.Parse(time.RFC3339, e.Timestamp)
+			if err == nil && ts.Before(since) {
+				continue
+			}
+		}
+
+		epic := payloadString(e.Payload, "epic")
+
+		switch e.Type {
+		case events.TypeDone:
+			report.actor(e.Actor).Done++
+			if epic != "" {
+				report.epic(epic).Done++
+			}
+		case events.TypeMergeFailed:
+			// Attribute rejected MRs to the worker who submitted them,
+			// not the refinery that reported the failure.
+			report.actor(worker(e)).MRsRejected++
+			if epic != "" {
+				report.epic(epic).MRsRejected++
+			}
+		case events.TypeReopened:
+			report.actor(worker(e)).Reopened++
+			if epic != "" {
+				report.epic(epic).Reopened++
+			}
+		case events.TypeChangesRequested:
+			report.actor(worker(e)).ChangesRequested++
+			if epic != "" {
+				report.epic(epic).ChangesRequested++
+			}
+		case events.TypeSling:
+			bead := payloadString(e.Payload, "bead")
+			target := payloadString(e.Payload, "target")
+			if bead == "" || target == "" {
+				continue
+			}
+			targets := slungTo[bead]
+			if targets == nil {
+				targets = make(map[string]bool)
+				slungTo[bead] = targets
+			}
+			if len(targets) > 0 && !targets[target] {
+				// Bead moved to a new agent: count against the slinger
+				report.actor(e.Actor).Bounces++
+				if epic != "" {
+					report.epic(epic).Bounces++
+				}
+			}
+			targets[target] = true
+		}
+	}
+
+	return report
+}
+
+// worker returns the agent a rework event counts against: the payload's
+// worker, else the event's actor.
+func worker(e events.Event) string {
+	if w := payloadString(e.Payload, "worker"); w != "" {
+		return w
+	}
+	return e.Actor
+}
+
+// Load reads the town events log and computes a report.
+// A missing events file yields an empty report.
+func Load(townRoot string, since time.Time) (*Report, error) {
+	evts, err := events.ReadTown(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	return Analyze(evts, since), nil
+}
+
+func (r *Report) actor(name string) *Signals {
+	s, ok := r.ByActor[name]
+	if !ok {
+		s = &Signals{}
+		r.ByActor[name] = s
+	}
+	return s
+}
+
+func (r *Report) epic(id string) *Signals {
+	s, ok := r.ByEpic[id]
+	if !ok {
+		s = &Signals{}
+		r.ByEpic[id] = s
+	}
+	return s
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	if payload == nil {
+		return ""
+	}
+	v, _ := payload[key].(string)
+	return v
+}
+
+func sortedKeys(m map[string]*Signals) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := m[keys[i]].Rework(), m[keys[j]].Rework()
+		if ri != rj {
+			return ri > rj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,73 @@
+package quality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func ev(ts, typ, actor string, payload map[string]interface{}) events.Event {
+	return events.Event{Timestamp: ts, Type: typ, Actor: actor, Payload: payload}
+}
+
+func TestAnalyze(t *testing.T) {
+	evts := []events.Event{
+		ev("2026-01-01T10:00:00Z", events.TypeSling, "mayor", events.SlingPayload("gt-1", "gastown/Toast")),
+		ev("2026-01-01T10:05:00Z", events.TypeDone, "gastown/Toast", map[string]interface{}{"bead": "gt-1", "epic": "gt-epic"}),
+		ev("2026-01-01T10:10:00Z", events.TypeMergeFailed, "gastown/refinery", events.MergePayload("gt-mr1", "gastown/Toast", "polecat/Toast", "tests failed")),
+		ev("2026-01-01T10:20:00Z", events.TypeReopened, "gastown/Toast", events.ReworkPayload("gt-1", "gt-epic", "", "regression")),
+		ev("2026-01-01T10:25:00Z", events.TypeChangesRequested, "gastown/refinery", events.ReworkPayload("gt-1", "gt-epic", "gastown/Toast", "needs tests")),
+		ev("2026-01-01T10:30:00Z", events.TypeSling, "mayor", events.SlingPayload("gt-1", "gastown/Nux")),
+		ev("2026-01-01T10:31:00Z", events.TypeSling, "mayor", events.SlingPayload("gt-1", "gastown/Nux")),
+	}
+
+	r := Analyze(evts, time.Time{})
+
+	toast := r.ByActor["gastown/Toast"]
+	if toast == nil {
+		t.Fatal("expected signals for gastown/Toast")
+	}
+	if toast.Done != 1 || toast.MRsRejected != 1 || toast.Reopened != 1 || toast.ChangesRequested != 1 {
+		t.Errorf("Toast signals = %+v", toast)
+	}
+	if got := toast.ReworkRate(); got != 3 {
+		t.Errorf("ReworkRate() = %v, want 3", got)
+	}
+
+	if r.ByActor["mayor"].Bounces != 1 {
+		t.Errorf("mayor bounces = %d, want 1", r.ByActor["mayor"].Bounces)
+	}
+
+	epic := r.ByEpic["gt-epic"]
+	if epic == nil || epic.Done != 1 || epic.Reopened != 1 {
+		t.Errorf("epic signals = %+v", epic)
+	}
+
+	if names := r.ActorNames(); names[0] != "gastown/Toast" {
+		t.Errorf("ActorNames()[0] = %q, want gastown/Toast", names[0])
+	}
+}
+
+func TestAnalyzeSince(t *testing.T) {
+	evts := []events.Event{
+		ev("2026-01-01T10:00:00Z", events.TypeReopened, "a", nil),
+		ev("2026-01-03T10:00:00Z", events.TypeReopened, "a", nil),
+	}
+	since, _ := time.Parse(time.RFC3339, "2026-01-02T00:00:00Z")
+
+	r := Analyze(evts, since)
+	if got := r.ByActor["a"].Reopened; got != 1 {
+		t.Errorf("Reopened = %d, want 1", got)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	r, err := Load(t.TempDir(), time.Time{})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(r.ByActor) != 0 {
+		t.Errorf("expected empty report, got %d actors", len(r.ByActor))
+	}
+}
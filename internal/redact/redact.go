@@ -0,0 +1,258 @@
+// Package redact scrubs secrets from text before Gas Town stores it.
+//
+// Agents occasionally paste credentials into mail and bead descriptions,
+// and from there into the events log and its remote sinks. A Redactor
+// replaces anything matching its rules with "[REDACTED:<rule>]". Built-in
+// rules catch AWS keys, common API tokens, and private keys; towns add
+// patterns and keywords, or turn redaction off, in settings (redaction).
+// Every redaction is counted per town (see Record).
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/flock"
+)
+
+// Sources of redacted text, for Record.
+const (
+	SourceEvent      = "event"
+	SourceBead       = "bead"
+	SourceMail       = "mail"
+	SourceTranscript = "transcript"
+)
+
+// marker prefixes every replacement, so redacted text is left alone when
+// it is redacted again.
+const marker = "[REDACTED:"
+
+// rule redacts matches of re. If prefixed, re's first group is kept and
+// only the rest of the match is replaced ("password=" stays).
+type rule struct {
+	name     string
+	re       *regexp.Regexp
+	prefixed bool
+}
+
+// builtinKeywords are names whose assigned values are always redacted.
+var builtinKeywords = []string{
+	"password", "passwd", "secret", "client_secret", "api_key", "apikey",
+	"access_token", "auth_token", "aws_secret_access_key",
+}
+
+var builtinRules = []rule{
+	{name: "private_key", re: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{name: "aws_access_key", re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{name: "github_token", re: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{name: "slack_token", re: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{name: "api_key", re: regexp.MustCompile(`\bsk-(?:ant-)?[A-Za-z0-9_-]{20,}`)},
+	{name: "bearer_token", re: regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9._~+/-]{16,}=*`), prefixed: true},
+}
+
+// keywordRule redacts values assigned to any of keywords, as in
+// "password=x", "Password: x", or JSON "password": "x".
+func keywordRule(keywords []string) rule {
+	quoted := make([]string, len(keywords))
+	for i, k := range keywords {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	re := regexp.MustCompile(`(?i)(\b(?:` + strings.Join(quoted, "|") + `)["']?\s*[:=]\s*["']?)[^\s"',;]+`)
+	return rule{name: "keyword", re: re, prefixed: true}
+}
+
+// Redactor replaces secrets in text. A nil Redactor redacts nothing.
+type Redactor struct {
+	rules []rule
+}
+
+// New builds a redactor from the built-in rules plus the town's extra
+// patterns and keywords. A disabled config returns nil.
+func New(c *config.RedactionConfig) (*Redactor, error) {
+	if c != nil && c.Disabled {
+		return nil, nil
+	}
+	r := &Redactor{rules: append([]rule(nil), builtinRules...)}
+	keywords := builtinKeywords
+	if c != nil {
+		for i, p := range c.Patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+			}
+			r.rules = append(r.rules, rule{name: fmt.Sprintf("pattern%d", i+1), re: re})
+		}
+		keywords = append(append([]string(nil), keywords...), c.Keywords...)
+	}
+	r.rules = append(r.rules, keywordRule(keywords))
+	return r, nil
+}
+
+// Default returns a redactor with only the built-in rules.
+func Default() *Redactor {
+	r, _ := New(nil)
+	return r
+}
+
+// towns caches each town's redactor.
+var towns sync.Map // townRoot → *Redactor
+
+// ForTown returns the redactor for a town's settings, cached for the life
+// of the process. Outside a town, or if the settings can't be loaded, it
+// returns Default: redaction fails closed. Patterns that don't compile are
+// skipped.
+func ForTown(townRoot string) *Redactor {
+	if townRoot == "" {
+		return Default()
+	}
+	if r, ok := towns.Load(townRoot); ok {
+		return r.(*Redactor)
+	}
+	r := Default()
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+		if tr, err := New(compilable(settings.Redaction)); err == nil {
+			r = tr
+		}
+	}
+	towns.Store(townRoot, r)
+	return r
+}
+
+// compilable returns c without the patterns that don't compile, so one bad
+// pattern doesn't cost the town its other patterns and keywords.
+func compilable(c *config.RedactionConfig) *config.RedactionConfig {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.Patterns = nil
+	for _, p := range c.Patterns {
+		if _, err := regexp.Compile(p); err == nil {
+			out.Patterns = append(out.Patterns, p)
+		}
+	}
+	return &out
+}
+
+// String returns s with secrets replaced, and how many were replaced.
+func (r *Redactor) String(s string) (string, int) {
+	if r == nil || s == "" {
+		return s, 0
+	}
+	total := 0
+	for _, ru := range r.rules {
+		n := 0
+		s = ru.re.ReplaceAllStringFunc(s, func(m string) string {
+			keep := ""
+			if ru.prefixed {
+				keep = ru.re.FindStringSubmatch(m)[1]
+			}
+			if strings.HasPrefix(m[len(keep):], marker) {
+				return m
+			}
+			n++
+			return keep + marker + ru.name + "]"
+		})
+		total += n
+	}
+	return s, total
+}
+
+// Payload returns an event payload with secrets replaced in every string,
+// nested ones included, and how many were replaced. The payload is copied
+// only if something was redacted.
+func (r *Redactor) Payload(p map[string]interface{}) (map[string]interface{}, int) {
+	if r == nil || len(p) == 0 {
+		return p, 0
+	}
+	v, n := r.value(p)
+	if n == 0 {
+		return p, 0
+	}
+	return v.(map[string]interface{}), n
+}
+
+func (r *Redactor) value(v interface{}) (interface{}, int) {
+	switch x := v.(type) {
+	case string:
+		return r.String(x)
+	case []string:
+		out, total := make([]string, len(x)), 0
+		for i, s := range x {
+			var n int
+			out[i], n = r.String(s)
+			total += n
+		}
+		return out, total
+	case []interface{}:
+		out, total := make([]interface{}, len(x)), 0
+		for i, item := range x {
+			var n int
+			out[i], n = r.value(item)
+			total += n
+		}
+		return out, total
+	case map[string]interface{}:
+		out, total := make(map[string]interface{}, len(x)), 0
+		for k, item := range x {
+			var n int
+			out[k], n = r.value(item)
+			total += n
+		}
+		return out, total
+	}
+	return v, 0
+}
+
+// CountsPath returns where a town's redaction counts are kept.
+func CountsPath(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "redactions.json")
+}
+
+// Counts are a town's redactions so far, by source (SourceEvent, ...).
+type Counts map[string]int
+
+// Record adds n redactions from source to the town's counts.
+func Record(townRoot, source string, n int) error {
+	if townRoot == "" || n <= 0 {
+		return nil
+	}
+	path := CountsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return flock.With(path, flock.DefaultTimeout, func() error {
+		counts, err := LoadCounts(townRoot)
+		if err != nil {
+			return err
+		}
+		counts[source] += n
+		data, err := json.MarshalIndent(counts, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, append(data, '\n'), 0644)
+	})
+}
+
+// LoadCounts returns a town's redaction counts; empty if none were made.
+func LoadCounts(townRoot string) (Counts, error) {
+	counts := make(Counts)
+	data, err := os.ReadFile(CountsPath(townRoot))
+	if os.IsNotExist(err) {
+		return counts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", CountsPath(townRoot), err)
+	}
+	return counts, nil
+}
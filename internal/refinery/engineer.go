@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/mrqueue"
@@ -208,6 +209,7 @@ type ProcessResult struct {
 	MergeCommit string
 	Error       string
 	Conflict    bool
+	Conflicts   []string // Conflicting files, when the conflict check found them
 	TestsFailed bool
 }
 
@@ -277,9 +279,10 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 	if len(conflicts) > 0 {
 		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+			Success:   false,
+			Conflict:  true,
+			Conflicts: conflicts,
+			Error:     fmt.Sprintf("merge conflicts in: %v", conflicts),
 		}
 	}
 
@@ -405,16 +408,8 @@ func (e *Engineer) handleSuccess(mr *beads.Issue, result ProcessResult) {
 		mrFields = &beads.MRFields{}
 	}
 
-	// 1. Update MR with merge_commit SHA
-	mrFields.MergeCommit = result.MergeCommit
-	mrFields.CloseReason = "merged"
-	newDesc := beads.SetMRFields(mr, mrFields)
-	if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with merge commit: %v\n", mr.ID, err)
-	}
-
-	// 2. Close MR with reason 'merged'
-	if err := e.beads.CloseWithReason("merged", mr.ID); err != nil {
+	// 1-2. Record merge_commit SHA and close MR with reason 'merged'
+	if err := e.beads.CloseMR(mr.ID, result.MergeCommit, "merged"); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close MR %s: %v\n", mr.ID, err)
 	}
 
@@ -455,6 +450,12 @@ func (e *Engineer) handleFailure(mr *beads.Issue, result ProcessResult) {
 	open := "open"
 	if err := e.beads.Update(mr.ID, beads.UpdateOptions{Status: &open}); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to reopen MR %s: %v\n", mr.ID, err)
+	} else {
+		mrFields := beads.ParseMRFields(mr)
+		if mrFields == nil {
+			mrFields = &beads.MRFields{}
+		}
+		logRework(e.beads, events.TypeReopened, e.rig.Name, mrFields.SourceIssue, mr.ID, mrFields.Worker, result.Error)
 	}
 
 	// Log the failure
@@ -500,28 +501,9 @@ func (e *Engineer) handleSuccessFromQueue(mr *mrqueue.MR, result ProcessResult)
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Released merge slot\n")
 	}
 
-	// Update and close the MR bead (matches handleSuccess behavior)
+	// Record merge_commit SHA and close the MR bead (matches handleSuccess behavior)
 	if mr.ID != "" {
-		// Fetch the MR bead to update its fields
-		mrBead, err := e.beads.Show(mr.ID)
-		if err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to fetch MR bead %s: %v\n", mr.ID, err)
-		} else {
-			// Update MR with merge_commit SHA and close_reason
-			mrFields := beads.ParseMRFields(mrBead)
-			if mrFields == nil {
-				mrFields = &beads.MRFields{}
-			}
-			mrFields.MergeCommit = result.MergeCommit
-			mrFields.CloseReason = "merged"
-			newDesc := beads.SetMRFields(mrBead, mrFields)
-			if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
-				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with merge commit: %v\n", mr.ID, err)
-			}
-		}
-
-		// Close MR bead with reason 'merged'
-		if err := e.beads.CloseWithReason("merged", mr.ID); err != nil {
+		if err := e.beads.CloseMR(mr.ID, result.MergeCommit, "merged"); err != nil {
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close MR %s: %v\n", mr.ID, err)
 		} else {
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Closed MR bead: %s\n", mr.ID)
@@ -577,6 +559,8 @@ func (e *Engineer) handleFailureFromQueue(mr *mrqueue.MR, result ProcessResult)
 	failureType := "build"
 	if result.Conflict {
 		failureType = "conflict"
+		_ = events.LogError(events.TypeMergeConflict, e.rig.Name+"/refinery", errors.New(result.Error),
+			events.MergeConflictPayload(mr.ID, mr.Worker, mr.Branch, result.Conflicts))
 	} else if result.TestsFailed {
 		failureType = "tests"
 	}
@@ -671,8 +655,8 @@ func (e *Engineer) createConflictResolutionTask(mr *mrqueue.MR, _ ProcessResult)
 	// Priority boost: decrease priority number (lower = higher priority)
 	// P2 -> P1, P1 -> P0, P0 stays P0
 	boostedPriority := mr.Priority - 1
-	if boostedPriority < 0 {
-		boostedPriority = 0
+	if boostedPriority < beads.PriorityCritical {
+		boostedPriority = beads.PriorityCritical
 	}
 
 	// Increment retry count for tracking
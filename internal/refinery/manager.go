@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/ghpr"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/mrqueue"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -286,11 +287,7 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 	// Query beads for open merge-request type issues
 	// BeadsPath() returns the git-synced beads location
 	b := beads.New(m.rig.BeadsPath())
-	issues, err := b.List(beads.ListOptions{
-		Type:     "merge-request",
-		Status:   "open",
-		Priority: -1, // No priority filter
-	})
+	issues, err := b.ListOpenMRs("")
 	if err != nil {
 		return nil, fmt.Errorf("querying merge queue from beads: %w", err)
 	}
@@ -320,8 +317,17 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 		issue *beads.Issue
 		score float64
 	}
+	// In a rig whose merge queue runs through GitHub pull requests, every
+	// MR merges there (see ghpr), including ones not yet published
+	if ghpr.Enabled(m.rig.Path) {
+		issues = nil
+	}
 	scored := make([]scoredIssue, 0, len(issues))
 	for _, issue := range issues {
+		// MRs with a pull request merge on GitHub, not here
+		if fields := beads.ParseMRFields(issue); fields != nil && fields.PRURL != "" {
+			continue
+		}
 		score := m.calculateIssueScore(issue, now)
 		scored = append(scored, scoredIssue{issue: issue, score: score})
 	}
@@ -356,7 +362,7 @@ func (m *Manager) calculateIssueScore(issue *beads.Issue, now time.Time) float64
 	fields := beads.ParseMRFields(issue)
 
 	// Parse MR creation time
-	mrCreatedAt := parseTime(issue.CreatedAt)
+	mrCreatedAt := issue.CreatedAt
 	if mrCreatedAt.IsZero() {
 		mrCreatedAt = now // Fallback
 	}
@@ -399,7 +405,7 @@ func (m *Manager) issueToMR(issue *beads.Issue) *MergeRequest {
 			ID:           issue.ID,
 			IssueID:      issue.ID,
 			Status:       MROpen,
-			CreatedAt:    parseTime(issue.CreatedAt),
+			CreatedAt:    issue.CreatedAt,
 			TargetBranch: defaultBranch,
 		}
 	}
@@ -417,7 +423,7 @@ func (m *Manager) issueToMR(issue *beads.Issue) *MergeRequest {
 		IssueID:      fields.SourceIssue,
 		TargetBranch: target,
 		Status:       MROpen,
-		CreatedAt:    parseTime(issue.CreatedAt),
+		CreatedAt:    issue.CreatedAt,
 	}
 }
 
@@ -498,13 +504,15 @@ func (m *Manager) completeMR(mr *MergeRequest, closeReason CloseReason, errMsg s
 			ref.LastMergeAt = &now
 		case CloseReasonSuperseded:
 			// Emit merge_skipped event
-			_ = events.LogFeed(events.TypeMergeSkipped, actor, events.MergePayload(mr.ID, mr.Worker, mr.Branch, "superseded"))
+			_ = events.Log(events.TypeMergeSkipped, actor, events.MergePayload(mr.ID, mr.Worker, mr.Branch, "superseded"), events.VisibilityDefault)
 		}
 	} else {
 		// Reopen the MR for rework (in_progress → open)
 		if err := mr.Reopen(); err != nil {
 			// Log error but continue
 			_, _ = fmt.Fprintf(m.output, "Warning: failed to reopen MR: %v\n", err)
+		} else {
+			logRework(beads.New(m.rig.BeadsPath()), events.TypeReopened, m.rig.Name, mr.IssueID, mr.ID, mr.Worker, errMsg)
 		}
 	}
 
@@ -754,6 +762,9 @@ func (m *Manager) RejectMR(idOrBranch string, reason string, notify bool) (*Merg
 		return nil, fmt.Errorf("failed to close MR: %w", err)
 	}
 	mr.Error = reason
+	bd := beads.New(m.rig.BeadsPath())
+	logRejected(bd, m.rig.Name, mr, reason)
+	logRework(bd, events.TypeChangesRequested, m.rig.Name, mr.IssueID, mr.ID, mr.Worker, reason)
 
 	// Optionally notify worker
 	if notify {
@@ -763,6 +774,50 @@ func (m *Manager) RejectMR(idOrBranch string, reason string, notify bool) (*Merg
 	return mr, nil
 }
 
+// logRework records a reopened or changes_requested event for gt quality,
+// counted against the polecat whose work was sent back and rolled up under
+// the work item's epic. bead is the work item, falling back to the MR when
+// it isn't known.
+func logRework(bd beads.Client, eventType, rigName, bead, mrID, worker, reason string) {
+	epic := workEpic(bd, bead)
+	if bead == "" {
+		bead = mrID
+	}
+	_ = events.Log(eventType, rigName+"/refinery", events.ReworkPayload(bead, epic, rigWorker(rigName, worker), reason), events.VisibilityDefault)
+}
+
+// logRejected records a merge_failed event for an MR the refinery rejected,
+// which gt quality counts against the submitting polecat.
+func logRejected(bd beads.Client, rigName string, mr *MergeRequest, reason string) {
+	payload := events.Encode(events.MergeEvent{
+		MR:     mr.ID,
+		Worker: rigWorker(rigName, mr.Worker),
+		Branch: mr.Branch,
+		Reason: reason,
+		Epic:   workEpic(bd, mr.IssueID),
+	})
+	_ = events.Log(events.TypeMergeFailed, rigName+"/refinery", payload, events.VisibilityDefault)
+}
+
+// rigWorker qualifies a polecat name with its rig, as gt quality keys
+// actors.
+func rigWorker(rigName, worker string) string {
+	if worker == "" {
+		return ""
+	}
+	return rigName + "/" + worker
+}
+
+// workEpic returns the epic bead belongs to, or "" if it has none or the
+// lookup fails; the event is worth logging either way.
+func workEpic(bd beads.Client, bead string) string {
+	if bead == "" {
+		return ""
+	}
+	epic, _ := beads.ParentEpicWith(bd, bead)
+	return epic
+}
+
 // notifyWorkerRejected sends a rejection notification to a polecat.
 func (m *Manager) notifyWorkerRejected(mr *MergeRequest, reason string) {
 	router := mail.NewRouter(m.workDir)
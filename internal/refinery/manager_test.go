@@ -7,6 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/quality"
 	"github.com/steveyegge/gastown/internal/rig"
 )
 
@@ -170,3 +174,28 @@ func TestManager_RegisterMR(t *testing.T) {
 		t.Errorf("saved MR worker = %s, want Cheedo", saved.Worker)
 	}
 }
+
+func TestLogRejectionsForQuality(t *testing.T) {
+	t.Chdir(t.TempDir()) // Outside any town, so nothing is written to disk
+	f := beadstest.NewFakeClient()
+	f.Add(&beads.Issue{ID: "gt-epic", Type: "epic"})
+	f.Add(&beads.Issue{ID: "gt-1", Type: "task", Parent: "gt-epic"})
+
+	var got []events.Event
+	t.Cleanup(events.Default().SubscribeSync("refinery-test", func(e events.Event) error {
+		got = append(got, e)
+		return nil
+	}))
+
+	mr := &MergeRequest{ID: "gt-mr1", Worker: "Toast", Branch: "polecat/Toast", IssueID: "gt-1"}
+	logRejected(f, "gastown", mr, "tests fail")
+	logRework(f, events.TypeChangesRequested, "gastown", mr.IssueID, mr.ID, mr.Worker, "tests fail")
+
+	r := quality.Analyze(got, time.Time{})
+	if s := r.ByActor["gastown/Toast"]; s == nil || s.MRsRejected != 1 || s.ChangesRequested != 1 {
+		t.Errorf("Toast signals = %+v", s)
+	}
+	if s := r.ByEpic["gt-epic"]; s == nil || s.MRsRejected != 1 || s.ChangesRequested != 1 {
+		t.Errorf("epic signals = %+v", s)
+	}
+}
@@ -923,7 +923,7 @@ func (m *Manager) seedPatrolMoleculesManually(rigPath string) error {
 			"--type=molecule",
 			"--title="+mol.title,
 			"--description="+mol.desc,
-			"--priority=2",
+			fmt.Sprintf("--priority=%d", beads.PriorityMedium),
 		)
 		cmd.Dir = rigPath
 		if err := cmd.Run(); err != nil {
@@ -0,0 +1,121 @@
+// Package sandbox scopes agent file writes to their assigned worktree.
+//
+// Cross-worktree writes (a polecat editing another polecat's checkout, or the
+// mayor's rig clone) corrupt other agents' branches. The sandbox is enforced
+// from a Claude Code PreToolUse hook: before a file-writing tool runs, the
+// hook resolves the target path and checks it against the agent's worktree.
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Violation describes a write outside the allowed worktree.
+type Violation struct {
+	Tool     string `json:"tool"`
+	Path     string `json:"path"`
+	Worktree string `json:"worktree"`
+}
+
+// Error implements error.
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s write to %s is outside worktree %s", v.Tool, v.Path, v.Worktree)
+}
+
+// HookInput is the subset of the Claude Code PreToolUse hook payload we need.
+type HookInput struct {
+	ToolName  string                 `json:"tool_name"`
+	ToolInput map[string]interface{} `json:"tool_input"`
+	Cwd       string                 `json:"cwd,omitempty"`
+}
+
+// writeTools lists tools that modify files, mapped to the input key holding
+// the target path.
+var writeTools = map[string]string{
+	"Write":        "file_path",
+	"Edit":         "file_path",
+	"MultiEdit":    "file_path",
+	"NotebookEdit": "notebook_path",
+}
+
+// ParseHookInput decodes a PreToolUse hook payload.
+func ParseHookInput(r io.Reader) (*HookInput, error) {
+	var in HookInput
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("parsing hook input: %w", err)
+	}
+	return &in, nil
+}
+
+// TargetPath returns the file the tool intends to write, or "" if the tool
+// does not write files.
+func (in *HookInput) TargetPath() string {
+	key, ok := writeTools[in.ToolName]
+	if !ok {
+		return ""
+	}
+	path, _ := in.ToolInput[key].(string)
+	return path
+}
+
+// Check verifies that target lies within worktree or one of the allowed paths.
+// Relative targets are resolved against cwd. Returns a *Violation if not.
+func Check(tool, target, cwd, worktree string, allow []string) error {
+	if target == "" || worktree == "" {
+		return nil
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(cwd, target)
+	}
+	resolved := resolve(target)
+
+	roots := append([]string{worktree}, allow...)
+	for _, root := range roots {
+		if root == "" {
+			continue
+		}
+		if within(resolved, resolve(root)) {
+			return nil
+		}
+	}
+
+	return &Violation{Tool: tool, Path: resolved, Worktree: worktree}
+}
+
+// within reports whether path is root or a descendant of root.
+func within(path, root string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// resolve cleans a path and follows symlinks on its longest existing prefix,
+// so a symlink inside the worktree cannot be used to escape it.
+func resolve(path string) string {
+	path = filepath.Clean(path)
+	existing := path
+	var rest []string
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return path
+		}
+		rest = append([]string{filepath.Base(existing)}, rest...)
+		existing = parent
+	}
+	real, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return path
+	}
+	return filepath.Join(append([]string{real}, rest...)...)
+}
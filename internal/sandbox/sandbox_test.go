@@ -0,0 +1,80 @@
+package sandbox
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	town := t.TempDir()
+	toast := filepath.Join(town, "gastown", "polecats", "Toast")
+	nux := filepath.Join(town, "gastown", "polecats", "Nux")
+	scratch := filepath.Join(town, "scratch")
+	for _, d := range []string{toast, nux, scratch} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		ok     bool
+	}{
+		{"inside worktree", filepath.Join(toast, "main.go"), true},
+		{"new nested file", filepath.Join(toast, "a", "b", "c.go"), true},
+		{"relative inside", "pkg/x.go", true},
+		{"other polecat", filepath.Join(nux, "main.go"), false},
+		{"dotdot escape", filepath.Join(toast, "..", "Nux", "main.go"), false},
+		{"sibling prefix", toast + "-evil/x.go", false},
+		{"allowed scratch", filepath.Join(scratch, "log.txt"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check("Write", tt.target, toast, toast, []string{scratch})
+			if tt.ok && err != nil {
+				t.Errorf("unexpected violation: %v", err)
+			}
+			if !tt.ok {
+				var v *Violation
+				if !errors.As(err, &v) {
+					t.Errorf("expected violation, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckSymlinkEscape(t *testing.T) {
+	town := t.TempDir()
+	toast := filepath.Join(town, "Toast")
+	nux := filepath.Join(town, "Nux")
+	_ = os.MkdirAll(toast, 0755)
+	_ = os.MkdirAll(nux, 0755)
+	if err := os.Symlink(nux, filepath.Join(toast, "link")); err != nil {
+		t.Skip("symlinks unsupported")
+	}
+
+	if err := Check("Edit", filepath.Join(toast, "link", "main.go"), toast, toast, nil); err == nil {
+		t.Error("expected symlink escape to be a violation")
+	}
+}
+
+func TestHookInputTargetPath(t *testing.T) {
+	in, err := ParseHookInput(strings.NewReader(`{"tool_name":"NotebookEdit","tool_input":{"notebook_path":"/x/y.ipynb"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := in.TargetPath(); got != "/x/y.ipynb" {
+		t.Errorf("TargetPath() = %q", got)
+	}
+
+	in = &HookInput{ToolName: "Read", ToolInput: map[string]interface{}{"file_path": "/etc/passwd"}}
+	if got := in.TargetPath(); got != "" {
+		t.Errorf("Read should not be a write tool, got %q", got)
+	}
+}
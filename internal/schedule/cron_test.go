@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "0 9 * * MON", "*/15 8-18 * * 1-5", "0 0 1,15 * *", "30 4 * jan-mar sun", "@weekly", "5/10 * * * 7"} {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("Parse(%q): %v", expr, err)
+		}
+	}
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "@fortnightly", "0 9 * * funday"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q): expected an error", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Thursday
+	from := time.Date(2026, 10, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 15, 10, 31, 0, 0, time.UTC)},
+		{"0 9 * * mon", time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		{"*/20 * * * *", time.Date(2026, 10, 15, 10, 40, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches (the 20th, or a Saturday)
+		{"0 0 20 * sat", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		c, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		if got := c.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	never, _ := Parse("0 0 31 2 *")
+	if got := never.Next(from); !got.IsZero() {
+		t.Errorf("Feb 31 is due at %v", got)
+	}
+}
+
+func TestNextInZone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	// 12:00 UTC is 08:00 in New York
+	c, _ := Parse("0 9 * * *")
+	got := c.Next(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC).In(ny))
+	if want := time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("9am New York = %v, want %v", got.UTC(), want)
+	}
+
+	// Spring forward: 02:30 doesn't exist on 2026-03-08, so the run moves
+	// to the next day rather than looping
+	c, _ = Parse("30 2 * * *")
+	got = c.Next(time.Date(2026, 3, 8, 0, 0, 0, 0, ny))
+	if got.Day() != 9 || got.Hour() != 2 || got.Minute() != 30 {
+		t.Errorf("over DST = %v", got)
+	}
+}
+
+func TestMissed(t *testing.T) {
+	c, _ := Parse("0 9 * * *")
+	after := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)
+
+	latest, earlier, ok := c.Missed(after, time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC))
+	if !ok || earlier != 2 || !latest.Equal(time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Missed = %v, %d, %v", latest, earlier, ok)
+	}
+	if _, _, ok := c.Missed(after, time.Date(2026, 10, 13, 8, 59, 0, 0, time.UTC)); ok {
+		t.Error("run reported due before its time")
+	}
+}
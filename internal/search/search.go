@@ -0,0 +1,469 @@
+// Package search maintains a town-wide full-text index over beads, mail,
+// events, and agent transcripts.
+//
+// The index is a small inverted index persisted as JSON in the town's cache
+// directory along with its postings, so loading it tokenizes nothing. Update
+// scans the source files and only re-reads what changed: append-only logs
+// (events, transcripts) are read from the last offset, rewritten files
+// (issues.jsonl) are reparsed but only changed documents are retokenized,
+// and the index is written back only when something changed. Hits are
+// ranked with BM25.
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/flock"
+)
+
+// Document sources.
+const (
+	SourceBead       = "bead"
+	SourceMail       = "mail"
+	SourceEvent      = "event"
+	SourceTranscript = "transcript"
+)
+
+// IndexFileName is the index file within the town cache directory.
+const IndexFileName = "search-index.json"
+
+// maxDocText caps the text kept (and indexed) per document so long
+// transcript turns don't bloat the index.
+const maxDocText = 4000
+
+// lockTimeout bounds how long Update waits for another indexer.
+const lockTimeout = 30 * time.Second
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Doc is one searchable item.
+type Doc struct {
+	ID     string    `json:"id"`              // Unique key, e.g. "bead:gt-abc"
+	Source string    `json:"source"`          // SourceBead, SourceMail, ...
+	Ref    string    `json:"ref"`             // What to show the user: bead ID, event type, file:line
+	Title  string    `json:"title,omitempty"` // Short heading, if the source has one
+	Text   string    `json:"text"`            // Indexed body (see maxDocText)
+	Time   time.Time `json:"time,omitempty"`  // When the item was created, if known
+	File   string    `json:"file"`            // Source file the doc was read from
+
+	// Visibility is a bead's visibility scope when it isn't public.
+	Visibility string `json:"visibility,omitempty"`
+
+	terms map[string]int // term frequencies
+	size  int            // total terms
+}
+
+// FileState records how much of a source file has been indexed.
+type FileState struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Offset  int64     `json:"offset"`
+}
+
+// Index is the in-memory search index.
+type Index struct {
+	Docs     map[string]*Doc           `json:"docs"`
+	Files    map[string]FileState      `json:"files"`
+	Postings map[string]map[string]int `json:"postings"` // term -> doc ID -> frequency
+
+	total int  // sum of doc sizes
+	dirty bool // changed since loaded
+}
+
+// Hit is a ranked search result.
+type Hit struct {
+	Doc     *Doc    `json:"doc"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet"`
+}
+
+// Options filters a search.
+type Options struct {
+	Sources []string // Restrict to these sources (empty = all)
+	Scope   string   // Only beads visible at this scope (empty = all)
+	Limit   int      // Max hits (0 = 20)
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{
+		Docs:     make(map[string]*Doc),
+		Files:    make(map[string]FileState),
+		Postings: make(map[string]map[string]int),
+	}
+}
+
+// IndexPath returns the index location for a town.
+func IndexPath(townRoot string) string {
+	return filepath.Join(config.LoadPaths(townRoot).CacheDir, IndexFileName)
+}
+
+// Load reads an index from path, returning an empty index if it is missing
+// or unreadable (the index is a disposable cache).
+func Load(path string) *Index {
+	idx := NewIndex()
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return idx
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return NewIndex()
+	}
+	if idx.Docs == nil {
+		idx.Docs = make(map[string]*Doc)
+	}
+	if idx.Files == nil {
+		idx.Files = make(map[string]FileState)
+	}
+	if idx.Postings == nil {
+		// Written before postings were saved; tokenize once and save them
+		for _, d := range idx.Docs {
+			idx.addPostings(d)
+		}
+		idx.dirty = true
+		return idx
+	}
+	for _, d := range idx.Docs {
+		d.terms = make(map[string]int)
+	}
+	for t, p := range idx.Postings {
+		for id, n := range p {
+			d, ok := idx.Docs[id]
+			if !ok {
+				delete(p, id)
+				continue
+			}
+			d.terms[t] = n
+			d.size += n
+			idx.total += n
+		}
+		if len(p) == 0 {
+			delete(idx.Postings, t)
+		}
+	}
+	return idx
+}
+
+// Save writes the index to path atomically.
+func (idx *Index) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Update brings the town's on-disk index up to date and returns it.
+// Concurrent updaters (daemon and CLI) are serialized with a file lock.
+func Update(townRoot string) (*Index, error) {
+	path := IndexPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	var idx *Index
+	err := flock.With(path, lockTimeout, func() error {
+		idx = Load(path)
+		if err := idx.Refresh(Sources(townRoot)); err != nil {
+			return err
+		}
+		if !idx.dirty {
+			return nil
+		}
+		if err := idx.Save(path); err != nil {
+			return err
+		}
+		idx.dirty = false
+		return nil
+	})
+	return idx, err
+}
+
+// Rebuild discards the town's index and indexes every source from scratch.
+func Rebuild(townRoot string) (*Index, error) {
+	if err := os.Remove(IndexPath(townRoot)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return Update(townRoot)
+}
+
+// Refresh reindexes whatever changed in sources since the last refresh and
+// drops documents whose file has disappeared.
+func (idx *Index) Refresh(sources []SourceFile) error {
+	seen := make(map[string]bool, len(sources))
+	for _, src := range sources {
+		seen[src.Path] = true
+		if err := idx.refreshFile(src); err != nil {
+			return fmt.Errorf("indexing %s: %w", src.Path, err)
+		}
+	}
+	for path := range idx.Files {
+		if !seen[path] {
+			idx.removeFile(path)
+			delete(idx.Files, path)
+			idx.dirty = true
+		}
+	}
+	return nil
+}
+
+func (idx *Index) refreshFile(src SourceFile) error {
+	info, err := os.Stat(src.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	state, known := idx.Files[src.Path]
+	if known && state.Size == info.Size() && state.ModTime.Equal(info.ModTime()) {
+		return nil
+	}
+
+	appending := src.AppendOnly && known && info.Size() >= state.Offset
+	offset := int64(0)
+	if appending {
+		offset = state.Offset
+	}
+
+	docs, next, err := src.read(offset)
+	if err != nil {
+		return err
+	}
+	// A rewritten file is mostly unchanged, so only changed documents are
+	// retokenized and those no longer in it are dropped.
+	current := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		d.File = src.Path
+		current[d.ID] = true
+		if !appending && sameDoc(idx.Docs[d.ID], d) {
+			continue
+		}
+		idx.Add(d)
+	}
+	if !appending {
+		for id, d := range idx.Docs {
+			if d.File == src.Path && !current[id] {
+				idx.remove(id)
+			}
+		}
+	}
+	idx.Files[src.Path] = FileState{Size: info.Size(), ModTime: info.ModTime(), Offset: next}
+	idx.dirty = true
+	return nil
+}
+
+// sameDoc reports whether old already indexes exactly what d would.
+func sameDoc(old, d *Doc) bool {
+	return old != nil && old.File == d.File && old.Source == d.Source && old.Ref == d.Ref &&
+		old.Title == d.Title && old.Text == truncateText(d.Text) && old.Time.Equal(d.Time) &&
+		old.Visibility == d.Visibility
+}
+
+// truncateText cuts text to maxDocText bytes on a rune boundary.
+func truncateText(text string) string {
+	if len(text) <= maxDocText {
+		return text
+	}
+	cut := maxDocText
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
+
+// Add indexes d, replacing any document with the same ID.
+func (idx *Index) Add(d *Doc) {
+	idx.remove(d.ID)
+	d.Text = truncateText(d.Text)
+	idx.addPostings(d)
+	idx.Docs[d.ID] = d
+	idx.dirty = true
+}
+
+func (idx *Index) addPostings(d *Doc) {
+	if idx.Postings == nil {
+		idx.Postings = make(map[string]map[string]int)
+	}
+	d.terms = make(map[string]int)
+	d.size = 0
+	for _, t := range Tokenize(d.Title + " " + d.Text) {
+		d.terms[t]++
+		d.size++
+	}
+	for t, n := range d.terms {
+		p := idx.Postings[t]
+		if p == nil {
+			p = make(map[string]int)
+			idx.Postings[t] = p
+		}
+		p[d.ID] = n
+	}
+	idx.total += d.size
+}
+
+func (idx *Index) remove(id string) {
+	d, ok := idx.Docs[id]
+	if !ok {
+		return
+	}
+	for t := range d.terms {
+		delete(idx.Postings[t], id)
+		if len(idx.Postings[t]) == 0 {
+			delete(idx.Postings, t)
+		}
+	}
+	idx.total -= d.size
+	delete(idx.Docs, id)
+	idx.dirty = true
+}
+
+func (idx *Index) removeFile(path string) {
+	for id, d := range idx.Docs {
+		if d.File == path {
+			idx.remove(id)
+		}
+	}
+}
+
+// Search returns documents matching any query term, best first.
+func (idx *Index) Search(query string, opts Options) []Hit {
+	terms := Tokenize(query)
+	if len(terms) == 0 || len(idx.Docs) == 0 {
+		return nil
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	allowed := make(map[string]bool, len(opts.Sources))
+	for _, s := range opts.Sources {
+		allowed[s] = true
+	}
+
+	n := float64(len(idx.Docs))
+	avg := float64(idx.total) / n
+	scores := make(map[string]float64)
+	for _, t := range uniq(terms) {
+		p := idx.Postings[t]
+		if len(p) == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-float64(len(p))+0.5)/(float64(len(p))+0.5))
+		for id, tf := range p {
+			d := idx.Docs[id]
+			if len(allowed) > 0 && !allowed[d.Source] || !beads.VisibleAt(d.Visibility, opts.Scope) {
+				continue
+			}
+			f := float64(tf)
+			scores[id] += idf * f * (bm25K1 + 1) / (f + bm25K1*(1-bm25B+bm25B*float64(d.size)/avg))
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, s := range scores {
+		d := idx.Docs[id]
+		hits = append(hits, Hit{Doc: d, Score: s, Snippet: Snippet(d.Text, terms, 160)})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].Doc.Time.Equal(hits[j].Doc.Time) {
+			return hits[i].Doc.Time.After(hits[j].Doc.Time)
+		}
+		return hits[i].Doc.ID < hits[j].Doc.ID
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// stopwords are skipped when indexing and querying.
+var stopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "was": true, "with": true,
+	"that": true, "this": true, "from": true, "have": true, "not": true, "but": true,
+	"you": true, "all": true, "can": true, "has": true, "its": true, "into": true,
+	"did": true, "our": true, "where": true, "what": true, "when": true, "how": true,
+	"we": true, "is": true, "it": true, "of": true, "to": true, "in": true, "on": true,
+	"an": true, "or": true, "be": true, "as": true, "at": true, "by": true,
+}
+
+// Tokenize lowercases s and splits it into index terms.
+func Tokenize(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := fields[:0]
+	for _, f := range fields {
+		if len(f) < 2 || stopwords[f] {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// Snippet returns up to width characters of text around the first
+// occurrence of any term.
+func Snippet(text string, terms []string, width int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	lower := strings.ToLower(text)
+	at := -1
+	for _, t := range terms {
+		if i := strings.Index(lower, t); i >= 0 && (at < 0 || i < at) {
+			at = i
+		}
+	}
+	start := max(at-width/3, 0)
+	end := min(start+width, len(text))
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	snip := text[start:end]
+	if start > 0 {
+		snip = "…" + snip
+	}
+	if end < len(text) {
+		snip += "…"
+	}
+	return snip
+}
+
+func uniq(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	out := terms[:0:0]
+	for _, t := range terms {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSearchRanksByRelevance(t *testing.T) {
+	idx := NewIndex()
+	idx.Add(&Doc{ID: "a", Source: SourceBead, Title: "Flaky auth test", Text: "auth test fails on CI about once a day"})
+	idx.Add(&Doc{ID: "b", Source: SourceMail, Title: "Lunch", Text: "the test kitchen is open"})
+	idx.Add(&Doc{ID: "c", Source: SourceEvent, Text: "refinery merged branch"})
+
+	hits := idx.Search("where did we discuss the flaky auth test", Options{})
+	if len(hits) != 2 || hits[0].Doc.ID != "a" {
+		t.Fatalf("hits = %+v, want a first then b", hits)
+	}
+
+	hits = idx.Search("test", Options{Sources: []string{SourceMail}})
+	if len(hits) != 1 || hits[0].Doc.ID != "b" {
+		t.Errorf("mail-only hits = %+v", hits)
+	}
+
+	idx.Add(&Doc{ID: "d", Source: SourceBead, Title: "Auth incident", Visibility: "private"})
+	if hits := idx.Search("auth", Options{Scope: "internal"}); len(hits) != 1 || hits[0].Doc.ID != "a" {
+		t.Errorf("internal-scope hits = %+v, want the private bead left out", hits)
+	}
+	if hits := idx.Search("auth", Options{}); len(hits) != 2 {
+		t.Errorf("unscoped hits = %+v, want both", hits)
+	}
+
+	// Replacing a doc drops its old terms.
+	idx.Add(&Doc{ID: "a", Source: SourceBead, Title: "Renamed"})
+	if hits := idx.Search("flaky", Options{}); len(hits) != 0 {
+		t.Errorf("stale postings after replace: %+v", hits)
+	}
+}
+
+func TestRefreshIncremental(t *testing.T) {
+	dir := t.TempDir()
+	eventsFile := filepath.Join(dir, ".events.jsonl")
+	issuesFile := filepath.Join(dir, "issues.jsonl")
+	write := func(path, data string, appendData bool) {
+		t.Helper()
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if appendData {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		f, err := os.OpenFile(path, flags, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sources := []SourceFile{
+		{Path: eventsFile, Kind: KindEvents, AppendOnly: true},
+		{Path: issuesFile, Kind: KindBeads},
+	}
+
+	write(eventsFile, `{"ts":"2026-01-02T10:00:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-1"}}`+"\n"+`{"ts":"2026-01-02T10:01:00Z","type":"hand`, false)
+	write(issuesFile, `{"id":"gt-1","title":"Fix login","issue_type":"task"}`+"\n"+`{"id":"hq-m1","title":"Re: login","issue_type":"message"}`+"\n", false)
+
+	idx := NewIndex()
+	if err := idx.Refresh(sources); err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Docs) != 3 {
+		t.Fatalf("docs = %d, want 3 (partial event line skipped)", len(idx.Docs))
+	}
+	if hits := idx.Search("login", Options{Sources: []string{SourceMail}}); len(hits) != 1 || hits[0].Doc.Ref != "hq-m1" {
+		t.Errorf("mail hits = %+v", hits)
+	}
+
+	// Finish the partial line and rewrite issues.jsonl without gt-1.
+	write(eventsFile, `off","actor":"Toast","payload":{"subject":"login handoff"}}`+"\n", true)
+	write(issuesFile, `{"id":"hq-m1","title":"Re: login","issue_type":"message"}`+"\n", false)
+	if err := idx.Refresh(sources); err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Docs) != 3 {
+		t.Fatalf("docs = %d, want 3 after refresh", len(idx.Docs))
+	}
+	if hits := idx.Search("handoff", Options{}); len(hits) != 1 || hits[0].Doc.Title != "Toast" {
+		t.Errorf("handoff hits = %+v", hits)
+	}
+
+	// Unchanged beads in a rewritten file keep their indexed doc.
+	kept := idx.Docs["bead:hq-m1"]
+	write(issuesFile, `{"id":"hq-m1","title":"Re: login","issue_type":"message"}`+"\n"+`{"id":"gt-2","title":"Audit","issue_type":"task","labels":["visibility:private"]}`+"\n", false)
+	if err := idx.Refresh(sources); err != nil {
+		t.Fatal(err)
+	}
+	if idx.Docs["bead:hq-m1"] != kept {
+		t.Error("unchanged bead was reindexed")
+	}
+	if d := idx.Docs["bead:gt-2"]; d == nil || d.Visibility != "private" {
+		t.Errorf("gt-2 doc = %+v, want private", d)
+	}
+
+	// Round-trip through disk.
+	path := filepath.Join(dir, IndexFileName)
+	if err := idx.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded := Load(path)
+	if hits := loaded.Search("login", Options{}); len(hits) != 2 {
+		t.Errorf("loaded hits = %d, want 2", len(hits))
+	}
+	if loaded.total != idx.total || loaded.dirty {
+		t.Errorf("loaded total = %d (dirty %v), want %d from saved postings", loaded.total, loaded.dirty, idx.total)
+	}
+	if err := loaded.Refresh(sources); err != nil || loaded.dirty {
+		t.Errorf("refresh with nothing new dirtied the index (err %v)", err)
+	}
+
+	// Removed files drop their docs.
+	if err := loaded.Refresh(sources[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Docs) != 2 {
+		t.Errorf("docs = %d, want 2 events", len(loaded.Docs))
+	}
+}
+
+func TestParseTranscript(t *testing.T) {
+	d := parseTranscript([]byte(`{"type":"assistant","timestamp":"2026-01-02T10:00:00Z","message":{"content":[{"type":"text","text":"The auth test is flaky"},{"type":"tool_use","name":"Bash"}]}}`), "/x/s.jsonl", 0)
+	if d == nil || d.Text != "The auth test is flaky" || d.Source != SourceTranscript {
+		t.Fatalf("doc = %+v", d)
+	}
+	if d := parseTranscript([]byte(`{"type":"summary","summary":"x"}`), "/x/s.jsonl", 0); d != nil {
+		t.Errorf("summary line indexed: %+v", d)
+	}
+}
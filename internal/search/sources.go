@@ -0,0 +1,252 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Source file kinds.
+const (
+	KindBeads      = "beads"      // issues.jsonl (beads and mail)
+	KindEvents     = "events"     // .events.jsonl
+	KindTranscript = "transcript" // Claude session transcript
+)
+
+// SourceFile is one file the index reads from.
+type SourceFile struct {
+	Path       string
+	Kind       string
+	AppendOnly bool // Read incrementally from the last offset
+}
+
+// Sources lists the town's indexable files: town and rig issues.jsonl,
+// the events log, and Claude transcripts for sessions run inside the town.
+func Sources(townRoot string) []SourceFile {
+	var out []SourceFile
+	seen := make(map[string]bool)
+	add := func(path, kind string, appendOnly bool) {
+		if real, err := filepath.EvalSymlinks(path); err == nil {
+			path = real
+		}
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		out = append(out, SourceFile{Path: path, Kind: kind, AppendOnly: appendOnly})
+	}
+
+	for _, pattern := range []string{
+		filepath.Join(townRoot, ".beads", "issues.jsonl"),
+		filepath.Join(townRoot, "*", ".beads", "issues.jsonl"),
+		filepath.Join(townRoot, "*", "mayor", "rig", ".beads", "issues.jsonl"),
+	} {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			add(m, KindBeads, false)
+		}
+	}
+
+	for _, path := range events.LogPaths(townRoot) {
+		if _, err := os.Stat(path); err == nil {
+			add(path, KindEvents, true)
+		}
+	}
+
+	for _, dir := range TranscriptDirs(townRoot) {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+		for _, m := range matches {
+			add(m, KindTranscript, true)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+// TranscriptDirs returns the Claude project directories for sessions whose
+// working directory is inside townRoot. Claude names each project directory
+// after its working directory with path separators and dots replaced by "-".
+func TranscriptDirs(townRoot string) []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	abs, err := filepath.Abs(townRoot)
+	if err != nil {
+		return nil
+	}
+	prefix := strings.NewReplacer("/", "-", ".", "-").Replace(abs)
+
+	entries, err := os.ReadDir(filepath.Join(home, ".claude", "projects"))
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() && (e.Name() == prefix || strings.HasPrefix(e.Name(), prefix+"-")) {
+			dirs = append(dirs, filepath.Join(home, ".claude", "projects", e.Name()))
+		}
+	}
+	return dirs
+}
+
+// read parses complete lines from offset and returns the documents found
+// and the offset just past the last complete line.
+func (s SourceFile) read(offset int64) ([]*Doc, int64, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, offset, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+
+	var docs []*Doc
+	r := bufio.NewReaderSize(f, 64*1024)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// A partial trailing line is left for the next refresh.
+			return docs, offset, nil
+		}
+		if err != nil {
+			return docs, offset, err
+		}
+		if d := s.parse(bytes.TrimSpace(line), offset); d != nil {
+			docs = append(docs, d)
+		}
+		offset += int64(len(line))
+	}
+}
+
+// parse turns one JSONL line into a document, or nil if it holds nothing
+// worth indexing.
+func (s SourceFile) parse(line []byte, offset int64) *Doc {
+	if len(line) == 0 {
+		return nil
+	}
+	switch s.Kind {
+	case KindBeads:
+		return parseIssue(line)
+	case KindEvents:
+		return parseEvent(line, s.Path, offset)
+	case KindTranscript:
+		return parseTranscript(line, s.Path, offset)
+	}
+	return nil
+}
+
+func parseIssue(line []byte) *Doc {
+	var issue beads.Issue
+	if err := json.Unmarshal(line, &issue); err != nil || issue.ID == "" {
+		return nil
+	}
+	source := SourceBead
+	if issue.Type == "message" {
+		source = SourceMail
+	}
+	d := &Doc{
+		ID:     "bead:" + issue.ID,
+		Source: source,
+		Ref:    issue.ID,
+		Title:  issue.Title,
+		Text:   strings.Join(append([]string{issue.Description}, issue.Labels...), "\n"),
+		Time:   issue.CreatedAt,
+	}
+	if v := issue.Visibility(); v != beads.VisibilityPublic {
+		d.Visibility = v
+	}
+	return d
+}
+
+func parseEvent(line []byte, path string, offset int64) *Doc {
+	var e events.Event
+	if err := json.Unmarshal(line, &e); err != nil || e.Type == "" {
+		return nil
+	}
+	keys := make([]string, 0, len(e.Payload))
+	for k := range e.Payload {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := []string{e.Type, e.Actor}
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprint(e.Payload[k]))
+	}
+	t, _ := time.Parse(time.RFC3339, e.Timestamp)
+	return &Doc{
+		ID:     fmt.Sprintf("event:%s:%d", path, offset),
+		Source: SourceEvent,
+		Ref:    e.Type,
+		Title:  e.Actor,
+		Text:   strings.Join(parts, " "),
+		Time:   t,
+	}
+}
+
+// transcriptLine is the subset of a Claude transcript entry we index.
+type transcriptLine struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Message   struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+func parseTranscript(line []byte, path string, offset int64) *Doc {
+	var tl transcriptLine
+	if err := json.Unmarshal(line, &tl); err != nil {
+		return nil
+	}
+	if tl.Type != "user" && tl.Type != "assistant" {
+		return nil
+	}
+	text := transcriptText(tl.Message.Content)
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	t, _ := time.Parse(time.RFC3339, tl.Timestamp)
+	return &Doc{
+		ID:     fmt.Sprintf("transcript:%s:%d", path, offset),
+		Source: SourceTranscript,
+		Ref:    filepath.Base(path),
+		Title:  tl.Type,
+		Text:   text,
+		Time:   t,
+	}
+}
+
+// transcriptText extracts the text blocks from message content, which is
+// either a plain string or a list of typed blocks.
+func transcriptText(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(raw, &blocks) != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" && b.Text != "" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,205 @@
+// Package setup implements the first-run flow for a new Gas Town: detect bd,
+// create the town, add a first rig, create handoff beads, and smoke test.
+//
+// The flow is shared by "gt setup" and install scripts. Every prompt has a
+// flag equivalent, so the same steps run non-interactively with --yes.
+// The side-effecting work is supplied through Actions so the command layer
+// can reuse its existing install and rig-add logic.
+package setup
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Options configures a setup run. Empty fields are prompted for in
+// interactive mode and defaulted otherwise.
+type Options struct {
+	TownRoot      string
+	TownName      string
+	RigName       string // Optional; no rig is added if RigURL is empty
+	RigURL        string
+	SkipSmokeTest bool
+}
+
+// Actions performs the side effects of each step.
+type Actions interface {
+	// DetectBeads returns the installed bd version or an error if bd is
+	// missing or incompatible.
+	DetectBeads() (string, error)
+
+	// TownExists reports whether root is already a Gas Town.
+	TownExists(root string) bool
+
+	CreateTown(opts *Options) error
+	AddRig(opts *Options) error
+	CreateHandoffBeads(opts *Options) error
+	SmokeTest(opts *Options) error
+}
+
+// Step statuses.
+const (
+	StatusOK      = "ok"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+// StepResult records the outcome of one step.
+type StepResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ErrSetupFailed is returned when a required step fails.
+var ErrSetupFailed = errors.New("setup failed")
+
+// Wizard runs the first-run flow.
+type Wizard struct {
+	Actions     Actions
+	Interactive bool
+	In          io.Reader
+	Out         io.Writer
+
+	// DefaultTownRoot is offered when TownRoot is empty.
+	DefaultTownRoot string
+
+	reader *bufio.Reader
+}
+
+// Run executes all steps in order. It stops at the first failed step and
+// returns the results so far along with an error wrapping ErrSetupFailed.
+func (w *Wizard) Run(opts Options) ([]StepResult, error) {
+	if err := w.fillOptions(&opts); err != nil {
+		return nil, err
+	}
+
+	steps := []struct {
+		name string
+		run  func(*Options) (string, string, error)
+	}{
+		{"detect-bd", w.detectBeads},
+		{"create-town", w.createTown},
+		{"add-rig", w.addRig},
+		{"handoff-beads", w.handoffBeads},
+		{"smoke-test", w.smokeTest},
+	}
+
+	var results []StepResult
+	for _, s := range steps {
+		status, detail, err := s.run(&opts)
+		if err != nil {
+			results = append(results, StepResult{Name: s.name, Status: StatusFailed, Detail: err.Error()})
+			w.printf("  ✗ %s: %v\n", s.name, err)
+			return results, fmt.Errorf("%w at %s: %v", ErrSetupFailed, s.name, err)
+		}
+		results = append(results, StepResult{Name: s.name, Status: status, Detail: detail})
+		mark := "✓"
+		if status == StatusSkipped {
+			mark = "○"
+		}
+		if detail != "" {
+			w.printf("  %s %s (%s)\n", mark, s.name, detail)
+		} else {
+			w.printf("  %s %s\n", mark, s.name)
+		}
+	}
+	return results, nil
+}
+
+// fillOptions prompts for (or defaults) missing options.
+func (w *Wizard) fillOptions(opts *Options) error {
+	if opts.TownRoot == "" {
+		opts.TownRoot = w.ask("Town location", w.DefaultTownRoot)
+	}
+	if opts.TownRoot == "" {
+		return fmt.Errorf("%w: town location is required", ErrSetupFailed)
+	}
+	if abs, err := filepath.Abs(opts.TownRoot); err == nil {
+		opts.TownRoot = abs
+	}
+	if opts.TownName == "" {
+		opts.TownName = w.ask("Town name", filepath.Base(opts.TownRoot))
+	}
+	if opts.RigURL == "" {
+		opts.RigURL = w.ask("Git URL for your first rig (blank to skip)", "")
+	}
+	if opts.RigURL != "" && opts.RigName == "" {
+		opts.RigName = w.ask("Rig name", RigNameFromURL(opts.RigURL))
+	}
+	return nil
+}
+
+func (w *Wizard) detectBeads(*Options) (string, string, error) {
+	version, err := w.Actions.DetectBeads()
+	if err != nil {
+		return "", "", err
+	}
+	return StatusOK, "bd " + version, nil
+}
+
+func (w *Wizard) createTown(opts *Options) (string, string, error) {
+	if w.Actions.TownExists(opts.TownRoot) {
+		return StatusSkipped, "already exists", nil
+	}
+	return StatusOK, opts.TownRoot, w.Actions.CreateTown(opts)
+}
+
+func (w *Wizard) addRig(opts *Options) (string, string, error) {
+	if opts.RigURL == "" {
+		return StatusSkipped, "no rig requested", nil
+	}
+	return StatusOK, opts.RigName, w.Actions.AddRig(opts)
+}
+
+func (w *Wizard) handoffBeads(opts *Options) (string, string, error) {
+	return StatusOK, "", w.Actions.CreateHandoffBeads(opts)
+}
+
+func (w *Wizard) smokeTest(opts *Options) (string, string, error) {
+	if opts.SkipSmokeTest {
+		return StatusSkipped, "disabled", nil
+	}
+	return StatusOK, "", w.Actions.SmokeTest(opts)
+}
+
+// ask prompts for a value in interactive mode; otherwise returns def.
+func (w *Wizard) ask(question, def string) string {
+	if !w.Interactive || w.In == nil {
+		return def
+	}
+	if w.reader == nil {
+		w.reader = bufio.NewReader(w.In)
+	}
+	if def != "" {
+		w.printf("%s [%s]: ", question, def)
+	} else {
+		w.printf("%s: ", question)
+	}
+	line, _ := w.reader.ReadString('\n')
+	if line = strings.TrimSpace(line); line != "" {
+		return line
+	}
+	return def
+}
+
+func (w *Wizard) printf(format string, args ...interface{}) {
+	if w.Out != nil {
+		fmt.Fprintf(w.Out, format, args...)
+	}
+}
+
+// RigNameFromURL derives a rig name from a git URL
+// (e.g. "git@github.com:acme/widgets.git" → "widgets").
+func RigNameFromURL(url string) string {
+	url = strings.TrimSuffix(strings.TrimRight(url, "/"), ".git")
+	if i := strings.LastIndexAny(url, "/:"); i >= 0 {
+		url = url[i+1:]
+	}
+	// Hyphens, dots, and spaces are reserved in rig names (agent ID parsing)
+	return strings.ToLower(strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(url))
+}
@@ -0,0 +1,109 @@
+package setup
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeActions struct {
+	bdErr    error
+	exists   bool
+	rigErr   error
+	calls    []string
+	lastOpts Options
+}
+
+func (f *fakeActions) DetectBeads() (string, error) {
+	f.calls = append(f.calls, "detect")
+	return "0.44.0", f.bdErr
+}
+func (f *fakeActions) TownExists(string) bool { return f.exists }
+func (f *fakeActions) CreateTown(o *Options) error {
+	f.calls = append(f.calls, "town")
+	f.lastOpts = *o
+	return nil
+}
+func (f *fakeActions) AddRig(o *Options) error {
+	f.calls = append(f.calls, "rig:"+o.RigName)
+	return f.rigErr
+}
+func (f *fakeActions) CreateHandoffBeads(*Options) error {
+	f.calls = append(f.calls, "handoff")
+	return nil
+}
+func (f *fakeActions) SmokeTest(*Options) error {
+	f.calls = append(f.calls, "smoke")
+	return nil
+}
+
+func TestWizardNonInteractive(t *testing.T) {
+	f := &fakeActions{}
+	w := &Wizard{Actions: f, DefaultTownRoot: "/home/u/gt"}
+
+	results, err := w.Run(Options{RigURL: "git@github.com:acme/my-widgets.git"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(f.calls, ","); got != "detect,town,rig:my_widgets,handoff,smoke" {
+		t.Errorf("calls = %s", got)
+	}
+	if f.lastOpts.TownRoot != "/home/u/gt" || f.lastOpts.TownName != "gt" {
+		t.Errorf("defaults not applied: %+v", f.lastOpts)
+	}
+	if len(results) != 5 {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestWizardInteractive(t *testing.T) {
+	f := &fakeActions{exists: true}
+	var out bytes.Buffer
+	w := &Wizard{
+		Actions:         f,
+		Interactive:     true,
+		In:              strings.NewReader("/srv/town\n\n\n"),
+		Out:             &out,
+		DefaultTownRoot: "/home/u/gt",
+	}
+
+	results, err := w.Run(Options{SkipSmokeTest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[1].Status != StatusSkipped || results[2].Status != StatusSkipped || results[4].Status != StatusSkipped {
+		t.Errorf("results = %+v", results)
+	}
+	if !strings.Contains(out.String(), "Town location [/home/u/gt]:") {
+		t.Errorf("prompt missing from output:\n%s", out.String())
+	}
+}
+
+func TestWizardStopsOnFailure(t *testing.T) {
+	f := &fakeActions{bdErr: errors.New("bd not found")}
+	w := &Wizard{Actions: f, DefaultTownRoot: "/tmp/gt"}
+
+	results, err := w.Run(Options{})
+	if !errors.Is(err, ErrSetupFailed) {
+		t.Fatalf("err = %v, want ErrSetupFailed", err)
+	}
+	if len(results) != 1 || results[0].Status != StatusFailed {
+		t.Errorf("results = %+v", results)
+	}
+	if len(f.calls) != 1 {
+		t.Errorf("ran steps after failure: %v", f.calls)
+	}
+}
+
+func TestRigNameFromURL(t *testing.T) {
+	for url, want := range map[string]string{
+		"https://github.com/acme/widgets.git": "widgets",
+		"git@github.com:acme/foo-bar":         "foo_bar",
+		"/local/path/repo.v2/":                "repo_v2",
+	} {
+		if got := RigNameFromURL(url); got != want {
+			t.Errorf("RigNameFromURL(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
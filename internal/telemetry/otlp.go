@@ -0,0 +1,252 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Export tuning.
+const (
+	ExportTimeout = 5 * time.Second // Bounds each export request
+	MaxBuffered   = 512             // Spans buffered before a background export
+)
+
+// Exporter buffers finished spans and sends them to an OTLP/HTTP traces
+// endpoint using the JSON encoding.
+type Exporter struct {
+	url     string
+	headers map[string]string
+	service string
+	client  *http.Client
+
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// NewExporter creates an exporter for the traces endpoint url (the full
+// path, e.g. http://localhost:4318/v1/traces).
+func NewExporter(url string, headers map[string]string, service string) *Exporter {
+	if service == "" {
+		service = DefaultServiceName
+	}
+	return &Exporter{
+		url:     url,
+		headers: headers,
+		service: service,
+		client:  &http.Client{Timeout: ExportTimeout},
+	}
+}
+
+// FromEnv returns an exporter configured by the standard OTel environment
+// variables, or nil if no endpoint is set.
+func FromEnv() *Exporter {
+	url := os.Getenv(EnvTracesEndpoint)
+	if url == "" {
+		base := os.Getenv(EnvEndpoint)
+		if base == "" {
+			return nil
+		}
+		url = strings.TrimSuffix(base, "/") + "/v1/traces"
+	}
+	return NewExporter(url, parseHeaders(os.Getenv(EnvHeaders)), os.Getenv(EnvServiceName))
+}
+
+// parseHeaders parses "k1=v1,k2=v2" as in OTEL_EXPORTER_OTLP_HEADERS.
+func parseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if ok && strings.TrimSpace(k) != "" {
+			headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return headers
+}
+
+// Start begins a span, a child of parent if parent is non-nil.
+func (e *Exporter) Start(name string, parent *Span) *Span {
+	s := &Span{
+		Name:   name,
+		SpanID: newID(8),
+		Start:  time.Now(),
+		Attrs:  make(map[string]interface{}),
+		exp:    e,
+	}
+	if parent != nil {
+		s.TraceID, s.ParentID = parent.TraceID, parent.SpanID
+	} else {
+		s.TraceID = traceIDFromEnv()
+	}
+	return s
+}
+
+// add queues a finished span. Long-running processes like the daemon
+// export in the background once MaxBuffered spans are waiting.
+func (e *Exporter) add(s *Span) {
+	e.mu.Lock()
+	e.spans = append(e.spans, s)
+	full := len(e.spans) >= MaxBuffered
+	e.mu.Unlock()
+	if full {
+		go func() { _ = e.Flush() }()
+	}
+}
+
+// Flush sends the buffered spans in one request.
+func (e *Exporter) Flush() error {
+	e.mu.Lock()
+	spans := e.spans
+	e.spans = nil
+	e.mu.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("exporting spans: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("exporting spans: %s", resp.Status)
+	}
+	return nil
+}
+
+// OTLP JSON encoding of an ExportTraceServiceRequest. IDs are hex and
+// 64-bit integers are decimal strings, per the OTLP/JSON spec.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+	}
+)
+
+// OTLP span kinds and status codes.
+const (
+	kindInternal = 1
+	kindClient   = 3
+	statusOK     = 1
+	statusError  = 2
+)
+
+func (e *Exporter) request(spans []*Span) otlpRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		span := otlpSpan{
+			TraceID:           s.TraceID,
+			SpanID:            s.SpanID,
+			ParentSpanID:      s.ParentID,
+			Name:              s.Name,
+			Kind:              kindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+			Attributes:        attributes(s.Attrs),
+			Status:            otlpStatus{Code: statusOK},
+		}
+		if s.Client {
+			span.Kind = kindClient
+		}
+		if s.Err != nil {
+			span.Status = otlpStatus{Code: statusError, Message: s.Err.Error()}
+		}
+		out = append(out, span)
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: attributes(map[string]interface{}{"service.name": e.service})},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "github.com/steveyegge/gastown"}, Spans: out}},
+	}}}
+}
+
+// attributes converts attrs to OTLP key/values, sorted by key.
+func attributes(attrs map[string]interface{}) []otlpKeyValue {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	out := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		var v otlpValue
+		switch x := attrs[k].(type) {
+		case string:
+			v.StringValue = &x
+		case int:
+			s := strconv.Itoa(x)
+			v.IntValue = &s
+		case int64:
+			s := strconv.FormatInt(x, 10)
+			v.IntValue = &s
+		case float64:
+			v.DoubleValue = &x
+		case bool:
+			v.BoolValue = &x
+		default:
+			s := fmt.Sprint(x)
+			v.StringValue = &s
+		}
+		out = append(out, otlpKeyValue{Key: k, Value: v})
+	}
+	return out
+}
@@ -0,0 +1,204 @@
+// Package telemetry exports OpenTelemetry spans for gt commands and the bd
+// calls they make, so bd latency and per-command wall-clock can be seen in
+// any OTLP-compatible tracing backend.
+//
+// It is off unless the standard OTel exporter environment names an
+// endpoint (OTEL_EXPORTER_OTLP_TRACES_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT).
+// Spans are buffered in memory and sent in one OTLP/HTTP JSON request when
+// the command finishes, so tracing adds no latency to bd calls.
+package telemetry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// OTel exporter environment variables honored by FromEnv.
+const (
+	EnvEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	EnvHeaders        = "OTEL_EXPORTER_OTLP_HEADERS"
+	EnvServiceName    = "OTEL_SERVICE_NAME"
+)
+
+// DefaultServiceName is the service.name of exported spans unless
+// OTEL_SERVICE_NAME says otherwise.
+const DefaultServiceName = "gt"
+
+// Span is one timed operation.
+type Span struct {
+	Name     string
+	TraceID  string // 32 hex digits
+	SpanID   string // 16 hex digits
+	ParentID string // Empty for a root span
+	Client   bool   // An outgoing call (bd) rather than gt's own work
+	Start    time.Time
+	End      time.Time
+	Attrs    map[string]interface{}
+	Err      error
+
+	exp *Exporter
+}
+
+// SetAttr records an attribute on the span. Safe on a nil span.
+func (s *Span) SetAttr(key string, value interface{}) {
+	if s != nil {
+		s.Attrs[key] = value
+	}
+}
+
+// Finish ends the span with err (nil for success) and queues it for export.
+// Safe on a nil span.
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.End, s.Err = time.Now(), err
+	s.exp.add(s)
+}
+
+// newID returns n random bytes as hex.
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// traceIDFromEnv continues the gt event trace (see events.StartTrace) if
+// there is one, so spans and events of one unit of work share an ID.
+func traceIDFromEnv() string {
+	if id := os.Getenv(config.EnvTraceID); len(id) == 16 {
+		if _, err := hex.DecodeString(id); err == nil {
+			return strings.Repeat("0", 16) + id
+		}
+	}
+	return newID(16)
+}
+
+// The process-wide exporter and the running command's span, set by
+// StartCommand.
+var current struct {
+	sync.Mutex
+	exp  *Exporter
+	root *Span
+}
+
+// StartCommand begins the span of a gt command if telemetry is configured,
+// and returns it (nil otherwise). bd calls recorded by RecordBd become its
+// children.
+func StartCommand(name string) *Span {
+	exp := FromEnv()
+	if exp == nil {
+		return nil
+	}
+	root := exp.Start(name, nil)
+	current.Lock()
+	current.exp, current.root = exp, root
+	current.Unlock()
+	return root
+}
+
+// EndCommand finishes the command span with the process exit code and
+// exports every queued span.
+func EndCommand(exitCode int, err error) error {
+	current.Lock()
+	exp, root := current.exp, current.root
+	current.exp, current.root = nil, nil
+	current.Unlock()
+	if exp == nil {
+		return nil
+	}
+	root.SetAttr("gt.exit_code", exitCode)
+	root.Finish(err)
+	return exp.Flush()
+}
+
+// RecordBd records a completed bd call as a child of the command span. Its
+// signature matches beads.TraceFunc, so it is installed with beads.Trace.
+func RecordBd(args []string, elapsed time.Duration, err error) {
+	current.Lock()
+	exp, root := current.exp, current.root
+	current.Unlock()
+	if exp == nil {
+		return
+	}
+
+	sub := subcommand(args)
+	s := exp.Start("bd "+sub, root)
+	s.Client = true
+	s.Start = time.Now().Add(-elapsed)
+	s.SetAttr("bd.command", sub)
+	s.SetAttr("bd.flags", strings.Join(flagNames(args), " "))
+	if id := BeadID(args); id != "" {
+		s.SetAttr("gt.bead_id", id)
+	}
+	exitCode := 0
+	if err != nil {
+		exitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+	}
+	s.SetAttr("bd.exit_code", exitCode)
+	s.Finish(err)
+}
+
+// subcommand returns the first non-flag argument.
+func subcommand(args []string) string {
+	for _, a := range args {
+		if !strings.HasPrefix(a, "-") {
+			return a
+		}
+	}
+	return ""
+}
+
+// flagNames returns the names of the flags in args, without their values.
+// Positional arguments and flag values carry titles, descriptions and mail
+// bodies, so only the names are safe to export.
+func flagNames(args []string) []string {
+	var names []string
+	for _, a := range args {
+		if a == "--" {
+			break
+		}
+		if strings.HasPrefix(a, "-") {
+			name, _, _ := strings.Cut(a, "=")
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// BeadID returns the first argument that looks like a bead ID
+// (prefix-suffix, e.g. gt-abc12 or hq-mayor), or "".
+func BeadID(args []string) string {
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") || strings.ContainsAny(a, "/ =:") {
+			continue
+		}
+		prefix, rest, ok := strings.Cut(a, "-")
+		if ok && len(prefix) >= 2 && len(prefix) <= 8 && rest != "" && isLower(prefix) {
+			return a
+		}
+	}
+	return ""
+}
+
+func isLower(s string) bool {
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
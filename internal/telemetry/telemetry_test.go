@@ -0,0 +1,131 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestCommandSpans(t *testing.T) {
+	var got otlpRequest
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			http.NotFound(w, r)
+			return
+		}
+		auth = r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv(EnvTracesEndpoint, "")
+	t.Setenv(EnvEndpoint, srv.URL+"/")
+	t.Setenv(EnvHeaders, "Authorization=Bearer abc, x-team = infra")
+	t.Setenv(EnvServiceName, "")
+	t.Setenv(config.EnvTraceID, "0123456789abcdef")
+
+	root := StartCommand("gt sling")
+	if root == nil {
+		t.Fatal("StartCommand returned nil with an endpoint configured")
+	}
+	RecordBd([]string{"update", "gt-abc12", "--status=hooked", "--notes=secret plans"}, 40*time.Millisecond, nil)
+	RecordBd([]string{"show", "gt-missing", "--json"}, time.Millisecond, errors.New("not found"))
+	if err := EndCommand(0, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if auth != "Bearer abc" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if len(got.ResourceSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("request = %+v", got)
+	}
+	if svc := got.ResourceSpans[0].Resource.Attributes[0]; svc.Key != "service.name" || *svc.Value.StringValue != DefaultServiceName {
+		t.Errorf("resource attribute = %+v", svc)
+	}
+	spans := got.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans", len(spans))
+	}
+	update, show, cmd := spans[0], spans[1], spans[2]
+	if cmd.Name != "gt sling" || cmd.ParentSpanID != "" || cmd.TraceID != "00000000000000000123456789abcdef" {
+		t.Errorf("command span = %+v", cmd)
+	}
+	if update.Name != "bd update" || update.ParentSpanID != cmd.SpanID || update.TraceID != cmd.TraceID || update.Kind != kindClient {
+		t.Errorf("bd span = %+v", update)
+	}
+	if attr(update, "gt.bead_id") != "gt-abc12" || attr(update, "bd.exit_code") != "0" {
+		t.Errorf("bd span attributes = %+v", update.Attributes)
+	}
+	if got := attr(update, "bd.flags"); got != "--status --notes" {
+		t.Errorf("bd.flags = %q, want flag names without values", got)
+	}
+	for _, kv := range update.Attributes {
+		if kv.Value.StringValue != nil && strings.Contains(*kv.Value.StringValue, "secret") {
+			t.Errorf("attribute %s exports argument content: %q", kv.Key, *kv.Value.StringValue)
+		}
+	}
+	if show.Status.Code != statusError || show.Status.Message != "not found" {
+		t.Errorf("failed bd span status = %+v", show.Status)
+	}
+	if attr(cmd, "gt.exit_code") != "0" || cmd.Status.Code != statusOK {
+		t.Errorf("command span = %+v", cmd)
+	}
+}
+
+func TestDisabledWithoutEndpoint(t *testing.T) {
+	t.Setenv(EnvTracesEndpoint, "")
+	t.Setenv(EnvEndpoint, "")
+	if span := StartCommand("gt status"); span != nil {
+		t.Errorf("StartCommand = %+v, want nil", span)
+	}
+	span := (*Span)(nil)
+	span.SetAttr("k", "v") // nil-safe
+	span.Finish(nil)
+	RecordBd([]string{"list"}, time.Millisecond, nil)
+	if err := EndCommand(0, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBeadID(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"show", "gt-abc12", "--json"}, "gt-abc12"},
+		{[]string{"--no-daemon", "update", "hq-mayor", "--status=open"}, "hq-mayor"},
+		{[]string{"list", "--status=open"}, ""},
+		{[]string{"gastown/polecats/nux"}, ""},
+	} {
+		if got := BeadID(tt.args); got != tt.want {
+			t.Errorf("BeadID(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func attr(s otlpSpan, key string) string {
+	for _, kv := range s.Attributes {
+		if kv.Key != key {
+			continue
+		}
+		switch {
+		case kv.Value.StringValue != nil:
+			return *kv.Value.StringValue
+		case kv.Value.IntValue != nil:
+			return *kv.Value.IntValue
+		}
+	}
+	return ""
+}
@@ -889,6 +889,13 @@ func (t *Tmux) SetFeedBinding(session string) error {
 	return err
 }
 
+// PipePane pipes the output of a session's pane to a shell command, e.g.
+// "cat >> file". It does nothing if the pane is already piped.
+func (t *Tmux) PipePane(session, command string) error {
+	_, err := t.run("pipe-pane", "-o", "-t", session, command)
+	return err
+}
+
 // SetPaneDiedHook sets a pane-died hook on a session to detect crashes.
 // When the pane exits, tmux runs the hook command with exit status info.
 // The agentID is used to identify the agent in crash logs (e.g., "gastown/Toast").
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/flock"
 )
 
 // EventType represents the type of agent lifecycle event.
@@ -79,16 +81,9 @@ func (l *Logger) LogEvent(event Event) error {
 		return fmt.Errorf("creating log directory: %w", err)
 	}
 
-	// Open file for appending
-	f, err := os.OpenFile(l.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return fmt.Errorf("opening log file: %w", err)
-	}
-	defer f.Close()
-
 	// Write human-readable log line
 	line := formatLogLine(event)
-	if _, err := f.WriteString(line + "\n"); err != nil {
+	if err := flock.AppendFile(l.logPath, []byte(line+"\n"), 0600); err != nil {
 		return fmt.Errorf("writing log line: %w", err)
 	}
 
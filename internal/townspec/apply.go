@@ -0,0 +1,125 @@
+package townspec
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Target is where Apply makes its changes. Rig creation needs the full
+// gt rig add flow (clone, beads init, routes), so the caller supplies it.
+type Target struct {
+	TownRoot  string
+	Beads     beads.Client // town beads, for recurring issues
+	AddRig    func(RigSpec) error
+	RemoveRig func(name string) error
+}
+
+// Apply carries out plan. Settings and daemon policies are written once
+// at the end; rig and issue changes happen as they are reached. It stops
+// at the first error, leaving earlier changes in place (running apply
+// again picks up where it stopped).
+func Apply(spec *Spec, state *State, plan *Plan, target Target) error {
+	settings, daemon := state.Settings, state.Daemon
+	settingsDirty, daemonDirty := false, false
+
+	rigs := make(map[string]RigSpec, len(spec.Rigs))
+	for _, r := range spec.Rigs {
+		rigs[r.Name] = r
+	}
+	issues := make(map[string]IssueSpec, len(spec.Issues))
+	for _, is := range spec.Issues {
+		issues[is.Key] = is
+	}
+
+	for _, c := range plan.Changes {
+		var err error
+		switch c.Kind {
+		case KindSetting:
+			settingsDirty = true
+			switch c.Name {
+			case "default_agent":
+				settings.DefaultAgent = spec.Settings.DefaultAgent
+			case "observers":
+				settings.Observers = spec.Settings.Observers
+			}
+		case KindAgent:
+			settingsDirty = true
+			if c.Action == ActionDelete {
+				delete(settings.Agents, c.Name)
+				break
+			}
+			if settings.Agents == nil {
+				settings.Agents = make(map[string]*config.RuntimeConfig)
+			}
+			settings.Agents[c.Name] = spec.Agents[c.Name].runtimeConfig()
+		case KindQuery:
+			settingsDirty = true
+			if c.Action == ActionDelete {
+				delete(settings.Queries, c.Name)
+				break
+			}
+			if settings.Queries == nil {
+				settings.Queries = make(map[string]*config.SavedQuery)
+			}
+			q := spec.Queries[c.Name]
+			settings.Queries[c.Name] = &q
+		case KindPolicy:
+			daemonDirty = true
+			switch c.Name {
+			case "idle_park":
+				daemon.IdlePark = spec.Policies.IdlePark
+			case "sync_drift":
+				daemon.SyncDrift = spec.Policies.SyncDrift
+			case "watchdog":
+				daemon.Watchdog = spec.Policies.Watchdog
+			case "dispatch":
+				daemon.Dispatch = spec.Policies.Dispatch
+			}
+		case KindRig:
+			if c.Action == ActionDelete {
+				err = target.RemoveRig(c.Name)
+			} else {
+				err = target.AddRig(rigs[c.Name])
+			}
+		case KindIssue:
+			err = applyIssue(target.Beads, c, issues[c.Name], state.Issues[c.Name])
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s %s: %w", c.Action, c.Kind, c.Name, err)
+		}
+	}
+
+	if settingsDirty {
+		if err := config.SaveTownSettings(config.TownSettingsPath(target.TownRoot), settings); err != nil {
+			return fmt.Errorf("saving town settings: %w", err)
+		}
+	}
+	if daemonDirty {
+		if err := config.SaveDaemonPatrolConfig(config.DaemonPatrolConfigPath(target.TownRoot), daemon); err != nil {
+			return fmt.Errorf("saving daemon config: %w", err)
+		}
+	}
+	return nil
+}
+
+func applyIssue(bd beads.Client, c Change, spec IssueSpec, existing *beads.Issue) error {
+	switch c.Action {
+	case ActionCreate:
+		_, err := bd.Create(beads.CreateOptions{
+			Title:       spec.Title,
+			Type:        spec.Type,
+			Priority:    spec.priority(),
+			Description: spec.Description,
+			Labels:      append([]string{IssueLabelPrefix + spec.Key}, spec.Labels...),
+		})
+		return err
+	case ActionUpdate:
+		opts, _ := spec.update(existing)
+		return bd.Update(existing.ID, opts)
+	case ActionDelete:
+		return bd.CloseWithReason("removed from town spec", existing.ID)
+	}
+	return nil
+}
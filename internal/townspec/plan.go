@@ -0,0 +1,331 @@
+package townspec
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// IssueLabelPrefix marks issues managed by a spec; the rest of the label
+// is the IssueSpec key.
+const IssueLabelPrefix = "apply:"
+
+// Change kinds, in the order a plan lists and applies them.
+const (
+	KindSetting = "setting"
+	KindAgent   = "agent"
+	KindPolicy  = "policy"
+	KindQuery   = "query"
+	KindRig     = "rig"
+	KindIssue   = "issue"
+)
+
+var kindOrder = []string{KindSetting, KindAgent, KindPolicy, KindQuery, KindRig, KindIssue}
+
+// Change actions.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Change is one step of a plan.
+type Change struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// String renders the change as a diff line: +create, ~update, -delete.
+func (c Change) String() string {
+	sign := map[string]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}[c.Action]
+	s := fmt.Sprintf("%s %s %s", sign, c.Kind, c.Name)
+	if c.Detail != "" {
+		s += " (" + c.Detail + ")"
+	}
+	return s
+}
+
+// Plan is the set of changes that brings a town in line with a spec.
+type Plan struct {
+	Changes  []Change `json:"changes"`
+	Warnings []string `json:"warnings,omitempty"` // drift apply won't fix
+}
+
+// Empty reports whether the town already matches the spec.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// State is the part of a town a spec manages.
+type State struct {
+	Rigs     map[string]config.RigEntry
+	Settings *config.TownSettings
+	Daemon   *config.DaemonPatrolConfig
+	Issues   map[string]*beads.Issue // open spec-managed issues by key
+}
+
+// LoadState reads the town's current state. town is the town-level
+// beads, where recurring issues live.
+func LoadState(townRoot string, town beads.Client) (*State, error) {
+	state := &State{
+		Rigs:   make(map[string]config.RigEntry),
+		Issues: make(map[string]*beads.Issue),
+	}
+
+	rigs, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err == nil {
+		state.Rigs = rigs.Rigs
+	}
+
+	if state.Settings, err = config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err != nil {
+		return nil, fmt.Errorf("loading town settings: %w", err)
+	}
+
+	state.Daemon, err = config.LoadDaemonPatrolConfig(config.DaemonPatrolConfigPath(townRoot))
+	if err != nil {
+		state.Daemon = config.NewDaemonPatrolConfig()
+	}
+
+	if town != nil {
+		open, err := town.List(beads.ListOptions{Status: "open", Priority: -1, Limit: beads.NoLimit})
+		if err != nil {
+			return nil, fmt.Errorf("listing town issues: %w", err)
+		}
+		for _, issue := range open {
+			if key := issueKey(issue); key != "" {
+				state.Issues[key] = issue
+			}
+		}
+	}
+	return state, nil
+}
+
+// issueKey returns the spec key an issue is managed under, or "".
+func issueKey(issue *beads.Issue) string {
+	for _, l := range issue.Labels {
+		if strings.HasPrefix(l, IssueLabelPrefix) {
+			return strings.TrimPrefix(l, IssueLabelPrefix)
+		}
+	}
+	return ""
+}
+
+// Diff compares spec with state. Declared items that are missing are
+// created and ones that differ are updated. Undeclared agents, queries,
+// rigs, and recurring issues are deleted only when prune is set.
+func Diff(spec *Spec, state *State, prune bool) *Plan {
+	p := &Plan{}
+	p.diffSettings(spec, state)
+	p.diffAgents(spec, state, prune)
+	p.diffPolicies(spec, state)
+	p.diffQueries(spec, state, prune)
+	p.diffRigs(spec, state, prune)
+	p.diffIssues(spec, state, prune)
+
+	sort.SliceStable(p.Changes, func(i, j int) bool {
+		a, b := p.Changes[i], p.Changes[j]
+		if a.Kind != b.Kind {
+			return slices.Index(kindOrder, a.Kind) < slices.Index(kindOrder, b.Kind)
+		}
+		return a.Name < b.Name
+	})
+	return p
+}
+
+func (p *Plan) add(kind, name, action, detail string) {
+	p.Changes = append(p.Changes, Change{Kind: kind, Name: name, Action: action, Detail: detail})
+}
+
+func (p *Plan) diffSettings(spec *Spec, state *State) {
+	if spec.Settings == nil {
+		return
+	}
+	cur := state.Settings
+	if want := spec.Settings.DefaultAgent; want != "" && want != cur.DefaultAgent {
+		p.add(KindSetting, "default_agent", ActionUpdate, fmt.Sprintf("%q → %q", cur.DefaultAgent, want))
+	}
+	if want := spec.Settings.Observers; want != nil && !sameSet(want, cur.Observers) {
+		p.add(KindSetting, "observers", ActionUpdate, fmt.Sprintf("[%s] → [%s]",
+			strings.Join(cur.Observers, ", "), strings.Join(want, ", ")))
+	}
+}
+
+func (p *Plan) diffAgents(spec *Spec, state *State, prune bool) {
+	cur := state.Settings.Agents
+	for name, a := range spec.Agents {
+		existing, ok := cur[name]
+		switch {
+		case !ok:
+			p.add(KindAgent, name, ActionCreate, a.Command)
+		case !reflect.DeepEqual(existing, a.runtimeConfig()):
+			p.add(KindAgent, name, ActionUpdate, a.Command)
+		}
+	}
+	if prune && spec.Agents != nil {
+		for name := range cur {
+			if _, ok := spec.Agents[name]; !ok {
+				p.add(KindAgent, name, ActionDelete, "")
+			}
+		}
+	}
+}
+
+func (p *Plan) diffPolicies(spec *Spec, state *State) {
+	if want := spec.Policies.IdlePark; want != nil && !reflect.DeepEqual(want, state.Daemon.IdlePark) {
+		action := ActionUpdate
+		if state.Daemon.IdlePark == nil {
+			action = ActionCreate
+		}
+		p.add(KindPolicy, "idle_park", action, fmt.Sprintf("enabled=%t after=%s", want.Enabled, want.Threshold()))
+	}
+	if want := spec.Policies.SyncDrift; want != nil && !reflect.DeepEqual(want, state.Daemon.SyncDrift) {
+		action := ActionUpdate
+		if state.Daemon.SyncDrift == nil {
+			action = ActionCreate
+		}
+		p.add(KindPolicy, "sync_drift", action, fmt.Sprintf("enabled=%t behind=%d", want.Enabled(), want.Threshold()))
+	}
+	if want := spec.Policies.Watchdog; want != nil && !reflect.DeepEqual(want, state.Daemon.Watchdog) {
+		action := ActionUpdate
+		if state.Daemon.Watchdog == nil {
+			action = ActionCreate
+		}
+		p.add(KindPolicy, "watchdog", action, fmt.Sprintf("enabled=%t grace=%s respawn=%t", !want.Disabled, want.GracePeriod(), want.Respawn))
+	}
+	if want := spec.Policies.Dispatch; want != nil && !reflect.DeepEqual(want, state.Daemon.Dispatch) {
+		action := ActionUpdate
+		if state.Daemon.Dispatch == nil {
+			action = ActionCreate
+		}
+		p.add(KindPolicy, "dispatch", action, fmt.Sprintf("enabled=%t wip=%d affinity=%d", want.Enabled, want.WIPLimit(), len(want.Affinity)))
+	}
+}
+
+func (p *Plan) diffQueries(spec *Spec, state *State, prune bool) {
+	cur := state.Settings.Queries
+	for name, q := range spec.Queries {
+		existing, ok := cur[name]
+		switch {
+		case !ok:
+			p.add(KindQuery, name, ActionCreate, q.Description)
+		case !reflect.DeepEqual(*existing, q):
+			p.add(KindQuery, name, ActionUpdate, q.Description)
+		}
+	}
+	if prune && spec.Queries != nil {
+		for name := range cur {
+			if _, ok := spec.Queries[name]; !ok {
+				p.add(KindQuery, name, ActionDelete, "")
+			}
+		}
+	}
+}
+
+func (p *Plan) diffRigs(spec *Spec, state *State, prune bool) {
+	declared := make(map[string]bool, len(spec.Rigs))
+	for _, r := range spec.Rigs {
+		declared[r.Name] = true
+		entry, ok := state.Rigs[r.Name]
+		if !ok {
+			p.add(KindRig, r.Name, ActionCreate, r.GitURL)
+			continue
+		}
+		if entry.GitURL != r.GitURL {
+			p.Warnings = append(p.Warnings, fmt.Sprintf(
+				"rig %s: git_url is %s, spec says %s (remove and re-add the rig to change it)", r.Name, entry.GitURL, r.GitURL))
+		}
+	}
+	if prune && spec.Rigs != nil {
+		for name := range state.Rigs {
+			if !declared[name] {
+				p.add(KindRig, name, ActionDelete, "")
+			}
+		}
+	}
+}
+
+func (p *Plan) diffIssues(spec *Spec, state *State, prune bool) {
+	declared := make(map[string]bool, len(spec.Issues))
+	for _, is := range spec.Issues {
+		declared[is.Key] = true
+		existing, ok := state.Issues[is.Key]
+		if !ok {
+			p.add(KindIssue, is.Key, ActionCreate, is.Title)
+			continue
+		}
+		if opts, changed := is.update(existing); changed {
+			p.add(KindIssue, is.Key, ActionUpdate, describeUpdate(existing.ID, opts))
+		}
+	}
+	if prune && spec.Issues != nil {
+		for key, issue := range state.Issues {
+			if !declared[key] {
+				p.add(KindIssue, key, ActionDelete, issue.ID)
+			}
+		}
+	}
+}
+
+// update returns the changes that make issue match the spec.
+func (is IssueSpec) update(issue *beads.Issue) (beads.UpdateOptions, bool) {
+	var opts beads.UpdateOptions
+	changed := false
+	if issue.Title != is.Title {
+		opts.Title = &is.Title
+		changed = true
+	}
+	if issue.Description != is.Description {
+		opts.Description = &is.Description
+		changed = true
+	}
+	if prio := is.priority(); issue.Priority != prio {
+		opts.Priority = &prio
+		changed = true
+	}
+	for _, l := range is.Labels {
+		if !slices.Contains(issue.Labels, l) {
+			opts.AddLabels = append(opts.AddLabels, l)
+			changed = true
+		}
+	}
+	return opts, changed
+}
+
+func (is IssueSpec) priority() int {
+	if is.Priority == nil {
+		return beads.PriorityMedium
+	}
+	return *is.Priority
+}
+
+func describeUpdate(id string, opts beads.UpdateOptions) string {
+	var fields []string
+	if opts.Title != nil {
+		fields = append(fields, "title")
+	}
+	if opts.Description != nil {
+		fields = append(fields, "description")
+	}
+	if opts.Priority != nil {
+		fields = append(fields, "priority")
+	}
+	if len(opts.AddLabels) > 0 {
+		fields = append(fields, "labels")
+	}
+	return id + ": " + strings.Join(fields, ", ")
+}
+
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,156 @@
+// Package townspec reconciles a town with a declarative spec.
+//
+// A spec is a TOML file describing the rigs, agent presets, daemon
+// policies, recurring issues, and saved queries a town should have. Diff
+// compares it with the town's current state and produces a Plan; Apply
+// carries the plan out. Sections left out of the spec are not managed:
+// nothing in them is changed or removed.
+package townspec
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/BurntSushi/toml"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Spec is the declared state of a town.
+type Spec struct {
+	Settings *SettingsSpec                `toml:"settings"`
+	Agents   map[string]AgentSpec         `toml:"agents"`
+	Policies PoliciesSpec                 `toml:"policies"`
+	Queries  map[string]config.SavedQuery `toml:"queries"`
+	Rigs     []RigSpec                    `toml:"rig"`
+	Issues   []IssueSpec                  `toml:"issue"`
+}
+
+// SettingsSpec declares town settings. Empty fields are left alone.
+type SettingsSpec struct {
+	DefaultAgent string   `toml:"default_agent"`
+	Observers    []string `toml:"observers"` // replaces the list when set
+}
+
+// AgentSpec declares an agent preset (see config.RuntimeConfig).
+type AgentSpec struct {
+	Command       string   `toml:"command"`
+	Args          []string `toml:"args"`
+	InitialPrompt string   `toml:"initial_prompt"`
+}
+
+// PoliciesSpec declares daemon policies written to mayor/daemon.json.
+type PoliciesSpec struct {
+	IdlePark  *config.IdleParkConfig  `toml:"idle_park"`
+	SyncDrift *config.SyncDriftConfig `toml:"sync_drift"`
+	Watchdog  *config.WatchdogConfig  `toml:"watchdog"`
+	Dispatch  *config.DispatchConfig  `toml:"dispatch"`
+}
+
+// RigSpec declares a rig. Rigs are only added or removed; an existing
+// rig's remote is never rewritten.
+type RigSpec struct {
+	Name   string `toml:"name"`
+	GitURL string `toml:"git_url"`
+	Prefix string `toml:"prefix"`
+	Branch string `toml:"branch"`
+}
+
+// IssueSpec declares a recurring issue in the town beads. Apply keeps
+// exactly one open issue per key: it is created when missing (including
+// after the previous one was closed) and updated to match the spec.
+type IssueSpec struct {
+	Key         string   `toml:"key"`
+	Title       string   `toml:"title"`
+	Type        string   `toml:"type"`
+	Priority    *int     `toml:"priority"`
+	Description string   `toml:"description"`
+	Labels      []string `toml:"labels"`
+}
+
+var keyPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Load reads and validates a spec file.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is user-chosen
+	if err != nil {
+		return nil, err
+	}
+	spec, err := Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes and validates a spec. Unknown keys are rejected so typos
+// don't silently go unmanaged.
+func Parse(data string) (*Spec, error) {
+	var spec Spec
+	md, err := toml.Decode(data, &spec)
+	if err != nil {
+		return nil, err
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("unknown key %q", undecoded[0].String())
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks names, required fields, and duplicates.
+func (s *Spec) Validate() error {
+	rigs := make(map[string]bool)
+	for _, r := range s.Rigs {
+		if !keyPattern.MatchString(r.Name) {
+			return fmt.Errorf("rig: invalid name %q", r.Name)
+		}
+		if r.GitURL == "" {
+			return fmt.Errorf("rig %s: git_url is required", r.Name)
+		}
+		if rigs[r.Name] {
+			return fmt.Errorf("rig %s: declared twice", r.Name)
+		}
+		rigs[r.Name] = true
+	}
+
+	keys := make(map[string]bool)
+	for _, is := range s.Issues {
+		if !keyPattern.MatchString(is.Key) {
+			return fmt.Errorf("issue: invalid key %q", is.Key)
+		}
+		if is.Title == "" {
+			return fmt.Errorf("issue %s: title is required", is.Key)
+		}
+		if is.Priority != nil && (*is.Priority < 0 || *is.Priority > 4) {
+			return fmt.Errorf("issue %s: priority must be 0-4", is.Key)
+		}
+		if keys[is.Key] {
+			return fmt.Errorf("issue %s: declared twice", is.Key)
+		}
+		keys[is.Key] = true
+	}
+
+	for name, a := range s.Agents {
+		if a.Command == "" {
+			return fmt.Errorf("agent %s: command is required", name)
+		}
+	}
+	for name := range s.Queries {
+		if !keyPattern.MatchString(name) {
+			return fmt.Errorf("query: invalid name %q", name)
+		}
+	}
+	return nil
+}
+
+// runtimeConfig converts the spec to the settings representation.
+func (a AgentSpec) runtimeConfig() *config.RuntimeConfig {
+	return &config.RuntimeConfig{
+		Command:       a.Command,
+		Args:          a.Args,
+		InitialPrompt: a.InitialPrompt,
+	}
+}
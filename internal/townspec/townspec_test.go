@@ -0,0 +1,199 @@
+package townspec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+const testSpec = `
+[settings]
+default_agent = "gemini"
+observers = ["auditor"]
+
+[agents.gemini]
+command = "gemini"
+args = ["--yolo"]
+
+[policies.idle_park]
+enabled = true
+after = "45m"
+
+[policies.watchdog]
+grace = "10m"
+respawn = true
+
+[policies.dispatch]
+enabled = true
+wip = 2
+affinity = { "gastown/Toast" = ["bug", "chore"] }
+
+[queries.triage]
+description = "Unassigned bugs"
+type = "bug"
+status = "open"
+
+[[rig]]
+name = "gastown"
+git_url = "https://example.com/gastown.git"
+prefix = "gt"
+
+[[issue]]
+key = "weekly-deps"
+title = "Update dependencies"
+priority = 3
+`
+
+func TestParseRejectsUnknownKeys(t *testing.T) {
+	if _, err := Parse("[settings]\ndefault_agnet = \"claude\"\n"); err == nil || !strings.Contains(err.Error(), "default_agnet") {
+		t.Fatalf("Parse() error = %v, want unknown key", err)
+	}
+	if _, err := Parse("[[rig]]\nname = \"x\"\n"); err == nil {
+		t.Fatal("Parse() accepted a rig without git_url")
+	}
+}
+
+func TestDiffAndApply(t *testing.T) {
+	spec, err := Parse(testSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	townRoot := t.TempDir()
+	fake := beadstest.NewFakeClient()
+
+	state, err := LoadState(townRoot, fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := Diff(spec, state, false)
+
+	var got []string
+	for _, c := range plan.Changes {
+		got = append(got, c.Action+" "+c.Kind+" "+c.Name)
+	}
+	want := []string{
+		"update setting default_agent",
+		"update setting observers",
+		"create agent gemini",
+		"create policy dispatch",
+		"create policy idle_park",
+		"create policy watchdog",
+		"create query triage",
+		"create rig gastown",
+		"create issue weekly-deps",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("plan:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	var added []string
+	target := Target{
+		TownRoot: townRoot,
+		Beads:    fake,
+		AddRig: func(r RigSpec) error {
+			added = append(added, r.Name)
+			return writeRigs(townRoot, r.Name, r.GitURL)
+		},
+	}
+	if err := Apply(spec, state, plan, target); err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 1 || added[0] != "gastown" {
+		t.Errorf("added rigs = %v", added)
+	}
+
+	// A second run finds nothing to do.
+	state, err = LoadState(townRoot, fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan := Diff(spec, state, false); !plan.Empty() {
+		t.Fatalf("second plan not empty: %v", plan.Changes)
+	}
+	if state.Settings.Queries["triage"].Type != "bug" {
+		t.Errorf("query not saved: %+v", state.Settings.Queries)
+	}
+	if state.Daemon.IdlePark == nil || !state.Daemon.IdlePark.Enabled {
+		t.Errorf("idle_park not saved: %+v", state.Daemon.IdlePark)
+	}
+	if state.Daemon.Watchdog == nil || !state.Daemon.Watchdog.Respawn {
+		t.Errorf("watchdog not saved: %+v", state.Daemon.Watchdog)
+	}
+	if d := state.Daemon.Dispatch; d == nil || d.WIP != 2 || len(d.AffinityFor("gastown", "Toast")) != 2 {
+		t.Errorf("dispatch not saved: %+v", d)
+	}
+
+	// Closing the recurring issue makes the next apply open another.
+	issue := state.Issues["weekly-deps"]
+	if issue == nil || issue.Priority != 3 {
+		t.Fatalf("recurring issue = %+v", issue)
+	}
+	if err := fake.Close(issue.ID); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = LoadState(townRoot, fake)
+	plan = Diff(spec, state, false)
+	if len(plan.Changes) != 1 || plan.Changes[0].Action != ActionCreate || plan.Changes[0].Kind != KindIssue {
+		t.Fatalf("plan after close = %v", plan.Changes)
+	}
+}
+
+func TestDiffPrune(t *testing.T) {
+	spec, err := Parse(`
+[[rig]]
+name = "keep"
+git_url = "https://example.com/other.git"
+
+[[issue]]
+key = "a"
+title = "A"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := &State{
+		Rigs: map[string]config.RigEntry{
+			"keep": {GitURL: "https://example.com/keep.git"},
+			"old":  {GitURL: "https://example.com/old.git"},
+		},
+		Settings: &config.TownSettings{Agents: map[string]*config.RuntimeConfig{"custom": {Command: "x"}}},
+		Daemon:   config.NewDaemonPatrolConfig(),
+		Issues: map[string]*beads.Issue{
+			"a":    {ID: "gt-1", Title: "A", Priority: beads.PriorityMedium},
+			"gone": {ID: "gt-2", Title: "Gone"},
+		},
+	}
+
+	if plan := Diff(spec, state, false); !plan.Empty() {
+		t.Fatalf("plan without prune = %v", plan.Changes)
+	}
+
+	plan := Diff(spec, state, true)
+	var got []string
+	for _, c := range plan.Changes {
+		got = append(got, c.String())
+	}
+	want := []string{"- rig old", "- issue gone (gt-2)"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("prune plan:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(plan.Warnings) != 1 || !strings.Contains(plan.Warnings[0], "rig keep") {
+		t.Errorf("warnings = %v", plan.Warnings)
+	}
+}
+
+// writeRigs registers a rig the way gt rig add would.
+func writeRigs(townRoot, name, gitURL string) error {
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		return err
+	}
+	return config.SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), &config.RigsConfig{
+		Version: 1,
+		Rigs:    map[string]config.RigEntry{name: {GitURL: gitURL}},
+	})
+}
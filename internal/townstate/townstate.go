@@ -0,0 +1,97 @@
+// Package townstate reads a point-in-time view of a town: its rigs, their
+// polecats and hooked beads, and each rig's ready/blocked queue depths.
+// gt dashboard --tui and gt serve's status endpoint show it.
+package townstate
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// Polecat is one polecat and the bead on its hook.
+type Polecat struct {
+	Name      string `json:"name"`
+	Hook      string `json:"hook,omitempty"` // Hooked bead ID, empty if idle
+	HookTitle string `json:"hook_title,omitempty"`
+}
+
+// Rig is one rig's workers and queue depths.
+type Rig struct {
+	Name     string    `json:"name"`
+	Polecats []Polecat `json:"polecats"`
+	Ready    int       `json:"ready"`
+	Blocked  int       `json:"blocked"`
+	Error    string    `json:"error,omitempty"` // Beads couldn't be read; counts are zero
+}
+
+// Snapshot is a town's state at TakenAt.
+type Snapshot struct {
+	TakenAt time.Time `json:"taken_at"`
+	Rigs    []Rig     `json:"rigs"`
+}
+
+// Load reads every rig's polecats and beads. A rig whose beads can't be
+// read is still listed, with Error set. Hooked beads hidden at scope (see
+// beads.VisibleTo) are listed without their titles; an empty scope shows
+// every title.
+func Load(townRoot, scope string) (*Snapshot, error) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+	rigs, err := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot)).DiscoverRigs()
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &Snapshot{TakenAt: time.Now(), Rigs: []Rig{}}
+	for _, r := range rigs {
+		bs, err := beads.New(r.BeadsPath()).Snapshot()
+		snap.Rigs = append(snap.Rigs, rigState(r.Name, r.Polecats, bs, err, scope))
+	}
+	sort.Slice(snap.Rigs, func(i, j int) bool { return snap.Rigs[i].Name < snap.Rigs[j].Name })
+	return snap, nil
+}
+
+// rigState builds a rig's state from its polecat names and beads snapshot.
+func rigState(name string, polecats []string, bs *beads.Snapshot, err error, scope string) Rig {
+	rs := Rig{Name: name, Polecats: []Polecat{}}
+	hooks := make(map[string]*beads.Issue)
+	if err != nil {
+		rs.Error = err.Error()
+	} else if bs != nil {
+		rs.Ready = len(bs.Ready())
+		rs.Blocked = len(bs.Blocked())
+		for _, issue := range bs.Issues() {
+			if issue.Status != beads.StatusHooked && issue.Status != "in_progress" {
+				continue
+			}
+			// Assignees are rig/name or rig/polecats/name
+			a := strings.Replace(issue.Assignee, "/polecats/", "/", 1)
+			if cur, ok := hooks[a]; !ok || cur.Status != beads.StatusHooked {
+				hooks[a] = issue
+			}
+		}
+	}
+
+	names := append([]string(nil), polecats...)
+	sort.Strings(names)
+	for _, p := range names {
+		ps := Polecat{Name: p}
+		if issue, ok := hooks[name+"/"+p]; ok {
+			ps.Hook = issue.ID
+			if beads.VisibleTo(issue, scope) {
+				ps.HookTitle = issue.Title
+			}
+		}
+		rs.Polecats = append(rs.Polecats, ps)
+	}
+	return rs
+}
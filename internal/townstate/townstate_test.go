@@ -0,0 +1,63 @@
+package townstate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestRigState(t *testing.T) {
+	bs := beads.NewSnapshot([]*beads.Issue{
+		{ID: "gt-1", Title: "Fix login", Status: beads.StatusHooked, Assignee: "gastown/polecats/nux"},
+		{ID: "gt-2", Title: "Docs", Status: "in_progress", Assignee: "gastown/toast"},
+		{ID: "gt-3", Status: "open"},
+		{ID: "gt-4", Status: "open", DependsOn: []string{"gt-3"}},
+		{ID: "gt-5", Status: "closed", Assignee: "gastown/slit"},
+	}, time.Now())
+
+	rs := rigState("gastown", []string{"toast", "slit", "nux"}, bs, nil, "")
+
+	if rs.Ready != 1 || rs.Blocked != 1 {
+		t.Errorf("ready/blocked = %d/%d, want 1/1", rs.Ready, rs.Blocked)
+	}
+	want := []Polecat{
+		{Name: "nux", Hook: "gt-1", HookTitle: "Fix login"},
+		{Name: "slit"},
+		{Name: "toast", Hook: "gt-2", HookTitle: "Docs"},
+	}
+	if len(rs.Polecats) != len(want) {
+		t.Fatalf("polecats = %+v, want %+v", rs.Polecats, want)
+	}
+	for i := range want {
+		if rs.Polecats[i] != want[i] {
+			t.Errorf("polecat %d = %+v, want %+v", i, rs.Polecats[i], want[i])
+		}
+	}
+
+	// A rig whose beads can't be read still lists its polecats
+	rs = rigState("beads-down", []string{"nux"}, nil, errors.New("bd: not found"), "")
+	if rs.Error == "" || len(rs.Polecats) != 1 || rs.Polecats[0].Hook != "" {
+		t.Errorf("unreadable rig = %+v", rs)
+	}
+}
+
+func TestRigStateScope(t *testing.T) {
+	bs := beads.NewSnapshot([]*beads.Issue{
+		{ID: "gt-1", Title: "Fix login", Status: beads.StatusHooked, Assignee: "gastown/polecats/nux"},
+		{ID: "gt-2", Title: "Payroll audit", Status: beads.StatusHooked, Assignee: "gastown/polecats/toast",
+			Labels: []string{beads.VisibilityLabel(beads.VisibilityPrivate)}},
+	}, time.Now())
+
+	rs := rigState("gastown", []string{"nux", "toast"}, bs, nil, beads.VisibilityPublic)
+	want := []Polecat{
+		{Name: "nux", Hook: "gt-1", HookTitle: "Fix login"},
+		{Name: "toast", Hook: "gt-2"}, // Title hidden; still shown busy
+	}
+	for i := range want {
+		if rs.Polecats[i] != want[i] {
+			t.Errorf("polecat %d = %+v, want %+v", i, rs.Polecats[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Package transcript records agent sessions. Each agent session's pane
+// output is piped to a transcript file under the town's runtime directory,
+// and an index links transcripts to the beads worked in them, so a bad
+// merge can be traced back to the session that produced it.
+//
+// Pane output passes through Record on its way to the file, which redacts
+// secrets and caps the file at MaxBytes. Start prunes transcripts older
+// than Retention.
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/flock"
+	"github.com/steveyegge/gastown/internal/redact"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Entry links a transcript to the bead worked in it.
+type Entry struct {
+	Timestamp time.Time `json:"ts"`
+	Bead      string    `json:"bead"`
+	Actor     string    `json:"actor,omitempty"`
+	Session   string    `json:"session,omitempty"`
+	Path      string    `json:"path"`
+}
+
+// Dir returns the directory holding a town's transcripts, one
+// subdirectory per session.
+func Dir(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "transcripts")
+}
+
+// IndexPath returns the location of the bead-to-transcript index.
+func IndexPath(townRoot string) string {
+	return filepath.Join(Dir(townRoot), "index.jsonl")
+}
+
+// MaxBytes caps a transcript file. Output past it is dropped, after a line
+// saying so.
+const MaxBytes = 20 << 20
+
+// Retention is how long transcripts are kept. Start removes older ones.
+const Retention = 30 * 24 * time.Hour
+
+// Recorder is the command tmux pipes pane output through. It is called
+// with the town root and transcript path, and must run Record.
+var Recorder = "gt transcript record"
+
+// truncatedNote ends a transcript that reached MaxBytes.
+const truncatedNote = "\n[gt: transcript truncated at size limit; later output not recorded]\n"
+
+// Start begins recording a session into a new transcript file and returns
+// its path. Each start gets its own file, so a restarted session doesn't
+// overwrite the transcript of the one before it.
+func Start(t *tmux.Tmux, townRoot, session string) (string, error) {
+	prune(townRoot, time.Now().Add(-Retention))
+	dir := filepath.Join(Dir(townRoot), session)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, time.Now().UTC().Format("20060102T150405Z")+".log")
+	// Create the file up front so Current finds it before any output arrives
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	_ = f.Close()
+	if err := t.PipePane(session, Recorder+" "+shellQuote(townRoot)+" "+shellQuote(path)); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Record appends pane output read from r to the transcript at path until
+// r is exhausted. Secrets are redacted line by line with the town's rules
+// and counted under redact.SourceTranscript. Once the file reaches
+// MaxBytes the rest of r is read and dropped, so the pane never blocks.
+func Record(townRoot, path string, r io.Reader) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var size int64
+	if info, err := f.Stat(); err == nil {
+		size = info.Size()
+	}
+
+	redactor := redact.ForTown(townRoot)
+	in := bufio.NewReader(r)
+	full := size >= MaxBytes
+	for {
+		line, readErr := in.ReadString('\n')
+		if line != "" && !full {
+			clean, n := redactor.String(line)
+			_ = redact.Record(townRoot, redact.SourceTranscript, n)
+			if size+int64(len(clean)) > MaxBytes {
+				clean, full = truncatedNote, true
+			}
+			if _, err := f.WriteString(clean); err != nil {
+				return err
+			}
+			size += int64(len(clean))
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// prune removes transcripts last written before cutoff, and session
+// directories left empty. Failures are ignored: a transcript that
+// outlives its retention is harmless.
+func prune(townRoot string, cutoff time.Time) {
+	sessions, _ := os.ReadDir(Dir(townRoot))
+	for _, s := range sessions {
+		if !s.IsDir() {
+			continue
+		}
+		dir := filepath.Join(Dir(townRoot), s.Name())
+		files, _ := os.ReadDir(dir)
+		kept := len(files)
+		for _, file := range files {
+			info, err := file.Info()
+			if err != nil || file.IsDir() || filepath.Ext(file.Name()) != ".log" || !info.ModTime().Before(cutoff) {
+				continue
+			}
+			if os.Remove(filepath.Join(dir, file.Name())) == nil {
+				kept--
+			}
+		}
+		if kept == 0 {
+			_ = os.Remove(dir)
+		}
+	}
+}
+
+// Current returns the newest transcript of a session ("" if it has none).
+func Current(townRoot, session string) string {
+	if session == "" {
+		return ""
+	}
+	matches, _ := filepath.Glob(filepath.Join(Dir(townRoot), session, "*.log"))
+	if len(matches) == 0 {
+		return ""
+	}
+	sort.Strings(matches)
+	return matches[len(matches)-1]
+}
+
+// Link records that a bead was worked in a transcript.
+func Link(townRoot string, e Entry) error {
+	if e.Bead == "" || e.Path == "" {
+		return errors.New("transcript link needs a bead and a path")
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(Dir(townRoot), 0700); err != nil {
+		return err
+	}
+	return flock.AppendFile(IndexPath(townRoot), append(line, '\n'), 0600)
+}
+
+// ForBead returns the transcripts linked to a bead, oldest first, one
+// entry per transcript (its latest link).
+func ForBead(townRoot, bead string) ([]Entry, error) {
+	f, err := os.Open(IndexPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []Entry
+	at := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Bead != bead {
+			continue
+		}
+		if i, ok := at[e.Path]; ok {
+			out[i] = e
+			continue
+		}
+		at[e.Path] = len(out)
+		out = append(out, e)
+	}
+	return out, scanner.Err()
+}
+
+// ansiPattern matches terminal escape sequences: CSI sequences (colors,
+// cursor movement), OSC sequences (titles, hyperlinks) and two-byte escapes.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// Clean strips terminal escape sequences and carriage returns from raw
+// pane output, leaving readable text.
+func Clean(raw []byte) []byte {
+	out := ansiPattern.ReplaceAll(raw, nil)
+	return []byte(strings.ReplaceAll(string(out), "\r", ""))
+}
+
+// shellQuote single-quotes s for the shell tmux runs the pipe command in.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,208 @@
+package transcript
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/redact"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// TestMain lets the test binary stand in for "gt transcript record" when
+// TestStart pipes a pane through it.
+func TestMain(m *testing.M) {
+	if os.Getenv("GT_TEST_TRANSCRIPT_RECORD") == "1" {
+		if err := Record(os.Args[1], os.Args[2], os.Stdin); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	Recorder = "GT_TEST_TRANSCRIPT_RECORD=1 " + shellQuote(os.Args[0])
+	os.Exit(m.Run())
+}
+
+func TestLinkForBead(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if got, err := ForBead(townRoot, "gt-1"); err != nil || len(got) != 0 {
+		t.Fatalf("empty index = %v, %v", got, err)
+	}
+	for _, e := range []Entry{
+		{Bead: "gt-1", Session: "gt-gastown-Toast", Path: "/t/a.log"},
+		{Bead: "gt-2", Session: "gt-gastown-Ace", Path: "/t/b.log"},
+		{Bead: "gt-1", Session: "gt-gastown-Toast", Path: "/t/c.log"},
+		{Bead: "gt-1", Actor: "gastown/polecats/Toast", Session: "gt-gastown-Toast", Path: "/t/a.log"},
+	} {
+		if err := Link(townRoot, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Link(townRoot, Entry{Bead: "gt-1"}); err == nil {
+		t.Error("link without a path accepted")
+	}
+
+	got, err := ForBead(townRoot, "gt-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Path != "/t/a.log" || got[1].Path != "/t/c.log" {
+		t.Fatalf("transcripts = %+v", got)
+	}
+	// A relink updates the entry in place
+	if got[0].Actor != "gastown/polecats/Toast" || got[0].Timestamp.IsZero() {
+		t.Errorf("relinked entry = %+v", got[0])
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	townRoot := t.TempDir()
+	dir := filepath.Join(Dir(townRoot), "gt-gastown-Toast")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"20261015T090000Z.log", "20261015T120000Z.log", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := Current(townRoot, "gt-gastown-Toast"); got != filepath.Join(dir, "20261015T120000Z.log") {
+		t.Errorf("Current = %q", got)
+	}
+	if got := Current(townRoot, "gt-gastown-Ace"); got != "" {
+		t.Errorf("Current of unrecorded session = %q", got)
+	}
+}
+
+func TestClean(t *testing.T) {
+	raw := "\x1b]0;claude\x07\x1b[1;32m✓\x1b[0m tests pass\r\n\x1b[?25lnext\x1b[2K line\r\n"
+	if got := string(Clean([]byte(raw))); got != "✓ tests pass\nnext line\n" {
+		t.Errorf("Clean = %q", got)
+	}
+}
+
+func TestStart(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := tmux.NewTmux()
+	session := "gt-test-transcript-" + time.Now().Format("150405")
+	if err := tm.NewSession(session, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(session) }()
+
+	townRoot := t.TempDir()
+	path, err := Start(tm, townRoot, session)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if Current(townRoot, session) != path {
+		t.Errorf("Current = %q, want %q", Current(townRoot, session), path)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("transcript mode = %v, %v; want 0600", info, err)
+	}
+	if err := tm.SendKeys(session, "echo transcript-marker password=hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(Clean(data)), "transcript-marker password=[REDACTED:keyword]") {
+			if strings.Contains(string(data), "hunter2") {
+				t.Errorf("secret recorded: %q", data)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("transcript never captured output: %q", data)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	townRoot := t.TempDir()
+	path := filepath.Join(townRoot, "t.log")
+	if err := Record(townRoot, path, strings.NewReader("ok\ntoken password=hunter2\ntail")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ok\ntoken password=[REDACTED:keyword]\ntail"; string(data) != want {
+		t.Errorf("transcript = %q, want %q", data, want)
+	}
+	if counts, _ := redact.LoadCounts(townRoot); counts[redact.SourceTranscript] != 1 {
+		t.Errorf("redaction counts = %v", counts)
+	}
+}
+
+func TestRecordSizeLimit(t *testing.T) {
+	townRoot := t.TempDir()
+	path := filepath.Join(townRoot, "t.log")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, MaxBytes-4); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(townRoot, path, strings.NewReader("abc\nlonger line\nmore\n")); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tail := make([]byte, 4+len(truncatedNote))
+	if _, err := f.ReadAt(tail, MaxBytes-4); err != nil {
+		t.Fatal(err)
+	}
+	if want := "abc\n" + truncatedNote; string(tail) != want {
+		t.Errorf("tail = %q, want %q", tail, want)
+	}
+	if info, _ := f.Stat(); info.Size() != MaxBytes+int64(len(truncatedNote)) {
+		t.Errorf("size = %d, want output past the limit dropped", info.Size())
+	}
+}
+
+func TestPrune(t *testing.T) {
+	townRoot := t.TempDir()
+	old := time.Now().Add(-2 * Retention)
+	write := func(session, name string, mtime time.Time) string {
+		dir := filepath.Join(Dir(townRoot), session)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	gone := write("gt-gastown-Ace", "a.log", old)
+	stale := write("gt-gastown-Toast", "a.log", old)
+	fresh := write("gt-gastown-Toast", "b.log", time.Now())
+
+	prune(townRoot, time.Now().Add(-Retention))
+
+	for _, path := range []string{gone, stale, filepath.Dir(gone)} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s not pruned", path)
+		}
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Errorf("recent transcript pruned: %v", err)
+	}
+}
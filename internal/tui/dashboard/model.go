@@ -0,0 +1,251 @@
+// Package dashboard is the terminal UI behind gt dashboard --tui: rigs,
+// their polecats and hooked beads, ready/blocked queue depths, and the
+// live feed, side by side.
+package dashboard
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steveyegge/gastown/internal/events"
+	gtfeed "github.com/steveyegge/gastown/internal/feed"
+	"github.com/steveyegge/gastown/internal/townstate"
+)
+
+const (
+	// pollInterval is how often the model checks whether to reload.
+	pollInterval = 2 * time.Second
+
+	// refreshInterval is the longest a snapshot is shown before it is
+	// reloaded, even with no events.
+	refreshInterval = 30 * time.Second
+
+	// feedBacklog is how far back the feed pane starts.
+	feedBacklog = time.Hour
+
+	// maxFeedLines caps the feed pane's history.
+	maxFeedLines = 200
+)
+
+// FeedLine is one rendered event in the feed pane.
+type FeedLine struct {
+	Time     time.Time
+	Severity string
+	Text     string
+}
+
+// Model is the bubbletea model for the dashboard TUI.
+type Model struct {
+	townRoot  string
+	snap      *townstate.Snapshot
+	err       error
+	feed      []FeedLine
+	events    <-chan events.Event
+	formatter *gtfeed.Formatter
+
+	// Snapshot reloads are driven by the events tail: any feed event
+	// marks the snapshot dirty, and the next poll reloads it.
+	loading  bool
+	dirty    bool
+	loadedAt time.Time
+
+	// UI state
+	rigOffset int // First rig line shown
+	keys      KeyMap
+	help      help.Model
+	showHelp  bool
+	width     int
+	height    int
+}
+
+// New creates a dashboard model for a town. evts is the town's events
+// tail (see events.TailTown); nil shows no live feed.
+func New(townRoot string, evts <-chan events.Event) Model {
+	formatter, _ := gtfeed.LoadFormatter(townRoot) // Falls back to the default templates
+	return Model{
+		townRoot:  townRoot,
+		events:    evts,
+		formatter: formatter,
+		loading:   true,
+		keys:      DefaultKeyMap(),
+		help:      help.New(),
+	}
+}
+
+// snapshotMsg is the result of loading a snapshot.
+type snapshotMsg struct {
+	snap *townstate.Snapshot
+	err  error
+}
+
+// backlogMsg carries the feed events logged before the dashboard started.
+type backlogMsg []events.Event
+
+// eventMsg is a feed event from the tail.
+type eventMsg events.Event
+
+// tickMsg is sent every pollInterval.
+type tickMsg time.Time
+
+// Init initializes the model.
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(
+		m.loadSnapshot,
+		m.loadBacklog,
+		m.listenForEvents(),
+		tick(),
+		tea.SetWindowTitle("GT Dashboard"),
+	)
+}
+
+// loadSnapshot reads the town's state.
+func (m Model) loadSnapshot() tea.Msg {
+	snap, err := townstate.Load(m.townRoot, "") // The operator's own view
+	return snapshotMsg{snap: snap, err: err}
+}
+
+// loadBacklog reads recent feed events so the pane isn't empty at start.
+func (m Model) loadBacklog() tea.Msg {
+	evts, err := events.ReadTownSince(m.townRoot, time.Now().Add(-feedBacklog), events.Filter{FeedOnly: true})
+	if err != nil {
+		return backlogMsg(nil)
+	}
+	if len(evts) > maxFeedLines {
+		evts = evts[len(evts)-maxFeedLines:]
+	}
+	return backlogMsg(evts)
+}
+
+// listenForEvents waits for the next event from the tail.
+func (m Model) listenForEvents() tea.Cmd {
+	if m.events == nil {
+		return nil
+	}
+	ch := m.events
+	return func() tea.Msg {
+		e, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return eventMsg(e)
+	}
+}
+
+// tick returns a command for the next poll.
+func tick() tea.Cmd {
+	return tea.Tick(pollInterval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// Update handles messages.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		return m, nil
+
+	case snapshotMsg:
+		m.loading = false
+		m.loadedAt = time.Now()
+		m.err = msg.err
+		if msg.err == nil {
+			m.snap = msg.snap
+		}
+		return m, nil
+
+	case backlogMsg:
+		// Keep anything the tail delivered while the backlog was read
+		live := m.feed
+		m.feed = nil
+		for _, e := range msg {
+			m.addEvent(e)
+		}
+		m.feed = append(m.feed, live...)
+		m.trimFeed()
+		return m, nil
+
+	case eventMsg:
+		e := events.Event(msg)
+		if (events.Filter{FeedOnly: true}).Match(e) {
+			m.addEvent(e)
+			m.dirty = true
+		}
+		return m, m.listenForEvents()
+
+	case tickMsg:
+		cmds := []tea.Cmd{tick()}
+		if m.needsReload(time.Time(msg)) {
+			m.loading = true
+			m.dirty = false
+			cmds = append(cmds, m.loadSnapshot)
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = !m.showHelp
+			return m, nil
+
+		case key.Matches(msg, m.keys.Refresh):
+			if !m.loading {
+				m.loading = true
+				m.dirty = false
+				return m, m.loadSnapshot
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Up):
+			if m.rigOffset > 0 {
+				m.rigOffset--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Down):
+			if m.rigOffset < len(m.rigLines())-1 {
+				m.rigOffset++
+			}
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// needsReload reports whether the snapshot should be reloaded at now.
+func (m Model) needsReload(now time.Time) bool {
+	if m.loading {
+		return false
+	}
+	return m.dirty || now.Sub(m.loadedAt) >= refreshInterval
+}
+
+// addEvent renders an event onto the end of the feed.
+func (m *Model) addEvent(e events.Event) {
+	ts, _ := time.Parse(time.RFC3339, e.Timestamp)
+	m.feed = append(m.feed, FeedLine{
+		Time:     ts,
+		Severity: e.Level(),
+		Text:     m.formatter.Format(e),
+	})
+	m.trimFeed()
+}
+
+func (m *Model) trimFeed() {
+	if len(m.feed) > maxFeedLines {
+		m.feed = m.feed[len(m.feed)-maxFeedLines:]
+	}
+}
+
+// View renders the model.
+func (m Model) View() string {
+	return m.renderView()
+}
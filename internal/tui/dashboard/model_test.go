@@ -0,0 +1,36 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestModelFeedEvents(t *testing.T) {
+	m := New(t.TempDir(), nil)
+	m.loading = false
+	m.loadedAt = time.Now()
+
+	audit := events.Event{Type: events.TypeReconcile, Actor: "daemon", Visibility: events.VisibilityAudit}
+	next, _ := m.Update(eventMsg(audit))
+	m = next.(Model)
+	if len(m.feed) != 0 || m.dirty {
+		t.Fatalf("audit-only event reached the feed pane")
+	}
+	if m.needsReload(time.Now()) {
+		t.Error("reload scheduled with nothing new")
+	}
+
+	hook := events.Event{Type: events.TypeHook, Actor: "gastown/polecats/nux",
+		Payload: events.HookPayload("gt-1"), Visibility: events.VisibilityFeed}
+	next, _ = m.Update(eventMsg(hook))
+	m = next.(Model)
+	if len(m.feed) != 1 || !strings.Contains(m.feed[0].Text, "gt-1") {
+		t.Fatalf("feed = %+v, want the hook event", m.feed)
+	}
+	if !m.needsReload(time.Now()) {
+		t.Error("feed event didn't schedule a snapshot reload")
+	}
+}
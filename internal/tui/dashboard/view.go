@@ -0,0 +1,214 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Styles for the dashboard TUI
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("12"))
+
+	paneStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("8")).
+			Padding(0, 1)
+
+	rigStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("15"))
+
+	hookedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11")) // yellow
+
+	idleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")) // gray
+
+	blockedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")) // red
+
+	warnStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11")) // yellow
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")) // red
+)
+
+// renderView renders the entire view.
+func (m Model) renderView() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Gas Town"))
+	b.WriteString(idleStyle.Render("  " + m.status()))
+	b.WriteString("\n")
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+
+	// Pane content height: the screen less title, error, borders, and help
+	bodyHeight := m.height - 4
+	if m.err != nil {
+		bodyHeight--
+	}
+	if m.showHelp {
+		bodyHeight -= 3
+	}
+	if bodyHeight < 3 {
+		bodyHeight = 3
+	}
+
+	// Rigs on the left, feed on the right. Borders and padding take 4
+	// columns each; long lines wrap, and MaxHeight clips the overflow.
+	leftWidth := m.width*2/5 - 4
+	rightWidth := m.width - m.width*2/5 - 4
+	if m.width == 0 {
+		leftWidth, rightWidth = 40, 60
+	}
+
+	rigs := paneStyle.Width(leftWidth).Height(bodyHeight).MaxHeight(bodyHeight + 2).
+		Render(strings.Join(window(m.rigLines(), m.rigOffset, bodyHeight), "\n"))
+	feed := paneStyle.Width(rightWidth).Height(bodyHeight).MaxHeight(bodyHeight + 2).
+		Render(strings.Join(m.feedLines(rightWidth, bodyHeight), "\n"))
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, rigs, feed))
+	b.WriteString("\n")
+
+	if m.showHelp {
+		b.WriteString(m.help.FullHelpView(m.keys.FullHelp()))
+	} else {
+		b.WriteString(helpStyle.Render(m.help.ShortHelpView(m.keys.ShortHelp())))
+	}
+
+	return b.String()
+}
+
+// status summarizes the snapshot for the title line.
+func (m Model) status() string {
+	if m.snap == nil {
+		return "loading..."
+	}
+	working, idle := 0, 0
+	for _, r := range m.snap.Rigs {
+		for _, p := range r.Polecats {
+			if p.Hook != "" {
+				working++
+			} else {
+				idle++
+			}
+		}
+	}
+	s := fmt.Sprintf("%d rigs · %d working · %d idle · updated %s",
+		len(m.snap.Rigs), working, idle, m.snap.TakenAt.Format("15:04:05"))
+	if m.loading {
+		s += " (refreshing)"
+	}
+	return s
+}
+
+// rigLines renders the rigs pane, one line per rig and polecat.
+func (m Model) rigLines() []string {
+	if m.snap == nil {
+		return []string{idleStyle.Render("Loading town state...")}
+	}
+	if len(m.snap.Rigs) == 0 {
+		return []string{idleStyle.Render("No rigs. Add one with: gt rig add <name> <git-url>")}
+	}
+
+	var lines []string
+	for i, r := range m.snap.Rigs {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		header := rigStyle.Render(r.Name)
+		switch {
+		case r.Error != "":
+			header += errorStyle.Render("  beads unavailable")
+		default:
+			blocked := fmt.Sprintf("blocked %d", r.Blocked)
+			if r.Blocked > 0 {
+				blocked = blockedStyle.Render(blocked)
+			}
+			header += fmt.Sprintf("  ready %d  %s", r.Ready, blocked)
+		}
+		lines = append(lines, header)
+
+		if len(r.Polecats) == 0 {
+			lines = append(lines, idleStyle.Render("  no polecats"))
+		}
+		for _, p := range r.Polecats {
+			if p.Hook == "" {
+				lines = append(lines, idleStyle.Render(fmt.Sprintf("  ○ %s  idle", p.Name)))
+				continue
+			}
+			line := fmt.Sprintf("  ● %s  %s", p.Name, hookedStyle.Render(p.Hook))
+			if p.HookTitle != "" {
+				line += " " + p.HookTitle
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// feedLines renders the newest feed events that fit in height lines of
+// width columns.
+func (m Model) feedLines(width, height int) []string {
+	if len(m.feed) == 0 {
+		return []string{idleStyle.Render("Waiting for events...")}
+	}
+	start := len(m.feed) - height
+	if start < 0 {
+		start = 0
+	}
+	var lines []string
+	for _, f := range m.feed[start:] {
+		ts := "--:--:--"
+		if !f.Time.IsZero() {
+			ts = f.Time.Local().Format("15:04:05")
+		}
+		text := truncate(f.Text, width-len(ts)-1)
+		switch f.Severity {
+		case events.SeverityError:
+			text = errorStyle.Render(text)
+		case events.SeverityWarn:
+			text = warnStyle.Render(text)
+		}
+		lines = append(lines, idleStyle.Render(ts)+" "+text)
+	}
+	return lines
+}
+
+// window returns at most height lines starting at offset.
+func window(lines []string, offset, height int) []string {
+	if offset >= len(lines) {
+		offset = len(lines) - 1
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	end := offset + height
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return lines[offset:end]
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if n <= 0 || len(r) <= n {
+		return s
+	}
+	if n == 1 {
+		return "…"
+	}
+	return string(r[:n-1]) + "…"
+}
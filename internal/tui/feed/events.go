@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,6 +12,8 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	gtfeed "github.com/steveyegge/gastown/internal/feed"
 )
 
 // EventSource represents a source of events
@@ -214,9 +215,9 @@ func parseBeadContext(beadID string) (actor, rig, role string) {
 
 // GtEventsSource reads events from ~/gt/.events.jsonl (gt activity log)
 type GtEventsSource struct {
-	file   *os.File
-	events chan Event
-	cancel context.CancelFunc
+	events    chan Event
+	cancel    context.CancelFunc
+	formatter *gtfeed.Formatter
 }
 
 // GtEvent is the structure of events in .events.jsonl
@@ -227,53 +228,47 @@ type GtEvent struct {
 	Actor      string                 `json:"actor"`
 	Payload    map[string]interface{} `json:"payload"`
 	Visibility string                 `json:"visibility"`
+	Severity   string                 `json:"severity"`
 }
 
-// NewGtEventsSource creates a source that tails ~/gt/.events.jsonl
+// NewGtEventsSource creates a source that tails ~/gt/.events.jsonl and any
+// per-rig events logs, rendering messages with the town's feed templates
 func NewGtEventsSource(townRoot string) (*GtEventsSource, error) {
-	eventsPath := filepath.Join(townRoot, ".events.jsonl")
-	file, err := os.Open(eventsPath)
-	if err != nil {
-		return nil, err
+	paths := events.LogPaths(townRoot)
+	if len(paths) == 1 {
+		if _, err := os.Stat(paths[0]); err != nil {
+			return nil, err
+		}
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	formatter, _ := gtfeed.LoadFormatter(townRoot) // Falls back to the default templates
+
 	source := &GtEventsSource{
-		file:   file,
-		events: make(chan Event, 100),
-		cancel: cancel,
+		events:    make(chan Event, 100),
+		cancel:    cancel,
+		formatter: formatter,
 	}
 
-	go source.tail(ctx)
+	go source.tail(events.TailTown(ctx, townRoot))
 
 	return source, nil
 }
 
-// tail follows the file and sends events
-func (s *GtEventsSource) tail(ctx context.Context) {
+// tail converts followed log events to feed events
+func (s *GtEventsSource) tail(in <-chan events.Event) {
 	defer close(s.events)
 
-	// Seek to end for live tailing
-	_, _ = s.file.Seek(0, 2)
-
-	scanner := bufio.NewScanner(s.file)
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			for scanner.Scan() {
-				line := scanner.Text()
-				if event := parseGtEventLine(line); event != nil {
-					select {
-					case s.events <- *event:
-					default:
-					}
-				}
+	for e := range in {
+		line, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		if event := parseGtEventLine(string(line), s.formatter); event != nil {
+			select {
+			case s.events <- *event:
+			default:
 			}
 		}
 	}
@@ -287,11 +282,12 @@ func (s *GtEventsSource) Events() <-chan Event {
 // Close stops the source
 func (s *GtEventsSource) Close() error {
 	s.cancel()
-	return s.file.Close()
+	return nil
 }
 
-// parseGtEventLine parses a line from .events.jsonl
-func parseGtEventLine(line string) *Event {
+// parseGtEventLine parses a line from .events.jsonl, rendering its message
+// with f
+func parseGtEventLine(line string, f *gtfeed.Formatter) *Event {
 	if strings.TrimSpace(line) == "" {
 		return nil
 	}
@@ -352,137 +348,19 @@ func parseGtEventLine(line string) *Event {
 		}
 	}
 
-	// Build message from event type and payload
-	message := buildEventMessage(ge.Type, ge.Payload)
+	e := events.Event{Type: ge.Type, Actor: ge.Actor, Payload: ge.Payload, Severity: ge.Severity}
 
 	return &Event{
-		Time:    t,
-		Type:    ge.Type,
-		Actor:   ge.Actor,
-		Target:  getPayloadString(ge.Payload, "bead"),
-		Message: message,
-		Rig:     rig,
-		Role:    role,
-		Raw:     line,
-	}
-}
-
-// buildEventMessage creates a human-readable message from event type and payload
-func buildEventMessage(eventType string, payload map[string]interface{}) string {
-	switch eventType {
-	case "patrol_started":
-		count := getPayloadInt(payload, "polecat_count")
-		if msg := getPayloadString(payload, "message"); msg != "" {
-			return msg
-		}
-		if count > 0 {
-			return fmt.Sprintf("patrol started (%d polecats)", count)
-		}
-		return "patrol started"
-
-	case "patrol_complete":
-		count := getPayloadInt(payload, "polecat_count")
-		if msg := getPayloadString(payload, "message"); msg != "" {
-			return msg
-		}
-		if count > 0 {
-			return fmt.Sprintf("patrol complete (%d polecats)", count)
-		}
-		return "patrol complete"
-
-	case "polecat_checked":
-		polecat := getPayloadString(payload, "polecat")
-		status := getPayloadString(payload, "status")
-		if polecat != "" {
-			if status != "" {
-				return fmt.Sprintf("checked %s (%s)", polecat, status)
-			}
-			return fmt.Sprintf("checked %s", polecat)
-		}
-		return "polecat checked"
-
-	case "polecat_nudged":
-		polecat := getPayloadString(payload, "polecat")
-		reason := getPayloadString(payload, "reason")
-		if polecat != "" {
-			if reason != "" {
-				return fmt.Sprintf("nudged %s: %s", polecat, reason)
-			}
-			return fmt.Sprintf("nudged %s", polecat)
-		}
-		return "polecat nudged"
-
-	case "escalation_sent":
-		target := getPayloadString(payload, "target")
-		to := getPayloadString(payload, "to")
-		reason := getPayloadString(payload, "reason")
-		if target != "" && to != "" {
-			if reason != "" {
-				return fmt.Sprintf("escalated %s to %s: %s", target, to, reason)
-			}
-			return fmt.Sprintf("escalated %s to %s", target, to)
-		}
-		return "escalation sent"
-
-	case "sling":
-		bead := getPayloadString(payload, "bead")
-		target := getPayloadString(payload, "target")
-		if bead != "" && target != "" {
-			return fmt.Sprintf("slung %s to %s", bead, target)
-		}
-		return "work slung"
-
-	case "hook":
-		bead := getPayloadString(payload, "bead")
-		if bead != "" {
-			return fmt.Sprintf("hooked %s", bead)
-		}
-		return "bead hooked"
-
-	case "handoff":
-		subject := getPayloadString(payload, "subject")
-		if subject != "" {
-			return fmt.Sprintf("handoff: %s", subject)
-		}
-		return "session handoff"
-
-	case "done":
-		bead := getPayloadString(payload, "bead")
-		if bead != "" {
-			return fmt.Sprintf("done: %s", bead)
-		}
-		return "work done"
-
-	case "mail":
-		subject := getPayloadString(payload, "subject")
-		to := getPayloadString(payload, "to")
-		if subject != "" {
-			if to != "" {
-				return fmt.Sprintf("→ %s: %s", to, subject)
-			}
-			return subject
-		}
-		return "mail sent"
-
-	case "merged":
-		worker := getPayloadString(payload, "worker")
-		if worker != "" {
-			return fmt.Sprintf("merged work from %s", worker)
-		}
-		return "merged"
-
-	case "merge_failed":
-		reason := getPayloadString(payload, "reason")
-		if reason != "" {
-			return fmt.Sprintf("merge failed: %s", reason)
-		}
-		return "merge failed"
-
-	default:
-		if msg := getPayloadString(payload, "message"); msg != "" {
-			return msg
-		}
-		return eventType
+		Time:      t,
+		Type:      ge.Type,
+		Actor:     ge.Actor,
+		Target:    getPayloadString(ge.Payload, "bead"),
+		Message:   f.Format(e),
+		Formatted: true,
+		Rig:       rig,
+		Role:      role,
+		Severity:  e.Level(),
+		Raw:       line,
 	}
 }
 
@@ -497,17 +375,6 @@ func getPayloadString(payload map[string]interface{}, key string) string {
 	return ""
 }
 
-// getPayloadInt extracts an int from payload
-func getPayloadInt(payload map[string]interface{}, key string) int {
-	if payload == nil {
-		return 0
-	}
-	if v, ok := payload[key].(float64); ok {
-		return int(v)
-	}
-	return 0
-}
-
 // CombinedSource merges events from multiple sources
 type CombinedSource struct {
 	sources []EventSource
@@ -22,14 +22,16 @@ const (
 
 // Event represents an activity event
 type Event struct {
-	Time     time.Time
-	Type     string // create, update, complete, fail, delete
-	Actor    string // who did it (e.g., "gastown/crew/joe")
-	Target   string // what was affected (e.g., "gt-xyz")
-	Message  string // human-readable description
-	Rig      string // which rig
-	Role     string // actor's role
-	Raw      string // raw line for fallback display
+	Time      time.Time
+	Type      string // create, update, complete, fail, delete
+	Actor     string // who did it (e.g., "gastown/crew/joe")
+	Target    string // what was affected (e.g., "gt-xyz")
+	Message   string // human-readable description
+	Formatted bool   // Message is a full line that names the actor itself
+	Rig       string // which rig
+	Role      string // actor's role
+	Severity  string // debug, info, warn, or error; empty for non-gt sources
+	Raw       string // raw line for fallback display
 }
 
 // Agent represents an agent in the tree
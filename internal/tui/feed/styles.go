@@ -143,6 +143,10 @@ var (
 		"merged":        "✓",
 		"merge_failed":  "✗",
 		"merge_skipped": "⊘",
+		// Failures
+		"sling_failed":   "✗",
+		"merge_conflict": "⚔",
+		"agent_crash":    "💥",
 		// General gt events
 		"sling":   "🎯",
 		"hook":    "🪝",
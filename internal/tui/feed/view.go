@@ -255,7 +255,7 @@ func (m *Model) renderEvent(e Event) string {
 		symbolStyle = EventUpdateStyle
 	case "complete", "patrol_complete", "merged", "done":
 		symbolStyle = EventCompleteStyle
-	case "fail", "merge_failed":
+	case "fail", "merge_failed", "sling_failed", "merge_conflict", "agent_crash":
 		symbolStyle = EventFailStyle
 	case "delete":
 		symbolStyle = EventDeleteStyle
@@ -277,9 +277,14 @@ func (m *Model) renderEvent(e Event) string {
 
 	styledSymbol := symbolStyle.Render(symbol)
 
-	// Actor (short form)
+	// Actor (short form); formatted messages name the actor themselves, so
+	// only the role icon is shown
 	actor := ""
-	if e.Actor != "" {
+	if e.Formatted {
+		if icon := RoleIcons[e.Role]; icon != "" {
+			actor = RoleStyle.Render(icon) + " "
+		}
+	} else if e.Actor != "" {
 		parts := strings.Split(e.Actor, "/")
 		if len(parts) > 0 {
 			actor = parts[len(parts)-1]
@@ -295,6 +300,9 @@ func (m *Model) renderEvent(e Event) string {
 	if msg == "" && e.Raw != "" {
 		msg = e.Raw
 	}
+	if e.Severity == "error" {
+		msg = EventFailStyle.Render(msg) // Errors stand out from the chatter
+	}
 
 	return fmt.Sprintf("%s %s %s%s", ts, styledSymbol, actor, msg)
 }
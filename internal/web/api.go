@@ -0,0 +1,357 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/townstate"
+)
+
+// maxRequestBody caps the size of a JSON request body.
+const maxRequestBody = 1 << 20
+
+// APIHandler serves the REST API behind gt serve:
+//
+//	GET   /api/issues        list issues (status, type, assignee, label, parent, priority, limit)
+//	POST  /api/issues        create an issue
+//	GET   /api/issues/{id}   show an issue
+//	PATCH /api/issues/{id}   update an issue
+//	GET   /api/events        query the events log (the gt events query filters, plus limit)
+//	GET   /api/status        rigs, polecats, hooks, and queue depths
+//
+// Issue writes are validated by beads, as they are for the CLI, and an
+// invalid request gets 400 with the reason. Writes must be sent as
+// application/json, which a browser won't do cross-origin without a CORS
+// preflight this API never grants.
+//
+// Issues are served at a visibility scope, public unless set otherwise
+// (see SetVisibilityScope); hidden issues are not found. Issues are
+// created by the server's actor (BD_ACTOR), never one named by the caller.
+type APIHandler struct {
+	townRoot string
+	beads    beads.Client
+	scope    string
+	mux      *http.ServeMux
+}
+
+// NewAPIHandler creates the API for a town. client is usually the town's
+// router (beads.NewTownRouter), so IDs reach the rig that owns them.
+func NewAPIHandler(townRoot string, client beads.Client) *APIHandler {
+	h := &APIHandler{townRoot: townRoot, beads: client, scope: beads.VisibilityPublic, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /api/issues", h.listIssues)
+	h.mux.HandleFunc("POST /api/issues", h.createIssue)
+	h.mux.HandleFunc("GET /api/issues/{id}", h.showIssue)
+	h.mux.HandleFunc("PATCH /api/issues/{id}", h.updateIssue)
+	h.mux.HandleFunc("GET /api/events", h.queryEvents)
+	h.mux.HandleFunc("GET /api/status", h.status)
+	return h
+}
+
+// SetVisibilityScope sets the scope issues are served at. An empty scope
+// serves every issue.
+func (h *APIHandler) SetVisibilityScope(scope string) error {
+	if scope != "" && !beads.ValidVisibility(scope) {
+		return fmt.Errorf("unknown visibility scope %q", scope)
+	}
+	h.scope = scope
+	return nil
+}
+
+// ServeHTTP routes API requests.
+func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// RestrictHost only passes on requests addressed to listenHost or to
+// localhost, so a page on another site can't reach the server through a
+// DNS name it rebinds to this machine. Others get 403.
+func RestrictHost(listenHost string, next http.Handler) http.Handler {
+	allowed := map[string]bool{"localhost": true, "127.0.0.1": true, "::1": true}
+	allowed[strings.ToLower(strings.Trim(listenHost, "[]"))] = true
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if !allowed[strings.ToLower(strings.Trim(host, "[]"))] {
+			writeError(w, http.StatusForbidden, fmt.Errorf("host %q not allowed", r.Host))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// createIssueRequest is the body of POST /api/issues.
+type createIssueRequest struct {
+	Title       string   `json:"title"`
+	Type        string   `json:"type,omitempty"`
+	Priority    *int     `json:"priority,omitempty"` // Omitted leaves it to bd
+	Description string   `json:"description,omitempty"`
+	Parent      string   `json:"parent,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// updateIssueRequest is the body of PATCH /api/issues/{id}. Omitted
+// fields are left unchanged.
+type updateIssueRequest struct {
+	Title        *string  `json:"title,omitempty"`
+	Status       *string  `json:"status,omitempty"`
+	Priority     *int     `json:"priority,omitempty"`
+	Description  *string  `json:"description,omitempty"`
+	Assignee     *string  `json:"assignee,omitempty"`
+	AddLabels    []string `json:"add_labels,omitempty"`
+	RemoveLabels []string `json:"remove_labels,omitempty"`
+	SetLabels    []string `json:"set_labels,omitempty"`
+}
+
+func (h *APIHandler) listIssues(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts := beads.ListOptions{
+		Status:   q.Get("status"),
+		Type:     q.Get("type"),
+		Assignee: q.Get("assignee"),
+		Parent:   q.Get("parent"),
+		Labels:   q["label"],
+	}
+	var err error
+	if opts.Priority, err = intParam(q.Get("priority"), -1); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid priority: %w", err))
+		return
+	}
+	limit, err := intParam(q.Get("limit"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit: %w", err))
+		return
+	}
+	if opts.Parent != "" {
+		if err := beads.ValidateID(opts.Parent); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	// The limit applies after hidden issues are dropped, so bd is asked
+	// for every match
+	opts.Limit = beads.NoLimit
+	issues, err := h.beads.List(opts)
+	if err != nil {
+		writeBeadsError(w, err)
+		return
+	}
+	issues = beads.FilterVisible(issues, h.scope)
+	if limit > 0 && len(issues) > limit {
+		issues = issues[:limit]
+	}
+	if issues == nil {
+		issues = []*beads.Issue{}
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (h *APIHandler) showIssue(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := beads.ValidateID(id); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	issue, err := h.show(id)
+	if err != nil {
+		writeBeadsError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, issue)
+}
+
+// show is Show at the handler's scope: an issue it hides is not found.
+func (h *APIHandler) show(id string) (*beads.Issue, error) {
+	issue, err := h.beads.Show(id)
+	if err != nil {
+		return nil, err
+	}
+	if !beads.VisibleTo(issue, h.scope) {
+		return nil, fmt.Errorf("%s: %w", id, beads.ErrNotFound)
+	}
+	return issue, nil
+}
+
+func (h *APIHandler) createIssue(w http.ResponseWriter, r *http.Request) {
+	var req createIssueRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	opts := beads.CreateOptions{
+		Title:       req.Title,
+		Type:        req.Type,
+		Priority:    -1,
+		Description: req.Description,
+		Parent:      req.Parent,
+		Assignee:    req.Assignee,
+		Labels:      req.Labels,
+	}
+	if req.Priority != nil {
+		opts.Priority = *req.Priority
+	}
+	if err := opts.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	issue, err := h.beads.Create(opts)
+	if err != nil {
+		writeBeadsError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, issue)
+}
+
+func (h *APIHandler) updateIssue(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := beads.ValidateID(id); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req updateIssueRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	opts := beads.UpdateOptions{
+		Title:        req.Title,
+		Status:       req.Status,
+		Priority:     req.Priority,
+		Description:  req.Description,
+		Assignee:     req.Assignee,
+		AddLabels:    req.AddLabels,
+		RemoveLabels: req.RemoveLabels,
+		SetLabels:    req.SetLabels,
+	}
+	if err := opts.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Issues the scope hides can't be changed either
+	if _, err := h.show(id); err != nil {
+		writeBeadsError(w, err)
+		return
+	}
+	if err := h.beads.Update(id, opts); err != nil {
+		writeBeadsError(w, err)
+		return
+	}
+	issue, err := h.show(id)
+	if err != nil {
+		writeBeadsError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, issue)
+}
+
+func (h *APIHandler) queryEvents(w http.ResponseWriter, r *http.Request) {
+	p := r.URL.Query()
+	q, err := events.QueryArgs{
+		Since:      p.Get("since"),
+		From:       p.Get("from"),
+		Until:      p.Get("until"),
+		Types:      p["type"],
+		Actor:      p.Get("actor"),
+		Visibility: p["visibility"],
+		Payload:    p["payload"],
+		Severity:   p.Get("severity"),
+		Trace:      p.Get("trace"),
+	}.Query(time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit, err := intParam(p.Get("limit"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit: %w", err))
+		return
+	}
+
+	evts, err := q.Run(h.townRoot)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("reading events: %w", err))
+		return
+	}
+	// Oldest first; a limit keeps the newest
+	if limit > 0 && len(evts) > limit {
+		evts = evts[len(evts)-limit:]
+	}
+	if evts == nil {
+		evts = []events.Event{}
+	}
+	writeJSON(w, http.StatusOK, evts)
+}
+
+func (h *APIHandler) status(w http.ResponseWriter, r *http.Request) {
+	snap, err := townstate.Load(h.townRoot, h.scope)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, snap)
+}
+
+// intParam parses an optional non-negative integer parameter.
+func intParam(s string, def int) (int, error) {
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a non-negative integer", s)
+	}
+	return n, nil
+}
+
+// readJSON decodes a JSON request body into v, rejecting other content
+// types and unknown fields. On failure it writes the error and returns
+// false.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, errors.New("request body must be application/json"))
+		return false
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+// writeBeadsError maps a beads error to a status: 404 for a missing
+// issue, 400 for input bd would reject, else 500.
+func writeBeadsError(w http.ResponseWriter, err error) {
+	var ve *beads.ValidationError
+	switch {
+	case errors.Is(err, beads.ErrNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.As(err, &ve), errors.Is(err, beads.ErrInvalidID), errors.Is(err, beads.ErrUnsafeArg):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
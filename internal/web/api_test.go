@@ -0,0 +1,200 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/townstate"
+)
+
+// apiRequest sends a request to h and decodes the JSON response into out.
+func apiRequest(t *testing.T, h http.Handler, method, target, body string, out interface{}) int {
+	t.Helper()
+	var req *http.Request
+	if body == "" {
+		req = httptest.NewRequest(method, target, nil)
+	} else {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: Content-Type = %q", method, target, ct)
+	}
+	if out != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, target, w.Body.String(), err)
+		}
+	}
+	return w.Code
+}
+
+func TestAPIHandler_Issues(t *testing.T) {
+	client := beadstest.NewFakeClient()
+	client.Add(&beads.Issue{ID: "gt-seed", Title: "Seeded", Status: "open", Type: "bug"})
+	h := NewAPIHandler(t.TempDir(), client)
+
+	var created beads.Issue
+	if code := apiRequest(t, h, "POST", "/api/issues", `{"title":"Fix login","type":"bug","priority":1,"labels":["auth"]}`, &created); code != http.StatusCreated {
+		t.Fatalf("create: status %d", code)
+	}
+	if created.ID == "" || created.Priority != 1 || created.Type != "bug" {
+		t.Errorf("created = %+v", created)
+	}
+
+	var shown beads.Issue
+	if code := apiRequest(t, h, "GET", "/api/issues/"+created.ID, "", &shown); code != http.StatusOK || shown.Title != "Fix login" {
+		t.Errorf("show: status %d, issue %+v", code, shown)
+	}
+
+	var updated beads.Issue
+	if code := apiRequest(t, h, "PATCH", "/api/issues/"+created.ID, `{"status":"in_progress","assignee":"gastown/nux"}`, &updated); code != http.StatusOK {
+		t.Fatalf("update: status %d", code)
+	}
+	if updated.Status != "in_progress" || updated.Assignee != "gastown/nux" {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	var listed []beads.Issue
+	if code := apiRequest(t, h, "GET", "/api/issues?assignee=gastown/nux", "", &listed); code != http.StatusOK || len(listed) != 1 {
+		t.Errorf("list: status %d, %d issues", code, len(listed))
+	}
+}
+
+func TestAPIHandler_Errors(t *testing.T) {
+	h := NewAPIHandler(t.TempDir(), beadstest.NewFakeClient())
+
+	tests := []struct {
+		name, method, target, body string
+		want                       int
+	}{
+		{"missing title", "POST", "/api/issues", `{"type":"bug"}`, http.StatusBadRequest},
+		{"bad priority", "POST", "/api/issues", `{"title":"x","priority":9}`, http.StatusBadRequest},
+		{"unknown type", "POST", "/api/issues", `{"title":"x","type":"saga"}`, http.StatusBadRequest},
+		{"unknown field", "POST", "/api/issues", `{"title":"x","owner":"me"}`, http.StatusBadRequest},
+		{"bad status", "PATCH", "/api/issues/gt-1", `{"status":"done"}`, http.StatusBadRequest},
+		{"bad id", "GET", "/api/issues/not_an_id", "", http.StatusBadRequest},
+		{"missing issue", "GET", "/api/issues/gt-404", "", http.StatusNotFound},
+		{"bad limit", "GET", "/api/issues?limit=-1", "", http.StatusBadRequest},
+		{"bad severity", "GET", "/api/events?severity=fatal", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		var body map[string]string
+		if code := apiRequest(t, h, tt.method, tt.target, tt.body, &body); code != tt.want {
+			t.Errorf("%s: status %d, want %d (%v)", tt.name, code, tt.want, body)
+		}
+		if body["error"] == "" {
+			t.Errorf("%s: no error message", tt.name)
+		}
+	}
+
+	// Writes must be JSON
+	req := httptest.NewRequest("POST", "/api/issues", strings.NewReader(`{"title":"x"}`))
+	req.Header.Set("Content-Type", "text/plain")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("text/plain create: status %d, want 415", w.Code)
+	}
+}
+
+func TestAPIHandler_VisibilityScope(t *testing.T) {
+	client := beadstest.NewFakeClient()
+	client.Add(&beads.Issue{ID: "gt-int", Title: "Internal", Status: "open", Labels: []string{beads.VisibilityLabel(beads.VisibilityInternal)}})
+	client.Add(&beads.Issue{ID: "gt-prv", Title: "Private", Status: "open", Labels: []string{beads.VisibilityLabel(beads.VisibilityPrivate)}})
+	client.Add(&beads.Issue{ID: "gt-pub", Title: "Public", Status: "open"})
+	h := NewAPIHandler(t.TempDir(), client)
+
+	// Public by default
+	var listed []beads.Issue
+	if code := apiRequest(t, h, "GET", "/api/issues", "", &listed); code != http.StatusOK || len(listed) != 1 || listed[0].ID != "gt-pub" {
+		t.Errorf("list: status %d, issues %+v", code, listed)
+	}
+	// The limit counts visible issues, not the hidden ones listed first
+	if code := apiRequest(t, h, "GET", "/api/issues?limit=1", "", &listed); code != http.StatusOK || len(listed) != 1 || listed[0].ID != "gt-pub" {
+		t.Errorf("limited list: status %d, issues %+v", code, listed)
+	}
+	for _, id := range []string{"gt-int", "gt-prv"} {
+		if code := apiRequest(t, h, "GET", "/api/issues/"+id, "", nil); code != http.StatusNotFound {
+			t.Errorf("show %s: status %d, want 404", id, code)
+		}
+		if code := apiRequest(t, h, "PATCH", "/api/issues/"+id, `{"status":"closed"}`, nil); code != http.StatusNotFound {
+			t.Errorf("update %s: status %d, want 404", id, code)
+		}
+	}
+	if issue, _ := client.Show("gt-int"); issue.Status != "open" {
+		t.Errorf("hidden issue updated: %+v", issue)
+	}
+
+	if err := h.SetVisibilityScope(beads.VisibilityInternal); err != nil {
+		t.Fatal(err)
+	}
+	if code := apiRequest(t, h, "GET", "/api/issues", "", &listed); code != http.StatusOK || len(listed) != 2 {
+		t.Errorf("internal list: status %d, issues %+v", code, listed)
+	}
+	if err := h.SetVisibilityScope("secret"); err == nil {
+		t.Error("unknown scope accepted")
+	}
+
+	// Callers can't name the actor
+	if code := apiRequest(t, h, "POST", "/api/issues", `{"title":"x","actor":"mayor"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("create with actor: status %d, want 400", code)
+	}
+}
+
+func TestRestrictHost(t *testing.T) {
+	h := RestrictHost("10.0.0.5", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{})
+	}))
+	for host, want := range map[string]int{
+		"localhost:8080":    http.StatusOK,
+		"127.0.0.1:8080":    http.StatusOK,
+		"[::1]:8080":        http.StatusOK,
+		"10.0.0.5:8080":     http.StatusOK,
+		"LOCALHOST":         http.StatusOK,
+		"evil.example:8080": http.StatusForbidden,
+		"10.0.0.5.nip.io":   http.StatusForbidden,
+	} {
+		req := httptest.NewRequest("GET", "/api/status", nil)
+		req.Host = host
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("Host %s: status %d, want %d", host, w.Code, want)
+		}
+	}
+}
+
+func TestAPIHandler_EventsAndStatus(t *testing.T) {
+	townRoot := t.TempDir()
+	log := strings.Join([]string{
+		`{"ts":"2026-01-01T10:00:00Z","source":"gt","type":"sling","actor":"mayor","payload":{"bead":"gt-1"},"visibility":"feed"}`,
+		`{"ts":"2026-01-01T10:01:00Z","source":"gt","type":"hook","actor":"gastown/polecats/nux","payload":{"bead":"gt-1"},"visibility":"feed"}`,
+		`{"ts":"2026-01-01T10:02:00Z","source":"gt","type":"sling","actor":"mayor","payload":{"bead":"gt-2"},"visibility":"feed"}`,
+	}, "\n") + "\n"
+	if err := os.WriteFile(events.Path(townRoot), []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+	h := NewAPIHandler(townRoot, beadstest.NewFakeClient())
+
+	var evts []events.Event
+	if code := apiRequest(t, h, "GET", "/api/events?type=sling&limit=1", "", &evts); code != http.StatusOK {
+		t.Fatalf("events: status %d", code)
+	}
+	if len(evts) != 1 || evts[0].Payload["bead"] != "gt-2" {
+		t.Errorf("events = %+v, want the newest sling", evts)
+	}
+
+	var snap townstate.Snapshot
+	if code := apiRequest(t, h, "GET", "/api/status", "", &snap); code != http.StatusOK || snap.Rigs == nil {
+		t.Errorf("status: status %d, snapshot %+v", code, snap)
+	}
+}
@@ -10,12 +10,17 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/activity"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/feed"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // LiveConvoyFetcher fetches convoy data from beads.
 type LiveConvoyFetcher struct {
+	townRoot  string
 	townBeads string
+	beads     *beads.Beads // Scoped to public beads: the dashboard is a public view
 }
 
 // NewLiveConvoyFetcher creates a fetcher for the current workspace.
@@ -25,36 +30,27 @@ func NewLiveConvoyFetcher() (*LiveConvoyFetcher, error) {
 		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
+	bd := beads.New(townRoot)
+	if err := bd.SetVisibilityScope(beads.VisibilityPublic); err != nil {
+		return nil, err
+	}
+
 	return &LiveConvoyFetcher{
+		townRoot:  townRoot,
 		townBeads: filepath.Join(townRoot, ".beads"),
+		beads:     bd,
 	}, nil
 }
 
-
 // FetchConvoys fetches all open convoys with their activity data.
 func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
-	// List all open convoy-type issues
-	listArgs := []string{"list", "--type=convoy", "--status=open", "--json"}
-	listCmd := exec.Command("bd", listArgs...)
-	listCmd.Dir = f.townBeads
-
-	var stdout bytes.Buffer
-	listCmd.Stdout = &stdout
-
-	if err := listCmd.Run(); err != nil {
+	// List all open convoy-type issues; the scope leaves out internal
+	// and private convoys
+	convoys, err := f.beads.List(beads.ListOptions{Type: "convoy", Status: "open", Priority: -1, Limit: beads.NoLimit})
+	if err != nil {
 		return nil, fmt.Errorf("listing convoys: %w", err)
 	}
 
-	var convoys []struct {
-		ID        string `json:"id"`
-		Title     string `json:"title"`
-		Status    string `json:"status"`
-		CreatedAt string `json:"created_at"`
-	}
-	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
-		return nil, fmt.Errorf("parsing convoy list: %w", err)
-	}
-
 	// Build convoy rows with activity data
 	rows := make([]ConvoyRow, 0, len(convoys))
 	for _, c := range convoys {
@@ -176,6 +172,7 @@ func (f *LiveConvoyFetcher) getTrackedIssues(convoyID string) []trackedIssueInfo
 
 	// Collect issue IDs (normalize external refs)
 	issueIDs := make([]string, 0, len(deps))
+	external := make(map[string]bool)
 	for _, dep := range deps {
 		issueID := dep.DependsOnID
 		if strings.HasPrefix(issueID, "external:") {
@@ -183,6 +180,7 @@ func (f *LiveConvoyFetcher) getTrackedIssues(convoyID string) []trackedIssueInfo
 			if len(parts) == 3 {
 				issueID = parts[2]
 			}
+			external[issueID] = true
 		}
 		issueIDs = append(issueIDs, issueID)
 	}
@@ -203,9 +201,11 @@ func (f *LiveConvoyFetcher) getTrackedIssues(convoyID string) []trackedIssueInfo
 			info.Status = d.Status
 			info.Assignee = d.Assignee
 			info.UpdatedAt = d.UpdatedAt
-		} else {
+		} else if external[id] {
 			info.Title = "(external)"
 			info.Status = "unknown"
+		} else {
+			continue // Hidden by the visibility scope, or gone
 		}
 
 		if w, ok := workers[id]; ok && w.LastActivity != nil {
@@ -227,48 +227,22 @@ type issueDetail struct {
 	UpdatedAt time.Time
 }
 
-// getIssueDetailsBatch fetches details for multiple issues.
+// getIssueDetailsBatch fetches details for multiple issues. Internal and
+// private issues are left out, like issues that don't exist.
 func (f *LiveConvoyFetcher) getIssueDetailsBatch(issueIDs []string) map[string]*issueDetail {
 	result := make(map[string]*issueDetail)
-	if len(issueIDs) == 0 {
-		return result
-	}
-
-	args := append([]string{"show"}, issueIDs...)
-	args = append(args, "--json")
-
-	// #nosec G204 -- bd is a trusted internal tool, args are issue IDs
-	showCmd := exec.Command("bd", args...)
-	var stdout bytes.Buffer
-	showCmd.Stdout = &stdout
-
-	if err := showCmd.Run(); err != nil {
-		return result
-	}
-
-	var issues []struct {
-		ID        string `json:"id"`
-		Title     string `json:"title"`
-		Status    string `json:"status"`
-		Assignee  string `json:"assignee"`
-		UpdatedAt string `json:"updated_at"`
-	}
-	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
+	issues, err := f.beads.ShowMultiple(issueIDs)
+	if err != nil {
 		return result
 	}
 
 	for _, issue := range issues {
 		detail := &issueDetail{
-			ID:       issue.ID,
-			Title:    issue.Title,
-			Status:   issue.Status,
-			Assignee: issue.Assignee,
-		}
-		// Parse updated_at timestamp
-		if issue.UpdatedAt != "" {
-			if t, err := time.Parse(time.RFC3339, issue.UpdatedAt); err == nil {
-				detail.UpdatedAt = t
-			}
+			ID:        issue.ID,
+			Title:     issue.Title,
+			Status:    issue.Status,
+			Assignee:  issue.Assignee,
+			UpdatedAt: issue.UpdatedAt,
 		}
 		result[issue.ID] = detail
 	}
@@ -691,6 +665,66 @@ func (f *LiveConvoyFetcher) getPolecatStatusHint(sessionName string) string {
 	return ""
 }
 
+// activityWindow and activityLimit bound the dashboard's recent activity.
+const (
+	activityWindow = 24 * time.Hour
+	activityLimit  = 20
+)
+
+// FetchActivity returns the most recent feed-visible town events, newest
+// first, rendered with the town's feed templates. Events about beads the
+// public scope hides are left out, as for chat notifications.
+func (f *LiveConvoyFetcher) FetchActivity() ([]ActivityRow, error) {
+	evts, err := events.ReadTownSince(f.townRoot, time.Now().Add(-activityWindow), events.Filter{FeedOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	formatter, _ := feed.LoadFormatter(f.townRoot) // Falls back to the default templates
+	rows := make([]ActivityRow, 0, activityLimit)
+	public := publicBeads(f.beads)
+	for i := len(evts) - 1; i >= 0 && len(rows) < activityLimit; i-- {
+		e := evts[i]
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil || !public(e) {
+			continue
+		}
+		rows = append(rows, ActivityRow{
+			Age:      activity.Calculate(ts),
+			Type:     e.Type,
+			Summary:  formatter.Format(e),
+			Severity: e.Level(),
+		})
+	}
+	return rows, nil
+}
+
+// publicBeads returns a check that every bead an event refers to can be
+// shown through bd, which should be scoped to public beads. A bead that
+// can't be looked up counts as hidden. Lookups are cached, since recent
+// events tend to share beads.
+func publicBeads(bd beads.Client) func(events.Event) bool {
+	shown := make(map[string]bool)
+	return func(e events.Event) bool {
+		for _, key := range []string{"bead", "epic"} {
+			id, _ := e.Payload[key].(string)
+			if id == "" {
+				continue
+			}
+			ok, seen := shown[id]
+			if !seen {
+				_, err := bd.Show(id)
+				ok = err == nil
+				shown[id] = ok
+			}
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 // getMergeQueueCount returns the total number of open PRs across all repos.
 func (f *LiveConvoyFetcher) getMergeQueueCount() int {
 	mergeQueue, err := f.FetchMergeQueue()
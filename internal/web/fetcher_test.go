@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/steveyegge/gastown/internal/activity"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beads/beadstest"
+	"github.com/steveyegge/gastown/internal/events"
 )
 
 func TestCalculateWorkStatus(t *testing.T) {
@@ -415,3 +418,31 @@ func TestParseActivityTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestPublicBeads(t *testing.T) {
+	bd := beadstest.NewFakeClient()
+	bd.Scope = beads.VisibilityPublic
+	pub, _ := bd.Create(beads.CreateOptions{Title: "public"})
+	priv, _ := bd.Create(beads.CreateOptions{Title: "private", Visibility: beads.VisibilityPrivate})
+	epic, _ := bd.Create(beads.CreateOptions{Title: "internal epic", Visibility: beads.VisibilityInternal})
+
+	public := publicBeads(bd)
+	tests := []struct {
+		name    string
+		payload map[string]interface{}
+		want    bool
+	}{
+		{"no bead", map[string]interface{}{"rig": "gastown"}, true},
+		{"public bead", map[string]interface{}{"bead": pub.ID}, true},
+		{"private bead", map[string]interface{}{"bead": priv.ID}, false},
+		{"internal epic", map[string]interface{}{"bead": pub.ID, "epic": epic.ID}, false},
+		{"unknown bead", map[string]interface{}{"bead": "gt-missing"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := public(events.Event{Type: events.TypeDone, Payload: tt.payload}); got != tt.want {
+				t.Errorf("public = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
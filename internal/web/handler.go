@@ -12,6 +12,12 @@ type ConvoyFetcher interface {
 	FetchPolecats() ([]PolecatRow, error)
 }
 
+// ActivityFetcher is implemented by fetchers that can also list recent
+// town activity for the dashboard.
+type ActivityFetcher interface {
+	FetchActivity() ([]ActivityRow, error)
+}
+
 // ConvoyHandler handles HTTP requests for the convoy dashboard.
 type ConvoyHandler struct {
 	fetcher  ConvoyFetcher
@@ -51,10 +57,17 @@ func (h *ConvoyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		polecats = nil
 	}
 
+	var recent []ActivityRow
+	if af, ok := h.fetcher.(ActivityFetcher); ok {
+		// Non-fatal: show convoys even if activity fails
+		recent, _ = af.FetchActivity()
+	}
+
 	data := ConvoyData{
 		Convoys:    convoys,
 		MergeQueue: mergeQueue,
 		Polecats:   polecats,
+		Activity:   recent,
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -995,3 +995,42 @@ func TestConvoyHandler_NonFatalErrors(t *testing.T) {
 		t.Error("Response should contain convoy data even when other fetches fail")
 	}
 }
+
+// activityFetcher is a MockConvoyFetcher that also lists recent activity.
+type activityFetcher struct {
+	MockConvoyFetcher
+	Activity []ActivityRow
+}
+
+func (m *activityFetcher) FetchActivity() ([]ActivityRow, error) {
+	return m.Activity, nil
+}
+
+func TestConvoyHandler_RendersActivity(t *testing.T) {
+	mock := &activityFetcher{
+		Activity: []ActivityRow{
+			{
+				Age:      activity.Calculate(time.Now().Add(-2 * time.Minute)),
+				Type:     "merge_conflict",
+				Summary:  "Merge conflict on polecat/nux",
+				Severity: "error",
+			},
+		},
+	}
+
+	handler, err := NewConvoyHandler(mock)
+	if err != nil {
+		t.Fatalf("NewConvoyHandler() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	body := w.Body.String()
+	if !strings.Contains(body, "Recent Activity") {
+		t.Error("Response should contain the activity section")
+	}
+	if !strings.Contains(body, `<td class="event-error">Merge conflict on polecat/nux</td>`) {
+		t.Error("Response should contain the event summary styled by severity")
+	}
+}
@@ -17,6 +17,15 @@ type ConvoyData struct {
 	Convoys    []ConvoyRow
 	MergeQueue []MergeQueueRow
 	Polecats   []PolecatRow
+	Activity   []ActivityRow
+}
+
+// ActivityRow represents a recent town event in the dashboard.
+type ActivityRow struct {
+	Age      activity.Info // When it happened
+	Type     string        // Event type, e.g. "sling"
+	Summary  string        // One-line rendering from the feed templates
+	Severity string        // debug, info, warn, or error
 }
 
 // PolecatRow represents a polecat worker in the dashboard.